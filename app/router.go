@@ -2,11 +2,15 @@ package app
 
 import (
 	"ekak_kabupaten_madiun/controller"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/middleware"
 	"net/http"
+	"time"
 
 	_ "ekak_kabupaten_madiun/docs"
 
 	"github.com/julienschmidt/httprouter"
+	"github.com/redis/go-redis/v9"
 	httpSwagger "github.com/swaggo/http-swagger"
 )
 
@@ -59,9 +63,29 @@ func NewRouter(
 	programUnggulanController controller.ProgramUnggulanController,
 	matrixRenjaController controller.MatrixRenjaController,
 	pkController controller.PkController,
+	lockDataController controller.LockDataController,
+	auditLogController controller.AuditLogController,
+	realisasiController controller.RealisasiController,
+	progresPelaksanaanController controller.ProgresPelaksanaanController,
+	laporanController controller.LaporanController,
+	pokinWorkflowController controller.PokinWorkflowController,
+	rencanaKinerjaVerifikasiController controller.RencanaKinerjaVerifikasiController,
+	cloneJobController controller.CloneJobController,
+	usulanPipelineController controller.UsulanPipelineController,
+	usulanPublikController controller.UsulanPublikController,
+	geospasialController controller.GeospasialController,
+	nomenklaturController controller.NomenklaturController,
+	redisClient *redis.Client,
 ) *httprouter.Router {
 	router := httprouter.New()
 
+	// role policy per route
+	superAdmin := middleware.RequireRoles(helper.RoleSuperAdmin)
+	admin := middleware.RequireRoles(helper.RoleSuperAdmin, helper.RoleAdminOpd)
+	adminOrReviewer := middleware.RequireRoles(helper.RoleSuperAdmin, helper.RoleAdminOpd, helper.RoleReviewer)
+	// route publik tanpa login dibatasi per IP
+	publik := middleware.RateLimit(helper.NewRateLimiter(redisClient, "publik", 60, time.Minute))
+
 	router.GET("/swagger/*any", func(w http.ResponseWriter, r *http.Request, ps httprouter.Params) {
 		handler := httpSwagger.Handler(
 			httpSwagger.URL("/swagger/doc.json"),
@@ -112,6 +136,7 @@ func NewRouter(
 	router.GET("/usulan_musrebang/opd/:kode_opd", usulanMusrebangController.FindAll)
 	router.POST("/usulan_musrebang/create_rekin/:rencana_kinerja_id", usulanMusrebangController.CreateRekin)
 	router.DELETE("/usulan_musrebang/delete_usulan_terpilih/:id", usulanMusrebangController.DeleteUsulanTerpilih)
+	router.POST("/usulan_musrebang/import/:tahun", superAdmin(usulanMusrebangController.Import))
 
 	//usulan mandatori
 	router.POST("/usulan_mandatori/create", usulanMandatoriController.Create)
@@ -136,6 +161,7 @@ func NewRouter(
 	router.GET("/usulan_pokok_pikiran/opd/:kode_opd", usulanPokokPikiranController.FindAll)
 	router.POST("/usulan_pokok_pikiran/create_rekin/:rencana_kinerja_id", usulanPokokPikiranController.CreateRekin)
 	router.DELETE("/usulan_pokok_pikiran/delete_usulan_terpilih/:id", usulanPokokPikiranController.DeleteUsulanTerpilih)
+	router.POST("/usulan_pokok_pikiran/import/:tahun", superAdmin(usulanPokokPikiranController.Import))
 
 	//usulan inisiatif
 	router.POST("/usulan_inisiatif/create", usulanInisiatifController.Create)
@@ -170,13 +196,13 @@ func NewRouter(
 	router.DELETE("/inovasi/delete/:id", inovasiController.Delete)
 
 	//sub kegiatan
-	router.POST("/sub_kegiatan/create", subKegiatanController.Create)
-	router.PUT("/sub_kegiatan/update/:id", subKegiatanController.Update)
+	router.POST("/sub_kegiatan/create", superAdmin(subKegiatanController.Create))
+	router.PUT("/sub_kegiatan/update/:id", superAdmin(subKegiatanController.Update))
 	router.GET("/sub_kegiatan/detail/:id", subKegiatanController.FindById)
 	router.GET("/sub_kegiatan/findall", subKegiatanController.FindAll)
 	router.GET("/sub_kegiatan/pilihan/:kode_opd", subKegiatanController.FindAll)
 	router.GET("/sub_kegiatan/byrekinid/:rencana_kinerja_id", subKegiatanController.FindAll)
-	router.DELETE("/sub_kegiatan/delete/:id", subKegiatanController.Delete)
+	router.DELETE("/sub_kegiatan/delete/:id", superAdmin(subKegiatanController.Delete))
 	router.GET("/sub_kegiatan/kak/:kode_opd/:kode_subkegiatan/:tahun", subKegiatanController.FindSubKegiatanKAK)
 
 	//sub kegiatan terpilih
@@ -192,6 +218,7 @@ func NewRouter(
 	router.GET("/pohon_kinerja_opd/detail/:id", pohonKinerjaOpdController.FindById)
 	router.DELETE("/pohon_kinerja_opd/delete/:id", pohonKinerjaOpdController.Delete)
 	router.GET("/pohon_kinerja_opd/findall/:kode_opd/:tahun", pohonKinerjaOpdController.FindAll)
+	router.GET("/pohon_kinerja_opd/findall/:kode_opd/:tahun/export", pohonKinerjaOpdController.ExportGraf)
 	router.GET("/pohon_kinerja_opd/strategic_no_parent/:kode_opd/:tahun", pohonKinerjaOpdController.FindStrategicNoParent)
 	router.DELETE("/pohon_kinerja_opd/delete_pelaksana/:id", pohonKinerjaOpdController.DeletePelaksana)
 	router.DELETE("/pohon_kinerja_opd/delete_pokin_pemda/:id", pohonKinerjaOpdController.DeletePokinPemdaInOpd)
@@ -200,19 +227,23 @@ func NewRouter(
 	router.PUT("/pohon_kinerja_opd/update_parent_clone/:id", pohonKinerjaOpdController.UpdateParentClone)
 
 	//pohon kinerja admin
-	router.POST("/pohon_kinerja_admin/create", pohonKinerjaAdminController.Create)
-	router.PUT("/pohon_kinerja_admin/update/:pohonKinerjaId", pohonKinerjaAdminController.Update)
-	router.GET("/pohon_kinerja_admin/detail/:id", pohonKinerjaAdminController.FindById)
-	router.DELETE("/pohon_kinerja_admin/delete/:pohonKinerjaId", pohonKinerjaAdminController.Delete)
-	router.GET("/pohon_kinerja_admin/findall/:tahun", pohonKinerjaAdminController.FindAll)
-	router.GET("/pohon_kinerja_admin/tematik/:idPokin", pohonKinerjaAdminController.FindPokinAdminByIdHierarki)
-	router.POST("/pohon_kinerja_admin/clone_strategic/create", pohonKinerjaAdminController.CreateStrategicAdmin)
-	router.POST("/pohon_kinerja_admin/clone_pokin_pemda/create", pohonKinerjaAdminController.CloneStrategiFromPemda)
-	router.PUT("/pohon_kinerja_admin/tolak_pokin/:pohonKinerjaId", pohonKinerjaAdminController.UpdatePokinStatusTolak)
-	router.GET("/pohon_kinerja_admin/crosscutting/:kode_opd/:tahun", pohonKinerjaAdminController.FindPokinByCrosscuttingStatus)
-	router.POST("/pokin/activation_tematik/:id", pohonKinerjaAdminController.AktiforNonAktifTematik)
-	router.GET("/pokin_tematik/list_opd/:tahun", pohonKinerjaAdminController.FindListOpdAllTematik)
-	router.POST("/clone_pokin_pemda/:id", pohonKinerjaAdminController.ClonePokinPemda)
+	router.POST("/pohon_kinerja_admin/create", superAdmin(pohonKinerjaAdminController.Create))
+	router.PUT("/pohon_kinerja_admin/update/:pohonKinerjaId", superAdmin(pohonKinerjaAdminController.Update))
+	router.GET("/pohon_kinerja_admin/detail/:id", adminOrReviewer(pohonKinerjaAdminController.FindById))
+	router.DELETE("/pohon_kinerja_admin/delete/:pohonKinerjaId", superAdmin(pohonKinerjaAdminController.Delete))
+	router.GET("/pohon_kinerja_admin/findall/:tahun", adminOrReviewer(pohonKinerjaAdminController.FindAll))
+	router.GET("/pohon_kinerja_admin/tematik/:idPokin", adminOrReviewer(pohonKinerjaAdminController.FindPokinAdminByIdHierarki))
+	router.GET("/pohon_kinerja_admin/tematik/:idPokin/export", adminOrReviewer(pohonKinerjaAdminController.ExportTematik))
+	router.POST("/pohon_kinerja_admin/clone_strategic/create", superAdmin(pohonKinerjaAdminController.CreateStrategicAdmin))
+	router.POST("/pohon_kinerja_admin/clone_pokin_pemda/create", superAdmin(pohonKinerjaAdminController.CloneStrategiFromPemda))
+	router.PUT("/pohon_kinerja_admin/tolak_pokin/:pohonKinerjaId", superAdmin(pohonKinerjaAdminController.UpdatePokinStatusTolak))
+	router.GET("/pohon_kinerja_workflow", pokinWorkflowController.FindAturan)
+	router.GET("/pohon_kinerja_workflow/:id", pokinWorkflowController.FindWorkflow)
+	router.POST("/pohon_kinerja_workflow/:id/transisi", pokinWorkflowController.Transisi)
+	router.GET("/pohon_kinerja_admin/crosscutting/:kode_opd/:tahun", adminOrReviewer(pohonKinerjaAdminController.FindPokinByCrosscuttingStatus))
+	router.POST("/pokin/activation_tematik/:id", superAdmin(pohonKinerjaAdminController.AktiforNonAktifTematik))
+	router.GET("/pokin_tematik/list_opd/:tahun", adminOrReviewer(pohonKinerjaAdminController.FindListOpdAllTematik))
+	router.POST("/clone_pokin_pemda/:id", superAdmin(pohonKinerjaAdminController.ClonePokinPemda))
 	// router.POST("/pohon_kinerja_admin/crosscutting/create", pohonKinerjaAdminController.CrosscuttingOpd)
 	// router.PUT("/pohon_kinerja_admin/setujui_crosscutting/:pohonKinerjaId", pohonKinerjaAdminController.SetujuiCrosscutting)
 	// router.PUT("/pohon_kinerja_admin/tolak_crosscutting/:pohonKinerjaId", pohonKinerjaAdminController.TolakCrosscutting)
@@ -233,84 +264,90 @@ func NewRouter(
 
 	//DATA MASTER
 	//pegawai
-	router.POST("/pegawai/create", pegawaiController.Create)
-	router.PUT("/pegawai/update/:id", pegawaiController.Update)
+	router.POST("/pegawai/create", admin(pegawaiController.Create))
+	router.PUT("/pegawai/update/:id", admin(pegawaiController.Update))
 	router.GET("/pegawai/detail/:id", pegawaiController.FindById)
-	router.DELETE("/pegawai/delete/:id", pegawaiController.Delete)
+	router.DELETE("/pegawai/delete/:id", admin(pegawaiController.Delete))
 	router.GET("/pegawai/findall", pegawaiController.FindAll)
-	router.POST("/pegawai/tambahJabatan", pegawaiController.TambahJabatanPegawai)
+	router.POST("/pegawai/tambahJabatan", admin(pegawaiController.TambahJabatanPegawai))
 
 	//lembaga
-	router.POST("/lembaga/create", lembagaController.Create)
-	router.PUT("/lembaga/update/:id", lembagaController.Update)
+	router.POST("/lembaga/create", superAdmin(lembagaController.Create))
+	router.PUT("/lembaga/update/:id", superAdmin(lembagaController.Update))
 	router.GET("/lembaga/detail/:id", lembagaController.FindById)
-	router.DELETE("/lembaga/delete/:id", lembagaController.Delete)
+	router.DELETE("/lembaga/delete/:id", superAdmin(lembagaController.Delete))
 	router.GET("/lembaga/findall", lembagaController.FindAll)
 
 	//jabatan
-	router.POST("/jabatan/create", jabatanController.Create)
-	router.PUT("/jabatan/update/:id", jabatanController.Update)
+	router.POST("/jabatan/create", admin(jabatanController.Create))
+	router.PUT("/jabatan/update/:id", admin(jabatanController.Update))
 	router.GET("/jabatan/detail/:id", jabatanController.FindById)
-	router.DELETE("/jabatan/delete/:id", jabatanController.Delete)
+	router.DELETE("/jabatan/delete/:id", admin(jabatanController.Delete))
 	router.GET("/jabatan/findall/:kode_opd", jabatanController.FindAll)
 	router.GET("/jabatan/findall/:kode_opd/:tahun", jabatanController.FindAll)
 
 	//opd
-	router.POST("/opd/create", opdController.Create)
-	router.PUT("/opd/update/:opdId", opdController.Update)
+	router.POST("/opd/create", superAdmin(opdController.Create))
+	router.PUT("/opd/update/:opdId", superAdmin(opdController.Update))
 	router.GET("/opd/detail/:opdId", opdController.FindById)
-	router.DELETE("/opd/delete/:opdId", opdController.Delete)
+	router.DELETE("/opd/delete/:opdId", superAdmin(opdController.Delete))
 	router.GET("/opd/findall", opdController.FindAll)
 
 	//program
-	router.POST("/program_kegiatan/create", programController.Create)
-	router.PUT("/program_kegiatan/update/:programId", programController.Update)
+	router.POST("/program_kegiatan/create", superAdmin(programController.Create))
+	router.PUT("/program_kegiatan/update/:programId", superAdmin(programController.Update))
 	router.GET("/program_kegiatan/detail/:id", programController.FindById)
-	router.DELETE("/program_kegiatan/delete/:id", programController.Delete)
+	router.DELETE("/program_kegiatan/delete/:id", superAdmin(programController.Delete))
 	router.GET("/program_kegiatan/findall", programController.FindAll)
 
 	//urusan
-	router.POST("/urusan/create", urusanController.Create)
-	router.PUT("/urusan/update/:id", urusanController.Update)
+	router.POST("/urusan/create", superAdmin(urusanController.Create))
+	router.PUT("/urusan/update/:id", superAdmin(urusanController.Update))
 	router.GET("/urusan/detail/:id", urusanController.FindById)
-	router.DELETE("/urusan/delete/:id", urusanController.Delete)
+	router.DELETE("/urusan/delete/:id", superAdmin(urusanController.Delete))
 	router.GET("/urusan/findall", urusanController.FindAll)
 	// router.GET("/urusan/findall/:kode_opd", urusanController.FindByKodeOpd)
 	router.GET("/urusan/findall/:kode_opd/urusan_bidang", urusanController.FindUrusanAndBidangByKodeOpd)
 
 	//bidang urusan
-	router.POST("/bidang_urusan/create", bidangUrusanController.Create)
-	router.PUT("/bidang_urusan/update/:id", bidangUrusanController.Update)
+	router.POST("/bidang_urusan/create", superAdmin(bidangUrusanController.Create))
+	router.PUT("/bidang_urusan/update/:id", superAdmin(bidangUrusanController.Update))
 	router.GET("/bidang_urusan/detail/:id", bidangUrusanController.FindById)
-	router.DELETE("/bidang_urusan/delete/:id", bidangUrusanController.Delete)
+	router.DELETE("/bidang_urusan/delete/:id", superAdmin(bidangUrusanController.Delete))
 	router.GET("/bidang_urusan/findall", bidangUrusanController.FindAll)
 	router.GET("/bidang_urusan/findall/:kode_opd", bidangUrusanController.FindByKodeOpd)
 
 	//kegiatan
-	router.POST("/kegiatan/create", kegiatanController.Create)
-	router.PUT("/kegiatan/update/:id", kegiatanController.Update)
+	router.POST("/kegiatan/create", superAdmin(kegiatanController.Create))
+	router.PUT("/kegiatan/update/:id", superAdmin(kegiatanController.Update))
 	router.GET("/kegiatan/detail/:id", kegiatanController.FindById)
-	router.DELETE("/kegiatan/delete/:id", kegiatanController.Delete)
+	router.DELETE("/kegiatan/delete/:id", superAdmin(kegiatanController.Delete))
 	router.GET("/kegiatan/findall", kegiatanController.FindAll)
 
 	//rincian kak
 	router.GET("/rencana_kinerja/:rencana_kinerja_id/pegawai/:pegawai_id/input_rincian_kak", rencanaKinerjaController.FindAllRincianKak)
 
 	//role
-	router.POST("/role/create", roleController.Create)
-	router.PUT("/role/update/:id", roleController.Update)
-	router.GET("/role/detail/:id", roleController.FindById)
-	router.DELETE("/role/delete/:id", roleController.Delete)
-	router.GET("/role/findall", roleController.FindAll)
+	router.POST("/role/create", superAdmin(roleController.Create))
+	router.PUT("/role/update/:id", superAdmin(roleController.Update))
+	router.GET("/role/detail/:id", admin(roleController.FindById))
+	router.DELETE("/role/delete/:id", superAdmin(roleController.Delete))
+	router.GET("/role/findall", admin(roleController.FindAll))
 
 	//user
-	router.POST("/user/create", userController.Create)
-	router.PUT("/user/update/:id", userController.Update)
+	router.POST("/user/create", admin(userController.Create))
+	router.PUT("/user/update/:id", admin(userController.Update))
 	router.GET("/user/detail/:id", userController.FindById)
-	router.DELETE("/user/delete/:id", userController.Delete)
-	router.GET("/user/findall", userController.FindAll)
+	router.DELETE("/user/delete/:id", admin(userController.Delete))
+	router.GET("/user/findall", admin(userController.FindAll))
 	router.POST("/user/login", userController.Login)
-	router.GET("/user/findbykodeopdandrole", userController.FindByKodeOpdAndRole)
+	router.POST("/user/refresh", userController.RefreshToken)
+	router.POST("/user/logout", userController.Logout)
+	router.POST("/user/change_password", userController.ChangePassword)
+	router.POST("/user/reset_password/:id", admin(userController.ResetPassword))
+	router.POST("/user/confirm_reset_password", userController.ConfirmResetPassword)
+	router.POST("/user/unlock_login/:id", admin(userController.UnlockLogin))
+	router.GET("/user/findbykodeopdandrole", admin(userController.FindByKodeOpdAndRole))
 	router.GET("/user/findpegawai/:nip", userController.FindByNip)
 
 	//tujuan opd renstra
@@ -339,36 +376,36 @@ func NewRouter(
 	router.GET("/manual_ik/sasaran_opd/:indikatorId/:tahun", manualIKController.FindManualIKSasaranOpdByIndikatorId)
 
 	//review
-	router.POST("/review_pokin/create/:pokinId", reviewController.Create)
-	router.PUT("/review_pokin/update/:id", reviewController.Update)
-	router.DELETE("/review_pokin/delete/:id", reviewController.Delete)
+	router.POST("/review_pokin/create/:pokinId", adminOrReviewer(reviewController.Create))
+	router.PUT("/review_pokin/update/:id", adminOrReviewer(reviewController.Update))
+	router.DELETE("/review_pokin/delete/:id", adminOrReviewer(reviewController.Delete))
 	router.GET("/review_pokin/findall/:pokin_id", reviewController.FindAll)
 	router.GET("/review_pokin/detail/:id", reviewController.FindById)
 	router.GET("/review_pokin/tematik/:tahun", reviewController.FindAllReviewByTematik)
 	router.GET("/review_pokin/opd/:kode_opd/:tahun", reviewController.FindAllReviewOpd)
 
 	//periode
-	router.POST("/periode/create", periodeController.Create)
-	router.PUT("/periode/update/:id", periodeController.Update)
+	router.POST("/periode/create", superAdmin(periodeController.Create))
+	router.PUT("/periode/update/:id", superAdmin(periodeController.Update))
 	router.GET("/periode/tahun/:tahun", periodeController.FindByTahun)
 	router.GET("/periode/findall", periodeController.FindAll)
 	router.GET("/periode/detail/:id", periodeController.FindById)
-	router.DELETE("/periode/delete/:id", periodeController.Delete)
+	router.DELETE("/periode/delete/:id", superAdmin(periodeController.Delete))
 
 	//tujuan pemda
-	router.POST("/tujuan_pemda/create", tujuanPemdaController.Create)
-	router.PUT("/tujuan_pemda/update/:id", tujuanPemdaController.Update)
-	router.DELETE("/tujuan_pemda/delete/:id", tujuanPemdaController.Delete)
+	router.POST("/tujuan_pemda/create", superAdmin(tujuanPemdaController.Create))
+	router.PUT("/tujuan_pemda/update/:id", superAdmin(tujuanPemdaController.Update))
+	router.DELETE("/tujuan_pemda/delete/:id", superAdmin(tujuanPemdaController.Delete))
 	router.GET("/tujuan_pemda/detail/:id", tujuanPemdaController.FindById)
 	router.GET("/tujuan_pemda/findall/:tahun/:jenis_periode", tujuanPemdaController.FindAll)
-	router.PUT("/tujuan_pemda/update_periode/:id", tujuanPemdaController.UpdatePeriode)
+	router.PUT("/tujuan_pemda/update_periode/:id", superAdmin(tujuanPemdaController.UpdatePeriode))
 	router.GET("/tujuan_pemda/findall_with_pokin/:tahun_awal/:tahun_akhir/:jenis_periode", tujuanPemdaController.FindAllWithPokin)
 	router.GET("/pohon_kinerja/pokin_with_periode/:pokin_id/:jenis_periode", tujuanPemdaController.FindPokinWithPeriode)
 
 	//sasaran pemda
-	router.POST("/sasaran_pemda/create", sasaranPemdaController.Create)
-	router.PUT("/sasaran_pemda/update/:id", sasaranPemdaController.Update)
-	router.DELETE("/sasaran_pemda/delete/:id", sasaranPemdaController.Delete)
+	router.POST("/sasaran_pemda/create", superAdmin(sasaranPemdaController.Create))
+	router.PUT("/sasaran_pemda/update/:id", superAdmin(sasaranPemdaController.Update))
+	router.DELETE("/sasaran_pemda/delete/:id", superAdmin(sasaranPemdaController.Delete))
 	router.GET("/sasaran_pemda/detail/:id", sasaranPemdaController.FindById)
 	// router.GET("/sasaran_pemda/findall/:tahun", sasaranPemdaController.FindAll)
 	router.GET("/sasaran_pemda/findall/tahun_awal/:tahun_awal/tahun_akhir/:tahun_akhir/jenis_periode/:jenis_periode", sasaranPemdaController.FindAllWithPokin)
@@ -383,8 +420,8 @@ func NewRouter(
 	//iku
 	router.GET("/indikator_utama/periode/:tahun_awal/:tahun_akhir/:jenis_periode", ikuController.FindAll)
 	router.GET("/indikator_utama/opd/:kode_opd/:tahun_awal/:tahun_akhir/:jenis_periode", ikuController.FindAllIkuOpd)
-	router.PUT("/indikator_utama/status/:indikator_id", ikuController.UpdateIkuActive)
-	router.PUT("/indikator_utama/opd/status/:kode_indikator", ikuController.UpdateIkuOpdActive)
+	router.PUT("/indikator_utama/status/:indikator_id", superAdmin(ikuController.UpdateIkuActive))
+	router.PUT("/indikator_utama/opd/status/:kode_indikator", admin(ikuController.UpdateIkuOpdActive))
 
 	//sasaran opd
 	// router.GET("/sasaran_opd/findall/:kode_opd/:tahun_awal/:tahun_akhir/:jenis_periode", sasaranOpdController.FindAll)
@@ -396,26 +433,26 @@ func NewRouter(
 	router.GET("/sasaran_opd/renja/:kode_opd/:tahun/:jenis_periode", sasaranOpdController.FindByTahun)
 
 	//visi pemda
-	router.POST("/visi_pemda/create", visiPemdaController.Create)
-	router.PUT("/visi_pemda/update/:id", visiPemdaController.Update)
-	router.DELETE("/visi_pemda/delete/:id", visiPemdaController.Delete)
+	router.POST("/visi_pemda/create", superAdmin(visiPemdaController.Create))
+	router.PUT("/visi_pemda/update/:id", superAdmin(visiPemdaController.Update))
+	router.DELETE("/visi_pemda/delete/:id", superAdmin(visiPemdaController.Delete))
 	// router.GET("/visi_pemda/findall/tahunawal/:tahun_awal/tahunakhir/:tahun_akhir/jenisperiode/:jenis_periode", visiPemdaController.FindAll)
 	router.GET("/visi_pemda/findall/tahun/:tahun_awal/jenisperiode/:jenis_periode", visiPemdaController.FindAll)
 	router.GET("/visi_pemda/detail/:id", visiPemdaController.FindById)
 
 	//misi pemda
-	router.POST("/misi_pemda/create", misiPemdaController.Create)
-	router.PUT("/misi_pemda/update/:id", misiPemdaController.Update)
-	router.DELETE("/misi_pemda/delete/:id", misiPemdaController.Delete)
+	router.POST("/misi_pemda/create", superAdmin(misiPemdaController.Create))
+	router.PUT("/misi_pemda/update/:id", superAdmin(misiPemdaController.Update))
+	router.DELETE("/misi_pemda/delete/:id", superAdmin(misiPemdaController.Delete))
 	// router.GET("/misi_pemda/findall/tahunawal/:tahun_awal/tahunakhir/:tahun_akhir/jenisperiode/:jenis_periode", misiPemdaController.FindAll)
 	router.GET("/misi_pemda/findall/tahun/:tahun_awal/jenisperiode/:jenis_periode", misiPemdaController.FindAll)
 	router.GET("/misi_pemda/detail/:id", misiPemdaController.FindById)
 	router.GET("/misi_pemda/findbyvisi/:id_visi", misiPemdaController.FindByIdVisi)
 
 	//subkegiatan opd
-	router.POST("/subkegiatanopd/create", subKegiatanTerpilihController.CreateOpd)
-	router.DELETE("/subkegiatanopd/delete/:id", subKegiatanTerpilihController.DeleteOpd)
-	router.PUT("/subkegiatanopd/update/:id", subKegiatanTerpilihController.UpdateOpd)
+	router.POST("/subkegiatanopd/create", admin(subKegiatanTerpilihController.CreateOpd))
+	router.DELETE("/subkegiatanopd/delete/:id", admin(subKegiatanTerpilihController.DeleteOpd))
+	router.PUT("/subkegiatanopd/update/:id", admin(subKegiatanTerpilihController.UpdateOpd))
 	router.GET("/subkegiatanopd/findall/:kode_opd/:tahun", subKegiatanTerpilihController.FindAllOpd)
 	router.GET("/subkegiatanopd/detail/:id", subKegiatanTerpilihController.FindById)
 	router.GET("/subkegiatanopd/bidangurusan/:kode_opd", subKegiatanTerpilihController.FindAllSubkegiatanByBidangUrusanOpd)
@@ -425,6 +462,8 @@ func NewRouter(
 	router.POST("/matrix_renstra/upsert_anggaran", matrixRenstraController.UpsertAnggaran)
 	router.DELETE("/matrix_renstra/indikator/delete/:kode_indikator", matrixRenstraController.DeleteIndikator)
 	router.POST("/matrix_renstra/indikator/upsert", matrixRenstraController.UpsertBatchIndikator)
+	router.GET("/matrix_renstra/export/:kode_opd", matrixRenstraController.ExportXlsx)
+	router.POST("/matrix_renstra/import/:kode_opd", matrixRenstraController.ImportXlsx)
 
 	//cascading opd
 	router.GET("/cascading_opd/findall/:kode_opd/:tahun", cascadingOpdController.FindAll)
@@ -439,12 +478,12 @@ func NewRouter(
 	router.GET("/rincian_belanja/laporan", rincianBelanjaController.LaporanRincianBelanjaOpd)
 
 	//kelompok anggaran
-	router.POST("/kelompok_anggaran/create", kelompokAnggaranController.Create)
+	router.POST("/kelompok_anggaran/create", superAdmin(kelompokAnggaranController.Create))
 	router.GET("/kelompok_anggaran/findall", kelompokAnggaranController.FindAll)
 	router.GET("/kelompok_anggaran/detail/:id", kelompokAnggaranController.FindById)
 
 	//clonning pohon kinerja opd
-	router.POST("/pohon_kinerja_opd/clone", pohonKinerjaOpdController.Clone)
+	router.POST("/pohon_kinerja_opd/clone", admin(cloneJobController.ClonePokinOpd))
 	router.GET("/pohon_kinerja_opd/check_pokin/:kode_opd/:tahun", pohonKinerjaOpdController.CheckPokinExistsByTahun)
 
 	//count pokin pemda in opd
@@ -458,9 +497,9 @@ func NewRouter(
 	//Master Program Unggulan
 	router.GET("/program_unggulan/findall", programUnggulanController.FindAll)
 	router.GET("/program_unggulan/detail/:id", programUnggulanController.FindById)
-	router.POST("/program_unggulan/create", programUnggulanController.Create)
-	router.PUT("/program_unggulan/update/:id", programUnggulanController.Update)
-	router.DELETE("/program_unggulan/delete/:id", programUnggulanController.Delete)
+	router.POST("/program_unggulan/create", superAdmin(programUnggulanController.Create))
+	router.PUT("/program_unggulan/update/:id", superAdmin(programUnggulanController.Update))
+	router.DELETE("/program_unggulan/delete/:id", superAdmin(programUnggulanController.Delete))
 	router.GET("/program_unggulan/findall/:tahun_awal/:tahun_akhir", programUnggulanController.FindAll)
 	router.GET("/program_unggulan/findbykodeprogramunggulan/:kode_program_unggulan", programUnggulanController.FindByKodeProgramUnggulan)
 	router.GET("/program_unggulan/findbytahun/:tahun", programUnggulanController.FindByTahun)
@@ -475,6 +514,8 @@ func NewRouter(
 	router.POST("/matrix_renja/indikator/rankhir/upsert", matrixRenjaController.UpsertBatchIndikatorRenjaRankhir)
 	router.POST("/matrix_renja/indikator/penetapan/upsert", matrixRenjaController.UpsertBatchIndikatorRenjaPenetapan)
 	router.POST("/matrix_renja/anggaran_penetapan/upsert", matrixRenjaController.UpsertAnggaran)
+	router.GET("/matrix_renja/export/:jenis/:kode_opd/:tahun", matrixRenjaController.ExportXlsx)
+	router.POST("/matrix_renja/import/:jenis/:kode_opd/:tahun", matrixRenjaController.ImportXlsx)
 
 	//Api Internal Consume
 	router.GET("/api/pokin_opd/findall/:kode_opd/:tahun", pohonKinerjaOpdController.FindAll)
@@ -491,22 +532,24 @@ func NewRouter(
 
 	//control pokin opd
 	router.GET("/pohon_kinerja_opd/control_pokin_opd/:kode_opd/:tahun", pohonKinerjaOpdController.ControlPokinOpd)
-	router.GET("/user/cek_admin_opd", userController.CekAdminOpd)
+	router.GET("/user/cek_admin_opd", superAdmin(userController.CekAdminOpd))
 	router.GET("/pohon_kinerja_opd/leaderboard_pokin_opd/:tahun", pohonKinerjaOpdController.LeaderboardPokinOpd)
 
 	//bidang urusan terpilih opd
-	router.POST("/bidang_urusan_opd/create", bidangUrusanController.CreateOPD)
-	router.DELETE("/bidang_urusan_opd/delete/:id", bidangUrusanController.DeleteOPD)
+	router.POST("/bidang_urusan_opd/create", admin(bidangUrusanController.CreateOPD))
+	router.DELETE("/bidang_urusan_opd/delete/:id", admin(bidangUrusanController.DeleteOPD))
 	router.GET("/bidang_urusan_opd/findall/:kode_opd", bidangUrusanController.FindBidangUrusanTerpilihByKodeOpd)
 
 	// PK
 	router.GET("/pk_opd/:kode_opd/:tahun", pkController.FindAllPkOpdTahunan)
-	router.POST("/pk_opd/hubungkan", pkController.HubungkanRekin)
-	router.POST("/pk_opd/hubungkan_atasan", pkController.HubungkanAtasan)
+	router.GET("/pk_opd/:kode_opd/:tahun/pdf", pkController.DownloadPkOpd)
+	router.GET("/pk_opd/:kode_opd/:tahun/pdf/:nip", pkController.DownloadPkPegawai)
+	router.POST("/pk_opd/hubungkan", admin(pkController.HubungkanRekin))
+	router.POST("/pk_opd/hubungkan_atasan", admin(pkController.HubungkanAtasan))
 
 	//clone rekin
 	router.POST("/rencana_kinerja/clone/:rekin_id/:tahun_tujuan", rencanaKinerjaController.CloneRencanaKinerja)
-	router.POST("/rencana_kinerja/clone_by_kode_opd", rencanaKinerjaController.CloneRencanaKinerjaByKodeOpd)
+	router.POST("/rencana_kinerja/clone_by_kode_opd", admin(cloneJobController.CloneRekinOpd))
 
 	//tujuan OPD NEW
 	router.GET("/tujuan_opd/renstra/:kode_opd/:tahun_awal/:tahun_akhir", tujuanOpdController.FindTujuanOpdRenstra)
@@ -543,7 +586,7 @@ func NewRouter(
 	router.GET("/iku_renja_opd/penetapan/:kode_opd/:tahun", ikuController.FindAllIkuRenjaOpdPenetapan)
 
 	// Leaderboard Hidden
-	router.POST("/leaderboard_rekin_hidden/upsert", pohonKinerjaOpdController.UpsertLeaderboardHidden)
+	router.POST("/leaderboard_rekin_hidden/upsert", superAdmin(pohonKinerjaOpdController.UpsertLeaderboardHidden))
 	router.GET("/leaderboard_rekin_hidden/findall/:tahun", pohonKinerjaOpdController.FindLeaderboardHiddenKodeOpds)
 
 	//delete crosscutting opd
@@ -552,5 +595,68 @@ func NewRouter(
 	//tujuan opd penetapan
 	router.GET("/tujuan_opd/penetapan/:kode_opd/:tahun", tujuanOpdController.TujuanOpdPenetapan)
 
+	// lock dokumen perencanaan
+	router.POST("/lock_data/lock", superAdmin(lockDataController.Lock))
+	router.POST("/lock_data/unlock", superAdmin(lockDataController.Unlock))
+	router.GET("/lock_data/findall", lockDataController.FindAll)
+
+	// audit log
+	router.GET("/audit/:entity/:id", adminOrReviewer(auditLogController.FindByEntity))
+	router.GET("/audit_opd/:kode_opd/:tahun", adminOrReviewer(auditLogController.FindByOpd))
+
+	// realisasi dan capaian indikator
+	router.POST("/realisasi/create", realisasiController.Create)
+	router.PUT("/realisasi/update/:id", realisasiController.Update)
+	router.DELETE("/realisasi/delete/:id", realisasiController.Delete)
+	router.PUT("/realisasi/indikator/:indikator_id/polaritas", realisasiController.UpdatePolaritas)
+	router.GET("/realisasi/indikator/:indikator_id/:tahun", realisasiController.FindByIndikator)
+	router.GET("/rencana_kinerja/:rencana_kinerja_id/realisasi/:tahun", realisasiController.FindByRencanaKinerja)
+	router.GET("/indikator_utama/realisasi/:kode_opd/:tahun", realisasiController.FindIkuOpd)
+
+	// progres bulanan pelaksanaan rencana aksi
+	router.PUT("/pelaksanaan_rencana_aksi/progres/:pelaksanaanRencanaAksiId", progresPelaksanaanController.Simpan)
+	router.DELETE("/pelaksanaan_rencana_aksi/progres/:pelaksanaanRencanaAksiId", progresPelaksanaanController.Delete)
+	router.GET("/rencana_kinerja/:rencana_kinerja_id/kurva_s", progresPelaksanaanController.KurvaS)
+	router.GET("/progres_pelaksanaan/opd/:kode_opd/:tahun", progresPelaksanaanController.FindByOpd)
+
+	// laporan
+	router.GET("/laporan/lkjip/:kode_opd/:tahun", laporanController.Lkjip)
+	router.GET("/rencana_kinerja/:rencana_kinerja_id/kak/export", laporanController.KakExport)
+
+	// verifikasi rencana kinerja oleh atasan
+	router.GET("/rencana_kinerja/:rencana_kinerja_id/verifikasi", rencanaKinerjaVerifikasiController.FindStatus)
+	router.GET("/rencana_kinerja_verifikasi/inbox", rencanaKinerjaVerifikasiController.FindInbox)
+	router.POST("/rencana_kinerja_verifikasi/:rencana_kinerja_id/transisi", rencanaKinerjaVerifikasiController.Transisi)
+
+	// clone job
+	router.POST("/clone_job", admin(cloneJobController.Mulai))
+	router.GET("/clone_job/:id", cloneJobController.FindById)
+	router.POST("/clone_job/:id/batal", admin(cloneJobController.Batalkan))
+
+	// pipeline usulan
+	router.GET("/usulan_pipeline/aturan", usulanPipelineController.FindAturan)
+	router.GET("/usulan_pipeline/opd/:kode_opd/:tahun", usulanPipelineController.FindAll)
+	router.GET("/usulan_pipeline/rekap/:kode_opd/:tahun", usulanPipelineController.Rekap)
+	router.GET("/usulan_pipeline/detail/:jenis_usulan/:id", usulanPipelineController.FindById)
+	router.POST("/usulan_pipeline/transisi/:jenis_usulan/:id", usulanPipelineController.Transisi)
+
+	// transparansi usulan tanpa login
+	router.GET("/publik/usulan", publik(usulanPublikController.FindAll))
+	router.GET("/publik/usulan/:jenis_usulan/:id", publik(usulanPublikController.FindById))
+
+	// geospasial usulan dan indikator
+	router.GET("/geo/wilayah", geospasialController.FindWilayah)
+	router.POST("/geo/wilayah/import/:tingkat", superAdmin(geospasialController.ImportWilayah))
+	router.PUT("/geo/lokasi_usulan/:jenis_usulan/:id", geospasialController.UpdateLokasiUsulan)
+	router.PUT("/geo/lokasi_indikator/:indikator_id", geospasialController.UpdateLokasiIndikator)
+	router.GET("/geo/usulan", geospasialController.FeatureUsulan)
+	router.GET("/geo/indikator", geospasialController.FeatureIndikator)
+	router.GET("/geo/sebaran", geospasialController.Sebaran)
+
+	// nomenklatur Kepmendagri
+	router.POST("/nomenklatur/import", superAdmin(nomenklaturController.Import))
+	router.GET("/nomenklatur/versi", superAdmin(nomenklaturController.FindAllVersi))
+	router.GET("/nomenklatur/versi/:id", superAdmin(nomenklaturController.FindVersiById))
+
 	return router
 }
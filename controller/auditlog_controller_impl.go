@@ -0,0 +1,69 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type AuditLogControllerImpl struct {
+	AuditLogService service.AuditLogService
+}
+
+func NewAuditLogControllerImpl(auditLogService service.AuditLogService) *AuditLogControllerImpl {
+	return &AuditLogControllerImpl{
+		AuditLogService: auditLogService,
+	}
+}
+
+// @Summary      Riwayat perubahan entitas
+// @Description  entity: rencana_kinerja, indikator_renstra, indikator_renja, anggaran_renstra, anggaran_renja, rincian_belanja, crosscutting_opd, indikator_pokin (id pohon kinerja), manual_ik (id indikator)
+// @Tags         Audit Log
+// @Produce      json
+// @Param        entity  path  string  true  "Jenis entitas"
+// @Param        id      path  string  true  "Id entitas"
+// @Success      200  {object}  web.WebResponse{data=[]auditlog.AuditLogResponse}
+// @Failure      403  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /audit/{entity}/{id} [get]
+func (controller *AuditLogControllerImpl) FindByEntity(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	auditLogResponses, err := controller.AuditLogService.FindByEntity(request.Context(), params.ByName("entity"), params.ByName("id"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "Success Find Audit Log",
+		Data:   auditLogResponses,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary      Riwayat perubahan data OPD per tahun
+// @Tags         Audit Log
+// @Produce      json
+// @Param        kode_opd  path  string  true  "Kode OPD"
+// @Param        tahun     path  string  true  "Tahun"
+// @Success      200  {object}  web.WebResponse{data=[]auditlog.AuditLogResponse}
+// @Failure      403  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /audit_opd/{kode_opd}/{tahun} [get]
+func (controller *AuditLogControllerImpl) FindByOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	auditLogResponses, err := controller.AuditLogService.FindByOpd(request.Context(), params.ByName("kode_opd"), params.ByName("tahun"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "Success Find Audit Log",
+		Data:   auditLogResponses,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -24,14 +24,13 @@ func (controller *BidangUrusanControllerImpl) Create(writer http.ResponseWriter,
 	bidangUrusanCreateRequest := bidangurusanresponse.BidangUrusanCreateRequest{}
 	helper.ReadFromRequestBody(request, &bidangUrusanCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), bidangUrusanCreateRequest) {
+		return
+	}
+
 	bidangUrusanCreateResponse, err := controller.BidangUrusanService.Create(request.Context(), bidangUrusanCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -49,14 +48,13 @@ func (controller *BidangUrusanControllerImpl) Update(writer http.ResponseWriter,
 
 	bidangUrusanUpdateRequest.Id = params.ByName("id")
 
+	if !helper.ValidateRequest(writer, request.Context(), bidangUrusanUpdateRequest) {
+		return
+	}
+
 	bidangUrusanUpdateResponse, err := controller.BidangUrusanService.Update(request.Context(), bidangUrusanUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -72,12 +70,7 @@ func (controller *BidangUrusanControllerImpl) FindById(writer http.ResponseWrite
 
 	bidangUrusanResponse, err := controller.BidangUrusanService.FindById(request.Context(), bidangUrusanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -91,12 +84,7 @@ func (controller *BidangUrusanControllerImpl) FindById(writer http.ResponseWrite
 func (controller *BidangUrusanControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	bidangUrusanResponses, err := controller.BidangUrusanService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -112,12 +100,7 @@ func (controller *BidangUrusanControllerImpl) Delete(writer http.ResponseWriter,
 
 	err := controller.BidangUrusanService.Delete(request.Context(), bidangUrusanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -132,12 +115,7 @@ func (controller *BidangUrusanControllerImpl) FindByKodeOpd(writer http.Response
 
 	bidangUrusanResponses, err := controller.BidangUrusanService.FindByKodeOpd(request.Context(), kodeOpd)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -152,14 +130,13 @@ func (controller *BidangUrusanControllerImpl) CreateOPD(writer http.ResponseWrit
 	bidangUrusanTerpilihCreateRequest := bidangurusanresponse.BidangUrusanOPDCreateRequest{}
 	helper.ReadFromRequestBody(request, &bidangUrusanTerpilihCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), bidangUrusanTerpilihCreateRequest) {
+		return
+	}
+
 	bidangUrusanTerpilihCreateResponse, err := controller.BidangUrusanService.CreateOPD(request.Context(), bidangUrusanTerpilihCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -176,12 +153,7 @@ func (controller *BidangUrusanControllerImpl) DeleteOPD(writer http.ResponseWrit
 
 	err := controller.BidangUrusanService.DeleteOPD(request.Context(), bidangUrusanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -196,12 +168,7 @@ func (controller *BidangUrusanControllerImpl) FindBidangUrusanTerpilihByKodeOpd(
 
 	bidangUrusanResponses, err := controller.BidangUrusanService.FindBidangUrusanTerpilihByKodeOpd(request.Context(), kodeOpd)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -50,13 +50,7 @@ func (controller *CascadingOpdControllerImpl) FindAll(writer http.ResponseWriter
 		}
 
 		// Untuk error lainnya
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		writer.WriteHeader(http.StatusNotFound)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -75,12 +69,7 @@ func (controller *CascadingOpdControllerImpl) FindByRekinPegawaiAndId(writer htt
 
 	cascadingOpdResponse, err := controller.CascadingOpdService.FindByRekinPegawaiAndId(request.Context(), rekinId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -95,23 +84,13 @@ func (controller *CascadingOpdControllerImpl) FindByRekinPegawaiAndId(writer htt
 func (controller *CascadingOpdControllerImpl) FindByIdPokin(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	pokinId, err := strconv.Atoi(params.ByName("pokin_id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   "ID harus berupa angka",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 
 	cascadingOpdResponse, err := controller.CascadingOpdService.FindByIdPokin(request.Context(), pokinId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -129,12 +108,7 @@ func (controller *CascadingOpdControllerImpl) FindByNip(writer http.ResponseWrit
 
 	cascadingOpdResponse, err := controller.CascadingOpdService.FindByNip(request.Context(), nip, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -150,14 +124,13 @@ func (controller *CascadingOpdControllerImpl) FindByMultipleRekinPegawai(writer
 	rekinRequest := pohonkinerja.FindByMultipleRekinRequest{}
 	helper.ReadFromRequestBody(request, &rekinRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), rekinRequest) {
+		return
+	}
+
 	cascadingOpdResponse, err := controller.CascadingOpdService.FindByMultipleRekinPegawai(request.Context(), rekinRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type CloneJobController interface {
+	Mulai(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Batalkan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ClonePokinOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	CloneRekinOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
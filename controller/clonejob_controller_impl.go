@@ -0,0 +1,160 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/clonejob"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type CloneJobControllerImpl struct {
+	CloneJobService service.CloneJobService
+}
+
+func NewCloneJobControllerImpl(cloneJobService service.CloneJobService) *CloneJobControllerImpl {
+	return &CloneJobControllerImpl{
+		CloneJobService: cloneJobService,
+	}
+}
+
+func (controller *CloneJobControllerImpl) mulai(writer http.ResponseWriter, request *http.Request, jobRequest clonejob.CloneJobRequest) {
+	jobResponse, err := controller.CloneJobService.Mulai(request.Context(), jobRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBodyWstatus(writer, web.WebResponse{
+		Code:   http.StatusAccepted,
+		Status: "clone job diterima",
+		Data:   jobResponse,
+	})
+}
+
+// @Summary      Mulai clone job
+// @Description  Menjalankan clone pohon kinerja (pokin) atau rencana kinerja (rekin) OPD di background. Dry run menjalankan seluruh fase lalu di-rollback sehingga progres menunjukkan data yang akan dibuat dan dilewati. Dry run tetap menulis ke tabel tujuan di dalam transaksi: selama berjalan baris tujuan terkunci dan nilai auto increment yang terpakai tidak kembali, jadi jangan dijalankan berulang pada jam sibuk. Clone lama yang tertinggal PENDING/PROCESS lebih dari 30 menit tanpa progres dianggap gagal dan boleh diulang.
+// @Tags         Clone Job
+// @Accept       json
+// @Produce      json
+// @Param        data  body  clonejob.CloneJobRequest  true  "Jenis, OPD, tahun sumber dan tujuan"
+// @Success      202  {object}  web.WebResponse{data=clonejob.CloneJobResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Failure      409  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /clone_job [post]
+func (controller *CloneJobControllerImpl) Mulai(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	jobRequest := clonejob.CloneJobRequest{}
+	helper.ReadFromRequestBody(request, &jobRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), jobRequest) {
+		return
+	}
+
+	controller.mulai(writer, request, jobRequest)
+}
+
+// @Summary      Progres clone job
+// @Description  Status, persentase per fase dan daftar warning clone job.
+// @Tags         Clone Job
+// @Produce      json
+// @Param        id  path  string  true  "ID job"
+// @Success      200  {object}  web.WebResponse{data=clonejob.CloneJobResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /clone_job/{id} [get]
+func (controller *CloneJobControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	jobResponse, err := controller.CloneJobService.FindById(request.Context(), params.ByName("id"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get clone job",
+		Data:   jobResponse,
+	})
+}
+
+// @Summary      Batalkan clone job
+// @Description  Membatalkan clone job yang masih berjalan, seluruh data yang sudah di-clone di-rollback.
+// @Tags         Clone Job
+// @Produce      json
+// @Param        id  path  string  true  "ID job"
+// @Success      200  {object}  web.WebResponse{data=clonejob.CloneJobResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Failure      409  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /clone_job/{id}/batal [post]
+func (controller *CloneJobControllerImpl) Batalkan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	jobResponse, err := controller.CloneJobService.Batalkan(request.Context(), params.ByName("id"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "clone job dibatalkan",
+		Data:   jobResponse,
+	})
+}
+
+// @Summary      Clone Pohon Kinerja opd
+// @Description  Melakukan cloning pohon kinerja dari tahun sumber ke tahun tujuan untuk OPD tertentu sebagai clone job. Progres dipantau lewat /clone_job/{id}.
+// @Tags         Clone Pohon Kinerja Opd
+// @Accept       json
+// @Produce      json
+// @Param        pohon_kinerja_clone_request  body     pohonkinerja.PohonKinerjaCloneRequest  true  "Data untuk cloning pohon kinerja"
+// @Success      202  {object}  web.WebResponse{data=clonejob.CloneJobResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /pohon_kinerja_opd/clone [post]
+func (controller *CloneJobControllerImpl) ClonePokinOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	cloneRequest := pohonkinerja.PohonKinerjaCloneRequest{}
+	helper.ReadFromRequestBody(request, &cloneRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), cloneRequest) {
+		return
+	}
+
+	controller.mulai(writer, request, clonejob.CloneJobRequest{
+		Jenis:       domain.CloneJenisPokin,
+		KodeOpd:     cloneRequest.KodeOpd,
+		TahunSumber: cloneRequest.TahunSumber,
+		TahunTujuan: cloneRequest.TahunTujuan,
+	})
+}
+
+// @Summary      Clone Rencana Kinerja opd
+// @Description  Melakukan cloning rencana kinerja beserta detailnya dari tahun sumber ke tahun tujuan untuk OPD tertentu sebagai clone job. Progres dipantau lewat /clone_job/{id}.
+// @Tags         Rencana Kinerja
+// @Accept       json
+// @Produce      json
+// @Param        data  body  rencanakinerja.RekinByOpdCloneRequest  true  "Data untuk cloning rencana kinerja"
+// @Success      202  {object}  web.WebResponse{data=clonejob.CloneJobResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /rencana_kinerja/clone_by_kode_opd [post]
+func (controller *CloneJobControllerImpl) CloneRekinOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	cloneRequest := rencanakinerja.RekinByOpdCloneRequest{}
+	helper.ReadFromRequestBody(request, &cloneRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), cloneRequest) {
+		return
+	}
+
+	controller.mulai(writer, request, clonejob.CloneJobRequest{
+		Jenis:       domain.CloneJenisRekin,
+		KodeOpd:     cloneRequest.KodeOpd,
+		TahunSumber: cloneRequest.TahunSumber,
+		TahunTujuan: cloneRequest.TahunTujuan,
+		UpdatedBy:   cloneRequest.UpdatedBy,
+	})
+}
@@ -25,12 +25,7 @@ func NewCrosscuttingOpdControllerImpl(crosscuttingOpdService service.Crosscuttin
 func (controller *CrosscuttingOpdControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	parentId, err := strconv.Atoi(params.ByName("parentId"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Invalid parent ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid parent ID")
 		return
 	}
 
@@ -38,23 +33,13 @@ func (controller *CrosscuttingOpdControllerImpl) Create(writer http.ResponseWrit
 	decoder := json.NewDecoder(request.Body)
 	err = decoder.Decode(&crosscuttingCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	crosscuttingResponse, err := controller.CrosscuttingOpdService.Create(request.Context(), crosscuttingCreateRequest, parentId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -71,12 +56,7 @@ func (controller *CrosscuttingOpdControllerImpl) Update(writer http.ResponseWrit
 	// Ambil crosscuttingId dari params
 	crosscuttingId, err := strconv.Atoi(params.ByName("crosscuttingId"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Invalid crosscutting ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid crosscutting ID")
 		return
 	}
 
@@ -85,12 +65,7 @@ func (controller *CrosscuttingOpdControllerImpl) Update(writer http.ResponseWrit
 	decoder := json.NewDecoder(request.Body)
 	err = decoder.Decode(&crosscuttingUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -102,31 +77,16 @@ func (controller *CrosscuttingOpdControllerImpl) Update(writer http.ResponseWrit
 	if err != nil {
 		// Handle specific errors
 		if err.Error() == "crosscutting tidak ditemukan" {
-			webResponse := web.WebResponse{
-				Code:   404,
-				Status: "NOT FOUND",
-				Data:   err.Error(),
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 			return
 		}
 		if err.Error() == "kode OPD hanya dapat diubah saat status crosscutting_menunggu" {
-			webResponse := web.WebResponse{
-				Code:   400,
-				Status: "BAD REQUEST",
-				Data:   err.Error(),
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 			return
 		}
 
 		// Default error response
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -143,23 +103,13 @@ func (controller *CrosscuttingOpdControllerImpl) Update(writer http.ResponseWrit
 func (controller *CrosscuttingOpdControllerImpl) FindAllByParent(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	parentId, err := strconv.Atoi(params.ByName("parentId"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Invalid parent ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid parent ID")
 		return
 	}
 
 	crosscuttingResponses, err := controller.CrosscuttingOpdService.FindAllByParent(request.Context(), parentId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -175,12 +125,7 @@ func (controller *CrosscuttingOpdControllerImpl) FindAllByParent(writer http.Res
 func (controller *CrosscuttingOpdControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	crosscuttingId, err := strconv.Atoi(params.ByName("crosscuttingId"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Invalid crosscutting ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid crosscutting ID")
 		return
 	}
 
@@ -188,22 +133,12 @@ func (controller *CrosscuttingOpdControllerImpl) Delete(writer http.ResponseWrit
 	err = controller.CrosscuttingOpdService.Delete(request.Context(), crosscuttingId, nipPegawai)
 	if err != nil {
 		if err.Error() == "crosscutting hanya dapat dihapus saat status crosscutting_disetujui" {
-			webResponse := web.WebResponse{
-				Code:   400,
-				Status: "BAD REQUEST",
-				Data:   err.Error(),
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 			return
 		}
 
 		// Default error response
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -220,24 +155,14 @@ func (controller *CrosscuttingOpdControllerImpl) Delete(writer http.ResponseWrit
 func (controller *CrosscuttingOpdControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	parentId, err := strconv.Atoi(params.ByName("parentId"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Invalid parent ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid parent ID")
 		return
 	}
 
 	// Panggil service untuk mendapatkan data
 	crosscuttingResponses, err := controller.CrosscuttingOpdService.FindAllByParent(request.Context(), parentId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -265,12 +190,7 @@ func (controller *CrosscuttingOpdControllerImpl) FindAll(writer http.ResponseWri
 func (controller *CrosscuttingOpdControllerImpl) ApproveOrReject(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	crosscuttingId, err := strconv.Atoi(params.ByName("crosscuttingId"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Invalid crosscutting ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid crosscutting ID")
 		return
 	}
 
@@ -278,23 +198,13 @@ func (controller *CrosscuttingOpdControllerImpl) ApproveOrReject(writer http.Res
 	decoder := json.NewDecoder(request.Body)
 	err = decoder.Decode(&approveRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	response, err := controller.CrosscuttingOpdService.ApproveOrReject(request.Context(), crosscuttingId, approveRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -310,33 +220,18 @@ func (controller *CrosscuttingOpdControllerImpl) ApproveOrReject(writer http.Res
 func (controller *CrosscuttingOpdControllerImpl) DeleteUnused(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	crosscuttingId, err := strconv.Atoi(params.ByName("crosscuttingId"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Invalid crosscutting ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid crosscutting ID")
 		return
 	}
 
 	err = controller.CrosscuttingOpdService.DeleteUnused(request.Context(), crosscuttingId)
 	if err != nil {
 		if err.Error() == "crosscutting tidak dapat dihapus" {
-			webResponse := web.WebResponse{
-				Code:   400,
-				Status: "BAD REQUEST",
-				Data:   err.Error(),
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 			return
 		}
 
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -354,23 +249,13 @@ func (controller *CrosscuttingOpdControllerImpl) FindPokinByCrosscuttingStatus(w
 	tahun := params.ByName("tahun")
 
 	if kodeOpd == "" || tahun == "" {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "kode_opd dan tahun harus diisi",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "kode_opd dan tahun harus diisi")
 		return
 	}
 
 	crosscuttingResponses, err := controller.CrosscuttingOpdService.FindPokinByCrosscuttingStatus(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -386,23 +271,13 @@ func (controller *CrosscuttingOpdControllerImpl) FindOPDCrosscuttingFrom(writer
 	crosscuttingTo := params.ByName("crosscuttingTo")
 	id, err := strconv.Atoi(crosscuttingTo)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid crosscutting_to ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid crosscutting_to ID")
 		return
 	}
 
 	response, err := controller.CrosscuttingOpdService.FindOPDCrosscuttingFrom(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -417,22 +292,12 @@ func (controller *CrosscuttingOpdControllerImpl) FindOPDCrosscuttingFrom(writer
 func (controller *CrosscuttingOpdControllerImpl) DeleteCrosscuttingDiterima(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	crosscuttingId, err := strconv.Atoi(params.ByName("crosscuttingId"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Invalid crosscutting ID",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid crosscutting ID")
 		return
 	}
 	err = controller.CrosscuttingOpdService.DeleteCrosscuttingDiterima(request.Context(), crosscuttingId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -25,11 +25,7 @@ func NewDasarHukumControllerImpl(dasarHukumService service.DasarHukumService) *D
 func (controller *DasarHukumControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	rekinId := params.ByName("rencana_kinerja_id")
 	if rekinId == "" {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "RekinId tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "RekinId tidak boleh kosong")
 		return
 	}
 
@@ -41,13 +37,13 @@ func (controller *DasarHukumControllerImpl) Create(writer http.ResponseWriter, r
 	dasarHukumCreateRequest.RekinId = rekinId
 
 	// Panggil service untuk membuat gambaran umum
+	if !helper.ValidateRequest(writer, request.Context(), dasarHukumCreateRequest) {
+		return
+	}
+
 	dasarHukumResponse, err := controller.DasarHukumService.Create(request.Context(), dasarHukumCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -66,13 +62,13 @@ func (controller *DasarHukumControllerImpl) Update(writer http.ResponseWriter, r
 	dasarHukumId := params.ByName("id")
 	dasarHukumUpdateRequest.Id = dasarHukumId
 
+	if !helper.ValidateRequest(writer, request.Context(), dasarHukumUpdateRequest) {
+		return
+	}
+
 	dasarHukumResponse, err := controller.DasarHukumService.Update(request.Context(), dasarHukumUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -88,11 +84,7 @@ func (controller *DasarHukumControllerImpl) FindById(writer http.ResponseWriter,
 
 	dasarHukumResponse, err := controller.DasarHukumService.FindById(request.Context(), dasarHukumId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -108,11 +100,7 @@ func (controller *DasarHukumControllerImpl) FindAll(writer http.ResponseWriter,
 
 	dasarHukumResponses, err := controller.DasarHukumService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -128,11 +116,7 @@ func (controller *DasarHukumControllerImpl) Delete(writer http.ResponseWriter, r
 
 	err := controller.DasarHukumService.Delete(request.Context(), dasarHukumId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -148,11 +132,7 @@ func (controller *DasarHukumControllerImpl) FindAllByRekinId(writer http.Respons
 
 	dasarHukumResponses, err := controller.DasarHukumService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebDasarHukumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
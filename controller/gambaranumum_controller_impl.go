@@ -27,11 +27,7 @@ func (controller *GambaranUmumControllerImpl) Create(writer http.ResponseWriter,
 	// Ambil rekinId dari params URL
 	rekinId := params.ByName("rencana_kinerja_id")
 	if rekinId == "" {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "RekinId tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "RekinId tidak boleh kosong")
 		return
 	}
 
@@ -43,13 +39,13 @@ func (controller *GambaranUmumControllerImpl) Create(writer http.ResponseWriter,
 	gambaranUmumCreateRequest.RekinId = rekinId
 
 	// Panggil service untuk membuat gambaran umum
+	if !helper.ValidateRequest(writer, request.Context(), gambaranUmumCreateRequest) {
+		return
+	}
+
 	gambaranUmumResponse, err := controller.GambaranUmumService.Create(request.Context(), gambaranUmumCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -65,11 +61,7 @@ func (controller *GambaranUmumControllerImpl) Update(writer http.ResponseWriter,
 	// Ambil id dari params URL
 	id := params.ByName("id")
 	if id == "" {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "ID tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "ID tidak boleh kosong")
 		return
 	}
 
@@ -77,11 +69,7 @@ func (controller *GambaranUmumControllerImpl) Update(writer http.ResponseWriter,
 	gambaranUmumUpdateRequest := gambaranumum.GambaranUmumUpdateRequest{}
 	err := json.NewDecoder(request.Body).Decode(&gambaranUmumUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Format JSON tidak valid",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "Format JSON tidak valid")
 		return
 	}
 
@@ -91,11 +79,7 @@ func (controller *GambaranUmumControllerImpl) Update(writer http.ResponseWriter,
 	// Panggil service untuk update gambaran umum
 	gambaranUmumResponse, err := controller.GambaranUmumService.Update(request.Context(), gambaranUmumUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -111,22 +95,14 @@ func (controller *GambaranUmumControllerImpl) Delete(writer http.ResponseWriter,
 	// Ambil id dari params URL
 	id := params.ByName("id")
 	if id == "" {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "ID tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "ID tidak boleh kosong")
 		return
 	}
 
 	// Panggil service untuk menghapus gambaran umum
 	err := controller.GambaranUmumService.Delete(request.Context(), id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -142,22 +118,14 @@ func (controller *GambaranUmumControllerImpl) FindAll(writer http.ResponseWriter
 	// Ambil rekinId dari params URL
 	rekinId := params.ByName("rencana_kinerja_id")
 	if rekinId == "" {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "RekinId tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "RekinId tidak boleh kosong")
 		return
 	}
 
 	// Panggil service untuk mendapatkan semua gambaran umum
 	gambaranUmumResponses, err := controller.GambaranUmumService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -173,22 +141,14 @@ func (controller *GambaranUmumControllerImpl) FindById(writer http.ResponseWrite
 	// Ambil id dari params URL
 	id := params.ByName("id")
 	if id == "" {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "ID tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "ID tidak boleh kosong")
 		return
 	}
 
 	// Panggil service untuk mendapatkan gambaran umum berdasarkan ID
 	gambaranUmumResponse, err := controller.GambaranUmumService.FindById(request.Context(), id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -204,22 +164,14 @@ func (controller *GambaranUmumControllerImpl) FindAllByRekinId(writer http.Respo
 	// Ambil rekinId dari params URL
 	rekinId := params.ByName("rencana_kinerja_id")
 	if rekinId == "" {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "RekinId tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "RekinId tidak boleh kosong")
 		return
 	}
 
 	// Panggil service untuk mendapatkan semua gambaran umum
 	gambaranUmumResponses, err := controller.GambaranUmumService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebGambaranUmumResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type GeospasialController interface {
+	FindWilayah(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ImportWilayah(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdateLokasiUsulan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdateLokasiIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FeatureUsulan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FeatureIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Sebaran(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,217 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/geospasial"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// batas wilayah desa satu kabupaten dalam GeoJSON bisa jauh lebih besar dari file import biasa
+const maksUkuranGeoJson = 5 * helper.MaksUkuranUpload
+
+type GeospasialControllerImpl struct {
+	GeospasialService service.GeospasialService
+}
+
+func NewGeospasialControllerImpl(geospasialService service.GeospasialService) *GeospasialControllerImpl {
+	return &GeospasialControllerImpl{
+		GeospasialService: geospasialService,
+	}
+}
+
+// @Summary      Batas wilayah
+// @Description  Referensi batas kecamatan/desa sebagai GeoJSON FeatureCollection.
+// @Tags         Geospasial
+// @Produce      json
+// @Param        tingkat     query  string  false  "kecamatan atau desa"
+// @Param        kode_induk  query  string  false  "Kode kecamatan untuk daftar desa"
+// @Success      200  {object}  web.WebResponse{data=geospasial.FeatureCollection}
+// @Security     BearerAuth
+// @Router       /geo/wilayah [get]
+func (controller *GeospasialControllerImpl) FindWilayah(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	wilayahResponse, err := controller.GeospasialService.FindWilayah(request.Context(), query.Get("tingkat"), query.Get("kode_induk"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get wilayah",
+		Data:   wilayahResponse,
+	})
+}
+
+// @Summary      Import batas wilayah
+// @Description  Upload GeoJSON FeatureCollection batas kecamatan atau desa. Fitur dengan kode yang sudah ada diperbarui, fitur tidak valid dilaporkan di ditolak. Desa tanpa kode_induk dicocokkan ke kecamatan yang memuat titik tengahnya.
+// @Tags         Geospasial
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        tingkat  path      string  true  "kecamatan atau desa"
+// @Param        file     formData  file    true  "File GeoJSON"
+// @Success      200  {object}  web.WebResponse{data=geospasial.WilayahImportResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /geo/wilayah/import/{tingkat} [post]
+func (controller *GeospasialControllerImpl) ImportWilayah(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	content, err := helper.ReadUploadFile(writer, request, "file", maksUkuranGeoJson)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+
+	importResponse, err := controller.GeospasialService.ImportWilayah(request.Context(), params.ByName("tingkat"), content)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success import wilayah",
+		Data:   importResponse,
+	})
+}
+
+// @Summary      Simpan lokasi usulan
+// @Description  Titik dan/atau geometri GeoJSON usulan musrebang atau pokok pikiran. Kode wilayah kosong diisi dari batas wilayah yang memuat titik, semua field kosong menghapus lokasi.
+// @Tags         Geospasial
+// @Accept       json
+// @Produce      json
+// @Param        jenis_usulan  path  string                    true  "musrebang atau pokok_pikiran"
+// @Param        id            path  string                    true  "ID usulan"
+// @Param        data          body  geospasial.LokasiRequest  true  "Lokasi usulan"
+// @Success      200  {object}  web.WebResponse{data=geospasial.LokasiResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /geo/lokasi_usulan/{jenis_usulan}/{id} [put]
+func (controller *GeospasialControllerImpl) UpdateLokasiUsulan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	lokasiRequest := geospasial.LokasiUsulanRequest{}
+	helper.ReadFromRequestBody(request, &lokasiRequest)
+	lokasiRequest.JenisUsulan = params.ByName("jenis_usulan")
+	lokasiRequest.UsulanId = params.ByName("id")
+
+	lokasiResponse, err := controller.GeospasialService.UpdateLokasiUsulan(request.Context(), lokasiRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success update lokasi usulan",
+		Data:   lokasiResponse,
+	})
+}
+
+// @Summary      Simpan lokasi indikator
+// @Description  Mengganti seluruh lokasi intervensi indikator rekin. Hanya indikator yang manual IK-nya ditandai spasial, daftar kosong menghapus lokasi.
+// @Tags         Geospasial
+// @Accept       json
+// @Produce      json
+// @Param        indikator_id  path  string                             true  "ID indikator"
+// @Param        data          body  geospasial.LokasiIndikatorRequest  true  "Daftar lokasi"
+// @Success      200  {object}  web.WebResponse{data=[]geospasial.LokasiResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Failure      409  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /geo/lokasi_indikator/{indikator_id} [put]
+func (controller *GeospasialControllerImpl) UpdateLokasiIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	lokasiRequest := geospasial.LokasiIndikatorRequest{}
+	helper.ReadFromRequestBody(request, &lokasiRequest)
+	lokasiRequest.IndikatorId = params.ByName("indikator_id")
+
+	lokasiResponse, err := controller.GeospasialService.UpdateLokasiIndikator(request.Context(), lokasiRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success update lokasi indikator",
+		Data:   lokasiResponse,
+	})
+}
+
+// @Summary      Peta usulan
+// @Description  Usulan musrebang dan pokok pikiran sebagai GeoJSON FeatureCollection. Usulan yang hanya punya kode wilayah ditaruh di titik tengah wilayah dengan lokasi_perkiraan true.
+// @Tags         Geospasial
+// @Produce      json
+// @Param        tahun         query  string  true   "Tahun usulan"
+// @Param        kode_opd      query  string  false  "Kode OPD, kosong untuk semua OPD (super admin)"
+// @Param        tahap         query  string  false  "Tahap pipeline usulan"
+// @Param        jenis_usulan  query  string  false  "musrebang atau pokok_pikiran"
+// @Success      200  {object}  web.WebResponse{data=geospasial.FeatureCollection}
+// @Security     BearerAuth
+// @Router       /geo/usulan [get]
+func (controller *GeospasialControllerImpl) FeatureUsulan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	featureResponse, err := controller.GeospasialService.FeatureUsulan(request.Context(), query.Get("kode_opd"), query.Get("tahun"), query.Get("tahap"), query.Get("jenis_usulan"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get peta usulan",
+		Data:   featureResponse,
+	})
+}
+
+// @Summary      Peta indikator spasial
+// @Description  Lokasi intervensi indikator rekin yang manual IK-nya ditandai spasial sebagai GeoJSON FeatureCollection.
+// @Tags         Geospasial
+// @Produce      json
+// @Param        tahun     query  string  true   "Tahun rencana kinerja"
+// @Param        kode_opd  query  string  false  "Kode OPD, kosong untuk semua OPD (super admin)"
+// @Success      200  {object}  web.WebResponse{data=geospasial.FeatureCollection}
+// @Security     BearerAuth
+// @Router       /geo/indikator [get]
+func (controller *GeospasialControllerImpl) FeatureIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	featureResponse, err := controller.GeospasialService.FeatureIndikator(request.Context(), query.Get("kode_opd"), query.Get("tahun"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get peta indikator",
+		Data:   featureResponse,
+	})
+}
+
+// @Summary      Sebaran usulan dan indikator per wilayah
+// @Description  Batas kecamatan/desa dengan jumlah usulan, jumlah usulan per tahap dan jumlah indikator spasial di dalamnya.
+// @Tags         Geospasial
+// @Produce      json
+// @Param        tahun     query  string  true   "Tahun"
+// @Param        tingkat   query  string  false  "kecamatan (default) atau desa"
+// @Param        kode_opd  query  string  false  "Kode OPD, kosong untuk semua OPD (super admin)"
+// @Param        tahap     query  string  false  "Tahap pipeline usulan"
+// @Success      200  {object}  web.WebResponse{data=geospasial.FeatureCollection}
+// @Security     BearerAuth
+// @Router       /geo/sebaran [get]
+func (controller *GeospasialControllerImpl) Sebaran(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	sebaranResponse, err := controller.GeospasialService.Sebaran(request.Context(), query.Get("tingkat"), query.Get("kode_opd"), query.Get("tahun"), query.Get("tahap"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get sebaran wilayah",
+		Data:   sebaranResponse,
+	})
+}
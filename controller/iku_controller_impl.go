@@ -33,7 +33,7 @@ func (controller *IkuControllerImpl) FindAll(writer http.ResponseWriter, request
 
 	ikuResponses, err := controller.IkuService.FindAll(request.Context(), tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -67,14 +67,8 @@ func (controller *IkuControllerImpl) FindAllIkuOpd(writer http.ResponseWriter, r
 
 	ikuOpdResponses, err := controller.IkuService.FindAllIkuOpd(request.Context(), kodeOpd, tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-
-		helper.WriteToResponseBody(writer, webResponse)
-
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
 	}
 
 	webResponse := web.WebResponse{
@@ -92,13 +86,13 @@ func (controller *IkuControllerImpl) UpdateIkuActive(writer http.ResponseWriter,
 
 	id := params.ByName("indikator_id")
 
+	if !helper.ValidateRequest(writer, request.Context(), ikuUpdateActiveRequest) {
+		return
+	}
+
 	err := controller.IkuService.UpdateIkuActive(request.Context(), id, ikuUpdateActiveRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -126,13 +120,13 @@ func (controller *IkuControllerImpl) UpdateIkuOpdActive(writer http.ResponseWrit
 
 	id := params.ByName("kode_indikator")
 
+	if !helper.ValidateRequest(writer, request.Context(), ikuUpdateActiveRequest) {
+		return
+	}
+
 	err := controller.IkuService.UpdateIkuOpdActive(request.Context(), id, ikuUpdateActiveRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -162,7 +156,7 @@ func (controller *IkuControllerImpl) FindAllIkuRenjaOpdRanwal(writer http.Respon
 
 	ikuRenjaResponses, err := controller.IkuService.FindAllIkuRenja(request.Context(), kodeOpd, tahun, jenisPeriode, jenisIndikator)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 	}
 	webResponse := web.WebResponse{
 		Code:   200,
@@ -191,7 +185,7 @@ func (controller *IkuControllerImpl) FindAllIkuRenjaOpdRankhir(writer http.Respo
 
 	ikuRenjaResponses, err := controller.IkuService.FindAllIkuRenja(request.Context(), kodeOpd, tahun, jenisPeriode, jenisIndikator)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 	}
 	webResponse := web.WebResponse{
 		Code:   200,
@@ -220,7 +214,7 @@ func (controller *IkuControllerImpl) FindAllIkuRenjaOpdPenetapan(writer http.Res
 
 	ikuRenjaResponses, err := controller.IkuService.FindAllIkuRenja(request.Context(), kodeOpd, tahun, jenisPeriode, jenisIndikator)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 	}
 	webResponse := web.WebResponse{
 		Code:   200,
@@ -25,11 +25,7 @@ func NewInovasiControllerImpl(inovasiService service.InovasiService) *InovasiCon
 func (controller *InovasiControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	rekinId := params.ByName("rencana_kinerja_id")
 	if rekinId == "" {
-		helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "RekinId tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "RekinId tidak boleh kosong")
 		return
 	}
 
@@ -41,13 +37,13 @@ func (controller *InovasiControllerImpl) Create(writer http.ResponseWriter, requ
 	inovasiCreateRequest.RekinId = rekinId
 
 	// Panggil service untuk membuat gambaran umum
+	if !helper.ValidateRequest(writer, request.Context(), inovasiCreateRequest) {
+		return
+	}
+
 	inovasiResponse, err := controller.InovasiService.Create(request.Context(), inovasiCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -66,13 +62,13 @@ func (controller *InovasiControllerImpl) Update(writer http.ResponseWriter, requ
 	inovasiId := params.ByName("id")
 	inovasiUpdateRequest.Id = inovasiId
 
+	if !helper.ValidateRequest(writer, request.Context(), inovasiUpdateRequest) {
+		return
+	}
+
 	inovasiResponse, err := controller.InovasiService.Update(request.Context(), inovasiUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -88,11 +84,7 @@ func (controller *InovasiControllerImpl) FindAll(writer http.ResponseWriter, req
 
 	inovasiResponses, err := controller.InovasiService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -110,17 +102,9 @@ func (controller *InovasiControllerImpl) FindById(writer http.ResponseWriter, re
 	if err != nil {
 		// Periksa apakah error disebabkan oleh ID yang tidak ditemukan
 		if err.Error() == "inovasi tidak ditemukan" {
-			helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-				Code:   http.StatusNotFound,
-				Status: "NOT FOUND",
-				Data:   "ID inovasi tidak ditemukan",
-			})
+			helper.WriteError(writer, http.StatusNotFound, "ID inovasi tidak ditemukan")
 		} else {
-			helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-				Code:   http.StatusInternalServerError,
-				Status: "INTERNAL SERVER ERROR",
-				Data:   err.Error(),
-			})
+			helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		}
 		return
 	}
@@ -140,17 +124,9 @@ func (controller *InovasiControllerImpl) Delete(writer http.ResponseWriter, requ
 	if err != nil {
 		// Periksa apakah error disebabkan oleh ID yang tidak ditemukan
 		if err.Error() == "inovasi tidak ditemukan" {
-			helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-				Code:   http.StatusNotFound,
-				Status: "NOT FOUND",
-				Data:   "ID inovasi tidak ditemukan",
-			})
+			helper.WriteError(writer, http.StatusNotFound, "ID inovasi tidak ditemukan")
 		} else {
-			helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-				Code:   http.StatusInternalServerError,
-				Status: "INTERNAL SERVER ERROR",
-				Data:   err.Error(),
-			})
+			helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		}
 		return
 	}
@@ -166,11 +142,7 @@ func (controller *InovasiControllerImpl) FindAllByRekinId(writer http.ResponseWr
 
 	inovasiResponses, err := controller.InovasiService.FindAll(request.Context(), rekinId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebInovasiResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -30,7 +30,7 @@ func (controller *CSFControllerImpl) FindByTahun(writer http.ResponseWriter, req
 
 	csfResponses, err := controller.CSFService.FindByTahun(request.Context(), tahun)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -52,13 +52,13 @@ func (controller *CSFControllerImpl) FindById(writer http.ResponseWriter, reques
 
 	csfID, err := strconv.Atoi(id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
 	csfResponse, err := controller.CSFService.FindById(request.Context(), csfID)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
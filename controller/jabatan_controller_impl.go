@@ -25,14 +25,13 @@ func (controller *JabatanControllerImpl) Create(writer http.ResponseWriter, requ
 	jabatanCreateRequest := jabatan.JabatanCreateRequest{}
 	helper.ReadFromRequestBody(request, &jabatanCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), jabatanCreateRequest) {
+		return
+	}
+
 	jabatanResponse, err := controller.jabatanService.Create(request.Context(), jabatanCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed create data jabatan",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -49,14 +48,13 @@ func (controller *JabatanControllerImpl) Update(writer http.ResponseWriter, requ
 	helper.ReadFromRequestBody(request, &jabatanUpdateRequest)
 	jabatanUpdateRequest.Id = params.ByName("id")
 
+	if !helper.ValidateRequest(writer, request.Context(), jabatanUpdateRequest) {
+		return
+	}
+
 	jabatanResponse, err := controller.jabatanService.Update(request.Context(), jabatanUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed update data jabatan",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -84,12 +82,7 @@ func (controller *JabatanControllerImpl) FindById(writer http.ResponseWriter, re
 	jabatanId := params.ByName("id")
 	jabatanResponse, err := controller.jabatanService.FindById(request.Context(), jabatanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "not found",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -107,23 +100,13 @@ func (controller *JabatanControllerImpl) FindAll(writer http.ResponseWriter, req
 
 	// Validasi kode OPD tidak boleh kosong
 	if kodeOpd == "" {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed",
-			Data:   "kode OPD tidak boleh kosong",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "kode OPD tidak boleh kosong")
 		return
 	}
 
 	jabatanResponse, err := controller.jabatanService.FindAll(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed get data jabatan",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -26,14 +26,13 @@ func (controller *KegiatanControllerImpl) Create(writer http.ResponseWriter, req
 	helper.ReadFromRequestBody(request, &kegiatanCreateRequest)
 
 	// Panggil service untuk membuat kegiatan baru
+	if !helper.ValidateRequest(writer, request.Context(), kegiatanCreateRequest) {
+		return
+	}
+
 	kegiatanResponse, err := controller.KegiatanService.Create(request.Context(), kegiatanCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -55,14 +54,13 @@ func (controller *KegiatanControllerImpl) Update(writer http.ResponseWriter, req
 	kegiatanUpdateRequest.Id = params.ByName("id")
 
 	// Panggil service untuk update kegiatan
+	if !helper.ValidateRequest(writer, request.Context(), kegiatanUpdateRequest) {
+		return
+	}
+
 	kegiatanResponse, err := controller.KegiatanService.Update(request.Context(), kegiatanUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -83,12 +81,7 @@ func (controller *KegiatanControllerImpl) Delete(writer http.ResponseWriter, req
 	// Panggil service untuk delete kegiatan
 	err := controller.KegiatanService.Delete(request.Context(), kegiatanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -109,12 +102,7 @@ func (controller *KegiatanControllerImpl) FindById(writer http.ResponseWriter, r
 	// Panggil service untuk mencari kegiatan berdasarkan ID
 	kegiatanResponse, err := controller.KegiatanService.FindById(request.Context(), kegiatanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -132,12 +120,7 @@ func (controller *KegiatanControllerImpl) FindAll(writer http.ResponseWriter, re
 	// Panggil service untuk mendapatkan semua kegiatan
 	kegiatanResponses, err := controller.KegiatanService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -26,14 +26,13 @@ func (controller *KelompokAnggaranControllerImpl) Create(writer http.ResponseWri
 	helper.ReadFromRequestBody(request, &KelompokAnggaranCreateRequest)
 
 	// Panggil service untuk membuat kegiatan baru
+	if !helper.ValidateRequest(writer, request.Context(), KelompokAnggaranCreateRequest) {
+		return
+	}
+
 	kelompokResponse, err := controller.kelompokanggaranService.Create(request.Context(), KelompokAnggaranCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -56,14 +55,13 @@ func (controller *KelompokAnggaranControllerImpl) Update(writer http.ResponseWri
 	KelompokAnggaranUpdateRequest.Id = idInt
 
 	// Panggil service untuk update kegiatan
+	if !helper.ValidateRequest(writer, request.Context(), KelompokAnggaranUpdateRequest) {
+		return
+	}
+
 	kelompokResponse, err := controller.kelompokanggaranService.Update(request.Context(), KelompokAnggaranUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -83,12 +81,7 @@ func (controller *KelompokAnggaranControllerImpl) Delete(writer http.ResponseWri
 	// Panggil service untuk delete kegiatan
 	err := controller.kelompokanggaranService.Delete(request.Context(), Id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -108,12 +101,7 @@ func (controller *KelompokAnggaranControllerImpl) FindById(writer http.ResponseW
 	// Panggil service untuk mencari kegiatan berdasarkan ID
 	kegiatanResponse, err := controller.kelompokanggaranService.FindById(request.Context(), kegiatanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -130,12 +118,7 @@ func (controller *KelompokAnggaranControllerImpl) FindById(writer http.ResponseW
 func (controller *KelompokAnggaranControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	kelompokResponses, err := controller.kelompokanggaranService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
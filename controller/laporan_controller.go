@@ -0,0 +1,12 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type LaporanController interface {
+	Lkjip(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	KakExport(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
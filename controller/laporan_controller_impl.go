@@ -0,0 +1,87 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type LaporanControllerImpl struct {
+	LaporanService service.LaporanService
+}
+
+func NewLaporanControllerImpl(laporanService service.LaporanService) *LaporanControllerImpl {
+	return &LaporanControllerImpl{
+		LaporanService: laporanService,
+	}
+}
+
+// @Summary      Laporan Kinerja Instansi Pemerintah (LKjIP)
+// @Description  Tujuan, sasaran, IKU beserta target dan realisasi, perjanjian kinerja kepala OPD dan anggaran per program. Query format=pdf atau format=docx untuk mengunduh dokumen.
+// @Tags         Laporan
+// @Produce      json
+// @Produce      application/pdf
+// @Param        kode_opd  path   string  true   "Kode OPD"
+// @Param        tahun     path   string  true   "Tahun"
+// @Param        format    query  string  false  "json (default), pdf atau docx"
+// @Success      200  {object}  web.WebResponse{data=laporan.LkjipResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /laporan/lkjip/{kode_opd}/{tahun} [get]
+func (controller *LaporanControllerImpl) Lkjip(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	kodeOpd := params.ByName("kode_opd")
+	tahun := params.ByName("tahun")
+
+	format := helper.FormatDokumen(request)
+	if format != helper.FormatJson {
+		dokumen, err := controller.LaporanService.LkjipDokumen(request.Context(), kodeOpd, tahun)
+		if err != nil {
+			helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+			return
+		}
+		helper.WriteDokumen(writer, dokumen, format, "LKjIP_"+kodeOpd+"_"+tahun)
+		return
+	}
+
+	lkjipResponse, err := controller.LaporanService.Lkjip(request.Context(), kodeOpd, tahun)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get lkjip",
+		Data:   lkjipResponse,
+	})
+}
+
+// @Summary      Unduh Kerangka Acuan Kerja (KAK)
+// @Description  Dokumen KAK rencana kinerja lengkap dengan dasar hukum, permasalahan, indikator, program/kegiatan/subkegiatan dan jadwal rencana aksi per bulan.
+// @Tags         Laporan
+// @Produce      application/pdf
+// @Produce      application/vnd.openxmlformats-officedocument.wordprocessingml.document
+// @Param        rencana_kinerja_id  path   string  true   "ID Rencana Kinerja"
+// @Param        format              query  string  false  "pdf (default) atau docx"
+// @Success      200
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /rencana_kinerja/{rencana_kinerja_id}/kak/export [get]
+func (controller *LaporanControllerImpl) KakExport(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	rencanaKinerjaId := params.ByName("rencana_kinerja_id")
+
+	format := helper.FormatDokumen(request)
+	if format == helper.FormatJson {
+		format = helper.FormatPdf
+	}
+
+	dokumen, err := controller.LaporanService.KakDokumen(request.Context(), rencanaKinerjaId)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+	helper.WriteDokumen(writer, dokumen, format, "KAK_"+rencanaKinerjaId)
+}
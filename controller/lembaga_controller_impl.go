@@ -24,14 +24,13 @@ func (controller *LembagaControllerImpl) Create(writer http.ResponseWriter, requ
 	lembagaCreateRequest := lembaga.LembagaCreateRequest{}
 	helper.ReadFromRequestBody(request, &lembagaCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), lembagaCreateRequest) {
+		return
+	}
+
 	lembagaResponse, err := controller.LembagaService.Create(request.Context(), lembagaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -51,14 +50,13 @@ func (controller *LembagaControllerImpl) Update(writer http.ResponseWriter, requ
 
 	lembagaUpdateRequest.Id = lembagaId
 
+	if !helper.ValidateRequest(writer, request.Context(), lembagaUpdateRequest) {
+		return
+	}
+
 	lembagaResponse, err := controller.LembagaService.Update(request.Context(), lembagaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -88,12 +86,7 @@ func (controller *LembagaControllerImpl) FindById(writer http.ResponseWriter, re
 
 	lembagaResponse, err := controller.LembagaService.FindById(request.Context(), lembagaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -108,12 +101,7 @@ func (controller *LembagaControllerImpl) FindById(writer http.ResponseWriter, re
 func (controller *LembagaControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	lembagaResponse, err := controller.LembagaService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1,110 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/lockdata"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type LockDataControllerImpl struct {
+	LockDataService service.LockDataService
+}
+
+func NewLockDataControllerImpl(lockDataService service.LockDataService) *LockDataControllerImpl {
+	return &LockDataControllerImpl{
+		LockDataService: lockDataService,
+	}
+}
+
+// @Summary      Kunci dokumen perencanaan
+// @Description  Mengunci (jenis_data, kode_opd, tahun) sehingga data tidak bisa diubah. jenis_data: tujuan_opd, sasaran_opd, rencana_kinerja, renja_ranwal, renja_rankhir, renja_penetapan, rincian_belanja, pohon_kinerja_opd, renstra
+// @Tags         Lock Data
+// @Accept       json
+// @Produce      json
+// @Param        data body lockdata.LockDataRequest true "Lock Data"
+// @Success      200  {object}  web.WebResponse{data=lockdata.LockDataResponse}
+// @Failure      400  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /lock_data/lock [post]
+func (controller *LockDataControllerImpl) Lock(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	lockRequest := lockdata.LockDataRequest{}
+	helper.ReadFromRequestBody(request, &lockRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), lockRequest) {
+		return
+	}
+
+	lockResponse, err := controller.LockDataService.Lock(request.Context(), lockRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "Success Lock Data",
+		Data:   lockResponse,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary      Buka kunci dokumen perencanaan
+// @Tags         Lock Data
+// @Accept       json
+// @Produce      json
+// @Param        data body lockdata.LockDataRequest true "Lock Data"
+// @Success      200  {object}  web.WebResponse
+// @Failure      400  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /lock_data/unlock [post]
+func (controller *LockDataControllerImpl) Unlock(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	unlockRequest := lockdata.LockDataRequest{}
+	helper.ReadFromRequestBody(request, &unlockRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), unlockRequest) {
+		return
+	}
+
+	err := controller.LockDataService.Unlock(request.Context(), unlockRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "Success Unlock Data",
+		Data:   unlockRequest,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+// @Summary      Daftar dokumen yang dikunci
+// @Tags         Lock Data
+// @Produce      json
+// @Param        kode_opd  query  string  false  "Kode OPD"
+// @Param        tahun     query  string  false  "Tahun"
+// @Success      200  {object}  web.WebResponse{data=[]lockdata.LockDataResponse}
+// @Security     BearerAuth
+// @Router       /lock_data/findall [get]
+func (controller *LockDataControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	kodeOpd := request.URL.Query().Get("kode_opd")
+	tahun := request.URL.Query().Get("tahun")
+
+	lockResponses, err := controller.LockDataService.FindAll(request.Context(), kodeOpd, tahun)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "Success Find All Lock Data",
+		Data:   lockResponses,
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -26,14 +26,13 @@ func (controller *ManualIKControllerImpl) Create(writer http.ResponseWriter, req
 	manualIKCreateRequest := rencanakinerja.ManualIKCreateRequest{}
 	helper.ReadFromRequestBody(request, &manualIKCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), manualIKCreateRequest) {
+		return
+	}
+
 	manualIKResponse, err := controller.ManualIKService.Create(request.Context(), manualIKCreateRequest, indikatorId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -52,6 +51,10 @@ func (controller *ManualIKControllerImpl) Update(writer http.ResponseWriter, req
 	manualIKUpdateRequest := rencanakinerja.ManualIKUpdateRequest{}
 	helper.ReadFromRequestBody(request, &manualIKUpdateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), manualIKUpdateRequest) {
+		return
+	}
+
 	manualIKResponse, err := controller.ManualIKService.Update(request.Context(), manualIKUpdateRequest, indikatorId)
 	helper.PanicIfError(err)
 
@@ -85,12 +88,7 @@ func (controller *ManualIKControllerImpl) FindManualIKSasaranOpdByIndikatorId(wr
 
 	manualIKResponses, err := controller.ManualIKService.FindManualIKSasaranOpdByIndikatorId(request.Context(), indikatorId, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -14,4 +14,6 @@ type MatrixRenjaController interface {
 	UpsertBatchIndikatorRenjaRankhir(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpsertBatchIndikatorRenjaPenetapan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpsertAnggaran(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ExportXlsx(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ImportXlsx(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
@@ -36,12 +36,7 @@ func (controller *MatrixRenjaControllerImpl) GetRenjaRanwal(writer http.Response
 	jenisPagu := "renstra"
 	matrixRenjaResponses, err := controller.MatrixRenjaService.GetRenja(request.Context(), kodeOpd, tahun, jenisPagu)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -69,12 +64,7 @@ func (controller *MatrixRenjaControllerImpl) GetRenjaRankhir(writer http.Respons
 	tahun := params.ByName("tahun")
 	matrixRenjaResponses, err := controller.MatrixRenjaService.GetRenjaRankhir(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -103,12 +93,7 @@ func (controller *MatrixRenjaControllerImpl) GetRenjaPenetapan(writer http.Respo
 	jenisPagu := "penetapan"
 	matrixRenjaResponses, err := controller.MatrixRenjaService.GetRenjaPenetapan(request.Context(), kodeOpd, tahun, jenisPagu)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -136,14 +121,13 @@ func (controller *MatrixRenjaControllerImpl) UpsertBatchIndikatorRenjaRanwal(wri
 	for i := range BatchIndikatorRenjaRequest {
 		BatchIndikatorRenjaRequest[i].Jenis = "ranwal"
 	}
+	if !helper.ValidateRequest(writer, request.Context(), BatchIndikatorRenjaRequest) {
+		return
+	}
+
 	BatchIndikatorRenjaResponse, err := controller.MatrixRenjaService.UpsertBatchIndikatorRenja(request.Context(), BatchIndikatorRenjaRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -173,14 +157,13 @@ func (controller *MatrixRenjaControllerImpl) UpsertBatchIndikatorRenjaRankhir(wr
 		BatchIndikatorRenjaRequest[i].Jenis = "rankhir"
 	}
 
+	if !helper.ValidateRequest(writer, request.Context(), BatchIndikatorRenjaRequest) {
+		return
+	}
+
 	BatchIndikatorRenjaResponse, err := controller.MatrixRenjaService.UpsertBatchIndikatorRenja(request.Context(), BatchIndikatorRenjaRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -210,14 +193,13 @@ func (controller *MatrixRenjaControllerImpl) UpsertBatchIndikatorRenjaPenetapan(
 		BatchIndikatorRenjaRequest[i].Jenis = "penetapan"
 	}
 
+	if !helper.ValidateRequest(writer, request.Context(), BatchIndikatorRenjaRequest) {
+		return
+	}
+
 	BatchIndikatorRenjaResponse, err := controller.MatrixRenjaService.UpsertBatchIndikatorRenjaPenetapan(request.Context(), BatchIndikatorRenjaRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -243,14 +225,13 @@ func (controller *MatrixRenjaControllerImpl) UpsertAnggaran(writer http.Response
 	AnggaranRenjaRequest := programkegiatan.AnggaranRenjaRequest{}
 	helper.ReadFromRequestBody(request, &AnggaranRenjaRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), AnggaranRenjaRequest) {
+		return
+	}
+
 	AnggaranRenjaResponse, err := controller.MatrixRenjaService.UpsertAnggaran(request.Context(), AnggaranRenjaRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -261,3 +242,61 @@ func (controller *MatrixRenjaControllerImpl) UpsertAnggaran(writer http.Response
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
+
+// @Summary      Export Matrix Renja (xlsx)
+// @Description  Matrix renja ranwal, rankhir atau penetapan dalam format tabel renja standar.
+// @Tags         Matrix Renja
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        jenis     path  string  true  "ranwal, rankhir atau penetapan"
+// @Param        kode_opd  path  string  true  "Kode OPD"  example("1.01.1.01.0.00.01.0000")
+// @Param        tahun     path  string  true  "Tahun"     example("2025")
+// @Success      200  {file}    file
+// @Failure      400  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /matrix_renja/export/{jenis}/{kode_opd}/{tahun} [get]
+func (controller *MatrixRenjaControllerImpl) ExportXlsx(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	jenis := params.ByName("jenis")
+	kodeOpd := params.ByName("kode_opd")
+	tahun := params.ByName("tahun")
+
+	sheets, err := controller.MatrixRenjaService.ExportXlsx(request.Context(), jenis, kodeOpd, tahun)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+	helper.WriteXlsx(writer, sheets, "Matrix_Renja_"+jenis+"_"+kodeOpd+"_"+tahun)
+}
+
+// @Summary      Import Matrix Renja (xlsx)
+// @Description  Unggah file hasil export (form field "file"). Seluruh baris divalidasi lebih dulu; tanpa simpan=true hanya laporan validasi yang dikembalikan. Pagu subkegiatan hanya disimpan untuk jenis penetapan.
+// @Tags         Matrix Renja
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        jenis     path      string  true   "ranwal, rankhir atau penetapan"
+// @Param        kode_opd  path      string  true   "Kode OPD"  example("1.01.1.01.0.00.01.0000")
+// @Param        tahun     path      string  true   "Tahun"     example("2025")
+// @Param        simpan    query     bool    false  "Simpan bila seluruh baris valid"
+// @Param        file      formData  file    true   "File xlsx"
+// @Success      200  {object}  web.WebResponse{data=programkegiatan.MatrixImportResponse}
+// @Failure      422  {object}  web.WebResponse{data=programkegiatan.MatrixImportResponse}
+// @Security     BearerAuth
+// @Router       /matrix_renja/import/{jenis}/{kode_opd}/{tahun} [post]
+func (controller *MatrixRenjaControllerImpl) ImportXlsx(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	content, err := helper.ReadUploadFile(writer, request, "file", helper.MaksUkuranUpload)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+
+	jenis := params.ByName("jenis")
+	kodeOpd := params.ByName("kode_opd")
+	tahun := params.ByName("tahun")
+	simpan := request.URL.Query().Get("simpan") == "true"
+
+	laporan, err := controller.MatrixRenjaService.ImportXlsx(request.Context(), jenis, kodeOpd, tahun, content, simpan)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+	writeLaporanImportMatrix(writer, laporan)
+}
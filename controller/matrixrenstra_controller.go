@@ -11,4 +11,6 @@ type MatrixRenstraController interface {
 	DeleteIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpsertAnggaran(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	UpsertBatchIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ExportXlsx(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ImportXlsx(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
@@ -37,12 +37,7 @@ func (controller *MatrixRenstraControllerImpl) GetByKodeSubKegiatan(writer http.
 
 	matrixRenstraResponses, err := controller.MatrixRenstraService.GetByKodeSubKegiatan(request.Context(), kodeOpd, tahunAwal, tahunAkhir)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -70,12 +65,7 @@ func (controller *MatrixRenstraControllerImpl) DeleteIndikator(writer http.Respo
 
 	err := controller.MatrixRenstraService.DeleteIndikator(request.Context(), kodeIndikator)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -100,14 +90,13 @@ func (controller *MatrixRenstraControllerImpl) UpsertAnggaran(writer http.Respon
 	AnggaranRenstraRequest := programkegiatan.AnggaranRenstraRequest{}
 	helper.ReadFromRequestBody(request, &AnggaranRenstraRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), AnggaranRenstraRequest) {
+		return
+	}
+
 	AnggaranRenstraResponse, err := controller.MatrixRenstraService.UpsertAnggaran(request.Context(), AnggaranRenstraRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -131,14 +120,91 @@ func (controller *MatrixRenstraControllerImpl) UpsertAnggaran(writer http.Respon
 func (controller *MatrixRenstraControllerImpl) UpsertBatchIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	var requests []programkegiatan.IndikatorRenstraCreateRequest
 	helper.ReadFromRequestBody(request, &requests)
+	if !helper.ValidateRequest(writer, request.Context(), requests) {
+		return
+	}
+
 	resp, err := controller.MatrixRenstraService.UpsertBatchIndikator(request.Context(), requests)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code: http.StatusBadRequest, Status: "BAD REQUEST", Data: err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
 		Code: http.StatusOK, Status: "success upsert indikator renstra", Data: resp,
 	})
 }
+
+// @Summary      Export Matrix Renstra (xlsx)
+// @Description  Matrix renstra dalam format tabel renstra standar: indikator, target, satuan dan pagu per tahun.
+// @Tags         Matrix Renstra
+// @Produce      application/vnd.openxmlformats-officedocument.spreadsheetml.sheet
+// @Param        kode_opd     path   string  true  "Kode OPD"     example("1.01.1.01.0.00.01.0000")
+// @Param        tahun_awal   query  string  true  "Tahun Awal"   example("2025")
+// @Param        tahun_akhir  query  string  true  "Tahun Akhir"  example("2029")
+// @Success      200  {file}    file
+// @Failure      400  {object}  web.WebResponse
+// @Security     BearerAuth
+// @Router       /matrix_renstra/export/{kode_opd} [get]
+func (controller *MatrixRenstraControllerImpl) ExportXlsx(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	kodeOpd := params.ByName("kode_opd")
+	tahunAwal := request.URL.Query().Get("tahun_awal")
+	tahunAkhir := request.URL.Query().Get("tahun_akhir")
+
+	sheets, err := controller.MatrixRenstraService.ExportXlsx(request.Context(), kodeOpd, tahunAwal, tahunAkhir)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+	helper.WriteXlsx(writer, sheets, "Matrix_Renstra_"+kodeOpd+"_"+tahunAwal+"_"+tahunAkhir)
+}
+
+// @Summary      Import Matrix Renstra (xlsx)
+// @Description  Unggah file hasil export (form field "file"). Seluruh baris divalidasi lebih dulu; tanpa simpan=true hanya laporan validasi yang dikembalikan. Indikator yang dihapus dari spreadsheet ikut dihapus selama masih ada indikator lain pada kode dan tahun yang sama.
+// @Tags         Matrix Renstra
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        kode_opd     path      string  true   "Kode OPD"     example("1.01.1.01.0.00.01.0000")
+// @Param        tahun_awal   query     string  true   "Tahun Awal"   example("2025")
+// @Param        tahun_akhir  query     string  true   "Tahun Akhir"  example("2029")
+// @Param        simpan       query     bool    false  "Simpan bila seluruh baris valid"
+// @Param        file         formData  file    true   "File xlsx"
+// @Success      200  {object}  web.WebResponse{data=programkegiatan.MatrixImportResponse}
+// @Failure      422  {object}  web.WebResponse{data=programkegiatan.MatrixImportResponse}
+// @Security     BearerAuth
+// @Router       /matrix_renstra/import/{kode_opd} [post]
+func (controller *MatrixRenstraControllerImpl) ImportXlsx(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	content, err := helper.ReadUploadFile(writer, request, "file", helper.MaksUkuranUpload)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+
+	kodeOpd := params.ByName("kode_opd")
+	tahunAwal := request.URL.Query().Get("tahun_awal")
+	tahunAkhir := request.URL.Query().Get("tahun_akhir")
+	simpan := request.URL.Query().Get("simpan") == "true"
+
+	laporan, err := controller.MatrixRenstraService.ImportXlsx(request.Context(), kodeOpd, tahunAwal, tahunAkhir, content, simpan)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+	writeLaporanImportMatrix(writer, laporan)
+}
+
+// writeLaporanImportMatrix 422 bila ada baris yang tidak valid, dipakai juga oleh matrix renja
+func writeLaporanImportMatrix(writer http.ResponseWriter, laporan programkegiatan.MatrixImportResponse) {
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "validasi berhasil, kirim ulang dengan simpan=true untuk menyimpan",
+		Data:   laporan,
+	}
+	switch {
+	case !laporan.Valid:
+		webResponse.Code = http.StatusUnprocessableEntity
+		webResponse.Status = "terdapat kesalahan pada spreadsheet, tidak ada data yang disimpan"
+	case laporan.Disimpan:
+		webResponse.Status = "success import matrix"
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
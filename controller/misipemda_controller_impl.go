@@ -23,14 +23,13 @@ func (controller *MisiPemdaControllerImpl) Create(writer http.ResponseWriter, re
 	misiPemdaCreateRequest := visimisipemda.MisiPemdaCreateRequest{}
 	helper.ReadFromRequestBody(request, &misiPemdaCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), misiPemdaCreateRequest) {
+		return
+	}
+
 	misiPemdaResponse, err := controller.MisiPemdaService.Create(request.Context(), misiPemdaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -49,20 +48,19 @@ func (controller *MisiPemdaControllerImpl) Update(writer http.ResponseWriter, re
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	misiPemdaUpdateRequest.Id = idInt
 
+	if !helper.ValidateRequest(writer, request.Context(), misiPemdaUpdateRequest) {
+		return
+	}
+
 	misiPemdaResponse, err := controller.MisiPemdaService.Update(request.Context(), misiPemdaUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -79,18 +77,13 @@ func (controller *MisiPemdaControllerImpl) Delete(writer http.ResponseWriter, re
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	err = controller.MisiPemdaService.Delete(request.Context(), idInt)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -105,18 +98,13 @@ func (controller *MisiPemdaControllerImpl) FindById(writer http.ResponseWriter,
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	misiPemdaResponse, err := controller.MisiPemdaService.FindById(request.Context(), idInt)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -135,7 +123,7 @@ func (controller *MisiPemdaControllerImpl) FindAll(writer http.ResponseWriter, r
 
 	misiPemdaResponses, err := controller.MisiPemdaService.FindAll(request.Context(), tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -152,17 +140,12 @@ func (controller *MisiPemdaControllerImpl) FindByIdVisi(writer http.ResponseWrit
 
 	idVisi, err := strconv.Atoi(idVisistr)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	misiPemdaResponses, err := controller.MisiPemdaService.FindByIdVisi(request.Context(), idVisi)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
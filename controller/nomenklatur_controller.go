@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type NomenklaturController interface {
+	Import(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAllVersi(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindVersiById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
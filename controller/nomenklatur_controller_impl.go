@@ -0,0 +1,113 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type NomenklaturControllerImpl struct {
+	NomenklaturService service.NomenklaturService
+}
+
+func NewNomenklaturControllerImpl(nomenklaturService service.NomenklaturService) *NomenklaturControllerImpl {
+	return &NomenklaturControllerImpl{
+		NomenklaturService: nomenklaturService,
+	}
+}
+
+// @Summary      Import nomenklatur Kepmendagri
+// @Description  Upload file referensi (csv/xlsx) berisi kolom kode dan nomenklatur urusan sampai subkegiatan. Tanpa simpan=true hanya menampilkan diff terhadap master. Kode yang hilang dari file dihapus, kecuali masih dipakai rekin atau subkegiatan terpilih sehingga ditandai deprecated.
+// @Tags         Nomenklatur
+// @Accept       multipart/form-data
+// @Produce      json
+// @Param        file    formData  file    true   "File referensi nomenklatur"
+// @Param        versi   query     string  false  "Versi referensi, wajib saat simpan=true"
+// @Param        simpan  query     bool    false  "true untuk menerapkan perubahan"
+// @Success      200  {object}  web.WebResponse{data=nomenklatur.NomenklaturImportResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Failure      409  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /nomenklatur/import [post]
+func (controller *NomenklaturControllerImpl) Import(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	content, err := helper.ReadUploadFile(writer, request, "file", helper.MaksUkuranUpload)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+	namaFile := ""
+	if files := request.MultipartForm.File["file"]; len(files) > 0 {
+		namaFile = files[0].Filename
+	}
+
+	query := request.URL.Query()
+	simpan := query.Get("simpan") == "true"
+	importResponse, err := controller.NomenklaturService.Import(request.Context(), query.Get("versi"), namaFile, content, simpan)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	status := "preview import nomenklatur, kirim ulang dengan simpan=true untuk menerapkan"
+	if importResponse.Disimpan {
+		status = "success import nomenklatur"
+	}
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: status,
+		Data:   importResponse,
+	})
+}
+
+// @Summary      Riwayat versi nomenklatur
+// @Tags         Nomenklatur
+// @Produce      json
+// @Success      200  {object}  web.WebResponse{data=[]nomenklatur.NomenklaturVersiResponse}
+// @Security     BearerAuth
+// @Router       /nomenklatur/versi [get]
+func (controller *NomenklaturControllerImpl) FindAllVersi(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	versiResponse, err := controller.NomenklaturService.FindAllVersi(request.Context())
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get versi nomenklatur",
+		Data:   versiResponse,
+	})
+}
+
+// @Summary      Detail versi nomenklatur
+// @Description  Versi beserta daftar perubahan master yang diterapkan.
+// @Tags         Nomenklatur
+// @Produce      json
+// @Param        id   path  int  true  "ID versi"
+// @Success      200  {object}  web.WebResponse{data=nomenklatur.NomenklaturVersiResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /nomenklatur/versi/{id} [get]
+func (controller *NomenklaturControllerImpl) FindVersiById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.ParseInt(params.ByName("id"), 10, 64)
+	if err != nil {
+		helper.WriteError(writer, http.StatusBadRequest, "id versi tidak valid")
+		return
+	}
+
+	versiResponse, err := controller.NomenklaturService.FindVersiById(request.Context(), id)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get versi nomenklatur",
+		Data:   versiResponse,
+	})
+}
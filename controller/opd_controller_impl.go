@@ -25,13 +25,13 @@ func (controller *OpdControllerImpl) Create(writer http.ResponseWriter, request
 	opdCreateRequest := opdmaster.OpdCreateRequest{}
 	helper.ReadFromRequestBody(request, &opdCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), opdCreateRequest) {
+		return
+	}
+
 	opdResponse, err := controller.OpdService.Create(request.Context(), opdCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   500,
-			Status: "error",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -51,6 +51,10 @@ func (controller *OpdControllerImpl) Update(writer http.ResponseWriter, request
 	opdId := params.ByName("opdId")
 	opdUpdateRequest.Id = opdId
 
+	if !helper.ValidateRequest(writer, request.Context(), opdUpdateRequest) {
+		return
+	}
+
 	opdResponse, err := controller.OpdService.Update(request.Context(), opdUpdateRequest)
 	if err != nil {
 		helper.WriteToResponseBody(writer, err)
@@ -88,11 +92,7 @@ func (controller *OpdControllerImpl) FindById(writer http.ResponseWriter, reques
 
 	opdResponse, err := controller.OpdService.FindById(request.Context(), opdId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   500,
-			Status: "error",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -107,11 +107,7 @@ func (controller *OpdControllerImpl) FindById(writer http.ResponseWriter, reques
 func (controller *OpdControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	opdResponses, err := controller.OpdService.FindAll(request.Context())
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   500,
-			Status: "error",
-			Data:   "Gagal mengambil data OPD. Silakan coba lagi.",
-		})
+		helper.WriteError(writer, http.StatusInternalServerError, "Gagal mengambil data OPD. Silakan coba lagi.")
 		return
 	}
 
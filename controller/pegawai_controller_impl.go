@@ -24,14 +24,13 @@ func (controller *PegawaiControllerImpl) Create(writer http.ResponseWriter, requ
 	pegawaiCreateRequest := pegawai.PegawaiCreateRequest{}
 	helper.ReadFromRequestBody(request, &pegawaiCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), pegawaiCreateRequest) {
+		return
+	}
+
 	pegawaiResponse, err := controller.PegawaiService.Create(request.Context(), pegawaiCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -51,23 +50,13 @@ func (controller *PegawaiControllerImpl) Update(writer http.ResponseWriter, requ
 
 	pegawaiUpdateRequest.Id = pegawaiId
 
+	if !helper.ValidateRequest(writer, request.Context(), pegawaiUpdateRequest) {
+		return
+	}
+
 	pegawaiResponse, err := controller.PegawaiService.Update(request.Context(), pegawaiUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-
-		if err.Error() == "INTERNAL_SERVER_ERROR" {
-			webResponse.Code = 500
-			webResponse.Status = "Internal Server Error"
-		} else if err.Error() == "BAD_REQUEST" {
-			webResponse.Code = 400
-			webResponse.Status = "Bad Request"
-		}
-
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -96,12 +85,7 @@ func (controller *PegawaiControllerImpl) FindById(writer http.ResponseWriter, re
 
 	pegawaiResponse, err := controller.PegawaiService.FindById(request.Context(), pegawaiId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -118,12 +102,7 @@ func (controller *PegawaiControllerImpl) FindAll(writer http.ResponseWriter, req
 	nip := request.URL.Query().Get("nip")
 	pegawaiResponses, err := controller.PegawaiService.FindAll(request.Context(), kodeOpd, nip)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -139,14 +118,13 @@ func (controller *PegawaiControllerImpl) TambahJabatanPegawai(writer http.Respon
 	tambahJabatanRequest := pegawai.TambahJabatanRequest{}
 	helper.ReadFromRequestBody(request, &tambahJabatanRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), tambahJabatanRequest) {
+		return
+	}
+
 	pegawaiResponse, err := controller.PegawaiService.TambahJabatan(request.Context(), tambahJabatanRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
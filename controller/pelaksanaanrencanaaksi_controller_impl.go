@@ -27,24 +27,18 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) Create(writer http.Respo
 	// Mengambil rencanaAksiId dari query URL
 	rencanaAksiId := params.ByName("rencanaAksiId")
 	if rencanaAksiId == "" {
-		webResponse := web.WebPelaksanaanRencanaAksiResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "rencanaAksiId tidak boleh kosong",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "rencanaAksiId tidak boleh kosong")
 		return
 	}
 	createRequest.RencanaAksiId = rencanaAksiId
 
+	if !helper.ValidateRequest(writer, request.Context(), createRequest) {
+		return
+	}
+
 	response, err := controller.PelaksanaanRencanaAksiService.Create(request.Context(), createRequest)
 	if err != nil {
-		webResponse := web.WebPelaksanaanRencanaAksiResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -62,23 +56,13 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) Update(writer http.Respo
 	updateRequest.Id = params.ByName("pelaksanaanRencanaAksiId")
 	helper.ReadFromRequestBody(request, &updateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), updateRequest) {
+		return
+	}
+
 	response, err := controller.PelaksanaanRencanaAksiService.Update(request.Context(), updateRequest)
 	if err != nil {
-		if customErr, ok := err.(*web.CustomError); ok {
-			webResponse := web.WebPelaksanaanRencanaAksiResponse{
-				Code:   customErr.Code,
-				Status: http.StatusText(customErr.Code),
-				Data:   customErr.Message,
-			}
-			helper.WriteToResponseBody(writer, webResponse)
-		} else {
-			webResponse := web.WebPelaksanaanRencanaAksiResponse{
-				Code:   http.StatusInternalServerError,
-				Status: "INTERNAL SERVER ERROR",
-				Data:   err.Error(),
-			}
-			helper.WriteToResponseBody(writer, webResponse)
-		}
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -96,12 +80,7 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) FindById(writer http.Res
 
 	response, err := controller.PelaksanaanRencanaAksiService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebPelaksanaanRencanaAksiResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -119,12 +98,7 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) Delete(writer http.Respo
 
 	err := controller.PelaksanaanRencanaAksiService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebPelaksanaanRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -142,12 +116,7 @@ func (controller *PelaksanaanRencanaAksiControllerImpl) FindByRencanaAksiId(writ
 
 	responses, err := controller.PelaksanaanRencanaAksiService.FindByRencanaAksiId(request.Context(), rencanaAksiId)
 	if err != nil {
-		webResponse := web.WebPelaksanaanRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -23,14 +23,13 @@ func (controller *PeriodeControllerImpl) Create(writer http.ResponseWriter, requ
 	periodeCreateRequest := periodetahun.PeriodeCreateRequest{}
 	helper.ReadFromRequestBody(request, &periodeCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), periodeCreateRequest) {
+		return
+	}
+
 	periodeResponse, err := controller.PeriodeService.Create(request.Context(), periodeCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -48,14 +47,13 @@ func (controller *PeriodeControllerImpl) Update(writer http.ResponseWriter, requ
 	id := params.ByName("id")
 	periodeUpdateRequest.Id, _ = strconv.Atoi(id)
 
+	if !helper.ValidateRequest(writer, request.Context(), periodeUpdateRequest) {
+		return
+	}
+
 	periodeResponse, err := controller.PeriodeService.Update(request.Context(), periodeUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -71,12 +69,7 @@ func (controller *PeriodeControllerImpl) FindByTahun(writer http.ResponseWriter,
 	tahun := params.ByName("tahun")
 	periodeResponse, err := controller.PeriodeService.FindByTahun(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed find data periode",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -91,12 +84,7 @@ func (controller *PeriodeControllerImpl) FindAll(writer http.ResponseWriter, req
 	jenis_periode := request.URL.Query().Get("jenis_periode")
 	periodeResponse, err := controller.PeriodeService.FindAll(request.Context(), jenis_periode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed find data periode",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -113,12 +101,7 @@ func (controller *PeriodeControllerImpl) FindById(writer http.ResponseWriter, re
 	idInt, _ := strconv.Atoi(id)
 	periodeResponse, err := controller.PeriodeService.FindById(request.Context(), idInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed find data periode",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -135,12 +118,7 @@ func (controller *PeriodeControllerImpl) Delete(writer http.ResponseWriter, requ
 	idInt, _ := strconv.Atoi(id)
 	err := controller.PeriodeService.Delete(request.Context(), idInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed delete data periode",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
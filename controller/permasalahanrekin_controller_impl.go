@@ -25,14 +25,13 @@ func (controller *PermasalahanRekinControllerImpl) Create(writer http.ResponseWr
 	permasalahanRekinCreateRequest := permasalahan.PermasalahanRekinCreateRequest{}
 	helper.ReadFromRequestBody(request, &permasalahanRekinCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), permasalahanRekinCreateRequest) {
+		return
+	}
+
 	permasalahanRekinResponse, err := controller.PermasalahanRekinService.Create(request.Context(), permasalahanRekinCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -51,24 +50,18 @@ func (controller *PermasalahanRekinControllerImpl) Update(writer http.ResponseWr
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	permasalahanRekinUpdateRequest.Id = idInt
 
+	if !helper.ValidateRequest(writer, request.Context(), permasalahanRekinUpdateRequest) {
+		return
+	}
+
 	permasalahanRekinResponse, err := controller.PermasalahanRekinService.Update(request.Context(), permasalahanRekinUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -85,23 +78,13 @@ func (controller *PermasalahanRekinControllerImpl) Delete(writer http.ResponseWr
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	err = controller.PermasalahanRekinService.Delete(request.Context(), idInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -117,23 +100,13 @@ func (controller *PermasalahanRekinControllerImpl) FindById(writer http.Response
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	permasalahanRekinResponse, err := controller.PermasalahanRekinService.FindById(request.Context(), idInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -155,12 +128,7 @@ func (controller *PermasalahanRekinControllerImpl) FindAll(writer http.ResponseW
 
 	permasalahanRekinResponse, err := controller.PermasalahanRekinService.FindAll(request.Context(), rekinIdPtr)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -9,4 +9,6 @@ type PkController interface {
 	FindAllPkOpdTahunan(w http.ResponseWriter, r *http.Request, params httprouter.Params)
 	HubungkanRekin(w http.ResponseWriter, r *http.Request, params httprouter.Params)
 	HubungkanAtasan(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	DownloadPkOpd(w http.ResponseWriter, r *http.Request, params httprouter.Params)
+	DownloadPkPegawai(w http.ResponseWriter, r *http.Request, params httprouter.Params)
 }
@@ -27,34 +27,19 @@ func (controller *PkControllerImpl) FindAllPkOpdTahunan(w http.ResponseWriter, r
 	tahunStr := params.ByName("tahun")
 
 	if kodeOpd == "" {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "Kode OPD harus terisi",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(w, webResponse)
+		helper.WriteError(w, http.StatusBadRequest, "Kode OPD harus terisi")
 		return
 	}
 
 	tahun, err := strconv.Atoi(tahunStr)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "Tahun tidak sesuai",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(w, webResponse)
+		helper.WriteErrorResponse(w, err, http.StatusBadRequest)
 		return
 	}
 
 	response, err := controller.pkOpdService.FindByKodeOpdTahun(r.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "[ERROR] PK OPD sedang tidak dapat diakses",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(w, webResponse)
+		helper.WriteErrorResponse(w, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -71,14 +56,13 @@ func (controller *PkControllerImpl) HubungkanRekin(w http.ResponseWriter, r *htt
 	hubungkanRekinRequest := pkopd.PkOpdRequest{}
 	helper.ReadFromRequestBody(r, &hubungkanRekinRequest)
 
+	if !helper.ValidateRequest(w, r.Context(), hubungkanRekinRequest) {
+		return
+	}
+
 	hubungkanResponse, err := controller.pkOpdService.HubungkanRekin(r.Context(), hubungkanRekinRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "[ERROR] Terjadi kesalahan di hubungkan rekin",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(w, webResponse)
+		helper.WriteErrorResponse(w, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -95,14 +79,13 @@ func (controller *PkControllerImpl) HubungkanAtasan(w http.ResponseWriter, r *ht
 	hubungkanAtasanRequest := pkopd.HubungkanAtasanRequest{}
 	helper.ReadFromRequestBody(r, &hubungkanAtasanRequest)
 
+	if !helper.ValidateRequest(w, r.Context(), hubungkanAtasanRequest) {
+		return
+	}
+
 	hubungkanResponse, err := controller.pkOpdService.HubungkanAtasan(r.Context(), hubungkanAtasanRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "[ERROR] Terjadi kesalahan di hubungkan atasan",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(w, webResponse)
+		helper.WriteErrorResponse(w, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -114,3 +97,37 @@ func (controller *PkControllerImpl) HubungkanAtasan(w http.ResponseWriter, r *ht
 
 	helper.WriteToResponseBody(w, webResponse)
 }
+
+// DownloadPkOpd naskah PK seluruh pegawai OPD dalam satu PDF
+func (controller *PkControllerImpl) DownloadPkOpd(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	controller.downloadPk(w, r, params.ByName("kode_opd"), params.ByName("tahun"), "")
+}
+
+func (controller *PkControllerImpl) DownloadPkPegawai(w http.ResponseWriter, r *http.Request, params httprouter.Params) {
+	controller.downloadPk(w, r, params.ByName("kode_opd"), params.ByName("tahun"), params.ByName("nip"))
+}
+
+func (controller *PkControllerImpl) downloadPk(w http.ResponseWriter, r *http.Request, kodeOpd string, tahunStr string, nip string) {
+	if kodeOpd == "" {
+		helper.WriteError(w, http.StatusBadRequest, "Kode OPD harus terisi")
+		return
+	}
+
+	tahun, err := strconv.Atoi(tahunStr)
+	if err != nil {
+		helper.WriteErrorResponse(w, err, http.StatusBadRequest)
+		return
+	}
+
+	dokumen, err := controller.pkOpdService.DokumenPk(r.Context(), kodeOpd, tahun, nip)
+	if err != nil {
+		helper.WriteErrorResponse(w, err, http.StatusInternalServerError)
+		return
+	}
+
+	namaFile := "PK_" + kodeOpd + "_" + tahunStr
+	if nip != "" {
+		namaFile += "_" + nip
+	}
+	helper.WriteDokumen(w, dokumen, helper.FormatPdf, namaFile)
+}
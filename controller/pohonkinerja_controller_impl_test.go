@@ -0,0 +1,55 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/julienschmidt/httprouter"
+)
+
+func pasangValidatorTest(t *testing.T) {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(helper.JsonTagName)
+	// tahun_periode asli memeriksa tb_tahun_periode, cukup format tahun di test
+	for _, tag := range []string{"tahun", "tahun_periode"} {
+		if err := validate.RegisterValidation(tag, helper.ValidateTahun); err != nil {
+			t.Fatal(err)
+		}
+	}
+	helper.SetRequestValidator(validate)
+	t.Cleanup(func() { helper.SetRequestValidator(nil) })
+}
+
+func TestCreatePohonKinerjaBodyKosong(t *testing.T) {
+	pasangValidatorTest(t)
+
+	// service sengaja nil: request yang lolos validasi akan panic
+	tests := []struct {
+		name   string
+		handle httprouter.Handle
+	}{
+		{name: "pohon kinerja opd", handle: NewPohonKinerjaOpdControllerImpl(nil).Create},
+		{name: "pohon kinerja admin", handle: NewPohonKinerjaAdminControllerImpl(nil).Create},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			request := httptest.NewRequest(http.MethodPost, "/pohon_kinerja/create", strings.NewReader(`{}`))
+			tt.handle(recorder, request, nil)
+
+			if recorder.Code != http.StatusBadRequest {
+				t.Fatalf("status = %d, expected %d", recorder.Code, http.StatusBadRequest)
+			}
+			for _, field := range []string{"nama_pohon", "jenis_pohon", "tahun"} {
+				if !strings.Contains(recorder.Body.String(), `"field":"`+field+`"`) {
+					t.Errorf("body %s tidak menandai field %s", recorder.Body.String(), field)
+				}
+			}
+		})
+	}
+}
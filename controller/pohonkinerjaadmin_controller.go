@@ -14,6 +14,7 @@ type PohonKinerjaAdminController interface {
 	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindSubTematik(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindPokinAdminByIdHierarki(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ExportTematik(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	CreateStrategicAdmin(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindPokinByTematik(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindPokinByStrategic(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
@@ -5,6 +5,7 @@ import (
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/service"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -25,14 +26,13 @@ func (controller *PohonKinerjaAdminControllerImpl) Create(writer http.ResponseWr
 	helper.ReadFromRequestBody(request, &pohonKinerjaCreateRequest)
 
 	// Panggil service create
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaCreateRequest) {
+		return
+	}
+
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.Create(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -56,14 +56,13 @@ func (controller *PohonKinerjaAdminControllerImpl) Update(writer http.ResponseWr
 	pohonKinerjaUpdateRequest.Id, _ = strconv.Atoi(pohonKinerjaId)
 
 	// Panggil service update
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaUpdateRequest) {
+		return
+	}
+
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.Update(request.Context(), pohonKinerjaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -82,24 +81,14 @@ func (controller *PohonKinerjaAdminControllerImpl) Delete(writer http.ResponseWr
 	pohonKinerjaId := params.ByName("pohonKinerjaId")
 	id, err := strconv.Atoi(pohonKinerjaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "ID tidak valid",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID tidak valid")
 		return
 	}
 
 	// Panggil service delete
 	err = controller.pohonKinerjaAdminService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -118,24 +107,14 @@ func (controller *PohonKinerjaAdminControllerImpl) FindById(writer http.Response
 	pohonKinerjaId := params.ByName("id")
 	id, err := strconv.Atoi(pohonKinerjaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "ID tidak valid",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID tidak valid")
 		return
 	}
 
 	// Panggil service findById
 	result, err := controller.pohonKinerjaAdminService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -155,12 +134,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindAll(writer http.ResponseW
 	// Panggil service findAll
 	result, err := controller.pohonKinerjaAdminService.FindAll(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -180,12 +154,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindSubTematik(writer http.Re
 	// Panggil service findAll
 	result, err := controller.pohonKinerjaAdminService.FindSubTematik(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -204,24 +173,14 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinAdminByIdHierarki(wr
 	idPokin := params.ByName("idPokin")
 	id, err := strconv.Atoi(idPokin)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	// Panggil service findAll
 	result, err := controller.pohonKinerjaAdminService.FindPokinAdminByIdHierarki(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -235,19 +194,46 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinAdminByIdHierarki(wr
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
+// @Summary      Export Graf Pohon Kinerja Tematik
+// @Description  Merender pohon kinerja tematik beserta indikator dan pelaksana, diwarnai per jenis pohon. Format svg (default), png, graphml atau json (node dan edge).
+// @Tags         Pohon Kinerja Admin
+// @Produce      image/svg+xml
+// @Produce      image/png
+// @Param        idPokin  path   int     true   "ID Pohon Kinerja Tematik"
+// @Param        format   query  string  false  "svg (default), png, graphml atau json"
+// @Success      200
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /pohon_kinerja_admin/tematik/{idPokin}/export [get]
+func (controller *PohonKinerjaAdminControllerImpl) ExportTematik(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("idPokin"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, web.NewBadRequestError("id pohon kinerja tidak valid"), http.StatusBadRequest)
+		return
+	}
+
+	tematik, err := controller.pohonKinerjaAdminService.FindPokinAdminByIdHierarki(request.Context(), id)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	graf := helper.NewGrafPokinTematik("Pohon Kinerja "+tematik.Tema, tematik)
+	helper.WriteGrafPokin(writer, graf, helper.FormatGraf(request), fmt.Sprintf("Pokin_Tematik_%d", id))
+}
+
 func (controller *PohonKinerjaAdminControllerImpl) CreateStrategicAdmin(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	pohonKinerjaCreateRequest := pohonkinerja.PohonKinerjaAdminStrategicCreateRequest{}
 	helper.ReadFromRequestBody(request, &pohonKinerjaCreateRequest)
 
 	// Panggil service create
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaCreateRequest) {
+		return
+	}
+
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.CreateStrategicAdmin(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -266,12 +252,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByTematik(writer htt
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByTematik(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -290,12 +271,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByStrategic(writer h
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByStrategic(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -314,12 +290,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByTactical(writer ht
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByTactical(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -338,12 +309,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByOperational(writer
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByOperational(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -362,12 +328,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByStatus(writer http
 
 	pokinResponse, err := controller.pohonKinerjaAdminService.FindPokinByStatus(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -385,14 +346,13 @@ func (controller *PohonKinerjaAdminControllerImpl) CloneStrategiFromPemda(writer
 	helper.ReadFromRequestBody(request, &pohonKinerjaCreateRequest)
 
 	// Panggil service create
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaCreateRequest) {
+		return
+	}
+
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.CloneStrategiFromPemda(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -413,24 +373,18 @@ func (controller *PohonKinerjaAdminControllerImpl) UpdatePokinStatusTolak(writer
 	pohonKinerjaId := params.ByName("pohonKinerjaId")
 	id, err := strconv.Atoi(pohonKinerjaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 	pohonKinerjaUpdateRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaUpdateRequest) {
+		return
+	}
+
 	err = controller.pohonKinerjaAdminService.TolakPokin(request.Context(), pohonKinerjaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -448,14 +402,13 @@ func (controller *PohonKinerjaAdminControllerImpl) CrosscuttingOpd(writer http.R
 	helper.ReadFromRequestBody(request, &pohonKinerjaCreateRequest)
 
 	// Panggil service create
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaCreateRequest) {
+		return
+	}
+
 	pohonKinerjaResponse, err := controller.pohonKinerjaAdminService.CrosscuttingOpd(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -475,12 +428,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinByCrosscuttingStatus
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinByCrosscuttingStatus(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -498,12 +446,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinFromPemda(writer htt
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinFromPemda(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid Level Pohon format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid Level Pohon format")
 		return
 	}
 
@@ -520,12 +463,7 @@ func (controller *PohonKinerjaAdminControllerImpl) SetujuiCrosscutting(writer ht
 	pohonKinerjaId := params.ByName("pohonKinerjaId")
 	id, err := strconv.Atoi(pohonKinerjaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 
@@ -536,12 +474,7 @@ func (controller *PohonKinerjaAdminControllerImpl) SetujuiCrosscutting(writer ht
 	// Panggil service
 	err = controller.pohonKinerjaAdminService.SetujuiCrosscutting(request.Context(), pohonKinerjaRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -558,27 +491,17 @@ func (controller *PohonKinerjaAdminControllerImpl) TolakCrosscutting(writer http
 	pohonKinerjaId := params.ByName("pohonKinerjaId")
 	id, err := strconv.Atoi(pohonKinerjaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 
-	pohonKinerjaRequest := pohonkinerja.PohonKinerjaAdminTolakRequest{
-		Id: id,
-	}
+	pohonKinerjaRequest := pohonkinerja.PohonKinerjaAdminTolakRequest{}
+	helper.ReadFromRequestBody(request, &pohonKinerjaRequest)
+	pohonKinerjaRequest.Id = id
 
 	err = controller.pohonKinerjaAdminService.TolakCrosscutting(request.Context(), pohonKinerjaRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -609,23 +532,13 @@ func (controller *PohonKinerjaAdminControllerImpl) FindPokinFromOpd(writer http.
 	tahun := params.ByName("tahun")
 	levelPohon, err := strconv.Atoi(params.ByName("level_pohon"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid Level Pohon format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid Level Pohon format")
 		return
 	}
 
 	result, err := controller.pohonKinerjaAdminService.FindPokinFromOpd(request.Context(), kodeOpd, tahun, levelPohon)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -648,14 +561,13 @@ func (controller *PohonKinerjaAdminControllerImpl) AktiforNonAktifTematik(writer
 
 	tematikRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), tematikRequest) {
+		return
+	}
+
 	message, err := controller.pohonKinerjaAdminService.AktiforNonAktifTematik(request.Context(), tematikRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   message,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -673,12 +585,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindListOpdAllTematik(writer
 
 	result, err := controller.pohonKinerjaAdminService.FindListOpdAllTematik(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -696,12 +603,7 @@ func (controller *PohonKinerjaAdminControllerImpl) RekapIntermediate(writer http
 
 	result, err := controller.pohonKinerjaAdminService.RekapIntermediate(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -719,12 +621,7 @@ func (controller *PohonKinerjaAdminControllerImpl) FindAllTematik(writer http.Re
 
 	result, err := controller.pohonKinerjaAdminService.FindAllTematik(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -747,14 +644,13 @@ func (controller *PohonKinerjaAdminControllerImpl) ClonePokinPemda(writer http.R
 
 	pohonKinerjaCloneRequest.IdPokinSource = id
 
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaCloneRequest) {
+		return
+	}
+
 	result, err := controller.pohonKinerjaAdminService.ClonePokinPemda(request.Context(), pohonKinerjaCloneRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -6,6 +6,7 @@ import (
 	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/service"
+	"fmt"
 	"net/http"
 	"strconv"
 
@@ -26,14 +27,13 @@ func (controller *PohonKinerjaOpdControllerImpl) Create(writer http.ResponseWrit
 	pohonKinerjaCreateRequest := pohonkinerja.PohonKinerjaCreateRequest{}
 	helper.ReadFromRequestBody(request, &pohonKinerjaCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaCreateRequest) {
+		return
+	}
+
 	response, err := controller.PohonKinerjaOpdService.Create(request.Context(), pohonKinerjaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -52,26 +52,19 @@ func (controller *PohonKinerjaOpdControllerImpl) Update(writer http.ResponseWrit
 
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID harus berupa angka",
-		}
-		writer.WriteHeader(http.StatusBadRequest)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 	pohonKinerjaUpdateRequest.Id = id
 
 	// Panggil service Update
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaUpdateRequest) {
+		return
+	}
+
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.Update(request.Context(), pohonKinerjaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -89,23 +82,13 @@ func (controller *PohonKinerjaOpdControllerImpl) Delete(writer http.ResponseWrit
 	idStr := params.ByName("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID harus berupa angka",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 
 	err = controller.PohonKinerjaOpdService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -124,26 +107,14 @@ func (controller *PohonKinerjaOpdControllerImpl) FindById(writer http.ResponseWr
 	// Dapatkan id dari params
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID harus berupa angka",
-		}
-		writer.WriteHeader(http.StatusBadRequest)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 
 	// Panggil service FindById
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		writer.WriteHeader(http.StatusNotFound)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -198,13 +169,7 @@ func (controller *PohonKinerjaOpdControllerImpl) FindAll(writer http.ResponseWri
 		}
 
 		// Untuk error lainnya
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		writer.WriteHeader(http.StatusNotFound)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -217,30 +182,49 @@ func (controller *PohonKinerjaOpdControllerImpl) FindAll(writer http.ResponseWri
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
+// @Summary      Export Graf Pohon Kinerja Opd
+// @Description  Merender pohon kinerja OPD (strategic, tactical, operational) beserta indikator dan pelaksana. Format svg (default), png, graphml atau json (node dan edge).
+// @Tags         Pohon Kinerja Opd
+// @Produce      image/svg+xml
+// @Produce      image/png
+// @Param        kode_opd  path   string  true   "Kode OPD"
+// @Param        tahun     path   string  true   "Tahun"
+// @Param        format    query  string  false  "svg (default), png, graphml atau json"
+// @Success      200
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /pohon_kinerja_opd/findall/{kode_opd}/{tahun}/export [get]
+func (controller *PohonKinerjaOpdControllerImpl) ExportGraf(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	kodeOpd := params.ByName("kode_opd")
+	tahun := params.ByName("tahun")
+
+	pohonKinerja, err := controller.PohonKinerjaOpdService.FindAll(request.Context(), kodeOpd, tahun)
+	if err == sql.ErrNoRows {
+		helper.WriteErrorResponse(writer, web.NewNotFoundError("pohon kinerja opd tidak ditemukan"), http.StatusNotFound)
+		return
+	}
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	judul := fmt.Sprintf("Pohon Kinerja %s Tahun %s", pohonKinerja.NamaOpd, tahun)
+	graf := helper.NewGrafPokinOpd(judul, pohonKinerja.Strategics)
+	helper.WriteGrafPokin(writer, graf, helper.FormatGraf(request), "Pokin_"+kodeOpd+"_"+tahun)
+}
+
 func (controller *PohonKinerjaOpdControllerImpl) FindStrategicNoParent(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	kodeOpd := params.ByName("kode_opd")
 	tahun := params.ByName("tahun")
 
 	if kodeOpd == "" || tahun == "" {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "kode_opd dan tahun harus diisi",
-		}
-		writer.WriteHeader(http.StatusBadRequest)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "kode_opd dan tahun harus diisi")
 		return
 	}
 
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.FindStrategicNoParent(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		writer.WriteHeader(http.StatusNotFound)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -255,13 +239,7 @@ func (controller *PohonKinerjaOpdControllerImpl) FindStrategicNoParent(writer ht
 func (controller *PohonKinerjaOpdControllerImpl) DeletePelaksana(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	err := controller.PohonKinerjaOpdService.DeletePelaksana(request.Context(), params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		writer.WriteHeader(http.StatusInternalServerError)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -279,36 +257,18 @@ func (controller *PohonKinerjaOpdControllerImpl) FindPokinByPelaksana(writer htt
 
 	// Validasi parameter
 	if pelaksanaId == "" {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID Pegawai harus diisi",
-		}
-		writer.WriteHeader(http.StatusBadRequest)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID Pegawai harus diisi")
 		return
 	}
 
 	if tahun == "" {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "Parameter tahun harus diisi",
-		}
-		writer.WriteHeader(http.StatusBadRequest)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Parameter tahun harus diisi")
 		return
 	}
 
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.FindPokinByPelaksana(request.Context(), pelaksanaId, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		writer.WriteHeader(http.StatusNotFound)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -324,24 +284,13 @@ func (controller *PohonKinerjaOpdControllerImpl) DeletePokinPemdaInOpd(writer ht
 	pokinId := params.ByName("id")
 	pokinIdInt, err := strconv.Atoi(pokinId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	err = controller.PohonKinerjaOpdService.DeletePokinPemdaInOpd(request.Context(), pokinIdInt)
 	if err != nil {
-
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -362,24 +311,18 @@ func (controller *PohonKinerjaOpdControllerImpl) UpdateParent(writer http.Respon
 
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID harus berupa angka",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 	pohonKinerjaUpdateParentRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaUpdateParentRequest) {
+		return
+	}
+
 	_, err = controller.PohonKinerjaOpdService.UpdateParent(request.Context(), pohonKinerjaUpdateParentRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -394,23 +337,13 @@ func (controller *PohonKinerjaOpdControllerImpl) UpdateParent(writer http.Respon
 func (controller *PohonKinerjaOpdControllerImpl) FindidPokinWithAllTema(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID harus berupa angka",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.FindidPokinWithAllTema(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -422,52 +355,13 @@ func (controller *PohonKinerjaOpdControllerImpl) FindidPokinWithAllTema(writer h
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
-// @Summary      Clone Pohon Kinerja opd
-// @Description  Melakukan cloning pohon kinerja dari tahun sumber ke tahun tujuan untuk OPD tertentu.
-// @Tags         Clone Pohon Kinerja Opd
-// @Accept       json
-// @Produce      json
-// @Param        pohon_kinerja_clone_request  body     pohonkinerja.PohonKinerjaCloneRequest  true  "Data untuk cloning pohon kinerja"
-// @Success      200  {object}  web.WebResponse{data=string}
-// @Failure      400  {object}  web.WebResponse
-// @Security     BearerAuth
-// @Router       /pohon_kinerja_opd/clone [post]
-func (controller *PohonKinerjaOpdControllerImpl) Clone(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	cloneRequest := pohonkinerja.PohonKinerjaCloneRequest{}
-	helper.ReadFromRequestBody(request, &cloneRequest)
-
-	err := controller.PohonKinerjaOpdService.CloneByKodeOpdAndTahun(request.Context(), cloneRequest)
-	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
-		return
-	}
-
-	webResponse := web.WebResponse{
-		Code:   http.StatusOK,
-		Status: "OK",
-		Data:   "Berhasil cloning pohon kinerja dari tahun " + cloneRequest.TahunSumber + " ke tahun " + cloneRequest.TahunTujuan + " untuk OPD " + cloneRequest.KodeOpd,
-	}
-
-	helper.WriteToResponseBody(writer, webResponse)
-}
-
 func (controller *PohonKinerjaOpdControllerImpl) CheckPokinExistsByTahun(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	kodeOpd := params.ByName("kode_opd")
 	tahun := params.ByName("tahun")
 
 	exists, err := controller.PohonKinerjaOpdService.CheckPokinExistsByTahun(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -485,12 +379,7 @@ func (controller *PohonKinerjaOpdControllerImpl) CountPokinPemda(writer http.Res
 
 	countPokinPemda, err := controller.PohonKinerjaOpdService.CountPokinPemda(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -505,23 +394,13 @@ func (controller *PohonKinerjaOpdControllerImpl) CountPokinPemda(writer http.Res
 func (controller *PohonKinerjaOpdControllerImpl) FindPokinAtasan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID harus berupa angka",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.FindPokinAtasan(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -550,13 +429,7 @@ func (controller *PohonKinerjaOpdControllerImpl) ControlPokinOpd(writer http.Res
 
 	controlPokinOpd, err := controller.PohonKinerjaOpdService.ControlPokinOpd(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		writer.WriteHeader(http.StatusBadRequest)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -583,12 +456,7 @@ func (controller *PohonKinerjaOpdControllerImpl) LeaderboardPokinOpd(writer http
 
 	response, err := controller.PohonKinerjaOpdService.LeaderboardPokinOpd(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -620,23 +488,13 @@ func (controller *PohonKinerjaOpdControllerImpl) FindAllPokinParentClonePokinOpd
 
 	levelPohonInt, err := strconv.Atoi(levelPohon)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "Level Pohon harus berupa angka",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Level Pohon harus berupa angka")
 		return
 	}
 
 	pohonKinerjaResponse, err := controller.PohonKinerjaOpdService.FindAllPokinParentClonePokinOpd(request.Context(), kodeOpd, tahun, &levelPohonInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -665,24 +523,18 @@ func (controller *PohonKinerjaOpdControllerImpl) UpdateParentClone(writer http.R
 
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID harus berupa angka",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 	pohonKinerjaUpdateParentCloneRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), pohonKinerjaUpdateParentCloneRequest) {
+		return
+	}
+
 	updateCloneResponse, err := controller.PohonKinerjaOpdService.UpdateParentClone(request.Context(), pohonKinerjaUpdateParentCloneRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -708,14 +560,13 @@ func (controller *PohonKinerjaOpdControllerImpl) UpsertLeaderboardHidden(writer
 	leaderboardHiddenUpsertRequest := pohonkinerja.LeaderboardHiddenUpsertRequest{}
 	helper.ReadFromRequestBody(request, &leaderboardHiddenUpsertRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), leaderboardHiddenUpsertRequest) {
+		return
+	}
+
 	err := controller.PohonKinerjaOpdService.UpsertLeaderboardHidden(request.Context(), leaderboardHiddenUpsertRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -732,12 +583,7 @@ func (controller *PohonKinerjaOpdControllerImpl) FindLeaderboardHiddenKodeOpds(w
 
 	response, err := controller.PohonKinerjaOpdService.FindLeaderboardHiddenKodeOpds(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type PokinWorkflowController interface {
+	Transisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindWorkflow(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAturan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
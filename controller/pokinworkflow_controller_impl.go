@@ -0,0 +1,105 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type PokinWorkflowControllerImpl struct {
+	PokinWorkflowService service.PokinWorkflowService
+}
+
+func NewPokinWorkflowControllerImpl(pokinWorkflowService service.PokinWorkflowService) *PokinWorkflowControllerImpl {
+	return &PokinWorkflowControllerImpl{
+		PokinWorkflowService: pokinWorkflowService,
+	}
+}
+
+// @Summary      Transisi status pohon kinerja
+// @Description  Menjalankan aksi workflow (ajukan, setujui, tolak, batalkan_persetujuan, setujui_crosscutting, tolak_crosscutting). Aksi tolak wajib disertai alasan. Transisi yang tidak diizinkan untuk status atau role saat ini ditolak.
+// @Tags         Pohon Kinerja Workflow
+// @Accept       json
+// @Produce      json
+// @Param        id    path  int                                  true  "ID Pohon Kinerja"
+// @Param        data  body  pohonkinerja.PokinTransisiRequest  true  "Aksi dan alasan"
+// @Success      200  {object}  web.WebResponse{data=pohonkinerja.PokinTransisiResponse}
+// @Failure      403  {object}  web.ErrorResponse
+// @Failure      409  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /pohon_kinerja_workflow/{id}/transisi [post]
+func (controller *PokinWorkflowControllerImpl) Transisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, web.NewBadRequestError("id pohon kinerja tidak valid"), http.StatusBadRequest)
+		return
+	}
+
+	transisiRequest := pohonkinerja.PokinTransisiRequest{}
+	helper.ReadFromRequestBody(request, &transisiRequest)
+	transisiRequest.PohonKinerjaId = id
+
+	if !helper.ValidateRequest(writer, request.Context(), transisiRequest) {
+		return
+	}
+
+	transisiResponse, err := controller.PokinWorkflowService.Transisi(request.Context(), transisiRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success transisi pohon kinerja",
+		Data:   transisiResponse,
+	})
+}
+
+// @Summary      Status dan riwayat workflow pohon kinerja
+// @Description  Status saat ini, aksi yang tersedia untuk user dan riwayat transisi terbaru lebih dulu.
+// @Tags         Pohon Kinerja Workflow
+// @Produce      json
+// @Param        id  path  int  true  "ID Pohon Kinerja"
+// @Success      200  {object}  web.WebResponse{data=pohonkinerja.PokinWorkflowResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /pohon_kinerja_workflow/{id} [get]
+func (controller *PokinWorkflowControllerImpl) FindWorkflow(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, web.NewBadRequestError("id pohon kinerja tidak valid"), http.StatusBadRequest)
+		return
+	}
+
+	workflowResponse, err := controller.PokinWorkflowService.FindWorkflow(request.Context(), id)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get workflow pohon kinerja",
+		Data:   workflowResponse,
+	})
+}
+
+// @Summary      Aturan transisi workflow pohon kinerja
+// @Tags         Pohon Kinerja Workflow
+// @Produce      json
+// @Success      200  {object}  web.WebResponse{data=[]pohonkinerja.PokinAturanTransisiResponse}
+// @Security     BearerAuth
+// @Router       /pohon_kinerja_workflow [get]
+func (controller *PokinWorkflowControllerImpl) FindAturan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get aturan workflow pohon kinerja",
+		Data:   controller.PokinWorkflowService.FindAturan(),
+	})
+}
@@ -24,14 +24,13 @@ func (controller *ProgramControllerImpl) Create(writer http.ResponseWriter, requ
 	programCreateRequest := programkegiatan.ProgramKegiatanCreateRequest{}
 	helper.ReadFromRequestBody(request, &programCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), programCreateRequest) {
+		return
+	}
+
 	programResponse, err := controller.ProgramService.Create(request.Context(), programCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -48,14 +47,13 @@ func (controller *ProgramControllerImpl) Update(writer http.ResponseWriter, requ
 	helper.ReadFromRequestBody(request, &programUpdateRequest)
 
 	programUpdateRequest.Id = params.ByName("programId")
+	if !helper.ValidateRequest(writer, request.Context(), programUpdateRequest) {
+		return
+	}
+
 	programResponse, err := controller.ProgramService.Update(request.Context(), programUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -71,12 +69,7 @@ func (controller *ProgramControllerImpl) Delete(writer http.ResponseWriter, requ
 	programId := params.ByName("id")
 	err := controller.ProgramService.Delete(request.Context(), programId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -92,12 +85,7 @@ func (controller *ProgramControllerImpl) FindById(writer http.ResponseWriter, re
 	programId := params.ByName("id")
 	programResponse, err := controller.ProgramService.FindById(request.Context(), programId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   404,
-			Status: "Not Found",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -112,12 +100,7 @@ func (controller *ProgramControllerImpl) FindById(writer http.ResponseWriter, re
 func (controller *ProgramControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programResponses, err := controller.ProgramService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -24,14 +24,13 @@ func (controller *ProgramUnggulanControllerImpl) Create(writer http.ResponseWrit
 	programUnggulanCreateRequest := programunggulan.ProgramUnggulanCreateRequest{}
 	helper.ReadFromRequestBody(request, &programUnggulanCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), programUnggulanCreateRequest) {
+		return
+	}
+
 	programUnggulanResponse, err := controller.ProgramUnggulanService.Create(request.Context(), programUnggulanCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -50,24 +49,18 @@ func (controller *ProgramUnggulanControllerImpl) Update(writer http.ResponseWrit
 	idStr := params.ByName("id")
 	id, err := strconv.Atoi(idStr)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	programUnggulanUpdateRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), programUnggulanUpdateRequest) {
+		return
+	}
+
 	programUnggulanResponse, err := controller.ProgramUnggulanService.Update(request.Context(), programUnggulanUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -83,23 +76,13 @@ func (controller *ProgramUnggulanControllerImpl) Delete(writer http.ResponseWrit
 	programUnggulanId := params.ByName("id")
 	id, err := strconv.Atoi(programUnggulanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	err = controller.ProgramUnggulanService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -114,23 +97,13 @@ func (controller *ProgramUnggulanControllerImpl) FindById(writer http.ResponseWr
 	programUnggulanId := params.ByName("id")
 	id, err := strconv.Atoi(programUnggulanId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -145,12 +118,7 @@ func (controller *ProgramUnggulanControllerImpl) FindById(writer http.ResponseWr
 func (controller *ProgramUnggulanControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindAll(request.Context(), params.ByName("tahun_awal"), params.ByName("tahun_akhir"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -164,12 +132,7 @@ func (controller *ProgramUnggulanControllerImpl) FindAll(writer http.ResponseWri
 func (controller *ProgramUnggulanControllerImpl) FindByKodeProgramUnggulan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindByKodeProgramUnggulan(request.Context(), params.ByName("kode_program_unggulan"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -182,12 +145,7 @@ func (controller *ProgramUnggulanControllerImpl) FindByKodeProgramUnggulan(write
 func (controller *ProgramUnggulanControllerImpl) FindByTahun(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindByTahun(request.Context(), params.ByName("tahun"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -201,12 +159,7 @@ func (controller *ProgramUnggulanControllerImpl) FindByTahun(writer http.Respons
 func (controller *ProgramUnggulanControllerImpl) FindUnusedByTahun(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindUnusedByTahun(request.Context(), params.ByName("tahun"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -221,14 +174,13 @@ func (controller *ProgramUnggulanControllerImpl) FindByIdTerkait(writer http.Res
 	findByIdTerkaitRequest := programunggulan.FindByIdTerkaitRequest{}
 	helper.ReadFromRequestBody(request, &findByIdTerkaitRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), findByIdTerkaitRequest) {
+		return
+	}
+
 	programUnggulanResponse, err := controller.ProgramUnggulanService.FindByIdTerkait(request.Context(), findByIdTerkaitRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	webResponse := web.WebResponse{
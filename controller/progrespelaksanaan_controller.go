@@ -0,0 +1,14 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ProgresPelaksanaanController interface {
+	Simpan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	KurvaS(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
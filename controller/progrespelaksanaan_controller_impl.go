@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/rencanaaksi"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type ProgresPelaksanaanControllerImpl struct {
+	ProgresPelaksanaanService service.ProgresPelaksanaanService
+}
+
+func NewProgresPelaksanaanControllerImpl(progresPelaksanaanService service.ProgresPelaksanaanService) *ProgresPelaksanaanControllerImpl {
+	return &ProgresPelaksanaanControllerImpl{
+		ProgresPelaksanaanService: progresPelaksanaanService,
+	}
+}
+
+// @Summary      Lapor progres bulanan pelaksanaan rencana aksi
+// @Description  Persentase (0-100) dari bobot bulan tersebut yang sudah terlaksana, beserta catatan dan kendala. Laporan sebelumnya ditimpa.
+// @Tags         Progres Pelaksanaan
+// @Accept       json
+// @Produce      json
+// @Param        pelaksanaanRencanaAksiId  path  string  true  "Pelaksanaan Rencana Aksi ID"
+// @Param        data body rencanaaksi.ProgresPelaksanaanRequest true "Progres"
+// @Success      200  {object}  web.WebResponse{data=rencanaaksi.ProgresPelaksanaanResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /pelaksanaan_rencana_aksi/progres/{pelaksanaanRencanaAksiId} [put]
+func (controller *ProgresPelaksanaanControllerImpl) Simpan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	progresRequest := rencanaaksi.ProgresPelaksanaanRequest{}
+	helper.ReadFromRequestBody(request, &progresRequest)
+	progresRequest.PelaksanaanRencanaAksiId = params.ByName("pelaksanaanRencanaAksiId")
+
+	if !helper.ValidateRequest(writer, request.Context(), progresRequest) {
+		return
+	}
+
+	progresResponse, err := controller.ProgresPelaksanaanService.Simpan(request.Context(), progresRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success simpan progres pelaksanaan",
+		Data:   progresResponse,
+	})
+}
+
+// @Summary      Hapus progres pelaksanaan rencana aksi
+// @Tags         Progres Pelaksanaan
+// @Produce      json
+// @Param        pelaksanaanRencanaAksiId  path  string  true  "Pelaksanaan Rencana Aksi ID"
+// @Success      200  {object}  web.WebResponse
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /pelaksanaan_rencana_aksi/progres/{pelaksanaanRencanaAksiId} [delete]
+func (controller *ProgresPelaksanaanControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	err := controller.ProgresPelaksanaanService.Delete(request.Context(), params.ByName("pelaksanaanRencanaAksiId"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success delete progres pelaksanaan",
+		Data:   nil,
+	})
+}
+
+// @Summary      Kurva S rencana kinerja
+// @Description  Rencana vs realisasi bobot kumulatif per bulan. Tanpa query bulan, acuan memakai bulan berjalan.
+// @Tags         Progres Pelaksanaan
+// @Produce      json
+// @Param        rencana_kinerja_id  path   string  true   "Rencana Kinerja ID"
+// @Param        bulan               query  int     false  "Bulan acuan (1-12)"
+// @Success      200  {object}  web.WebResponse{data=rencanaaksi.KurvaSResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /rencana_kinerja/{rencana_kinerja_id}/kurva_s [get]
+func (controller *ProgresPelaksanaanControllerImpl) KurvaS(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	bulan, ok := bulanQuery(writer, request)
+	if !ok {
+		return
+	}
+
+	kurvaResponse, err := controller.ProgresPelaksanaanService.KurvaS(request.Context(), params.ByName("rencana_kinerja_id"), bulan)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get kurva s",
+		Data:   kurvaResponse,
+	})
+}
+
+// @Summary      Rekap progres pelaksanaan OPD
+// @Description  Posisi rencana vs realisasi setiap rencana kinerja OPD pada bulan acuan, rencana kinerja yang tertinggal ditandai terlambat
+// @Tags         Progres Pelaksanaan
+// @Produce      json
+// @Param        kode_opd  path   string  true   "Kode OPD"
+// @Param        tahun     path   string  true   "Tahun"
+// @Param        bulan     query  int     false  "Bulan acuan (1-12)"
+// @Success      200  {object}  web.WebResponse{data=rencanaaksi.ProgresOpdResponse}
+// @Security     BearerAuth
+// @Router       /progres_pelaksanaan/opd/{kode_opd}/{tahun} [get]
+func (controller *ProgresPelaksanaanControllerImpl) FindByOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	bulan, ok := bulanQuery(writer, request)
+	if !ok {
+		return
+	}
+
+	progresResponse, err := controller.ProgresPelaksanaanService.FindByOpd(request.Context(), params.ByName("kode_opd"), params.ByName("tahun"), bulan)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get progres pelaksanaan opd",
+		Data:   progresResponse,
+	})
+}
+
+// bulanQuery membaca query bulan opsional, 0 jika tidak diisi
+func bulanQuery(writer http.ResponseWriter, request *http.Request) (int, bool) {
+	value := request.URL.Query().Get("bulan")
+	if value == "" {
+		return 0, true
+	}
+	bulan, err := strconv.Atoi(value)
+	if err != nil || bulan < 1 || bulan > 12 {
+		helper.WriteError(writer, http.StatusBadRequest, "bulan harus antara 1 dan 12")
+		return 0, false
+	}
+	return bulan, true
+}
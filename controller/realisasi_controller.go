@@ -0,0 +1,17 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RealisasiController interface {
+	Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UpdatePolaritas(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindByRencanaKinerja(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindIkuOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
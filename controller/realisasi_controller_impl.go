@@ -0,0 +1,220 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/realisasi"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RealisasiControllerImpl struct {
+	RealisasiService service.RealisasiService
+}
+
+func NewRealisasiControllerImpl(realisasiService service.RealisasiService) *RealisasiControllerImpl {
+	return &RealisasiControllerImpl{
+		RealisasiService: realisasiService,
+	}
+}
+
+// @Summary      Simpan realisasi indikator
+// @Description  Menyimpan realisasi satu periode (bulanan 1-12, triwulan 1-4, tahunan 1). Periode yang sudah terisi akan ditimpa.
+// @Tags         Realisasi
+// @Accept       json
+// @Produce      json
+// @Param        data body realisasi.RealisasiCreateRequest true "Realisasi"
+// @Success      200  {object}  web.WebResponse{data=realisasi.RealisasiResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /realisasi/create [post]
+func (controller *RealisasiControllerImpl) Create(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	createRequest := realisasi.RealisasiCreateRequest{}
+	helper.ReadFromRequestBody(request, &createRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), createRequest) {
+		return
+	}
+
+	realisasiResponse, err := controller.RealisasiService.Create(request.Context(), createRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success simpan realisasi",
+		Data:   realisasiResponse,
+	})
+}
+
+// @Summary      Ubah realisasi indikator
+// @Tags         Realisasi
+// @Accept       json
+// @Produce      json
+// @Param        id   path  int  true  "Realisasi ID"
+// @Param        data body realisasi.RealisasiUpdateRequest true "Realisasi"
+// @Success      200  {object}  web.WebResponse{data=realisasi.RealisasiResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /realisasi/update/{id} [put]
+func (controller *RealisasiControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.ParseInt(params.ByName("id"), 10, 64)
+	if err != nil {
+		helper.WriteError(writer, http.StatusBadRequest, "id realisasi tidak valid")
+		return
+	}
+
+	updateRequest := realisasi.RealisasiUpdateRequest{}
+	helper.ReadFromRequestBody(request, &updateRequest)
+	updateRequest.Id = id
+
+	if !helper.ValidateRequest(writer, request.Context(), updateRequest) {
+		return
+	}
+
+	realisasiResponse, err := controller.RealisasiService.Update(request.Context(), updateRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success update realisasi",
+		Data:   realisasiResponse,
+	})
+}
+
+// @Summary      Hapus realisasi indikator
+// @Tags         Realisasi
+// @Produce      json
+// @Param        id   path  int  true  "Realisasi ID"
+// @Success      200  {object}  web.WebResponse
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /realisasi/delete/{id} [delete]
+func (controller *RealisasiControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.ParseInt(params.ByName("id"), 10, 64)
+	if err != nil {
+		helper.WriteError(writer, http.StatusBadRequest, "id realisasi tidak valid")
+		return
+	}
+
+	err = controller.RealisasiService.Delete(request.Context(), id)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success delete realisasi",
+		Data:   nil,
+	})
+}
+
+// @Summary      Ubah polaritas indikator
+// @Description  Positif: semakin tinggi realisasi semakin baik. Negatif: semakin rendah realisasi semakin baik.
+// @Tags         Realisasi
+// @Accept       json
+// @Produce      json
+// @Param        indikator_id  path  string  true  "Indikator ID / kode indikator"
+// @Param        data body realisasi.PolaritasUpdateRequest true "Polaritas"
+// @Success      200  {object}  web.WebResponse
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /realisasi/indikator/{indikator_id}/polaritas [put]
+func (controller *RealisasiControllerImpl) UpdatePolaritas(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	polaritasRequest := realisasi.PolaritasUpdateRequest{}
+	helper.ReadFromRequestBody(request, &polaritasRequest)
+	polaritasRequest.IndikatorId = params.ByName("indikator_id")
+
+	if !helper.ValidateRequest(writer, request.Context(), polaritasRequest) {
+		return
+	}
+
+	err := controller.RealisasiService.UpdatePolaritas(request.Context(), polaritasRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success update polaritas indikator",
+		Data:   nil,
+	})
+}
+
+// @Summary      Capaian satu indikator
+// @Description  Target, realisasi per periode dan capaian (%) indikator rencana kinerja, tujuan/sasaran OPD atau IKU
+// @Tags         Realisasi
+// @Produce      json
+// @Param        indikator_id  path  string  true  "Indikator ID / kode indikator"
+// @Param        tahun         path  string  true  "Tahun"
+// @Success      200  {object}  web.WebResponse{data=realisasi.CapaianIndikatorResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /realisasi/indikator/{indikator_id}/{tahun} [get]
+func (controller *RealisasiControllerImpl) FindByIndikator(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	capaianResponse, err := controller.RealisasiService.FindByIndikator(request.Context(), params.ByName("indikator_id"), params.ByName("tahun"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get capaian indikator",
+		Data:   capaianResponse,
+	})
+}
+
+// @Summary      Capaian indikator rencana kinerja
+// @Tags         Realisasi
+// @Produce      json
+// @Param        rencana_kinerja_id  path  string  true  "Rencana Kinerja ID"
+// @Param        tahun               path  string  true  "Tahun"
+// @Success      200  {object}  web.WebResponse{data=[]realisasi.CapaianIndikatorResponse}
+// @Security     BearerAuth
+// @Router       /rencana_kinerja/{rencana_kinerja_id}/realisasi/{tahun} [get]
+func (controller *RealisasiControllerImpl) FindByRencanaKinerja(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	capaianResponses, err := controller.RealisasiService.FindByRencanaKinerja(request.Context(), params.ByName("rencana_kinerja_id"), params.ByName("tahun"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get capaian rencana kinerja",
+		Data:   capaianResponses,
+	})
+}
+
+// @Summary      Capaian IKU OPD
+// @Tags         Realisasi
+// @Produce      json
+// @Param        kode_opd  path  string  true  "Kode OPD"
+// @Param        tahun     path  string  true  "Tahun"
+// @Success      200  {object}  web.WebResponse{data=[]realisasi.CapaianIndikatorResponse}
+// @Security     BearerAuth
+// @Router       /indikator_utama/realisasi/{kode_opd}/{tahun} [get]
+func (controller *RealisasiControllerImpl) FindIkuOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	capaianResponses, err := controller.RealisasiService.FindIkuOpd(request.Context(), params.ByName("kode_opd"), params.ByName("tahun"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get capaian iku opd",
+		Data:   capaianResponses,
+	})
+}
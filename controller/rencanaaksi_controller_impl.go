@@ -30,14 +30,13 @@ func (controller *RencanaAksiControllerImpl) Create(writer http.ResponseWriter,
 
 	rencanaAksiCreateRequest.RencanaKinerjaId = rencanaKinerjaId
 
+	if !helper.ValidateRequest(writer, request.Context(), rencanaAksiCreateRequest) {
+		return
+	}
+
 	rencanaAksiResponse, err := controller.RencanaAksiService.Create(request.Context(), rencanaAksiCreateRequest)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -57,14 +56,13 @@ func (controller *RencanaAksiControllerImpl) Update(writer http.ResponseWriter,
 	rencanaAksiId := params.ByName("rencanaaksiId")
 	rencanaAksiUpdateRequest.Id = rencanaAksiId
 
+	if !helper.ValidateRequest(writer, request.Context(), rencanaAksiUpdateRequest) {
+		return
+	}
+
 	rencanaAksiResponse, err := controller.RencanaAksiService.Update(request.Context(), rencanaAksiUpdateRequest)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -82,12 +80,7 @@ func (controller *RencanaAksiControllerImpl) FindAll(writer http.ResponseWriter,
 
 	rencanaAksiResponses, err := controller.RencanaAksiService.FindAll(request.Context(), rencanaKinerjaId)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -105,12 +98,7 @@ func (controller *RencanaAksiControllerImpl) FindById(writer http.ResponseWriter
 
 	rencanaAksiResponse, err := controller.RencanaAksiService.FindById(request.Context(), rencanaAksiId)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -128,12 +116,7 @@ func (controller *RencanaAksiControllerImpl) Delete(writer http.ResponseWriter,
 
 	err := controller.RencanaAksiService.Delete(request.Context(), rencanaAksiId)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -151,12 +134,7 @@ func (controller *RencanaAksiControllerImpl) FindAllByRekin(writer http.Response
 
 	rencanaAksiResponses, err := controller.RencanaAksiService.FindAll(request.Context(), rencanaKinerjaId)
 	if err != nil {
-		webResponse := web.WebRencanaAksiResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -24,14 +24,13 @@ func (controller *RencanaKinerjaControllerImpl) Create(writer http.ResponseWrite
 	rencanaKinerjaCreateRequest := rencanakinerja.RencanaKinerjaCreateRequest{}
 	helper.ReadFromRequestBody(request, &rencanaKinerjaCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), rencanaKinerjaCreateRequest) {
+		return
+	}
+
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.Create(request.Context(), rencanaKinerjaCreateRequest)
 	if err != nil {
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   400,
-			Status: "failed create rencana kinerja",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebRencanaKinerjaResponse{
@@ -49,14 +48,13 @@ func (controller *RencanaKinerjaControllerImpl) Update(writer http.ResponseWrite
 
 	rencanaKinerjaUpdateRequest.Id = params.ByName("id")
 
+	if !helper.ValidateRequest(writer, request.Context(), rencanaKinerjaUpdateRequest) {
+		return
+	}
+
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.Update(request.Context(), rencanaKinerjaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   400,
-			Status: "failed update rencana kinerja",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebRencanaKinerjaResponse{
@@ -90,12 +88,7 @@ func (controller *RencanaKinerjaControllerImpl) FindAll(writer http.ResponseWrit
 
 	rencanaKinerjaResponses, err := controller.rencanaKinerjaService.FindAll(request.Context(), pegawaiId, kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed get rencana kinerja",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebRencanaKinerjaResponse{
@@ -114,13 +107,7 @@ func (controller *RencanaKinerjaControllerImpl) FindById(writer http.ResponseWri
 
 	result, err := controller.rencanaKinerjaService.FindById(request.Context(), id, kodeOPD, tahun)
 	if err != nil {
-		// Handle error
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   404,
-			Status: http.StatusText(http.StatusNotFound),
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -136,7 +123,11 @@ func (controller *RencanaKinerjaControllerImpl) FindById(writer http.ResponseWri
 func (controller *RencanaKinerjaControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	rencanaKinerjaId := params.ByName("id")
 
-	controller.rencanaKinerjaService.Delete(request.Context(), rencanaKinerjaId)
+	err := controller.rencanaKinerjaService.Delete(request.Context(), rencanaKinerjaId)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
 	webResponse := web.WebRencanaKinerjaResponse{
 		Code:   200,
 		Status: "success delete rencana kinerja",
@@ -167,12 +158,7 @@ func (controller *RencanaKinerjaControllerImpl) FindAllRencanaKinerja(writer htt
 
 	rencanaKinerjaResponses, err := controller.rencanaKinerjaService.FindAll(request.Context(), pegawaiId, kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed get rencana kinerja",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -199,12 +185,7 @@ func (controller *RencanaKinerjaControllerImpl) FindAllRincianKak(writer http.Re
 
 	rencanaAksiResponses, err := controller.rencanaKinerjaService.FindAllRincianKak(request.Context(), pegawaiId, rencanaKinerjaId)
 	if err != nil {
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed get rincian kak",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -246,14 +227,13 @@ func (controller *RencanaKinerjaControllerImpl) CreateRekinLevel1(writer http.Re
 	rencanaKinerjaCreateRequest := rencanakinerja.RencanaKinerjaCreateRequest{}
 	helper.ReadFromRequestBody(request, &rencanaKinerjaCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), rencanaKinerjaCreateRequest) {
+		return
+	}
+
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.CreateRekinLevel1(request.Context(), rencanaKinerjaCreateRequest)
 	if err != nil {
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   400,
-			Status: "failed create rekin level 1",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebRencanaKinerjaResponse{
@@ -271,14 +251,13 @@ func (controller *RencanaKinerjaControllerImpl) UpdateRekinLevel1(writer http.Re
 
 	rencanaKinerjaUpdateRequest.Id = params.ByName("id")
 
+	if !helper.ValidateRequest(writer, request.Context(), rencanaKinerjaUpdateRequest) {
+		return
+	}
+
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.UpdateRekinLevel1(request.Context(), rencanaKinerjaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   400,
-			Status: "failed update rekin level 1",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebRencanaKinerjaResponse{
@@ -293,23 +272,13 @@ func (controller *RencanaKinerjaControllerImpl) UpdateRekinLevel1(writer http.Re
 func (controller *RencanaKinerjaControllerImpl) FindIdRekinLevel1(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	id := params.ByName("id")
 	if id == "" {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "ID tidak boleh kosong",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID tidak boleh kosong")
 		return
 	}
 
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.FindIdRekinLevel1(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -327,12 +296,7 @@ func (controller *RencanaKinerjaControllerImpl) FindRekinLevel3(writer http.Resp
 
 	rencanaKinerjaResponses, err := controller.rencanaKinerjaService.FindRekinLevel3(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebRencanaKinerjaResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed get rekin level 3",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -349,12 +313,7 @@ func (controller *RencanaKinerjaControllerImpl) FindRekinAtasan(writer http.Resp
 
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.FindRekinAtasan(request.Context(), rekinId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed get rekin atasan",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -372,12 +331,7 @@ func (controller *RencanaKinerjaControllerImpl) CloneRencanaKinerja(writer http.
 
 	rencanaKinerjaResponse, err := controller.rencanaKinerjaService.CloneRencanaKinerja(request.Context(), rekinId, tahunBaru)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed clone rencana kinerja",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -388,26 +342,3 @@ func (controller *RencanaKinerjaControllerImpl) CloneRencanaKinerja(writer http.
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
-
-func (controller *RencanaKinerjaControllerImpl) CloneRencanaKinerjaByKodeOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
-	cloneRequest := rencanakinerja.RekinByOpdCloneRequest{}
-	helper.ReadFromRequestBody(request, &cloneRequest)
-
-	err := controller.rencanaKinerjaService.CloneRekinByKodeOpdAndTahun(request.Context(), cloneRequest)
-	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "gagal clone rencana kinerja",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBodyWstatus(writer, webResponse)
-		return
-	}
-
-	webResponse := web.WebResponse{
-		Code:   http.StatusOK,
-		Status: "success clone rencana kinerja",
-		Data:   "Berhasil clone rencana kinerja dari tahun " + cloneRequest.TahunSumber + " ke tahun " + cloneRequest.TahunTujuan + " untuk OPD " + cloneRequest.KodeOpd,
-	}
-	helper.WriteToResponseBodyWstatus(writer, webResponse)
-}
@@ -0,0 +1,13 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RencanaKinerjaVerifikasiController interface {
+	Transisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindStatus(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindInbox(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
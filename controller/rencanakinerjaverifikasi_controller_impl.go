@@ -0,0 +1,100 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type RencanaKinerjaVerifikasiControllerImpl struct {
+	RencanaKinerjaVerifikasiService service.RencanaKinerjaVerifikasiService
+}
+
+func NewRencanaKinerjaVerifikasiControllerImpl(rencanaKinerjaVerifikasiService service.RencanaKinerjaVerifikasiService) *RencanaKinerjaVerifikasiControllerImpl {
+	return &RencanaKinerjaVerifikasiControllerImpl{
+		RencanaKinerjaVerifikasiService: rencanaKinerjaVerifikasiService,
+	}
+}
+
+// @Summary      Transisi verifikasi rencana kinerja
+// @Description  Pemilik rekin menjalankan ajukan atau revisi, atasan menurut struktur organisasi menjalankan verifikasi, kembalikan atau tolak. Kembalikan, tolak dan revisi rekin terverifikasi wajib disertai catatan.
+// @Tags         Rencana Kinerja Verifikasi
+// @Accept       json
+// @Produce      json
+// @Param        rencana_kinerja_id  path  string                                 true  "ID Rencana Kinerja"
+// @Param        data                body  rencanakinerja.RekinVerifikasiRequest  true  "Aksi dan catatan"
+// @Success      200  {object}  web.WebResponse{data=rencanakinerja.RekinVerifikasiResponse}
+// @Failure      403  {object}  web.ErrorResponse
+// @Failure      409  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /rencana_kinerja_verifikasi/{rencana_kinerja_id}/transisi [post]
+func (controller *RencanaKinerjaVerifikasiControllerImpl) Transisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	transisiRequest := rencanakinerja.RekinVerifikasiRequest{}
+	helper.ReadFromRequestBody(request, &transisiRequest)
+	transisiRequest.RencanaKinerjaId = params.ByName("rencana_kinerja_id")
+
+	if !helper.ValidateRequest(writer, request.Context(), transisiRequest) {
+		return
+	}
+
+	transisiResponse, err := controller.RencanaKinerjaVerifikasiService.Transisi(request.Context(), transisiRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success transisi verifikasi rencana kinerja",
+		Data:   transisiResponse,
+	})
+}
+
+// @Summary      Status verifikasi rencana kinerja
+// @Description  Status, catatan atasan, revisi, aksi yang tersedia untuk user dan riwayat verifikasi terbaru lebih dulu.
+// @Tags         Rencana Kinerja Verifikasi
+// @Produce      json
+// @Param        rencana_kinerja_id  path  string  true  "ID Rencana Kinerja"
+// @Success      200  {object}  web.WebResponse{data=rencanakinerja.RekinStatusVerifikasiResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /rencana_kinerja/{rencana_kinerja_id}/verifikasi [get]
+func (controller *RencanaKinerjaVerifikasiControllerImpl) FindStatus(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	statusResponse, err := controller.RencanaKinerjaVerifikasiService.FindStatus(request.Context(), params.ByName("rencana_kinerja_id"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get verifikasi rencana kinerja",
+		Data:   statusResponse,
+	})
+}
+
+// @Summary      Inbox verifikasi rencana kinerja
+// @Description  Rekin bawahan user login yang menunggu verifikasi, pengajuan terlama lebih dulu.
+// @Tags         Rencana Kinerja Verifikasi
+// @Produce      json
+// @Param        tahun  query  string  true  "Tahun rencana kinerja"
+// @Success      200  {object}  web.WebResponse{data=[]rencanakinerja.RekinInboxResponse}
+// @Security     BearerAuth
+// @Router       /rencana_kinerja_verifikasi/inbox [get]
+func (controller *RencanaKinerjaVerifikasiControllerImpl) FindInbox(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	inboxResponse, err := controller.RencanaKinerjaVerifikasiService.FindInbox(request.Context(), request.URL.Query().Get("tahun"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get inbox verifikasi rencana kinerja",
+		Data:   inboxResponse,
+	})
+}
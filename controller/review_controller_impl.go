@@ -35,13 +35,13 @@ func (controller *ReviewControllerImpl) Create(writer http.ResponseWriter, reque
 	claims := request.Context().Value(helper.UserInfoKey).(web.JWTClaim)
 	ctx := context.WithValue(request.Context(), helper.UserInfoKey, claims)
 
+	if !helper.ValidateRequest(writer, ctx, reviewCreateRequest) {
+		return
+	}
+
 	reviewResponse, err := controller.ReviewService.Create(ctx, reviewCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -56,11 +56,8 @@ func (controller *ReviewControllerImpl) Update(writer http.ResponseWriter, reque
 	paramId := params.ByName("id")
 	id, err := strconv.Atoi(paramId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
 	}
 
 	reviewUpdateRequest := pohonkinerja.ReviewUpdateRequest{}
@@ -68,13 +65,13 @@ func (controller *ReviewControllerImpl) Update(writer http.ResponseWriter, reque
 
 	reviewUpdateRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), reviewUpdateRequest) {
+		return
+	}
+
 	reviewResponse, err := controller.ReviewService.Update(request.Context(), reviewUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -89,11 +86,7 @@ func (controller *ReviewControllerImpl) Delete(writer http.ResponseWriter, reque
 	paramId := params.ByName("id")
 	id, err := strconv.Atoi(paramId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -108,21 +101,13 @@ func (controller *ReviewControllerImpl) FindAll(writer http.ResponseWriter, requ
 	pohonkinerjaId := params.ByName("pokin_id")
 	id, err := strconv.Atoi(pohonkinerjaId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	reviewResponse, err := controller.ReviewService.FindAll(request.Context(), id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -137,20 +122,14 @@ func (controller *ReviewControllerImpl) FindById(writer http.ResponseWriter, req
 	paramId := params.ByName("id")
 	id, err := strconv.Atoi(paramId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
 	}
 
 	reviewResponse, err := controller.ReviewService.FindById(request.Context(), id)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
 	}
 
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -165,11 +144,8 @@ func (controller *ReviewControllerImpl) FindAllReviewByTematik(writer http.Respo
 
 	reviewResponse, err := controller.ReviewService.FindAllReviewByTematik(request.Context(), tahun)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
 	}
 
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -185,11 +161,8 @@ func (controller *ReviewControllerImpl) FindAllReviewOpd(writer http.ResponseWri
 
 	reviewResponse, err := controller.ReviewService.FindAllReviewOpd(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
 	}
 
 	helper.WriteToResponseBody(writer, web.WebResponse{
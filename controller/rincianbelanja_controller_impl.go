@@ -24,13 +24,13 @@ func (controller *RincianBelanjaControllerImpl) Create(writer http.ResponseWrite
 	rincianBelanjaCreateRequest := rincianbelanja.RincianBelanjaCreateRequest{}
 	helper.ReadFromRequestBody(request, &rincianBelanjaCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), rincianBelanjaCreateRequest) {
+		return
+	}
+
 	rincianBelanjaResponse, err := controller.rincianBelanjaService.Create(request.Context(), rincianBelanjaCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -46,14 +46,13 @@ func (controller *RincianBelanjaControllerImpl) Update(writer http.ResponseWrite
 	helper.ReadFromRequestBody(request, &rincianBelanjaUpdateRequest)
 
 	rincianBelanjaUpdateRequest.RenaksiId = params.ByName("renaksiId")
+	if !helper.ValidateRequest(writer, request.Context(), rincianBelanjaUpdateRequest) {
+		return
+	}
+
 	rincianBelanjaResponse, err := controller.rincianBelanjaService.Update(request.Context(), rincianBelanjaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "Internal Server Error",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -95,12 +94,7 @@ func (controller *RincianBelanjaControllerImpl) LaporanRincianBelanjaOpd(writer
 
 	rincianBelanjaResponses, err := controller.rincianBelanjaService.LaporanRincianBelanjaOpd(request.Context(), kodeOpd, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed get laporan rincian belanja",
-			Data:   nil,
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -118,12 +112,7 @@ func (controller *RincianBelanjaControllerImpl) LaporanRincianBelanjaPegawai(wri
 
 	response, err := controller.rincianBelanjaService.LaporanRincianBelanjaPegawai(request.Context(), pegawaiId, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed get laporan rincian belanja",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -139,14 +128,13 @@ func (controller *RincianBelanjaControllerImpl) Upsert(writer http.ResponseWrite
 	rincianBelanjaUpsertRequest := rincianbelanja.RincianBelanjaCreateRequest{}
 	helper.ReadFromRequestBody(request, &rincianBelanjaUpsertRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), rincianBelanjaUpsertRequest) {
+		return
+	}
+
 	rincianBelanjaResponse, err := controller.rincianBelanjaService.Upsert(request.Context(), rincianBelanjaUpsertRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "failed upsert rincian belanja",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
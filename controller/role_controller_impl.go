@@ -25,14 +25,13 @@ func (controller *RoleControllerImpl) Create(writer http.ResponseWriter, request
 	roleCreateRequest := user.RoleCreateRequest{}
 	helper.ReadFromRequestBody(request, &roleCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), roleCreateRequest) {
+		return
+	}
+
 	roleResponse, err := controller.RoleService.Create(request.Context(), roleCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed create role",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -48,12 +47,7 @@ func (controller *RoleControllerImpl) Update(writer http.ResponseWriter, request
 	roleId := params.ByName("id")
 	id, err := strconv.Atoi(roleId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed update role",
-			Data:   "invalid role id",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "invalid role id")
 		return
 	}
 
@@ -61,14 +55,13 @@ func (controller *RoleControllerImpl) Update(writer http.ResponseWriter, request
 	helper.ReadFromRequestBody(request, &roleUpdateRequest)
 	roleUpdateRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), roleUpdateRequest) {
+		return
+	}
+
 	roleResponse, err := controller.RoleService.Update(request.Context(), roleUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed update role",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -84,23 +77,13 @@ func (controller *RoleControllerImpl) Delete(writer http.ResponseWriter, request
 	roleId := params.ByName("id")
 	id, err := strconv.Atoi(roleId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed delete role",
-			Data:   "invalid role id",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "invalid role id")
 		return
 	}
 
 	err = controller.RoleService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed delete role",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -116,23 +99,13 @@ func (controller *RoleControllerImpl) FindById(writer http.ResponseWriter, reque
 	roleId := params.ByName("id")
 	id, err := strconv.Atoi(roleId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed get role",
-			Data:   "invalid role id",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "invalid role id")
 		return
 	}
 
 	roleResponse, err := controller.RoleService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed get role",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -147,12 +120,7 @@ func (controller *RoleControllerImpl) FindById(writer http.ResponseWriter, reque
 func (controller *RoleControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	roleResponses, err := controller.RoleService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed get roles",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -29,12 +29,7 @@ func (controller *SasaranOpdControllerImpl) FindAll(writer http.ResponseWriter,
 
 	sasaranOpdResponse, err := controller.SasaranOpdService.FindAll(request.Context(), KodeOpd, tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 	} else {
 		webResponse := web.WebResponse{
 			Code:   200,
@@ -52,12 +47,7 @@ func (controller *SasaranOpdControllerImpl) FindById(writer http.ResponseWriter,
 
 	sasaranOpdResponse, err := controller.SasaranOpdService.FindById(request.Context(), idInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 	} else {
 		webResponse := web.WebResponse{
 			Code:   200,
@@ -82,14 +72,13 @@ func (controller *SasaranOpdControllerImpl) Create(writer http.ResponseWriter, r
 	sasaranOpdCreateRequest := sasaranopd.SasaranOpdCreateRequest{}
 	helper.ReadFromRequestBody(request, &sasaranOpdCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), sasaranOpdCreateRequest) {
+		return
+	}
+
 	sasaranOpdCreateResponse, err := controller.SasaranOpdService.Create(request.Context(), sasaranOpdCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed create sasaran opd",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -118,26 +107,19 @@ func (controller *SasaranOpdControllerImpl) Update(writer http.ResponseWriter, r
 
 	id, err := strconv.Atoi(params.ByName("id"))
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "Bad Request",
-			Data:   "ID harus berupa angka",
-		}
-		writer.WriteHeader(http.StatusBadRequest)
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID harus berupa angka")
 		return
 	}
 	sasaranOpdUpdateRequest.IdSasaranOpd = id
 
 	// Panggil service Update
+	if !helper.ValidateRequest(writer, request.Context(), sasaranOpdUpdateRequest) {
+		return
+	}
+
 	sasaranOpdResponse, err := controller.SasaranOpdService.Update(request.Context(), sasaranOpdUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -166,12 +148,7 @@ func (controller *SasaranOpdControllerImpl) Delete(writer http.ResponseWriter, r
 
 	err := controller.SasaranOpdService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed delete sasaran opd",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 	} else {
 		webResponse := web.WebResponse{
 			Code:   200,
@@ -188,55 +165,30 @@ func (controller *SasaranOpdControllerImpl) FindByIdPokin(writer http.ResponseWr
 
 	// Validasi parameter tidak boleh kosong
 	if idPokinStr == "" {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "ID pohon kinerja tidak boleh kosong",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID pohon kinerja tidak boleh kosong")
 		return
 	}
 
 	if tahun == "" {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Tahun tidak boleh kosong",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Tahun tidak boleh kosong")
 		return
 	}
 
 	// Validasi format tahun
 	if len(tahun) != 4 {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "Format tahun harus 4 digit (YYYY)",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Format tahun harus 4 digit (YYYY)")
 		return
 	}
 
 	idPokin, err := strconv.Atoi(idPokinStr)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   "ID pohon kinerja harus berupa angka",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "ID pohon kinerja harus berupa angka")
 		return
 	}
 
 	sasaranOpdResponse, err := controller.SasaranOpdService.FindByIdPokin(request.Context(), idPokin, tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -255,12 +207,7 @@ func (controller *SasaranOpdControllerImpl) FindIdPokinSasaran(writer http.Respo
 
 	sasaranOpdResponse, err := controller.SasaranOpdService.FindIdPokinSasaran(request.Context(), idPokin)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 	} else {
 		webResponse := web.WebResponse{
 			Code:   200,
@@ -278,12 +225,7 @@ func (controller *SasaranOpdControllerImpl) FindByTahun(writer http.ResponseWrit
 
 	sasaranOpdResponses, err := controller.SasaranOpdService.FindByTahun(request.Context(), kodeOpd, tahun, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	} else {
 		webResponse := web.WebResponse{
@@ -318,12 +260,7 @@ func (controller *SasaranOpdControllerImpl) FindSasaranRenstra(writer http.Respo
 		request.Context(), kodeOpd, tahunAwal, tahunAkhir, jenisPeriode,
 	)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -353,12 +290,7 @@ func (controller *SasaranOpdControllerImpl) FindSasaranRanwal(writer http.Respon
 		request.Context(), kodeOpd, tahun, "RPJMD",
 	)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -389,12 +321,7 @@ func (controller *SasaranOpdControllerImpl) FindSasaranRankhir(writer http.Respo
 		request.Context(), kodeOpd, tahun, "RPJMD",
 	)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -420,26 +347,20 @@ func (controller *SasaranOpdControllerImpl) CreateIndikatorRanwal(writer http.Re
 	sasaranopdId := params.ByName("sasaranopdId")
 	sasaranopdIdInt, err := strconv.Atoi(sasaranopdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	indikatorCreateRequests := []sasaranopd.IndikatorCreateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
 
+	if !helper.ValidateRequest(writer, request.Context(), indikatorCreateRequests) {
+		return
+	}
+
 	indikatorCreateResponses, err := controller.SasaranOpdService.CreateRenjaIndikator(request.Context(), sasaranopdIdInt, "ranwal", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed create indikator",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -467,14 +388,13 @@ func (controller *SasaranOpdControllerImpl) UpdateIndikatorRanwal(writer http.Re
 	kodeIndikator := params.ByName("kodeIndikator")
 	indikatorUpdateRequests := sasaranopd.IndikatorUpdateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorUpdateRequests) {
+		return
+	}
+
 	indikatorUpdateResponses, err := controller.SasaranOpdService.UpdateRenjaIndikator(request.Context(), kodeIndikator, "ranwal", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -499,26 +419,20 @@ func (controller *SasaranOpdControllerImpl) CreateIndikatorRankhir(writer http.R
 	sasaranopdId := params.ByName("sasaranopdId")
 	sasaranopdIdInt, err := strconv.Atoi(sasaranopdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	indikatorCreateRequests := []sasaranopd.IndikatorCreateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
 
+	if !helper.ValidateRequest(writer, request.Context(), indikatorCreateRequests) {
+		return
+	}
+
 	indikatorCreateResponses, err := controller.SasaranOpdService.CreateRenjaIndikator(request.Context(), sasaranopdIdInt, "rankhir", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed create indikator",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -546,14 +460,13 @@ func (controller *SasaranOpdControllerImpl) UpdateIndikatorRankhir(writer http.R
 	kodeIndikator := params.ByName("kodeIndikator")
 	indikatorUpdateRequests := sasaranopd.IndikatorUpdateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorUpdateRequests) {
+		return
+	}
+
 	indikatorUpdateResponses, err := controller.SasaranOpdService.UpdateRenjaIndikator(request.Context(), kodeIndikator, "rankhir", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -579,12 +492,7 @@ func (controller *SasaranOpdControllerImpl) DeleteIndikatorTargetRenja(writer ht
 	kodeIndikator := params.ByName("kodeIndikator")
 	err := controller.SasaranOpdService.DeleteRenjaIndikator(request.Context(), kodeIndikator)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -613,12 +521,7 @@ func (controller *SasaranOpdControllerImpl) FindSasaranPenetapan(writer http.Res
 		request.Context(), kodeOpd, tahun, "RPJMD",
 	)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -644,26 +547,20 @@ func (controller *SasaranOpdControllerImpl) CreateIndikatorPenetapan(writer http
 	sasaranopdId := params.ByName("sasaranopdId")
 	sasaranopdIdInt, err := strconv.Atoi(sasaranopdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "BAD_REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	indikatorCreateRequests := []sasaranopd.IndikatorCreateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
 
+	if !helper.ValidateRequest(writer, request.Context(), indikatorCreateRequests) {
+		return
+	}
+
 	indikatorCreateResponses, err := controller.SasaranOpdService.CreateRenjaIndikator(request.Context(), sasaranopdIdInt, "penetapan", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed create indikator",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -691,14 +588,13 @@ func (controller *SasaranOpdControllerImpl) UpdateIndikatorPenetapan(writer http
 	kodeIndikator := params.ByName("kodeIndikator")
 	indikatorUpdateRequests := sasaranopd.IndikatorUpdateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorUpdateRequests) {
+		return
+	}
+
 	indikatorUpdateResponses, err := controller.SasaranOpdService.UpdateRenjaIndikator(request.Context(), kodeIndikator, "penetapan", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -27,14 +27,13 @@ func (controller *SasaranPemdaControllerImpl) Create(writer http.ResponseWriter,
 	helper.ReadFromRequestBody(request, &sasaranPemdaCreateRequest)
 
 	// Panggil service create
+	if !helper.ValidateRequest(writer, request.Context(), sasaranPemdaCreateRequest) {
+		return
+	}
+
 	sasaranPemdaResponse, err := controller.sasaranPemdaService.Create(request.Context(), sasaranPemdaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -53,25 +52,19 @@ func (controller *SasaranPemdaControllerImpl) Update(writer http.ResponseWriter,
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 	sasaranPemdaUpdateRequest.Id = idInt
 
 	// Panggil service update
+	if !helper.ValidateRequest(writer, request.Context(), sasaranPemdaUpdateRequest) {
+		return
+	}
+
 	sasaranPemdaResponse, err := controller.sasaranPemdaService.Update(request.Context(), sasaranPemdaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -87,23 +80,13 @@ func (controller *SasaranPemdaControllerImpl) Delete(writer http.ResponseWriter,
 	sasaranPemdaId := params.ByName("id")
 	id, err := strconv.Atoi(sasaranPemdaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 
 	err = controller.sasaranPemdaService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -120,23 +103,13 @@ func (controller *SasaranPemdaControllerImpl) FindById(writer http.ResponseWrite
 
 	id, err := strconv.Atoi(sasaranPemdaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 
 	sasaranPemdaResponse, err := controller.sasaranPemdaService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -152,12 +125,7 @@ func (controller *SasaranPemdaControllerImpl) FindAll(writer http.ResponseWriter
 	tahun := params.ByName("tahun")
 	sasaranPemdaResponses, err := controller.sasaranPemdaService.FindAll(request.Context(), tahun)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -176,12 +144,7 @@ func (controller *SasaranPemdaControllerImpl) FindAllWithPokin(writer http.Respo
 
 	sasaranPemdaResponses, err := controller.sasaranPemdaService.FindAllWithPokin(request.Context(), tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
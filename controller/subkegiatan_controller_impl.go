@@ -27,13 +27,13 @@ func (controller *SubKegiatanControllerImpl) Create(writer http.ResponseWriter,
 	subKegiatanCreateRequest := subkegiatan.SubKegiatanCreateRequest{}
 	helper.ReadFromRequestBody(request, &subKegiatanCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), subKegiatanCreateRequest) {
+		return
+	}
+
 	subKegiatanResponse, err := controller.SubKegiatanService.Create(request.Context(), subKegiatanCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -47,11 +47,7 @@ func (controller *SubKegiatanControllerImpl) Create(writer http.ResponseWriter,
 func (controller *SubKegiatanControllerImpl) Update(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	id := params.ByName("id")
 	if id == "" {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "ID tidak boleh kosong",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "ID tidak boleh kosong")
 		return
 	}
 
@@ -59,11 +55,7 @@ func (controller *SubKegiatanControllerImpl) Update(writer http.ResponseWriter,
 	subKegiatanUpdateRequest := subkegiatan.SubKegiatanUpdateRequest{}
 	err := json.NewDecoder(request.Body).Decode(&subKegiatanUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Format JSON tidak valid",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "Format JSON tidak valid")
 		return
 	}
 
@@ -73,11 +65,7 @@ func (controller *SubKegiatanControllerImpl) Update(writer http.ResponseWriter,
 	// Panggil service untuk update gambaran umum
 	subKegiatanResponse, err := controller.SubKegiatanService.Update(request.Context(), subKegiatanUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -92,21 +80,13 @@ func (controller *SubKegiatanControllerImpl) Update(writer http.ResponseWriter,
 func (controller *SubKegiatanControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	subKegiatanId := params.ByName("id")
 	if subKegiatanId == "" {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "ID sub kegiatan tidak valid",
-		})
+		helper.WriteError(writer, http.StatusBadRequest, "ID sub kegiatan tidak valid")
 		return
 	}
 
 	subKegiatanResponse, err := controller.SubKegiatanService.FindById(request.Context(), subKegiatanId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -121,11 +101,7 @@ func (controller *SubKegiatanControllerImpl) FindAll(writer http.ResponseWriter,
 	subKegiatanResponses, err := controller.SubKegiatanService.FindAll(request.Context())
 
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -141,11 +117,7 @@ func (controller *SubKegiatanControllerImpl) Delete(writer http.ResponseWriter,
 
 	err := controller.SubKegiatanService.Delete(request.Context(), subKegiatanId)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -161,11 +133,7 @@ func (controller *SubKegiatanControllerImpl) FindAllByRekin(writer http.Response
 	subKegiatanResponses, err := controller.SubKegiatanService.FindAll(request.Context())
 
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebSubKegiatanResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -216,11 +184,7 @@ func (controller *SubKegiatanControllerImpl) FindSubKegiatanKAK(writer http.Resp
 
 	subKegiatanKAKResponse, err := controller.SubKegiatanService.FindSubKegiatanKAK(request.Context(), kodeOpd, kodeSubKegiatan, tahun)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
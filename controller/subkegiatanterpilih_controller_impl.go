@@ -28,14 +28,13 @@ func (controller *SubKegiatanTerpilihControllerImpl) Update(writer http.Response
 	subKegiatanTerpilihUpdateRequest.Id = rencanaKinerjaId
 
 	// Panggil service untuk membuat SubKegiatanTerpilih
+	if !helper.ValidateRequest(writer, request.Context(), subKegiatanTerpilihUpdateRequest) {
+		return
+	}
+
 	subKegiatanTerpilihResponse, err := controller.SubKegiatanTerpilihService.Update(request.Context(), subKegiatanTerpilihUpdateRequest)
 	if err != nil {
-		webResponse := web.WebSubKegiatanTerpilihResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -54,12 +53,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) Delete(writer http.Response
 
 	err := controller.SubKegiatanTerpilihService.Delete(request.Context(), rencanaKinerjaId, kodeSubKegiatan)
 	if err != nil {
-		webResponse := web.WebSubKegiatanTerpilihResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -75,12 +69,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) FindByKodeSubKegiatan(write
 
 	subKegiatanTerpilihResponse, err := controller.SubKegiatanTerpilihService.FindByKodeSubKegiatan(request.Context(), kodeSubKegiatan)
 	if err != nil {
-		webResponse := web.WebSubKegiatanTerpilihResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -99,14 +88,13 @@ func (controller *SubKegiatanTerpilihControllerImpl) CreateRekin(writer http.Res
 	idRekin := params.ByName("rencana_kinerja_id")
 	subKegiatanCreateRekinRequest.RekinId = idRekin
 
+	if !helper.ValidateRequest(writer, request.Context(), subKegiatanCreateRekinRequest) {
+		return
+	}
+
 	subKegiatanResponse, err := controller.SubKegiatanTerpilihService.CreateRekin(request.Context(), subKegiatanCreateRekinRequest)
 	if err != nil {
-		webResponse := web.WebSubKegiatanResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -123,12 +111,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) DeleteSubKegiatanTerpilih(w
 
 	err := controller.SubKegiatanTerpilihService.DeleteSubKegiatanTerpilih(request.Context(), idSubKegiatan)
 	if err != nil {
-		webResponse := web.WebSubKegiatanResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -143,14 +126,13 @@ func (controller *SubKegiatanTerpilihControllerImpl) CreateOpd(writer http.Respo
 	SubKegiatanOpdMultipleCreateRequest := subkegiatan.SubKegiatanOpdMultipleCreateRequest{}
 	helper.ReadFromRequestBody(request, &SubKegiatanOpdMultipleCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), SubKegiatanOpdMultipleCreateRequest) {
+		return
+	}
+
 	subKegiatanOpdResponse, err := controller.SubKegiatanTerpilihService.CreateOpdMultiple(request.Context(), SubKegiatanOpdMultipleCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -171,14 +153,13 @@ func (controller *SubKegiatanTerpilihControllerImpl) UpdateOpd(writer http.Respo
 
 	SubKegiatanOpdUpdateRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), SubKegiatanOpdUpdateRequest) {
+		return
+	}
+
 	subKegiatanOpdResponse, err := controller.SubKegiatanTerpilihService.UpdateOpd(request.Context(), SubKegiatanOpdUpdateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -206,12 +187,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) FindAllOpd(writer http.Resp
 
 	subkegiatanResponse, err := controller.SubKegiatanTerpilihService.FindAllOpd(request.Context(), kodeOpdPtr, tahunPtr)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -229,12 +205,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) FindById(writer http.Respon
 
 	subkegiatanResponse, err := controller.SubKegiatanTerpilihService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -251,12 +222,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) DeleteOpd(writer http.Respo
 	id, _ := strconv.Atoi(idstr)
 	err := controller.SubKegiatanTerpilihService.DeleteOpd(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebSubKegiatanTerpilihResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -272,12 +238,7 @@ func (controller *SubKegiatanTerpilihControllerImpl) FindAllSubkegiatanByBidangU
 
 	subkegiatanResponse, err := controller.SubKegiatanTerpilihService.FindAllSubkegiatanByBidangUrusanOpd(request.Context(), kodeOpd)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
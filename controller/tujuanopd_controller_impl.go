@@ -41,14 +41,13 @@ func (controller *TujuanOpdControllerImpl) CreateTujuanOpdRenstra(writer http.Re
 	}
 
 	// Panggil service Create
+	if !helper.ValidateRequest(writer, request.Context(), tujuanOpdCreateRequest) {
+		return
+	}
+
 	tujuanOpdResponse, err := controller.TujuanOpdService.Create(request.Context(), tujuanOpdCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -79,12 +78,8 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanOpdRenstra(writer http.Re
 	tujuanOpdId := params.ByName("tujuanOpdId")
 	tujuanOpdIdInt, err := strconv.Atoi(tujuanOpdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
 	}
 
 	// Baca request body
@@ -99,14 +94,13 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanOpdRenstra(writer http.Re
 	tujuanOpdUpdateRequest.Id = tujuanOpdIdInt
 
 	// Panggil service Update
+	if !helper.ValidateRequest(writer, request.Context(), tujuanOpdUpdateRequest) {
+		return
+	}
+
 	tujuanOpdResponse, err := controller.TujuanOpdService.Update(request.Context(), tujuanOpdUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -123,23 +117,13 @@ func (controller *TujuanOpdControllerImpl) Delete(writer http.ResponseWriter, re
 	tujuanOpdId := params.ByName("tujuanOpdId")
 	tujuanOpdIdInt, err := strconv.Atoi(tujuanOpdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	err = controller.TujuanOpdService.Delete(request.Context(), tujuanOpdIdInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -166,23 +150,13 @@ func (controller *TujuanOpdControllerImpl) FindById(writer http.ResponseWriter,
 	tujuanOpdId := params.ByName("tujuanOpdId")
 	tujuanOpdIdInt, err := strconv.Atoi(tujuanOpdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	tujuanOpdResponse, err := controller.TujuanOpdService.FindById(request.Context(), tujuanOpdIdInt)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -202,12 +176,7 @@ func (controller *TujuanOpdControllerImpl) FindAll(writer http.ResponseWriter, r
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindAll(request.Context(), kodeOpd, tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -227,12 +196,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdOnlyName(writer http.Res
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindTujuanOpdOnlyName(request.Context(), kodeOpd, tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -251,12 +215,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdByTahun(writer http.Resp
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindTujuanOpdByTahun(request.Context(), kodeOpd, tahun, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -294,11 +253,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdRenstra(
 		request.Context(), kodeOpd, tahunAwal, tahunAkhir, "RPJMD",
 	)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -326,11 +281,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdRanwal(writer http.Respo
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindTujuanRanwal(request.Context(), kodeOpd, tahun, "RPJMD")
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -360,11 +311,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdRankhir(writer http.Resp
 		request.Context(), kodeOpd, tahun, jenisPeriode,
 	)
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -390,24 +337,18 @@ func (controller *TujuanOpdControllerImpl) CreateTujuanRenjaRanwalIndikator(writ
 	tujuanOpdId := params.ByName("tujuanOpdId")
 	tujuanOpdIdInt, err := strconv.Atoi(tujuanOpdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	indikatorCreateRequests := []tujuanopd.IndikatorCreateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorCreateRequests) {
+		return
+	}
+
 	indikatorResponses, err := controller.TujuanOpdService.CreateTujuanRenjaIndikator(request.Context(), tujuanOpdIdInt, "ranwal", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -434,14 +375,13 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanRenjaRanwalIndikator(writ
 
 	indikatorUpdateRequests := tujuanopd.IndikatorUpdateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorUpdateRequests) {
+		return
+	}
+
 	indikatorResponses, err := controller.TujuanOpdService.UpdateTujuanRenjaIndikator(request.Context(), kodeIndikator, "ranwal", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -467,24 +407,18 @@ func (controller *TujuanOpdControllerImpl) CreateTujuanRenjaRankhirIndikator(wri
 	tujuanOpdId := params.ByName("tujuanOpdId")
 	tujuanOpdIdInt, err := strconv.Atoi(tujuanOpdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	indikatorCreateRequests := []tujuanopd.IndikatorCreateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorCreateRequests) {
+		return
+	}
+
 	indikatorResponses, err := controller.TujuanOpdService.CreateTujuanRenjaIndikator(request.Context(), tujuanOpdIdInt, "rankhir", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -511,14 +445,13 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanRenjaRankhirIndikator(wri
 
 	indikatorUpdateRequests := tujuanopd.IndikatorUpdateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorUpdateRequests) {
+		return
+	}
+
 	indikatorResponses, err := controller.TujuanOpdService.UpdateTujuanRenjaIndikator(request.Context(), kodeIndikator, "rankhir", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -546,12 +479,7 @@ func (controller *TujuanOpdControllerImpl) DeleteTujuanRenjaIndikator(writer htt
 	kodeIndikator := params.ByName("kodeIndikator")
 	err := controller.TujuanOpdService.DeleteTujuanRenjaIndikator(request.Context(), kodeIndikator)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -581,11 +509,7 @@ func (controller *TujuanOpdControllerImpl) FindTujuanOpdPenetapan(writer http.Re
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.FindTujuanPenetapan(request.Context(), kodeOpd, tahun, "RPJMD")
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -611,24 +535,18 @@ func (controller *TujuanOpdControllerImpl) CreateTujuanRenjaPenetapanIndikator(w
 	tujuanOpdId := params.ByName("tujuanOpdId")
 	tujuanOpdIdInt, err := strconv.Atoi(tujuanOpdId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	indikatorCreateRequests := []tujuanopd.IndikatorCreateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorCreateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorCreateRequests) {
+		return
+	}
+
 	indikatorResponses, err := controller.TujuanOpdService.CreateTujuanRenjaIndikator(request.Context(), tujuanOpdIdInt, "penetapan", indikatorCreateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -655,14 +573,13 @@ func (controller *TujuanOpdControllerImpl) UpdateTujuanRenjaPenetapanIndikator(w
 
 	indikatorUpdateRequests := tujuanopd.IndikatorUpdateRequest{}
 	helper.ReadFromRequestBody(request, &indikatorUpdateRequests)
+	if !helper.ValidateRequest(writer, request.Context(), indikatorUpdateRequests) {
+		return
+	}
+
 	indikatorResponses, err := controller.TujuanOpdService.UpdateTujuanRenjaIndikator(request.Context(), kodeIndikator, "penetapan", indikatorUpdateRequests)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
@@ -690,11 +607,7 @@ func (controller *TujuanOpdControllerImpl) TujuanOpdPenetapan(writer http.Respon
 
 	tujuanOpdResponses, err := controller.TujuanOpdService.TujuanOpdPenetapan(request.Context(), kodeOpd, tahun, "RPJMD")
 	if err != nil {
-		helper.WriteToResponseBody(writer, web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		})
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 	helper.WriteToResponseBody(writer, web.WebResponse{
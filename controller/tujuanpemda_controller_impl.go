@@ -27,14 +27,13 @@ func (controller *TujuanPemdaControllerImpl) Create(writer http.ResponseWriter,
 	helper.ReadFromRequestBody(request, &tujuanPemdaCreateRequest)
 
 	// Panggil service create
+	if !helper.ValidateRequest(writer, request.Context(), tujuanPemdaCreateRequest) {
+		return
+	}
+
 	tujuanPemdaResponse, err := controller.TujuanPemdaService.Create(request.Context(), tujuanPemdaCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -53,25 +52,19 @@ func (controller *TujuanPemdaControllerImpl) Update(writer http.ResponseWriter,
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 	tujuanPemdaUpdateRequest.Id = idInt
 
 	// Panggil service update
+	if !helper.ValidateRequest(writer, request.Context(), tujuanPemdaUpdateRequest) {
+		return
+	}
+
 	tujuanPemdaResponse, err := controller.TujuanPemdaService.Update(request.Context(), tujuanPemdaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -87,23 +80,13 @@ func (controller *TujuanPemdaControllerImpl) Delete(writer http.ResponseWriter,
 	tujuanPemdaId := params.ByName("id")
 	id, err := strconv.Atoi(tujuanPemdaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 
 	err = controller.TujuanPemdaService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -119,23 +102,13 @@ func (controller *TujuanPemdaControllerImpl) FindById(writer http.ResponseWriter
 
 	id, err := strconv.Atoi(tujuanPemdaId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 
 	tujuanPemdaResponse, err := controller.TujuanPemdaService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusNotFound,
-			Status: "NOT FOUND",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusNotFound)
 		return
 	}
 
@@ -152,12 +125,7 @@ func (controller *TujuanPemdaControllerImpl) FindAll(writer http.ResponseWriter,
 	jenisPeriode := params.ByName("jenis_periode")
 	tujuanPemdaResponses, err := controller.TujuanPemdaService.FindAll(request.Context(), tahun, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -176,25 +144,19 @@ func (controller *TujuanPemdaControllerImpl) UpdatePeriode(writer http.ResponseW
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 	tujuanPemdaUpdateRequest.Id = idInt
 
 	// Panggil service update
+	if !helper.ValidateRequest(writer, request.Context(), tujuanPemdaUpdateRequest) {
+		return
+	}
+
 	tujuanPemdaResponse, err := controller.TujuanPemdaService.UpdatePeriode(request.Context(), tujuanPemdaUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -212,12 +174,7 @@ func (controller *TujuanPemdaControllerImpl) FindAllWithPokin(writer http.Respon
 	jenisPeriode := params.ByName("jenis_periode")
 	tujuanPemdaResponses, err := controller.TujuanPemdaService.FindAllWithPokin(request.Context(), tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -234,23 +191,13 @@ func (controller *TujuanPemdaControllerImpl) FindPokinWithPeriode(writer http.Re
 	jenisPeriode := params.ByName("jenis_periode")
 	id, err := strconv.Atoi(pokinId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid ID format",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid ID format")
 		return
 	}
 
 	pokinWithPeriodeResponse, err := controller.TujuanPemdaService.FindPokinWithPeriode(request.Context(), id, jenisPeriode)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
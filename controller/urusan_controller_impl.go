@@ -24,14 +24,13 @@ func (controller *UrusanControllerImpl) Create(writer http.ResponseWriter, reque
 	urusanCreateRequest := urusanrespon.UrusanCreateRequest{}
 	helper.ReadFromRequestBody(request, &urusanCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), urusanCreateRequest) {
+		return
+	}
+
 	urusanResponse, err := controller.UrusanService.Create(request.Context(), urusanCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -49,14 +48,13 @@ func (controller *UrusanControllerImpl) Update(writer http.ResponseWriter, reque
 
 	urusanUpdateRequest.Id = params.ByName("id")
 
+	if !helper.ValidateRequest(writer, request.Context(), urusanUpdateRequest) {
+		return
+	}
+
 	urusanResponse, err := controller.UrusanService.Update(request.Context(), urusanUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -73,12 +71,7 @@ func (controller *UrusanControllerImpl) FindById(writer http.ResponseWriter, req
 
 	urusanResponse, err := controller.UrusanService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -93,12 +86,7 @@ func (controller *UrusanControllerImpl) FindById(writer http.ResponseWriter, req
 func (controller *UrusanControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	urusanResponses, err := controller.UrusanService.FindAll(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -115,12 +103,7 @@ func (controller *UrusanControllerImpl) Delete(writer http.ResponseWriter, reque
 
 	err := controller.UrusanService.Delete(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -136,12 +119,7 @@ func (controller *UrusanControllerImpl) FindByKodeOpd(writer http.ResponseWriter
 
 	urusanResponses, err := controller.UrusanService.FindByKodeOpd(request.Context(), kodeOpd)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -158,12 +136,7 @@ func (controller *UrusanControllerImpl) FindUrusanAndBidangByKodeOpd(writer http
 
 	urusanResponses, err := controller.UrusanService.FindUrusanAndBidangByKodeOpd(request.Context(), kodeOpd)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -13,6 +13,12 @@ type UserController interface {
 	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	Login(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	RefreshToken(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Logout(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ChangePassword(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ResetPassword(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	ConfirmResetPassword(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	UnlockLogin(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindByKodeOpdAndRole(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	FindByNip(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	CekAdminOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
@@ -25,14 +25,13 @@ func (controller *UserControllerImpl) Create(writer http.ResponseWriter, request
 	userCreateRequest := user.UserCreateRequest{}
 	helper.ReadFromRequestBody(request, &userCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), userCreateRequest) {
+		return
+	}
+
 	userResponse, err := controller.userService.Create(request.Context(), userCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed create user",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 	webResponse := web.WebResponse{
@@ -49,12 +48,7 @@ func (controller *UserControllerImpl) Update(writer http.ResponseWriter, request
 	userId := params.ByName("id")
 	id, err := strconv.Atoi(userId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed update user",
-			Data:   "invalid user id",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "invalid user id")
 		return
 	}
 
@@ -63,14 +57,13 @@ func (controller *UserControllerImpl) Update(writer http.ResponseWriter, request
 
 	userUpdateRequest.Id = id
 
+	if !helper.ValidateRequest(writer, request.Context(), userUpdateRequest) {
+		return
+	}
+
 	userResponse, err := controller.userService.Update(request.Context(), userUpdateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed update user",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -87,12 +80,7 @@ func (controller *UserControllerImpl) Delete(writer http.ResponseWriter, request
 	userId := params.ByName("id")
 	id, err := strconv.Atoi(userId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed delete user",
-			Data:   "invalid user id",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "invalid user id")
 		return
 	}
 
@@ -110,12 +98,7 @@ func (controller *UserControllerImpl) FindAll(writer http.ResponseWriter, reques
 	kodeOpd := request.URL.Query().Get("kode_opd")
 	userResponses, err := controller.userService.FindAll(request.Context(), kodeOpd)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed find all user",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -132,23 +115,13 @@ func (controller *UserControllerImpl) FindById(writer http.ResponseWriter, reque
 	userId := params.ByName("id")
 	id, err := strconv.Atoi(userId)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed find by id user",
-			Data:   "invalid user id",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "invalid user id")
 		return
 	}
 
 	userResponse, err := controller.userService.FindById(request.Context(), id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed find by id user",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -174,15 +147,42 @@ func (controller *UserControllerImpl) FindById(writer http.ResponseWriter, reque
 func (controller *UserControllerImpl) Login(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	loginRequest := user.UserLoginRequest{}
 	helper.ReadFromRequestBody(request, &loginRequest)
+	loginRequest.ClientIp = helper.GetClientIP(request)
+
+	if !helper.ValidateRequest(writer, request.Context(), loginRequest) {
+		return
+	}
 
 	loginResponse, err := controller.userService.Login(request.Context(), loginRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data: map[string]interface{}{
+			"token":         loginResponse.Token,
+			"refresh_token": loginResponse.RefreshToken,
+			"expires_in":    loginResponse.ExpiresIn,
+		},
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *UserControllerImpl) RefreshToken(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	refreshRequest := user.UserRefreshRequest{}
+	helper.ReadFromRequestBody(request, &refreshRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), refreshRequest) {
+		return
+	}
+
+	loginResponse, err := controller.userService.RefreshToken(request.Context(), refreshRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusUnauthorized)
 		return
 	}
 
@@ -190,25 +190,47 @@ func (controller *UserControllerImpl) Login(writer http.ResponseWriter, request
 		Code:   http.StatusOK,
 		Status: "OK",
 		Data: map[string]interface{}{
-			"token": loginResponse.Token,
+			"token":         loginResponse.Token,
+			"refresh_token": loginResponse.RefreshToken,
+			"expires_in":    loginResponse.ExpiresIn,
 		},
 	}
 
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
+func (controller *UserControllerImpl) Logout(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	logoutRequest := user.UserLogoutRequest{}
+	if request.ContentLength > 0 {
+		helper.ReadFromRequestBody(request, &logoutRequest)
+	}
+
+	if !helper.ValidateRequest(writer, request.Context(), logoutRequest) {
+		return
+	}
+
+	err := controller.userService.Logout(request.Context(), logoutRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "berhasil logout",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
 func (controller *UserControllerImpl) FindByKodeOpdAndRole(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	kodeOpd := request.URL.Query().Get("kode_opd")
 	roleName := request.URL.Query().Get("role")
 
 	userResponses, err := controller.userService.FindByKodeOpdAndRole(request.Context(), kodeOpd, roleName)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed find by kode opd and role",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -226,12 +248,7 @@ func (controller *UserControllerImpl) FindByNip(writer http.ResponseWriter, requ
 
 	userResponse, err := controller.userService.FindByNip(request.Context(), nip)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   400,
-			Status: "failed find by nip",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -247,12 +264,7 @@ func (controller *UserControllerImpl) FindByNip(writer http.ResponseWriter, requ
 func (controller *UserControllerImpl) CekAdminOpd(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	response, err := controller.userService.CekAdminOpd(request.Context())
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   500,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -264,3 +276,93 @@ func (controller *UserControllerImpl) CekAdminOpd(writer http.ResponseWriter, re
 
 	helper.WriteToResponseBody(writer, webResponse)
 }
+
+func (controller *UserControllerImpl) ChangePassword(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	changePasswordRequest := user.UserChangePasswordRequest{}
+	helper.ReadFromRequestBody(request, &changePasswordRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), changePasswordRequest) {
+		return
+	}
+
+	err := controller.userService.ChangePassword(request.Context(), changePasswordRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "password berhasil diubah, silakan login kembali",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *UserControllerImpl) ResetPassword(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		helper.WriteError(writer, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	resetResponse, err := controller.userService.ResetPassword(request.Context(), id)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   resetResponse,
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *UserControllerImpl) ConfirmResetPassword(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	confirmRequest := user.UserResetPasswordConfirmRequest{}
+	helper.ReadFromRequestBody(request, &confirmRequest)
+
+	if !helper.ValidateRequest(writer, request.Context(), confirmRequest) {
+		return
+	}
+
+	err := controller.userService.ConfirmResetPassword(request.Context(), confirmRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "password berhasil direset, silakan login kembali",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
+
+func (controller *UserControllerImpl) UnlockLogin(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	id, err := strconv.Atoi(params.ByName("id"))
+	if err != nil {
+		helper.WriteError(writer, http.StatusBadRequest, "invalid user id")
+		return
+	}
+
+	err = controller.userService.UnlockLogin(request.Context(), id)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "OK",
+		Data:   "kunci login user berhasil dibuka",
+	}
+
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -33,24 +33,18 @@ func (controller *UsulanInisiatifControllerImpl) Create(writer http.ResponseWrit
 	}
 
 	if pegawaiID == "" {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid pegawai_id: not found in URL params or request body",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid pegawai_id: not found in URL params or request body")
 		return
 	}
 	usulanInovasiCreateRequest.PegawaiId = pegawaiID
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanInovasiCreateRequest) {
+		return
+	}
+
 	usulanInovasiResponse, err := controller.UsulanInisiatifService.Create(request.Context(), usulanInovasiCreateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -68,12 +62,7 @@ func (controller *UsulanInisiatifControllerImpl) Update(writer http.ResponseWrit
 
 	idUsulan := params.ByName("id")
 	if idUsulan == "" {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid id usulan parameter",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid id usulan parameter")
 		return
 	}
 	usulanInovasiUpdateRequest.Id = idUsulan
@@ -81,24 +70,18 @@ func (controller *UsulanInisiatifControllerImpl) Update(writer http.ResponseWrit
 	pegawaiID := params.ByName("pegawai_id")
 	if pegawaiID != "" {
 		if usulanInovasiUpdateRequest.PegawaiId != pegawaiID {
-			webResponse := web.WebUsulanInisiatifResponse{
-				Code:   http.StatusForbidden,
-				Status: "FORBIDDEN",
-				Data:   "Tidak dapat mengedit usulan pegawai lain",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusForbidden, "Tidak dapat mengedit usulan pegawai lain")
 			return
 		}
 	}
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanInovasiUpdateRequest) {
+		return
+	}
+
 	usulanInovasiResponse, err := controller.UsulanInisiatifService.Update(request.Context(), usulanInovasiUpdateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -129,12 +112,7 @@ func (controller *UsulanInisiatifControllerImpl) FindAll(writer http.ResponseWri
 	if isActive != "" {
 		isActiveBool, err := strconv.ParseBool(isActive)
 		if err != nil {
-			webResponse := web.WebUsulanInisiatifResponse{
-				Code:   http.StatusBadRequest,
-				Status: "BAD REQUEST",
-				Data:   "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusBadRequest, "Parameter is_active harus berupa boolean")
 			return
 		}
 		isActivePtr = &isActiveBool
@@ -142,12 +120,7 @@ func (controller *UsulanInisiatifControllerImpl) FindAll(writer http.ResponseWri
 
 	usulanInovasiResponses, err := controller.UsulanInisiatifService.FindAll(request.Context(), pegawaiIDPtr, isActivePtr, rekinIDPtr)
 	if err != nil {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -164,12 +137,7 @@ func (controller *UsulanInisiatifControllerImpl) FindById(writer http.ResponseWr
 
 	usulanInovasiResponse, err := controller.UsulanInisiatifService.FindById(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -184,23 +152,13 @@ func (controller *UsulanInisiatifControllerImpl) FindById(writer http.ResponseWr
 func (controller *UsulanInisiatifControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	idUsulan := params.ByName("id")
 	if idUsulan == "" {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid id usulan parameter",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid id usulan parameter")
 		return
 	}
 
 	err := controller.UsulanInisiatifService.Delete(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -230,12 +188,7 @@ func (controller *UsulanInisiatifControllerImpl) FindAllByRekin(writer http.Resp
 	if isActive != "" {
 		isActiveBool, err := strconv.ParseBool(isActive)
 		if err != nil {
-			webResponse := web.WebUsulanInisiatifResponse{
-				Code:   http.StatusBadRequest,
-				Status: "BAD REQUEST",
-				Data:   "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusBadRequest, "Parameter is_active harus berupa boolean")
 			return
 		}
 		isActivePtr = &isActiveBool
@@ -243,12 +196,7 @@ func (controller *UsulanInisiatifControllerImpl) FindAllByRekin(writer http.Resp
 
 	usulanInisiatifResponses, err := controller.UsulanInisiatifService.FindAll(request.Context(), pegawaiIDPtr, isActivePtr, rekinIDPtr)
 	if err != nil {
-		webResponse := web.WebUsulanInisiatifResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -33,24 +33,18 @@ func (controller *UsulanMandatoriControllerImpl) Create(writer http.ResponseWrit
 	}
 
 	if pegawaiID == "" {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid pegawai_id: not found in URL params or request body",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid pegawai_id: not found in URL params or request body")
 		return
 	}
 	usulanMandatoriCreateRequest.PegawaiId = pegawaiID
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanMandatoriCreateRequest) {
+		return
+	}
+
 	usulanMandatoriResponse, err := controller.UsulanMandatoriService.Create(request.Context(), usulanMandatoriCreateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -68,12 +62,7 @@ func (controller *UsulanMandatoriControllerImpl) Update(writer http.ResponseWrit
 
 	idUsulan := params.ByName("id")
 	if idUsulan == "" {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid id usulan parameter",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid id usulan parameter")
 		return
 	}
 	usulanMandatoriUpdateRequest.Id = idUsulan
@@ -81,24 +70,18 @@ func (controller *UsulanMandatoriControllerImpl) Update(writer http.ResponseWrit
 	pegawaiID := params.ByName("pegawai_id")
 	if pegawaiID != "" {
 		if usulanMandatoriUpdateRequest.PegawaiId != pegawaiID {
-			webResponse := web.WebUsulanMandatoriResponse{
-				Code:   http.StatusForbidden,
-				Status: "FORBIDDEN",
-				Data:   "Tidak dapat mengedit usulan pegawai lain",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusForbidden, "Tidak dapat mengedit usulan pegawai lain")
 			return
 		}
 	}
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanMandatoriUpdateRequest) {
+		return
+	}
+
 	usulanMandatoriResponse, err := controller.UsulanMandatoriService.Update(request.Context(), usulanMandatoriUpdateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -130,12 +113,7 @@ func (controller *UsulanMandatoriControllerImpl) FindAll(writer http.ResponseWri
 	if isActive != "" {
 		isActiveBool, err := strconv.ParseBool(isActive)
 		if err != nil {
-			webResponse := web.WebUsulanMusrebangResponse{
-				Code:   http.StatusBadRequest,
-				Status: "BAD REQUEST",
-				Data:   "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusBadRequest, "Parameter is_active harus berupa boolean")
 			return
 		}
 		isActivePtr = &isActiveBool
@@ -148,12 +126,7 @@ func (controller *UsulanMandatoriControllerImpl) FindAll(writer http.ResponseWri
 
 	usulanMandatoriResponses, err := controller.UsulanMandatoriService.FindAll(request.Context(), kodeOpdPtr, pegawaiIDPtr, isActivePtr, rekinIDPtr)
 	if err != nil {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -170,12 +143,7 @@ func (controller *UsulanMandatoriControllerImpl) FindById(writer http.ResponseWr
 
 	usulanMandatoriResponse, err := controller.UsulanMandatoriService.FindById(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -190,23 +158,13 @@ func (controller *UsulanMandatoriControllerImpl) FindById(writer http.ResponseWr
 func (controller *UsulanMandatoriControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	idUsulan := params.ByName("id")
 	if idUsulan == "" {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid id usulan parameter",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid id usulan parameter")
 		return
 	}
 
 	err := controller.UsulanMandatoriService.Delete(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -237,12 +195,7 @@ func (controller *UsulanMandatoriControllerImpl) FindAllByRekin(writer http.Resp
 	if isActive != "" {
 		isActiveBool, err := strconv.ParseBool(isActive)
 		if err != nil {
-			webResponse := web.WebUsulanMandatoriResponse{
-				Code:        http.StatusBadRequest,
-				Status:      "BAD REQUEST",
-				DataPilihan: "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusBadRequest, "Parameter is_active harus berupa boolean")
 			return
 		}
 		isActivePtr = &isActiveBool
@@ -255,12 +208,7 @@ func (controller *UsulanMandatoriControllerImpl) FindAllByRekin(writer http.Resp
 
 	usulanMandatoriResponses, err := controller.UsulanMandatoriService.FindAll(request.Context(), kodeOpdPtr, pegawaiIDPtr, isActivePtr, rekinIDPtr)
 	if err != nil {
-		webResponse := web.WebUsulanMandatoriResponse{
-			Code:        http.StatusBadRequest,
-			Status:      "BAD REQUEST",
-			DataPilihan: err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -15,4 +15,5 @@ type UsulanMusrebangController interface {
 	FindAllRekin(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	CreateRekin(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 	DeleteUsulanTerpilih(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Import(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
 }
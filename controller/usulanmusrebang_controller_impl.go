@@ -27,14 +27,13 @@ func (controller *UsulanMusrebangControllerImpl) Create(writer http.ResponseWrit
 	usulanMusrebangCreateRequest := usulan.UsulanMusrebangCreateRequest{}
 	helper.ReadFromRequestBody(request, &usulanMusrebangCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanMusrebangCreateRequest) {
+		return
+	}
+
 	usulanMusrebangResponse, err := controller.UsulanMusrebangService.Create(request.Context(), usulanMusrebangCreateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -53,25 +52,19 @@ func (controller *UsulanMusrebangControllerImpl) Update(writer http.ResponseWrit
 	// Ambil id usulan dari params URL
 	idUsulan := params.ByName("id")
 	if idUsulan == "" {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid id usulan parameter",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid id usulan parameter")
 		return
 	}
 	usulanMusrebangUpdateRequest.Id = idUsulan
 
 	// Lakukan update
+	if !helper.ValidateRequest(writer, request.Context(), usulanMusrebangUpdateRequest) {
+		return
+	}
+
 	usulanMusrebangResponse, err := controller.UsulanMusrebangService.Update(request.Context(), usulanMusrebangUpdateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -103,12 +96,7 @@ func (controller *UsulanMusrebangControllerImpl) FindAll(writer http.ResponseWri
 	if isActive != "" {
 		isActiveBool, err := strconv.ParseBool(isActive)
 		if err != nil {
-			webResponse := web.WebUsulanMusrebangResponse{
-				Code:   http.StatusBadRequest,
-				Status: "BAD REQUEST",
-				Data:   "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusBadRequest, "Parameter is_active harus berupa boolean")
 			return
 		}
 		isActivePtr = &isActiveBool
@@ -121,12 +109,7 @@ func (controller *UsulanMusrebangControllerImpl) FindAll(writer http.ResponseWri
 
 	usulanMusrebangResponses, err := controller.UsulanMusrebangService.FindAll(request.Context(), kodeOpdPtr, isActivePtr, rekinIDPtr, statusPtr)
 	if err != nil {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -143,12 +126,7 @@ func (controller *UsulanMusrebangControllerImpl) FindById(writer http.ResponseWr
 
 	usulanMusrebangResponse, err := controller.UsulanMusrebangService.FindById(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -164,23 +142,13 @@ func (controller *UsulanMusrebangControllerImpl) Delete(writer http.ResponseWrit
 
 	idUsulan := params.ByName("id")
 	if idUsulan == "" {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid id usulan parameter",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid id usulan parameter")
 		return
 	}
 
 	err := controller.UsulanMusrebangService.Delete(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -211,12 +179,7 @@ func (controller *UsulanMusrebangControllerImpl) FindAllRekin(writer http.Respon
 	if isActive != "" {
 		isActiveBool, err := strconv.ParseBool(isActive)
 		if err != nil {
-			webResponse := web.WebUsulanMusrebangResponse{
-				Code:        http.StatusBadRequest,
-				Status:      "BAD REQUEST",
-				DataPilihan: "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusBadRequest, "Parameter is_active harus berupa boolean")
 			return
 		}
 		isActivePtr = &isActiveBool
@@ -229,12 +192,7 @@ func (controller *UsulanMusrebangControllerImpl) FindAllRekin(writer http.Respon
 
 	usulanMusrebangResponses, err := controller.UsulanMusrebangService.FindAll(request.Context(), pegawaiIDPtr, isActivePtr, rekinIDPtr, statusPtr)
 	if err != nil {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:        http.StatusBadRequest,
-			Status:      "BAD REQUEST",
-			DataPilihan: err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -286,14 +244,13 @@ func (controller *UsulanMusrebangControllerImpl) CreateRekin(writer http.Respons
 	idRekin := params.ByName("rencana_kinerja_id")
 	usulanMusrebangCreateRekinRequest.RekinId = idRekin
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanMusrebangCreateRekinRequest) {
+		return
+	}
+
 	usulanMusrebangResponse, err := controller.UsulanMusrebangService.CreateRekin(request.Context(), usulanMusrebangCreateRekinRequest)
 	if err != nil {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -310,12 +267,7 @@ func (controller *UsulanMusrebangControllerImpl) DeleteUsulanTerpilih(writer htt
 
 	err := controller.UsulanMusrebangService.DeleteUsulanTerpilih(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanMusrebangResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -325,3 +277,36 @@ func (controller *UsulanMusrebangControllerImpl) DeleteUsulanTerpilih(writer htt
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
+
+// Import menerima file csv/xlsx (form field "file"), tanpa simpan=true hanya preview laporan per baris
+func (controller *UsulanMusrebangControllerImpl) Import(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	content, err := helper.ReadUploadFile(writer, request, "file", helper.MaksUkuranUpload)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+
+	simpan := request.URL.Query().Get("simpan") == "true"
+	laporan, err := controller.UsulanMusrebangService.Import(request.Context(), params.ByName("tahun"), content, simpan)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+	writeLaporanImportUsulan(writer, laporan)
+}
+
+func writeLaporanImportUsulan(writer http.ResponseWriter, laporan usulan.UsulanImportResponse) {
+	webResponse := web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "preview import, kirim ulang dengan simpan=true untuk menyimpan baris yang diterima",
+		Data:   laporan,
+	}
+	switch {
+	case laporan.Disimpan:
+		webResponse.Status = "success import usulan"
+	case laporan.JumlahDiterima == 0:
+		webResponse.Code = http.StatusUnprocessableEntity
+		webResponse.Status = "tidak ada baris yang dapat diimport"
+	}
+	helper.WriteToResponseBody(writer, webResponse)
+}
@@ -0,0 +1,15 @@
+package controller
+
+import (
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type UsulanPipelineController interface {
+	FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Transisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	Rekap(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+	FindAturan(writer http.ResponseWriter, request *http.Request, params httprouter.Params)
+}
@@ -0,0 +1,149 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/usulan"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type UsulanPipelineControllerImpl struct {
+	UsulanPipelineService service.UsulanPipelineService
+}
+
+func NewUsulanPipelineControllerImpl(usulanPipelineService service.UsulanPipelineService) *UsulanPipelineControllerImpl {
+	return &UsulanPipelineControllerImpl{
+		UsulanPipelineService: usulanPipelineService,
+	}
+}
+
+// @Summary      Daftar pipeline usulan OPD
+// @Description  Gabungan usulan musrebang, mandatori, pokok pikiran dan inisiatif beserta tahap, rencana kinerja, subkegiatan terpilih dan pagunya.
+// @Tags         Usulan Pipeline
+// @Produce      json
+// @Param        kode_opd      path   string  true   "Kode OPD"
+// @Param        tahun         path   string  true   "Tahun usulan"
+// @Param        jenis_usulan  query  string  false  "musrebang, mandatori, pokok_pikiran atau inisiatif"
+// @Param        tahap         query  string  false  "Filter tahap usulan"
+// @Param        jenis_pagu    query  string  false  "Jenis pagu subkegiatan, default penetapan"
+// @Success      200  {object}  web.WebResponse{data=[]usulan.UsulanPipelineResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /usulan_pipeline/opd/{kode_opd}/{tahun} [get]
+func (controller *UsulanPipelineControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	pipelineResponse, err := controller.UsulanPipelineService.FindAll(request.Context(), params.ByName("kode_opd"), params.ByName("tahun"), query.Get("jenis_usulan"), query.Get("tahap"), query.Get("jenis_pagu"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get pipeline usulan",
+		Data:   pipelineResponse,
+	})
+}
+
+// @Summary      Detail pipeline usulan
+// @Description  Tahap usulan, aksi yang tersedia untuk user dan riwayat perpindahan tahap.
+// @Tags         Usulan Pipeline
+// @Produce      json
+// @Param        jenis_usulan  path   string  true   "musrebang, mandatori, pokok_pikiran atau inisiatif"
+// @Param        id            path   string  true   "ID usulan"
+// @Param        jenis_pagu    query  string  false  "Jenis pagu subkegiatan, default penetapan"
+// @Success      200  {object}  web.WebResponse{data=usulan.UsulanPipelineResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /usulan_pipeline/detail/{jenis_usulan}/{id} [get]
+func (controller *UsulanPipelineControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	pipelineResponse, err := controller.UsulanPipelineService.FindById(request.Context(), params.ByName("jenis_usulan"), params.ByName("id"), request.URL.Query().Get("jenis_pagu"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get detail pipeline usulan",
+		Data:   pipelineResponse,
+	})
+}
+
+// @Summary      Transisi tahap usulan
+// @Description  Memindahkan usulan ke tahap berikutnya. Akomodir mensyaratkan usulan sudah dipilih ke rekin, danai mensyaratkan subkegiatan rekin sudah berpagu, tolak wajib disertai alasan.
+// @Tags         Usulan Pipeline
+// @Accept       json
+// @Produce      json
+// @Param        jenis_usulan  path  string                        true  "musrebang, mandatori, pokok_pikiran atau inisiatif"
+// @Param        id            path  string                        true  "ID usulan"
+// @Param        data          body  usulan.UsulanTransisiRequest  true  "Aksi dan alasan"
+// @Success      200  {object}  web.WebResponse{data=usulan.UsulanTahapRiwayatResponse}
+// @Failure      403  {object}  web.ErrorResponse
+// @Failure      409  {object}  web.ErrorResponse
+// @Security     BearerAuth
+// @Router       /usulan_pipeline/transisi/{jenis_usulan}/{id} [post]
+func (controller *UsulanPipelineControllerImpl) Transisi(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	transisiRequest := usulan.UsulanTransisiRequest{}
+	helper.ReadFromRequestBody(request, &transisiRequest)
+	transisiRequest.JenisUsulan = params.ByName("jenis_usulan")
+	transisiRequest.UsulanId = params.ByName("id")
+
+	if !helper.ValidateRequest(writer, request.Context(), transisiRequest) {
+		return
+	}
+
+	transisiResponse, err := controller.UsulanPipelineService.Transisi(request.Context(), transisiRequest)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success transisi tahap usulan",
+		Data:   transisiResponse,
+	})
+}
+
+// @Summary      Rekap usulan masuk renja
+// @Description  Jumlah usulan per tahap, yang diakomodir rekin, masuk renja melalui subkegiatan terpilih dan didanai, serta total pagu subkegiatan yang menampungnya.
+// @Tags         Usulan Pipeline
+// @Produce      json
+// @Param        kode_opd      path   string  true   "Kode OPD"
+// @Param        tahun         path   string  true   "Tahun usulan"
+// @Param        jenis_usulan  query  string  false  "musrebang, mandatori, pokok_pikiran atau inisiatif"
+// @Param        jenis_pagu    query  string  false  "Jenis pagu subkegiatan, default penetapan"
+// @Success      200  {object}  web.WebResponse{data=usulan.UsulanRekapResponse}
+// @Security     BearerAuth
+// @Router       /usulan_pipeline/rekap/{kode_opd}/{tahun} [get]
+func (controller *UsulanPipelineControllerImpl) Rekap(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	rekapResponse, err := controller.UsulanPipelineService.Rekap(request.Context(), params.ByName("kode_opd"), params.ByName("tahun"), query.Get("jenis_usulan"), query.Get("jenis_pagu"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get rekap usulan",
+		Data:   rekapResponse,
+	})
+}
+
+// @Summary      Aturan tahap usulan
+// @Tags         Usulan Pipeline
+// @Produce      json
+// @Success      200  {object}  web.WebResponse{data=[]usulan.UsulanAturanTransisiResponse}
+// @Router       /usulan_pipeline/aturan [get]
+func (controller *UsulanPipelineControllerImpl) FindAturan(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get aturan tahap usulan",
+		Data:   controller.UsulanPipelineService.FindAturan(),
+	})
+}
@@ -25,14 +25,13 @@ func (controller *UsulanPokokPikiranControllerImpl) Create(writer http.ResponseW
 	usulanPokokPikiranCreateRequest := usulan.UsulanPokokPikiranCreateRequest{}
 	helper.ReadFromRequestBody(request, &usulanPokokPikiranCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanPokokPikiranCreateRequest) {
+		return
+	}
+
 	usulanPokokPikiranResponse, err := controller.UsulanPokokPikiranService.Create(request.Context(), usulanPokokPikiranCreateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -50,24 +49,18 @@ func (controller *UsulanPokokPikiranControllerImpl) Update(writer http.ResponseW
 
 	idUsulan := params.ByName("id")
 	if idUsulan == "" {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid id usulan parameter",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid id usulan parameter")
 		return
 	}
 	usulanPokokPikiranUpdateRequest.Id = idUsulan
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanPokokPikiranUpdateRequest) {
+		return
+	}
+
 	usulanPokokPikiranResponse, err := controller.UsulanPokokPikiranService.Update(request.Context(), usulanPokokPikiranUpdateRequest)
 	if err != nil {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -99,12 +92,7 @@ func (controller *UsulanPokokPikiranControllerImpl) FindAll(writer http.Response
 	if isActive != "" {
 		isActiveBool, err := strconv.ParseBool(isActive)
 		if err != nil {
-			webResponse := web.WebUsulanPokokPikiranResponse{
-				Code:   http.StatusBadRequest,
-				Status: "BAD REQUEST",
-				Data:   "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusBadRequest, "Parameter is_active harus berupa boolean")
 			return
 		}
 		isActivePtr = &isActiveBool
@@ -117,12 +105,7 @@ func (controller *UsulanPokokPikiranControllerImpl) FindAll(writer http.Response
 
 	usulanPokokPikiranResponses, err := controller.UsulanPokokPikiranService.FindAll(request.Context(), kodeOpdPtr, isActivePtr, rekinIDPtr, statusPtr)
 	if err != nil {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -139,12 +122,7 @@ func (controller *UsulanPokokPikiranControllerImpl) FindById(writer http.Respons
 
 	usulanPokokPikiranResponse, err := controller.UsulanPokokPikiranService.FindById(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -159,23 +137,13 @@ func (controller *UsulanPokokPikiranControllerImpl) FindById(writer http.Respons
 func (controller *UsulanPokokPikiranControllerImpl) Delete(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
 	idUsulan := params.ByName("id")
 	if idUsulan == "" {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   "Invalid id usulan parameter",
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusBadRequest, "Invalid id usulan parameter")
 		return
 	}
 
 	err := controller.UsulanPokokPikiranService.Delete(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -206,12 +174,7 @@ func (controller *UsulanPokokPikiranControllerImpl) FindAllByRekin(writer http.R
 	if isActive != "" {
 		isActiveBool, err := strconv.ParseBool(isActive)
 		if err != nil {
-			webResponse := web.WebUsulanPokokPikiranResponse{
-				Code:   http.StatusBadRequest,
-				Status: "BAD REQUEST",
-				Data:   "Parameter is_active harus berupa boolean",
-			}
-			helper.WriteToResponseBody(writer, webResponse)
+			helper.WriteError(writer, http.StatusBadRequest, "Parameter is_active harus berupa boolean")
 			return
 		}
 		isActivePtr = &isActiveBool
@@ -224,12 +187,7 @@ func (controller *UsulanPokokPikiranControllerImpl) FindAllByRekin(writer http.R
 
 	usulanPokokPikiranResponses, err := controller.UsulanPokokPikiranService.FindAll(request.Context(), kodeOpdPtr, isActivePtr, rekinIDPtr, statusPtr)
 	if err != nil {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:        http.StatusBadRequest,
-			Status:      "BAD REQUEST",
-			DataPilihan: err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -248,14 +206,13 @@ func (controller *UsulanPokokPikiranControllerImpl) CreateRekin(writer http.Resp
 	idRekin := params.ByName("rencana_kinerja_id")
 	usulanPokokPikiranCreateRekinRequest.RekinId = idRekin
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanPokokPikiranCreateRekinRequest) {
+		return
+	}
+
 	usulanPokokPikiranResponse, err := controller.UsulanPokokPikiranService.CreateRekin(request.Context(), usulanPokokPikiranCreateRekinRequest)
 	if err != nil {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -272,12 +229,7 @@ func (controller *UsulanPokokPikiranControllerImpl) DeleteUsulanTerpilih(writer
 
 	err := controller.UsulanPokokPikiranService.DeleteUsulanTerpilih(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebUsulanPokokPikiranResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
@@ -287,3 +239,20 @@ func (controller *UsulanPokokPikiranControllerImpl) DeleteUsulanTerpilih(writer
 	}
 	helper.WriteToResponseBody(writer, webResponse)
 }
+
+// Import menerima file csv/xlsx (form field "file"), tanpa simpan=true hanya preview laporan per baris
+func (controller *UsulanPokokPikiranControllerImpl) Import(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	content, err := helper.ReadUploadFile(writer, request, "file", helper.MaksUkuranUpload)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+
+	simpan := request.URL.Query().Get("simpan") == "true"
+	laporan, err := controller.UsulanPokokPikiranService.Import(request.Context(), params.ByName("tahun"), content, simpan)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
+		return
+	}
+	writeLaporanImportUsulan(writer, laporan)
+}
@@ -0,0 +1,92 @@
+package controller
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/service"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+type UsulanPublikControllerImpl struct {
+	UsulanPublikService service.UsulanPublikService
+}
+
+func NewUsulanPublikControllerImpl(usulanPublikService service.UsulanPublikService) *UsulanPublikControllerImpl {
+	return &UsulanPublikControllerImpl{
+		UsulanPublikService: usulanPublikService,
+	}
+}
+
+// @Summary      Daftar status usulan untuk publik
+// @Description  Tanpa login dan dibatasi per IP. Menampilkan status usulan musrebang dan pokok pikiran, serta rencana kinerja dan subkegiatan bila usulan diakomodir.
+// @Tags         Usulan Publik
+// @Produce      json
+// @Param        tahun         query  string  true   "Tahun usulan"
+// @Param        kode_opd      query  string  false  "Kode OPD"
+// @Param        kecamatan     query  string  false  "Potongan alamat, misal nama kecamatan atau desa"
+// @Param        jenis_usulan  query  string  false  "musrebang atau pokok_pikiran"
+// @Param        limit         query  int     false  "Jumlah per halaman, default 50 maksimal 100"
+// @Param        offset        query  int     false  "Posisi awal halaman"
+// @Success      200  {object}  web.WebResponse{data=usulan.UsulanPublikListResponse}
+// @Failure      400  {object}  web.ErrorResponse
+// @Failure      429  {object}  web.ErrorResponse
+// @Router       /publik/usulan [get]
+func (controller *UsulanPublikControllerImpl) FindAll(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	query := request.URL.Query()
+	kecamatan := query.Get("kecamatan")
+	if kecamatan == "" {
+		kecamatan = query.Get("alamat")
+	}
+
+	var limit, offset int
+	for nama, nilai := range map[string]*int{"limit": &limit, "offset": &offset} {
+		if query.Get(nama) == "" {
+			continue
+		}
+		angka, err := strconv.Atoi(query.Get(nama))
+		if err != nil {
+			helper.WriteError(writer, http.StatusBadRequest, nama+" harus berupa angka")
+			return
+		}
+		*nilai = angka
+	}
+
+	usulanResponse, err := controller.UsulanPublikService.FindAll(request.Context(), query.Get("tahun"), query.Get("kode_opd"), kecamatan, query.Get("jenis_usulan"), limit, offset)
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get usulan publik",
+		Data:   usulanResponse,
+	})
+}
+
+// @Summary      Detail status usulan untuk publik
+// @Description  Tanpa login dan dibatasi per IP. Riwayat hanya memuat tahap dan tanggal, tanpa pelaku.
+// @Tags         Usulan Publik
+// @Produce      json
+// @Param        jenis_usulan  path  string  true  "musrebang atau pokok_pikiran"
+// @Param        id            path  string  true  "ID usulan"
+// @Success      200  {object}  web.WebResponse{data=usulan.UsulanPublikResponse}
+// @Failure      404  {object}  web.ErrorResponse
+// @Failure      429  {object}  web.ErrorResponse
+// @Router       /publik/usulan/{jenis_usulan}/{id} [get]
+func (controller *UsulanPublikControllerImpl) FindById(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+	usulanResponse, err := controller.UsulanPublikService.FindById(request.Context(), params.ByName("jenis_usulan"), params.ByName("id"))
+	if err != nil {
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	helper.WriteToResponseBody(writer, web.WebResponse{
+		Code:   http.StatusOK,
+		Status: "success get detail usulan publik",
+		Data:   usulanResponse,
+	})
+}
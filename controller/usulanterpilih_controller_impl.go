@@ -22,14 +22,13 @@ func (controller *UsulanTerpilihControllerImpl) Create(writer http.ResponseWrite
 	usulanTerpilihCreateRequest := usulan.UsulanTerpilihCreateRequest{}
 	helper.ReadFromRequestBody(request, &usulanTerpilihCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), usulanTerpilihCreateRequest) {
+		return
+	}
+
 	usulanTerpilihResponse, err := controller.UsulanTerpilihService.Create(request.Context(), usulanTerpilihCreateRequest)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -38,7 +37,6 @@ func (controller *UsulanTerpilihControllerImpl) Create(writer http.ResponseWrite
 		Status: "success create usulan terpilih",
 		Data:   usulanTerpilihResponse,
 	}
-	writer.WriteHeader(http.StatusCreated)
 	helper.WriteToResponseBody(writer, webResponse)
 }
 
@@ -47,12 +45,7 @@ func (controller *UsulanTerpilihControllerImpl) Delete(writer http.ResponseWrite
 
 	err := controller.UsulanTerpilihService.Delete(request.Context(), idUsulan)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusInternalServerError,
-			Status: "INTERNAL SERVER ERROR",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -60,6 +53,5 @@ func (controller *UsulanTerpilihControllerImpl) Delete(writer http.ResponseWrite
 		Code:   http.StatusOK,
 		Status: "success delete usulan terpilih",
 	}
-	writer.WriteHeader(http.StatusOK)
 	helper.WriteToResponseBody(writer, webResponse)
 }
@@ -23,9 +23,13 @@ func (controller *VisiPemdaControllerImpl) Create(writer http.ResponseWriter, re
 	visiPemdaCreateRequest := visimisipemda.VisiPemdaCreateRequest{}
 	helper.ReadFromRequestBody(request, &visiPemdaCreateRequest)
 
+	if !helper.ValidateRequest(writer, request.Context(), visiPemdaCreateRequest) {
+		return
+	}
+
 	visiPemdaResponse, err := controller.VisiPemdaService.Create(request.Context(), visiPemdaCreateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -44,20 +48,19 @@ func (controller *VisiPemdaControllerImpl) Update(writer http.ResponseWriter, re
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	visiPemdaUpdateRequest.Id = idInt
 
+	if !helper.ValidateRequest(writer, request.Context(), visiPemdaUpdateRequest) {
+		return
+	}
+
 	visiPemdaResponse, err := controller.VisiPemdaService.Update(request.Context(), visiPemdaUpdateRequest)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -74,18 +77,13 @@ func (controller *VisiPemdaControllerImpl) Delete(writer http.ResponseWriter, re
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	err = controller.VisiPemdaService.Delete(request.Context(), idInt)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -100,18 +98,13 @@ func (controller *VisiPemdaControllerImpl) FindById(writer http.ResponseWriter,
 	id := params.ByName("id")
 	idInt, err := strconv.Atoi(id)
 	if err != nil {
-		webResponse := web.WebResponse{
-			Code:   http.StatusBadRequest,
-			Status: "BAD REQUEST",
-			Data:   err.Error(),
-		}
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteErrorResponse(writer, err, http.StatusBadRequest)
 		return
 	}
 
 	visiPemdaResponse, err := controller.VisiPemdaService.FindById(request.Context(), idInt)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -130,7 +123,7 @@ func (controller *VisiPemdaControllerImpl) FindAll(writer http.ResponseWriter, r
 
 	visiPemdaResponses, err := controller.VisiPemdaService.FindAll(request.Context(), tahunAwal, tahunAkhir, jenisPeriode)
 	if err != nil {
-		helper.WriteToResponseBody(writer, err.Error())
+		helper.WriteErrorResponse(writer, err, http.StatusInternalServerError)
 		return
 	}
 
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tb_password_reset;
@@ -0,0 +1,11 @@
+CREATE TABLE tb_password_reset (
+    id         INT AUTO_INCREMENT PRIMARY KEY,
+    user_id    INT          NOT NULL,
+    token_hash VARCHAR(64)  NOT NULL,
+    expired_at DATETIME     NOT NULL,
+    used_at    DATETIME     NULL,
+    created_by VARCHAR(255) DEFAULT '',
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY uq_password_reset_token (token_hash),
+    KEY idx_password_reset_user (user_id)
+);
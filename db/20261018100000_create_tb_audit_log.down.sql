@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tb_audit_log;
@@ -0,0 +1,16 @@
+CREATE TABLE tb_audit_log (
+    id             BIGINT AUTO_INCREMENT PRIMARY KEY,
+    entity_type    VARCHAR(50)  NOT NULL,
+    entity_id      VARCHAR(255) NOT NULL,
+    action         VARCHAR(20)  NOT NULL,
+    kode_opd       VARCHAR(255) DEFAULT '',
+    tahun          VARCHAR(4)   DEFAULT '',
+    actor_user_id  INT          DEFAULT 0,
+    actor_nip      VARCHAR(255) DEFAULT '',
+    actor_kode_opd VARCHAR(255) DEFAULT '',
+    before_data    JSON         NULL,
+    after_data     JSON         NULL,
+    created_at     TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_audit_entity (entity_type, entity_id),
+    KEY idx_audit_opd_tahun (kode_opd, tahun)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tb_realisasi_indikator;
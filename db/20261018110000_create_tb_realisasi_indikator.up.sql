@@ -0,0 +1,16 @@
+CREATE TABLE tb_realisasi_indikator (
+    id            BIGINT AUTO_INCREMENT PRIMARY KEY,
+    indikator_id  VARCHAR(255) NOT NULL,
+    tahun         VARCHAR(4)   NOT NULL,
+    jenis_periode VARCHAR(20)  NOT NULL,
+    periode       INT          NOT NULL DEFAULT 1,
+    realisasi     VARCHAR(255) NOT NULL DEFAULT '',
+    satuan        VARCHAR(255) DEFAULT '',
+    bukti_dukung  TEXT,
+    keterangan    TEXT,
+    created_by    VARCHAR(255) DEFAULT '',
+    created_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at    TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_realisasi_periode (indikator_id, tahun, jenis_periode, periode),
+    KEY idx_realisasi_indikator_tahun (indikator_id, tahun)
+);
@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tb_progres_pelaksanaan_rencana_aksi;
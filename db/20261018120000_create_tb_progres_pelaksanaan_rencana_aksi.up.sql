@@ -0,0 +1,11 @@
+CREATE TABLE tb_progres_pelaksanaan_rencana_aksi (
+    id                          BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pelaksanaan_rencana_aksi_id VARCHAR(255)  NOT NULL,
+    persentase                  DECIMAL(5,2)  NOT NULL DEFAULT 0,
+    catatan                     TEXT,
+    kendala                     TEXT,
+    created_by                  VARCHAR(255) DEFAULT '',
+    created_at                  TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at                  TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_progres_pelaksanaan (pelaksanaan_rencana_aksi_id)
+);
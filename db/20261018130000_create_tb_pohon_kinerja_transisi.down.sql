@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tb_pohon_kinerja_transisi;
@@ -0,0 +1,13 @@
+CREATE TABLE tb_pohon_kinerja_transisi (
+    id               BIGINT AUTO_INCREMENT PRIMARY KEY,
+    pohon_kinerja_id INT          NOT NULL,
+    dari_status      VARCHAR(50)  NOT NULL DEFAULT '',
+    ke_status        VARCHAR(50)  NOT NULL,
+    aksi             VARCHAR(50)  NOT NULL,
+    alasan           TEXT,
+    actor_nip        VARCHAR(255) DEFAULT '',
+    actor_role       VARCHAR(50)  DEFAULT '',
+    actor_kode_opd   VARCHAR(255) DEFAULT '',
+    created_at       TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_transisi_pohon_kinerja (pohon_kinerja_id, created_at)
+);
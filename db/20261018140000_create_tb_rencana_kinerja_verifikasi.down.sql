@@ -0,0 +1 @@
+DROP TABLE IF EXISTS tb_rencana_kinerja_verifikasi;
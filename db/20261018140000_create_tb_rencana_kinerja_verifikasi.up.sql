@@ -0,0 +1,13 @@
+CREATE TABLE tb_rencana_kinerja_verifikasi (
+    id                 BIGINT AUTO_INCREMENT PRIMARY KEY,
+    rencana_kinerja_id VARCHAR(255) NOT NULL,
+    revisi             INT          NOT NULL DEFAULT 0,
+    dari_status        VARCHAR(50)  NOT NULL DEFAULT '',
+    ke_status          VARCHAR(50)  NOT NULL,
+    aksi               VARCHAR(50)  NOT NULL,
+    catatan            TEXT,
+    actor_nip          VARCHAR(255) DEFAULT '',
+    actor_peran        VARCHAR(50)  DEFAULT '',
+    created_at         TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_verifikasi_rencana_kinerja (rencana_kinerja_id, created_at)
+);
@@ -0,0 +1,6 @@
+ALTER TABLE clone_record
+  DROP INDEX idx_clone_record_job_id,
+  DROP COLUMN warnings,
+  DROP COLUMN progres,
+  DROP COLUMN job_id,
+  DROP COLUMN jenis;
@@ -0,0 +1,6 @@
+ALTER TABLE clone_record
+  ADD COLUMN jenis VARCHAR(50) NOT NULL DEFAULT 'rekin',
+  ADD COLUMN job_id VARCHAR(64) NOT NULL DEFAULT '',
+  ADD COLUMN progres TEXT,
+  ADD COLUMN warnings TEXT,
+  ADD INDEX idx_clone_record_job_id (job_id);
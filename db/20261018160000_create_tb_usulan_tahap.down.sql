@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS tb_usulan_tahap_riwayat;
+DROP TABLE IF EXISTS tb_usulan_tahap;
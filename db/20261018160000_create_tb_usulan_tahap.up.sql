@@ -0,0 +1,25 @@
+CREATE TABLE tb_usulan_tahap (
+    jenis_usulan VARCHAR(20)  NOT NULL,
+    usulan_id    VARCHAR(255) NOT NULL,
+    tahap        VARCHAR(30)  NOT NULL,
+    alasan       TEXT,
+    updated_by   VARCHAR(255) DEFAULT '',
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (jenis_usulan, usulan_id),
+    KEY idx_usulan_tahap_tahap (tahap)
+) ENGINE=InnoDB;
+
+CREATE TABLE tb_usulan_tahap_riwayat (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    jenis_usulan VARCHAR(20)  NOT NULL,
+    usulan_id    VARCHAR(255) NOT NULL,
+    dari_tahap   VARCHAR(30)  NOT NULL,
+    ke_tahap     VARCHAR(30)  NOT NULL,
+    aksi         VARCHAR(50)  NOT NULL,
+    alasan       TEXT,
+    actor_nip    VARCHAR(255) DEFAULT '',
+    actor_role   VARCHAR(50)  DEFAULT '',
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_usulan_tahap_riwayat (jenis_usulan, usulan_id, created_at)
+) ENGINE=InnoDB;
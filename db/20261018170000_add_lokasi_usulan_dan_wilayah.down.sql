@@ -0,0 +1,15 @@
+DROP TABLE IF EXISTS tb_indikator_lokasi;
+
+ALTER TABLE tb_usulan_pokok_pikiran
+    DROP COLUMN kode_wilayah,
+    DROP COLUMN geometri,
+    DROP COLUMN longitude,
+    DROP COLUMN latitude;
+
+ALTER TABLE tb_usulan_musrebang
+    DROP COLUMN kode_wilayah,
+    DROP COLUMN geometri,
+    DROP COLUMN longitude,
+    DROP COLUMN latitude;
+
+DROP TABLE IF EXISTS tb_wilayah;
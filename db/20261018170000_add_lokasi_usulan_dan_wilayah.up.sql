@@ -0,0 +1,37 @@
+CREATE TABLE tb_wilayah (
+    kode_wilayah VARCHAR(20)  NOT NULL,
+    nama         VARCHAR(255) NOT NULL,
+    tingkat      VARCHAR(20)  NOT NULL,
+    kode_induk   VARCHAR(20)  DEFAULT '',
+    geometri     JSON,
+    latitude     DECIMAL(10,7),
+    longitude    DECIMAL(10,7),
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    PRIMARY KEY (kode_wilayah),
+    KEY idx_wilayah_tingkat (tingkat, kode_induk)
+) ENGINE=InnoDB;
+
+ALTER TABLE tb_usulan_musrebang
+    ADD COLUMN latitude DECIMAL(10,7) NULL,
+    ADD COLUMN longitude DECIMAL(10,7) NULL,
+    ADD COLUMN geometri JSON NULL,
+    ADD COLUMN kode_wilayah VARCHAR(20) NULL;
+
+ALTER TABLE tb_usulan_pokok_pikiran
+    ADD COLUMN latitude DECIMAL(10,7) NULL,
+    ADD COLUMN longitude DECIMAL(10,7) NULL,
+    ADD COLUMN geometri JSON NULL,
+    ADD COLUMN kode_wilayah VARCHAR(20) NULL;
+
+CREATE TABLE tb_indikator_lokasi (
+    id           BIGINT AUTO_INCREMENT PRIMARY KEY,
+    indikator_id VARCHAR(255) NOT NULL,
+    kode_wilayah VARCHAR(20),
+    latitude     DECIMAL(10,7),
+    longitude    DECIMAL(10,7),
+    geometri     JSON,
+    keterangan   TEXT,
+    created_at   TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    KEY idx_indikator_lokasi (indikator_id)
+) ENGINE=InnoDB;
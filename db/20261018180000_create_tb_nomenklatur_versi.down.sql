@@ -0,0 +1,8 @@
+DROP TABLE IF EXISTS tb_nomenklatur_perubahan;
+DROP TABLE IF EXISTS tb_nomenklatur_versi;
+
+ALTER TABLE tb_subkegiatan DROP COLUMN is_deprecated;
+ALTER TABLE tb_master_kegiatan DROP COLUMN is_deprecated;
+ALTER TABLE tb_master_program DROP COLUMN is_deprecated;
+ALTER TABLE tb_bidang_urusan DROP COLUMN is_deprecated;
+ALTER TABLE tb_urusan DROP COLUMN is_deprecated;
@@ -0,0 +1,31 @@
+ALTER TABLE tb_urusan ADD COLUMN is_deprecated TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE tb_bidang_urusan ADD COLUMN is_deprecated TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE tb_master_program ADD COLUMN is_deprecated TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE tb_master_kegiatan ADD COLUMN is_deprecated TINYINT(1) NOT NULL DEFAULT 0;
+ALTER TABLE tb_subkegiatan ADD COLUMN is_deprecated TINYINT(1) NOT NULL DEFAULT 0;
+
+CREATE TABLE tb_nomenklatur_versi (
+    id                   BIGINT AUTO_INCREMENT PRIMARY KEY,
+    versi                VARCHAR(255) NOT NULL,
+    nama_file            VARCHAR(255) DEFAULT '',
+    jumlah_tambah        INT NOT NULL DEFAULT 0,
+    jumlah_ubah          INT NOT NULL DEFAULT 0,
+    jumlah_hapus         INT NOT NULL DEFAULT 0,
+    jumlah_deprecated    INT NOT NULL DEFAULT 0,
+    jumlah_aktif_kembali INT NOT NULL DEFAULT 0,
+    applied_by           VARCHAR(255) DEFAULT '',
+    created_at           TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE KEY unique_nomenklatur_versi (versi)
+) ENGINE=InnoDB;
+
+CREATE TABLE tb_nomenklatur_perubahan (
+    id        BIGINT AUTO_INCREMENT PRIMARY KEY,
+    versi_id  BIGINT NOT NULL,
+    tingkat   VARCHAR(20)  NOT NULL,
+    kode      VARCHAR(255) NOT NULL,
+    aksi      VARCHAR(20)  NOT NULL,
+    nama_lama TEXT,
+    nama_baru TEXT,
+    KEY idx_nomenklatur_perubahan_versi (versi_id),
+    CONSTRAINT fk_nomenklatur_perubahan_versi FOREIGN KEY (versi_id) REFERENCES tb_nomenklatur_versi(id) ON DELETE CASCADE
+) ENGINE=InnoDB;
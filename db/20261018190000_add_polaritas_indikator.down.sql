@@ -0,0 +1,2 @@
+ALTER TABLE tb_indikator_matrix DROP COLUMN polaritas;
+ALTER TABLE tb_indikator DROP COLUMN polaritas;
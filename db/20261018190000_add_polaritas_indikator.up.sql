@@ -0,0 +1,12 @@
+ALTER TABLE tb_indikator ADD COLUMN polaritas VARCHAR(10) NOT NULL DEFAULT 'positif';
+ALTER TABLE tb_indikator_matrix ADD COLUMN polaritas VARCHAR(10) NOT NULL DEFAULT 'positif';
+
+-- pengisian awal dari rumus perhitungan lama, selanjutnya polaritas diatur lewat endpoint realisasi
+UPDATE tb_indikator SET polaritas = 'negatif'
+WHERE LOWER(COALESCE(rumus_perhitungan, '')) REGEXP 'target[[:space:]]*(/|:|dibagi)[[:space:]]*realisasi'
+   OR LOWER(COALESCE(rumus_perhitungan, '')) LIKE '%semakin kecil%'
+   OR LOWER(COALESCE(rumus_perhitungan, '')) LIKE '%semakin rendah%';
+UPDATE tb_indikator_matrix SET polaritas = 'negatif'
+WHERE LOWER(COALESCE(rumus_perhitungan, '')) REGEXP 'target[[:space:]]*(/|:|dibagi)[[:space:]]*realisasi'
+   OR LOWER(COALESCE(rumus_perhitungan, '')) LIKE '%semakin kecil%'
+   OR LOWER(COALESCE(rumus_perhitungan, '')) LIKE '%semakin rendah%';
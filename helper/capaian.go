@@ -0,0 +1,99 @@
+package helper
+
+import (
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const (
+	PolaritasPositif = "positif" // semakin tinggi realisasi semakin baik
+	PolaritasNegatif = "negatif" // semakin rendah realisasi semakin baik
+)
+
+var (
+	angkaPattern  = regexp.MustCompile(`-?[0-9][0-9.,]*`)
+	ribuanPattern = regexp.MustCompile(`^[0-9]{1,3}(\.[0-9]{3})+$`)
+)
+
+// ParseAngka membaca angka dari teks target/realisasi dengan format id-ID: titik pemisah ribuan
+// dan koma desimal, mis. "80%", "1.250" (1250), "1.250,5", "0,75 indeks". Teks yang ambigu
+// seperti "0.75" atau "12.5" (desimal format en-US) ditolak agar capaian tidak salah hitung.
+func ParseAngka(value string) (float64, bool) {
+	match := angkaPattern.FindString(strings.TrimSpace(value))
+	// titik/koma di akhir dianggap tanda baca kalimat, mis. "12 dokumen."
+	match = strings.TrimRight(match, ".,")
+	if match == "" || match == "-" {
+		return 0, false
+	}
+
+	negatif := strings.HasPrefix(match, "-")
+	bulat, desimal, adaKoma := strings.Cut(strings.TrimPrefix(match, "-"), ",")
+	if adaKoma && (desimal == "" || strings.ContainsAny(desimal, ".,")) {
+		return 0, false
+	}
+	if strings.Contains(bulat, ".") {
+		if !ribuanPattern.MatchString(bulat) || strings.HasPrefix(bulat, "0") {
+			return 0, false
+		}
+		bulat = strings.ReplaceAll(bulat, ".", "")
+	}
+
+	angka := bulat
+	if adaKoma {
+		angka += "." + desimal
+	}
+	if negatif {
+		angka = "-" + angka
+	}
+	number, err := strconv.ParseFloat(angka, 64)
+	if err != nil {
+		return 0, false
+	}
+	return number, true
+}
+
+// FormatAngka menulis angka dalam format id-ID tanpa pemisah ribuan (koma desimal, maks. 2 desimal)
+// sehingga bisa dibaca kembali oleh ParseAngka
+func FormatAngka(value float64) string {
+	return strings.Replace(strconv.FormatFloat(math.Round(value*100)/100, 'f', -1, 64), ".", ",", 1)
+}
+
+// NormalisasiPolaritas polaritas kosong atau tidak dikenal dianggap positif
+func NormalisasiPolaritas(polaritas string) string {
+	if strings.ToLower(strings.TrimSpace(polaritas)) == PolaritasNegatif {
+		return PolaritasNegatif
+	}
+	return PolaritasPositif
+}
+
+// HitungCapaian menghitung capaian (%) dibulatkan 2 desimal.
+// Positif: realisasi / target x 100, negatif: target / realisasi x 100.
+func HitungCapaian(target, realisasi float64, polaritas string) (float64, bool) {
+	pembilang, penyebut := realisasi, target
+	if polaritas == PolaritasNegatif {
+		pembilang, penyebut = target, realisasi
+	}
+	if penyebut == 0 {
+		return 0, false
+	}
+	return math.Round(pembilang/penyebut*10000) / 100, true
+}
+
+// CapaianDariTeks seperti HitungCapaian tetapi menerima target dan realisasi dalam bentuk teks
+func CapaianDariTeks(target, realisasi, polaritas string) *float64 {
+	targetAngka, ok := ParseAngka(target)
+	if !ok {
+		return nil
+	}
+	realisasiAngka, ok := ParseAngka(realisasi)
+	if !ok {
+		return nil
+	}
+	capaian, ok := HitungCapaian(targetAngka, realisasiAngka, polaritas)
+	if !ok {
+		return nil
+	}
+	return &capaian
+}
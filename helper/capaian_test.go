@@ -0,0 +1,95 @@
+package helper
+
+import "testing"
+
+func TestParseAngka(t *testing.T) {
+	tests := []struct {
+		name     string
+		value    string
+		expected float64
+		ok       bool
+	}{
+		{name: "bilangan bulat", value: "80", expected: 80, ok: true},
+		{name: "persen", value: "92,5%", expected: 92.5, ok: true},
+		{name: "koma desimal", value: "0,75", expected: 0.75, ok: true},
+		{name: "titik ribuan", value: "1.250", expected: 1250, ok: true},
+		{name: "ribuan format indonesia", value: "1.250,5", expected: 1250.5, ok: true},
+		{name: "ribuan tanpa desimal", value: "1.250.000", expected: 1250000, ok: true},
+		{name: "negatif", value: "-2,5", expected: -2.5, ok: true},
+		{name: "dengan satuan", value: "12 dokumen", expected: 12, ok: true},
+		{name: "tanda baca di akhir", value: "12 dokumen.", expected: 12, ok: true},
+		{name: "desimal format en-US ditolak", value: "0.75", expected: 0, ok: false},
+		{name: "titik bukan kelompok ribuan ditolak", value: "12.5", expected: 0, ok: false},
+		{name: "kelompok ribuan tidak lengkap ditolak", value: "1.25.000", expected: 0, ok: false},
+		{name: "format en-US dengan koma ribuan ditolak", value: "1,250.5", expected: 0, ok: false},
+		{name: "koma lebih dari satu ditolak", value: "1,2,3", expected: 0, ok: false},
+		{name: "bukan angka", value: "baik", expected: 0, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := ParseAngka(tt.value)
+			if ok != tt.ok || result != tt.expected {
+				t.Errorf("ParseAngka(%q) = (%v, %v), expected (%v, %v)", tt.value, result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
+
+func TestFormatAngka(t *testing.T) {
+	for value, expected := range map[float64]string{
+		1250:      "1250",
+		92.5:      "92,5",
+		2.0 / 3.0: "0,67",
+		-2.5:      "-2,5",
+	} {
+		result := FormatAngka(value)
+		if result != expected {
+			t.Errorf("FormatAngka(%v) = %s, expected %s", value, result, expected)
+		}
+		if _, ok := ParseAngka(result); !ok {
+			t.Errorf("ParseAngka(FormatAngka(%v)) gagal membaca %s", value, result)
+		}
+	}
+}
+
+func TestNormalisasiPolaritas(t *testing.T) {
+	for value, expected := range map[string]string{
+		"negatif":  PolaritasNegatif,
+		" NEGATIF": PolaritasNegatif,
+		"positif":  PolaritasPositif,
+		"":         PolaritasPositif,
+		"lainnya":  PolaritasPositif,
+	} {
+		if result := NormalisasiPolaritas(value); result != expected {
+			t.Errorf("NormalisasiPolaritas(%q) = %s, expected %s", value, result, expected)
+		}
+	}
+}
+
+func TestHitungCapaian(t *testing.T) {
+	tests := []struct {
+		name      string
+		target    float64
+		realisasi float64
+		polaritas string
+		expected  float64
+		ok        bool
+	}{
+		{name: "positif tercapai sebagian", target: 80, realisasi: 60, polaritas: PolaritasPositif, expected: 75, ok: true},
+		{name: "positif melebihi target", target: 80, realisasi: 100, polaritas: PolaritasPositif, expected: 125, ok: true},
+		{name: "negatif lebih rendah dari target", target: 10, realisasi: 8, polaritas: PolaritasNegatif, expected: 125, ok: true},
+		{name: "pembulatan dua desimal", target: 3, realisasi: 1, polaritas: PolaritasPositif, expected: 33.33, ok: true},
+		{name: "target nol", target: 0, realisasi: 5, polaritas: PolaritasPositif, expected: 0, ok: false},
+		{name: "negatif realisasi nol", target: 5, realisasi: 0, polaritas: PolaritasNegatif, expected: 0, ok: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, ok := HitungCapaian(tt.target, tt.realisasi, tt.polaritas)
+			if ok != tt.ok || result != tt.expected {
+				t.Errorf("HitungCapaian() = (%v, %v), expected (%v, %v)", result, ok, tt.expected, tt.ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"net"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+)
+
+// trustedProxies diisi dari env TRUSTED_PROXIES (IP atau CIDR dipisah koma),
+// header X-Forwarded-For dan X-Real-IP hanya dipercaya jika request datang dari alamat ini
+var trustedProxies = sync.OnceValue(func() []*net.IPNet {
+	return ParseTrustedProxies(os.Getenv("TRUSTED_PROXIES"))
+})
+
+// ParseTrustedProxies IP tunggal dianggap /32 atau /128, entri tidak valid diabaikan
+func ParseTrustedProxies(daftar string) []*net.IPNet {
+	var result []*net.IPNet
+	for _, entri := range strings.Split(daftar, ",") {
+		entri = strings.TrimSpace(entri)
+		if entri == "" {
+			continue
+		}
+		if !strings.Contains(entri, "/") {
+			ip := net.ParseIP(entri)
+			if ip == nil {
+				continue
+			}
+			bits := 128
+			if ip.To4() != nil {
+				ip, bits = ip.To4(), 32
+			}
+			result = append(result, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		if _, network, err := net.ParseCIDR(entri); err == nil {
+			result = append(result, network)
+		}
+	}
+	return result
+}
+
+// GetClientIP mengambil IP client, header proxy hanya dipakai jika RemoteAddr adalah proxy terpercaya
+func GetClientIP(request *http.Request) string {
+	return clientIP(request, trustedProxies())
+}
+
+func clientIP(request *http.Request, proxies []*net.IPNet) string {
+	remote, _, err := net.SplitHostPort(request.RemoteAddr)
+	if err != nil {
+		remote = request.RemoteAddr
+	}
+	if !ipTerpercaya(remote, proxies) {
+		return remote
+	}
+
+	// X-Forwarded-For dibaca dari kanan, alamat pertama yang bukan proxy terpercaya adalah client
+	if forwarded := request.Header.Get("X-Forwarded-For"); forwarded != "" {
+		alamat := strings.Split(forwarded, ",")
+		for i := len(alamat) - 1; i >= 0; i-- {
+			ip := strings.TrimSpace(alamat[i])
+			if net.ParseIP(ip) == nil {
+				break
+			}
+			if !ipTerpercaya(ip, proxies) || i == 0 {
+				return ip
+			}
+		}
+	}
+	if realIp := strings.TrimSpace(request.Header.Get("X-Real-IP")); net.ParseIP(realIp) != nil {
+		return realIp
+	}
+	return remote
+}
+
+func ipTerpercaya(alamat string, proxies []*net.IPNet) bool {
+	ip := net.ParseIP(alamat)
+	if ip == nil {
+		return false
+	}
+	for _, network := range proxies {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
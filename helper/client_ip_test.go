@@ -0,0 +1,42 @@
+package helper
+
+import (
+	"net/http/httptest"
+	"testing"
+)
+
+func TestClientIP(t *testing.T) {
+	proxies := ParseTrustedProxies("10.0.0.1, 172.16.0.0/12, bukan-ip")
+
+	tests := []struct {
+		name       string
+		remoteAddr string
+		forwarded  string
+		realIp     string
+		expected   string
+	}{
+		{name: "tanpa proxy", remoteAddr: "203.0.113.5:5000", expected: "203.0.113.5"},
+		{name: "header dari client langsung diabaikan", remoteAddr: "203.0.113.5:5000", forwarded: "1.2.3.4", realIp: "5.6.7.8", expected: "203.0.113.5"},
+		{name: "proxy terpercaya", remoteAddr: "10.0.0.1:80", forwarded: "198.51.100.7", expected: "198.51.100.7"},
+		{name: "alamat palsu di kiri tidak dipakai", remoteAddr: "10.0.0.1:80", forwarded: "1.2.3.4, 198.51.100.7", expected: "198.51.100.7"},
+		{name: "rantai proxy terpercaya", remoteAddr: "10.0.0.1:80", forwarded: "198.51.100.7, 172.20.0.3", expected: "198.51.100.7"},
+		{name: "x-real-ip dari proxy", remoteAddr: "172.16.1.1:80", realIp: "198.51.100.9", expected: "198.51.100.9"},
+		{name: "header tidak valid", remoteAddr: "10.0.0.1:80", forwarded: "bukan-ip", expected: "10.0.0.1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("GET", "/", nil)
+			request.RemoteAddr = tt.remoteAddr
+			if tt.forwarded != "" {
+				request.Header.Set("X-Forwarded-For", tt.forwarded)
+			}
+			if tt.realIp != "" {
+				request.Header.Set("X-Real-IP", tt.realIp)
+			}
+			if got := clientIP(request, proxies); got != tt.expected {
+				t.Errorf("clientIP() = %q, expected %q", got, tt.expected)
+			}
+		})
+	}
+}
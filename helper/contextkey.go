@@ -3,5 +3,6 @@ package helper
 type ContextKey string
 
 const (
-	UserInfoKey ContextKey = "userInfo"
+	UserInfoKey  ContextKey = "userInfo"
+	RequestIdKey ContextKey = "requestId"
 )
@@ -6,7 +6,8 @@ func NewCORSMiddleware() *cors.Cors {
 	return cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
 		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE"},
-		AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization"},
+		AllowedHeaders:   []string{"Origin", "Content-Type", "Accept", "Authorization", RequestIdHeader},
+		ExposedHeaders:   []string{RequestIdHeader},
 		AllowCredentials: true,
 	})
 }
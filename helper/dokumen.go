@@ -0,0 +1,166 @@
+package helper
+
+import (
+	"ekak_kabupaten_madiun/model/web"
+	"fmt"
+	"net/http"
+	"regexp"
+	"strings"
+)
+
+// format dokumen unduhan
+const (
+	FormatJson = "json"
+	FormatPdf  = "pdf"
+	FormatDocx = "docx"
+)
+
+const (
+	blokJudul    = "judul"
+	blokParagraf = "paragraf"
+	blokTabel    = "tabel"
+	blokHalaman  = "halaman"
+)
+
+// Dokumen isi laporan sederhana (judul, paragraf, tabel) yang bisa dirender ke PDF maupun DOCX
+type Dokumen struct {
+	Judul     string
+	SubJudul  []string
+	Landscape bool
+	Blok      []BlokDokumen
+}
+
+type BlokDokumen struct {
+	Jenis string
+	Level int
+	Teks  string
+	Tebal bool
+	Tabel TabelDokumen
+}
+
+// TabelDokumen Lebar adalah bobot relatif lebar kolom, kosong berarti sama rata
+type TabelDokumen struct {
+	Kolom      []string
+	Lebar      []float64
+	Baris      [][]string
+	Arsir      [][]bool // sel berlatar abu-abu (mis. jadwal gantt), indeks mengikuti Baris
+	TanpaGaris bool
+	RataTengah bool
+}
+
+func (tabel TabelDokumen) diarsir(baris, kolom int) bool {
+	return baris < len(tabel.Arsir) && kolom < len(tabel.Arsir[baris]) && tabel.Arsir[baris][kolom]
+}
+
+func NewDokumen(judul string, subJudul ...string) *Dokumen {
+	return &Dokumen{Judul: judul, SubJudul: subJudul}
+}
+
+// TambahJudul level 1 untuk bab, level 2 untuk sub bab
+func (dokumen *Dokumen) TambahJudul(level int, teks string) {
+	dokumen.Blok = append(dokumen.Blok, BlokDokumen{Jenis: blokJudul, Level: level, Teks: teks})
+}
+
+// TambahJudulTengah judul rata tengah seukuran judul dokumen, untuk dokumen yang memuat beberapa naskah
+func (dokumen *Dokumen) TambahJudulTengah(teks string) {
+	dokumen.Blok = append(dokumen.Blok, BlokDokumen{Jenis: blokJudul, Level: 0, Teks: teks})
+}
+
+func (dokumen *Dokumen) TambahParagraf(teks string) {
+	dokumen.Blok = append(dokumen.Blok, BlokDokumen{Jenis: blokParagraf, Teks: teks})
+}
+
+func (dokumen *Dokumen) TambahParagrafTebal(teks string) {
+	dokumen.Blok = append(dokumen.Blok, BlokDokumen{Jenis: blokParagraf, Teks: teks, Tebal: true})
+}
+
+func (dokumen *Dokumen) TambahTabel(tabel TabelDokumen) {
+	dokumen.Blok = append(dokumen.Blok, BlokDokumen{Jenis: blokTabel, Tabel: tabel})
+}
+
+// TambahHalamanBaru blok berikutnya dimulai di halaman baru
+func (dokumen *Dokumen) TambahHalamanBaru() {
+	dokumen.Blok = append(dokumen.Blok, BlokDokumen{Jenis: blokHalaman})
+}
+
+// lebarKolom bobot lebar kolom yang sudah dinormalisasi sehingga jumlahnya 1
+func (tabel TabelDokumen) lebarKolom() []float64 {
+	jumlahKolom := len(tabel.Kolom)
+	if jumlahKolom == 0 && len(tabel.Baris) > 0 {
+		jumlahKolom = len(tabel.Baris[0])
+	}
+	lebar := make([]float64, jumlahKolom)
+	total := 0.0
+	for i := range lebar {
+		lebar[i] = 1
+		if i < len(tabel.Lebar) && tabel.Lebar[i] > 0 {
+			lebar[i] = tabel.Lebar[i]
+		}
+		total += lebar[i]
+	}
+	for i := range lebar {
+		lebar[i] /= total
+	}
+	return lebar
+}
+
+// Render menghasilkan isi file sesuai format beserta content type-nya
+func (dokumen *Dokumen) Render(format string) ([]byte, string, error) {
+	switch format {
+	case FormatPdf:
+		content, err := dokumen.PDF()
+		return content, "application/pdf", err
+	case FormatDocx:
+		content, err := dokumen.DOCX()
+		return content, "application/vnd.openxmlformats-officedocument.wordprocessingml.document", err
+	}
+	return nil, "", web.NewBadRequestError(fmt.Sprintf("format %s tidak didukung, gunakan pdf atau docx", format))
+}
+
+var namaFileTidakValid = regexp.MustCompile(`[^A-Za-z0-9._-]+`)
+
+// WriteDokumen mengirim dokumen sebagai file unduhan
+func WriteDokumen(writer http.ResponseWriter, dokumen *Dokumen, format string, namaFile string) {
+	content, contentType, err := dokumen.Render(format)
+	if err != nil {
+		WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	namaFile = strings.Trim(namaFileTidakValid.ReplaceAllString(namaFile, "_"), "_")
+	writer.Header().Set("Content-Type", contentType)
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, namaFile, format))
+	writer.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	writer.WriteHeader(http.StatusOK)
+	_, err = writer.Write(content)
+	PanicIfError(err)
+}
+
+// FormatDokumen membaca query format, kosong berarti json
+func FormatDokumen(request *http.Request) string {
+	format := strings.ToLower(strings.TrimSpace(request.URL.Query().Get("format")))
+	if format == "" {
+		return FormatJson
+	}
+	return format
+}
+
+// FormatRupiah memformat angka dengan pemisah ribuan titik
+func FormatRupiah(nilai int64) string {
+	negatif := nilai < 0
+	if negatif {
+		nilai = -nilai
+	}
+	angka := fmt.Sprint(nilai)
+	var hasil strings.Builder
+	for i, digit := range angka {
+		if i > 0 && (len(angka)-i)%3 == 0 {
+			hasil.WriteByte('.')
+		}
+		hasil.WriteRune(digit)
+	}
+	if negatif {
+		return "-" + hasil.String()
+	}
+	return hasil.String()
+}
@@ -0,0 +1,195 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"strings"
+)
+
+// ukuran A4 dan margin 2 cm dalam twips
+const (
+	docxLebarA4  = 11906
+	docxTinggiA4 = 16838
+	docxMargin   = 1134
+)
+
+const docxContentTypes = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/word/document.xml" ContentType="application/vnd.openxmlformats-officedocument.wordprocessingml.document.main+xml"/></Types>`
+
+const docxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="word/document.xml"/></Relationships>`
+
+type docxRun struct {
+	tebal  bool
+	ukuran int // half-point
+}
+
+func docxEscape(teks string) string {
+	var buffer bytes.Buffer
+	_ = xml.EscapeText(&buffer, []byte(teks))
+	return buffer.String()
+}
+
+// docxParagraf menulis satu paragraf, baris baru di dalam teks menjadi line break
+func docxParagraf(body *bytes.Buffer, teks string, run docxRun, rataTengah bool, spasiSetelah int) {
+	body.WriteString("<w:p><w:pPr>")
+	if rataTengah {
+		body.WriteString(`<w:jc w:val="center"/>`)
+	}
+	fmt.Fprintf(body, `<w:spacing w:before="0" w:after="%d"/>`, spasiSetelah)
+	body.WriteString("</w:pPr>")
+
+	for i, baris := range strings.Split(teks, "\n") {
+		body.WriteString("<w:r><w:rPr>")
+		if run.tebal {
+			body.WriteString("<w:b/>")
+		}
+		fmt.Fprintf(body, `<w:sz w:val="%d"/>`, run.ukuran)
+		body.WriteString("</w:rPr>")
+		if i > 0 {
+			body.WriteString("<w:br/>")
+		}
+		fmt.Fprintf(body, `<w:t xml:space="preserve">%s</w:t></w:r>`, docxEscape(baris))
+	}
+	body.WriteString("</w:p>")
+}
+
+func docxTabel(body *bytes.Buffer, tabel TabelDokumen, lebarIsi int) {
+	lebar := tabel.lebarKolom()
+	if len(lebar) == 0 {
+		return
+	}
+	lebarKolom := make([]int, len(lebar))
+	for i, l := range lebar {
+		lebarKolom[i] = int(l * float64(lebarIsi))
+	}
+
+	garis := "single"
+	if tabel.TanpaGaris {
+		garis = "nil"
+	}
+	body.WriteString(`<w:tbl><w:tblPr><w:tblW w:w="5000" w:type="pct"/><w:tblLayout w:type="fixed"/><w:tblBorders>`)
+	for _, sisi := range []string{"top", "left", "bottom", "right", "insideH", "insideV"} {
+		fmt.Fprintf(body, `<w:%s w:val="%s" w:sz="4" w:space="0" w:color="000000"/>`, sisi, garis)
+	}
+	body.WriteString(`</w:tblBorders><w:tblCellMar><w:left w:w="60" w:type="dxa"/><w:right w:w="60" w:type="dxa"/></w:tblCellMar></w:tblPr><w:tblGrid>`)
+	for _, w := range lebarKolom {
+		fmt.Fprintf(body, `<w:gridCol w:w="%d"/>`, w)
+	}
+	body.WriteString("</w:tblGrid>")
+
+	tulisBaris := func(sel []string, kepala bool, indeksBaris int) {
+		body.WriteString("<w:tr>")
+		if kepala {
+			body.WriteString("<w:trPr><w:tblHeader/></w:trPr>")
+		}
+		for i, w := range lebarKolom {
+			fmt.Fprintf(body, `<w:tc><w:tcPr><w:tcW w:w="%d" w:type="dxa"/>`, w)
+			if kepala {
+				body.WriteString(`<w:shd w:val="clear" w:color="auto" w:fill="E0E0E0"/>`)
+			} else if tabel.diarsir(indeksBaris, i) {
+				body.WriteString(`<w:shd w:val="clear" w:color="auto" w:fill="999999"/>`)
+			}
+			body.WriteString("</w:tcPr>")
+			teks := ""
+			if i < len(sel) {
+				teks = sel[i]
+			}
+			docxParagraf(body, teks, docxRun{tebal: kepala, ukuran: 18}, tabel.RataTengah, 0)
+			body.WriteString("</w:tc>")
+		}
+		body.WriteString("</w:tr>")
+	}
+
+	if len(tabel.Kolom) > 0 {
+		tulisBaris(tabel.Kolom, true, -1)
+	}
+	for indeks, sel := range tabel.Baris {
+		tulisBaris(sel, false, indeks)
+	}
+	body.WriteString("</w:tbl>")
+	// paragraf kosong sebagai jarak antar tabel
+	docxParagraf(body, "", docxRun{ukuran: 20}, false, 120)
+}
+
+// DOCX merender dokumen ke Office Open XML tanpa style eksternal
+func (dokumen *Dokumen) DOCX() ([]byte, error) {
+	lebarHalaman, tinggiHalaman := docxLebarA4, docxTinggiA4
+	orientasi := ""
+	if dokumen.Landscape {
+		lebarHalaman, tinggiHalaman = docxTinggiA4, docxLebarA4
+		orientasi = ` w:orient="landscape"`
+	}
+	lebarIsi := lebarHalaman - 2*docxMargin
+
+	var body bytes.Buffer
+	if dokumen.Judul != "" {
+		docxParagraf(&body, dokumen.Judul, docxRun{tebal: true, ukuran: 28}, true, 60)
+	}
+	for _, sub := range dokumen.SubJudul {
+		docxParagraf(&body, sub, docxRun{tebal: true, ukuran: 22}, true, 60)
+	}
+	if dokumen.Judul != "" || len(dokumen.SubJudul) > 0 {
+		docxParagraf(&body, "", docxRun{ukuran: 20}, false, 120)
+	}
+
+	for _, blok := range dokumen.Blok {
+		switch blok.Jenis {
+		case blokJudul:
+			if blok.Level == 0 {
+				docxParagraf(&body, blok.Teks, docxRun{tebal: true, ukuran: 28}, true, 60)
+				continue
+			}
+			ukuran := 24
+			if blok.Level > 1 {
+				ukuran = 21
+			}
+			docxParagraf(&body, blok.Teks, docxRun{tebal: true, ukuran: ukuran}, false, 120)
+		case blokParagraf:
+			docxParagraf(&body, blok.Teks, docxRun{tebal: blok.Tebal, ukuran: 20}, false, 80)
+		case blokTabel:
+			docxTabel(&body, blok.Tabel, lebarIsi)
+		case blokHalaman:
+			body.WriteString(`<w:p><w:r><w:br w:type="page"/></w:r></w:p>`)
+		}
+	}
+
+	var document bytes.Buffer
+	document.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	document.WriteString(`<w:document xmlns:w="http://schemas.openxmlformats.org/wordprocessingml/2006/main"><w:body>`)
+	document.Write(body.Bytes())
+	fmt.Fprintf(&document, `<w:sectPr><w:pgSz w:w="%d" w:h="%d"%s/><w:pgMar w:top="%d" w:right="%d" w:bottom="%d" w:left="%d" w:header="708" w:footer="708" w:gutter="0"/></w:sectPr>`,
+		lebarHalaman, tinggiHalaman, orientasi, docxMargin, docxMargin, docxMargin, docxMargin)
+	document.WriteString("</w:body></w:document>")
+
+	return zipFiles([]zipFile{
+		{nama: "[Content_Types].xml", isi: []byte(docxContentTypes)},
+		{nama: "_rels/.rels", isi: []byte(docxRels)},
+		{nama: "word/document.xml", isi: document.Bytes()},
+	})
+}
+
+type zipFile struct {
+	nama string
+	isi  []byte
+}
+
+func zipFiles(files []zipFile) ([]byte, error) {
+	var output bytes.Buffer
+	writer := zip.NewWriter(&output)
+	for _, file := range files {
+		w, err := writer.Create(file.nama)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := w.Write(file.isi); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, err
+	}
+	return output.Bytes(), nil
+}
@@ -0,0 +1,366 @@
+package helper
+
+import (
+	"bytes"
+	"compress/zlib"
+	"fmt"
+	"strings"
+)
+
+// ukuran A4 dalam point
+const (
+	pdfLebarA4  = 595.28
+	pdfTinggiA4 = 841.89
+	pdfMargin   = 40.0
+	pdfFooter   = 20.0
+)
+
+// lebar glyph Helvetica (per 1000 unit) untuk karakter ASCII 32-126
+var helveticaWidths = [95]int{
+	278, 278, 355, 556, 556, 889, 667, 191, 333, 333, 389, 584, 278, 333, 278, 278,
+	556, 556, 556, 556, 556, 556, 556, 556, 556, 556, 278, 278, 584, 584, 584, 556,
+	1015, 667, 667, 722, 722, 667, 611, 778, 722, 278, 500, 667, 556, 833, 722, 778,
+	667, 778, 722, 667, 611, 722, 667, 944, 667, 667, 611, 278, 278, 278, 469, 556,
+	333, 556, 556, 500, 556, 556, 278, 556, 556, 222, 222, 500, 222, 833, 556, 556,
+	556, 556, 333, 500, 278, 556, 500, 722, 500, 500, 500, 334, 260, 334, 584,
+}
+
+type pdfFont struct {
+	nama  string
+	tebal bool
+	size  float64
+}
+
+func (font pdfFont) lebar(teks string) float64 {
+	total := 0
+	for _, b := range []byte(pdfEncode(teks)) {
+		if b >= 32 && b <= 126 {
+			total += helveticaWidths[b-32]
+		} else {
+			total += 556
+		}
+	}
+	lebar := float64(total) * font.size / 1000
+	// Helvetica-Bold sedikit lebih lebar, cukup didekati untuk pemenggalan baris
+	if font.tebal {
+		lebar *= 1.1
+	}
+	return lebar
+}
+
+func (font pdfFont) leading() float64 {
+	return font.size * 1.3
+}
+
+var (
+	pdfFontJudul    = pdfFont{nama: "F2", tebal: true, size: 14}
+	pdfFontSubJudul = pdfFont{nama: "F2", tebal: true, size: 11}
+	pdfFontBab      = pdfFont{nama: "F2", tebal: true, size: 12}
+	pdfFontSubBab   = pdfFont{nama: "F2", tebal: true, size: 10.5}
+	pdfFontIsi      = pdfFont{nama: "F1", size: 10}
+	pdfFontIsiTebal = pdfFont{nama: "F2", tebal: true, size: 10}
+	pdfFontTabel    = pdfFont{nama: "F1", size: 8.5}
+	pdfFontKepala   = pdfFont{nama: "F2", tebal: true, size: 8.5}
+)
+
+// pdfEncode mengubah teks ke WinAnsiEncoding, karakter di luar latin-1 diganti padanan terdekat
+func pdfEncode(teks string) string {
+	var hasil strings.Builder
+	for _, r := range teks {
+		switch {
+		case r == '\t':
+			hasil.WriteString("    ")
+		case r < 32:
+			continue
+		case r < 127 || (r >= 160 && r <= 255):
+			hasil.WriteByte(byte(r))
+		case r == '‘' || r == '’':
+			hasil.WriteByte('\'')
+		case r == '“' || r == '”':
+			hasil.WriteByte('"')
+		case r == '–' || r == '—':
+			hasil.WriteByte('-')
+		case r == '•':
+			hasil.WriteByte(0x95)
+		default:
+			hasil.WriteByte('?')
+		}
+	}
+	return hasil.String()
+}
+
+func pdfEscape(teks string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, "(", `\(`, ")", `\)`)
+	return replacer.Replace(pdfEncode(teks))
+}
+
+// wrapTeks memecah teks menjadi baris-baris yang muat pada lebar tertentu
+func wrapTeks(teks string, font pdfFont, lebarMaks float64) []string {
+	return wrapTeksUkur(teks, font.lebar, lebarMaks)
+}
+
+// wrapTeksUkur seperti wrapTeks dengan fungsi pengukur lebar sendiri
+func wrapTeksUkur(teks string, lebar func(string) float64, lebarMaks float64) []string {
+	var baris []string
+	for _, paragraf := range strings.Split(teks, "\n") {
+		kata := strings.Fields(paragraf)
+		if len(kata) == 0 {
+			baris = append(baris, "")
+			continue
+		}
+		sekarang := ""
+		for _, k := range kata {
+			calon := k
+			if sekarang != "" {
+				calon = sekarang + " " + k
+			}
+			if lebar(calon) <= lebarMaks {
+				sekarang = calon
+				continue
+			}
+			if sekarang != "" {
+				baris = append(baris, sekarang)
+			}
+			// kata lebih panjang dari satu baris dipotong per karakter
+			for len([]rune(k)) > 1 && lebar(k) > lebarMaks {
+				potong := len([]rune(k)) - 1
+				for potong > 1 && lebar(string([]rune(k)[:potong])) > lebarMaks {
+					potong--
+				}
+				baris = append(baris, string([]rune(k)[:potong]))
+				k = string([]rune(k)[potong:])
+			}
+			sekarang = k
+		}
+		baris = append(baris, sekarang)
+	}
+	return baris
+}
+
+type pdfLayout struct {
+	lebarHalaman  float64
+	tinggiHalaman float64
+	halaman       []*bytes.Buffer
+	isi           *bytes.Buffer
+	y             float64
+}
+
+func (layout *pdfLayout) lebarIsi() float64 {
+	return layout.lebarHalaman - 2*pdfMargin
+}
+
+func (layout *pdfLayout) halamanBaru() {
+	layout.isi = &bytes.Buffer{}
+	layout.halaman = append(layout.halaman, layout.isi)
+	layout.y = layout.tinggiHalaman - pdfMargin
+	layout.isi.WriteString("0.5 w\n")
+}
+
+// pastikan membuka halaman baru jika sisa ruang kurang dari tinggi yang dibutuhkan
+func (layout *pdfLayout) pastikan(tinggi float64) bool {
+	if layout.y-tinggi < pdfMargin+pdfFooter {
+		layout.halamanBaru()
+		return true
+	}
+	return false
+}
+
+func (layout *pdfLayout) teks(x float64, y float64, font pdfFont, teks string) {
+	fmt.Fprintf(layout.isi, "BT /%s %.1f Tf %.2f %.2f Td (%s) Tj ET\n", font.nama, font.size, x, y, pdfEscape(teks))
+}
+
+func (layout *pdfLayout) tengah(font pdfFont, teks string) {
+	for _, baris := range wrapTeks(teks, font, layout.lebarIsi()) {
+		layout.pastikan(font.leading())
+		layout.y -= font.leading()
+		x := pdfMargin + (layout.lebarIsi()-font.lebar(baris))/2
+		layout.teks(x, layout.y+font.size*0.25, font, baris)
+	}
+}
+
+func (layout *pdfLayout) paragraf(font pdfFont, teks string, spasiAtas float64) {
+	layout.y -= spasiAtas
+	for _, baris := range wrapTeks(teks, font, layout.lebarIsi()) {
+		layout.pastikan(font.leading())
+		layout.y -= font.leading()
+		layout.teks(pdfMargin, layout.y+font.size*0.25, font, baris)
+	}
+}
+
+func (layout *pdfLayout) tabel(tabel TabelDokumen) {
+	const padding = 3.0
+	lebar := tabel.lebarKolom()
+	if len(lebar) == 0 {
+		return
+	}
+
+	lebarKolom := make([]float64, len(lebar))
+	for i, l := range lebar {
+		lebarKolom[i] = l * layout.lebarIsi()
+	}
+
+	susunBaris := func(sel []string, font pdfFont) ([][]string, float64) {
+		isi := make([][]string, len(lebarKolom))
+		maksBaris := 1
+		for i := range lebarKolom {
+			teks := ""
+			if i < len(sel) {
+				teks = sel[i]
+			}
+			isi[i] = wrapTeks(teks, font, lebarKolom[i]-2*padding)
+			if len(isi[i]) > maksBaris {
+				maksBaris = len(isi[i])
+			}
+		}
+		return isi, float64(maksBaris)*font.leading() + 2*padding
+	}
+
+	gambarBaris := func(isi [][]string, tinggi float64, font pdfFont, kepala bool, indeksBaris int) {
+		x := pdfMargin
+		for i, w := range lebarKolom {
+			if kepala {
+				fmt.Fprintf(layout.isi, "0.88 g %.2f %.2f %.2f %.2f re f 0 g\n", x, layout.y-tinggi, w, tinggi)
+			} else if tabel.diarsir(indeksBaris, i) {
+				fmt.Fprintf(layout.isi, "0.6 g %.2f %.2f %.2f %.2f re f 0 g\n", x, layout.y-tinggi, w, tinggi)
+			}
+			if !tabel.TanpaGaris {
+				fmt.Fprintf(layout.isi, "%.2f %.2f %.2f %.2f re S\n", x, layout.y-tinggi, w, tinggi)
+			}
+			for j, baris := range isi[i] {
+				yBaris := layout.y - padding - float64(j+1)*font.leading() + font.size*0.25
+				xBaris := x + padding
+				if tabel.RataTengah {
+					xBaris = x + (w-font.lebar(baris))/2
+				}
+				layout.teks(xBaris, yBaris, font, baris)
+			}
+			x += w
+		}
+		layout.y -= tinggi
+	}
+
+	var kepala [][]string
+	var tinggiKepala float64
+	if len(tabel.Kolom) > 0 {
+		kepala, tinggiKepala = susunBaris(tabel.Kolom, pdfFontKepala)
+		layout.pastikan(tinggiKepala + pdfFontTabel.leading() + 2*padding)
+		gambarBaris(kepala, tinggiKepala, pdfFontKepala, true, -1)
+	}
+
+	for indeks, sel := range tabel.Baris {
+		isi, tinggi := susunBaris(sel, pdfFontTabel)
+		if layout.pastikan(tinggi) && kepala != nil {
+			// ulangi kepala tabel di halaman baru
+			gambarBaris(kepala, tinggiKepala, pdfFontKepala, true, -1)
+		}
+		gambarBaris(isi, tinggi, pdfFontTabel, false, indeks)
+	}
+	layout.y -= 6
+}
+
+// PDF merender dokumen ke PDF 1.4 dengan font standar Helvetica
+func (dokumen *Dokumen) PDF() ([]byte, error) {
+	layout := &pdfLayout{lebarHalaman: pdfLebarA4, tinggiHalaman: pdfTinggiA4}
+	if dokumen.Landscape {
+		layout.lebarHalaman, layout.tinggiHalaman = pdfTinggiA4, pdfLebarA4
+	}
+	layout.halamanBaru()
+
+	if dokumen.Judul != "" {
+		layout.tengah(pdfFontJudul, dokumen.Judul)
+	}
+	for _, sub := range dokumen.SubJudul {
+		layout.tengah(pdfFontSubJudul, sub)
+	}
+	if dokumen.Judul != "" || len(dokumen.SubJudul) > 0 {
+		layout.y -= 10
+	}
+
+	for _, blok := range dokumen.Blok {
+		switch blok.Jenis {
+		case blokJudul:
+			if blok.Level == 0 {
+				layout.tengah(pdfFontJudul, blok.Teks)
+				continue
+			}
+			font := pdfFontBab
+			if blok.Level > 1 {
+				font = pdfFontSubBab
+			}
+			// judul jangan tertinggal sendirian di dasar halaman
+			layout.pastikan(font.leading()*3 + 8)
+			layout.paragraf(font, blok.Teks, 8)
+		case blokParagraf:
+			font := pdfFontIsi
+			if blok.Tebal {
+				font = pdfFontIsiTebal
+			}
+			layout.paragraf(font, blok.Teks, 2)
+		case blokTabel:
+			layout.y -= 4
+			layout.tabel(blok.Tabel)
+		case blokHalaman:
+			layout.halamanBaru()
+		}
+	}
+
+	// nomor halaman baru bisa ditulis setelah jumlah halaman diketahui
+	for i, halaman := range layout.halaman {
+		teks := fmt.Sprintf("Halaman %d dari %d", i+1, len(layout.halaman))
+		x := layout.lebarHalaman - pdfMargin - pdfFontTabel.lebar(teks)
+		fmt.Fprintf(halaman, "BT /F1 8 Tf %.2f %.2f Td (%s) Tj ET\n", x, pdfMargin/2, pdfEscape(teks))
+	}
+
+	return tulisPdf(layout, dokumen.Judul)
+}
+
+func tulisPdf(layout *pdfLayout, judul string) ([]byte, error) {
+	var output bytes.Buffer
+	var offsets []int
+
+	tulisObjek := func(isi string) {
+		offsets = append(offsets, output.Len())
+		fmt.Fprintf(&output, "%d 0 obj\n%s\nendobj\n", len(offsets), isi)
+	}
+
+	output.WriteString("%PDF-1.4\n%\xE2\xE3\xCF\xD3\n")
+
+	jumlahHalaman := len(layout.halaman)
+	kids := make([]string, jumlahHalaman)
+	for i := range layout.halaman {
+		// objek 1-4 tetap, setiap halaman memakai dua objek: page dan content
+		kids[i] = fmt.Sprintf("%d 0 R", 5+2*i)
+	}
+
+	tulisObjek("<< /Type /Catalog /Pages 2 0 R >>")
+	tulisObjek(fmt.Sprintf("<< /Type /Pages /Kids [%s] /Count %d >>", strings.Join(kids, " "), jumlahHalaman))
+	tulisObjek("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica /Encoding /WinAnsiEncoding >>")
+	tulisObjek("<< /Type /Font /Subtype /Type1 /BaseFont /Helvetica-Bold /Encoding /WinAnsiEncoding >>")
+
+	for i, halaman := range layout.halaman {
+		var stream bytes.Buffer
+		zw := zlib.NewWriter(&stream)
+		if _, err := zw.Write(halaman.Bytes()); err != nil {
+			return nil, err
+		}
+		if err := zw.Close(); err != nil {
+			return nil, err
+		}
+
+		tulisObjek(fmt.Sprintf("<< /Type /Page /Parent 2 0 R /MediaBox [0 0 %.2f %.2f] /Resources << /Font << /F1 3 0 R /F2 4 0 R >> >> /Contents %d 0 R >>",
+			layout.lebarHalaman, layout.tinggiHalaman, 6+2*i))
+		tulisObjek(fmt.Sprintf("<< /Length %d /Filter /FlateDecode >>\nstream\n%s\nendstream", stream.Len(), stream.String()))
+	}
+
+	tulisObjek(fmt.Sprintf("<< /Title (%s) /Producer (e-KAK Kabupaten Madiun) >>", pdfEscape(judul)))
+	infoId := len(offsets)
+
+	xref := output.Len()
+	fmt.Fprintf(&output, "xref\n0 %d\n0000000000 65535 f \n", len(offsets)+1)
+	for _, offset := range offsets {
+		fmt.Fprintf(&output, "%010d 00000 n \n", offset)
+	}
+	fmt.Fprintf(&output, "trailer\n<< /Size %d /Root 1 0 R /Info %d 0 R >>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, infoId, xref)
+
+	return output.Bytes(), nil
+}
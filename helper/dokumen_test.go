@@ -0,0 +1,113 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+)
+
+func contohDokumen() *Dokumen {
+	dokumen := NewDokumen("Laporan Kinerja", "Dinas (Uji)", "Tahun 2025")
+	dokumen.TambahJudul(1, "BAB I Pendahuluan")
+	dokumen.TambahParagraf("Paragraf pertama dengan karakter khusus & < > “kutip”.")
+	dokumen.TambahTabel(TabelDokumen{
+		Kolom: []string{"No", "Uraian", "Target"},
+		Lebar: []float64{1, 6, 2},
+		Baris: [][]string{
+			{"1", "Persentase capaian kinerja yang sangat panjang sehingga harus dipecah menjadi beberapa baris", "100 %"},
+			{"2", "Indeks kepuasan", "3,5"},
+		},
+		Arsir: [][]bool{nil, {false, false, true}},
+	})
+	return dokumen
+}
+
+func TestDokumenPDF(t *testing.T) {
+	content, err := contohDokumen().PDF()
+	if err != nil {
+		t.Fatalf("PDF() error: %v", err)
+	}
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4")) || !bytes.HasSuffix(content, []byte("%%EOF\n")) {
+		t.Fatalf("PDF() bukan dokumen PDF yang utuh")
+	}
+	if !bytes.Contains(content, []byte("/Title (Laporan Kinerja)")) {
+		t.Errorf("PDF() tidak memuat judul pada info dokumen")
+	}
+}
+
+func TestDokumenDOCX(t *testing.T) {
+	content, err := contohDokumen().DOCX()
+	if err != nil {
+		t.Fatalf("DOCX() error: %v", err)
+	}
+
+	reader, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		t.Fatalf("DOCX() bukan arsip zip: %v", err)
+	}
+
+	var document string
+	for _, file := range reader.File {
+		if file.Name != "word/document.xml" {
+			continue
+		}
+		rc, err := file.Open()
+		if err != nil {
+			t.Fatal(err)
+		}
+		isi, _ := io.ReadAll(rc)
+		rc.Close()
+		document = string(isi)
+	}
+
+	for _, expected := range []string{"Dinas (Uji)", "&amp; &lt; &gt;", "<w:tblHeader/>", "Indeks kepuasan", `w:fill="999999"`} {
+		if !strings.Contains(document, expected) {
+			t.Errorf("document.xml tidak memuat %q", expected)
+		}
+	}
+}
+
+func TestWrapTeks(t *testing.T) {
+	font := pdfFont{nama: "F1", size: 10}
+	tests := []struct {
+		name      string
+		teks      string
+		lebarMaks float64
+		expected  []string
+	}{
+		{name: "muat satu baris", teks: "target kinerja", lebarMaks: 200, expected: []string{"target kinerja"}},
+		{name: "dipecah per kata", teks: "aaa bbb ccc", lebarMaks: 40, expected: []string{"aaa bbb", "ccc"}},
+		{name: "baris baru dipertahankan", teks: "satu\ndua", lebarMaks: 200, expected: []string{"satu", "dua"}},
+		{name: "kata panjang dipotong", teks: "0123456789", lebarMaks: 30, expected: []string{"01234", "56789"}},
+		{name: "lebar terlalu kecil tidak berulang tanpa akhir", teks: "ab", lebarMaks: 1, expected: []string{"a", "b"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := wrapTeks(tt.teks, font, tt.lebarMaks)
+			if strings.Join(result, "|") != strings.Join(tt.expected, "|") {
+				t.Errorf("wrapTeks() = %q, expected %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestFormatRupiah(t *testing.T) {
+	tests := []struct {
+		nilai    int64
+		expected string
+	}{
+		{nilai: 0, expected: "0"},
+		{nilai: 950, expected: "950"},
+		{nilai: 1500000, expected: "1.500.000"},
+		{nilai: -25000, expected: "-25.000"},
+	}
+
+	for _, tt := range tests {
+		if result := FormatRupiah(tt.nilai); result != tt.expected {
+			t.Errorf("FormatRupiah(%d) = %s, expected %s", tt.nilai, result, tt.expected)
+		}
+	}
+}
@@ -0,0 +1,140 @@
+package helper
+
+import (
+	"database/sql"
+	"ekak_kabupaten_madiun/model/web"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/go-sql-driver/mysql"
+)
+
+// kode error yang bisa dibaca mesin pada field error_code
+const (
+	ErrorCodeBadRequest      = "BAD_REQUEST"
+	ErrorCodeValidation      = "VALIDATION_ERROR"
+	ErrorCodeUnauthorized    = "UNAUTHORIZED"
+	ErrorCodeForbidden       = "FORBIDDEN"
+	ErrorCodeNotFound        = "NOT_FOUND"
+	ErrorCodeConflict        = "CONFLICT"
+	ErrorCodeLocked          = "LOCKED"
+	ErrorCodeTooManyRequests = "TOO_MANY_REQUESTS"
+	ErrorCodeInternal        = "INTERNAL_ERROR"
+)
+
+const mysqlErrDuplicateEntry = 1062
+
+// ErrorCodeFromStatus memetakan HTTP status ke error_code
+func ErrorCodeFromStatus(status int) string {
+	switch status {
+	case http.StatusBadRequest:
+		return ErrorCodeBadRequest
+	case http.StatusUnauthorized:
+		return ErrorCodeUnauthorized
+	case http.StatusForbidden:
+		return ErrorCodeForbidden
+	case http.StatusNotFound:
+		return ErrorCodeNotFound
+	case http.StatusConflict:
+		return ErrorCodeConflict
+	case http.StatusLocked:
+		return ErrorCodeLocked
+	case http.StatusTooManyRequests:
+		return ErrorCodeTooManyRequests
+	}
+	if status >= 500 {
+		return ErrorCodeInternal
+	}
+	return ErrorCodeBadRequest
+}
+
+// ErrorStatus memetakan error service ke HTTP status dan error_code.
+// fallback dipakai jika jenis error tidak dikenali.
+func ErrorStatus(err error, fallback int) (int, string) {
+	var customErr *web.CustomError
+	if errors.As(err, &customErr) {
+		return customErr.Code, ErrorCodeFromStatus(customErr.Code)
+	}
+
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		return http.StatusBadRequest, ErrorCodeValidation
+	}
+
+	if errors.Is(err, sql.ErrNoRows) {
+		return http.StatusNotFound, ErrorCodeNotFound
+	}
+
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		return http.StatusConflict, ErrorCodeConflict
+	}
+
+	// sebagian besar service masih mengembalikan fmt.Errorf("... tidak ditemukan")
+	message := strings.ToLower(err.Error())
+	if strings.Contains(message, "tidak ditemukan") || strings.Contains(message, "not found") {
+		return http.StatusNotFound, ErrorCodeNotFound
+	}
+	if strings.HasPrefix(message, "validasi gagal") {
+		return http.StatusBadRequest, ErrorCodeValidation
+	}
+
+	if fallback == 0 {
+		fallback = http.StatusInternalServerError
+	}
+	return fallback, ErrorCodeFromStatus(fallback)
+}
+
+// PanicStatus memetakan nilai recover() ke HTTP status: payload JSON rusak 400, selain itu 500
+func PanicStatus(recovered interface{}) int {
+	err, ok := recovered.(error)
+	if !ok {
+		return http.StatusInternalServerError
+	}
+
+	var syntaxErr *json.SyntaxError
+	var typeErr *json.UnmarshalTypeError
+	var validationErrs validator.ValidationErrors
+	switch {
+	case errors.As(err, &syntaxErr), errors.As(err, &typeErr), errors.As(err, &validationErrs):
+		return http.StatusBadRequest
+	case errors.Is(err, io.EOF), errors.Is(err, io.ErrUnexpectedEOF):
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
+// WriteErrorResponse menulis error service dengan HTTP status yang sesuai dalam envelope web.ErrorResponse
+func WriteErrorResponse(writer http.ResponseWriter, err error, fallback int) {
+	status, errorCode := ErrorStatus(err, fallback)
+
+	// error validasi dikirim per field agar frontend bisa menandai input yang salah
+	var validationErrs validator.ValidationErrors
+	if errors.As(err, &validationErrs) {
+		writeErrorEnvelope(writer, status, errorCode, ValidationFieldErrors(validationErrs))
+		return
+	}
+	writeErrorEnvelope(writer, status, errorCode, err.Error())
+}
+
+// WriteError menulis error dengan status dan pesan yang sudah diketahui
+func WriteError(writer http.ResponseWriter, status int, message string) {
+	writeErrorEnvelope(writer, status, ErrorCodeFromStatus(status), message)
+}
+
+func writeErrorEnvelope(writer http.ResponseWriter, status int, errorCode string, data interface{}) {
+	writer.Header().Set("Content-Type", "application/json")
+	writer.WriteHeader(status)
+	err := json.NewEncoder(writer).Encode(web.ErrorResponse{
+		Code:      status,
+		Status:    strings.ToUpper(http.StatusText(status)),
+		ErrorCode: errorCode,
+		Data:      data,
+		RequestId: writer.Header().Get(RequestIdHeader),
+	})
+	PanicIfError(err)
+}
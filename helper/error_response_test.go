@@ -0,0 +1,133 @@
+package helper
+
+import (
+	"database/sql"
+	"ekak_kabupaten_madiun/model/web"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/go-sql-driver/mysql"
+)
+
+func TestErrorStatus(t *testing.T) {
+	tests := []struct {
+		name         string
+		err          error
+		fallback     int
+		expectedCode int
+		expectedErr  string
+	}{
+		{
+			name:         "custom error memakai kodenya",
+			err:          web.NewLockedError("data dikunci"),
+			fallback:     http.StatusInternalServerError,
+			expectedCode: http.StatusLocked,
+			expectedErr:  ErrorCodeLocked,
+		},
+		{
+			name:         "custom error terbungkus",
+			err:          fmt.Errorf("gagal: %w", web.NewForbiddenError("akses ditolak")),
+			fallback:     http.StatusInternalServerError,
+			expectedCode: http.StatusForbidden,
+			expectedErr:  ErrorCodeForbidden,
+		},
+		{
+			name:         "sql no rows",
+			err:          sql.ErrNoRows,
+			fallback:     http.StatusInternalServerError,
+			expectedCode: http.StatusNotFound,
+			expectedErr:  ErrorCodeNotFound,
+		},
+		{
+			name:         "duplicate entry",
+			err:          &mysql.MySQLError{Number: 1062, Message: "Duplicate entry"},
+			fallback:     http.StatusInternalServerError,
+			expectedCode: http.StatusConflict,
+			expectedErr:  ErrorCodeConflict,
+		},
+		{
+			name:         "pesan tidak ditemukan",
+			err:          errors.New("rencana kinerja tidak ditemukan"),
+			fallback:     http.StatusInternalServerError,
+			expectedCode: http.StatusNotFound,
+			expectedErr:  ErrorCodeNotFound,
+		},
+		{
+			name:         "pesan validasi gagal",
+			err:          errors.New("validasi gagal: nama wajib diisi"),
+			fallback:     http.StatusInternalServerError,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  ErrorCodeValidation,
+		},
+		{
+			name:         "error lain memakai fallback",
+			err:          errors.New("koneksi terputus"),
+			fallback:     http.StatusBadRequest,
+			expectedCode: http.StatusBadRequest,
+			expectedErr:  ErrorCodeBadRequest,
+		},
+		{
+			name:         "fallback kosong menjadi 500",
+			err:          errors.New("koneksi terputus"),
+			fallback:     0,
+			expectedCode: http.StatusInternalServerError,
+			expectedErr:  ErrorCodeInternal,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, errorCode := ErrorStatus(tt.err, tt.fallback)
+			if code != tt.expectedCode || errorCode != tt.expectedErr {
+				t.Errorf("ErrorStatus() = (%d, %s), expected (%d, %s)", code, errorCode, tt.expectedCode, tt.expectedErr)
+			}
+		})
+	}
+}
+
+func TestPanicStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		recovered interface{}
+		expected  int
+	}{
+		{
+			name:      "json rusak",
+			recovered: &json.SyntaxError{Offset: 1},
+			expected:  http.StatusBadRequest,
+		},
+		{
+			name:      "tipe json tidak sesuai",
+			recovered: &json.UnmarshalTypeError{Value: "string"},
+			expected:  http.StatusBadRequest,
+		},
+		{
+			name:      "body kosong",
+			recovered: io.EOF,
+			expected:  http.StatusBadRequest,
+		},
+		{
+			name:      "error database",
+			recovered: errors.New("sql: connection refused"),
+			expected:  http.StatusInternalServerError,
+		},
+		{
+			name:      "panic string",
+			recovered: "BuildInQuery: ids tidak boleh kosong",
+			expected:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := PanicStatus(tt.recovered)
+			if result != tt.expected {
+				t.Errorf("PanicStatus() = %d, expected %d", result, tt.expected)
+			}
+		})
+	}
+}
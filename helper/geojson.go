@@ -0,0 +1,265 @@
+package helper
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+)
+
+// tipe geometri GeoJSON (RFC 7946) yang diterima, GeometryCollection tidak didukung
+const (
+	GeometriPoint           = "Point"
+	GeometriMultiPoint      = "MultiPoint"
+	GeometriLineString      = "LineString"
+	GeometriMultiLineString = "MultiLineString"
+	GeometriPolygon         = "Polygon"
+	GeometriMultiPolygon    = "MultiPolygon"
+)
+
+type geometriGeoJson struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// posisi GeoJSON berurutan [longitude, latitude]
+type posisiGeoJson []float64
+
+// GeometriTitik membuat geometri Point dari latitude dan longitude
+func GeometriTitik(latitude, longitude float64) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{"type":"Point","coordinates":[%s,%s]}`,
+		formatKoordinat(longitude), formatKoordinat(latitude)))
+}
+
+func formatKoordinat(nilai float64) string {
+	data, _ := json.Marshal(math.Round(nilai*1e7) / 1e7)
+	return string(data)
+}
+
+// ValidasiKoordinat latitude -90..90 dan longitude -180..180
+func ValidasiKoordinat(latitude, longitude float64) error {
+	if math.IsNaN(latitude) || latitude < -90 || latitude > 90 {
+		return fmt.Errorf("latitude %v di luar rentang -90 sampai 90", latitude)
+	}
+	if math.IsNaN(longitude) || longitude < -180 || longitude > 180 {
+		return fmt.Errorf("longitude %v di luar rentang -180 sampai 180", longitude)
+	}
+	return nil
+}
+
+func validasiPosisi(posisi posisiGeoJson) error {
+	if len(posisi) < 2 {
+		return fmt.Errorf("posisi harus berisi longitude dan latitude")
+	}
+	return ValidasiKoordinat(posisi[1], posisi[0])
+}
+
+func validasiDaftarPosisi(daftar []posisiGeoJson, minimal int) error {
+	if len(daftar) < minimal {
+		return fmt.Errorf("minimal %d posisi", minimal)
+	}
+	for _, posisi := range daftar {
+		if err := validasiPosisi(posisi); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func validasiPoligon(cincin [][]posisiGeoJson) error {
+	if len(cincin) == 0 {
+		return fmt.Errorf("polygon tidak memiliki ring")
+	}
+	for _, ring := range cincin {
+		if err := validasiDaftarPosisi(ring, 4); err != nil {
+			return fmt.Errorf("ring polygon: %w", err)
+		}
+		awal, akhir := ring[0], ring[len(ring)-1]
+		if awal[0] != akhir[0] || awal[1] != akhir[1] {
+			return fmt.Errorf("ring polygon harus tertutup, posisi awal dan akhir sama")
+		}
+	}
+	return nil
+}
+
+// ValidasiGeometri memeriksa struktur dan rentang koordinat geometri GeoJSON, mengembalikan tipenya
+func ValidasiGeometri(raw []byte) (string, error) {
+	var geometri geometriGeoJson
+	if err := json.Unmarshal(raw, &geometri); err != nil {
+		return "", fmt.Errorf("geometri bukan GeoJSON yang valid: %w", err)
+	}
+	if len(geometri.Coordinates) == 0 {
+		return "", fmt.Errorf("geometri %s tidak memiliki coordinates", geometri.Type)
+	}
+
+	var err error
+	switch geometri.Type {
+	case GeometriPoint:
+		var posisi posisiGeoJson
+		if err = json.Unmarshal(geometri.Coordinates, &posisi); err == nil {
+			err = validasiPosisi(posisi)
+		}
+	case GeometriMultiPoint:
+		var daftar []posisiGeoJson
+		if err = json.Unmarshal(geometri.Coordinates, &daftar); err == nil {
+			err = validasiDaftarPosisi(daftar, 1)
+		}
+	case GeometriLineString:
+		var daftar []posisiGeoJson
+		if err = json.Unmarshal(geometri.Coordinates, &daftar); err == nil {
+			err = validasiDaftarPosisi(daftar, 2)
+		}
+	case GeometriMultiLineString:
+		var garis [][]posisiGeoJson
+		if err = json.Unmarshal(geometri.Coordinates, &garis); err == nil {
+			for _, daftar := range garis {
+				if err = validasiDaftarPosisi(daftar, 2); err != nil {
+					break
+				}
+			}
+		}
+	case GeometriPolygon:
+		var cincin [][]posisiGeoJson
+		if err = json.Unmarshal(geometri.Coordinates, &cincin); err == nil {
+			err = validasiPoligon(cincin)
+		}
+	case GeometriMultiPolygon:
+		var poligon [][][]posisiGeoJson
+		if err = json.Unmarshal(geometri.Coordinates, &poligon); err == nil {
+			if len(poligon) == 0 {
+				err = fmt.Errorf("multipolygon kosong")
+			}
+			for _, cincin := range poligon {
+				if err = validasiPoligon(cincin); err != nil {
+					break
+				}
+			}
+		}
+	default:
+		return "", fmt.Errorf("tipe geometri %q tidak didukung", geometri.Type)
+	}
+	if err != nil {
+		return "", fmt.Errorf("geometri %s tidak valid: %w", geometri.Type, err)
+	}
+	return geometri.Type, nil
+}
+
+// semuaPosisi seluruh posisi geometri tanpa memperhatikan struktur, dipakai untuk titik tengah
+func semuaPosisi(geometri geometriGeoJson) []posisiGeoJson {
+	switch geometri.Type {
+	case GeometriPoint:
+		var posisi posisiGeoJson
+		if json.Unmarshal(geometri.Coordinates, &posisi) == nil {
+			return []posisiGeoJson{posisi}
+		}
+	case GeometriMultiPoint, GeometriLineString:
+		var daftar []posisiGeoJson
+		if json.Unmarshal(geometri.Coordinates, &daftar) == nil {
+			return daftar
+		}
+	case GeometriMultiLineString:
+		var garis [][]posisiGeoJson
+		if json.Unmarshal(geometri.Coordinates, &garis) == nil {
+			var hasil []posisiGeoJson
+			for _, daftar := range garis {
+				hasil = append(hasil, daftar...)
+			}
+			return hasil
+		}
+	case GeometriPolygon:
+		// hanya ring luar, lubang tidak menggeser titik tengah
+		var cincin [][]posisiGeoJson
+		if json.Unmarshal(geometri.Coordinates, &cincin) == nil && len(cincin) > 0 {
+			return cincin[0]
+		}
+	case GeometriMultiPolygon:
+		var poligon [][][]posisiGeoJson
+		if json.Unmarshal(geometri.Coordinates, &poligon) == nil {
+			var hasil []posisiGeoJson
+			for _, cincin := range poligon {
+				if len(cincin) > 0 {
+					hasil = append(hasil, cincin[0]...)
+				}
+			}
+			return hasil
+		}
+	}
+	return nil
+}
+
+// TitikTengahGeometri titik tengah bounding box geometri, cukup untuk menaruh marker di peta
+func TitikTengahGeometri(raw []byte) (latitude float64, longitude float64, ok bool) {
+	var geometri geometriGeoJson
+	if json.Unmarshal(raw, &geometri) != nil {
+		return 0, 0, false
+	}
+	daftar := semuaPosisi(geometri)
+	if len(daftar) == 0 {
+		return 0, 0, false
+	}
+	minLon, maxLon := math.Inf(1), math.Inf(-1)
+	minLat, maxLat := math.Inf(1), math.Inf(-1)
+	for _, posisi := range daftar {
+		if len(posisi) < 2 {
+			continue
+		}
+		minLon, maxLon = math.Min(minLon, posisi[0]), math.Max(maxLon, posisi[0])
+		minLat, maxLat = math.Min(minLat, posisi[1]), math.Max(maxLat, posisi[1])
+	}
+	if math.IsInf(minLon, 0) {
+		return 0, 0, false
+	}
+	return (minLat + maxLat) / 2, (minLon + maxLon) / 2, true
+}
+
+// dalamRing ray casting, titik tepat di garis batas dianggap bisa masuk ke salah satu sisi
+func dalamRing(ring []posisiGeoJson, latitude, longitude float64) bool {
+	dalam := false
+	for i, j := 0, len(ring)-1; i < len(ring); j, i = i, i+1 {
+		if len(ring[i]) < 2 || len(ring[j]) < 2 {
+			continue
+		}
+		xi, yi := ring[i][0], ring[i][1]
+		xj, yj := ring[j][0], ring[j][1]
+		if (yi > latitude) != (yj > latitude) && longitude < (xj-xi)*(latitude-yi)/(yj-yi)+xi {
+			dalam = !dalam
+		}
+	}
+	return dalam
+}
+
+func dalamPoligon(cincin [][]posisiGeoJson, latitude, longitude float64) bool {
+	if len(cincin) == 0 || !dalamRing(cincin[0], latitude, longitude) {
+		return false
+	}
+	for _, lubang := range cincin[1:] {
+		if dalamRing(lubang, latitude, longitude) {
+			return false
+		}
+	}
+	return true
+}
+
+// TitikDalamGeometri true jika titik berada di dalam Polygon atau MultiPolygon, tipe lain selalu false
+func TitikDalamGeometri(raw []byte, latitude, longitude float64) bool {
+	var geometri geometriGeoJson
+	if json.Unmarshal(raw, &geometri) != nil {
+		return false
+	}
+	switch geometri.Type {
+	case GeometriPolygon:
+		var cincin [][]posisiGeoJson
+		if json.Unmarshal(geometri.Coordinates, &cincin) == nil {
+			return dalamPoligon(cincin, latitude, longitude)
+		}
+	case GeometriMultiPolygon:
+		var poligon [][][]posisiGeoJson
+		if json.Unmarshal(geometri.Coordinates, &poligon) == nil {
+			for _, cincin := range poligon {
+				if dalamPoligon(cincin, latitude, longitude) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
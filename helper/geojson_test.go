@@ -0,0 +1,71 @@
+package helper
+
+import (
+	"testing"
+)
+
+// persegi 111.0-111.2 BT, 7.7-7.5 LS dengan lubang di tengah
+const poligonUji = `{"type":"Polygon","coordinates":[
+	[[111.0,-7.7],[111.2,-7.7],[111.2,-7.5],[111.0,-7.5],[111.0,-7.7]],
+	[[111.09,-7.61],[111.11,-7.61],[111.11,-7.59],[111.09,-7.59],[111.09,-7.61]]
+]}`
+
+func TestValidasiGeometri(t *testing.T) {
+	tests := []struct {
+		name     string
+		raw      string
+		expected string
+		valid    bool
+	}{
+		{name: "point", raw: `{"type":"Point","coordinates":[111.5,-7.6]}`, expected: GeometriPoint, valid: true},
+		{name: "polygon dengan lubang", raw: poligonUji, expected: GeometriPolygon, valid: true},
+		{name: "multipolygon", raw: `{"type":"MultiPolygon","coordinates":[[[[0,0],[1,0],[1,1],[0,0]]]]}`, expected: GeometriMultiPolygon, valid: true},
+		{name: "latitude di luar rentang", raw: `{"type":"Point","coordinates":[111.5,-97.6]}`},
+		{name: "ring tidak tertutup", raw: `{"type":"Polygon","coordinates":[[[0,0],[1,0],[1,1],[0,1]]]}`},
+		{name: "linestring satu titik", raw: `{"type":"LineString","coordinates":[[0,0]]}`},
+		{name: "tipe tidak didukung", raw: `{"type":"GeometryCollection","geometries":[]}`},
+		{name: "bukan json", raw: `POINT(111 -7)`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tipe, err := ValidasiGeometri([]byte(tt.raw))
+			if tt.valid && (err != nil || tipe != tt.expected) {
+				t.Errorf("ValidasiGeometri() = %q, %v, expected %q", tipe, err, tt.expected)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("ValidasiGeometri() = %q, expected error", tipe)
+			}
+		})
+	}
+}
+
+func TestTitikDalamGeometri(t *testing.T) {
+	tests := []struct {
+		name      string
+		latitude  float64
+		longitude float64
+		expected  bool
+	}{
+		{name: "di dalam", latitude: -7.65, longitude: 111.05, expected: true},
+		{name: "di luar", latitude: -7.65, longitude: 111.25},
+		{name: "di dalam lubang", latitude: -7.60, longitude: 111.10},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := TitikDalamGeometri([]byte(poligonUji), tt.latitude, tt.longitude); result != tt.expected {
+				t.Errorf("TitikDalamGeometri(%v, %v) = %v, expected %v", tt.latitude, tt.longitude, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTitikTengahGeometri(t *testing.T) {
+	latitude, longitude, ok := TitikTengahGeometri([]byte(poligonUji))
+	if !ok || latitude != -7.6 || longitude < 111.0999 || longitude > 111.1001 {
+		t.Errorf("TitikTengahGeometri() = %v, %v, %v", latitude, longitude, ok)
+	}
+	if string(GeometriTitik(-7.6, 111.1)) != `{"type":"Point","coordinates":[111.1,-7.6]}` {
+		t.Errorf("GeometriTitik() = %s", GeometriTitik(-7.6, 111.1))
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"ekak_kabupaten_madiun/model/web"
 	"encoding/json"
 	"net/http"
+	"reflect"
 )
 
 func ReadFromRequestBody(request *http.Request, result interface{}) {
@@ -12,8 +13,12 @@ func ReadFromRequestBody(request *http.Request, result interface{}) {
 	PanicIfError(err)
 }
 
+// WriteToResponseBody menulis response JSON, HTTP status diambil dari field Code response
 func WriteToResponseBody(writer http.ResponseWriter, response interface{}) {
 	writer.Header().Add("Content-Type", "application/json")
+	if status := responseStatusCode(response); status != 0 {
+		writer.WriteHeader(status)
+	}
 	encoder := json.NewEncoder(writer)
 	err := encoder.Encode(response)
 	PanicIfError(err)
@@ -26,3 +31,26 @@ func WriteToResponseBodyWstatus(writer http.ResponseWriter, response web.WebResp
 	err := encoder.Encode(response)
 	PanicIfError(err)
 }
+
+// responseStatusCode membaca field Code (int) dari struct response web.*, 0 jika tidak ada / tidak valid
+func responseStatusCode(response interface{}) int {
+	value := reflect.ValueOf(response)
+	if value.Kind() == reflect.Ptr {
+		if value.IsNil() {
+			return 0
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return 0
+	}
+	field := value.FieldByName("Code")
+	if !field.IsValid() || field.Kind() != reflect.Int {
+		return 0
+	}
+	code := int(field.Int())
+	if code < 100 || code > 599 {
+		return 0
+	}
+	return code
+}
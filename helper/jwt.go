@@ -1,28 +1,57 @@
 package helper
 
 import (
+	"context"
 	"ekak_kabupaten_madiun/model/web"
+	"errors"
 	"fmt"
 	"os"
 	"time"
 
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 )
 
 var jwtSecretKey = []byte(os.Getenv("JWT_SECRET_KEY"))
 var jwtIssuer = os.Getenv("JWT_ISSUER")
+
+// JWT_EXPIRATION (jam) sekarang menjadi umur refresh token,
+// access token memakai JWT_ACCESS_EXPIRATION (menit)
 var jwtExpiration = os.Getenv("JWT_EXPIRATION")
+var jwtAccessExpiration = os.Getenv("JWT_ACCESS_EXPIRATION")
 
-func CreateNewJWT(userId int, pegawaiId string, email string, nip string, kodeOpd string, namaOpd string, namaPegawai string, roles []string) string {
-	exp := 24 * time.Hour
+const (
+	tokenTypeAccess  = "access"
+	tokenTypeRefresh = "refresh"
+)
+
+// AccessTokenDuration umur access token, default 15 menit
+func AccessTokenDuration() time.Duration {
+	if jwtAccessExpiration != "" {
+		if duration, err := time.ParseDuration(jwtAccessExpiration + "m"); err == nil {
+			return duration
+		}
+	}
+	return 15 * time.Minute
+}
+
+// RefreshTokenDuration umur refresh token, default 24 jam
+func RefreshTokenDuration() time.Duration {
 	if jwtExpiration != "" {
 		if duration, err := time.ParseDuration(jwtExpiration + "h"); err == nil {
-			exp = duration
+			return duration
 		}
 	}
+	return 24 * time.Hour
+}
+
+func CreateNewJWT(userId int, pegawaiId string, email string, nip string, kodeOpd string, namaOpd string, namaPegawai string, roles []string) string {
+	exp := AccessTokenDuration()
 
 	claims := jwt.MapClaims{
 		"iss":          jwtIssuer,
+		"jti":          uuid.New().String(),
+		"typ":          tokenTypeAccess,
 		"user_id":      userId,
 		"pegawai_id":   pegawaiId,
 		"email":        email,
@@ -58,6 +87,16 @@ func ValidateJWT(tokenString string) web.JWTClaim {
 	}
 
 	if claims, ok := token.Claims.(jwt.MapClaims); ok && token.Valid {
+		// refresh token tidak boleh dipakai sebagai access token
+		if typ, _ := claims["typ"].(string); typ == tokenTypeRefresh {
+			return web.JWTClaim{}
+		}
+
+		jti, _ := claims["jti"].(string)
+		if tokenStore.IsRevoked(context.Background(), jti) {
+			return web.JWTClaim{}
+		}
+
 		var roles []string
 		if rolesInterface, exists := claims["roles"]; exists {
 			if rolesArray, ok := rolesInterface.([]interface{}); ok {
@@ -114,7 +153,13 @@ func ValidateJWT(tokenString string) web.JWTClaim {
 			namaOpd = opd
 		}
 
+		// token diterbitkan sebelum sesi user dicabut (ganti / reset password)
+		if iat < tokenStore.UserRevokedAt(context.Background(), userId) {
+			return web.JWTClaim{}
+		}
+
 		return web.JWTClaim{
+			Jti:       jti,
 			Issuer:    issuer,
 			UserId:    userId,
 			PegawaiId: pegawaiId,
@@ -130,3 +175,71 @@ func ValidateJWT(tokenString string) web.JWTClaim {
 
 	return web.JWTClaim{}
 }
+
+// CreateRefreshToken membuat refresh token, hanya berisi user_id dan jti
+func CreateRefreshToken(userId int) (string, error) {
+	claims := jwt.MapClaims{
+		"iss":     jwtIssuer,
+		"jti":     uuid.New().String(),
+		"typ":     tokenTypeRefresh,
+		"user_id": userId,
+		"iat":     time.Now().Unix(),
+		"exp":     time.Now().Add(RefreshTokenDuration()).Unix(),
+	}
+
+	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
+	return token.SignedString(jwtSecretKey)
+}
+
+// ValidateRefreshToken memvalidasi refresh token dan memastikan jti belum dicabut
+func ValidateRefreshToken(tokenString string) (web.JWTClaim, error) {
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
+		}
+		return jwtSecretKey, nil
+	})
+	if err != nil || !token.Valid {
+		return web.JWTClaim{}, errors.New("refresh token tidak valid")
+	}
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	if !ok {
+		return web.JWTClaim{}, errors.New("refresh token tidak valid")
+	}
+
+	if typ, _ := claims["typ"].(string); typ != tokenTypeRefresh {
+		return web.JWTClaim{}, errors.New("refresh token tidak valid")
+	}
+
+	jti, _ := claims["jti"].(string)
+	if jti == "" || tokenStore.IsRevoked(context.Background(), jti) {
+		return web.JWTClaim{}, errors.New("refresh token sudah tidak berlaku")
+	}
+
+	userId := 0
+	if id, ok := claims["user_id"].(float64); ok {
+		userId = int(id)
+	}
+
+	iat := int64(0)
+	if issuedAt, ok := claims["iat"].(float64); ok {
+		iat = int64(issuedAt)
+	}
+
+	exp := int64(0)
+	if expiry, ok := claims["exp"].(float64); ok {
+		exp = int64(expiry)
+	}
+
+	if iat < tokenStore.UserRevokedAt(context.Background(), userId) {
+		return web.JWTClaim{}, errors.New("refresh token sudah tidak berlaku")
+	}
+
+	return web.JWTClaim{
+		Jti:    jti,
+		UserId: userId,
+		Iat:    iat,
+		Exp:    exp,
+	}, nil
+}
@@ -0,0 +1,217 @@
+package helper
+
+import (
+	"context"
+	"log"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CacheKeyLoginAttempt prefix key redis untuk percobaan login gagal
+const CacheKeyLoginAttempt = "login_attempt"
+
+const (
+	// batas gagal per NIP sebelum akun dikunci sementara
+	LoginMaxFailuresNip = 5
+	// batas gagal per IP lebih longgar karena banyak pegawai di belakang satu IP kantor
+	LoginMaxFailuresIp = 20
+	// lama kunci pertama, berlipat dua setiap gagal berikutnya
+	loginBaseLockout = 1 * time.Minute
+	loginMaxLockout  = 30 * time.Minute
+	// hitungan gagal otomatis hilang setelah cool-down tanpa percobaan gagal
+	loginAttemptWindow = 1 * time.Hour
+	loginMemoryLimit   = 10000
+)
+
+type LoginAttempt struct {
+	Failures    int       `json:"failures"`
+	LockedUntil time.Time `json:"locked_until"`
+	LastFailure time.Time `json:"last_failure"`
+}
+
+// IsLocked mengembalikan true dan sisa waktu jika masih terkunci
+func (attempt LoginAttempt) IsLocked() (bool, time.Duration) {
+	remaining := time.Until(attempt.LockedUntil)
+	if remaining > 0 {
+		return true, remaining
+	}
+	return false, 0
+}
+
+// LoginLimiter mencatat percobaan login gagal per key (nip / ip),
+// disimpan di redis dengan fallback in-memory
+type LoginLimiter struct {
+	client *redis.Client
+	mu     sync.Mutex
+	memory map[string]LoginAttempt
+}
+
+func NewLoginLimiter(client *redis.Client) *LoginLimiter {
+	return &LoginLimiter{
+		client: client,
+		memory: make(map[string]LoginAttempt),
+	}
+}
+
+func LoginKeyNip(nip string) string {
+	return GenerateCacheKey(CacheKeyLoginAttempt, "nip", nip)
+}
+
+func LoginKeyIp(ip string) string {
+	return GenerateCacheKey(CacheKeyLoginAttempt, "ip", ip)
+}
+
+// LockoutDuration menghitung lama kunci (exponential backoff) untuk jumlah gagal tertentu
+func LockoutDuration(failures int, maxFailures int) time.Duration {
+	if failures < maxFailures {
+		return 0
+	}
+	lockout := loginBaseLockout * time.Duration(math.Pow(2, float64(failures-maxFailures)))
+	if lockout > loginMaxLockout || lockout <= 0 {
+		return loginMaxLockout
+	}
+	return lockout
+}
+
+func (limiter *LoginLimiter) Get(ctx context.Context, key string) LoginAttempt {
+	if limiter.client != nil {
+		ctx, cancel := context.WithTimeout(ctx, tokenStoreTimeout)
+		defer cancel()
+
+		fields, err := limiter.client.HGetAll(ctx, key).Result()
+		if err == nil {
+			return loginAttemptDariHash(fields)
+		}
+		log.Printf("Warning: gagal membaca login attempt dari redis, memakai memory: %v", err)
+	}
+
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	attempt, found := limiter.memory[key]
+	if found && time.Since(attempt.LastFailure) > loginAttemptWindow {
+		delete(limiter.memory, key)
+		return LoginAttempt{}
+	}
+	return attempt
+}
+
+// naikkanLockedUntil hanya memperpanjang kunci, request paralel dengan hitungan lebih kecil tidak memperpendeknya
+var naikkanLockedUntil = redis.NewScript(`
+local current = tonumber(redis.call('HGET', KEYS[1], 'locked_until') or '0')
+if tonumber(ARGV[1]) > current then
+	redis.call('HSET', KEYS[1], 'locked_until', ARGV[1])
+end
+return 0
+`)
+
+// RegisterFailure menambah hitungan gagal dan mengunci key jika melewati batas
+func (limiter *LoginLimiter) RegisterFailure(ctx context.Context, key string, maxFailures int) LoginAttempt {
+	if limiter.client != nil {
+		attempt, err := limiter.registerFailureRedis(ctx, key, maxFailures)
+		if err == nil {
+			limiter.simpanMemory(key, attempt)
+			return attempt
+		}
+		log.Printf("Warning: gagal mencatat login attempt di redis, memakai memory: %v", err)
+	}
+
+	// hanya fallback in-memory yang memakai read-modify-write di bawah mutex
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	attempt := limiter.memory[key]
+	if time.Since(attempt.LastFailure) > loginAttemptWindow {
+		attempt = LoginAttempt{}
+	}
+	attempt.Failures++
+	attempt.LastFailure = time.Now()
+	if lockout := LockoutDuration(attempt.Failures, maxFailures); lockout > 0 {
+		attempt.LockedUntil = time.Now().Add(lockout)
+	}
+	limiter.simpanMemoryLocked(key, attempt)
+	return attempt
+}
+
+// registerFailureRedis menaikkan hitungan secara atomik (HINCRBY + PEXPIRE dalam satu MULTI)
+// agar percobaan paralel di beberapa instance tidak saling menimpa
+func (limiter *LoginLimiter) registerFailureRedis(ctx context.Context, key string, maxFailures int) (LoginAttempt, error) {
+	ctx, cancel := context.WithTimeout(ctx, tokenStoreTimeout)
+	defer cancel()
+
+	now := time.Now()
+	var failures *redis.IntCmd
+	_, err := limiter.client.TxPipelined(ctx, func(pipe redis.Pipeliner) error {
+		failures = pipe.HIncrBy(ctx, key, "failures", 1)
+		pipe.HSet(ctx, key, "last_failure", now.UnixMilli())
+		// window selalu lebih panjang dari kunci maksimal, jadi expire ini juga menutup masa kunci
+		pipe.PExpire(ctx, key, loginAttemptWindow)
+		return nil
+	})
+	if err != nil {
+		return LoginAttempt{}, err
+	}
+
+	if lockout := LockoutDuration(int(failures.Val()), maxFailures); lockout > 0 {
+		lockedUntil := now.Add(lockout).UnixMilli()
+		if err := naikkanLockedUntil.Run(ctx, limiter.client, []string{key}, lockedUntil).Err(); err != nil {
+			return LoginAttempt{}, err
+		}
+	}
+
+	fields, err := limiter.client.HGetAll(ctx, key).Result()
+	if err != nil {
+		return LoginAttempt{}, err
+	}
+	return loginAttemptDariHash(fields), nil
+}
+
+// Reset menghapus catatan gagal, dipakai saat login berhasil atau dibuka manual oleh admin
+func (limiter *LoginLimiter) Reset(ctx context.Context, key string) {
+	limiter.mu.Lock()
+	delete(limiter.memory, key)
+	limiter.mu.Unlock()
+
+	if limiter.client == nil {
+		return
+	}
+	if err := limiter.client.Del(ctx, key).Err(); err != nil {
+		log.Printf("Warning: gagal menghapus login attempt %s dari redis: %v", key, err)
+	}
+}
+
+func (limiter *LoginLimiter) simpanMemory(key string, attempt LoginAttempt) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+	limiter.simpanMemoryLocked(key, attempt)
+}
+
+// simpanMemoryLocked dipanggil dengan limiter.mu sudah terkunci
+func (limiter *LoginLimiter) simpanMemoryLocked(key string, attempt LoginAttempt) {
+	// bersihkan entry kedaluwarsa agar memory tidak terus membesar
+	if len(limiter.memory) > loginMemoryLimit {
+		for k, v := range limiter.memory {
+			if time.Since(v.LastFailure) > loginAttemptWindow && time.Now().After(v.LockedUntil) {
+				delete(limiter.memory, k)
+			}
+		}
+	}
+	limiter.memory[key] = attempt
+}
+
+// loginAttemptDariHash membaca hash redis (waktu dalam unix milidetik), hash kosong berarti belum ada gagal
+func loginAttemptDariHash(fields map[string]string) LoginAttempt {
+	var attempt LoginAttempt
+	attempt.Failures, _ = strconv.Atoi(fields["failures"])
+	if lastFailure, err := strconv.ParseInt(fields["last_failure"], 10, 64); err == nil {
+		attempt.LastFailure = time.UnixMilli(lastFailure)
+	}
+	if lockedUntil, err := strconv.ParseInt(fields["locked_until"], 10, 64); err == nil {
+		attempt.LockedUntil = time.UnixMilli(lockedUntil)
+	}
+	return attempt
+}
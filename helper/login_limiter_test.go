@@ -0,0 +1,88 @@
+package helper
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestLockoutDuration(t *testing.T) {
+	tests := []struct {
+		name     string
+		failures int
+		expected time.Duration
+	}{
+		{
+			name:     "belum mencapai batas",
+			failures: 4,
+			expected: 0,
+		},
+		{
+			name:     "tepat di batas",
+			failures: 5,
+			expected: 1 * time.Minute,
+		},
+		{
+			name:     "backoff berlipat",
+			failures: 7,
+			expected: 4 * time.Minute,
+		},
+		{
+			name:     "dibatasi maksimal",
+			failures: 50,
+			expected: 30 * time.Minute,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := LockoutDuration(tt.failures, LoginMaxFailuresNip)
+			if result != tt.expected {
+				t.Errorf("LockoutDuration(%d) = %v, expected %v", tt.failures, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestLoginLimiterRedisMatiMemakaiMemory(t *testing.T) {
+	// port 1 tidak pernah menerima koneksi sehingga setiap perintah redis gagal
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+	limiter := NewLoginLimiter(client)
+	ctx := context.Background()
+	key := LoginKeyNip("198001012005011001")
+
+	var attempt LoginAttempt
+	for i := 0; i < LoginMaxFailuresNip; i++ {
+		attempt = limiter.RegisterFailure(ctx, key, LoginMaxFailuresNip)
+	}
+	if attempt.Failures != LoginMaxFailuresNip {
+		t.Errorf("failures = %d, expected %d", attempt.Failures, LoginMaxFailuresNip)
+	}
+	if locked, _ := limiter.Get(ctx, key).IsLocked(); !locked {
+		t.Error("key harus terkunci setelah mencapai batas gagal")
+	}
+
+	limiter.Reset(ctx, key)
+	if attempt := limiter.Get(ctx, key); attempt.Failures != 0 {
+		t.Errorf("failures setelah reset = %d", attempt.Failures)
+	}
+}
+
+func TestLoginAttemptDariHash(t *testing.T) {
+	lockedUntil := time.Now().Add(time.Minute).Truncate(time.Millisecond)
+	attempt := loginAttemptDariHash(map[string]string{
+		"failures":     "6",
+		"last_failure": "1700000000000",
+		"locked_until": strconv.FormatInt(lockedUntil.UnixMilli(), 10),
+	})
+	if attempt.Failures != 6 || attempt.LastFailure.UnixMilli() != 1700000000000 || !attempt.LockedUntil.Equal(lockedUntil) {
+		t.Errorf("attempt = %+v", attempt)
+	}
+	if empty := loginAttemptDariHash(map[string]string{}); empty.Failures != 0 || !empty.LockedUntil.IsZero() {
+		t.Errorf("hash kosong = %+v", empty)
+	}
+}
@@ -420,6 +420,7 @@ func ToSubKegiatanResponse(subKegiatan domain.SubKegiatan) subkegiatan.SubKegiat
 		RekinId:              subKegiatan.RekinId,
 		KodeSubKegiatan:      subKegiatan.KodeSubKegiatan,
 		NamaSubKegiatan:      subKegiatan.NamaSubKegiatan,
+		IsDeprecated:         subKegiatan.IsDeprecated,
 		Indikator:            indikatorResponses,
 		IndikatorSubkegiatan: indikatorSubKegiatanResponses,
 		PaguSubKegiatan:      paguResponses,
@@ -0,0 +1,33 @@
+package helper
+
+import (
+	"errors"
+	"unicode"
+)
+
+const passwordMinLength = 8
+
+// ValidatePasswordPolicy: minimal 8 karakter, mengandung huruf besar, huruf kecil dan angka
+func ValidatePasswordPolicy(password string) error {
+	if len(password) < passwordMinLength {
+		return errors.New("password minimal 8 karakter")
+	}
+
+	var hasUpper, hasLower, hasDigit bool
+	for _, char := range password {
+		switch {
+		case unicode.IsUpper(char):
+			hasUpper = true
+		case unicode.IsLower(char):
+			hasLower = true
+		case unicode.IsDigit(char):
+			hasDigit = true
+		}
+	}
+
+	if !hasUpper || !hasLower || !hasDigit {
+		return errors.New("password harus mengandung huruf besar, huruf kecil dan angka")
+	}
+
+	return nil
+}
@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"testing"
+)
+
+func TestValidatePasswordPolicy(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		valid    bool
+	}{
+		{
+			name:     "password valid",
+			password: "Madiun2025",
+			valid:    true,
+		},
+		{
+			name:     "kurang dari 8 karakter",
+			password: "Mad1un",
+			valid:    false,
+		},
+		{
+			name:     "tanpa huruf besar",
+			password: "madiun2025",
+			valid:    false,
+		},
+		{
+			name:     "tanpa huruf kecil",
+			password: "MADIUN2025",
+			valid:    false,
+		},
+		{
+			name:     "tanpa angka",
+			password: "MadiunKab",
+			valid:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidatePasswordPolicy(tt.password)
+			if tt.valid && err != nil {
+				t.Errorf("expected valid, got %v", err)
+			}
+			if !tt.valid && err == nil {
+				t.Errorf("expected error for %q", tt.password)
+			}
+		})
+	}
+}
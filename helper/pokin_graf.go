@@ -0,0 +1,485 @@
+package helper
+
+import (
+	"bytes"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"encoding/json"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"strings"
+)
+
+// format unduhan graf pohon kinerja
+const (
+	FormatSvg     = "svg"
+	FormatPng     = "png"
+	FormatGraphml = "graphml"
+)
+
+// ukuran layout graf dalam pixel, teks memakai lebar karakter tetap agar SVG dan PNG identik
+const (
+	grafLebarNode     = 240.0
+	grafJarakX        = 30.0
+	grafJarakY        = 60.0
+	grafMargin        = 40.0
+	grafPadding       = 8.0
+	grafTinggiBaris   = 14.0
+	grafLebarKarakter = 6.0
+	grafMaksPixel     = 40_000_000
+)
+
+// GrafPokin pohon kinerja dalam bentuk node dan edge yang sudah diberi posisi
+type GrafPokin struct {
+	Judul  string          `json:"judul"`
+	Lebar  float64         `json:"lebar"`
+	Tinggi float64         `json:"tinggi"`
+	Nodes  []NodeGrafPokin `json:"nodes"`
+	Edges  []EdgeGrafPokin `json:"edges"`
+}
+
+type NodeGrafPokin struct {
+	Id         string   `json:"id"`
+	PohonId    int      `json:"pohon_id,omitempty"`
+	Nama       string   `json:"nama"`
+	JenisPohon string   `json:"jenis_pohon"`
+	LevelPohon int      `json:"level_pohon"`
+	Indikator  []string `json:"indikator"`
+	Pelaksana  []string `json:"pelaksana"`
+	Warna      string   `json:"warna"`
+	X          float64  `json:"x"`
+	Y          float64  `json:"y"`
+	Lebar      float64  `json:"lebar"`
+	Tinggi     float64  `json:"tinggi"`
+
+	kedalaman int
+	baris     []barisGraf
+}
+
+type EdgeGrafPokin struct {
+	Source string `json:"source"`
+	Target string `json:"target"`
+}
+
+type barisGraf struct {
+	teks  string
+	tebal bool
+}
+
+type warnaGraf struct {
+	isi   string
+	garis string
+}
+
+// warnaJenisPohon warna node berdasarkan jenis pohon, varian Pemda dan Operational N mengikuti induknya
+func warnaJenisPohon(jenisPohon string) warnaGraf {
+	switch {
+	case jenisPohon == "Tematik":
+		return warnaGraf{isi: "#FEE2E2", garis: "#B91C1C"}
+	case strings.Contains(jenisPohon, "Tematik"):
+		return warnaGraf{isi: "#FEF3C7", garis: "#B45309"}
+	case strings.HasPrefix(jenisPohon, "Strategic"):
+		return warnaGraf{isi: "#DBEAFE", garis: "#1D4ED8"}
+	case strings.HasPrefix(jenisPohon, "Tactical"):
+		return warnaGraf{isi: "#DCFCE7", garis: "#15803D"}
+	case strings.HasPrefix(jenisPohon, "Operational"):
+		return warnaGraf{isi: "#F3E8FF", garis: "#7E22CE"}
+	}
+	return warnaGraf{isi: "#F3F4F6", garis: "#4B5563"}
+}
+
+// NewGrafPokinTematik membangun graf dari hierarki pohon kinerja tematik
+func NewGrafPokinTematik(judul string, tematik pohonkinerja.TematikResponse) *GrafPokin {
+	graf := &GrafPokin{Judul: judul}
+	akar := graf.tambahNode(-1, nodePokin(tematik.Id, tematik.Tema, tematik.JenisPohon, tematik.LevelPohon, tematik.Indikators, nil))
+	graf.tambahChildTematik(akar, tematik.Child)
+	graf.susunLayout()
+	return graf
+}
+
+// NewGrafPokinOpd membangun graf pohon kinerja OPD, node judul dipakai sebagai akar bersama strategic
+func NewGrafPokinOpd(judul string, strategics []pohonkinerja.StrategicOpdResponse) *GrafPokin {
+	graf := &GrafPokin{Judul: judul}
+	akar := graf.tambahNode(-1, NodeGrafPokin{Nama: judul, JenisPohon: "Pohon Kinerja"})
+	for _, strategic := range strategics {
+		indeksStrategic := graf.tambahNode(akar, nodePokin(strategic.Id, strategic.Strategi, strategic.JenisPohon, strategic.LevelPohon, strategic.Indikator, strategic.Pelaksana))
+		for _, tactical := range strategic.Tacticals {
+			indeksTactical := graf.tambahNode(indeksStrategic, nodePokin(tactical.Id, tactical.Strategi, tactical.JenisPohon, tactical.LevelPohon, tactical.Indikator, tactical.Pelaksana))
+			for _, operational := range tactical.Operationals {
+				indeksOperational := graf.tambahNode(indeksTactical, nodePokin(operational.Id, operational.Strategi, operational.JenisPohon, operational.LevelPohon, operational.Indikator, operational.Pelaksana))
+				graf.tambahOperationalNOpd(indeksOperational, operational.Childs)
+			}
+		}
+	}
+	graf.susunLayout()
+	return graf
+}
+
+// tambahChildTematik childs tematik berisi campuran sub tematik s.d. operational, tipe lain diabaikan
+func (graf *GrafPokin) tambahChildTematik(parent int, childs []interface{}) {
+	for _, child := range childs {
+		switch pohon := child.(type) {
+		case pohonkinerja.SubtematikResponse:
+			indeks := graf.tambahNode(parent, nodePokin(pohon.Id, pohon.Tema, pohon.JenisPohon, pohon.LevelPohon, pohon.Indikators, nil))
+			graf.tambahChildTematik(indeks, pohon.Child)
+		case pohonkinerja.SubSubTematikResponse:
+			indeks := graf.tambahNode(parent, nodePokin(pohon.Id, pohon.Tema, pohon.JenisPohon, pohon.LevelPohon, pohon.Indikators, nil))
+			graf.tambahChildTematik(indeks, pohon.Child)
+		case pohonkinerja.SuperSubTematikResponse:
+			indeks := graf.tambahNode(parent, nodePokin(pohon.Id, pohon.Tema, pohon.JenisPohon, pohon.LevelPohon, pohon.Indikators, nil))
+			graf.tambahChildTematik(indeks, pohon.Childs)
+		case pohonkinerja.StrategicResponse:
+			indeks := graf.tambahNode(parent, nodePokin(pohon.Id, pohon.Strategi, pohon.JenisPohon, pohon.LevelPohon, pohon.Indikators, pohon.Pelaksana))
+			graf.tambahChildTematik(indeks, pohon.Childs)
+		case pohonkinerja.TacticalResponse:
+			indeks := graf.tambahNode(parent, nodePokin(pohon.Id, pohon.Strategi, pohon.JenisPohon, pohon.LevelPohon, pohon.Indikators, pohon.Pelaksana))
+			graf.tambahChildTematik(indeks, pohon.Childs)
+		case pohonkinerja.OperationalResponse:
+			indeks := graf.tambahNode(parent, nodePokin(pohon.Id, pohon.Strategi, pohon.JenisPohon, pohon.LevelPohon, pohon.Indikators, pohon.Pelaksana))
+			graf.tambahChildTematik(indeks, pohon.Childs)
+		case pohonkinerja.OperationalNResponse:
+			graf.tambahOperationalN(parent, pohon)
+		}
+	}
+}
+
+func (graf *GrafPokin) tambahOperationalN(parent int, pohon pohonkinerja.OperationalNResponse) {
+	indeks := graf.tambahNode(parent, nodePokin(pohon.Id, pohon.Strategi, pohon.JenisPohon, pohon.LevelPohon, pohon.Indikators, pohon.Pelaksana))
+	for _, child := range pohon.Childs {
+		graf.tambahOperationalN(indeks, child)
+	}
+}
+
+func (graf *GrafPokin) tambahOperationalNOpd(parent int, childs []pohonkinerja.OperationalNOpdResponse) {
+	for _, pohon := range childs {
+		indeks := graf.tambahNode(parent, nodePokin(pohon.Id, pohon.Strategi, pohon.JenisPohon, pohon.LevelPohon, pohon.Indikator, pohon.Pelaksana))
+		graf.tambahOperationalNOpd(indeks, pohon.Childs)
+	}
+}
+
+// nodePokin isi node dari field yang sama di setiap level, indikator ditulis beserta target pertamanya
+func nodePokin(id int, nama, jenisPohon string, levelPohon int, indikators []pohonkinerja.IndikatorResponse, pelaksanas []pohonkinerja.PelaksanaOpdResponse) NodeGrafPokin {
+	node := NodeGrafPokin{
+		PohonId:    id,
+		Nama:       strings.TrimSpace(nama),
+		JenisPohon: strings.TrimSpace(jenisPohon),
+		LevelPohon: levelPohon,
+	}
+	for _, indikator := range indikators {
+		teks := strings.TrimSpace(indikator.NamaIndikator)
+		if len(indikator.Target) > 0 {
+			target := indikator.Target[0]
+			if nilai := strings.TrimSpace(strings.TrimSpace(target.TargetIndikator) + " " + strings.TrimSpace(target.SatuanIndikator)); nilai != "" {
+				teks += " (" + nilai + ")"
+			}
+		}
+		node.Indikator = append(node.Indikator, teks)
+	}
+	for _, pelaksana := range pelaksanas {
+		node.Pelaksana = append(node.Pelaksana, strings.TrimSpace(pelaksana.NamaPegawai))
+	}
+	return node
+}
+
+func (graf *GrafPokin) tambahNode(parent int, node NodeGrafPokin) int {
+	indeks := len(graf.Nodes)
+	node.Id = fmt.Sprintf("n%d", indeks)
+	node.Warna = warnaJenisPohon(node.JenisPohon).isi
+	if parent >= 0 {
+		node.kedalaman = graf.Nodes[parent].kedalaman + 1
+		graf.Edges = append(graf.Edges, EdgeGrafPokin{Source: graf.Nodes[parent].Id, Target: node.Id})
+	}
+	graf.Nodes = append(graf.Nodes, node)
+	return indeks
+}
+
+func lebarKarakterGraf(teks string) float64 {
+	return float64(len([]rune(teks))) * grafLebarKarakter
+}
+
+// barisNode isi teks node: jenis pohon, nama, indikator lalu pelaksana
+func barisNode(node NodeGrafPokin) []barisGraf {
+	lebarTeks := grafLebarNode - 2*grafPadding
+	baris := []barisGraf{{teks: strings.ToUpper(node.JenisPohon)}}
+	for _, teks := range wrapTeksUkur(node.Nama, lebarKarakterGraf, lebarTeks) {
+		baris = append(baris, barisGraf{teks: teks, tebal: true})
+	}
+	if len(node.Indikator) > 0 {
+		baris = append(baris, barisGraf{teks: "Indikator:"})
+		for _, indikator := range node.Indikator {
+			for _, teks := range wrapTeksUkur("- "+indikator, lebarKarakterGraf, lebarTeks) {
+				baris = append(baris, barisGraf{teks: teks})
+			}
+		}
+	}
+	if len(node.Pelaksana) > 0 {
+		for _, teks := range wrapTeksUkur("Pelaksana: "+strings.Join(node.Pelaksana, ", "), lebarKarakterGraf, lebarTeks) {
+			baris = append(baris, barisGraf{teks: teks})
+		}
+	}
+	return baris
+}
+
+// susunLayout menata pohon dari atas ke bawah: daun berjajar dari kiri, induk di tengah anak-anaknya
+func (graf *GrafPokin) susunLayout() {
+	if len(graf.Nodes) == 0 {
+		return
+	}
+	anak := make([][]int, len(graf.Nodes))
+	indeks := make(map[string]int, len(graf.Nodes))
+	for i, node := range graf.Nodes {
+		indeks[node.Id] = i
+	}
+	for _, edge := range graf.Edges {
+		anak[indeks[edge.Source]] = append(anak[indeks[edge.Source]], indeks[edge.Target])
+	}
+
+	var tinggiLevel []float64
+	for i := range graf.Nodes {
+		node := &graf.Nodes[i]
+		node.baris = barisNode(*node)
+		node.Lebar = grafLebarNode
+		node.Tinggi = 2*grafPadding + float64(len(node.baris))*grafTinggiBaris
+		for len(tinggiLevel) <= node.kedalaman {
+			tinggiLevel = append(tinggiLevel, 0)
+		}
+		if node.Tinggi > tinggiLevel[node.kedalaman] {
+			tinggiLevel[node.kedalaman] = node.Tinggi
+		}
+	}
+	yLevel := make([]float64, len(tinggiLevel))
+	y := grafMargin
+	for level, tinggi := range tinggiLevel {
+		yLevel[level] = y
+		y += tinggi + grafJarakY
+	}
+
+	xDaun := grafMargin
+	var tempatkan func(i int)
+	tempatkan = func(i int) {
+		node := &graf.Nodes[i]
+		node.Y = yLevel[node.kedalaman]
+		if len(anak[i]) == 0 {
+			node.X = xDaun
+			xDaun += grafLebarNode + grafJarakX
+			return
+		}
+		for _, child := range anak[i] {
+			tempatkan(child)
+		}
+		pertama, terakhir := graf.Nodes[anak[i][0]], graf.Nodes[anak[i][len(anak[i])-1]]
+		node.X = (pertama.X + terakhir.X) / 2
+	}
+	for i, node := range graf.Nodes {
+		if node.kedalaman == 0 {
+			tempatkan(i)
+		}
+	}
+
+	graf.Lebar = xDaun - grafJarakX + grafMargin
+	graf.Tinggi = y - grafJarakY + grafMargin
+}
+
+func (graf *GrafPokin) node(id string) *NodeGrafPokin {
+	for i := range graf.Nodes {
+		if graf.Nodes[i].Id == id {
+			return &graf.Nodes[i]
+		}
+	}
+	return nil
+}
+
+// garisEdge titik siku dari bawah induk ke atas anak
+func (graf *GrafPokin) garisEdge(edge EdgeGrafPokin) [4][2]float64 {
+	source, target := graf.node(edge.Source), graf.node(edge.Target)
+	x1, y1 := source.X+source.Lebar/2, source.Y+source.Tinggi
+	x2, y2 := target.X+target.Lebar/2, target.Y
+	tengah := y2 - grafJarakY/2
+	return [4][2]float64{{x1, y1}, {x1, tengah}, {x2, tengah}, {x2, y2}}
+}
+
+func xmlEscape(teks string) string {
+	return docxEscape(teks)
+}
+
+// SVG merender graf sebagai gambar vektor
+func (graf *GrafPokin) SVG() []byte {
+	var buffer bytes.Buffer
+	fmt.Fprintf(&buffer, `<?xml version="1.0" encoding="UTF-8"?>`+"\n")
+	fmt.Fprintf(&buffer, `<svg xmlns="http://www.w3.org/2000/svg" width="%.0f" height="%.0f" viewBox="0 0 %.0f %.0f" font-family="DejaVu Sans Mono, Consolas, monospace" font-size="10">`+"\n", graf.Lebar, graf.Tinggi, graf.Lebar, graf.Tinggi)
+	fmt.Fprintf(&buffer, `<title>%s</title>`+"\n", xmlEscape(graf.Judul))
+	buffer.WriteString(`<rect width="100%" height="100%" fill="#FFFFFF"/>` + "\n")
+	for _, edge := range graf.Edges {
+		titik := graf.garisEdge(edge)
+		fmt.Fprintf(&buffer, `<polyline points="%.1f,%.1f %.1f,%.1f %.1f,%.1f %.1f,%.1f" fill="none" stroke="#6B7280" stroke-width="1.5"/>`+"\n",
+			titik[0][0], titik[0][1], titik[1][0], titik[1][1], titik[2][0], titik[2][1], titik[3][0], titik[3][1])
+	}
+	for _, node := range graf.Nodes {
+		warna := warnaJenisPohon(node.JenisPohon)
+		fmt.Fprintf(&buffer, `<g id="%s">`, node.Id)
+		fmt.Fprintf(&buffer, `<rect x="%.1f" y="%.1f" width="%.1f" height="%.1f" rx="4" fill="%s" stroke="%s" stroke-width="2"/>`, node.X, node.Y, node.Lebar, node.Tinggi, warna.isi, warna.garis)
+		for i, baris := range node.baris {
+			tebal := ""
+			if baris.tebal {
+				tebal = ` font-weight="bold"`
+			}
+			fmt.Fprintf(&buffer, `<text x="%.1f" y="%.1f"%s>%s</text>`, node.X+grafPadding, node.Y+grafPadding+float64(i+1)*grafTinggiBaris-4, tebal, xmlEscape(baris.teks))
+		}
+		buffer.WriteString("</g>\n")
+	}
+	buffer.WriteString("</svg>\n")
+	return buffer.Bytes()
+}
+
+func parseWarnaHex(hex string) color.RGBA {
+	var r, g, b uint8
+	fmt.Sscanf(strings.TrimPrefix(hex, "#"), "%02x%02x%02x", &r, &g, &b)
+	return color.RGBA{R: r, G: g, B: b, A: 255}
+}
+
+func isiKotak(img *image.RGBA, x0, y0, x1, y1 int, warna color.RGBA) {
+	if x0 > x1 {
+		x0, x1 = x1, x0
+	}
+	if y0 > y1 {
+		y0, y1 = y1, y0
+	}
+	for y := y0; y <= y1; y++ {
+		for x := x0; x <= x1; x++ {
+			img.SetRGBA(x, y, warna)
+		}
+	}
+}
+
+func tulisTeksPng(img *image.RGBA, x, y int, teks string, tebal bool, warna color.RGBA) {
+	for _, b := range []byte(pdfEncode(teks)) {
+		if b < 32 || b > 126 {
+			b = '?'
+		}
+		for kolom, bit := range glyph5x8[b-32] {
+			for baris := 0; baris < 8; baris++ {
+				if bit&(1<<baris) == 0 {
+					continue
+				}
+				img.SetRGBA(x+kolom, y+baris, warna)
+				if tebal {
+					img.SetRGBA(x+kolom+1, y+baris, warna)
+				}
+			}
+		}
+		x += int(grafLebarKarakter)
+	}
+}
+
+// PNG merender graf sebagai gambar raster dengan font bitmap bawaan
+func (graf *GrafPokin) PNG() ([]byte, error) {
+	lebar, tinggi := int(graf.Lebar)+1, int(graf.Tinggi)+1
+	if lebar*tinggi > grafMaksPixel {
+		return nil, web.NewBadRequestError("pohon kinerja terlalu besar untuk PNG, gunakan format svg")
+	}
+	img := image.NewRGBA(image.Rect(0, 0, lebar, tinggi))
+	isiKotak(img, 0, 0, lebar-1, tinggi-1, color.RGBA{R: 255, G: 255, B: 255, A: 255})
+
+	abu := parseWarnaHex("#6B7280")
+	for _, edge := range graf.Edges {
+		titik := graf.garisEdge(edge)
+		for i := 0; i < 3; i++ {
+			isiKotak(img, int(titik[i][0]), int(titik[i][1]), int(titik[i+1][0]), int(titik[i+1][1]), abu)
+		}
+	}
+
+	hitam := color.RGBA{R: 17, G: 24, B: 39, A: 255}
+	for _, node := range graf.Nodes {
+		warna := warnaJenisPohon(node.JenisPohon)
+		x0, y0 := int(node.X), int(node.Y)
+		x1, y1 := int(node.X+node.Lebar), int(node.Y+node.Tinggi)
+		isiKotak(img, x0, y0, x1, y1, parseWarnaHex(warna.garis))
+		isiKotak(img, x0+2, y0+2, x1-2, y1-2, parseWarnaHex(warna.isi))
+		for i, baris := range node.baris {
+			tulisTeksPng(img, x0+int(grafPadding), y0+int(grafPadding)+i*int(grafTinggiBaris)+3, baris.teks, baris.tebal, hitam)
+		}
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, img); err != nil {
+		return nil, err
+	}
+	return buffer.Bytes(), nil
+}
+
+// GraphML untuk disunting di yEd, Gephi atau alat graf lain
+func (graf *GrafPokin) GraphML() []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(`<?xml version="1.0" encoding="UTF-8"?>` + "\n")
+	buffer.WriteString(`<graphml xmlns="http://graphml.graphdrawing.org/xmlns">` + "\n")
+	kunci := [][3]string{
+		{"pohon_id", "int"}, {"nama", "string"}, {"jenis_pohon", "string"}, {"level_pohon", "int"},
+		{"indikator", "string"}, {"pelaksana", "string"}, {"warna", "string"}, {"x", "double"}, {"y", "double"},
+	}
+	for _, k := range kunci {
+		fmt.Fprintf(&buffer, `<key id="%s" for="node" attr.name="%s" attr.type="%s"/>`+"\n", k[0], k[0], k[1])
+	}
+	fmt.Fprintf(&buffer, `<graph id="pokin" edgedefault="directed"><desc>%s</desc>`+"\n", xmlEscape(graf.Judul))
+	for _, node := range graf.Nodes {
+		fmt.Fprintf(&buffer, `<node id="%s">`, node.Id)
+		data := []string{
+			fmt.Sprint(node.PohonId), node.Nama, node.JenisPohon, fmt.Sprint(node.LevelPohon),
+			strings.Join(node.Indikator, "\n"), strings.Join(node.Pelaksana, ", "), node.Warna,
+			fmt.Sprintf("%.1f", node.X), fmt.Sprintf("%.1f", node.Y),
+		}
+		for i, nilai := range data {
+			fmt.Fprintf(&buffer, `<data key="%s">%s</data>`, kunci[i][0], xmlEscape(nilai))
+		}
+		buffer.WriteString("</node>\n")
+	}
+	for i, edge := range graf.Edges {
+		fmt.Fprintf(&buffer, `<edge id="e%d" source="%s" target="%s"/>`+"\n", i, edge.Source, edge.Target)
+	}
+	buffer.WriteString("</graph>\n</graphml>\n")
+	return buffer.Bytes()
+}
+
+func (graf *GrafPokin) Render(format string) ([]byte, string, error) {
+	switch format {
+	case FormatSvg:
+		return graf.SVG(), "image/svg+xml", nil
+	case FormatPng:
+		content, err := graf.PNG()
+		return content, "image/png", err
+	case FormatGraphml:
+		return graf.GraphML(), "application/graphml+xml", nil
+	case FormatJson:
+		content, err := json.Marshal(graf)
+		return content, "application/json", err
+	}
+	return nil, "", web.NewBadRequestError(fmt.Sprintf("format %s tidak didukung, gunakan svg, png, graphml atau json", format))
+}
+
+// FormatGraf membaca query format, kosong berarti svg
+func FormatGraf(request *http.Request) string {
+	format := strings.ToLower(strings.TrimSpace(request.URL.Query().Get("format")))
+	if format == "" {
+		return FormatSvg
+	}
+	return format
+}
+
+// WriteGrafPokin mengirim graf pohon kinerja sebagai file unduhan
+func WriteGrafPokin(writer http.ResponseWriter, graf *GrafPokin, format string, namaFile string) {
+	content, contentType, err := graf.Render(format)
+	if err != nil {
+		WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	namaFile = strings.Trim(namaFileTidakValid.ReplaceAllString(namaFile, "_"), "_")
+	writer.Header().Set("Content-Type", contentType)
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.%s"`, namaFile, format))
+	writer.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	writer.WriteHeader(http.StatusOK)
+	_, err = writer.Write(content)
+	PanicIfError(err)
+}
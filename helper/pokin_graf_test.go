@@ -0,0 +1,82 @@
+package helper
+
+import (
+	"bytes"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"image/png"
+	"strings"
+	"testing"
+)
+
+func contohTematik() pohonkinerja.TematikResponse {
+	return pohonkinerja.TematikResponse{
+		Id: 1, Tema: "Kemiskinan", JenisPohon: "Tematik",
+		Indikators: []pohonkinerja.IndikatorResponse{{NamaIndikator: "Persentase penduduk miskin", Target: []pohonkinerja.TargetResponse{{TargetIndikator: "9", SatuanIndikator: "%"}}}},
+		Child: []interface{}{
+			pohonkinerja.SubtematikResponse{Id: 2, Tema: "Pendapatan <rendah>", JenisPohon: "Sub Tematik"},
+			pohonkinerja.StrategicResponse{
+				Id: 3, Strategi: "Meningkatnya daya beli", JenisPohon: "Strategic Pemda", LevelPohon: 4,
+				Pelaksana: []pohonkinerja.PelaksanaOpdResponse{{NamaPegawai: "Budi"}},
+				Childs:    []interface{}{pohonkinerja.TacticalResponse{Id: 4, Strategi: "Bantuan sosial tepat sasaran", JenisPohon: "Tactical Pemda"}},
+			},
+		},
+	}
+}
+
+func TestNewGrafPokin(t *testing.T) {
+	graf := NewGrafPokinTematik("Tematik Kemiskinan", contohTematik())
+	if len(graf.Nodes) != 4 || len(graf.Edges) != 3 {
+		t.Fatalf("NewGrafPokinTematik() nodes %d, edges %d", len(graf.Nodes), len(graf.Edges))
+	}
+	akar, strategic, tactical := graf.Nodes[0], graf.Nodes[2], graf.Nodes[3]
+	if akar.Indikator[0] != "Persentase penduduk miskin (9 %)" || strategic.Pelaksana[0] != "Budi" {
+		t.Errorf("NewGrafPokinTematik() indikator/pelaksana = %q %q", akar.Indikator, strategic.Pelaksana)
+	}
+	if strategic.Warna != "#DBEAFE" || tactical.Warna != "#DCFCE7" {
+		t.Errorf("NewGrafPokinTematik() warna = %s %s", strategic.Warna, tactical.Warna)
+	}
+	// induk berada di tengah anak-anaknya dan anak berada di bawah induk
+	if akar.X != (graf.Nodes[1].X+strategic.X)/2 || tactical.X != strategic.X || tactical.Y <= strategic.Y+strategic.Tinggi {
+		t.Errorf("NewGrafPokinTematik() posisi akar %v, strategic %v, tactical %v", akar.X, strategic.X, tactical.Y)
+	}
+
+	// slice di akar digabung di bawah node judul
+	opd := NewGrafPokinOpd("Pokin OPD", []pohonkinerja.StrategicOpdResponse{
+		{Id: 7, Strategi: "A", JenisPohon: "Strategic"},
+		{Id: 8, Strategi: "B", JenisPohon: "Strategic", Tacticals: []pohonkinerja.TacticalOpdResponse{{
+			Id: 9, Strategi: "C", JenisPohon: "Tactical",
+			Operationals: []pohonkinerja.OperationalOpdResponse{{Id: 10, Strategi: "D", JenisPohon: "Operational",
+				Childs: []pohonkinerja.OperationalNOpdResponse{{Id: 11, Strategi: "E", JenisPohon: "Operational N"}}}},
+		}}},
+	})
+	if len(opd.Nodes) != 6 || opd.Nodes[0].Nama != "Pokin OPD" || opd.Nodes[2].PohonId != 8 || opd.Nodes[5].PohonId != 11 || opd.Nodes[5].Warna != "#F3E8FF" {
+		t.Errorf("NewGrafPokinOpd() opd = %+v", opd.Nodes)
+	}
+}
+
+func TestGrafPokinRender(t *testing.T) {
+	graf := NewGrafPokinTematik("Tematik Kemiskinan", contohTematik())
+
+	svg, contentType, err := graf.Render(FormatSvg)
+	if err != nil || contentType != "image/svg+xml" || !strings.Contains(string(svg), "Pendapatan &lt;rendah&gt;") {
+		t.Errorf("Render(svg) = %s, %v", contentType, err)
+	}
+
+	content, _, err := graf.Render(FormatPng)
+	if err != nil {
+		t.Fatalf("Render(png) error: %v", err)
+	}
+	img, err := png.Decode(bytes.NewReader(content))
+	if err != nil || img.Bounds().Dx() != int(graf.Lebar)+1 {
+		t.Errorf("Render(png) tidak valid: %v", err)
+	}
+
+	graphml, _, _ := graf.Render(FormatGraphml)
+	if strings.Count(string(graphml), "<node ") != 4 || !strings.Contains(string(graphml), `source="n2" target="n3"`) {
+		t.Errorf("Render(graphml) = %s", graphml)
+	}
+
+	if _, _, err := graf.Render("pdf"); err == nil {
+		t.Errorf("Render(pdf) seharusnya error")
+	}
+}
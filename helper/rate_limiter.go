@@ -0,0 +1,94 @@
+package helper
+
+import (
+	"context"
+	"log"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CacheKeyRateLimit prefix key redis untuk hitungan request per jendela waktu
+const CacheKeyRateLimit = "rate_limit"
+
+const rateLimitMemoryLimit = 10000
+
+type rateWindow struct {
+	Count   int
+	ResetAt time.Time
+}
+
+// RateLimiter membatasi jumlah request per key (biasanya IP) dalam satu jendela waktu tetap,
+// dihitung di redis agar berlaku lintas instance dengan fallback in-memory
+type RateLimiter struct {
+	client *redis.Client
+	nama   string
+	limit  int
+	window time.Duration
+	mu     sync.Mutex
+	memory map[string]rateWindow
+}
+
+func NewRateLimiter(client *redis.Client, nama string, limit int, window time.Duration) *RateLimiter {
+	return &RateLimiter{
+		client: client,
+		nama:   nama,
+		limit:  limit,
+		window: window,
+		memory: make(map[string]rateWindow),
+	}
+}
+
+// Allow mencatat satu request untuk key, mengembalikan false dan sisa waktu jendela jika batas terlampaui
+func (limiter *RateLimiter) Allow(ctx context.Context, key string) (bool, time.Duration) {
+	cacheKey := GenerateCacheKey(CacheKeyRateLimit, limiter.nama, key)
+	if limiter.client != nil {
+		ctx, cancel := context.WithTimeout(ctx, tokenStoreTimeout)
+		defer cancel()
+
+		count, err := limiter.client.Incr(ctx, cacheKey).Result()
+		if err == nil {
+			if count == 1 {
+				limiter.client.Expire(ctx, cacheKey, limiter.window)
+			}
+			if count <= int64(limiter.limit) {
+				return true, 0
+			}
+			ttl, err := limiter.client.TTL(ctx, cacheKey).Result()
+			if err != nil || ttl <= 0 {
+				// key tanpa expire tidak boleh mengunci selamanya
+				limiter.client.Expire(ctx, cacheKey, limiter.window)
+				ttl = limiter.window
+			}
+			return false, ttl
+		}
+		log.Printf("Warning: rate limit redis gagal, memakai memory: %v", err)
+	}
+	return limiter.allowMemory(cacheKey, time.Now())
+}
+
+func (limiter *RateLimiter) allowMemory(key string, now time.Time) (bool, time.Duration) {
+	limiter.mu.Lock()
+	defer limiter.mu.Unlock()
+
+	if len(limiter.memory) > rateLimitMemoryLimit {
+		for k, v := range limiter.memory {
+			if now.After(v.ResetAt) {
+				delete(limiter.memory, k)
+			}
+		}
+	}
+
+	current, found := limiter.memory[key]
+	if !found || !now.Before(current.ResetAt) {
+		current = rateWindow{ResetAt: now.Add(limiter.window)}
+	}
+	current.Count++
+	limiter.memory[key] = current
+
+	if current.Count > limiter.limit {
+		return false, current.ResetAt.Sub(now)
+	}
+	return true, 0
+}
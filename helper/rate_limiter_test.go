@@ -0,0 +1,27 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRateLimiterMemory(t *testing.T) {
+	limiter := NewRateLimiter(nil, "test", 2, time.Minute)
+	now := time.Date(2025, 1, 1, 8, 0, 0, 0, time.UTC)
+
+	for i := 1; i <= 2; i++ {
+		if ok, _ := limiter.allowMemory("ip-a", now); !ok {
+			t.Fatalf("request ke-%d seharusnya lolos", i)
+		}
+	}
+	ok, retry := limiter.allowMemory("ip-a", now.Add(10*time.Second))
+	if ok || retry != 50*time.Second {
+		t.Errorf("request ke-3 = %v, retry %v, expected ditolak dengan retry 50s", ok, retry)
+	}
+	if ok, _ := limiter.allowMemory("ip-b", now); !ok {
+		t.Error("key lain tidak boleh ikut dibatasi")
+	}
+	if ok, _ := limiter.allowMemory("ip-a", now.Add(time.Minute)); !ok {
+		t.Error("jendela baru seharusnya mereset hitungan")
+	}
+}
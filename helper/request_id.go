@@ -0,0 +1,35 @@
+package helper
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+const RequestIdHeader = "X-Request-Id"
+
+// maksimal panjang request id dari client, selebihnya dibuatkan baru
+const maxRequestIdLength = 64
+
+// RequestIdFrom memakai request id dari client jika wajar, jika tidak membuat uuid baru
+func RequestIdFrom(headerValue string) string {
+	if headerValue == "" || len(headerValue) > maxRequestIdLength || !isPrintableASCII(headerValue) {
+		return uuid.New().String()
+	}
+	return headerValue
+}
+
+// GetRequestId mengambil request id dari context, kosong jika tidak ada
+func GetRequestId(ctx context.Context) string {
+	requestId, _ := ctx.Value(RequestIdKey).(string)
+	return requestId
+}
+
+func isPrintableASCII(value string) bool {
+	for _, r := range value {
+		if r < 0x21 || r > 0x7e {
+			return false
+		}
+	}
+	return true
+}
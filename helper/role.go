@@ -0,0 +1,36 @@
+package helper
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web"
+)
+
+// Nama role sesuai isi tb_role
+const (
+	RoleSuperAdmin = "super_admin"
+	RoleAdminOpd   = "admin_opd"
+	RoleReviewer   = "reviewer"
+	RoleLevel1     = "level_1"
+	RoleLevel2     = "level_2"
+	RoleLevel3     = "level_3"
+	RoleLevel4     = "level_4"
+	RoleAsn        = "asn"
+)
+
+// HasAnyRole memeriksa apakah claims memiliki minimal satu role dari daftar roles
+func HasAnyRole(claims web.JWTClaim, roles ...string) bool {
+	for _, owned := range claims.Roles {
+		for _, role := range roles {
+			if owned == role {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// GetUserClaims mengambil JWTClaim yang disimpan AuthMiddleware di context
+func GetUserClaims(ctx context.Context) (web.JWTClaim, bool) {
+	claims, ok := ctx.Value(UserInfoKey).(web.JWTClaim)
+	return claims, ok
+}
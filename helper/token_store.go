@@ -0,0 +1,230 @@
+package helper
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web"
+	"log"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// CacheKeyRevokedToken prefix key redis untuk jti token yang sudah dicabut
+const CacheKeyRevokedToken = "revoked_jti"
+
+// CacheKeyRevokedUser prefix key redis untuk batas iat token milik user
+const CacheKeyRevokedUser = "revoked_user"
+
+const tokenStoreTimeout = 300 * time.Millisecond
+
+// TokenStore menyimpan daftar jti token yang sudah dicabut (logout / refresh rotation)
+type TokenStore interface {
+	Revoke(ctx context.Context, jti string, ttl time.Duration) error
+	// IsRevoked memakai revocation list di memory saat redis tidak bisa diperiksa
+	IsRevoked(ctx context.Context, jti string) bool
+	// Consume menandai jti terpakai secara atomik, false jika jti sudah pernah dipakai atau dicabut
+	Consume(ctx context.Context, jti string, ttl time.Duration) (bool, error)
+	// RevokeUser mencabut semua token user yang diterbitkan sebelum saat ini
+	RevokeUser(ctx context.Context, userId int, ttl time.Duration) error
+	// UserRevokedAt mengembalikan unix time batas iat token user (0 jika tidak ada)
+	UserRevokedAt(ctx context.Context, userId int) int64
+}
+
+type revokedUser struct {
+	revokedAt int64
+	expiredAt time.Time
+}
+
+// RedisTokenStore menyimpan revocation list di redis,
+// dengan fallback in-memory jika redis tidak tersedia
+type RedisTokenStore struct {
+	client *redis.Client
+	mu     sync.Mutex
+	memory map[string]time.Time
+	users  map[int]revokedUser
+}
+
+func NewRedisTokenStore(client *redis.Client) *RedisTokenStore {
+	return &RedisTokenStore{
+		client: client,
+		memory: make(map[string]time.Time),
+		users:  make(map[int]revokedUser),
+	}
+}
+
+var tokenStore TokenStore = NewRedisTokenStore(nil)
+
+// SetTokenStore mengganti store yang dipakai ValidateJWT
+func SetTokenStore(store TokenStore) {
+	tokenStore = store
+}
+
+func (store *RedisTokenStore) Revoke(ctx context.Context, jti string, ttl time.Duration) error {
+	if jti == "" || ttl <= 0 {
+		return nil
+	}
+
+	// selalu simpan di memory agar tetap berlaku saat redis mati
+	store.mu.Lock()
+	store.memory[jti] = time.Now().Add(ttl)
+	store.mu.Unlock()
+
+	if store.client == nil {
+		return nil
+	}
+
+	key := GenerateCacheKey(CacheKeyRevokedToken, jti)
+	if err := store.client.Set(ctx, key, "1", ttl).Err(); err != nil {
+		log.Printf("Warning: gagal menyimpan revoked token ke redis, memakai memory: %v", err)
+	}
+
+	return nil
+}
+
+func (store *RedisTokenStore) IsRevoked(ctx context.Context, jti string) bool {
+	if jti == "" {
+		return false
+	}
+
+	store.mu.Lock()
+	expiredAt, found := store.memory[jti]
+	if found && time.Now().After(expiredAt) {
+		delete(store.memory, jti)
+		found = false
+	}
+	store.mu.Unlock()
+
+	if found {
+		return true
+	}
+
+	if store.client == nil {
+		return false
+	}
+
+	// dipanggil di setiap request, jangan sampai tertahan jika redis mati
+	ctx, cancel := context.WithTimeout(ctx, tokenStoreTimeout)
+	defer cancel()
+
+	key := GenerateCacheKey(CacheKeyRevokedToken, jti)
+	exists, err := store.client.Exists(ctx, key).Result()
+	if err != nil {
+		// revocation list di memory sudah diperiksa di atas
+		log.Printf("Warning: gagal memeriksa revoked token di redis, memakai memory: %v", err)
+		return false
+	}
+
+	return exists > 0
+}
+
+func (store *RedisTokenStore) Consume(ctx context.Context, jti string, ttl time.Duration) (bool, error) {
+	if jti == "" || ttl <= 0 {
+		return false, nil
+	}
+
+	store.mu.Lock()
+	expiredAt, found := store.memory[jti]
+	if found && time.Now().Before(expiredAt) {
+		store.mu.Unlock()
+		return false, nil
+	}
+	store.memory[jti] = time.Now().Add(ttl)
+	store.mu.Unlock()
+
+	if store.client == nil {
+		return true, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenStoreTimeout)
+	defer cancel()
+
+	// SET NX: hanya satu pemakaian yang berhasil walau request datang bersamaan di instance berbeda
+	key := GenerateCacheKey(CacheKeyRevokedToken, jti)
+	return store.client.SetNX(ctx, key, "1", ttl).Result()
+}
+
+func (store *RedisTokenStore) RevokeUser(ctx context.Context, userId int, ttl time.Duration) error {
+	if userId == 0 || ttl <= 0 {
+		return nil
+	}
+
+	revokedAt := time.Now().Unix()
+
+	store.mu.Lock()
+	store.users[userId] = revokedUser{revokedAt: revokedAt, expiredAt: time.Now().Add(ttl)}
+	store.mu.Unlock()
+
+	if store.client == nil {
+		return nil
+	}
+
+	key := GenerateCacheKey(CacheKeyRevokedUser, strconv.Itoa(userId))
+	if err := store.client.Set(ctx, key, revokedAt, ttl).Err(); err != nil {
+		log.Printf("Warning: gagal menyimpan revoked user ke redis, memakai memory: %v", err)
+	}
+
+	return nil
+}
+
+func (store *RedisTokenStore) UserRevokedAt(ctx context.Context, userId int) int64 {
+	if userId == 0 {
+		return 0
+	}
+
+	store.mu.Lock()
+	entry, found := store.users[userId]
+	if found && time.Now().After(entry.expiredAt) {
+		delete(store.users, userId)
+		found = false
+	}
+	store.mu.Unlock()
+
+	if found {
+		return entry.revokedAt
+	}
+
+	if store.client == nil {
+		return 0
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, tokenStoreTimeout)
+	defer cancel()
+
+	key := GenerateCacheKey(CacheKeyRevokedUser, strconv.Itoa(userId))
+	revokedAt, err := store.client.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		return 0
+	}
+	if err != nil {
+		// batas iat di memory sudah diperiksa di atas
+		log.Printf("Warning: gagal memeriksa revoked user di redis, memakai memory: %v", err)
+		return 0
+	}
+
+	return revokedAt
+}
+
+// RevokeUserSessions mencabut seluruh sesi (access dan refresh token) milik user
+func RevokeUserSessions(ctx context.Context, userId int) error {
+	return tokenStore.RevokeUser(ctx, userId, RefreshTokenDuration())
+}
+
+// RevokeToken mencabut token dengan jti sampai waktu exp token tersebut
+func RevokeToken(ctx context.Context, jti string, exp int64) error {
+	return tokenStore.Revoke(ctx, jti, time.Until(time.Unix(exp, 0)))
+}
+
+// ConsumeRefreshToken memakai jti refresh token tepat sekali, pemakaian ulang ditolak
+func ConsumeRefreshToken(ctx context.Context, jti string, exp int64) error {
+	consumed, err := tokenStore.Consume(ctx, jti, time.Until(time.Unix(exp, 0)))
+	if err != nil {
+		log.Printf("Warning: gagal mencatat pemakaian refresh token: %v", err)
+		return web.NewUnauthorizedError("refresh token tidak dapat diverifikasi, silakan login ulang")
+	}
+	if !consumed {
+		return web.NewUnauthorizedError("refresh token sudah dipakai")
+	}
+	return nil
+}
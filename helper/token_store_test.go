@@ -0,0 +1,78 @@
+package helper
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+func TestTokenStoreConsumeSekali(t *testing.T) {
+	store := NewRedisTokenStore(nil)
+	ctx := context.Background()
+
+	var berhasil int32
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if ok, err := store.Consume(ctx, "jti-1", time.Minute); err == nil && ok {
+				atomic.AddInt32(&berhasil, 1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if berhasil != 1 {
+		t.Errorf("consume bersamaan berhasil %d kali, expected 1", berhasil)
+	}
+	if !store.IsRevoked(ctx, "jti-1") {
+		t.Error("jti yang sudah dipakai harus dianggap dicabut")
+	}
+}
+
+func TestTokenStoreConsumeTokenDicabut(t *testing.T) {
+	store := NewRedisTokenStore(nil)
+	ctx := context.Background()
+
+	if err := store.Revoke(ctx, "jti-logout", time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if ok, err := store.Consume(ctx, "jti-logout", time.Minute); err != nil || ok {
+		t.Errorf("consume token yang sudah logout = %v, %v, expected ditolak", ok, err)
+	}
+	if ok, _ := store.Consume(ctx, "jti-kedaluwarsa", 0); ok {
+		t.Error("token kedaluwarsa tidak boleh dipakai")
+	}
+}
+
+func TestTokenStoreRedisMatiMemakaiMemory(t *testing.T) {
+	// port 1 tidak pernah menerima koneksi sehingga setiap perintah redis gagal
+	client := redis.NewClient(&redis.Options{Addr: "127.0.0.1:1", MaxRetries: -1})
+	defer client.Close()
+	store := NewRedisTokenStore(client)
+	ctx := context.Background()
+
+	if store.IsRevoked(ctx, "jti-tidak-diketahui") {
+		t.Error("IsRevoked tidak boleh menolak token yang tidak ada di memory saat redis mati")
+	}
+	if store.UserRevokedAt(ctx, 7) != 0 {
+		t.Error("UserRevokedAt harus 0 untuk user yang tidak ada di memory saat redis mati")
+	}
+
+	store.Revoke(ctx, "jti-1", time.Minute)
+	if !store.IsRevoked(ctx, "jti-1") {
+		t.Error("token yang dicabut di memory harus tetap ditolak saat redis mati")
+	}
+	store.RevokeUser(ctx, 7, time.Minute)
+	if store.UserRevokedAt(ctx, 7) == 0 {
+		t.Error("revoked user di memory harus tetap berlaku saat redis mati")
+	}
+	if ok, err := store.Consume(ctx, "jti-2", time.Minute); err == nil || ok {
+		t.Errorf("consume saat redis mati = %v, %v, expected error", ok, err)
+	}
+}
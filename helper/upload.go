@@ -0,0 +1,28 @@
+package helper
+
+import (
+	"ekak_kabupaten_madiun/model/web"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// batas ukuran file unggahan import
+const MaksUkuranUpload = 10 << 20
+
+// ReadUploadFile membaca isi file dari form multipart, ukuran dibatasi maksBytes
+func ReadUploadFile(writer http.ResponseWriter, request *http.Request, field string, maksBytes int64) ([]byte, error) {
+	request.Body = http.MaxBytesReader(writer, request.Body, maksBytes+(1<<20))
+	if err := request.ParseMultipartForm(maksBytes); err != nil {
+		return nil, web.NewBadRequestError(fmt.Sprintf("form upload tidak valid atau melebihi %d MB", maksBytes>>20))
+	}
+	file, header, err := request.FormFile(field)
+	if err != nil {
+		return nil, web.NewBadRequestError(fmt.Sprintf("file %s wajib diunggah", field))
+	}
+	defer file.Close()
+	if header.Size > maksBytes {
+		return nil, web.NewBadRequestError(fmt.Sprintf("ukuran file melebihi %d MB", maksBytes>>20))
+	}
+	return io.ReadAll(file)
+}
@@ -0,0 +1,140 @@
+package helper
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"regexp"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// validator bersama yang dipasang saat wiring (lihat service.NewValidator)
+var requestValidator *validator.Validate
+
+var tahunPattern = regexp.MustCompile(`^\d{4}$`)
+
+// SetRequestValidator memasang validator yang dipakai ValidateRequest
+func SetRequestValidator(validate *validator.Validate) {
+	requestValidator = validate
+}
+
+// IsTahun memeriksa tahun 4 digit
+func IsTahun(tahun string) bool {
+	return tahunPattern.MatchString(tahun)
+}
+
+// ValidateTahun validasi tag `tahun`, menerima string maupun int
+func ValidateTahun(fl validator.FieldLevel) bool {
+	return IsTahun(fmt.Sprint(fl.Field().Interface()))
+}
+
+// JsonTagName memakai nama field json pada pesan validasi
+func JsonTagName(field reflect.StructField) string {
+	name := strings.SplitN(field.Tag.Get("json"), ",", 2)[0]
+	if name == "-" {
+		return ""
+	}
+	if name == "" {
+		return field.Name
+	}
+	return name
+}
+
+// ValidateRequest memvalidasi request sebelum diteruskan ke service.
+// Jika tidak valid, response 400 berisi daftar field sudah ditulis dan mengembalikan false.
+func ValidateRequest(writer http.ResponseWriter, ctx context.Context, request interface{}) bool {
+	if requestValidator == nil {
+		return true
+	}
+
+	var err error
+	value := reflect.Indirect(reflect.ValueOf(request))
+	if value.Kind() == reflect.Slice {
+		err = requestValidator.VarCtx(ctx, request, "dive")
+	} else {
+		err = requestValidator.StructCtx(ctx, request)
+	}
+
+	var invalidErr *validator.InvalidValidationError
+	if err == nil || errors.As(err, &invalidErr) {
+		return true
+	}
+	WriteErrorResponse(writer, err, http.StatusBadRequest)
+	return false
+}
+
+// ValidationFieldErrors mengubah hasil validator menjadi daftar field dengan pesan bahasa Indonesia
+func ValidationFieldErrors(validationErrs validator.ValidationErrors) []web.FieldError {
+	fieldErrors := make([]web.FieldError, 0, len(validationErrs))
+	for _, fieldErr := range validationErrs {
+		fieldErrors = append(fieldErrors, web.FieldError{
+			Field:   validationFieldName(fieldErr.Namespace()),
+			Message: ValidationMessage(fieldErr),
+		})
+	}
+	return fieldErrors
+}
+
+// validationFieldName membuang nama struct root, mis. RencanaKinerjaCreateRequest.indikator[0].tahun -> indikator[0].tahun
+func validationFieldName(namespace string) string {
+	if strings.HasPrefix(namespace, "[") {
+		return namespace
+	}
+	if idx := strings.Index(namespace, "."); idx >= 0 {
+		return namespace[idx+1:]
+	}
+	return namespace
+}
+
+// ValidationMessage pesan validasi bahasa Indonesia per tag
+func ValidationMessage(fieldErr validator.FieldError) string {
+	param := fieldErr.Param()
+	switch fieldErr.Tag() {
+	case "required", "required_if", "required_with", "required_without":
+		return "wajib diisi"
+	case "tahun":
+		return "harus berupa tahun 4 digit"
+	case "tahun_periode":
+		return "harus berupa tahun 4 digit di dalam periode yang aktif"
+	case "min":
+		return "minimal " + param + validationUnit(fieldErr.Kind())
+	case "max":
+		return "maksimal " + param + validationUnit(fieldErr.Kind())
+	case "len":
+		return "harus " + param + validationUnit(fieldErr.Kind())
+	case "gt":
+		return "harus lebih dari " + param
+	case "gte":
+		return "tidak boleh kurang dari " + param
+	case "lt":
+		return "harus kurang dari " + param
+	case "lte":
+		return "tidak boleh lebih dari " + param
+	case "oneof":
+		return "harus salah satu dari: " + strings.Join(strings.Fields(param), ", ")
+	case "numeric", "number":
+		return "harus berupa angka"
+	case "email":
+		return "format email tidak valid"
+	case "uuid", "uuid4":
+		return "harus berupa UUID"
+	case "datetime":
+		return "format tanggal harus " + param
+	}
+	return "tidak valid"
+}
+
+func validationUnit(kind reflect.Kind) string {
+	switch kind {
+	case reflect.String:
+		return " karakter"
+	case reflect.Slice, reflect.Array, reflect.Map:
+		return " item"
+	}
+	return ""
+}
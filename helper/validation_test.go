@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestIsTahun(t *testing.T) {
+	tests := []struct {
+		name     string
+		tahun    string
+		expected bool
+	}{
+		{name: "tahun valid", tahun: "2025", expected: true},
+		{name: "dua digit", tahun: "25", expected: false},
+		{name: "lima digit", tahun: "20250", expected: false},
+		{name: "bukan angka", tahun: "20a5", expected: false},
+		{name: "kosong", tahun: "", expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := IsTahun(tt.tahun); result != tt.expected {
+				t.Errorf("IsTahun(%q) = %v, expected %v", tt.tahun, result, tt.expected)
+			}
+		})
+	}
+}
+
+type validationTestTarget struct {
+	Tahun string `json:"tahun" validate:"required,tahun"`
+}
+
+type validationTestRequest struct {
+	Nama   string                 `json:"nama" validate:"required"`
+	Tahun  string                 `json:"tahun" validate:"tahun"`
+	Status string                 `json:"status" validate:"oneof=draft final"`
+	Target []validationTestTarget `json:"target" validate:"min=1,dive"`
+}
+
+func TestValidationFieldErrors(t *testing.T) {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(JsonTagName)
+	if err := validate.RegisterValidation("tahun", ValidateTahun); err != nil {
+		t.Fatal(err)
+	}
+
+	err := validate.Struct(validationTestRequest{
+		Tahun:  "25",
+		Status: "batal",
+		Target: []validationTestTarget{{Tahun: ""}},
+	})
+
+	var validationErrs validator.ValidationErrors
+	if !errors.As(err, &validationErrs) {
+		t.Fatalf("expected validator.ValidationErrors, got %v", err)
+	}
+
+	expected := []web.FieldError{
+		{Field: "nama", Message: "wajib diisi"},
+		{Field: "tahun", Message: "harus berupa tahun 4 digit"},
+		{Field: "status", Message: "harus salah satu dari: draft, final"},
+		{Field: "target[0].tahun", Message: "wajib diisi"},
+	}
+	if result := ValidationFieldErrors(validationErrs); !reflect.DeepEqual(result, expected) {
+		t.Errorf("ValidationFieldErrors() = %+v, expected %+v", result, expected)
+	}
+}
@@ -0,0 +1,341 @@
+package helper
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+const ContentTypeXlsx = "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+
+// SheetXlsx satu lembar kerja, baris pertama diperlakukan sebagai kepala tabel
+type SheetXlsx struct {
+	Nama       string
+	Baris      [][]string
+	LebarKolom []float64 // lebar kolom dalam satuan karakter, kosong berarti default
+}
+
+const xlsxContentTypesAwal = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Types xmlns="http://schemas.openxmlformats.org/package/2006/content-types"><Default Extension="rels" ContentType="application/vnd.openxmlformats-package.relationships+xml"/><Default Extension="xml" ContentType="application/xml"/><Override PartName="/xl/workbook.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.sheet.main+xml"/><Override PartName="/xl/styles.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.styles+xml"/>`
+
+const xlsxRels = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId1" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/officeDocument" Target="xl/workbook.xml"/></Relationships>`
+
+// style 0 normal, style 1 tebal berlatar abu-abu untuk kepala tabel
+const xlsxStyles = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<styleSheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><fonts count="2"><font><sz val="11"/><name val="Calibri"/></font><font><b/><sz val="11"/><name val="Calibri"/></font></fonts><fills count="3"><fill><patternFill patternType="none"/></fill><fill><patternFill patternType="gray125"/></fill><fill><patternFill patternType="solid"><fgColor rgb="FFE0E0E0"/><bgColor indexed="64"/></patternFill></fill></fills><borders count="1"><border><left/><right/><top/><bottom/><diagonal/></border></borders><cellStyleXfs count="1"><xf numFmtId="0" fontId="0" fillId="0" borderId="0"/></cellStyleXfs><cellXfs count="2"><xf numFmtId="0" fontId="0" fillId="0" borderId="0" xfId="0"/><xf numFmtId="0" fontId="1" fillId="2" borderId="0" xfId="0" applyFont="1" applyFill="1"/></cellXfs></styleSheet>`
+
+var (
+	angkaXlsx         = regexp.MustCompile(`^-?(0|[1-9][0-9]{0,14})$`)
+	namaSheetTidakSah = regexp.MustCompile(`[\[\]:*?/\\]`)
+)
+
+// kolomXlsx indeks kolom (mulai 0) ke huruf kolom A, B, ..., AA
+func kolomXlsx(indeks int) string {
+	nama := ""
+	for indeks >= 0 {
+		nama = string(rune('A'+indeks%26)) + nama
+		indeks = indeks/26 - 1
+	}
+	return nama
+}
+
+// indeksKolomXlsx huruf kolom pada referensi sel (mis. "AB12") ke indeks mulai 0, -1 jika tidak valid
+func indeksKolomXlsx(ref string) int {
+	indeks := 0
+	ada := false
+	for _, huruf := range ref {
+		if huruf < 'A' || huruf > 'Z' {
+			break
+		}
+		indeks = indeks*26 + int(huruf-'A'+1)
+		ada = true
+	}
+	if !ada {
+		return -1
+	}
+	return indeks - 1
+}
+
+func namaSheetXlsx(nama string, urutan int) string {
+	nama = strings.TrimSpace(namaSheetTidakSah.ReplaceAllString(nama, " "))
+	if nama == "" {
+		nama = fmt.Sprintf("Sheet%d", urutan)
+	}
+	if runes := []rune(nama); len(runes) > 31 {
+		nama = string(runes[:31])
+	}
+	return nama
+}
+
+func tulisSheetXlsx(sheet SheetXlsx) []byte {
+	var buffer bytes.Buffer
+	buffer.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	buffer.WriteString(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main">`)
+	buffer.WriteString(`<sheetViews><sheetView workbookViewId="0"><pane ySplit="1" topLeftCell="A2" activePane="bottomLeft" state="frozen"/></sheetView></sheetViews>`)
+	if len(sheet.LebarKolom) > 0 {
+		buffer.WriteString("<cols>")
+		for i, lebar := range sheet.LebarKolom {
+			if lebar <= 0 {
+				continue
+			}
+			fmt.Fprintf(&buffer, `<col min="%d" max="%d" width="%.1f" customWidth="1"/>`, i+1, i+1, lebar)
+		}
+		buffer.WriteString("</cols>")
+	}
+	buffer.WriteString("<sheetData>")
+	for i, baris := range sheet.Baris {
+		fmt.Fprintf(&buffer, `<row r="%d">`, i+1)
+		for j, nilai := range baris {
+			if nilai == "" {
+				continue
+			}
+			ref := kolomXlsx(j) + strconv.Itoa(i+1)
+			style := ""
+			if i == 0 {
+				style = ` s="1"`
+			}
+			if i > 0 && angkaXlsx.MatchString(nilai) {
+				fmt.Fprintf(&buffer, `<c r="%s"%s><v>%s</v></c>`, ref, style, nilai)
+				continue
+			}
+			fmt.Fprintf(&buffer, `<c r="%s"%s t="inlineStr"><is><t xml:space="preserve">%s</t></is></c>`, ref, style, docxEscape(nilai))
+		}
+		buffer.WriteString("</row>")
+	}
+	buffer.WriteString("</sheetData></worksheet>")
+	return buffer.Bytes()
+}
+
+// TulisXlsx menyusun workbook xlsx, angka bulat ditulis sebagai sel numerik dan lainnya sebagai teks
+func TulisXlsx(sheets []SheetXlsx) ([]byte, error) {
+	if len(sheets) == 0 {
+		return nil, fmt.Errorf("workbook minimal memiliki satu sheet")
+	}
+
+	var contentTypes, workbook, workbookRels bytes.Buffer
+	contentTypes.WriteString(xlsxContentTypesAwal)
+	workbook.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	workbook.WriteString(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets>`)
+	workbookRels.WriteString(`<?xml version="1.0" encoding="UTF-8" standalone="yes"?>`)
+	workbookRels.WriteString(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships">`)
+
+	files := make([]zipFile, 0, len(sheets)+5)
+	for i, sheet := range sheets {
+		nomor := i + 1
+		fmt.Fprintf(&contentTypes, `<Override PartName="/xl/worksheets/sheet%d.xml" ContentType="application/vnd.openxmlformats-officedocument.spreadsheetml.worksheet+xml"/>`, nomor)
+		fmt.Fprintf(&workbook, `<sheet name="%s" sheetId="%d" r:id="rId%d"/>`, docxEscape(namaSheetXlsx(sheet.Nama, nomor)), nomor, nomor)
+		fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/worksheet" Target="worksheets/sheet%d.xml"/>`, nomor, nomor)
+		files = append(files, zipFile{nama: fmt.Sprintf("xl/worksheets/sheet%d.xml", nomor), isi: tulisSheetXlsx(sheet)})
+	}
+	contentTypes.WriteString("</Types>")
+	workbook.WriteString("</sheets></workbook>")
+	fmt.Fprintf(&workbookRels, `<Relationship Id="rId%d" Type="http://schemas.openxmlformats.org/officeDocument/2006/relationships/styles" Target="styles.xml"/>`, len(sheets)+1)
+	workbookRels.WriteString("</Relationships>")
+
+	files = append([]zipFile{
+		{nama: "[Content_Types].xml", isi: contentTypes.Bytes()},
+		{nama: "_rels/.rels", isi: []byte(xlsxRels)},
+		{nama: "xl/workbook.xml", isi: workbook.Bytes()},
+		{nama: "xl/_rels/workbook.xml.rels", isi: workbookRels.Bytes()},
+		{nama: "xl/styles.xml", isi: []byte(xlsxStyles)},
+	}, files...)
+	return zipFiles(files)
+}
+
+// WriteXlsx mengirim workbook sebagai file unduhan
+func WriteXlsx(writer http.ResponseWriter, sheets []SheetXlsx, namaFile string) {
+	content, err := TulisXlsx(sheets)
+	if err != nil {
+		WriteErrorResponse(writer, err, http.StatusInternalServerError)
+		return
+	}
+
+	namaFile = strings.Trim(namaFileTidakValid.ReplaceAllString(namaFile, "_"), "_")
+	writer.Header().Set("Content-Type", ContentTypeXlsx)
+	writer.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="%s.xlsx"`, namaFile))
+	writer.Header().Set("Content-Length", fmt.Sprint(len(content)))
+	writer.WriteHeader(http.StatusOK)
+	_, err = writer.Write(content)
+	PanicIfError(err)
+}
+
+type xlsxTeksKaya struct {
+	T    string `xml:"t"`
+	Runs []struct {
+		T string `xml:"t"`
+	} `xml:"r"`
+}
+
+func (teks xlsxTeksKaya) String() string {
+	if len(teks.Runs) == 0 {
+		return teks.T
+	}
+	var hasil strings.Builder
+	for _, run := range teks.Runs {
+		hasil.WriteString(run.T)
+	}
+	return hasil.String()
+}
+
+type xlsxWorkbook struct {
+	Sheets []struct {
+		Nama string `xml:"name,attr"`
+		Id   string `xml:"http://schemas.openxmlformats.org/officeDocument/2006/relationships id,attr"`
+	} `xml:"sheets>sheet"`
+}
+
+type xlsxRelationships struct {
+	Relationship []struct {
+		Id     string `xml:"Id,attr"`
+		Target string `xml:"Target,attr"`
+	} `xml:"Relationship"`
+}
+
+type xlsxWorksheet struct {
+	Rows []struct {
+		R     int `xml:"r,attr"`
+		Cells []struct {
+			R      string       `xml:"r,attr"`
+			T      string       `xml:"t,attr"`
+			V      string       `xml:"v"`
+			Inline xlsxTeksKaya `xml:"is"`
+		} `xml:"c"`
+	} `xml:"sheetData>row"`
+}
+
+// batas file xlsx upload, dicek sebelum alokasi agar zip bomb atau referensi sel
+// r="1048576" pada file kecil tidak menghabiskan memori
+const (
+	maksUkuranXmlXlsx = 64 << 20
+	MaksBarisXlsx     = 100000
+	MaksKolomXlsx     = 1000
+)
+
+// bacaXmlZip sisa adalah kuota ukuran hasil dekompresi untuk seluruh berkas xlsx
+func bacaXmlZip(files map[string]*zip.File, nama string, hasil interface{}, sisa *int64) error {
+	file, ok := files[nama]
+	if !ok {
+		return fmt.Errorf("berkas %s tidak ditemukan di dalam xlsx", nama)
+	}
+	if file.UncompressedSize64 > uint64(*sisa) {
+		return fmt.Errorf("isi xlsx melebihi batas %d MB", maksUkuranXmlXlsx>>20)
+	}
+	reader, err := file.Open()
+	if err != nil {
+		return err
+	}
+	defer reader.Close()
+	// ukuran di header zip bisa dipalsukan, pembacaan tetap dibatasi
+	isi, err := io.ReadAll(io.LimitReader(reader, *sisa+1))
+	if err != nil {
+		return err
+	}
+	if int64(len(isi)) > *sisa {
+		return fmt.Errorf("isi xlsx melebihi batas %d MB", maksUkuranXmlXlsx>>20)
+	}
+	*sisa -= int64(len(isi))
+	return xml.Unmarshal(isi, hasil)
+}
+
+// BacaXlsx membaca seluruh sheet sebagai teks, urutan baris dan kolom mengikuti referensi sel
+func BacaXlsx(content []byte) ([]SheetXlsx, error) {
+	archive, err := zip.NewReader(bytes.NewReader(content), int64(len(content)))
+	if err != nil {
+		return nil, fmt.Errorf("file bukan xlsx yang valid: %w", err)
+	}
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+	sisa := int64(maksUkuranXmlXlsx)
+
+	var workbook xlsxWorkbook
+	if err := bacaXmlZip(files, "xl/workbook.xml", &workbook, &sisa); err != nil {
+		return nil, err
+	}
+	var rels xlsxRelationships
+	if err := bacaXmlZip(files, "xl/_rels/workbook.xml.rels", &rels, &sisa); err != nil {
+		return nil, err
+	}
+	targets := make(map[string]string, len(rels.Relationship))
+	for _, rel := range rels.Relationship {
+		target := rel.Target
+		if strings.HasPrefix(target, "/") {
+			target = strings.TrimPrefix(target, "/")
+		} else {
+			target = path.Join("xl", target)
+		}
+		targets[rel.Id] = target
+	}
+
+	var sharedStrings []string
+	if _, ok := files["xl/sharedStrings.xml"]; ok {
+		var sst struct {
+			Si []xlsxTeksKaya `xml:"si"`
+		}
+		if err := bacaXmlZip(files, "xl/sharedStrings.xml", &sst, &sisa); err != nil {
+			return nil, err
+		}
+		for _, si := range sst.Si {
+			sharedStrings = append(sharedStrings, si.String())
+		}
+	}
+
+	sheets := make([]SheetXlsx, 0, len(workbook.Sheets))
+	for _, sheetInfo := range workbook.Sheets {
+		var worksheet xlsxWorksheet
+		if err := bacaXmlZip(files, targets[sheetInfo.Id], &worksheet, &sisa); err != nil {
+			return nil, err
+		}
+
+		sheet := SheetXlsx{Nama: sheetInfo.Nama}
+		for i, row := range worksheet.Rows {
+			nomorBaris := row.R
+			if nomorBaris <= 0 {
+				nomorBaris = i + 1
+			}
+			if nomorBaris > MaksBarisXlsx {
+				return nil, fmt.Errorf("sheet %s melebihi batas %d baris", sheetInfo.Nama, MaksBarisXlsx)
+			}
+			for len(sheet.Baris) < nomorBaris {
+				sheet.Baris = append(sheet.Baris, nil)
+			}
+			var baris []string
+			for j, cell := range row.Cells {
+				kolom := indeksKolomXlsx(cell.R)
+				if kolom < 0 {
+					kolom = j
+				}
+				if kolom >= MaksKolomXlsx {
+					return nil, fmt.Errorf("sheet %s melebihi batas %d kolom", sheetInfo.Nama, MaksKolomXlsx)
+				}
+				nilai := cell.V
+				switch cell.T {
+				case "s":
+					indeks, err := strconv.Atoi(cell.V)
+					if err != nil || indeks < 0 || indeks >= len(sharedStrings) {
+						return nil, fmt.Errorf("sheet %s sel %s merujuk shared string yang tidak ada", sheetInfo.Nama, cell.R)
+					}
+					nilai = sharedStrings[indeks]
+				case "inlineStr":
+					nilai = cell.Inline.String()
+				}
+				for len(baris) <= kolom {
+					baris = append(baris, "")
+				}
+				baris[kolom] = nilai
+			}
+			sheet.Baris[nomorBaris-1] = baris
+		}
+		sheets = append(sheets, sheet)
+	}
+	return sheets, nil
+}
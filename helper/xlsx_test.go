@@ -0,0 +1,116 @@
+package helper
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestKolomXlsx(t *testing.T) {
+	tests := []struct {
+		indeks   int
+		expected string
+	}{
+		{indeks: 0, expected: "A"},
+		{indeks: 25, expected: "Z"},
+		{indeks: 26, expected: "AA"},
+		{indeks: 701, expected: "ZZ"},
+		{indeks: 702, expected: "AAA"},
+	}
+
+	for _, tt := range tests {
+		if result := kolomXlsx(tt.indeks); result != tt.expected {
+			t.Errorf("kolomXlsx(%d) = %s, expected %s", tt.indeks, result, tt.expected)
+		}
+		if result := indeksKolomXlsx(tt.expected + "12"); result != tt.indeks {
+			t.Errorf("indeksKolomXlsx(%s12) = %d, expected %d", tt.expected, result, tt.indeks)
+		}
+	}
+}
+
+func TestXlsxTulisBaca(t *testing.T) {
+	sheets := []SheetXlsx{{
+		Nama: "Matrix: Renja",
+		Baris: [][]string{
+			{"Kode", "Nama", "Pagu"},
+			{"1.01", "Program & <uji>", "1500000"},
+			{},
+			{"1.01.01", "", "0012"},
+		},
+	}}
+
+	content, err := TulisXlsx(sheets)
+	if err != nil {
+		t.Fatalf("TulisXlsx() error: %v", err)
+	}
+	result, err := BacaXlsx(content)
+	if err != nil {
+		t.Fatalf("BacaXlsx() error: %v", err)
+	}
+
+	if len(result) != 1 || result[0].Nama != "Matrix  Renja" {
+		t.Fatalf("BacaXlsx() sheet = %+v", result)
+	}
+	expected := [][]string{
+		{"Kode", "Nama", "Pagu"},
+		{"1.01", "Program & <uji>", "1500000"},
+		nil,
+		{"1.01.01", "", "0012"},
+	}
+	if !reflect.DeepEqual(result[0].Baris, expected) {
+		t.Errorf("BacaXlsx() baris = %q, expected %q", result[0].Baris, expected)
+	}
+}
+
+func TestBacaXlsxSharedStrings(t *testing.T) {
+	// struktur minimal seperti file yang disimpan ulang oleh aplikasi spreadsheet
+	content, err := zipFiles([]zipFile{
+		{nama: "xl/workbook.xml", isi: []byte(`<workbook xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main" xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Data" sheetId="1" r:id="rId3"/></sheets></workbook>`)},
+		{nama: "xl/_rels/workbook.xml.rels", isi: []byte(`<Relationships xmlns="http://schemas.openxmlformats.org/package/2006/relationships"><Relationship Id="rId3" Target="/xl/worksheets/data.xml"/></Relationships>`)},
+		{nama: "xl/sharedStrings.xml", isi: []byte(`<sst xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><si><t>Kode</t></si><si><r><t>Target </t></r><r><t>2025</t></r></si></sst>`)},
+		{nama: "xl/worksheets/data.xml", isi: []byte(`<worksheet xmlns="http://schemas.openxmlformats.org/spreadsheetml/2006/main"><sheetData><row r="1"><c r="A1" t="s"><v>0</v></c><c r="C1" t="s"><v>1</v></c></row><row r="2"><c r="B2"><v>90.5</v></c></row></sheetData></worksheet>`)},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	result, err := BacaXlsx(content)
+	if err != nil {
+		t.Fatalf("BacaXlsx() error: %v", err)
+	}
+	expected := [][]string{{"Kode", "", "Target 2025"}, {"", "90.5"}}
+	if len(result) != 1 || !reflect.DeepEqual(result[0].Baris, expected) {
+		t.Errorf("BacaXlsx() = %+v, expected baris %q", result, expected)
+	}
+}
+
+func TestBacaXlsxBatas(t *testing.T) {
+	sheet := func(isi string) []zipFile {
+		return []zipFile{
+			{nama: "xl/workbook.xml", isi: []byte(`<workbook xmlns:r="http://schemas.openxmlformats.org/officeDocument/2006/relationships"><sheets><sheet name="Data" sheetId="1" r:id="rId1"/></sheets></workbook>`)},
+			{nama: "xl/_rels/workbook.xml.rels", isi: []byte(`<Relationships><Relationship Id="rId1" Target="worksheets/sheet1.xml"/></Relationships>`)},
+			{nama: "xl/worksheets/sheet1.xml", isi: []byte(`<worksheet><sheetData>` + isi + `</sheetData></worksheet>`)},
+		}
+	}
+
+	tests := []struct {
+		name  string
+		files []zipFile
+	}{
+		{name: "nomor baris terlalu besar", files: sheet(`<row r="1048576"><c r="A1048576"><v>1</v></c></row>`)},
+		{name: "kolom terlalu jauh", files: sheet(`<row r="1"><c r="XFD1"><v>1</v></c></row>`)},
+		{name: "isi melebihi kuota dekompresi", files: sheet(`<row r="1"><c r="A1"><v>` + strings.Repeat("0", maksUkuranXmlXlsx) + `</v></c></row>`)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content, err := zipFiles(tt.files)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if _, err := BacaXlsx(content); err == nil {
+				t.Error("BacaXlsx() harus menolak file di luar batas")
+			}
+		})
+	}
+}
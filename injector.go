@@ -12,7 +12,6 @@ import (
 	"ekak_kabupaten_madiun/service"
 	"net/http"
 
-	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
 	"github.com/julienschmidt/httprouter"
 )
@@ -235,6 +234,8 @@ var roleSet = wire.NewSet(
 var userSet = wire.NewSet(
 	repository.NewUserRepositoryImpl,
 	wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)),
+	repository.NewPasswordResetRepositoryImpl,
+	wire.Bind(new(repository.PasswordResetRepository), new(*repository.PasswordResetRepositoryImpl)),
 	service.NewUserServiceImpl,
 	wire.Bind(new(service.UserService), new(*service.UserServiceImpl)),
 	controller.NewUserControllerImpl,
@@ -449,13 +450,114 @@ var lockDataRepository = wire.NewSet(
 	wire.Bind(new(repository.LockDataRepository), new(*repository.LockDataRepositoryImpl)),
 )
 
+var opdGuardSet = wire.NewSet(
+	service.NewOpdGuardServiceImpl,
+	wire.Bind(new(service.OpdGuardService), new(*service.OpdGuardServiceImpl)),
+)
+
+var auditLogSet = wire.NewSet(
+	repository.NewAuditLogRepositoryImpl,
+	wire.Bind(new(repository.AuditLogRepository), new(*repository.AuditLogRepositoryImpl)),
+	service.NewAuditLogServiceImpl,
+	wire.Bind(new(service.AuditLogService), new(*service.AuditLogServiceImpl)),
+	controller.NewAuditLogControllerImpl,
+	wire.Bind(new(controller.AuditLogController), new(*controller.AuditLogControllerImpl)),
+)
+
+var realisasiSet = wire.NewSet(
+	repository.NewRealisasiRepositoryImpl,
+	wire.Bind(new(repository.RealisasiRepository), new(*repository.RealisasiRepositoryImpl)),
+	service.NewRealisasiServiceImpl,
+	wire.Bind(new(service.RealisasiService), new(*service.RealisasiServiceImpl)),
+	controller.NewRealisasiControllerImpl,
+	wire.Bind(new(controller.RealisasiController), new(*controller.RealisasiControllerImpl)),
+)
+
+var progresPelaksanaanSet = wire.NewSet(
+	repository.NewProgresPelaksanaanRepositoryImpl,
+	wire.Bind(new(repository.ProgresPelaksanaanRepository), new(*repository.ProgresPelaksanaanRepositoryImpl)),
+	service.NewProgresPelaksanaanServiceImpl,
+	wire.Bind(new(service.ProgresPelaksanaanService), new(*service.ProgresPelaksanaanServiceImpl)),
+	controller.NewProgresPelaksanaanControllerImpl,
+	wire.Bind(new(controller.ProgresPelaksanaanController), new(*controller.ProgresPelaksanaanControllerImpl)),
+)
+
+var pokinWorkflowSet = wire.NewSet(
+	repository.NewPohonKinerjaTransisiRepositoryImpl,
+	wire.Bind(new(repository.PohonKinerjaTransisiRepository), new(*repository.PohonKinerjaTransisiRepositoryImpl)),
+	service.NewPokinWorkflowServiceImpl,
+	wire.Bind(new(service.PokinWorkflowService), new(*service.PokinWorkflowServiceImpl)),
+	controller.NewPokinWorkflowControllerImpl,
+	wire.Bind(new(controller.PokinWorkflowController), new(*controller.PokinWorkflowControllerImpl)),
+)
+
+var rencanaKinerjaVerifikasiSet = wire.NewSet(
+	repository.NewRencanaKinerjaVerifikasiRepositoryImpl,
+	wire.Bind(new(repository.RencanaKinerjaVerifikasiRepository), new(*repository.RencanaKinerjaVerifikasiRepositoryImpl)),
+	service.NewRencanaKinerjaVerifikasiServiceImpl,
+	wire.Bind(new(service.RencanaKinerjaVerifikasiService), new(*service.RencanaKinerjaVerifikasiServiceImpl)),
+	controller.NewRencanaKinerjaVerifikasiControllerImpl,
+	wire.Bind(new(controller.RencanaKinerjaVerifikasiController), new(*controller.RencanaKinerjaVerifikasiControllerImpl)),
+)
+
+var cloneJobSet = wire.NewSet(
+	service.NewCloneJobServiceImpl,
+	wire.Bind(new(service.CloneJobService), new(*service.CloneJobServiceImpl)),
+	controller.NewCloneJobControllerImpl,
+	wire.Bind(new(controller.CloneJobController), new(*controller.CloneJobControllerImpl)),
+)
+
+var usulanPipelineSet = wire.NewSet(
+	repository.NewUsulanPipelineRepositoryImpl,
+	wire.Bind(new(repository.UsulanPipelineRepository), new(*repository.UsulanPipelineRepositoryImpl)),
+	service.NewUsulanPipelineServiceImpl,
+	wire.Bind(new(service.UsulanPipelineService), new(*service.UsulanPipelineServiceImpl)),
+	controller.NewUsulanPipelineControllerImpl,
+	wire.Bind(new(controller.UsulanPipelineController), new(*controller.UsulanPipelineControllerImpl)),
+	service.NewUsulanPublikServiceImpl,
+	wire.Bind(new(service.UsulanPublikService), new(*service.UsulanPublikServiceImpl)),
+	controller.NewUsulanPublikControllerImpl,
+	wire.Bind(new(controller.UsulanPublikController), new(*controller.UsulanPublikControllerImpl)),
+)
+
+var geospasialSet = wire.NewSet(
+	repository.NewGeospasialRepositoryImpl,
+	wire.Bind(new(repository.GeospasialRepository), new(*repository.GeospasialRepositoryImpl)),
+	service.NewGeospasialServiceImpl,
+	wire.Bind(new(service.GeospasialService), new(*service.GeospasialServiceImpl)),
+	controller.NewGeospasialControllerImpl,
+	wire.Bind(new(controller.GeospasialController), new(*controller.GeospasialControllerImpl)),
+)
+
+var nomenklaturSet = wire.NewSet(
+	repository.NewNomenklaturRepositoryImpl,
+	wire.Bind(new(repository.NomenklaturRepository), new(*repository.NomenklaturRepositoryImpl)),
+	service.NewNomenklaturServiceImpl,
+	wire.Bind(new(service.NomenklaturService), new(*service.NomenklaturServiceImpl)),
+	controller.NewNomenklaturControllerImpl,
+	wire.Bind(new(controller.NomenklaturController), new(*controller.NomenklaturControllerImpl)),
+)
+
+var laporanSet = wire.NewSet(
+	service.NewLaporanServiceImpl,
+	wire.Bind(new(service.LaporanService), new(*service.LaporanServiceImpl)),
+	controller.NewLaporanControllerImpl,
+	wire.Bind(new(controller.LaporanController), new(*controller.LaporanControllerImpl)),
+)
+
+var lockDataSet = wire.NewSet(
+	service.NewLockDataServiceImpl,
+	wire.Bind(new(service.LockDataService), new(*service.LockDataServiceImpl)),
+	controller.NewLockDataControllerImpl,
+	wire.Bind(new(controller.LockDataController), new(*controller.LockDataControllerImpl)),
+)
+
 func InitializeServer() *http.Server {
 
 	wire.Build(
 		app.GetConnection,
 		app.GetRedisClient,
-		wire.Value([]validator.Option{}),
-		validator.New,
+		service.NewValidator,
 		rencanaKinerjaSet,
 		rencanaAksiSet,
 		pelaksanaanRencanaAksiSet,
@@ -505,6 +607,18 @@ func InitializeServer() *http.Server {
 		jabatanPegawaiSet,
 		cloneRecordSet,
 		lockDataRepository,
+		opdGuardSet,
+		lockDataSet,
+		auditLogSet,
+		realisasiSet,
+		progresPelaksanaanSet,
+		laporanSet,
+		pokinWorkflowSet,
+		rencanaKinerjaVerifikasiSet,
+		cloneJobSet,
+		usulanPipelineSet,
+		geospasialSet,
+		nomenklaturSet,
 		app.NewRouter,
 		wire.Bind(new(http.Handler), new(*httprouter.Router)),
 		middleware.NewAuthMiddleware,
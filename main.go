@@ -37,7 +37,7 @@ func NewServer(authMiddleware *middleware.AuthMiddleware) *http.Server {
 
 	return &http.Server{
 		Addr:    addr,
-		Handler: cors.Handler(authMiddleware),
+		Handler: cors.Handler(middleware.NewRecoveryMiddleware(authMiddleware)),
 	}
 }
 
@@ -3,16 +3,20 @@ package middleware
 import (
 	"context"
 	"ekak_kabupaten_madiun/helper"
-	"ekak_kabupaten_madiun/model/web"
 	"net/http"
 	"strings"
+
+	"github.com/redis/go-redis/v9"
 )
 
 type AuthMiddleware struct {
 	Handler http.Handler
 }
 
-func NewAuthMiddleware(handler http.Handler) *AuthMiddleware {
+func NewAuthMiddleware(handler http.Handler, redisClient *redis.Client) *AuthMiddleware {
+	// revocation list jti yang dicek oleh ValidateJWT
+	helper.SetTokenStore(helper.NewRedisTokenStore(redisClient))
+
 	return &AuthMiddleware{Handler: handler}
 }
 
@@ -22,12 +26,15 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 		pattern string
 	}{
 		{"/user/login", "^/user/login$"},
+		{"/user/refresh", "^/user/refresh$"},
+		{"/user/confirm_reset_password", "^/user/confirm_reset_password$"},
 		{"/swagger/*", "^/swagger/.*$"},
 		{"/api/pokin_opd/findall/", "^/api/pokin_opd/findall/[^/]+/[^/]+$"},
 		{"/api/pokin_pemda/subtematik/", "^/api/pokin_pemda/subtematik/[^/]+$"},
 		{"/pohon_kinerja/pokin_atasan/", "^/pohon_kinerja/pokin_atasan/[^/]+$"},
 		{"/rekin/atasan/", "^/rekin/atasan/[^/]+$"},
 		{"/api_internal/rencana_kinerja/findall", "^/api_internal/rencana_kinerja/findall$"},
+		{"/publik/usulan/", "^/publik/usulan$"},
 	}
 
 	currentPath := request.URL.Path
@@ -40,16 +47,7 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 
 	tokenString := request.Header.Get("Authorization")
 	if tokenString == "" {
-		writer.Header().Set("Content-Type", "application/json")
-		writer.WriteHeader(http.StatusUnauthorized)
-
-		webResponse := web.WebResponse{
-			Code:   http.StatusUnauthorized,
-			Status: "UNAUTHORIZED",
-			Data:   "Token tidak ditemukan",
-		}
-
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusUnauthorized, "Token tidak ditemukan")
 		return
 	}
 
@@ -57,16 +55,7 @@ func (middleware *AuthMiddleware) ServeHTTP(writer http.ResponseWriter, request
 
 	claims := helper.ValidateJWT(tokenString)
 	if claims.UserId == 0 {
-		writer.Header().Set("Content-Type", "application/json")
-		writer.WriteHeader(http.StatusUnauthorized)
-
-		webResponse := web.WebResponse{
-			Code:   http.StatusUnauthorized,
-			Status: "UNAUTHORIZED",
-			Data:   "Token tidak valid",
-		}
-
-		helper.WriteToResponseBody(writer, webResponse)
+		helper.WriteError(writer, http.StatusUnauthorized, "Token tidak valid")
 		return
 	}
 
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthMiddlewarePathPublik(t *testing.T) {
+	handler := NewAuthMiddleware(http.HandlerFunc(func(writer http.ResponseWriter, request *http.Request) {
+		writer.WriteHeader(http.StatusOK)
+	}), nil)
+
+	tests := []struct {
+		path     string
+		expected int
+	}{
+		{path: "/publik/usulan", expected: http.StatusOK},
+		{path: "/publik/usulan/musrebang/USU-MUS-1", expected: http.StatusOK},
+		{path: "/publik/usulan_internal", expected: http.StatusUnauthorized},
+		{path: "/publik/usulanx/musrebang/USU-MUS-1", expected: http.StatusUnauthorized},
+		{path: "/user/findall", expected: http.StatusUnauthorized},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			recorder := httptest.NewRecorder()
+			handler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, tt.path, nil))
+			if recorder.Code != tt.expected {
+				t.Errorf("status %s = %d, expected %d", tt.path, recorder.Code, tt.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package middleware
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// RateLimit membatasi request per IP client, dipasang di app/router.go untuk route publik, contoh:
+//
+//	publik := middleware.RateLimit(helper.NewRateLimiter(redisClient, "publik", 60, time.Minute))
+//	router.GET("/publik/usulan", publik(usulanPublikController.FindAll))
+func RateLimit(limiter *helper.RateLimiter) func(handle httprouter.Handle) httprouter.Handle {
+	return func(handle httprouter.Handle) httprouter.Handle {
+		return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			clientIp := helper.GetClientIP(request)
+			allowed, retryAfter := limiter.Allow(request.Context(), clientIp)
+			if !allowed {
+				log.Printf("[RateLimit] request ditolak: ip=%s %s %s", clientIp, request.Method, request.URL.Path)
+				writer.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(retryAfter.Seconds()))))
+				helper.WriteError(writer, http.StatusTooManyRequests, "Terlalu banyak request, coba lagi nanti")
+				return
+			}
+
+			handle(writer, request, params)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/helper"
+	"log"
+	"net/http"
+	"runtime/debug"
+)
+
+// RecoveryMiddleware memberi request id pada setiap request dan mengubah panic menjadi response JSON
+type RecoveryMiddleware struct {
+	Handler http.Handler
+}
+
+func NewRecoveryMiddleware(handler http.Handler) *RecoveryMiddleware {
+	return &RecoveryMiddleware{Handler: handler}
+}
+
+// recoveryWriter mencatat apakah header sudah terkirim sebelum panic
+type recoveryWriter struct {
+	http.ResponseWriter
+	wroteHeader bool
+}
+
+func (w *recoveryWriter) WriteHeader(status int) {
+	w.wroteHeader = true
+	w.ResponseWriter.WriteHeader(status)
+}
+
+func (w *recoveryWriter) Write(body []byte) (int, error) {
+	w.wroteHeader = true
+	return w.ResponseWriter.Write(body)
+}
+
+func (middleware *RecoveryMiddleware) ServeHTTP(writer http.ResponseWriter, request *http.Request) {
+	requestId := helper.RequestIdFrom(request.Header.Get(helper.RequestIdHeader))
+	writer.Header().Set(helper.RequestIdHeader, requestId)
+
+	ctx := context.WithValue(request.Context(), helper.RequestIdKey, requestId)
+	request = request.WithContext(ctx)
+
+	recorder := &recoveryWriter{ResponseWriter: writer}
+	defer func() {
+		recovered := recover()
+		if recovered == nil {
+			return
+		}
+		if recovered == http.ErrAbortHandler {
+			panic(recovered)
+		}
+
+		status := helper.PanicStatus(recovered)
+		log.Printf("[PANIC] request_id=%s %s %s: %v\n%s", requestId, request.Method, request.URL.Path, recovered, debug.Stack())
+
+		// response sudah sebagian terkirim, tidak bisa menulis envelope lagi
+		if recorder.wroteHeader {
+			return
+		}
+
+		message := "terjadi kesalahan pada server"
+		if status == http.StatusBadRequest {
+			message = "request body tidak valid"
+		}
+		helper.WriteError(writer, status, message)
+	}()
+
+	middleware.Handler.ServeHTTP(recorder, request)
+}
@@ -0,0 +1,42 @@
+package middleware
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"log"
+	"net/http"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+// RolePolicy membungkus handler route agar hanya bisa diakses role tertentu.
+// Dipasang di app/router.go saat route didaftarkan, contoh:
+//
+//	superAdmin := middleware.RequireRoles(helper.RoleSuperAdmin)
+//	router.POST("/role/create", superAdmin(roleController.Create))
+type RolePolicy func(handle httprouter.Handle) httprouter.Handle
+
+// RequireRoles membuat RolePolicy yang meloloskan request jika user
+// memiliki minimal satu role dari daftar roles
+func RequireRoles(roles ...string) RolePolicy {
+	return func(handle httprouter.Handle) httprouter.Handle {
+		return func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+			claims, ok := helper.GetUserClaims(request.Context())
+			if !ok || claims.UserId == 0 {
+				writeForbidden(writer, "Token tidak valid")
+				return
+			}
+
+			if !helper.HasAnyRole(claims, roles...) {
+				log.Printf("[RolePolicy] akses ditolak: nip=%s roles=%v %s %s", claims.Nip, claims.Roles, request.Method, request.URL.Path)
+				writeForbidden(writer, "Anda tidak memiliki akses ke resource ini")
+				return
+			}
+
+			handle(writer, request, params)
+		}
+	}
+}
+
+func writeForbidden(writer http.ResponseWriter, message string) {
+	helper.WriteError(writer, http.StatusForbidden, message)
+}
@@ -0,0 +1,47 @@
+package middleware
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/julienschmidt/httprouter"
+)
+
+func TestRequireRoles(t *testing.T) {
+	admin := RequireRoles(helper.RoleSuperAdmin, helper.RoleAdminOpd)
+
+	tests := []struct {
+		name     string
+		claims   *web.JWTClaim
+		expected int
+	}{
+		{name: "tanpa claims", expected: http.StatusForbidden},
+		{name: "claims tanpa user id", claims: &web.JWTClaim{Roles: []string{helper.RoleSuperAdmin}}, expected: http.StatusForbidden},
+		{name: "role tidak termasuk", claims: &web.JWTClaim{UserId: 1, Roles: []string{helper.RoleAsn}}, expected: http.StatusForbidden},
+		{name: "salah satu role cocok", claims: &web.JWTClaim{UserId: 1, Roles: []string{helper.RoleAsn, helper.RoleAdminOpd}}, expected: http.StatusOK},
+		{name: "super admin", claims: &web.JWTClaim{UserId: 1, Roles: []string{helper.RoleSuperAdmin}}, expected: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodPost, "/user/create", nil)
+			if tt.claims != nil {
+				request = request.WithContext(context.WithValue(request.Context(), helper.UserInfoKey, *tt.claims))
+			}
+			recorder := httptest.NewRecorder()
+			dipanggil := false
+			admin(func(writer http.ResponseWriter, request *http.Request, params httprouter.Params) {
+				dipanggil = true
+				writer.WriteHeader(http.StatusOK)
+			})(recorder, request, nil)
+
+			if recorder.Code != tt.expected || dipanggil != (tt.expected == http.StatusOK) {
+				t.Errorf("status = %d, handler dipanggil %v, expected %d", recorder.Code, dipanggil, tt.expected)
+			}
+		})
+	}
+}
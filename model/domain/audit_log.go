@@ -0,0 +1,21 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+type AuditLog struct {
+	Id           int64
+	EntityType   string
+	EntityId     string
+	Action       string
+	KodeOpd      string
+	Tahun        string
+	ActorUserId  int
+	ActorNip     string
+	ActorKodeOpd string
+	BeforeData   sql.NullString
+	AfterData    sql.NullString
+	CreatedAt    time.Time
+}
@@ -0,0 +1,46 @@
+package domain
+
+import "time"
+
+// jenis clone satu OPD antar tahun
+const (
+	CloneJenisPokin = "pokin"
+	CloneJenisRekin = "rekin"
+)
+
+// status clone_record dan CloneJob
+const (
+	CloneStatusPending         = "PENDING"
+	CloneStatusProcess         = "PROCESS"
+	CloneStatusDone            = "DONE"
+	CloneStatusDoneWithWarning = "DONE_WITH_WARNING"
+	CloneStatusFailed          = "FAILED"
+	CloneStatusCancelled       = "CANCELLED"
+)
+
+// CloneJob clone data satu OPD dari tahun sumber ke tahun tujuan yang berjalan di background
+type CloneJob struct {
+	Id           string
+	Jenis        string
+	KodeOpd      string
+	TahunSumber  string
+	TahunTujuan  string
+	DryRun       bool
+	Status       string
+	Fase         []CloneFase
+	Warnings     []CloneWarning
+	ErrorMessage string
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// CloneFase Total dan Diproses menghitung data sumber fase, Dibuat dan Dilewati hasilnya di tahun tujuan
+type CloneFase struct {
+	Nama     string `json:"nama"`
+	Total    int    `json:"total"`
+	Diproses int    `json:"diproses"`
+	Dibuat   int    `json:"dibuat"`
+	Dilewati int    `json:"dilewati"`
+	Selesai  bool   `json:"selesai"`
+}
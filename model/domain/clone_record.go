@@ -14,6 +14,12 @@ type CloneRecord struct {
 	UpdatedBy            string
 	Status               string
 	ErrorMessage         string
+	// Jenis pokin atau rekin, JobId id CloneJob yang menjalankan clone
+	Jenis string
+	JobId string
+	// Progres dan Warnings snapshot json CloneJob terakhir
+	Progres  string
+	Warnings string
 }
 
 type WarningType string
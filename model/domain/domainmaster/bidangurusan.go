@@ -9,6 +9,7 @@ type BidangUrusan struct {
 	KodeUrusan       string
 	NamaUrusan       string
 	Tahun            string
+	IsDeprecated     bool
 	CreatedAt        time.Time
 }
 
@@ -17,6 +18,7 @@ type BidangUrusanOpd struct {
 	KodeBidangUrusan string
 	NamaBidangUrusan string
 	KodeOpd          string
+	IsDeprecated     bool
 	CreatedAt        time.Time
 	UpdatedAt        time.Time
 }
@@ -11,5 +11,6 @@ type Kegiatan struct {
 	KodeKegiatan string
 	KodeOPD      string
 	CreatedAt    time.Time
+	IsDeprecated bool
 	Indikator    []domain.Indikator
 }
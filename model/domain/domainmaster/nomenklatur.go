@@ -0,0 +1,52 @@
+package domainmaster
+
+import "time"
+
+// tingkat nomenklatur sesuai jumlah segmen kode Kepmendagri
+const (
+	TingkatNomenklaturUrusan       = "urusan"
+	TingkatNomenklaturBidangUrusan = "bidang_urusan"
+	TingkatNomenklaturProgram      = "program"
+	TingkatNomenklaturKegiatan     = "kegiatan"
+	TingkatNomenklaturSubKegiatan  = "subkegiatan"
+)
+
+// aksi perubahan master hasil diff file nomenklatur
+const (
+	AksiNomenklaturTambah       = "tambah"
+	AksiNomenklaturUbahNama     = "ubah_nama"
+	AksiNomenklaturHapus        = "hapus"
+	AksiNomenklaturDeprecated   = "deprecated"
+	AksiNomenklaturAktifKembali = "aktif_kembali"
+)
+
+// Nomenklatur satu kode master urusan sampai subkegiatan
+type Nomenklatur struct {
+	Tingkat      string
+	Kode         string
+	Nama         string
+	IsDeprecated bool
+}
+
+type NomenklaturPerubahan struct {
+	Id       int64
+	VersiId  int64
+	Tingkat  string
+	Kode     string
+	Aksi     string
+	NamaLama string
+	NamaBaru string
+}
+
+type NomenklaturVersi struct {
+	Id                 int64
+	Versi              string
+	NamaFile           string
+	JumlahTambah       int
+	JumlahUbah         int
+	JumlahHapus        int
+	JumlahDeprecated   int
+	JumlahAktifKembali int
+	AppliedBy          string
+	CreatedAt          time.Time
+}
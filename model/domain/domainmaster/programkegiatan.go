@@ -3,11 +3,12 @@ package domainmaster
 import "ekak_kabupaten_madiun/model/domain"
 
 type ProgramKegiatan struct {
-	Id          string
-	KodeProgram string
-	NamaProgram string
-	KodeOPD     string
-	IsActive    bool
-	Tahun       string
-	Indikator   []domain.Indikator
+	Id           string
+	KodeProgram  string
+	NamaProgram  string
+	KodeOPD      string
+	IsActive     bool
+	Tahun        string
+	IsDeprecated bool
+	Indikator    []domain.Indikator
 }
@@ -6,6 +6,7 @@ type Urusan struct {
 	Id           string
 	KodeUrusan   string
 	NamaUrusan   string
+	IsDeprecated bool
 	CreatedAt    time.Time
 	BidangUrusan []BidangUrusan
 }
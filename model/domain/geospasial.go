@@ -0,0 +1,57 @@
+package domain
+
+// tingkat wilayah referensi batas administrasi
+const (
+	TingkatWilayahKecamatan = "kecamatan"
+	TingkatWilayahDesa      = "desa"
+)
+
+// Wilayah batas kecamatan atau desa, Geometri GeoJSON Polygon/MultiPolygon
+type Wilayah struct {
+	KodeWilayah string
+	Nama        string
+	Tingkat     string
+	KodeInduk   string
+	Geometri    string
+	Latitude    *float64
+	Longitude   *float64
+}
+
+// Lokasi titik dan/atau geometri GeoJSON beserta wilayah tempat lokasi berada, semua opsional
+type Lokasi struct {
+	Latitude    *float64
+	Longitude   *float64
+	Geometri    string
+	KodeWilayah string
+}
+
+func (lokasi Lokasi) Kosong() bool {
+	return lokasi.Latitude == nil && lokasi.Longitude == nil && lokasi.Geometri == "" && lokasi.KodeWilayah == ""
+}
+
+// LokasiUsulan usulan musrebang atau pokok pikiran beserta lokasinya
+type LokasiUsulan struct {
+	JenisUsulan string
+	UsulanId    string
+	Usulan      string
+	Alamat      string
+	Tahun       string
+	KodeOpd     string
+	NamaOpd     string
+	RekinId     string
+	Tahap       string
+	Lokasi      Lokasi
+}
+
+// LokasiIndikator lokasi intervensi indikator rekin yang manual IK-nya ditandai spasial
+type LokasiIndikator struct {
+	Id                 int64
+	IndikatorId        string
+	Indikator          string
+	RekinId            string
+	NamaRencanaKinerja string
+	KodeOpd            string
+	Tahun              string
+	Keterangan         string
+	Lokasi             Lokasi
+}
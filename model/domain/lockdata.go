@@ -1,8 +1,11 @@
 package domain
 
+import "time"
+
 type LockData struct {
 	Id        int
 	JenisData string
 	KodeOpd   string
 	Tahun     string
+	LockedAt  time.Time
 }
@@ -0,0 +1,11 @@
+package domain
+
+import "time"
+
+type PasswordReset struct {
+	Id        int
+	UserId    int
+	TokenHash string
+	ExpiredAt time.Time
+	CreatedBy string
+}
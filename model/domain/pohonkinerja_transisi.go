@@ -0,0 +1,30 @@
+package domain
+
+import "time"
+
+// PohonKinerjaTransisi satu perpindahan status pohon kinerja melalui workflow
+type PohonKinerjaTransisi struct {
+	Id             int64
+	PohonKinerjaId int
+	DariStatus     string
+	KeStatus       string
+	Aksi           string
+	Alasan         string
+	ActorNip       string
+	ActorRole      string
+	ActorKodeOpd   string
+	CreatedAt      time.Time
+}
+
+// status tb_pohon_kinerja, kosong berarti pokin OPD yang belum diajukan ke pemda
+const (
+	StatusPokinDraft                = ""
+	StatusPokinMenungguDisetujui    = "menunggu_disetujui"
+	StatusPokinDisetujui            = "disetujui"
+	StatusPokinDitolak              = "ditolak"
+	StatusPokinDariPemda            = "pokin dari pemda"
+	StatusPokinTarikOpd             = "tarik pokin opd"
+	StatusPokinCrosscuttingMenunggu = "crosscutting_menunggu"
+	StatusPokinCrosscuttingSetuju   = "crosscutting_disetujui"
+	StatusPokinCrosscuttingDitolak  = "crosscutting_ditolak"
+)
@@ -0,0 +1,30 @@
+package domain
+
+import (
+	"database/sql"
+	"time"
+)
+
+// ProgresPelaksanaan laporan bulanan atas satu pelaksanaan rencana aksi
+type ProgresPelaksanaan struct {
+	Id                       int64
+	PelaksanaanRencanaAksiId string
+	Persentase               float64
+	Catatan                  string
+	Kendala                  string
+	CreatedBy                string
+	UpdatedAt                time.Time
+}
+
+// BobotBulanan bobot rencana satu bulan beserta progresnya, Persentase kosong jika belum dilaporkan
+type BobotBulanan struct {
+	RencanaKinerjaId         string
+	RencanaAksiId            string
+	NamaRencanaAksi          string
+	PelaksanaanRencanaAksiId string
+	Bulan                    int
+	Bobot                    int
+	Persentase               sql.NullFloat64
+	Catatan                  string
+	Kendala                  string
+}
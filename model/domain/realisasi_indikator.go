@@ -0,0 +1,27 @@
+package domain
+
+import "time"
+
+type RealisasiIndikator struct {
+	Id           int64
+	IndikatorId  string
+	Tahun        string
+	JenisPeriode string
+	Periode      int
+	Realisasi    string
+	Satuan       string
+	BuktiDukung  string
+	Keterangan   string
+	CreatedBy    string
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+// IndikatorRealisasi indikator beserta OPD pemiliknya, dipakai untuk menghitung capaian
+type IndikatorRealisasi struct {
+	Id               string
+	Indikator        string
+	RumusPerhitungan string
+	Polaritas        string
+	KodeOpd          string
+}
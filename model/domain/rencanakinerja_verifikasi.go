@@ -0,0 +1,33 @@
+package domain
+
+import "time"
+
+// RencanaKinerjaVerifikasi satu perpindahan status rencana kinerja dalam verifikasi atasan
+type RencanaKinerjaVerifikasi struct {
+	Id               int64
+	RencanaKinerjaId string
+	Revisi           int
+	DariStatus       string
+	KeStatus         string
+	Aksi             string
+	Catatan          string
+	ActorNip         string
+	ActorPeran       string
+	CreatedAt        time.Time
+}
+
+// status verifikasi tb_rencana_kinerja, status lain dianggap draft yang belum diajukan
+const (
+	StatusRekinDraft              = ""
+	StatusRekinMenungguVerifikasi = "menunggu_verifikasi"
+	StatusRekinTerverifikasi      = "terverifikasi"
+	StatusRekinDikembalikan       = "dikembalikan"
+	StatusRekinDitolak            = "ditolak"
+)
+
+// RencanaKinerjaInbox rekin bawahan yang menunggu verifikasi atasan
+type RencanaKinerjaInbox struct {
+	RencanaKinerja RencanaKinerja
+	Revisi         int
+	DiajukanPada   time.Time
+}
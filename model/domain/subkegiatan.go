@@ -15,6 +15,7 @@ type SubKegiatan struct {
 	RekinId string
 	// Status               string
 	CreatedAt            time.Time
+	IsDeprecated         bool
 	Indikator            []Indikator
 	IndikatorSubKegiatan []IndikatorSubKegiatan
 	PaguSubKegiatan      []PaguSubKegiatan
@@ -0,0 +1,62 @@
+package domain
+
+import "time"
+
+// jenis usulan, sama dengan enum jenis_usulan di tb_usulan_terpilih
+const (
+	JenisUsulanMusrebang    = "musrebang"
+	JenisUsulanMandatori    = "mandatori"
+	JenisUsulanPokokPikiran = "pokok_pikiran"
+	JenisUsulanInisiatif    = "inisiatif"
+)
+
+// tahap pipeline usulan, disimpan di tb_usulan_tahap terpisah dari kolom status lama tiap tabel usulan
+const (
+	TahapUsulanDiajukan        = "diajukan"
+	TahapUsulanDiverifikasiOpd = "diverifikasi_opd"
+	TahapUsulanDiakomodir      = "diakomodir"
+	TahapUsulanDidanai         = "didanai"
+	TahapUsulanSelesai         = "selesai"
+	TahapUsulanDitolak         = "ditolak"
+)
+
+// UsulanPipeline tampilan gabungan keempat jenis usulan, Tahap kosong berarti belum pernah ditransisikan
+type UsulanPipeline struct {
+	JenisUsulan        string
+	Id                 string
+	Usulan             string
+	Uraian             string
+	Alamat             string
+	Tahun              string
+	KodeOpd            string
+	NamaOpd            string
+	RekinId            string
+	NamaRencanaKinerja string
+	IsActive           bool
+	Status             string
+	Tahap              string
+	AlasanTahap        string
+	CreatedAt          time.Time
+	SubKegiatan        []UsulanSubKegiatan
+}
+
+// UsulanSubKegiatan subkegiatan terpilih pada rekin yang mengakomodir usulan beserta pagunya
+type UsulanSubKegiatan struct {
+	RekinId         string
+	KodeSubKegiatan string
+	NamaSubKegiatan string
+	Pagu            int64
+}
+
+type UsulanTahapRiwayat struct {
+	Id          int64
+	JenisUsulan string
+	UsulanId    string
+	DariTahap   string
+	KeTahap     string
+	Aksi        string
+	Alasan      string
+	ActorNip    string
+	ActorRole   string
+	CreatedAt   time.Time
+}
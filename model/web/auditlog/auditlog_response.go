@@ -0,0 +1,21 @@
+package auditlog
+
+import (
+	"encoding/json"
+	"time"
+)
+
+type AuditLogResponse struct {
+	Id           int64           `json:"id"`
+	EntityType   string          `json:"entity_type"`
+	EntityId     string          `json:"entity_id"`
+	Action       string          `json:"action"`
+	KodeOpd      string          `json:"kode_opd"`
+	Tahun        string          `json:"tahun"`
+	ActorUserId  int             `json:"actor_user_id"`
+	ActorNip     string          `json:"actor_nip"`
+	ActorKodeOpd string          `json:"actor_kode_opd"`
+	Before       json.RawMessage `json:"before" swaggertype:"object"`
+	After        json.RawMessage `json:"after" swaggertype:"object"`
+	CreatedAt    time.Time       `json:"created_at"`
+}
@@ -5,6 +5,7 @@ type BidangUrusanResponse struct {
 	KodeBidangUrusan string `json:"kode_bidang_urusan"`
 	NamaBidangUrusan string `json:"nama_bidang_urusan"`
 	Tahun            string `json:"tahun"`
+	IsDeprecated     bool   `json:"is_deprecated"`
 }
 
 type BidangUrusanOpdsResponse struct {
@@ -12,4 +13,5 @@ type BidangUrusanOpdsResponse struct {
 	KodeOpd          string `json:"kode_opd,omitempty"`
 	KodeBidangUrusan string `json:"kode_bidang_urusan"`
 	NamaBidangUrusan string `json:"nama_bidang_urusan"`
+	IsDeprecated     bool   `json:"is_deprecated"`
 }
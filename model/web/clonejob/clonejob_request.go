@@ -0,0 +1,12 @@
+package clonejob
+
+type CloneJobRequest struct {
+	Jenis       string `json:"jenis" validate:"required,oneof=pokin rekin"`
+	KodeOpd     string `json:"kode_opd" validate:"required"`
+	TahunSumber string `json:"tahun_sumber" validate:"required,min=4,max=4"`
+	TahunTujuan string `json:"tahun_tujuan" validate:"required,min=4,max=4"`
+	// DryRun menjalankan clone lalu membatalkan transaksi, hasilnya hanya pratinjau.
+	// Insert tetap dijalankan sehingga mengunci baris tujuan dan memakai auto increment
+	DryRun    bool   `json:"dry_run"`
+	UpdatedBy string `json:"updated_by"`
+}
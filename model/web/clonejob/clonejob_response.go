@@ -0,0 +1,38 @@
+package clonejob
+
+import "time"
+
+type CloneJobResponse struct {
+	Id           string                 `json:"id"`
+	Jenis        string                 `json:"jenis"`
+	KodeOpd      string                 `json:"kode_opd"`
+	TahunSumber  string                 `json:"tahun_sumber"`
+	TahunTujuan  string                 `json:"tahun_tujuan"`
+	DryRun       bool                   `json:"dry_run"`
+	Status       string                 `json:"status"`
+	Persen       int                    `json:"persen"`
+	FaseAktif    string                 `json:"fase_aktif"`
+	Fase         []CloneFaseResponse    `json:"fase"`
+	Warnings     []CloneWarningResponse `json:"warnings"`
+	ErrorMessage string                 `json:"error_message,omitempty"`
+	CreatedBy    string                 `json:"created_by"`
+	CreatedAt    time.Time              `json:"created_at"`
+	UpdatedAt    time.Time              `json:"updated_at"`
+}
+
+// CloneFaseResponse pada dry run Dibuat dan Dilewati adalah data yang akan dibuat atau dilewati
+type CloneFaseResponse struct {
+	Nama     string `json:"nama"`
+	Total    int    `json:"total"`
+	Diproses int    `json:"diproses"`
+	Dibuat   int    `json:"dibuat"`
+	Dilewati int    `json:"dilewati"`
+	Persen   int    `json:"persen"`
+	Selesai  bool   `json:"selesai"`
+}
+
+type CloneWarningResponse struct {
+	Type    string `json:"type"`
+	Jumlah  int    `json:"jumlah"`
+	Message string `json:"message"`
+}
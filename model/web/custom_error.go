@@ -1,6 +1,6 @@
 package web
 
-// CustomError untuk menandai error yang harus dikembalikan sebagai Bad Request
+// CustomError untuk menandai error service yang membawa HTTP status sendiri
 type CustomError struct {
 	Code    int
 	Message string
@@ -23,3 +23,38 @@ func NewNotFoundError(message string) *CustomError {
 		Message: message,
 	}
 }
+
+func NewForbiddenError(message string) *CustomError {
+	return &CustomError{
+		Code:    403,
+		Message: message,
+	}
+}
+
+func NewTooManyRequestsError(message string) *CustomError {
+	return &CustomError{
+		Code:    429,
+		Message: message,
+	}
+}
+
+func NewLockedError(message string) *CustomError {
+	return &CustomError{
+		Code:    423,
+		Message: message,
+	}
+}
+
+func NewConflictError(message string) *CustomError {
+	return &CustomError{
+		Code:    409,
+		Message: message,
+	}
+}
+
+func NewUnauthorizedError(message string) *CustomError {
+	return &CustomError{
+		Code:    401,
+		Message: message,
+	}
+}
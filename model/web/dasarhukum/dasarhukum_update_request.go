@@ -3,7 +3,7 @@ package dasarhukum
 type DasarHukumUpdateRequest struct {
 	Id               string `json:"id"`
 	RekinId          string `json:"rencana_kinerja_id"`
-	Urutan           int    `json:"urutan"`
-	PeraturanTerkait string `json:"peraturan_terkait"`
+	Urutan           int    `json:"urutan" validate:"gte=0"`
+	PeraturanTerkait string `json:"peraturan_terkait" validate:"required"`
 	Uraian           string `json:"uraian"`
 }
@@ -0,0 +1,11 @@
+package web
+
+// ErrorResponse envelope seragam untuk semua response error.
+// Pesan tetap di field data agar kompatibel dengan frontend lama.
+type ErrorResponse struct {
+	Code      int         `json:"code"`
+	Status    string      `json:"status"`
+	ErrorCode string      `json:"error_code"`
+	Data      interface{} `json:"data"`
+	RequestId string      `json:"request_id,omitempty"`
+}
@@ -0,0 +1,7 @@
+package web
+
+// FieldError satu field request yang tidak lolos validasi
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
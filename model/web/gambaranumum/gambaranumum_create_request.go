@@ -3,6 +3,6 @@ package gambaranumum
 type GambaranUmumCreateRequest struct {
 	RekinId      string `json:"rekin_id"`
 	KodeOpd      string `json:"kode_opd"`
-	Urutan       int    `json:"urutan"`
-	GambaranUmum string `json:"gambaran_umum"`
+	Urutan       int    `json:"urutan" validate:"gte=0"`
+	GambaranUmum string `json:"gambaran_umum" validate:"required"`
 }
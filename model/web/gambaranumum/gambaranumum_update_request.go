@@ -2,6 +2,6 @@ package gambaranumum
 
 type GambaranUmumUpdateRequest struct {
 	Id           string `json:"id"`
-	Urutan       int    `json:"urutan"`
-	GambaranUmum string `json:"gambaran_umum"`
+	Urutan       int    `json:"urutan" validate:"gte=0"`
+	GambaranUmum string `json:"gambaran_umum" validate:"required"`
 }
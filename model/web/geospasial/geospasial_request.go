@@ -0,0 +1,29 @@
+package geospasial
+
+import "encoding/json"
+
+// LokasiRequest latitude dan longitude diisi berpasangan, geometri GeoJSON opsional,
+// kode_wilayah kosong diisi otomatis dari batas wilayah yang memuat titik
+type LokasiRequest struct {
+	Latitude    *float64        `json:"latitude"`
+	Longitude   *float64        `json:"longitude"`
+	Geometri    json.RawMessage `json:"geometri" swaggertype:"object"`
+	KodeWilayah string          `json:"kode_wilayah"`
+}
+
+type LokasiUsulanRequest struct {
+	JenisUsulan string `json:"-"`
+	UsulanId    string `json:"-"`
+	LokasiRequest
+}
+
+type LokasiIndikatorItemRequest struct {
+	LokasiRequest
+	Keterangan string `json:"keterangan"`
+}
+
+// LokasiIndikatorRequest mengganti seluruh lokasi indikator, daftar kosong menghapus lokasi
+type LokasiIndikatorRequest struct {
+	IndikatorId string                       `json:"-"`
+	Lokasi      []LokasiIndikatorItemRequest `json:"lokasi" validate:"dive"`
+}
@@ -0,0 +1,35 @@
+package geospasial
+
+import "encoding/json"
+
+type LokasiResponse struct {
+	Latitude      *float64        `json:"latitude"`
+	Longitude     *float64        `json:"longitude"`
+	Geometri      json.RawMessage `json:"geometri,omitempty" swaggertype:"object"`
+	KodeWilayah   string          `json:"kode_wilayah"`
+	NamaWilayah   string          `json:"nama_wilayah"`
+	KodeKecamatan string          `json:"kode_kecamatan"`
+	NamaKecamatan string          `json:"nama_kecamatan"`
+	Keterangan    string          `json:"keterangan,omitempty"`
+}
+
+// FeatureCollection GeoJSON (RFC 7946), TanpaLokasi jumlah data yang tidak bisa dipetakan
+type FeatureCollection struct {
+	Type        string    `json:"type"`
+	Features    []Feature `json:"features"`
+	TanpaLokasi int       `json:"tanpa_lokasi"`
+}
+
+type Feature struct {
+	Type       string                 `json:"type"`
+	Id         string                 `json:"id"`
+	Geometry   json.RawMessage        `json:"geometry" swaggertype:"object"`
+	Properties map[string]interface{} `json:"properties"`
+}
+
+type WilayahImportResponse struct {
+	Tingkat        string   `json:"tingkat"`
+	JumlahFitur    int      `json:"jumlah_fitur"`
+	JumlahDisimpan int      `json:"jumlah_disimpan"`
+	Ditolak        []string `json:"ditolak"`
+}
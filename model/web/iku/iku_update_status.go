@@ -1,6 +1,6 @@
 package iku
 
 type IkuUpdateActiveRequest struct {
-	IndikatorId string `json:"indikator_id"`
+	IndikatorId string `json:"indikator_id" validate:"required"`
 	IsActive    bool   `json:"is_active"`
 }
@@ -1,6 +1,7 @@
 package web
 
 type JWTClaim struct {
+	Jti       string   `json:"jti"`
 	Issuer    string   `json:"iss"`
 	Subject   string   `json:"sub"`
 	UserId    int      `json:"user_id"`
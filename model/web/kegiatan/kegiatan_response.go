@@ -8,6 +8,7 @@ type KegiatanResponse struct {
 	Id           string    `json:"id"`
 	NamaKegiatan string    `json:"nama_kegiatan"`
 	KodeKegiatan string    `json:"kode_kegiatan"`
+	IsDeprecated bool      `json:"is_deprecated"`
 	CreatedAt    time.Time `json:"created_at"`
 	Indikator    []IndikatorResponse
 }
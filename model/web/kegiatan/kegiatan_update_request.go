@@ -1,24 +1,24 @@
 package kegiatan
 
 type KegiatanUpdateRequest struct {
-	Id           string `json:"id"`
-	NamaKegiatan string `json:"nama_kegiatan"`
-	KodeKegiatan string `json:"kode_kegiatan"`
-	Indikator    []IndikatorUpdateRequest
+	Id           string                   `json:"id"`
+	NamaKegiatan string                   `json:"nama_kegiatan" validate:"required"`
+	KodeKegiatan string                   `json:"kode_kegiatan" validate:"required"`
+	Indikator    []IndikatorUpdateRequest `validate:"dive"`
 }
 
 type IndikatorUpdateRequest struct {
-	Id         string `json:"id"`
-	KegiatanId string `json:"kegiatan_id"`
-	Indikator  string `json:"indikator"`
-	Tahun      string `json:"tahun"`
-	Target     []TargetUpdateRequest
+	Id         string                `json:"id"`
+	KegiatanId string                `json:"kegiatan_id"`
+	Indikator  string                `json:"indikator"`
+	Tahun      string                `json:"tahun" validate:"omitempty,tahun"`
+	Target     []TargetUpdateRequest `validate:"dive"`
 }
 
 type TargetUpdateRequest struct {
 	Id          string `json:"id"`
 	IndikatorId string `json:"indikator_id"`
-	Tahun       string `json:"tahun"`
+	Tahun       string `json:"tahun" validate:"omitempty,tahun"`
 	Target      string `json:"target"`
 	Satuan      string `json:"satuan"`
 }
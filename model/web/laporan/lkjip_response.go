@@ -0,0 +1,74 @@
+package laporan
+
+type LkjipResponse struct {
+	KodeOpd           string           `json:"kode_opd"`
+	NamaOpd           string           `json:"nama_opd"`
+	NamaKepalaOpd     string           `json:"nama_kepala_opd"`
+	NipKepalaOpd      string           `json:"nip_kepala_opd"`
+	Tahun             string           `json:"tahun"`
+	Periode           PeriodeResponse  `json:"periode"`
+	Tujuan            []TujuanLkjip    `json:"tujuan"`
+	Sasaran           []SasaranLkjip   `json:"sasaran"`
+	Iku               []IndikatorLkjip `json:"iku"`
+	PerjanjianKinerja []PkLkjip        `json:"perjanjian_kinerja"`
+	Anggaran          []ProgramLkjip   `json:"anggaran"`
+	TotalAnggaran     int64            `json:"total_anggaran"`
+	Ringkasan         RingkasanLkjip   `json:"ringkasan"`
+}
+
+type PeriodeResponse struct {
+	TahunAwal    string `json:"tahun_awal"`
+	TahunAkhir   string `json:"tahun_akhir"`
+	JenisPeriode string `json:"jenis_periode"`
+}
+
+type TujuanLkjip struct {
+	Id        int              `json:"id_tujuan_opd"`
+	Tujuan    string           `json:"tujuan"`
+	Indikator []IndikatorLkjip `json:"indikator"`
+}
+
+type SasaranLkjip struct {
+	Id        string           `json:"id_sasaran_opd"`
+	Sasaran   string           `json:"sasaran"`
+	Tujuan    string           `json:"tujuan"`
+	Indikator []IndikatorLkjip `json:"indikator"`
+}
+
+type IndikatorLkjip struct {
+	IndikatorId      string   `json:"indikator_id"`
+	Indikator        string   `json:"indikator"`
+	RumusPerhitungan string   `json:"rumus_perhitungan,omitempty"`
+	Target           string   `json:"target"`
+	Satuan           string   `json:"satuan"`
+	Realisasi        string   `json:"realisasi"`
+	Capaian          *float64 `json:"capaian"`
+}
+
+type PkLkjip struct {
+	LevelPk        int              `json:"level_pk"`
+	Nip            string           `json:"nip"`
+	Nama           string           `json:"nama"`
+	Jabatan        string           `json:"jabatan"`
+	RencanaKinerja string           `json:"rencana_kinerja"`
+	Indikator      []IndikatorLkjip `json:"indikator"`
+}
+
+type ProgramLkjip struct {
+	KodeProgram string             `json:"kode_program"`
+	NamaProgram string             `json:"nama_program"`
+	Pagu        int64              `json:"pagu"`
+	SubKegiatan []SubKegiatanLkjip `json:"sub_kegiatan"`
+}
+
+type SubKegiatanLkjip struct {
+	KodeSubKegiatan string `json:"kode_subkegiatan"`
+	NamaSubKegiatan string `json:"nama_subkegiatan"`
+	Pagu            int64  `json:"pagu"`
+}
+
+type RingkasanLkjip struct {
+	JumlahIndikator int      `json:"jumlah_indikator"`
+	JumlahTerukur   int      `json:"jumlah_terukur"`
+	RataRataCapaian *float64 `json:"rata_rata_capaian"`
+}
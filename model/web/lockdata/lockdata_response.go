@@ -0,0 +1,11 @@
+package lockdata
+
+import "time"
+
+type LockDataResponse struct {
+	Id        int       `json:"id"`
+	JenisData string    `json:"jenis_data"`
+	KodeOpd   string    `json:"kode_opd"`
+	Tahun     string    `json:"tahun"`
+	LockedAt  time.Time `json:"locked_at"`
+}
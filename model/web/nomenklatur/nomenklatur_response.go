@@ -0,0 +1,54 @@
+package nomenklatur
+
+import "time"
+
+// NomenklaturImportResponse hasil diff file terhadap master, Disimpan false berarti preview
+type NomenklaturImportResponse struct {
+	Versi        string                          `json:"versi"`
+	NamaFile     string                          `json:"nama_file"`
+	Disimpan     bool                            `json:"disimpan"`
+	VersiId      int64                           `json:"versi_id,omitempty"`
+	JumlahBaris  int                             `json:"jumlah_baris"`
+	PerTingkat   map[string]NomenklaturRingkasan `json:"per_tingkat"`
+	Perubahan    []NomenklaturPerubahanResponse  `json:"perubahan"`
+	BarisDitolak []NomenklaturBarisDitolak       `json:"baris_ditolak"`
+	Peringatan   []string                        `json:"peringatan"`
+}
+
+type NomenklaturRingkasan struct {
+	JumlahFile   int `json:"jumlah_file"`
+	JumlahMaster int `json:"jumlah_master"`
+	Tambah       int `json:"tambah"`
+	UbahNama     int `json:"ubah_nama"`
+	Hapus        int `json:"hapus"`
+	Deprecated   int `json:"deprecated"`
+	AktifKembali int `json:"aktif_kembali"`
+}
+
+type NomenklaturPerubahanResponse struct {
+	Tingkat  string `json:"tingkat"`
+	Kode     string `json:"kode"`
+	Aksi     string `json:"aksi"`
+	NamaLama string `json:"nama_lama,omitempty"`
+	NamaBaru string `json:"nama_baru,omitempty"`
+}
+
+type NomenklaturBarisDitolak struct {
+	Baris  int    `json:"baris"`
+	Kode   string `json:"kode"`
+	Alasan string `json:"alasan"`
+}
+
+type NomenklaturVersiResponse struct {
+	Id                 int64                          `json:"id"`
+	Versi              string                         `json:"versi"`
+	NamaFile           string                         `json:"nama_file"`
+	JumlahTambah       int                            `json:"jumlah_tambah"`
+	JumlahUbah         int                            `json:"jumlah_ubah"`
+	JumlahHapus        int                            `json:"jumlah_hapus"`
+	JumlahDeprecated   int                            `json:"jumlah_deprecated"`
+	JumlahAktifKembali int                            `json:"jumlah_aktif_kembali"`
+	AppliedBy          string                         `json:"applied_by"`
+	CreatedAt          time.Time                      `json:"created_at"`
+	Perubahan          []NomenklaturPerubahanResponse `json:"perubahan,omitempty"`
+}
@@ -2,7 +2,7 @@ package pegawai
 
 type PegawaiUpdateRequest struct {
 	Id          string `json:"id"`
-	NamaPegawai string `json:"nama_pegawai"`
-	Nip         string `json:"nip"`
+	NamaPegawai string `json:"nama_pegawai" validate:"required"`
+	Nip         string `json:"nip" validate:"required"`
 	KodeOpd     string `json:"kode_opd"`
 }
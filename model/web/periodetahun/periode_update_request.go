@@ -2,7 +2,7 @@ package periodetahun
 
 type PeriodeUpdateRequest struct {
 	Id           int    `json:"id"`
-	TahunAwal    string `json:"tahun_awal"`
-	TahunAkhir   string `json:"tahun_akhir"`
-	JenisPeriode string `json:"jenis_periode"`
+	TahunAwal    string `json:"tahun_awal" validate:"required,tahun"`
+	TahunAkhir   string `json:"tahun_akhir" validate:"required,tahun"`
+	JenisPeriode string `json:"jenis_periode" validate:"required"`
 }
@@ -2,7 +2,7 @@ package permasalahan
 
 type PermasalahanRekinUpdateRequest struct {
 	Id                int    `json:"id"`
-	Permasalahan      string `json:"permasalahan"`
+	Permasalahan      string `json:"permasalahan" validate:"required"`
 	PenyebabInternal  string `json:"penyebab_internal"`
 	PenyebabEksternal string `json:"penyebab_eksternal"`
 }
@@ -11,10 +11,11 @@ type PkOpdResponse struct {
 }
 
 type SasaranPemdaPk struct {
-	NamaKepalaPemda  string `json:"nama_kepala_pemda"`
-	NipKepalaPemda   string `json:"nip_kepala_pemda"`
-	IdSasaranPemda   int    `json:"id_sasaran_pemda"`
-	NamaSasaranPemda string `json:"sasaran_pemda"`
+	NamaKepalaPemda    string `json:"nama_kepala_pemda"`
+	NipKepalaPemda     string `json:"nip_kepala_pemda"`
+	JabatanKepalaPemda string `json:"jabatan_kepala_pemda"`
+	IdSasaranPemda     int    `json:"id_sasaran_pemda"`
+	NamaSasaranPemda   string `json:"sasaran_pemda"`
 }
 
 type PkOpdByLevel struct {
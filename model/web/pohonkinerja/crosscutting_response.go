@@ -32,6 +32,7 @@ type CrosscuttingApproveRequest struct {
 	LevelPohon  int    `json:"level_pohon"`
 	JenisPohon  string `json:"jenis_pohon"`
 	ExistingId  int    `json:"existing_id,omitempty"`
+	Alasan      string `json:"alasan,omitempty"`
 }
 
 type CrosscuttingApproveResponse struct {
@@ -2,12 +2,12 @@ package pohonkinerja
 
 type PohonKinerjaCreateRequest struct {
 	Parent       int                      `json:"parent"`
-	NamaPohon    string                   `json:"nama_pohon"`
-	JenisPohon   string                   `json:"jenis_pohon"`
-	LevelPohon   int                      `json:"level_pohon"`
-	KodeOpd      string                   `json:"kode_opd"`
+	NamaPohon    string                   `json:"nama_pohon" validate:"required"`
+	JenisPohon   string                   `json:"jenis_pohon" validate:"required"`
+	LevelPohon   int                      `json:"level_pohon" validate:"required"`
+	KodeOpd      string                   `json:"kode_opd" validate:"required"`
 	Keterangan   string                   `json:"keterangan"`
-	Tahun        string                   `json:"tahun"`
+	Tahun        string                   `json:"tahun" validate:"required,tahun_periode"`
 	Status       string                   `json:"status"`
 	PelaksanaId  []PelaksanaCreateRequest `json:"pelaksana"`
 	Indikator    []IndikatorCreateRequest `json:"indikator"`
@@ -22,12 +22,12 @@ type PelaksanaCreateRequest struct {
 type PohonKinerjaAdminCreateRequest struct {
 	CSFRequest   `json:",inline"`
 	Parent       int                      `json:"parent"`
-	NamaPohon    string                   `json:"nama_pohon"`
-	JenisPohon   string                   `json:"jenis_pohon"`
-	KodeOpd      string                   `json:"kode_opd,omitempty"`
-	LevelPohon   int                      `json:"level_pohon"`
+	NamaPohon    string                   `json:"nama_pohon" validate:"required"`
+	JenisPohon   string                   `json:"jenis_pohon" validate:"required"`
+	KodeOpd      string                   `json:"kode_opd,omitempty"`           // kosong untuk pohon kinerja pemda
+	LevelPohon   int                      `json:"level_pohon" validate:"min=0"` // tematik berada di level 0
 	Keterangan   string                   `json:"keterangan"`
-	Tahun        string                   `json:"tahun"`
+	Tahun        string                   `json:"tahun" validate:"required,tahun"`
 	Status       string                   `json:"status"`
 	TaggingPokin []TaggingCreateRequest   `json:"tagging"`
 	Pelaksana    []PelaksanaCreateRequest `json:"pelaksana"`
@@ -52,7 +52,8 @@ type TargetUpdateRequest struct {
 }
 
 type PohonKinerjaAdminTolakRequest struct {
-	Id int `json:"id" validate:"required"`
+	Id     int    `json:"id" validate:"required"`
+	Alasan string `json:"alasan"`
 }
 
 type TaggingUpdateRequest struct {
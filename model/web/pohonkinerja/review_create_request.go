@@ -3,7 +3,7 @@ package pohonkinerja
 type ReviewCreateRequest struct {
 	Id             int    `json:"id"`
 	IdPohonKinerja int    `json:"id_pohon_kinerja"`
-	Review         string `json:"review"`
+	Review         string `json:"review" validate:"required"`
 	Keterangan     string `json:"keterangan"`
 	CreatedBy      string `json:"created_by"`
 	JenisPokin     string `json:"jenis_pokin"`
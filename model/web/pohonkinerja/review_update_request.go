@@ -3,6 +3,6 @@ package pohonkinerja
 type ReviewUpdateRequest struct {
 	Id             int    `json:"id"`
 	IdPohonKinerja int    `json:"id_pohon_kinerja"`
-	Review         string `json:"review"`
+	Review         string `json:"review" validate:"required"`
 	Keterangan     string `json:"keterangan"`
 }
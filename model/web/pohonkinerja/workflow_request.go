@@ -0,0 +1,7 @@
+package pohonkinerja
+
+type PokinTransisiRequest struct {
+	PohonKinerjaId int    `json:"-"`
+	Aksi           string `json:"aksi" validate:"required"`
+	Alasan         string `json:"alasan"`
+}
@@ -0,0 +1,33 @@
+package pohonkinerja
+
+import "time"
+
+type PokinTransisiResponse struct {
+	Id             int64     `json:"id"`
+	PohonKinerjaId int       `json:"pohon_kinerja_id"`
+	DariStatus     string    `json:"dari_status"`
+	KeStatus       string    `json:"ke_status"`
+	Aksi           string    `json:"aksi"`
+	Alasan         string    `json:"alasan,omitempty"`
+	ActorNip       string    `json:"actor_nip"`
+	ActorRole      string    `json:"actor_role"`
+	ActorKodeOpd   string    `json:"actor_kode_opd"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// PokinAturanTransisiResponse satu transisi yang diizinkan workflow, status kosong berarti pokin OPD yang belum diajukan
+type PokinAturanTransisiResponse struct {
+	Aksi        string   `json:"aksi"`
+	DariStatus  string   `json:"dari_status"`
+	KeStatus    string   `json:"ke_status"`
+	Roles       []string `json:"roles"`
+	WajibAlasan bool     `json:"wajib_alasan"`
+}
+
+// PokinWorkflowResponse status pokin saat ini beserta aksi yang bisa dilakukan user
+type PokinWorkflowResponse struct {
+	PohonKinerjaId int                           `json:"pohon_kinerja_id"`
+	Status         string                        `json:"status"`
+	AksiTersedia   []PokinAturanTransisiResponse `json:"aksi_tersedia"`
+	Riwayat        []PokinTransisiResponse       `json:"riwayat"`
+}
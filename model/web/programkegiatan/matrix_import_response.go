@@ -0,0 +1,20 @@
+package programkegiatan
+
+// MatrixImportResponse laporan validasi per baris import matrix dari xlsx
+type MatrixImportResponse struct {
+	Jenis           string                  `json:"jenis"`
+	KodeOpd         string                  `json:"kode_opd"`
+	JumlahBaris     int                     `json:"jumlah_baris"`
+	JumlahIndikator int                     `json:"jumlah_indikator"`
+	JumlahAnggaran  int                     `json:"jumlah_anggaran"`
+	Valid           bool                    `json:"valid"`
+	Disimpan        bool                    `json:"disimpan"`
+	Kesalahan       []MatrixImportKesalahan `json:"kesalahan"`
+}
+
+// MatrixImportKesalahan Baris mengikuti nomor baris di spreadsheet
+type MatrixImportKesalahan struct {
+	Baris int    `json:"baris"`
+	Kode  string `json:"kode"`
+	Pesan string `json:"pesan"`
+}
@@ -45,14 +45,14 @@ type AnggaranRenstraRequest struct {
 	KodeSubKegiatan string `json:"kode_subkegiatan" validate:"required"`
 	KodeOpd         string `json:"kode_opd"         validate:"required"`
 	Tahun           string `json:"tahun"            validate:"required"`
-	Pagu            int64  `json:"pagu_indikatif" validate:"required"`
+	Pagu            int64  `json:"pagu_indikatif" validate:"gte=0"`
 }
 
 type AnggaranRenjaRequest struct {
 	KodeSubKegiatan string `json:"kode_subkegiatan" validate:"required"`
 	KodeOpd         string `json:"kode_opd"         validate:"required"`
 	Tahun           string `json:"tahun"            validate:"required"`
-	Pagu            int64  `json:"pagu_indikatif" validate:"required"`
+	Pagu            int64  `json:"pagu_indikatif" validate:"gte=0"`
 }
 
 type TargetRenjaRequest struct {
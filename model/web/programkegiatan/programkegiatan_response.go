@@ -1,12 +1,13 @@
 package programkegiatan
 
 type ProgramKegiatanResponse struct {
-	Id          string              `json:"id"`
-	KodeProgram string              `json:"kode_program"`
-	NamaProgram string              `json:"nama_program"`
-	Tahun       string              `json:"tahun"`
-	IsActive    bool                `json:"is_active"`
-	Indikator   []IndikatorResponse `json:"indikator"`
+	Id           string              `json:"id"`
+	KodeProgram  string              `json:"kode_program"`
+	NamaProgram  string              `json:"nama_program"`
+	Tahun        string              `json:"tahun"`
+	IsActive     bool                `json:"is_active"`
+	IsDeprecated bool                `json:"is_deprecated"`
+	Indikator    []IndikatorResponse `json:"indikator"`
 }
 
 type IndikatorResponse struct {
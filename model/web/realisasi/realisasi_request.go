@@ -0,0 +1,25 @@
+package realisasi
+
+type RealisasiCreateRequest struct {
+	IndikatorId  string `json:"indikator_id" validate:"required"`
+	Tahun        string `json:"tahun" validate:"required,tahun"`
+	JenisPeriode string `json:"jenis_periode" validate:"required,oneof=bulanan triwulan tahunan"`
+	Periode      int    `json:"periode" validate:"min=0,max=12"`
+	Realisasi    string `json:"realisasi" validate:"required"`
+	Satuan       string `json:"satuan"`
+	BuktiDukung  string `json:"bukti_dukung"`
+	Keterangan   string `json:"keterangan"`
+}
+
+type RealisasiUpdateRequest struct {
+	Id          int64  `json:"-"`
+	Realisasi   string `json:"realisasi" validate:"required"`
+	Satuan      string `json:"satuan"`
+	BuktiDukung string `json:"bukti_dukung"`
+	Keterangan  string `json:"keterangan"`
+}
+
+type PolaritasUpdateRequest struct {
+	IndikatorId string `json:"-"`
+	Polaritas   string `json:"polaritas" validate:"required,oneof=positif negatif"`
+}
@@ -0,0 +1,35 @@
+package realisasi
+
+import "time"
+
+type RealisasiResponse struct {
+	Id           int64     `json:"id"`
+	IndikatorId  string    `json:"indikator_id"`
+	Tahun        string    `json:"tahun"`
+	JenisPeriode string    `json:"jenis_periode"`
+	Periode      int       `json:"periode"`
+	Realisasi    string    `json:"realisasi"`
+	Satuan       string    `json:"satuan"`
+	Capaian      *float64  `json:"capaian"`
+	BuktiDukung  string    `json:"bukti_dukung"`
+	Keterangan   string    `json:"keterangan"`
+	CreatedBy    string    `json:"created_by"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+// CapaianIndikatorResponse target, realisasi per periode dan capaian (%) satu indikator pada satu tahun.
+// Capaian null jika target/realisasi bukan angka, pembagi nol, atau jenis_rumus tidak_didukung.
+type CapaianIndikatorResponse struct {
+	IndikatorId       string              `json:"indikator_id"`
+	Indikator         string              `json:"indikator"`
+	RumusPerhitungan  string              `json:"rumus_perhitungan"`
+	JenisRumus        string              `json:"jenis_rumus"`
+	Polaritas         string              `json:"polaritas"`
+	Tahun             string              `json:"tahun"`
+	Target            string              `json:"target"`
+	Satuan            string              `json:"satuan"`
+	RealisasiTerakhir string              `json:"realisasi_terakhir"`
+	RealisasiTahun    string              `json:"realisasi_tahun"`
+	Capaian           *float64            `json:"capaian"`
+	Realisasi         []RealisasiResponse `json:"realisasi"`
+}
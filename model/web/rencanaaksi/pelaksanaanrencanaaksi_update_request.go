@@ -2,6 +2,6 @@ package rencanaaksi
 
 type PelaksanaanRencanaAksiUpdateRequest struct {
 	Id    string `json:"id"`
-	Bobot int    `json:"bobot"`
-	Bulan int    `json:"bulan"`
+	Bobot int    `json:"bobot" validate:"gte=0"`
+	Bulan int    `json:"bulan" validate:"required,min=1,max=12"`
 }
@@ -0,0 +1,8 @@
+package rencanaaksi
+
+type ProgresPelaksanaanRequest struct {
+	PelaksanaanRencanaAksiId string  `json:"-"`
+	Persentase               float64 `json:"persentase" validate:"gte=0,lte=100"`
+	Catatan                  string  `json:"catatan"`
+	Kendala                  string  `json:"kendala"`
+}
@@ -0,0 +1,65 @@
+package rencanaaksi
+
+import "time"
+
+type ProgresPelaksanaanResponse struct {
+	PelaksanaanRencanaAksiId string     `json:"pelaksanaan_rencana_aksi_id"`
+	Bulan                    int        `json:"bulan"`
+	Bobot                    int        `json:"bobot"`
+	Persentase               *float64   `json:"persentase"`
+	BobotRealisasi           float64    `json:"bobot_realisasi"`
+	Catatan                  string     `json:"catatan,omitempty"`
+	Kendala                  string     `json:"kendala,omitempty"`
+	CreatedBy                string     `json:"created_by,omitempty"`
+	UpdatedAt                *time.Time `json:"updated_at,omitempty"`
+}
+
+type RencanaAksiProgresResponse struct {
+	RencanaAksiId   string                       `json:"rencana_aksi_id"`
+	NamaRencanaAksi string                       `json:"nama_rencana_aksi"`
+	Pelaksanaan     []ProgresPelaksanaanResponse `json:"pelaksanaan"`
+}
+
+type KurvaSBulanResponse struct {
+	Bulan              int     `json:"bulan"`
+	Rencana            float64 `json:"rencana"`
+	Realisasi          float64 `json:"realisasi"`
+	RencanaKumulatif   float64 `json:"rencana_kumulatif"`
+	RealisasiKumulatif float64 `json:"realisasi_kumulatif"`
+	Deviasi            float64 `json:"deviasi"`
+}
+
+type KurvaSResponse struct {
+	RencanaKinerjaId   string                       `json:"rencana_kinerja_id"`
+	NamaRencanaKinerja string                       `json:"nama_rencana_kinerja"`
+	Tahun              string                       `json:"tahun"`
+	BulanAcuan         int                          `json:"bulan_acuan"`
+	TotalBobot         int                          `json:"total_bobot"`
+	RencanaKumulatif   float64                      `json:"rencana_kumulatif"`
+	RealisasiKumulatif float64                      `json:"realisasi_kumulatif"`
+	Deviasi            float64                      `json:"deviasi"`
+	Terlambat          bool                         `json:"terlambat"`
+	Kurva              []KurvaSBulanResponse        `json:"kurva"`
+	RencanaAksi        []RencanaAksiProgresResponse `json:"rencana_aksi"`
+}
+
+type ProgresRekinOpdResponse struct {
+	RencanaKinerjaId   string   `json:"rencana_kinerja_id"`
+	NamaRencanaKinerja string   `json:"nama_rencana_kinerja"`
+	PegawaiId          string   `json:"pegawai_id"`
+	TotalBobot         int      `json:"total_bobot"`
+	RencanaKumulatif   float64  `json:"rencana_kumulatif"`
+	RealisasiKumulatif float64  `json:"realisasi_kumulatif"`
+	Deviasi            float64  `json:"deviasi"`
+	Terlambat          bool     `json:"terlambat"`
+	Kendala            []string `json:"kendala,omitempty"`
+}
+
+type ProgresOpdResponse struct {
+	KodeOpd         string                    `json:"kode_opd"`
+	Tahun           string                    `json:"tahun"`
+	BulanAcuan      int                       `json:"bulan_acuan"`
+	JumlahRekin     int                       `json:"jumlah_rencana_kinerja"`
+	JumlahTerlambat int                       `json:"jumlah_terlambat"`
+	RencanaKinerja  []ProgresRekinOpdResponse `json:"rencana_kinerja"`
+}
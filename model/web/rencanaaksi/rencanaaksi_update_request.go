@@ -2,6 +2,6 @@ package rencanaaksi
 
 type RencanaAksiUpdateRequest struct {
 	Id              string `json:"id"`
-	Urutan          int    `json:"urutan"`
-	NamaRencanaAksi string `json:"nama_rencana_aksi"`
+	Urutan          int    `json:"urutan" validate:"gte=0"`
+	NamaRencanaAksi string `json:"nama_rencana_aksi" validate:"required"`
 }
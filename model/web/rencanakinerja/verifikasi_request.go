@@ -0,0 +1,7 @@
+package rencanakinerja
+
+type RekinVerifikasiRequest struct {
+	RencanaKinerjaId string `json:"-"`
+	Aksi             string `json:"aksi" validate:"required"`
+	Catatan          string `json:"catatan"`
+}
@@ -0,0 +1,50 @@
+package rencanakinerja
+
+import "time"
+
+type RekinVerifikasiResponse struct {
+	Id               int64     `json:"id"`
+	RencanaKinerjaId string    `json:"rencana_kinerja_id"`
+	Revisi           int       `json:"revisi"`
+	DariStatus       string    `json:"dari_status"`
+	KeStatus         string    `json:"ke_status"`
+	Aksi             string    `json:"aksi"`
+	Catatan          string    `json:"catatan,omitempty"`
+	ActorNip         string    `json:"actor_nip"`
+	ActorPeran       string    `json:"actor_peran"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+// RekinAturanVerifikasiResponse status kosong berarti rekin yang belum diajukan
+type RekinAturanVerifikasiResponse struct {
+	Aksi         string `json:"aksi"`
+	DariStatus   string `json:"dari_status"`
+	KeStatus     string `json:"ke_status"`
+	Peran        string `json:"peran"`
+	WajibCatatan bool   `json:"wajib_catatan"`
+}
+
+// RekinStatusVerifikasiResponse status verifikasi rekin beserta aksi yang bisa dilakukan user
+type RekinStatusVerifikasiResponse struct {
+	RencanaKinerjaId string                          `json:"rencana_kinerja_id"`
+	Status           string                          `json:"status"`
+	Catatan          string                          `json:"catatan"`
+	CatatanAtasan    string                          `json:"catatan_atasan"`
+	Revisi           int                             `json:"revisi"`
+	Terkunci         bool                            `json:"terkunci"`
+	NipAtasan        string                          `json:"nip_atasan"`
+	AksiTersedia     []RekinAturanVerifikasiResponse `json:"aksi_tersedia"`
+	Riwayat          []RekinVerifikasiResponse       `json:"riwayat"`
+}
+
+// RekinInboxResponse rekin bawahan yang menunggu verifikasi user
+type RekinInboxResponse struct {
+	Id                 string    `json:"id_rencana_kinerja"`
+	NamaRencanaKinerja string    `json:"nama_rencana_kinerja"`
+	Tahun              string    `json:"tahun"`
+	KodeOpd            string    `json:"kode_opd"`
+	PegawaiId          string    `json:"pegawai_id"`
+	NamaPegawai        string    `json:"nama_pegawai"`
+	Revisi             int       `json:"revisi"`
+	DiajukanPada       time.Time `json:"diajukan_pada"`
+}
@@ -4,26 +4,26 @@ type SasaranPemdaUpdateRequest struct {
 	Id            int                      `json:"id"`
 	TujuanPemdaId int                      `json:"tujuan_pemda_id"`
 	SubtemaId     int                      `json:"subtema_id"`
-	SasaranPemda  string                   `json:"sasaran_pemda"`
+	SasaranPemda  string                   `json:"sasaran_pemda" validate:"required"`
 	PeriodeId     int                      `json:"periode_id"`
-	TahunAwal     string                   `json:"tahun_awal"`
-	TahunAkhir    string                   `json:"tahun_akhir"`
+	TahunAwal     string                   `json:"tahun_awal" validate:"omitempty,tahun"`
+	TahunAkhir    string                   `json:"tahun_akhir" validate:"omitempty,tahun"`
 	JenisPeriode  string                   `json:"jenis_periode"`
-	Indikator     []IndikatorUpdateRequest `json:"indikator"`
+	Indikator     []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorUpdateRequest struct {
 	Id               string                `json:"id"`
 	SasaranPemdaId   string                `json:"sasaran_id"`
-	Indikator        string                `json:"indikator"`
+	Indikator        string                `json:"indikator" validate:"required"`
 	RumusPerhitungan string                `json:"rumus_perhitungan"`
 	SumberData       string                `json:"sumber_data"`
-	Target           []TargetUpdateRequest `json:"target"`
+	Target           []TargetUpdateRequest `json:"target" validate:"dive"`
 }
 
 type TargetUpdateRequest struct {
 	Id     string `json:"id"`
 	Target string `json:"target"`
 	Satuan string `json:"satuan"`
-	Tahun  string `json:"tahun"`
+	Tahun  string `json:"tahun" validate:"omitempty,tahun"`
 }
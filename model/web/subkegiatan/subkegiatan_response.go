@@ -12,6 +12,7 @@ type SubKegiatanResponse struct {
 	KodeOpd               string                         `json:"kode_opd,omitempty"`
 	NamaOpd               string                         `json:"nama_opd,omitempty"`
 	Tahun                 string                         `json:"tahun,omitempty"`
+	IsDeprecated          bool                           `json:"is_deprecated,omitempty"`
 	Indikator             []IndikatorResponse            `json:"indikator,omitempty"`
 	IndikatorSubkegiatan  []IndikatorSubKegiatanResponse `json:"indikator_subkegiatan,omitempty"`
 	PaguSubKegiatan       []PaguSubKegiatanResponse      `json:"pagu,omitempty"`
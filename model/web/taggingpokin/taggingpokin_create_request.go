@@ -1,6 +1,6 @@
 package taggingpokin
 
 type TaggingPokinCreateRequest struct {
-	NamaTagging       string  `json:"nama_tagging"`
+	NamaTagging       string  `json:"nama_tagging" validate:"required"`
 	KeteranganTagging *string `json:"keterangan_tagging"`
 }
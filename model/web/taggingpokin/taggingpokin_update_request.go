@@ -2,6 +2,6 @@ package taggingpokin
 
 type TaggingPokinUpdateRequest struct {
 	Id                int     `json:"id"`
-	NamaTagging       string  `json:"nama_tagging"`
+	NamaTagging       string  `json:"nama_tagging" validate:"required"`
 	KeteranganTagging *string `json:"keterangan_tagging"`
 }
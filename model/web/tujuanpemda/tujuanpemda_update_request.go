@@ -4,27 +4,27 @@ type TujuanPemdaUpdateRequest struct {
 	Id                int                      `json:"id"`
 	IdVisi            int                      `json:"id_visi"`
 	IdMisi            int                      `json:"id_misi"`
-	TujuanPemda       string                   `json:"tujuan_pemda"`
+	TujuanPemda       string                   `json:"tujuan_pemda" validate:"required"`
 	TematikId         int                      `json:"tema_id"`
 	PeriodeId         int                      `json:"periode_id"`
-	TahunAwalPeriode  string                   `json:"tahun_awal_periode"`
-	TahunAkhirPeriode string                   `json:"tahun_akhir_periode"`
+	TahunAwalPeriode  string                   `json:"tahun_awal_periode" validate:"omitempty,tahun"`
+	TahunAkhirPeriode string                   `json:"tahun_akhir_periode" validate:"omitempty,tahun"`
 	JenisPeriode      string                   `json:"jenis_periode"`
-	Indikator         []IndikatorUpdateRequest `json:"indikator"`
+	Indikator         []IndikatorUpdateRequest `json:"indikator" validate:"dive"`
 }
 
 type IndikatorUpdateRequest struct {
 	Id               string                `json:"id"`
 	TujuanPemdaId    string                `json:"tujuan_pemda_id"`
-	Indikator        string                `json:"indikator"`
+	Indikator        string                `json:"indikator" validate:"required"`
 	RumusPerhitungan string                `json:"rumus_perhitungan"`
 	SumberData       string                `json:"sumber_data"`
-	Target           []TargetUpdateRequest `json:"target"`
+	Target           []TargetUpdateRequest `json:"target" validate:"dive"`
 }
 
 type TargetUpdateRequest struct {
 	Id     string `json:"id"`
 	Target string `json:"target"`
 	Satuan string `json:"satuan"`
-	Tahun  string `json:"tahun"`
+	Tahun  string `json:"tahun" validate:"omitempty,tahun"`
 }
@@ -6,5 +6,6 @@ type UrusanResponse struct {
 	Id           string                                      `json:"id"`
 	KodeUrusan   string                                      `json:"kode_urusan"`
 	NamaUrusan   string                                      `json:"nama_urusan"`
+	IsDeprecated bool                                        `json:"is_deprecated"`
 	BidangUrusan []bidangurusanresponse.BidangUrusanResponse `json:"bidang_urusan"`
 }
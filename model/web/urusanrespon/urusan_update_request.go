@@ -2,6 +2,6 @@ package urusanrespon
 
 type UrusanUpdateRequest struct {
 	Id         string `json:"id"`
-	KodeUrusan string `json:"kode_urusan"`
-	NamaUrusan string `json:"nama_urusan"`
+	KodeUrusan string `json:"kode_urusan" validate:"required"`
+	NamaUrusan string `json:"nama_urusan" validate:"required"`
 }
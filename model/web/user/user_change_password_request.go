@@ -0,0 +1,7 @@
+package user
+
+type UserChangePasswordRequest struct {
+	PasswordLama       string `json:"password_lama" validate:"required"`
+	PasswordBaru       string `json:"password_baru" validate:"required"`
+	KonfirmasiPassword string `json:"konfirmasi_password" validate:"required"`
+}
@@ -1,11 +1,11 @@
 package user
 
 type UserCreateRequest struct {
-	Nip      string                  `json:"nip"`
-	Email    string                  `json:"email"`
-	Password string                  `json:"password"`
+	Nip      string                  `json:"nip" validate:"required"`
+	Email    string                  `json:"email" validate:"omitempty,email"`
+	Password string                  `json:"password" validate:"required"`
 	IsActive bool                    `json:"is_active"`
-	Role     []UserRoleCreateRequest `json:"role"`
+	Role     []UserRoleCreateRequest `json:"role" validate:"required,min=1,dive"`
 }
 
 type UserRoleCreateRequest struct {
@@ -1,6 +1,8 @@
 package user
 
 type UserLoginRequest struct {
-	Username string `json:"username"`
-	Password string `json:"password"`
+	Username string `json:"username" validate:"required"`
+	Password string `json:"password" validate:"required"`
+	// diisi controller dari request, untuk pembatasan percobaan login per IP
+	ClientIp string `json:"-"`
 }
@@ -0,0 +1,5 @@
+package user
+
+type UserLogoutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
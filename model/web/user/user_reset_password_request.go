@@ -0,0 +1,7 @@
+package user
+
+type UserResetPasswordConfirmRequest struct {
+	Token              string `json:"token" validate:"required"`
+	PasswordBaru       string `json:"password_baru" validate:"required"`
+	KonfirmasiPassword string `json:"konfirmasi_password" validate:"required"`
+}
@@ -0,0 +1,10 @@
+package user
+
+import "time"
+
+type UserResetPasswordResponse struct {
+	UserId    int       `json:"user_id"`
+	Nip       string    `json:"nip"`
+	Token     string    `json:"token"`
+	ExpiredAt time.Time `json:"expired_at"`
+}
@@ -1,5 +1,7 @@
 package user
 
+import "time"
+
 type UserResponse struct {
 	Id          int            `json:"id,omitempty"`
 	Nip         string         `json:"nip"`
@@ -10,6 +12,14 @@ type UserResponse struct {
 	IsActive    bool           `json:"is_active"`
 	PegawaiId   string         `json:"pegawai_id,omitempty"`
 	Role        []RoleResponse `json:"role"`
+	// hanya diisi untuk super_admin
+	LoginLockout *LoginLockoutResponse `json:"login_lockout,omitempty"`
+}
+
+type LoginLockoutResponse struct {
+	Failures    int        `json:"failures"`
+	IsLocked    bool       `json:"is_locked"`
+	LockedUntil *time.Time `json:"locked_until"`
 }
 
 type CekAdminOpdResponse struct {
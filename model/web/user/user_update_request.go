@@ -2,11 +2,11 @@ package user
 
 type UserUpdateRequest struct {
 	Id       int                     `json:"id"`
-	Nip      string                  `json:"nip"`
-	Email    string                  `json:"email"`
+	Nip      string                  `json:"nip" validate:"required"`
+	Email    string                  `json:"email" validate:"omitempty,email"`
 	Password string                  `json:"password"`
 	IsActive bool                    `json:"is_active"`
-	Role     []UserRoleUpdateRequest `json:"role"`
+	Role     []UserRoleUpdateRequest `json:"role" validate:"dive"`
 }
 
 type UserRoleUpdateRequest struct {
@@ -0,0 +1,26 @@
+package usulan
+
+// UsulanImportResponse laporan import usulan, baris ditolak tidak ikut disimpan
+type UsulanImportResponse struct {
+	Jenis          string              `json:"jenis"`
+	Tahun          string              `json:"tahun"`
+	JumlahBaris    int                 `json:"jumlah_baris"`
+	JumlahDiterima int                 `json:"jumlah_diterima"`
+	JumlahDitolak  int                 `json:"jumlah_ditolak"`
+	Disimpan       bool                `json:"disimpan"`
+	Baris          []UsulanImportBaris `json:"baris"`
+}
+
+// UsulanImportBaris Baris mengikuti nomor baris di file, Id terisi setelah disimpan
+type UsulanImportBaris struct {
+	Baris   int      `json:"baris"`
+	Status  string   `json:"status"`
+	Alasan  []string `json:"alasan"`
+	Id      string   `json:"id,omitempty"`
+	Usulan  string   `json:"usulan"`
+	Alamat  string   `json:"alamat"`
+	Uraian  string   `json:"uraian"`
+	Tahun   string   `json:"tahun"`
+	NamaOpd string   `json:"nama_opd"`
+	KodeOpd string   `json:"kode_opd"`
+}
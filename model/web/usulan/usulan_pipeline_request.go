@@ -0,0 +1,8 @@
+package usulan
+
+type UsulanTransisiRequest struct {
+	JenisUsulan string `json:"-"`
+	UsulanId    string `json:"-"`
+	Aksi        string `json:"aksi" validate:"required"`
+	Alasan      string `json:"alasan"`
+}
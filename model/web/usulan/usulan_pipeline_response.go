@@ -0,0 +1,73 @@
+package usulan
+
+import "time"
+
+type UsulanPipelineResponse struct {
+	JenisUsulan        string                         `json:"jenis_usulan"`
+	Id                 string                         `json:"id"`
+	Usulan             string                         `json:"usulan"`
+	Uraian             string                         `json:"uraian"`
+	Alamat             string                         `json:"alamat,omitempty"`
+	Tahun              string                         `json:"tahun"`
+	KodeOpd            string                         `json:"kode_opd"`
+	NamaOpd            string                         `json:"nama_opd"`
+	RekinId            string                         `json:"rencana_kinerja_id"`
+	NamaRencanaKinerja string                         `json:"nama_rencana_kinerja"`
+	Status             string                         `json:"status"`
+	Tahap              string                         `json:"tahap"`
+	AlasanTahap        string                         `json:"alasan_tahap,omitempty"`
+	SubKegiatan        []UsulanSubKegiatanResponse    `json:"subkegiatan"`
+	TotalPagu          int64                          `json:"total_pagu"`
+	AksiTersedia       []UsulanAturanTransisiResponse `json:"aksi_tersedia,omitempty"`
+	Riwayat            []UsulanTahapRiwayatResponse   `json:"riwayat,omitempty"`
+}
+
+type UsulanSubKegiatanResponse struct {
+	KodeSubKegiatan string `json:"kode_subkegiatan"`
+	NamaSubKegiatan string `json:"nama_subkegiatan"`
+	Pagu            int64  `json:"pagu"`
+}
+
+type UsulanAturanTransisiResponse struct {
+	Aksi        string   `json:"aksi"`
+	DariTahap   string   `json:"dari_tahap"`
+	KeTahap     string   `json:"ke_tahap"`
+	Roles       []string `json:"roles"`
+	WajibAlasan bool     `json:"wajib_alasan"`
+	Syarat      string   `json:"syarat,omitempty"`
+}
+
+type UsulanTahapRiwayatResponse struct {
+	Id          int64     `json:"id"`
+	JenisUsulan string    `json:"jenis_usulan"`
+	UsulanId    string    `json:"usulan_id"`
+	DariTahap   string    `json:"dari_tahap"`
+	KeTahap     string    `json:"ke_tahap"`
+	Aksi        string    `json:"aksi"`
+	Alasan      string    `json:"alasan,omitempty"`
+	ActorNip    string    `json:"actor_nip"`
+	ActorRole   string    `json:"actor_role"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+// UsulanRekapResponse berapa usulan yang masuk renja dan berapa pagunya, pagu dihitung sekali per subkegiatan
+type UsulanRekapResponse struct {
+	KodeOpd          string                           `json:"kode_opd"`
+	Tahun            string                           `json:"tahun"`
+	JenisUsulan      string                           `json:"jenis_usulan"`
+	JenisPagu        string                           `json:"jenis_pagu"`
+	JumlahUsulan     int                              `json:"jumlah_usulan"`
+	PerTahap         map[string]int                   `json:"per_tahap"`
+	JumlahDiakomodir int                              `json:"jumlah_diakomodir"`
+	JumlahMasukRenja int                              `json:"jumlah_masuk_renja"`
+	JumlahDidanai    int                              `json:"jumlah_didanai"`
+	TotalPagu        int64                            `json:"total_pagu"`
+	SubKegiatan      []UsulanRekapSubKegiatanResponse `json:"subkegiatan"`
+}
+
+type UsulanRekapSubKegiatanResponse struct {
+	KodeSubKegiatan string `json:"kode_subkegiatan"`
+	NamaSubKegiatan string `json:"nama_subkegiatan"`
+	Pagu            int64  `json:"pagu"`
+	JumlahUsulan    int    `json:"jumlah_usulan"`
+}
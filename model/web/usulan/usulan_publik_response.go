@@ -0,0 +1,38 @@
+package usulan
+
+import "time"
+
+// UsulanPublikResponse usulan untuk halaman transparansi, tanpa id rekin maupun data pegawai
+type UsulanPublikResponse struct {
+	JenisUsulan        string                        `json:"jenis_usulan"`
+	Id                 string                        `json:"id"`
+	Usulan             string                        `json:"usulan"`
+	Uraian             string                        `json:"uraian"`
+	Alamat             string                        `json:"alamat"`
+	Tahun              string                        `json:"tahun"`
+	KodeOpd            string                        `json:"kode_opd"`
+	NamaOpd            string                        `json:"nama_opd"`
+	Status             string                        `json:"status"`
+	KeteranganStatus   string                        `json:"keterangan_status"`
+	AlasanDitolak      string                        `json:"alasan_ditolak,omitempty"`
+	Diakomodir         bool                          `json:"diakomodir"`
+	NamaRencanaKinerja string                        `json:"nama_rencana_kinerja,omitempty"`
+	SubKegiatan        []UsulanSubKegiatanResponse   `json:"subkegiatan"`
+	TotalPagu          int64                         `json:"total_pagu"`
+	TanggalUsulan      time.Time                     `json:"tanggal_usulan"`
+	Riwayat            []UsulanPublikRiwayatResponse `json:"riwayat,omitempty"`
+}
+
+// UsulanPublikListResponse satu halaman usulan publik, Total jumlah seluruh usulan sesuai filter
+type UsulanPublikListResponse struct {
+	Total  int                    `json:"total"`
+	Limit  int                    `json:"limit"`
+	Offset int                    `json:"offset"`
+	Usulan []UsulanPublikResponse `json:"usulan"`
+}
+
+type UsulanPublikRiwayatResponse struct {
+	Status           string    `json:"status"`
+	KeteranganStatus string    `json:"keterangan_status"`
+	Tanggal          time.Time `json:"tanggal"`
+}
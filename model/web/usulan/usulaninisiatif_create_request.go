@@ -1,10 +1,10 @@
 package usulan
 
 type UsulanInisiatifCreateRequest struct {
-	Usulan    string `json:"usulan"`
+	Usulan    string `json:"usulan" validate:"required"`
 	Manfaat   string `json:"manfaat"`
 	Uraian    string `json:"uraian"`
-	Tahun     string `json:"tahun"`
+	Tahun     string `json:"tahun" validate:"required,tahun_periode"`
 	RekinId   string `json:"rencana_kinerja_id"`
 	PegawaiId string `json:"pegawai_id"`
 	KodeOpd   string `json:"kode_opd"`
@@ -2,10 +2,10 @@ package usulan
 
 type UsulanInisiatifUpdateRequest struct {
 	Id        string `json:"id"`
-	Usulan    string `json:"usulan"`
+	Usulan    string `json:"usulan" validate:"required"`
 	Manfaat   string `json:"manfaat"`
 	Uraian    string `json:"uraian"`
-	Tahun     string `json:"tahun"`
+	Tahun     string `json:"tahun" validate:"required,tahun_periode"`
 	PegawaiId string `json:"pegawai_id"`
 	KodeOpd   string `json:"kode_opd"`
 	Status    string `json:"status"`
@@ -1,12 +1,12 @@
 package usulan
 
 type UsulanMusrebangCreateRequest struct {
-	Usulan  string `json:"usulan"`
+	Usulan  string `json:"usulan" validate:"required"`
 	Alamat  string `json:"alamat"`
 	Uraian  string `json:"uraian"`
-	Tahun   string `json:"tahun"`
+	Tahun   string `json:"tahun" validate:"required,tahun_periode"`
 	RekinId string `json:"rencana_kinerja_id"`
-	KodeOpd string `json:"kode_opd"`
+	KodeOpd string `json:"kode_opd" validate:"required"`
 	Status  string `json:"status"`
 }
 
@@ -2,10 +2,10 @@ package usulan
 
 type UsulanMusrebangUpdateRequest struct {
 	Id      string `json:"id"`
-	Usulan  string `json:"usulan"`
+	Usulan  string `json:"usulan" validate:"required"`
 	Alamat  string `json:"alamat"`
 	Uraian  string `json:"uraian"`
-	Tahun   string `json:"tahun"`
+	Tahun   string `json:"tahun" validate:"required,tahun_periode"`
 	KodeOpd string `json:"kode_opd"`
 	Status  string `json:"status"`
 }
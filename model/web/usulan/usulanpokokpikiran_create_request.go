@@ -1,13 +1,13 @@
 package usulan
 
 type UsulanPokokPikiranCreateRequest struct {
-	Usulan    string `json:"usulan"`
+	Usulan    string `json:"usulan" validate:"required"`
 	Alamat    string `json:"alamat"`
 	Uraian    string `json:"uraian"`
-	Tahun     string `json:"tahun"`
+	Tahun     string `json:"tahun" validate:"required,tahun_periode"`
 	RekinId   string `json:"rencana_kinerja_id"`
 	PegawaiId string `json:"pegawai_id"`
-	KodeOpd   string `json:"kode_opd"`
+	KodeOpd   string `json:"kode_opd" validate:"required"`
 	Status    string `json:"status"`
 }
 
@@ -1,10 +1,10 @@
 package usulan
 
 type UsulanTerpilihCreateRequest struct {
-	JenisUsulan string `json:"jenis_usulan"`
-	UsulanId    string `json:"usulan_id"`
-	RekinId     string `json:"rekin_id"`
-	Tahun       string `json:"tahun"`
+	JenisUsulan string `json:"jenis_usulan" validate:"required"`
+	UsulanId    string `json:"usulan_id" validate:"required"`
+	RekinId     string `json:"rekin_id" validate:"required"`
+	Tahun       string `json:"tahun" validate:"omitempty,tahun"`
 	KodeOpd     string `json:"kode_opd"`
 	Keterangan  string `json:"keterangan"`
 }
@@ -2,11 +2,11 @@ package visimisipemda
 
 type MisiPemdaUpdateRequest struct {
 	Id                int    `json:"id"`
-	IdVisi            int    `json:"id_visi"`
-	Misi              string `json:"misi"`
+	IdVisi            int    `json:"id_visi" validate:"required"`
+	Misi              string `json:"misi" validate:"required"`
 	Urutan            int    `json:"urutan"`
-	TahunAwalPeriode  string `json:"tahun_awal_periode"`
-	TahunAkhirPeriode string `json:"tahun_akhir_periode"`
+	TahunAwalPeriode  string `json:"tahun_awal_periode" validate:"omitempty,tahun"`
+	TahunAkhirPeriode string `json:"tahun_akhir_periode" validate:"omitempty,tahun"`
 	JenisPeriode      string `json:"jenis_periode"`
 	Keterangan        string `json:"keterangan"`
 }
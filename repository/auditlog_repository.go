@@ -0,0 +1,13 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type AuditLogRepository interface {
+	Create(ctx context.Context, tx *sql.Tx, auditLog domain.AuditLog) error
+	FindByEntity(ctx context.Context, tx *sql.Tx, entityType string, entityId string) ([]domain.AuditLog, error)
+	FindByOpd(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.AuditLog, error)
+}
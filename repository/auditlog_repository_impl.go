@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+)
+
+type AuditLogRepositoryImpl struct{}
+
+func NewAuditLogRepositoryImpl() *AuditLogRepositoryImpl {
+	return &AuditLogRepositoryImpl{}
+}
+
+const auditLogColumns = `id, entity_type, entity_id, action, kode_opd, tahun,
+		actor_user_id, actor_nip, actor_kode_opd, before_data, after_data, created_at`
+
+func (repository *AuditLogRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, auditLog domain.AuditLog) error {
+	script := `
+		INSERT INTO tb_audit_log
+			(entity_type, entity_id, action, kode_opd, tahun, actor_user_id, actor_nip, actor_kode_opd, before_data, after_data)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	_, err := tx.ExecContext(ctx, script,
+		auditLog.EntityType,
+		auditLog.EntityId,
+		auditLog.Action,
+		auditLog.KodeOpd,
+		auditLog.Tahun,
+		auditLog.ActorUserId,
+		auditLog.ActorNip,
+		auditLog.ActorKodeOpd,
+		auditLog.BeforeData,
+		auditLog.AfterData,
+	)
+	if err != nil {
+		return fmt.Errorf("AuditLogRepository.Create: %w", err)
+	}
+	return nil
+}
+
+func (repository *AuditLogRepositoryImpl) FindByEntity(ctx context.Context, tx *sql.Tx, entityType string, entityId string) ([]domain.AuditLog, error) {
+	script := "SELECT " + auditLogColumns + " FROM tb_audit_log WHERE entity_type = ? AND entity_id = ? ORDER BY id DESC"
+	return repository.query(ctx, tx, script, entityType, entityId)
+}
+
+func (repository *AuditLogRepositoryImpl) FindByOpd(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.AuditLog, error) {
+	script := "SELECT " + auditLogColumns + " FROM tb_audit_log WHERE kode_opd = ? AND tahun = ? ORDER BY id DESC"
+	return repository.query(ctx, tx, script, kodeOpd, tahun)
+}
+
+func (repository *AuditLogRepositoryImpl) query(ctx context.Context, tx *sql.Tx, script string, args ...interface{}) ([]domain.AuditLog, error) {
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var auditLogs []domain.AuditLog
+	for rows.Next() {
+		var auditLog domain.AuditLog
+		err := rows.Scan(
+			&auditLog.Id,
+			&auditLog.EntityType,
+			&auditLog.EntityId,
+			&auditLog.Action,
+			&auditLog.KodeOpd,
+			&auditLog.Tahun,
+			&auditLog.ActorUserId,
+			&auditLog.ActorNip,
+			&auditLog.ActorKodeOpd,
+			&auditLog.BeforeData,
+			&auditLog.AfterData,
+			&auditLog.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	return auditLogs, rows.Err()
+}
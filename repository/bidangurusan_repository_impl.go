@@ -56,7 +56,7 @@ func (repository *BidangUrusanRepositoryImpl) FindById(ctx context.Context, tx *
 }
 
 func (repository *BidangUrusanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.BidangUrusan, error) {
-	script := "SELECT id, kode_bidang_urusan, nama_bidang_urusan, tahun FROM tb_bidang_urusan"
+	script := "SELECT id, kode_bidang_urusan, nama_bidang_urusan, tahun, is_deprecated FROM tb_bidang_urusan"
 	rows, err := tx.QueryContext(ctx, script)
 	if err != nil {
 		return []domainmaster.BidangUrusan{}, err
@@ -66,7 +66,7 @@ func (repository *BidangUrusanRepositoryImpl) FindAll(ctx context.Context, tx *s
 	var bidangurusans []domainmaster.BidangUrusan
 	for rows.Next() {
 		bidangurusan := domainmaster.BidangUrusan{}
-		rows.Scan(&bidangurusan.Id, &bidangurusan.KodeBidangUrusan, &bidangurusan.NamaBidangUrusan, &bidangurusan.Tahun)
+		rows.Scan(&bidangurusan.Id, &bidangurusan.KodeBidangUrusan, &bidangurusan.NamaBidangUrusan, &bidangurusan.Tahun, &bidangurusan.IsDeprecated)
 		bidangurusans = append(bidangurusans, bidangurusan)
 	}
 	return bidangurusans, nil
@@ -109,7 +109,7 @@ func (repository *BidangUrusanRepositoryImpl) FindByKodeOpd(ctx context.Context,
 	}
 
 	// Membuat query dengan IN clause
-	query := "SELECT id, kode_bidang_urusan, nama_bidang_urusan FROM tb_bidang_urusan WHERE kode_bidang_urusan IN ("
+	query := "SELECT id, kode_bidang_urusan, nama_bidang_urusan, is_deprecated FROM tb_bidang_urusan WHERE kode_bidang_urusan IN ("
 	params := make([]interface{}, len(kodeBidangUrusans))
 	for i := range kodeBidangUrusans {
 		if i > 0 {
@@ -129,7 +129,7 @@ func (repository *BidangUrusanRepositoryImpl) FindByKodeOpd(ctx context.Context,
 	var bidangUrusans []domainmaster.BidangUrusan
 	for rows.Next() {
 		bidangUrusan := domainmaster.BidangUrusan{}
-		err := rows.Scan(&bidangUrusan.Id, &bidangUrusan.KodeBidangUrusan, &bidangUrusan.NamaBidangUrusan)
+		err := rows.Scan(&bidangUrusan.Id, &bidangUrusan.KodeBidangUrusan, &bidangUrusan.NamaBidangUrusan, &bidangUrusan.IsDeprecated)
 		if err != nil {
 			return nil, err
 		}
@@ -241,7 +241,7 @@ func (repository *BidangUrusanRepositoryImpl) FindBidangUrusanTerpilihByKodeOpd(
 	script := `
 		SELECT 
 			but.id, but.kode_opd, but.kode_bidang_urusan, 
-			bu.nama_bidang_urusan
+			bu.nama_bidang_urusan, COALESCE(bu.is_deprecated, 0)
 		FROM tb_bidangurusan_terpilih but
 		LEFT JOIN tb_bidang_urusan bu ON but.kode_bidang_urusan = bu.kode_bidang_urusan
 		WHERE but.kode_opd = ?
@@ -255,7 +255,7 @@ func (repository *BidangUrusanRepositoryImpl) FindBidangUrusanTerpilihByKodeOpd(
 	var results []domainmaster.BidangUrusanOpd
 	for rows.Next() {
 		var res domainmaster.BidangUrusanOpd
-		err := rows.Scan(&res.Id, &res.KodeOpd, &res.KodeBidangUrusan, &res.NamaBidangUrusan)
+		err := rows.Scan(&res.Id, &res.KodeOpd, &res.KodeBidangUrusan, &res.NamaBidangUrusan, &res.IsDeprecated)
 		if err != nil {
 			return nil, err
 		}
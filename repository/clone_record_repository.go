@@ -4,10 +4,15 @@ import (
 	"context"
 	"database/sql"
 	"ekak_kabupaten_madiun/model/domain"
+	"time"
 )
 
 type CloneRecordRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, cloneRecord domain.CloneRecord) (domain.CloneRecord, error)
-	GetCloneByKodeOpdTahunSumberTahunTujuan(ctx context.Context, tx *sql.Tx, kodeOpd string, tahunSumber string, tahunTujuan string) (domain.CloneRecord, error)
+	GetCloneByKodeOpdTahunSumberTahunTujuan(ctx context.Context, tx *sql.Tx, jenis string, kodeOpd string, tahunSumber string, tahunTujuan string) (domain.CloneRecord, error)
 	UpdateStatus(ctx context.Context, tx *sql.Tx, id int, status string, errMsg string) error
+	UpdateProgres(ctx context.Context, tx *sql.Tx, cloneRecord domain.CloneRecord) error
+	FindByJobId(ctx context.Context, tx *sql.Tx, jobId string) (domain.CloneRecord, error)
+	// MarkFailedIfStale menandai FAILED record PENDING/PROCESS yang tidak diperbarui lebih dari batas, true jika berubah
+	MarkFailedIfStale(ctx context.Context, tx *sql.Tx, id int, batas time.Duration, errMsg string) (bool, error)
 }
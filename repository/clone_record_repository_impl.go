@@ -29,8 +29,10 @@ func (r *CloneRecordRepositoryImpl) Create(
 			tahun_asal,
 			tahun_target,
 			keterangan_tahun_clone,
-			updated_by
-		) VALUES (?, ?, ?, ?, ?, ?)
+			updated_by,
+			jenis,
+			job_id
+		) VALUES (?, ?, ?, ?, ?, ?, ?, ?)
 	`
 
 	result, err := tx.ExecContext(
@@ -42,6 +44,8 @@ func (r *CloneRecordRepositoryImpl) Create(
 		cloneRecord.TahunTarget,
 		cloneRecord.KeteranganTahunClone,
 		cloneRecord.UpdatedBy,
+		cloneRecord.Jenis,
+		cloneRecord.JobId,
 	)
 	if err != nil {
 		return cloneRecord, err
@@ -61,7 +65,9 @@ func (r *CloneRecordRepositoryImpl) Create(
 
 func (r *CloneRecordRepositoryImpl) GetCloneByKodeOpdTahunSumberTahunTujuan(
 	ctx context.Context,
-	tx *sql.Tx, kodeOpd string,
+	tx *sql.Tx,
+	jenis string,
+	kodeOpd string,
 	tahunSumber string,
 	tahunTujuan string,
 ) (domain.CloneRecord, error) {
@@ -76,16 +82,18 @@ func (r *CloneRecordRepositoryImpl) GetCloneByKodeOpdTahunSumberTahunTujuan(
                         status,
 			created_at,
 			updated_at,
-			updated_by
+			updated_by,
+			COALESCE(job_id, '')
 		FROM clone_record
-		WHERE kode_opd = ?
+		WHERE jenis = ?
+		AND kode_opd = ?
 		AND tahun_asal = ?
 		AND tahun_target = ?
                 ORDER BY id DESC
 		LIMIT 1
 	`
 
-	rows, err := tx.QueryContext(ctx, query, kodeOpd, tahunSumber, tahunTujuan)
+	rows, err := tx.QueryContext(ctx, query, jenis, kodeOpd, tahunSumber, tahunTujuan)
 	if err != nil {
 		return domain.CloneRecord{}, err
 	}
@@ -104,6 +112,7 @@ func (r *CloneRecordRepositoryImpl) GetCloneByKodeOpdTahunSumberTahunTujuan(
 			&cloneRecord.CreatedAt,
 			&cloneRecord.UpdatedAt,
 			&cloneRecord.UpdatedBy,
+			&cloneRecord.JobId,
 		)
 		if err != nil {
 			return cloneRecord, err
@@ -131,3 +140,93 @@ func (r *CloneRecordRepositoryImpl) UpdateStatus(
 	_, err := tx.ExecContext(ctx, query, status, errMsg, id)
 	return err
 }
+
+func (r *CloneRecordRepositoryImpl) UpdateProgres(
+	ctx context.Context,
+	tx *sql.Tx,
+	cloneRecord domain.CloneRecord,
+) error {
+
+	query := `
+		UPDATE clone_record
+		SET status = ?, error_message = ?, progres = ?, warnings = ?
+		WHERE id = ?
+	`
+
+	_, err := tx.ExecContext(ctx, query, cloneRecord.Status, cloneRecord.ErrorMessage, cloneRecord.Progres, cloneRecord.Warnings, cloneRecord.Id)
+	return err
+}
+
+func (r *CloneRecordRepositoryImpl) FindByJobId(
+	ctx context.Context,
+	tx *sql.Tx,
+	jobId string,
+) (domain.CloneRecord, error) {
+
+	query := `
+		SELECT
+			id,
+			kode_clone,
+			kode_opd,
+			tahun_asal,
+			tahun_target,
+			COALESCE(status, ''),
+			COALESCE(error_message, ''),
+			created_at,
+			updated_at,
+			COALESCE(updated_by, ''),
+			jenis,
+			job_id,
+			COALESCE(progres, ''),
+			COALESCE(warnings, '')
+		FROM clone_record
+		WHERE job_id = ?
+	`
+
+	cloneRecord := domain.CloneRecord{}
+	err := tx.QueryRowContext(ctx, query, jobId).Scan(
+		&cloneRecord.Id,
+		&cloneRecord.KodeClone,
+		&cloneRecord.KodeOpd,
+		&cloneRecord.TahunAsal,
+		&cloneRecord.TahunTarget,
+		&cloneRecord.Status,
+		&cloneRecord.ErrorMessage,
+		&cloneRecord.CreatedAt,
+		&cloneRecord.UpdatedAt,
+		&cloneRecord.UpdatedBy,
+		&cloneRecord.Jenis,
+		&cloneRecord.JobId,
+		&cloneRecord.Progres,
+		&cloneRecord.Warnings,
+	)
+	return cloneRecord, err
+}
+
+func (r *CloneRecordRepositoryImpl) MarkFailedIfStale(
+	ctx context.Context,
+	tx *sql.Tx,
+	id int,
+	batas time.Duration,
+	errMsg string,
+) (bool, error) {
+
+	// umur dihitung di database agar tidak bergantung zona waktu koneksi
+	query := `
+		UPDATE clone_record
+		SET status = ?, error_message = ?
+		WHERE id = ?
+		AND status IN (?, ?)
+		AND updated_at < NOW() - INTERVAL ? SECOND
+	`
+
+	result, err := tx.ExecContext(ctx, query, domain.CloneStatusFailed, errMsg, id, domain.CloneStatusPending, domain.CloneStatusProcess, int(batas.Seconds()))
+	if err != nil {
+		return false, err
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
@@ -15,20 +15,21 @@ type CrosscuttingOpdRepository interface {
 	ValidateKodeOpdChange(ctx context.Context, tx *sql.Tx, id int) error
 	FindTargetByIndikatorIds(ctx context.Context, tx *sql.Tx, indikatorIds []string) ([]domain.Target, error)
 	FindIndikatorByPokinId(ctx context.Context, tx *sql.Tx, pokinIds []int) ([]domain.Indikator, error)
-	ApproveOrRejectCrosscutting(ctx context.Context, tx *sql.Tx, crosscuttingId int, request pohonkinerja.CrosscuttingApproveRequest) error
+	// ApproveOrRejectCrosscutting mengembalikan id pohon kinerja yang statusnya harus dipindah lewat workflow, 0 jika tidak ada
+	ApproveOrRejectCrosscutting(ctx context.Context, tx *sql.Tx, crosscuttingId int, request pohonkinerja.CrosscuttingApproveRequest) (int, error)
 	DeleteUnused(ctx context.Context, tx *sql.Tx, crosscuttingId int) error
 	FindPokinByCrosscuttingStatus(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.Crosscutting, error)
 	FindOPDCrosscuttingFrom(ctx context.Context, tx *sql.Tx, crosscuttingTo int) (string, error)
+	FindCrosscuttingById(ctx context.Context, tx *sql.Tx, crosscuttingId int) (domain.Crosscutting, error)
 	// DeleteCrosscuttingExisting(ctx context.Context, tx *sql.Tx, crosscuttingId int) error
 	FindCrosscuttingByPokinIdsBatch(ctx context.Context, tx *sql.Tx, pokinIds []int) (map[int][]domain.Crosscutting, error)
 	FindCrosscuttingFromByPokinIdsBatch(ctx context.Context, tx *sql.Tx, pokinIds []int) (map[int][]domain.Crosscutting, error)
 
-	//crosscutting legacy untuk delete
-	FixPokinStatusAfterExistingUnlink(ctx context.Context, tx *sql.Tx, pokinId int) error
-	FixPokinStatusAfterExistingDelete(ctx context.Context, tx *sql.Tx, pokinId int) error
-
 	// Plan A: jika ref tunggal → hapus pohon kinerja + child
 	DeleteCrosscuttingDiterima(ctx context.Context, tx *sql.Tx, crosscuttingId int) error
 	// Plan B: jika ref tunggal → hanya lepas tautan, pohon kinerja tidak dihapus
 	UnlinkCrosscuttingDiterima(ctx context.Context, tx *sql.Tx, crosscuttingId int) error
+
+	// cek apakah dua OPD terhubung crosscutting yang sudah disetujui (tahun kosong = semua tahun)
+	IsCrosscuttingDisetujuiAntarOpd(ctx context.Context, tx *sql.Tx, kodeOpdA, kodeOpdB, tahun string) (bool, error)
 }
@@ -429,7 +429,7 @@ func (repository *CrosscuttingOpdRepositoryImpl) cleanOutgoingAndDeletePokin(
 	return nil
 }
 
-func (repository *CrosscuttingOpdRepositoryImpl) ApproveOrRejectCrosscutting(ctx context.Context, tx *sql.Tx, crosscuttingId int, request pohonkinerja.CrosscuttingApproveRequest) error {
+func (repository *CrosscuttingOpdRepositoryImpl) ApproveOrRejectCrosscutting(ctx context.Context, tx *sql.Tx, crosscuttingId int, request pohonkinerja.CrosscuttingApproveRequest) (int, error) {
 	var currentStatus, keterangan, kodeOpd, tahun string
 	var crosscuttingTo int
 	err := tx.QueryRowContext(ctx, `
@@ -437,10 +437,10 @@ func (repository *CrosscuttingOpdRepositoryImpl) ApproveOrRejectCrosscutting(ctx
 		FROM tb_crosscutting WHERE id = ?`, crosscuttingId).
 		Scan(&currentStatus, &keterangan, &kodeOpd, &tahun, &crosscuttingTo)
 	if err != nil {
-		return fmt.Errorf("error getting crosscutting data: %w", err)
+		return 0, fmt.Errorf("error getting crosscutting data: %w", err)
 	}
 	if currentStatus != "crosscutting_menunggu" && currentStatus != "crosscutting_ditolak" {
-		return errors.New("crosscutting sudah disetujui")
+		return 0, errors.New("crosscutting sudah disetujui")
 	}
 	currentTime := time.Now()
 	var pegawaiAction map[string]interface{}
@@ -451,28 +451,28 @@ func (repository *CrosscuttingOpdRepositoryImpl) ApproveOrRejectCrosscutting(ctx
 	}
 	pegawaiActionJSON, err := json.Marshal(pegawaiAction)
 	if err != nil {
-		return fmt.Errorf("error marshaling pegawai action: %w", err)
+		return 0, fmt.Errorf("error marshaling pegawai action: %w", err)
 	}
 	if request.Approve {
 		if request.CreateNew {
 			// ── Logic 1: Buat pohon kinerja baru ──
-			// Pohon kinerja BARU: status crosscutting_disetujui, TANPA keterangan_crosscutting
-			// (keterangan ada di tb_crosscutting, bukan di pohon kinerja)
+			// Pohon kinerja BARU dibuat crosscutting_menunggu lalu disetujui lewat workflow agar tercatat di riwayat,
+			// TANPA keterangan_crosscutting (keterangan ada di tb_crosscutting, bukan di pohon kinerja)
 			scriptNewPokin := `
 				INSERT INTO tb_pohon_kinerja (
 					nama_pohon, parent, level_pohon, jenis_pohon,
 					kode_opd, tahun, status, pegawai_action, keterangan
-				) VALUES ('', ?, ?, ?, ?, ?, 'crosscutting_disetujui', ?, '')
+				) VALUES ('', ?, ?, ?, ?, ?, ?, ?, '')
 			`
 			result, err := tx.ExecContext(ctx, scriptNewPokin,
 				request.ParentId, request.LevelPohon, request.JenisPohon,
-				kodeOpd, tahun, pegawaiActionJSON)
+				kodeOpd, tahun, domain.StatusPokinCrosscuttingMenunggu, pegawaiActionJSON)
 			if err != nil {
-				return fmt.Errorf("error creating new pohon kinerja: %w", err)
+				return 0, fmt.Errorf("error creating new pohon kinerja: %w", err)
 			}
 			newPokinId, err := result.LastInsertId()
 			if err != nil {
-				return fmt.Errorf("error getting last insert id: %w", err)
+				return 0, fmt.Errorf("error getting last insert id: %w", err)
 			}
 			// Update tb_crosscutting: status + crosscutting_to ke id pohon baru
 			_, err = tx.ExecContext(ctx, `
@@ -481,8 +481,9 @@ func (repository *CrosscuttingOpdRepositoryImpl) ApproveOrRejectCrosscutting(ctx
 				WHERE id = ?
 			`, newPokinId, crosscuttingId)
 			if err != nil {
-				return fmt.Errorf("error updating crosscutting (create new): %w", err)
+				return 0, fmt.Errorf("error updating crosscutting (create new): %w", err)
 			}
+			return int(newPokinId), nil
 		} else if request.UseExisting {
 			// ── Logic 2: Gunakan pohon kinerja yang sudah ada ──
 			// TIDAK ubah tb_pohon_kinerja sama sekali
@@ -493,29 +494,21 @@ func (repository *CrosscuttingOpdRepositoryImpl) ApproveOrRejectCrosscutting(ctx
 				WHERE id = ?
 			`, request.ExistingId, crosscuttingId)
 			if err != nil {
-				return fmt.Errorf("error updating crosscutting (use existing): %w", err)
+				return 0, fmt.Errorf("error updating crosscutting (use existing): %w", err)
 			}
 		}
 	} else {
 		// ── Logic 3: Tolak / balikkan ke menunggu ──
-		// if crosscuttingTo > 0 {
-		// 	_, err = tx.ExecContext(ctx, `
-		// 		UPDATE tb_pohon_kinerja
-		// 		SET status = 'crosscutting_menunggu', pegawai_action = ?
-		// 		WHERE id = ?
-		// 	`, pegawaiActionJSON, crosscuttingTo)
-		// 	if err != nil {
-		// 		return fmt.Errorf("error reverting pohon kinerja status: %w", err)
-		// 	}
-		// }
+		// pohon tujuan (jika sudah ada) ditolak lewat workflow oleh service
 		_, err = tx.ExecContext(ctx, `
 			UPDATE tb_crosscutting SET status = 'crosscutting_ditolak' WHERE id = ?
 		`, crosscuttingId)
 		if err != nil {
-			return fmt.Errorf("error reverting crosscutting status: %w", err)
+			return 0, fmt.Errorf("error reverting crosscutting status: %w", err)
 		}
+		return crosscuttingTo, nil
 	}
-	return nil
+	return 0, nil
 }
 
 func (repository *CrosscuttingOpdRepositoryImpl) DeleteUnused(ctx context.Context, tx *sql.Tx, crosscuttingId int) error {
@@ -599,6 +592,34 @@ func (repository *CrosscuttingOpdRepositoryImpl) FindPokinByCrosscuttingStatus(c
 	return crosscuttings, nil
 }
 
+func (repository *CrosscuttingOpdRepositoryImpl) FindCrosscuttingById(ctx context.Context, tx *sql.Tx, crosscuttingId int) (domain.Crosscutting, error) {
+	script := `
+        SELECT id,
+               COALESCE(crosscutting_from, 0),
+               COALESCE(crosscutting_to, 0),
+               COALESCE(keterangan_crosscutting, ''),
+               COALESCE(kode_opd, ''),
+               COALESCE(status, ''),
+               COALESCE(CAST(tahun AS CHAR), '')
+        FROM tb_crosscutting
+        WHERE id = ?
+    `
+	var crosscutting domain.Crosscutting
+	err := tx.QueryRowContext(ctx, script, crosscuttingId).Scan(
+		&crosscutting.Id,
+		&crosscutting.CrosscuttingFrom,
+		&crosscutting.CrosscuttingTo,
+		&crosscutting.Keterangan,
+		&crosscutting.KodeOpd,
+		&crosscutting.Status,
+		&crosscutting.Tahun,
+	)
+	if err != nil {
+		return domain.Crosscutting{}, err
+	}
+	return crosscutting, nil
+}
+
 func (repository *CrosscuttingOpdRepositoryImpl) FindOPDCrosscuttingFrom(ctx context.Context, tx *sql.Tx, crosscuttingTo int) (string, error) {
 	script := `
         SELECT 
@@ -661,63 +682,6 @@ func (repository *CrosscuttingOpdRepositoryImpl) FindCrosscuttingByPokinIdsBatch
 	return result, rows.Err()
 }
 
-func (repository *CrosscuttingOpdRepositoryImpl) FixPokinStatusAfterExistingUnlink(
-	ctx context.Context,
-	tx *sql.Tx,
-	pokinId int,
-) error {
-	var jenisPohon string
-	err := tx.QueryRowContext(ctx,
-		`SELECT jenis_pohon FROM tb_pohon_kinerja WHERE id = ?`, pokinId,
-	).Scan(&jenisPohon)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil // pohon tidak ditemukan, skip
-		}
-		return fmt.Errorf("FixPokinStatus: gagal ambil jenis_pohon id=%d: %w", pokinId, err)
-	}
-	var newStatus string
-	switch jenisPohon {
-	case "Strategic Pemda", "Tactical Pemda", "Operational Pemda":
-		newStatus = "pokin dari pemda"
-	default:
-		newStatus = ""
-	}
-	_, err = tx.ExecContext(ctx,
-		`UPDATE tb_pohon_kinerja SET status = ? WHERE id = ?`, newStatus, pokinId,
-	)
-	if err != nil {
-		return fmt.Errorf("FixPokinStatus: gagal update status id=%d: %w", pokinId, err)
-	}
-	return nil
-}
-
-func (repository *CrosscuttingOpdRepositoryImpl) FixPokinStatusAfterExistingDelete(
-	ctx context.Context,
-	tx *sql.Tx,
-	pokinId int,
-) error {
-	var jenisPohon string
-	err := tx.QueryRowContext(ctx, `
-		SELECT jenis_pohon FROM tb_pohon_kinerja WHERE id = ?`, pokinId).Scan(&jenisPohon)
-	if err != nil {
-		// Pohon tidak ditemukan → tidak perlu diproses
-		return nil
-	}
-	var newStatus string
-	switch jenisPohon {
-	case "Strategic Pemda", "Tactical Pemda", "Operational Pemda":
-		newStatus = "pokin dari pemda"
-	default:
-		newStatus = "" // String kosong untuk jenis tanpa "Pemda"
-	}
-	if _, err := tx.ExecContext(ctx, `
-		UPDATE tb_pohon_kinerja SET status = ? WHERE id = ?`, newStatus, pokinId); err != nil {
-		return fmt.Errorf("gagal update status pokin id=%d: %w", pokinId, err)
-	}
-	return nil
-}
-
 // DELETE CROSSCUTTING DITERIMA
 func (repository *CrosscuttingOpdRepositoryImpl) DeleteCrosscuttingDiterima(
 	ctx context.Context, tx *sql.Tx, crosscuttingId int,
@@ -1045,3 +1009,24 @@ func (repository *CrosscuttingOpdRepositoryImpl) FindCrosscuttingFromByPokinIdsB
 	}
 	return result, rows.Err()
 }
+
+func (repository *CrosscuttingOpdRepositoryImpl) IsCrosscuttingDisetujuiAntarOpd(ctx context.Context, tx *sql.Tx, kodeOpdA, kodeOpdB, tahun string) (bool, error) {
+	query := `
+        SELECT COUNT(*)
+        FROM tb_crosscutting c
+        JOIN tb_pohon_kinerja p ON p.id = c.crosscutting_from
+        WHERE c.status IN ('crosscutting_disetujui', 'crosscutting_disetujui_existing')
+        AND ((p.kode_opd = ? AND c.kode_opd = ?) OR (p.kode_opd = ? AND c.kode_opd = ?))
+    `
+	args := []interface{}{kodeOpdA, kodeOpdB, kodeOpdB, kodeOpdA}
+	if tahun != "" {
+		query += " AND c.tahun = ?"
+		args = append(args, tahun)
+	}
+
+	var count int
+	if err := tx.QueryRowContext(ctx, query, args...).Scan(&count); err != nil {
+		return false, fmt.Errorf("IsCrosscuttingDisetujuiAntarOpd: %w", err)
+	}
+	return count > 0, nil
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type GeospasialRepository interface {
+	// FindWilayah tingkat dan kodeInduk kosong berarti tanpa filter
+	FindWilayah(ctx context.Context, tx *sql.Tx, tingkat string, kodeInduk string) ([]domain.Wilayah, error)
+	UpsertWilayah(ctx context.Context, tx *sql.Tx, wilayah domain.Wilayah) error
+	// FindLokasiUsulan usulan musrebang dan/atau pokok pikiran, kodeOpd kosong berarti semua OPD
+	FindLokasiUsulan(ctx context.Context, tx *sql.Tx, jenisUsulan []string, kodeOpd string, tahun string) ([]domain.LokasiUsulan, error)
+	FindLokasiUsulanById(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string) (domain.LokasiUsulan, error)
+	UpdateLokasiUsulan(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string, lokasi domain.Lokasi) error
+	// FindIndikatorSpasial indikator rekin beserta flag spasial manual IK-nya
+	FindIndikatorSpasial(ctx context.Context, tx *sql.Tx, indikatorId string) (domain.LokasiIndikator, bool, error)
+	// FindLokasiIndikator lokasi indikator yang manual IK-nya masih ditandai spasial
+	FindLokasiIndikator(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.LokasiIndikator, error)
+	ReplaceLokasiIndikator(ctx context.Context, tx *sql.Tx, indikatorId string, lokasi []domain.LokasiIndikator) error
+}
@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type GeospasialRepositoryImpl struct{}
+
+func NewGeospasialRepositoryImpl() *GeospasialRepositoryImpl {
+	return &GeospasialRepositoryImpl{}
+}
+
+// tabelLokasiUsulan hanya usulan yang memiliki alamat yang bisa diberi lokasi
+var tabelLokasiUsulan = map[string]string{
+	domain.JenisUsulanMusrebang:    "tb_usulan_musrebang",
+	domain.JenisUsulanPokokPikiran: "tb_usulan_pokok_pikiran",
+}
+
+func tabelUsulanBerlokasi(jenisUsulan string) (string, error) {
+	tabel, ok := tabelLokasiUsulan[jenisUsulan]
+	if !ok {
+		return "", fmt.Errorf("usulan %s tidak memiliki lokasi", jenisUsulan)
+	}
+	return tabel, nil
+}
+
+func nullFloat(nilai *float64) sql.NullFloat64 {
+	if nilai == nil {
+		return sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: *nilai, Valid: true}
+}
+
+func nullString(nilai string) sql.NullString {
+	return sql.NullString{String: nilai, Valid: nilai != ""}
+}
+
+func pointerFloat(nilai sql.NullFloat64) *float64 {
+	if !nilai.Valid {
+		return nil
+	}
+	return &nilai.Float64
+}
+
+func (repository *GeospasialRepositoryImpl) FindWilayah(ctx context.Context, tx *sql.Tx, tingkat string, kodeInduk string) ([]domain.Wilayah, error) {
+	script := `SELECT kode_wilayah, nama, tingkat, COALESCE(kode_induk, ''), geometri, latitude, longitude
+		FROM tb_wilayah WHERE 1=1`
+	var args []interface{}
+	if tingkat != "" {
+		script += " AND tingkat = ?"
+		args = append(args, tingkat)
+	}
+	if kodeInduk != "" {
+		script += " AND kode_induk = ?"
+		args = append(args, kodeInduk)
+	}
+	script += " ORDER BY kode_wilayah"
+
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GeospasialRepository.FindWilayah: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.Wilayah
+	for rows.Next() {
+		var wilayah domain.Wilayah
+		var geometri sql.NullString
+		var latitude, longitude sql.NullFloat64
+		if err := rows.Scan(&wilayah.KodeWilayah, &wilayah.Nama, &wilayah.Tingkat, &wilayah.KodeInduk, &geometri, &latitude, &longitude); err != nil {
+			return nil, err
+		}
+		wilayah.Geometri = geometri.String
+		wilayah.Latitude = pointerFloat(latitude)
+		wilayah.Longitude = pointerFloat(longitude)
+		result = append(result, wilayah)
+	}
+	return result, rows.Err()
+}
+
+func (repository *GeospasialRepositoryImpl) UpsertWilayah(ctx context.Context, tx *sql.Tx, wilayah domain.Wilayah) error {
+	_, err := tx.ExecContext(ctx, `
+		INSERT INTO tb_wilayah (kode_wilayah, nama, tingkat, kode_induk, geometri, latitude, longitude)
+		VALUES (?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE nama = VALUES(nama), tingkat = VALUES(tingkat), kode_induk = VALUES(kode_induk),
+			geometri = VALUES(geometri), latitude = VALUES(latitude), longitude = VALUES(longitude)`,
+		wilayah.KodeWilayah, wilayah.Nama, wilayah.Tingkat, wilayah.KodeInduk, nullString(wilayah.Geometri),
+		nullFloat(wilayah.Latitude), nullFloat(wilayah.Longitude))
+	if err != nil {
+		return fmt.Errorf("GeospasialRepository.UpsertWilayah: %w", err)
+	}
+	return nil
+}
+
+func queryLokasiUsulan(jenisUsulan []string, kondisi string, args []interface{}) (string, []interface{}) {
+	var cabang []string
+	var semuaArgs []interface{}
+	// urutan tetap agar hasil UNION stabil
+	for _, jenis := range []string{domain.JenisUsulanMusrebang, domain.JenisUsulanPokokPikiran} {
+		if len(jenisUsulan) > 0 && !slices.Contains(jenisUsulan, jenis) {
+			continue
+		}
+		cabang = append(cabang, fmt.Sprintf(`
+			SELECT '%s' AS jenis_usulan, u.id, COALESCE(u.usulan, ''), COALESCE(u.alamat, ''), COALESCE(u.tahun, ''),
+			       COALESCE(u.kode_opd, ''), COALESCE(o.nama_opd, ''), COALESCE(u.rekin_id, ''), COALESCE(t.tahap, ''),
+			       u.latitude, u.longitude, u.geometri, COALESCE(u.kode_wilayah, '')
+			FROM %s u
+			LEFT JOIN tb_operasional_daerah o ON o.kode_opd = u.kode_opd
+			LEFT JOIN tb_usulan_tahap t ON t.jenis_usulan = '%s' AND t.usulan_id = u.id
+			WHERE %s`, jenis, tabelLokasiUsulan[jenis], jenis, kondisi))
+		semuaArgs = append(semuaArgs, args...)
+	}
+	return strings.Join(cabang, "\n\t\t\tUNION ALL") + "\n\t\t\tORDER BY 1, 2", semuaArgs
+}
+
+func scanLokasiUsulan(rows *sql.Rows) ([]domain.LokasiUsulan, error) {
+	var result []domain.LokasiUsulan
+	for rows.Next() {
+		var usulan domain.LokasiUsulan
+		var latitude, longitude sql.NullFloat64
+		var geometri sql.NullString
+		err := rows.Scan(&usulan.JenisUsulan, &usulan.UsulanId, &usulan.Usulan, &usulan.Alamat, &usulan.Tahun,
+			&usulan.KodeOpd, &usulan.NamaOpd, &usulan.RekinId, &usulan.Tahap,
+			&latitude, &longitude, &geometri, &usulan.Lokasi.KodeWilayah)
+		if err != nil {
+			return nil, err
+		}
+		usulan.Lokasi.Latitude = pointerFloat(latitude)
+		usulan.Lokasi.Longitude = pointerFloat(longitude)
+		usulan.Lokasi.Geometri = geometri.String
+		result = append(result, usulan)
+	}
+	return result, rows.Err()
+}
+
+func (repository *GeospasialRepositoryImpl) FindLokasiUsulan(ctx context.Context, tx *sql.Tx, jenisUsulan []string, kodeOpd string, tahun string) ([]domain.LokasiUsulan, error) {
+	kondisi := "u.tahun = ?"
+	args := []interface{}{tahun}
+	if kodeOpd != "" {
+		kondisi += " AND u.kode_opd = ?"
+		args = append(args, kodeOpd)
+	}
+	script, args := queryLokasiUsulan(jenisUsulan, kondisi, args)
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GeospasialRepository.FindLokasiUsulan: %w", err)
+	}
+	defer rows.Close()
+	return scanLokasiUsulan(rows)
+}
+
+func (repository *GeospasialRepositoryImpl) FindLokasiUsulanById(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string) (domain.LokasiUsulan, error) {
+	if _, err := tabelUsulanBerlokasi(jenisUsulan); err != nil {
+		return domain.LokasiUsulan{}, err
+	}
+	script, args := queryLokasiUsulan([]string{jenisUsulan}, "u.id = ?", []interface{}{usulanId})
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return domain.LokasiUsulan{}, fmt.Errorf("GeospasialRepository.FindLokasiUsulanById: %w", err)
+	}
+	defer rows.Close()
+
+	result, err := scanLokasiUsulan(rows)
+	if err != nil {
+		return domain.LokasiUsulan{}, err
+	}
+	if len(result) == 0 {
+		return domain.LokasiUsulan{}, sql.ErrNoRows
+	}
+	return result[0], nil
+}
+
+func (repository *GeospasialRepositoryImpl) UpdateLokasiUsulan(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string, lokasi domain.Lokasi) error {
+	tabel, err := tabelUsulanBerlokasi(jenisUsulan)
+	if err != nil {
+		return err
+	}
+	script := fmt.Sprintf("UPDATE %s SET latitude = ?, longitude = ?, geometri = ?, kode_wilayah = ? WHERE id = ?", tabel)
+	_, err = tx.ExecContext(ctx, script, nullFloat(lokasi.Latitude), nullFloat(lokasi.Longitude),
+		nullString(lokasi.Geometri), nullString(lokasi.KodeWilayah), usulanId)
+	if err != nil {
+		return fmt.Errorf("GeospasialRepository.UpdateLokasiUsulan: %w", err)
+	}
+	return nil
+}
+
+func (repository *GeospasialRepositoryImpl) FindIndikatorSpasial(ctx context.Context, tx *sql.Tx, indikatorId string) (domain.LokasiIndikator, bool, error) {
+	var indikator domain.LokasiIndikator
+	var spasial bool
+	err := tx.QueryRowContext(ctx, `
+		SELECT i.id, COALESCE(i.indikator, ''), COALESCE(i.rencana_kinerja_id, ''), COALESCE(r.nama_rencana_kinerja, ''),
+		       COALESCE(r.kode_opd, ''), COALESCE(r.tahun, i.tahun, ''), COALESCE(m.spasial, 0)
+		FROM tb_indikator i
+		LEFT JOIN tb_rencana_kinerja r ON r.id = i.rencana_kinerja_id
+		LEFT JOIN tb_manual_ik m ON m.indikator_id = i.id
+		WHERE i.id = ?`, indikatorId).Scan(&indikator.IndikatorId, &indikator.Indikator, &indikator.RekinId,
+		&indikator.NamaRencanaKinerja, &indikator.KodeOpd, &indikator.Tahun, &spasial)
+	if err != nil {
+		return domain.LokasiIndikator{}, false, err
+	}
+	return indikator, spasial, nil
+}
+
+func (repository *GeospasialRepositoryImpl) FindLokasiIndikator(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.LokasiIndikator, error) {
+	script := `
+		SELECT l.id, i.id, COALESCE(i.indikator, ''), r.id, COALESCE(r.nama_rencana_kinerja, ''), COALESCE(r.kode_opd, ''),
+		       COALESCE(r.tahun, ''), COALESCE(l.keterangan, ''), l.latitude, l.longitude, l.geometri, COALESCE(l.kode_wilayah, '')
+		FROM tb_indikator_lokasi l
+		JOIN tb_indikator i ON i.id = l.indikator_id
+		JOIN tb_rencana_kinerja r ON r.id = i.rencana_kinerja_id
+		JOIN tb_manual_ik m ON m.indikator_id = i.id AND m.spasial = 1
+		WHERE r.tahun = ?`
+	args := []interface{}{tahun}
+	if kodeOpd != "" {
+		script += " AND r.kode_opd = ?"
+		args = append(args, kodeOpd)
+	}
+	script += " ORDER BY r.kode_opd, i.id, l.id"
+
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, fmt.Errorf("GeospasialRepository.FindLokasiIndikator: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.LokasiIndikator
+	for rows.Next() {
+		var lokasi domain.LokasiIndikator
+		var latitude, longitude sql.NullFloat64
+		var geometri sql.NullString
+		err := rows.Scan(&lokasi.Id, &lokasi.IndikatorId, &lokasi.Indikator, &lokasi.RekinId, &lokasi.NamaRencanaKinerja,
+			&lokasi.KodeOpd, &lokasi.Tahun, &lokasi.Keterangan, &latitude, &longitude, &geometri, &lokasi.Lokasi.KodeWilayah)
+		if err != nil {
+			return nil, err
+		}
+		lokasi.Lokasi.Latitude = pointerFloat(latitude)
+		lokasi.Lokasi.Longitude = pointerFloat(longitude)
+		lokasi.Lokasi.Geometri = geometri.String
+		result = append(result, lokasi)
+	}
+	return result, rows.Err()
+}
+
+func (repository *GeospasialRepositoryImpl) ReplaceLokasiIndikator(ctx context.Context, tx *sql.Tx, indikatorId string, lokasi []domain.LokasiIndikator) error {
+	if _, err := tx.ExecContext(ctx, "DELETE FROM tb_indikator_lokasi WHERE indikator_id = ?", indikatorId); err != nil {
+		return fmt.Errorf("GeospasialRepository.ReplaceLokasiIndikator: %w", err)
+	}
+	for _, item := range lokasi {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO tb_indikator_lokasi (indikator_id, kode_wilayah, latitude, longitude, geometri, keterangan)
+			VALUES (?, ?, ?, ?, ?, ?)`,
+			indikatorId, nullString(item.Lokasi.KodeWilayah), nullFloat(item.Lokasi.Latitude), nullFloat(item.Lokasi.Longitude),
+			nullString(item.Lokasi.Geometri), item.Keterangan)
+		if err != nil {
+			return fmt.Errorf("GeospasialRepository.ReplaceLokasiIndikator: %w", err)
+		}
+	}
+	return nil
+}
@@ -103,7 +103,7 @@ func (repository *KegiatanRepositoryImpl) FindById(ctx context.Context, tx *sql.
 }
 
 func (repository *KegiatanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.Kegiatan, error) {
-	scriptKegiatan := "SELECT id, nama_kegiatan, kode_kegiatan FROM tb_master_kegiatan"
+	scriptKegiatan := "SELECT id, nama_kegiatan, kode_kegiatan, is_deprecated FROM tb_master_kegiatan"
 	rows, err := tx.QueryContext(ctx, scriptKegiatan)
 	if err != nil {
 		return []domainmaster.Kegiatan{}, err
@@ -112,7 +112,7 @@ func (repository *KegiatanRepositoryImpl) FindAll(ctx context.Context, tx *sql.T
 	var kegiatans []domainmaster.Kegiatan
 	for rows.Next() {
 		var kegiatan domainmaster.Kegiatan
-		rows.Scan(&kegiatan.Id, &kegiatan.NamaKegiatan, &kegiatan.KodeKegiatan)
+		rows.Scan(&kegiatan.Id, &kegiatan.NamaKegiatan, &kegiatan.KodeKegiatan, &kegiatan.IsDeprecated)
 		kegiatans = append(kegiatans, kegiatan)
 	}
 	return kegiatans, nil
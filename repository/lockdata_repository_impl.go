@@ -3,6 +3,7 @@ package repository
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
 	"fmt"
 )
 
@@ -49,3 +50,34 @@ func (r *LockDataRepositoryImpl) Unlock(
 	}
 	return nil
 }
+func (r *LockDataRepositoryImpl) FindAll(
+	ctx context.Context, tx *sql.Tx, kodeOpd, tahun string,
+) ([]domain.LockData, error) {
+	script := `SELECT id, jenis_data, kode_opd, tahun, locked_at FROM tb_lock_data WHERE 1=1`
+	var args []interface{}
+	if kodeOpd != "" {
+		script += ` AND kode_opd=?`
+		args = append(args, kodeOpd)
+	}
+	if tahun != "" {
+		script += ` AND tahun=?`
+		args = append(args, tahun)
+	}
+	script += ` ORDER BY kode_opd, tahun, jenis_data`
+
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, fmt.Errorf("LockDataRepository.FindAll: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.LockData
+	for rows.Next() {
+		var lock domain.LockData
+		if err := rows.Scan(&lock.Id, &lock.JenisData, &lock.KodeOpd, &lock.Tahun, &lock.LockedAt); err != nil {
+			return nil, fmt.Errorf("LockDataRepository.FindAll: %w", err)
+		}
+		result = append(result, lock)
+	}
+	return result, rows.Err()
+}
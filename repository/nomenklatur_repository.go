@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+)
+
+type NomenklaturRepository interface {
+	FindAllMaster(ctx context.Context, tx *sql.Tx, tingkat string) ([]domainmaster.Nomenklatur, error)
+	// FindKodeTerpakai semua kode nomenklatur yang masih dirujuk data transaksi: rekin, subkegiatan
+	// dan bidang urusan terpilih, tujuan OPD, pagu, indikator renstra/cascading dan program unggulan
+	FindKodeTerpakai(ctx context.Context, tx *sql.Tx) ([]string, error)
+	Insert(ctx context.Context, tx *sql.Tx, nomenklatur domainmaster.Nomenklatur) error
+	UpdateNama(ctx context.Context, tx *sql.Tx, tingkat string, kode string, nama string) error
+	UpdateDeprecated(ctx context.Context, tx *sql.Tx, tingkat string, kode string, deprecated bool) error
+	// Delete ikut menghapus indikator dan target master program, kegiatan dan subkegiatan
+	Delete(ctx context.Context, tx *sql.Tx, tingkat string, kode string) error
+	IsVersiExist(ctx context.Context, tx *sql.Tx, versi string) (bool, error)
+	CreateVersi(ctx context.Context, tx *sql.Tx, versi domainmaster.NomenklaturVersi) (domainmaster.NomenklaturVersi, error)
+	CreatePerubahan(ctx context.Context, tx *sql.Tx, versiId int64, perubahan []domainmaster.NomenklaturPerubahan) error
+	FindAllVersi(ctx context.Context, tx *sql.Tx) ([]domainmaster.NomenklaturVersi, error)
+	FindVersiById(ctx context.Context, tx *sql.Tx, id int64) (domainmaster.NomenklaturVersi, error)
+	FindPerubahanByVersiId(ctx context.Context, tx *sql.Tx, versiId int64) ([]domainmaster.NomenklaturPerubahan, error)
+}
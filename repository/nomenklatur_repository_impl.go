@@ -0,0 +1,237 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"fmt"
+)
+
+type NomenklaturRepositoryImpl struct{}
+
+func NewNomenklaturRepositoryImpl() *NomenklaturRepositoryImpl {
+	return &NomenklaturRepositoryImpl{}
+}
+
+// tabelNomenklatur prefix id mengikuti service create masing-masing master,
+// kolomIndikator kosong berarti master tidak punya indikator
+var tabelNomenklatur = map[string]struct {
+	tabel          string
+	kolomKode      string
+	kolomNama      string
+	prefixId       string
+	kolomIndikator string
+}{
+	domainmaster.TingkatNomenklaturUrusan:       {"tb_urusan", "kode_urusan", "nama_urusan", "URU-", ""},
+	domainmaster.TingkatNomenklaturBidangUrusan: {"tb_bidang_urusan", "kode_bidang_urusan", "nama_bidang_urusan", "BID-URU-", ""},
+	domainmaster.TingkatNomenklaturProgram:      {"tb_master_program", "kode_program", "nama_program", "PRGM-", "program_id"},
+	domainmaster.TingkatNomenklaturKegiatan:     {"tb_master_kegiatan", "kode_kegiatan", "nama_kegiatan", "KGT-", "kegiatan_id"},
+	domainmaster.TingkatNomenklaturSubKegiatan:  {"tb_subkegiatan", "kode_subkegiatan", "nama_subkegiatan", "SUB-KEG-", "subkegiatan_id"},
+}
+
+func (repository *NomenklaturRepositoryImpl) FindAllMaster(ctx context.Context, tx *sql.Tx, tingkat string) ([]domainmaster.Nomenklatur, error) {
+	meta, ok := tabelNomenklatur[tingkat]
+	if !ok {
+		return nil, fmt.Errorf("tingkat nomenklatur %s tidak dikenal", tingkat)
+	}
+	script := fmt.Sprintf("SELECT %s, COALESCE(%s, ''), is_deprecated FROM %s ORDER BY %s", meta.kolomKode, meta.kolomNama, meta.tabel, meta.kolomKode)
+	rows, err := tx.QueryContext(ctx, script)
+	if err != nil {
+		return nil, fmt.Errorf("NomenklaturRepository.FindAllMaster: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domainmaster.Nomenklatur
+	for rows.Next() {
+		item := domainmaster.Nomenklatur{Tingkat: tingkat}
+		if err := rows.Scan(&item.Kode, &item.Nama, &item.IsDeprecated); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, rows.Err()
+}
+
+func (repository *NomenklaturRepositoryImpl) FindKodeTerpakai(ctx context.Context, tx *sql.Tx) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT kode_subkegiatan FROM tb_subkegiatan_terpilih WHERE COALESCE(kode_subkegiatan, '') <> ''
+		UNION
+		SELECT kode_subkegiatan FROM tb_rencana_kinerja WHERE COALESCE(kode_subkegiatan, '') <> ''
+		UNION
+		SELECT kode_subkegiatan FROM tb_subkegiatan_opd WHERE COALESCE(kode_subkegiatan, '') <> ''
+		UNION
+		SELECT kode_bidang_urusan FROM tb_bidangurusan_terpilih WHERE COALESCE(kode_bidang_urusan, '') <> ''
+		UNION
+		SELECT kode_bidang_urusan FROM tb_tujuan_opd WHERE COALESCE(kode_bidang_urusan, '') <> ''
+		UNION
+		SELECT kode_subkegiatan FROM tb_pagu WHERE COALESCE(kode_subkegiatan, '') <> ''
+		UNION
+		SELECT kode FROM tb_indikator WHERE COALESCE(kode, '') <> ''
+		UNION
+		SELECT kode FROM tb_indikator_matrix WHERE COALESCE(kode, '') <> ''
+		UNION
+		SELECT kode_program_unggulan FROM tb_program_unggulan WHERE COALESCE(kode_program_unggulan, '') <> ''
+		UNION
+		SELECT kode_program_unggulan FROM tb_keterangan_tagging_program_unggulan WHERE COALESCE(kode_program_unggulan, '') <> ''`)
+	if err != nil {
+		return nil, fmt.Errorf("NomenklaturRepository.FindKodeTerpakai: %w", err)
+	}
+	defer rows.Close()
+
+	var result []string
+	for rows.Next() {
+		var kode string
+		if err := rows.Scan(&kode); err != nil {
+			return nil, err
+		}
+		result = append(result, kode)
+	}
+	return result, rows.Err()
+}
+
+func (repository *NomenklaturRepositoryImpl) Insert(ctx context.Context, tx *sql.Tx, nomenklatur domainmaster.Nomenklatur) error {
+	meta, ok := tabelNomenklatur[nomenklatur.Tingkat]
+	if !ok {
+		return fmt.Errorf("tingkat nomenklatur %s tidak dikenal", nomenklatur.Tingkat)
+	}
+	script := fmt.Sprintf("INSERT INTO %s (id, %s, %s) VALUES (?, ?, ?)", meta.tabel, meta.kolomKode, meta.kolomNama)
+	if _, err := tx.ExecContext(ctx, script, meta.prefixId+nomenklatur.Kode, nomenklatur.Kode, nomenklatur.Nama); err != nil {
+		return fmt.Errorf("NomenklaturRepository.Insert %s %s: %w", nomenklatur.Tingkat, nomenklatur.Kode, err)
+	}
+	return nil
+}
+
+func (repository *NomenklaturRepositoryImpl) UpdateNama(ctx context.Context, tx *sql.Tx, tingkat string, kode string, nama string) error {
+	meta, ok := tabelNomenklatur[tingkat]
+	if !ok {
+		return fmt.Errorf("tingkat nomenklatur %s tidak dikenal", tingkat)
+	}
+	script := fmt.Sprintf("UPDATE %s SET %s = ?, is_deprecated = 0 WHERE %s = ?", meta.tabel, meta.kolomNama, meta.kolomKode)
+	if _, err := tx.ExecContext(ctx, script, nama, kode); err != nil {
+		return fmt.Errorf("NomenklaturRepository.UpdateNama %s %s: %w", tingkat, kode, err)
+	}
+	return nil
+}
+
+func (repository *NomenklaturRepositoryImpl) UpdateDeprecated(ctx context.Context, tx *sql.Tx, tingkat string, kode string, deprecated bool) error {
+	meta, ok := tabelNomenklatur[tingkat]
+	if !ok {
+		return fmt.Errorf("tingkat nomenklatur %s tidak dikenal", tingkat)
+	}
+	script := fmt.Sprintf("UPDATE %s SET is_deprecated = ? WHERE %s = ?", meta.tabel, meta.kolomKode)
+	if _, err := tx.ExecContext(ctx, script, deprecated, kode); err != nil {
+		return fmt.Errorf("NomenklaturRepository.UpdateDeprecated %s %s: %w", tingkat, kode, err)
+	}
+	return nil
+}
+
+func (repository *NomenklaturRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, tingkat string, kode string) error {
+	meta, ok := tabelNomenklatur[tingkat]
+	if !ok {
+		return fmt.Errorf("tingkat nomenklatur %s tidak dikenal", tingkat)
+	}
+	var scripts []string
+	if meta.kolomIndikator != "" {
+		subquery := fmt.Sprintf("SELECT id FROM %s WHERE %s = ?", meta.tabel, meta.kolomKode)
+		scripts = append(scripts,
+			fmt.Sprintf("DELETE FROM tb_target WHERE indikator_id IN (SELECT id FROM tb_indikator WHERE %s IN (%s))", meta.kolomIndikator, subquery),
+			fmt.Sprintf("DELETE FROM tb_indikator WHERE %s IN (%s)", meta.kolomIndikator, subquery),
+		)
+	}
+	scripts = append(scripts, fmt.Sprintf("DELETE FROM %s WHERE %s = ?", meta.tabel, meta.kolomKode))
+	for _, script := range scripts {
+		if _, err := tx.ExecContext(ctx, script, kode); err != nil {
+			return fmt.Errorf("NomenklaturRepository.Delete %s %s: %w", tingkat, kode, err)
+		}
+	}
+	return nil
+}
+
+func (repository *NomenklaturRepositoryImpl) IsVersiExist(ctx context.Context, tx *sql.Tx, versi string) (bool, error) {
+	var exist bool
+	err := tx.QueryRowContext(ctx, "SELECT EXISTS(SELECT 1 FROM tb_nomenklatur_versi WHERE versi = ?)", versi).Scan(&exist)
+	if err != nil {
+		return false, fmt.Errorf("NomenklaturRepository.IsVersiExist: %w", err)
+	}
+	return exist, nil
+}
+
+func (repository *NomenklaturRepositoryImpl) CreateVersi(ctx context.Context, tx *sql.Tx, versi domainmaster.NomenklaturVersi) (domainmaster.NomenklaturVersi, error) {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO tb_nomenklatur_versi
+			(versi, nama_file, jumlah_tambah, jumlah_ubah, jumlah_hapus, jumlah_deprecated, jumlah_aktif_kembali, applied_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		versi.Versi, versi.NamaFile, versi.JumlahTambah, versi.JumlahUbah, versi.JumlahHapus,
+		versi.JumlahDeprecated, versi.JumlahAktifKembali, versi.AppliedBy)
+	if err != nil {
+		return domainmaster.NomenklaturVersi{}, fmt.Errorf("NomenklaturRepository.CreateVersi: %w", err)
+	}
+	versi.Id, err = result.LastInsertId()
+	return versi, err
+}
+
+func (repository *NomenklaturRepositoryImpl) CreatePerubahan(ctx context.Context, tx *sql.Tx, versiId int64, perubahan []domainmaster.NomenklaturPerubahan) error {
+	for _, item := range perubahan {
+		_, err := tx.ExecContext(ctx, `
+			INSERT INTO tb_nomenklatur_perubahan (versi_id, tingkat, kode, aksi, nama_lama, nama_baru)
+			VALUES (?, ?, ?, ?, ?, ?)`, versiId, item.Tingkat, item.Kode, item.Aksi, item.NamaLama, item.NamaBaru)
+		if err != nil {
+			return fmt.Errorf("NomenklaturRepository.CreatePerubahan: %w", err)
+		}
+	}
+	return nil
+}
+
+const selectNomenklaturVersi = `
+	SELECT id, versi, COALESCE(nama_file, ''), jumlah_tambah, jumlah_ubah, jumlah_hapus, jumlah_deprecated,
+	       jumlah_aktif_kembali, COALESCE(applied_by, ''), created_at
+	FROM tb_nomenklatur_versi`
+
+func scanNomenklaturVersi(scanner interface{ Scan(...interface{}) error }) (domainmaster.NomenklaturVersi, error) {
+	var versi domainmaster.NomenklaturVersi
+	err := scanner.Scan(&versi.Id, &versi.Versi, &versi.NamaFile, &versi.JumlahTambah, &versi.JumlahUbah, &versi.JumlahHapus,
+		&versi.JumlahDeprecated, &versi.JumlahAktifKembali, &versi.AppliedBy, &versi.CreatedAt)
+	return versi, err
+}
+
+func (repository *NomenklaturRepositoryImpl) FindAllVersi(ctx context.Context, tx *sql.Tx) ([]domainmaster.NomenklaturVersi, error) {
+	rows, err := tx.QueryContext(ctx, selectNomenklaturVersi+" ORDER BY created_at DESC, id DESC")
+	if err != nil {
+		return nil, fmt.Errorf("NomenklaturRepository.FindAllVersi: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domainmaster.NomenklaturVersi
+	for rows.Next() {
+		versi, err := scanNomenklaturVersi(rows)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, versi)
+	}
+	return result, rows.Err()
+}
+
+func (repository *NomenklaturRepositoryImpl) FindVersiById(ctx context.Context, tx *sql.Tx, id int64) (domainmaster.NomenklaturVersi, error) {
+	return scanNomenklaturVersi(tx.QueryRowContext(ctx, selectNomenklaturVersi+" WHERE id = ?", id))
+}
+
+func (repository *NomenklaturRepositoryImpl) FindPerubahanByVersiId(ctx context.Context, tx *sql.Tx, versiId int64) ([]domainmaster.NomenklaturPerubahan, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, versi_id, tingkat, kode, aksi, COALESCE(nama_lama, ''), COALESCE(nama_baru, '')
+		FROM tb_nomenklatur_perubahan WHERE versi_id = ? ORDER BY id`, versiId)
+	if err != nil {
+		return nil, fmt.Errorf("NomenklaturRepository.FindPerubahanByVersiId: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domainmaster.NomenklaturPerubahan
+	for rows.Next() {
+		var item domainmaster.NomenklaturPerubahan
+		if err := rows.Scan(&item.Id, &item.VersiId, &item.Tingkat, &item.Kode, &item.Aksi, &item.NamaLama, &item.NamaBaru); err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, rows.Err()
+}
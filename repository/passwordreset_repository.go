@@ -0,0 +1,19 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"time"
+)
+
+type PasswordResetRepository interface {
+	// Create: expired_at dihitung di database (NOW() + ttl) agar tidak terpengaruh timezone aplikasi
+	Create(ctx context.Context, tx *sql.Tx, reset domain.PasswordReset, ttl time.Duration) (domain.PasswordReset, error)
+	// FindValidByTokenHash: token belum dipakai dan belum kedaluwarsa, baris dikunci sampai transaksi selesai
+	FindValidByTokenHash(ctx context.Context, tx *sql.Tx, tokenHash string) (domain.PasswordReset, error)
+	// MarkUsed: sql.ErrNoRows jika token sudah dipakai
+	MarkUsed(ctx context.Context, tx *sql.Tx, id int) error
+	// InvalidateByUserId: tandai semua token user yang belum dipakai sebagai terpakai
+	InvalidateByUserId(ctx context.Context, tx *sql.Tx, userId int) error
+}
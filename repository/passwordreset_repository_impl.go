@@ -0,0 +1,77 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+	"time"
+)
+
+type PasswordResetRepositoryImpl struct{}
+
+func NewPasswordResetRepositoryImpl() *PasswordResetRepositoryImpl {
+	return &PasswordResetRepositoryImpl{}
+}
+
+func (repository *PasswordResetRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, reset domain.PasswordReset, ttl time.Duration) (domain.PasswordReset, error) {
+	script := "INSERT INTO tb_password_reset (user_id, token_hash, expired_at, created_by) VALUES (?, ?, DATE_ADD(NOW(), INTERVAL ? SECOND), ?)"
+	result, err := tx.ExecContext(ctx, script, reset.UserId, reset.TokenHash, int(ttl.Seconds()), reset.CreatedBy)
+	if err != nil {
+		return reset, fmt.Errorf("PasswordResetRepository.Create: %w", err)
+	}
+
+	id, err := result.LastInsertId()
+	if err != nil {
+		return reset, fmt.Errorf("PasswordResetRepository.Create: %w", err)
+	}
+	reset.Id = int(id)
+	reset.ExpiredAt = time.Now().Add(ttl)
+
+	return reset, nil
+}
+
+func (repository *PasswordResetRepositoryImpl) FindValidByTokenHash(ctx context.Context, tx *sql.Tx, tokenHash string) (domain.PasswordReset, error) {
+	script := `
+		SELECT id, user_id, token_hash, expired_at, created_by
+		FROM tb_password_reset
+		WHERE token_hash = ? AND used_at IS NULL AND expired_at > NOW()
+		FOR UPDATE
+	`
+	var reset domain.PasswordReset
+	err := tx.QueryRowContext(ctx, script, tokenHash).Scan(
+		&reset.Id,
+		&reset.UserId,
+		&reset.TokenHash,
+		&reset.ExpiredAt,
+		&reset.CreatedBy,
+	)
+	if err != nil {
+		return domain.PasswordReset{}, err
+	}
+
+	return reset, nil
+}
+
+func (repository *PasswordResetRepositoryImpl) MarkUsed(ctx context.Context, tx *sql.Tx, id int) error {
+	result, err := tx.ExecContext(ctx, "UPDATE tb_password_reset SET used_at = NOW() WHERE id = ? AND used_at IS NULL", id)
+	if err != nil {
+		return fmt.Errorf("PasswordResetRepository.MarkUsed: %w", err)
+	}
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("PasswordResetRepository.MarkUsed: %w", err)
+	}
+	if affected == 0 {
+		return fmt.Errorf("PasswordResetRepository.MarkUsed: token %d sudah dipakai: %w", id, sql.ErrNoRows)
+	}
+	return nil
+}
+
+func (repository *PasswordResetRepositoryImpl) InvalidateByUserId(ctx context.Context, tx *sql.Tx, userId int) error {
+	_, err := tx.ExecContext(ctx, "UPDATE tb_password_reset SET used_at = NOW() WHERE user_id = ? AND used_at IS NULL", userId)
+	if err != nil {
+		return fmt.Errorf("PasswordResetRepository.InvalidateByUserId: %w", err)
+	}
+	return nil
+}
@@ -32,8 +32,14 @@ func (repository *PelaksanaanRencanaAksiRepositoryImpl) Update(ctx context.Conte
 }
 
 func (repository *PelaksanaanRencanaAksiRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id string) error {
+	scriptProgres := "DELETE FROM tb_progres_pelaksanaan_rencana_aksi WHERE pelaksanaan_rencana_aksi_id = ?"
+	_, err := tx.ExecContext(ctx, scriptProgres, id)
+	if err != nil {
+		return err
+	}
+
 	script := "DELETE FROM tb_pelaksanaan_rencana_aksi WHERE id = ?"
-	_, err := tx.ExecContext(ctx, script, id)
+	_, err = tx.ExecContext(ctx, script, id)
 	return err
 }
 
@@ -10,13 +10,15 @@ type PohonKinerjaRepository interface {
 	//pokin opd
 	Create(ctx context.Context, tx *sql.Tx, pohonKinerja domain.PohonKinerja) (domain.PohonKinerja, error)
 	Update(ctx context.Context, tx *sql.Tx, pohonKinerja domain.PohonKinerja) (domain.PohonKinerja, error)
-	Delete(ctx context.Context, tx *sql.Tx, id int) error
+	// Delete menghapus pohon beserta turunannya dan mengembalikan id sumber clone_from,
+	// persetujuan sumber tersebut dibatalkan service lewat workflow
+	Delete(ctx context.Context, tx *sql.Tx, id int) ([]int, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (domain.PohonKinerja, error)
 	FindAll(ctx context.Context, tx *sql.Tx, kodeOpd, tahun string) ([]domain.PohonKinerja, error)
 	FindStrategicNoParent(ctx context.Context, tx *sql.Tx, levelPohon, parent int, kodeOpd, tahun string) ([]domain.PohonKinerja, error)
 	FindPelaksanaPokin(ctx context.Context, tx *sql.Tx, pohonKinerjaId string) ([]domain.PelaksanaPokin, error)
 	DeletePelaksanaPokin(ctx context.Context, tx *sql.Tx, pelaksanaId string) error
-	UpdatePokinStatusFromApproved(ctx context.Context, tx *sql.Tx, id int) error
+	FindPokinIdByPelaksanaId(ctx context.Context, tx *sql.Tx, pelaksanaId string) (int, error)
 	UpdateParent(ctx context.Context, tx *sql.Tx, pohonKinerja domain.PohonKinerja) (domain.PohonKinerja, error)
 	FindidPokinWithAllTema(ctx context.Context, tx *sql.Tx, id int) ([]domain.PohonKinerja, error)
 	CheckAsalPokin(ctx context.Context, tx *sql.Tx, id int) (int, error)
@@ -38,10 +40,7 @@ type PohonKinerjaRepository interface {
 	InsertClonedPokin(ctx context.Context, tx *sql.Tx, pokin domain.PohonKinerja) (int64, error)
 	InsertClonedIndikator(ctx context.Context, tx *sql.Tx, indikatorId string, pokinId int64, indikator domain.Indikator) error
 	InsertClonedTarget(ctx context.Context, tx *sql.Tx, targetId string, indikatorId string, target domain.Target) error
-	UpdatePokinStatus(ctx context.Context, tx *sql.Tx, id int, status string) error
-	CheckPokinStatus(ctx context.Context, tx *sql.Tx, id int) (string, error)
 	InsertClonedPokinWithStatus(ctx context.Context, tx *sql.Tx, pokin domain.PohonKinerja) (int64, error)
-	UpdatePokinStatusTolak(ctx context.Context, tx *sql.Tx, id int, status string) error
 	CheckCloneFrom(ctx context.Context, tx *sql.Tx, id int) (int, error)
 	FindPokinByCloneFrom(ctx context.Context, tx *sql.Tx, cloneFromId int) ([]domain.PohonKinerja, error)
 	FindIndikatorByCloneFrom(ctx context.Context, tx *sql.Tx, pokinId int, cloneFromId string) (domain.Indikator, error)
@@ -70,7 +69,11 @@ type PohonKinerjaRepository interface {
 	GetChildrenAndClones(ctx context.Context, tx *sql.Tx, parentId int, isActivating bool) ([]int, error)
 
 	//clone pokin opd
-	ClonePokinOpd(ctx context.Context, tx *sql.Tx, kodeOpd string, sourceTahun string, targetTahun string) error
+	FindPokinOpdUntukClone(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.PohonKinerja, error)
+	ClonePokinNode(ctx context.Context, tx *sql.Tx, id int, sourceTahun string, targetTahun string) (int, error)
+	CloneIndikatorPokin(ctx context.Context, tx *sql.Tx, oldPokinId int, newPokinId int) (map[string]string, error)
+	CloneTargetIndikator(ctx context.Context, tx *sql.Tx, oldIndikatorId string, newIndikatorId string, targetTahun string) (int, error)
+	CloneTaggingPokin(ctx context.Context, tx *sql.Tx, oldPokinId int, newPokinId int) (int, error)
 	IsExistsByTahun(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) bool
 	FindPokinByParentClonePokinOpd(ctx context.Context, tx *sql.Tx, kodeOpd, tahun string, levelPohon *int) ([]domain.PohonKinerja, error)
 
@@ -447,7 +447,7 @@ func (repository *PohonKinerjaRepositoryImpl) FindStrategicNoParent(ctx context.
 }
 
 //POKIN LAMA
-// func (repository *PohonKinerjaRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+// func (repository *PohonKinerjaRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int) ([]int, error) {
 // 	// Temukan semua ID anak pohon secara rekursif
 // 	scriptFindChildren := `
 //         WITH RECURSIVE child_tree AS (
@@ -562,20 +562,16 @@ func (repository *PohonKinerjaRepositoryImpl) FindStrategicNoParent(ctx context.
 //END POKIN LAMA DELETE
 
 // DELETE POKIN TRIAL
-func (repository *PohonKinerjaRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int) error {
+func (repository *PohonKinerjaRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int) ([]int, error) {
 	// 1. Kumpulkan semua ID subtree (akar + seluruh turunan via parent)
 	nodeIds, cloneFromMap, err := repository.collectSubtreeIds(ctx, tx, id)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	// 2. Update status node asli yang di-clone → kembali ke 'menunggu_disetujui'
+	// 2. Node asli yang di-clone dikembalikan ke pemanggil, statusnya diubah lewat workflow
+	var cloneFromIds []int
 	for _, cloneFromId := range cloneFromMap {
-		if _, err := tx.ExecContext(ctx,
-			`UPDATE tb_pohon_kinerja SET status = 'menunggu_disetujui' WHERE id = ?`,
-			cloneFromId,
-		); err != nil {
-			return fmt.Errorf("gagal update status clone_from id=%d: %w", cloneFromId, err)
-		}
+		cloneFromIds = append(cloneFromIds, cloneFromId)
 	}
 	// 3. Handle crosscutting per node, kumpulkan pohon yg lahir dari crosscutting_disetujui
 	//    dan hanya punya 1 referensi → perlu ikut dihapus
@@ -584,14 +580,14 @@ func (repository *PohonKinerjaRepositoryImpl) Delete(ctx context.Context, tx *sq
 		nodeId, _ := strconv.Atoi(nodeIdStr)
 		derived, err := repository.processCrosscuttingForDelete(ctx, tx, nodeId)
 		if err != nil {
-			return fmt.Errorf("gagal proses crosscutting node id=%d: %w", nodeId, err)
+			return nil, fmt.Errorf("gagal proses crosscutting node id=%d: %w", nodeId, err)
 		}
 		crosscuttingDerivedIds = append(crosscuttingDerivedIds, derived...)
 	}
 	// 4. Hapus semua data pendukung + pohon kinerja utama (subtree)
 	for _, nodeIdStr := range nodeIds {
 		if err := repository.deletePokinAndDependencies(ctx, tx, nodeIdStr); err != nil {
-			return err
+			return nil, err
 		}
 	}
 	// 5. Rekursif hapus pohon yg lahir dari crosscutting_disetujui (single ref)
@@ -607,11 +603,13 @@ func (repository *PohonKinerjaRepositoryImpl) Delete(ctx context.Context, tx *sq
 			continue
 		}
 		deletedSet[derivedId] = true
-		if err := repository.Delete(ctx, tx, derivedId); err != nil {
-			return fmt.Errorf("gagal hapus pohon crosscutting derived id=%d: %w", derivedId, err)
+		derivedCloneFromIds, err := repository.Delete(ctx, tx, derivedId)
+		if err != nil {
+			return nil, fmt.Errorf("gagal hapus pohon crosscutting derived id=%d: %w", derivedId, err)
 		}
+		cloneFromIds = append(cloneFromIds, derivedCloneFromIds...)
 	}
-	return nil
+	return cloneFromIds, nil
 }
 
 //ENDING
@@ -723,12 +721,7 @@ func (repository *PohonKinerjaRepositoryImpl) processCrosscuttingForDelete(
 			); err != nil {
 				return nil, fmt.Errorf("gagal hapus crosscutting existing id=%d: %w", cc.Id, err)
 			}
-			// Fix status pohon existing (legacy repair — repo terpisah di crosscuttingopd_repository_impl)
-			if cc.CrosscuttingTo > 0 {
-				if err := repository.fixPokinStatusAfterExistingUnlink(ctx, tx, cc.CrosscuttingTo); err != nil {
-					return nil, err
-				}
-			}
+			// Status pohon existing tidak diubah saat ditautkan, jadi tidak perlu dikembalikan
 		}
 	}
 	// ── B. Incoming crosscutting: node ini sebagai crosscutting_to ──
@@ -785,40 +778,6 @@ func (repository *PohonKinerjaRepositoryImpl) deletePokinAndDependencies(
 	return nil
 }
 
-// ─────────────────────────────────────────────────────────
-// fixPokinStatusAfterExistingUnlink (private)
-// Dipanggil saat crosscutting_disetujui_existing diputus;
-// kembalikan status pohon existing sesuai jenis_pohon.
-// (Logic ini juga tersedia di CrosscuttingOpdRepositoryImpl sebagai legacy repair)
-// ─────────────────────────────────────────────────────────
-func (repository *PohonKinerjaRepositoryImpl) fixPokinStatusAfterExistingUnlink(
-	ctx context.Context, tx *sql.Tx, pokinId int,
-) error {
-	var jenisPohon string
-	err := tx.QueryRowContext(ctx,
-		`SELECT jenis_pohon FROM tb_pohon_kinerja WHERE id = ?`, pokinId,
-	).Scan(&jenisPohon)
-	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil // pohon tidak ada, skip
-		}
-		return fmt.Errorf("fixPokinStatus gagal ambil jenis_pohon id=%d: %w", pokinId, err)
-	}
-	var newStatus string
-	switch jenisPohon {
-	case "Strategic Pemda", "Tactical Pemda", "Operational Pemda":
-		newStatus = "pokin dari pemda"
-	default:
-		newStatus = "" // string kosong untuk non-Pemda
-	}
-	if _, err := tx.ExecContext(ctx,
-		`UPDATE tb_pohon_kinerja SET status = ? WHERE id = ?`, newStatus, pokinId,
-	); err != nil {
-		return fmt.Errorf("fixPokinStatus gagal update id=%d: %w", pokinId, err)
-	}
-	return nil
-}
-
 func (repository *PohonKinerjaRepositoryImpl) recursiveDelete(ctx context.Context, tx *sql.Tx, id string) error {
 	// Temukan semua ID anak pohon secara rekursif
 	scriptFindRelatedIds := `
@@ -952,6 +911,13 @@ func (repository *PohonKinerjaRepositoryImpl) DeletePelaksanaPokin(ctx context.C
 	return err
 }
 
+func (repository *PohonKinerjaRepositoryImpl) FindPokinIdByPelaksanaId(ctx context.Context, tx *sql.Tx, pelaksanaId string) (int, error) {
+	script := "SELECT pohon_kinerja_id FROM tb_pelaksana_pokin WHERE id = ?"
+	var pokinId int
+	err := tx.QueryRowContext(ctx, script, pelaksanaId).Scan(&pokinId)
+	return pokinId, err
+}
+
 // admin pokin
 func (repository *PohonKinerjaRepositoryImpl) CreatePokinAdmin(ctx context.Context, tx *sql.Tx, pokinAdmin domain.PohonKinerja) (domain.PohonKinerja, error) {
 	// Insert pohon kinerja tanpa ID
@@ -2304,25 +2270,6 @@ func (repository *PohonKinerjaRepositoryImpl) FindPokinByStatus(ctx context.Cont
 	return pokins, nil
 }
 
-func (repository *PohonKinerjaRepositoryImpl) UpdatePokinStatus(ctx context.Context, tx *sql.Tx, id int, status string) error {
-	script := "UPDATE tb_pohon_kinerja SET status = ? WHERE id = ?"
-	_, err := tx.ExecContext(ctx, script, status, id)
-	if err != nil {
-		return fmt.Errorf("gagal mengupdate status: %v", err)
-	}
-	return nil
-}
-
-func (repository *PohonKinerjaRepositoryImpl) CheckPokinStatus(ctx context.Context, tx *sql.Tx, id int) (string, error) {
-	script := "SELECT status FROM tb_pohon_kinerja WHERE id = ?"
-	var status string
-	err := tx.QueryRowContext(ctx, script, id).Scan(&status)
-	if err != nil {
-		return "", fmt.Errorf("gagal mengecek status: %v", err)
-	}
-	return status, nil
-}
-
 func (repository *PohonKinerjaRepositoryImpl) InsertClonedPokinWithStatus(ctx context.Context, tx *sql.Tx, pokin domain.PohonKinerja) (int64, error) {
 	script := `INSERT INTO tb_pohon_kinerja 
         (nama_pohon, parent, jenis_pohon, level_pohon, kode_opd, keterangan, tahun, status, clone_from) 
@@ -2344,15 +2291,6 @@ func (repository *PohonKinerjaRepositoryImpl) InsertClonedPokinWithStatus(ctx co
 	return result.LastInsertId()
 }
 
-func (repository *PohonKinerjaRepositoryImpl) UpdatePokinStatusTolak(ctx context.Context, tx *sql.Tx, id int, status string) error {
-	script := "UPDATE tb_pohon_kinerja SET status = ? WHERE id = ?"
-	_, err := tx.ExecContext(ctx, script, status, id)
-	if err != nil {
-		return fmt.Errorf("gagal mengupdate status dan alasan: %v", err)
-	}
-	return nil
-}
-
 func (repository *PohonKinerjaRepositoryImpl) CheckCloneFrom(ctx context.Context, tx *sql.Tx, id int) (int, error) {
 	script := "SELECT COALESCE(clone_from, 0) FROM tb_pohon_kinerja WHERE id = ?"
 	var cloneFrom int
@@ -2579,31 +2517,6 @@ func (repository *PohonKinerjaRepositoryImpl) InsertClonedPelaksana(ctx context.
 	return err
 }
 
-func (repository *PohonKinerjaRepositoryImpl) UpdatePokinStatusFromApproved(ctx context.Context, tx *sql.Tx, id int) error {
-	SQL := `
-        UPDATE tb_pohon_kinerja 
-        SET status = 'menunggu_disetujui' 
-        WHERE id = ? 
-        AND status = 'disetujui'
-    `
-
-	result, err := tx.ExecContext(ctx, SQL, id)
-	if err != nil {
-		return err
-	}
-
-	rows, err := result.RowsAffected()
-	if err != nil {
-		return err
-	}
-
-	if rows == 0 {
-		return fmt.Errorf("tidak ada data yang diupdate untuk ID %d", id)
-	}
-
-	return nil
-}
-
 func (repository *PohonKinerjaRepositoryImpl) UpdateParent(ctx context.Context, tx *sql.Tx, pohonKinerja domain.PohonKinerja) (domain.PohonKinerja, error) {
 	script := `UPDATE tb_pohon_kinerja SET parent = ? WHERE id = ?`
 	_, err := tx.ExecContext(ctx, script, pohonKinerja.Parent, pohonKinerja.Id)
@@ -3039,235 +2952,34 @@ func (repository *PohonKinerjaRepositoryImpl) IsExistsByTahun(ctx context.Contex
 	return count > 0
 }
 
-//clone pokin lama
-// func (repository *PohonKinerjaRepositoryImpl) ClonePokinOpd(ctx context.Context, tx *sql.Tx, kodeOpd string, sourceTahun string, targetTahun string) error {
-// 	// 1. Dapatkan daftar ID yang valid (status kosong dan parent dengan status kosong)
-// 	scriptValidIds := `
-// 	  SELECT p1.id
-// 	  FROM tb_pohon_kinerja p1
-// 	  LEFT JOIN tb_pohon_kinerja p2 ON p1.parent = p2.id
-// 	  WHERE p1.kode_opd = ?
-// 	  AND p1.tahun = ?
-// 	  AND p1.status = ''
-// 	  AND (p1.parent = 0 OR (p2.status = '' OR p2.status IS NULL))
-//   `
-// 	validRows, err := tx.QueryContext(ctx, scriptValidIds, kodeOpd, sourceTahun)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	defer validRows.Close()
-
-// 	var validIds []int
-// 	for validRows.Next() {
-// 		var id int
-// 		if err := validRows.Scan(&id); err != nil {
-// 			return err
-// 		}
-// 		validIds = append(validIds, id)
-// 	}
-
-// 	// 2. Clone Pohon Kinerja yang valid
-// 	for _, validId := range validIds {
-// 		scriptPokin := `
-// 		  INSERT INTO tb_pohon_kinerja (
-// 			  nama_pohon, parent, jenis_pohon, level_pohon,
-// 			  kode_opd, keterangan, keterangan_crosscutting,
-// 			  tahun, status, is_active
-// 		  )
-// 		  SELECT
-// 			  nama_pohon, parent, jenis_pohon, level_pohon,
-// 			  kode_opd, keterangan, keterangan_crosscutting,
-// 			  ?, '', is_active
-// 		  FROM tb_pohon_kinerja
-// 		  WHERE id = ?
-// 	  `
-// 		_, err := tx.ExecContext(ctx, scriptPokin, targetTahun, validId)
-// 		if err != nil {
-// 			return err
-// 		}
-// 	}
-
-// 	// 3. Dapatkan mapping ID lama ke ID baru
-// 	scriptMapping := `
-// 	  SELECT
-// 		  src.id as old_id,
-// 		  dst.id as new_id
-// 	  FROM tb_pohon_kinerja src
-// 	  JOIN tb_pohon_kinerja dst ON
-// 		  src.nama_pohon = dst.nama_pohon AND
-// 		  src.kode_opd = dst.kode_opd AND
-// 		  src.level_pohon = dst.level_pohon
-// 	  WHERE src.tahun = ? AND dst.tahun = ?
-// 	  AND src.kode_opd = ?
-//   `
-// 	rows, err := tx.QueryContext(ctx, scriptMapping, sourceTahun, targetTahun, kodeOpd)
-// 	if err != nil {
-// 		return err
-// 	}
-// 	defer rows.Close()
-
-// 	idMapping := make(map[int]int)
-// 	for rows.Next() {
-// 		var oldId, newId int
-// 		if err := rows.Scan(&oldId, &newId); err != nil {
-// 			return err
-// 		}
-// 		idMapping[oldId] = newId
-// 	}
-
-// 	// 4. Update parent IDs menggunakan mapping
-// 	for oldId, newId := range idMapping {
-// 		scriptUpdateParent := `
-// 		  UPDATE tb_pohon_kinerja
-// 		  SET parent = ?
-// 		  WHERE id = ? AND tahun = ?
-// 	  `
-// 		oldPokin := `SELECT parent FROM tb_pohon_kinerja WHERE id = ? AND tahun = ?`
-// 		var oldParent int
-// 		err := tx.QueryRowContext(ctx, oldPokin, oldId, sourceTahun).Scan(&oldParent)
-// 		if err != nil {
-// 			continue
-// 		}
-
-// 		if newParent, exists := idMapping[oldParent]; exists {
-// 			_, err = tx.ExecContext(ctx, scriptUpdateParent, newParent, newId, targetTahun)
-// 			if err != nil {
-// 				return err
-// 			}
-// 		}
-// 	}
-
-// 	// 5. Clone Indikator untuk pohon kinerja yang valid
-// 	for oldId, newId := range idMapping {
-// 		// Clone indikator - Tambahkan kolom tahun
-// 		scriptIndikator := `
-// 			INSERT INTO tb_indikator (
-// 				id, pokin_id, indikator, tahun
-// 			)
-// 			SELECT
-// 				CONCAT('IND-', UUID_SHORT()),
-// 				?,
-// 				indikator,
-// 				''
-// 			FROM tb_indikator
-// 			WHERE pokin_id = ?
-// 		`
-// 		_, err := tx.ExecContext(ctx, scriptIndikator, newId, oldId)
-// 		if err != nil {
-// 			return err
-// 		}
-
-// 		// Dapatkan mapping ID indikator (menggunakan string untuk ID)
-// 		scriptIndikatorMapping := `
-//             SELECT
-//                 src.id as old_indikator_id,
-//                 dst.id as new_indikator_id
-//             FROM tb_indikator src
-//             JOIN tb_indikator dst ON
-//                 src.indikator = dst.indikator AND
-//                 dst.pokin_id = ?
-//             WHERE src.pokin_id = ?
-//         `
-// 		indikatorRows, err := tx.QueryContext(ctx, scriptIndikatorMapping, newId, oldId)
-// 		if err != nil {
-// 			return err
-// 		}
-// 		defer indikatorRows.Close()
-
-// 		// Ubah tipe mapping menjadi string
-// 		indikatorMapping := make(map[string]string)
-// 		for indikatorRows.Next() {
-// 			var oldIndikatorId, newIndikatorId string
-// 			if err := indikatorRows.Scan(&oldIndikatorId, &newIndikatorId); err != nil {
-// 				return err
-// 			}
-// 			indikatorMapping[oldIndikatorId] = newIndikatorId
-// 		}
-
-// 		// Clone target untuk setiap indikator
-// 		for oldIndikatorId, newIndikatorId := range indikatorMapping {
-// 			scriptTarget := `
-// 				INSERT INTO tb_target (
-// 					id, indikator_id, target, satuan, tahun
-// 				)
-// 				SELECT
-// 					CONCAT('TRG-', UUID_SHORT()),
-// 					?,
-// 					target,
-// 					satuan,
-// 					?
-// 				FROM tb_target
-// 				WHERE indikator_id = ?
-// 			`
-// 			_, err := tx.ExecContext(ctx, scriptTarget, newIndikatorId, targetTahun, oldIndikatorId)
-// 			if err != nil {
-// 				return err
-// 			}
-// 		}
-// 	}
-
-// 	// Clone tagging untuk setiap pohon kinerja
-// 	for oldId, newId := range idMapping {
-// 		// Clone tagging dengan menambahkan clone_from
-// 		scriptTagging := `
-// 		INSERT INTO tb_tagging_pokin (
-// 			id_pokin,
-// 			nama_tagging,
-// 			keterangan_tagging,
-// 			clone_from
-// 		)
-// 		SELECT
-// 			?,  -- new pokin_id
-// 			nama_tagging,
-// 			keterangan_tagging,
-// 			id  -- menyimpan id lama sebagai clone_from
-// 		FROM tb_tagging_pokin
-// 		WHERE id_pokin = ?  -- old pokin_id
-// 	`
-// 		_, err := tx.ExecContext(ctx, scriptTagging, newId, oldId)
-// 		if err != nil {
-// 			return fmt.Errorf("gagal mengkloning tagging: %v", err)
-// 		}
-// 	}
-
-// 	return nil
-// }
-
-// clone pokin opd baru Insya allah fix
-func (repository *PohonKinerjaRepositoryImpl) ClonePokinOpd(ctx context.Context, tx *sql.Tx, kodeOpd string, sourceTahun string, targetTahun string) error {
-	// Step 1: Ambil ID + parent dari pohon NON-Pemda, urut level asc agar parent selalu lebih dulu
-	type pokinRow struct {
-		id     int
-		parent int
-	}
-	validRows, err := tx.QueryContext(ctx, `
-        SELECT id, COALESCE(parent, 0)
+// FindPokinOpdUntukClone seluruh pokin OPD pada tahun sumber, urut level asc agar parent selalu lebih dulu
+func (repository *PohonKinerjaRepositoryImpl) FindPokinOpdUntukClone(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string) ([]domain.PohonKinerja, error) {
+	rows, err := tx.QueryContext(ctx, `
+        SELECT id, COALESCE(parent, 0), jenis_pohon, level_pohon, COALESCE(status, '')
         FROM tb_pohon_kinerja
         WHERE kode_opd = ?
           AND tahun = ?
-          AND jenis_pohon NOT IN ('Strategic Pemda', 'Tactical Pemda', 'Operational Pemda')
-          AND status NOT IN ('menunggu_disetujui', 'tarik pokin opd', 'disetujui', 'ditolak',
-                             'crosscutting_menunggu', 'crosscutting_ditolak')
         ORDER BY level_pohon ASC, id ASC
-    `, kodeOpd, sourceTahun)
+    `, kodeOpd, tahun)
 	if err != nil {
-		return err
+		return nil, err
 	}
-	var valid []pokinRow
-	for validRows.Next() {
-		var r pokinRow
-		if err := validRows.Scan(&r.id, &r.parent); err != nil {
-			validRows.Close()
-			return err
+	defer rows.Close()
+
+	var result []domain.PohonKinerja
+	for rows.Next() {
+		var pokin domain.PohonKinerja
+		if err := rows.Scan(&pokin.Id, &pokin.Parent, &pokin.JenisPohon, &pokin.LevelPohon, &pokin.Status); err != nil {
+			return nil, err
 		}
-		valid = append(valid, r)
+		result = append(result, pokin)
 	}
-	validRows.Close()
-	// Step 2: INSERT satu per satu, ambil LastInsertId langsung → idMapping akurat tanpa kolom tambahan
-	idMapping := make(map[int]int) // old_id → new_id
+	return result, rows.Err()
+}
 
-	for _, r := range valid {
-		result, err := tx.ExecContext(ctx, `
+// ClonePokinNode salin satu pokin ke tahun tujuan dengan parent 0, parent diperbaiki setelah semua node tersalin
+func (repository *PohonKinerjaRepositoryImpl) ClonePokinNode(ctx context.Context, tx *sql.Tx, id int, sourceTahun string, targetTahun string) (int, error) {
+	result, err := tx.ExecContext(ctx, `
         INSERT INTO tb_pohon_kinerja (
             nama_pohon,
             parent,
@@ -3297,95 +3009,77 @@ func (repository *PohonKinerjaRepositoryImpl) ClonePokinOpd(ctx context.Context,
             id
         FROM tb_pohon_kinerja
         WHERE id = ?
-    `, targetTahun, sourceTahun, r.id)
-
-		if err != nil {
-			return err
-		}
+    `, targetTahun, sourceTahun, id)
+	if err != nil {
+		return 0, err
+	}
 
-		newId, err := result.LastInsertId()
-		if err != nil {
-			return err
-		}
+	newId, err := result.LastInsertId()
+	if err != nil {
+		return 0, err
+	}
+	return int(newId), nil
+}
 
-		idMapping[r.id] = int(newId)
+// CloneIndikatorPokin salin indikator pokin, hasilnya mapping id indikator lama ke baru
+func (repository *PohonKinerjaRepositoryImpl) CloneIndikatorPokin(ctx context.Context, tx *sql.Tx, oldPokinId int, newPokinId int) (map[string]string, error) {
+	_, err := tx.ExecContext(ctx, `
+        INSERT INTO tb_indikator (id, pokin_id, indikator, tahun)
+        SELECT CONCAT('IND-', UUID_SHORT()), ?, indikator, ''
+        FROM tb_indikator WHERE pokin_id = ?
+    `, newPokinId, oldPokinId)
+	if err != nil {
+		return nil, err
 	}
-	// Step 3: Update parent berdasarkan idMapping
-	// - old parent ada di idMapping → pakai ID baru
-	// - old parent tidak ada di idMapping (Pemda / tidak di-clone) → -100
-	// - old parent = 0 → biarkan 0 (root strategic)
-	for _, r := range valid {
-		newId := idMapping[r.id]
-		if r.parent == 0 {
-			continue // root, parent tetap 0
-		}
-		if newParent, exists := idMapping[r.parent]; exists {
-			_, err = tx.ExecContext(ctx, `UPDATE tb_pohon_kinerja SET parent = ? WHERE id = ?`, newParent, newId)
-		} else {
-			// parent Pemda atau tidak ikut clone → terlepas, parent = -100
-			_, err = tx.ExecContext(ctx, `UPDATE tb_pohon_kinerja SET parent = -100 WHERE id = ?`, newId)
-		}
-		if err != nil {
-			return err
-		}
-	}
-	// Step 4: Clone indikator & target
-	for _, r := range valid {
-		newId := idMapping[r.id]
-		// Insert indikator
-		_, err := tx.ExecContext(ctx, `
-            INSERT INTO tb_indikator (id, pokin_id, indikator, tahun)
-            SELECT CONCAT('IND-', UUID_SHORT()), ?, indikator, ''
-            FROM tb_indikator WHERE pokin_id = ?
-        `, newId, r.id)
-		if err != nil {
-			return err
-		}
-		// Mapping indikator lama → baru (by teks indikator, cukup aman karena scope 1 pohon)
-		indRows, err := tx.QueryContext(ctx, `
-            SELECT src.id, dst.id
-            FROM tb_indikator src
-            JOIN tb_indikator dst ON src.indikator = dst.indikator AND dst.pokin_id = ?
-            WHERE src.pokin_id = ?
-        `, newId, r.id)
-		if err != nil {
-			return err
-		}
-		indMapping := make(map[string]string)
-		for indRows.Next() {
-			var o, n string
-			if err := indRows.Scan(&o, &n); err != nil {
-				indRows.Close()
-				return err
-			}
-			indMapping[o] = n
-		}
-		indRows.Close()
-		// Insert target
-		for oldInd, newInd := range indMapping {
-			_, err := tx.ExecContext(ctx, `
-                INSERT INTO tb_target (id, indikator_id, target, satuan, tahun)
-                SELECT CONCAT('TRG-', UUID_SHORT()), ?, target, satuan, ?
-                FROM tb_target WHERE indikator_id = ?
-            `, newInd, targetTahun, oldInd)
-			if err != nil {
-				return err
-			}
-		}
+	// Mapping indikator lama → baru (by teks indikator, cukup aman karena scope 1 pohon)
+	rows, err := tx.QueryContext(ctx, `
+        SELECT src.id, dst.id
+        FROM tb_indikator src
+        JOIN tb_indikator dst ON src.indikator = dst.indikator AND dst.pokin_id = ?
+        WHERE src.pokin_id = ?
+    `, newPokinId, oldPokinId)
+	if err != nil {
+		return nil, err
 	}
-	// Step 5: Clone tagging
-	for _, r := range valid {
-		newId := idMapping[r.id]
-		_, err := tx.ExecContext(ctx, `
-            INSERT INTO tb_tagging_pokin (id_pokin, nama_tagging, keterangan_tagging, clone_from)
-            SELECT ?, nama_tagging, keterangan_tagging, id
-            FROM tb_tagging_pokin WHERE id_pokin = ?
-        `, newId, r.id)
-		if err != nil {
-			return fmt.Errorf("gagal mengkloning tagging: %v", err)
+	defer rows.Close()
+
+	indMapping := make(map[string]string)
+	for rows.Next() {
+		var o, n string
+		if err := rows.Scan(&o, &n); err != nil {
+			return nil, err
 		}
+		indMapping[o] = n
 	}
-	return nil
+	return indMapping, rows.Err()
+}
+
+// CloneTargetIndikator salin target indikator ke tahun tujuan, mengembalikan jumlah target tersalin
+func (repository *PohonKinerjaRepositoryImpl) CloneTargetIndikator(ctx context.Context, tx *sql.Tx, oldIndikatorId string, newIndikatorId string, targetTahun string) (int, error) {
+	result, err := tx.ExecContext(ctx, `
+        INSERT INTO tb_target (id, indikator_id, target, satuan, tahun)
+        SELECT CONCAT('TRG-', UUID_SHORT()), ?, target, satuan, ?
+        FROM tb_target WHERE indikator_id = ?
+    `, newIndikatorId, targetTahun, oldIndikatorId)
+	if err != nil {
+		return 0, err
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
+}
+
+// CloneTaggingPokin salin tagging pokin dengan clone_from berisi id tagging lama
+func (repository *PohonKinerjaRepositoryImpl) CloneTaggingPokin(ctx context.Context, tx *sql.Tx, oldPokinId int, newPokinId int) (int, error) {
+	result, err := tx.ExecContext(ctx, `
+        INSERT INTO tb_tagging_pokin (id_pokin, nama_tagging, keterangan_tagging, clone_from)
+        SELECT ?, nama_tagging, keterangan_tagging, id
+        FROM tb_tagging_pokin WHERE id_pokin = ?
+    `, newPokinId, oldPokinId)
+	if err != nil {
+		return 0, fmt.Errorf("gagal mengkloning tagging: %v", err)
+	}
+	rows, err := result.RowsAffected()
+	return int(rows), err
 }
 
 // count pokin pemda in opd
@@ -4105,7 +3799,7 @@ func (repository *PohonKinerjaRepositoryImpl) ClonePokinPemda(ctx context.Contex
 	if source.LevelPohon <= 3 {
 		newStatus = source.Status
 	} else {
-		newStatus = "menunggu_disetujui"
+		newStatus = domain.StatusPokinMenungguDisetujui
 	}
 
 	// 3. Insert pohon kinerja baru
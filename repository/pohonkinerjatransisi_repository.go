@@ -0,0 +1,16 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type PohonKinerjaTransisiRepository interface {
+	// FindPohonKinerja id, status, kode_opd dan tahun pokin yang akan ditransisikan
+	FindPohonKinerja(ctx context.Context, tx *sql.Tx, pohonKinerjaId int) (domain.PohonKinerja, error)
+	// UpdateStatus hanya mengubah status jika status saat ini masih dariStatus, false jika sudah berubah
+	UpdateStatus(ctx context.Context, tx *sql.Tx, pohonKinerjaId int, dariStatus string, keStatus string) (bool, error)
+	Create(ctx context.Context, tx *sql.Tx, transisi domain.PohonKinerjaTransisi) (domain.PohonKinerjaTransisi, error)
+	FindByPohonKinerjaId(ctx context.Context, tx *sql.Tx, pohonKinerjaId int) ([]domain.PohonKinerjaTransisi, error)
+}
@@ -0,0 +1,109 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+)
+
+type PohonKinerjaTransisiRepositoryImpl struct{}
+
+func NewPohonKinerjaTransisiRepositoryImpl() *PohonKinerjaTransisiRepositoryImpl {
+	return &PohonKinerjaTransisiRepositoryImpl{}
+}
+
+func (repository *PohonKinerjaTransisiRepositoryImpl) FindPohonKinerja(ctx context.Context, tx *sql.Tx, pohonKinerjaId int) (domain.PohonKinerja, error) {
+	script := `
+		SELECT id, nama_pohon, jenis_pohon, level_pohon, COALESCE(kode_opd, ''), tahun, COALESCE(status, '')
+		FROM tb_pohon_kinerja
+		WHERE id = ?
+	`
+	var pokin domain.PohonKinerja
+	err := tx.QueryRowContext(ctx, script, pohonKinerjaId).Scan(
+		&pokin.Id,
+		&pokin.NamaPohon,
+		&pokin.JenisPohon,
+		&pokin.LevelPohon,
+		&pokin.KodeOpd,
+		&pokin.Tahun,
+		&pokin.Status,
+	)
+	return pokin, err
+}
+
+func (repository *PohonKinerjaTransisiRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, pohonKinerjaId int, dariStatus string, keStatus string) (bool, error) {
+	script := "UPDATE tb_pohon_kinerja SET status = ? WHERE id = ? AND COALESCE(status, '') = ?"
+	result, err := tx.ExecContext(ctx, script, keStatus, pohonKinerjaId, dariStatus)
+	if err != nil {
+		return false, fmt.Errorf("PohonKinerjaTransisiRepository.UpdateStatus: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("PohonKinerjaTransisiRepository.UpdateStatus: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (repository *PohonKinerjaTransisiRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, transisi domain.PohonKinerjaTransisi) (domain.PohonKinerjaTransisi, error) {
+	script := `
+		INSERT INTO tb_pohon_kinerja_transisi
+			(pohon_kinerja_id, dari_status, ke_status, aksi, alasan, actor_nip, actor_role, actor_kode_opd)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := tx.ExecContext(ctx, script,
+		transisi.PohonKinerjaId,
+		transisi.DariStatus,
+		transisi.KeStatus,
+		transisi.Aksi,
+		transisi.Alasan,
+		transisi.ActorNip,
+		transisi.ActorRole,
+		transisi.ActorKodeOpd,
+	)
+	if err != nil {
+		return domain.PohonKinerjaTransisi{}, fmt.Errorf("PohonKinerjaTransisiRepository.Create: %w", err)
+	}
+	transisi.Id, err = result.LastInsertId()
+	if err != nil {
+		return domain.PohonKinerjaTransisi{}, fmt.Errorf("PohonKinerjaTransisiRepository.Create: %w", err)
+	}
+	return transisi, nil
+}
+
+func (repository *PohonKinerjaTransisiRepositoryImpl) FindByPohonKinerjaId(ctx context.Context, tx *sql.Tx, pohonKinerjaId int) ([]domain.PohonKinerjaTransisi, error) {
+	script := `
+		SELECT id, pohon_kinerja_id, dari_status, ke_status, aksi, COALESCE(alasan, ''),
+			COALESCE(actor_nip, ''), COALESCE(actor_role, ''), COALESCE(actor_kode_opd, ''), created_at
+		FROM tb_pohon_kinerja_transisi
+		WHERE pohon_kinerja_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+	rows, err := tx.QueryContext(ctx, script, pohonKinerjaId)
+	if err != nil {
+		return nil, fmt.Errorf("PohonKinerjaTransisiRepository.FindByPohonKinerjaId: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.PohonKinerjaTransisi
+	for rows.Next() {
+		var transisi domain.PohonKinerjaTransisi
+		err := rows.Scan(
+			&transisi.Id,
+			&transisi.PohonKinerjaId,
+			&transisi.DariStatus,
+			&transisi.KeStatus,
+			&transisi.Aksi,
+			&transisi.Alasan,
+			&transisi.ActorNip,
+			&transisi.ActorRole,
+			&transisi.ActorKodeOpd,
+			&transisi.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("PohonKinerjaTransisiRepository.FindByPohonKinerjaId: %w", err)
+		}
+		result = append(result, transisi)
+	}
+	return result, rows.Err()
+}
@@ -170,7 +170,7 @@ func (repository *ProgramRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx,
 }
 
 func (repository *ProgramRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.ProgramKegiatan, error) {
-	script := "SELECT id, kode_program, nama_program, tahun, is_active FROM tb_master_program ORDER BY id"
+	script := "SELECT id, kode_program, nama_program, tahun, is_active, is_deprecated FROM tb_master_program ORDER BY id"
 
 	rows, err := tx.QueryContext(ctx, script)
 	if err != nil {
@@ -187,6 +187,7 @@ func (repository *ProgramRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx
 			&program.NamaProgram,
 			&program.Tahun,
 			&program.IsActive,
+			&program.IsDeprecated,
 		)
 		if err != nil {
 			return nil, err
@@ -0,0 +1,15 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type ProgresPelaksanaanRepository interface {
+	Upsert(ctx context.Context, tx *sql.Tx, progres domain.ProgresPelaksanaan) (domain.ProgresPelaksanaan, error)
+	DeleteByPelaksanaanId(ctx context.Context, tx *sql.Tx, pelaksanaanRencanaAksiId string) error
+	// FindRekinByPelaksanaanId id, kode OPD dan tahun rencana kinerja pemilik pelaksanaan rencana aksi
+	FindRekinByPelaksanaanId(ctx context.Context, tx *sql.Tx, pelaksanaanRencanaAksiId string) (domain.RencanaKinerja, error)
+	FindBobotBulananByRekinIds(ctx context.Context, tx *sql.Tx, rekinIds []string) ([]domain.BobotBulanan, error)
+}
@@ -0,0 +1,122 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+)
+
+type ProgresPelaksanaanRepositoryImpl struct{}
+
+func NewProgresPelaksanaanRepositoryImpl() *ProgresPelaksanaanRepositoryImpl {
+	return &ProgresPelaksanaanRepositoryImpl{}
+}
+
+func (repository *ProgresPelaksanaanRepositoryImpl) Upsert(ctx context.Context, tx *sql.Tx, progres domain.ProgresPelaksanaan) (domain.ProgresPelaksanaan, error) {
+	script := `
+		INSERT INTO tb_progres_pelaksanaan_rencana_aksi
+			(pelaksanaan_rencana_aksi_id, persentase, catatan, kendala, created_by)
+		VALUES (?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			persentase = VALUES(persentase),
+			catatan = VALUES(catatan),
+			kendala = VALUES(kendala),
+			created_by = VALUES(created_by)
+	`
+	_, err := tx.ExecContext(ctx, script,
+		progres.PelaksanaanRencanaAksiId,
+		progres.Persentase,
+		progres.Catatan,
+		progres.Kendala,
+		progres.CreatedBy,
+	)
+	if err != nil {
+		return domain.ProgresPelaksanaan{}, fmt.Errorf("ProgresPelaksanaanRepository.Upsert: %w", err)
+	}
+
+	script = `
+		SELECT id, pelaksanaan_rencana_aksi_id, persentase, COALESCE(catatan, ''), COALESCE(kendala, ''),
+			COALESCE(created_by, ''), updated_at
+		FROM tb_progres_pelaksanaan_rencana_aksi
+		WHERE pelaksanaan_rencana_aksi_id = ?
+	`
+	var result domain.ProgresPelaksanaan
+	err = tx.QueryRowContext(ctx, script, progres.PelaksanaanRencanaAksiId).Scan(
+		&result.Id,
+		&result.PelaksanaanRencanaAksiId,
+		&result.Persentase,
+		&result.Catatan,
+		&result.Kendala,
+		&result.CreatedBy,
+		&result.UpdatedAt,
+	)
+	if err != nil {
+		return domain.ProgresPelaksanaan{}, fmt.Errorf("ProgresPelaksanaanRepository.Upsert: %w", err)
+	}
+	return result, nil
+}
+
+func (repository *ProgresPelaksanaanRepositoryImpl) DeleteByPelaksanaanId(ctx context.Context, tx *sql.Tx, pelaksanaanRencanaAksiId string) error {
+	script := "DELETE FROM tb_progres_pelaksanaan_rencana_aksi WHERE pelaksanaan_rencana_aksi_id = ?"
+	_, err := tx.ExecContext(ctx, script, pelaksanaanRencanaAksiId)
+	return err
+}
+
+func (repository *ProgresPelaksanaanRepositoryImpl) FindRekinByPelaksanaanId(ctx context.Context, tx *sql.Tx, pelaksanaanRencanaAksiId string) (domain.RencanaKinerja, error) {
+	script := `
+		SELECT rk.id, COALESCE(rk.kode_opd, ''), COALESCE(rk.tahun, '')
+		FROM tb_pelaksanaan_rencana_aksi p
+		JOIN tb_rencana_aksi ra ON ra.id = p.rencana_aksi_id
+		JOIN tb_rencana_kinerja rk ON rk.id = ra.rencana_kinerja_id
+		WHERE p.id = ?
+	`
+	var rekin domain.RencanaKinerja
+	err := tx.QueryRowContext(ctx, script, pelaksanaanRencanaAksiId).Scan(&rekin.Id, &rekin.KodeOpd, &rekin.Tahun)
+	return rekin, err
+}
+
+func (repository *ProgresPelaksanaanRepositoryImpl) FindBobotBulananByRekinIds(ctx context.Context, tx *sql.Tx, rekinIds []string) ([]domain.BobotBulanan, error) {
+	if len(rekinIds) == 0 {
+		return nil, nil
+	}
+
+	baseQuery := `
+		SELECT ra.rencana_kinerja_id, ra.id, COALESCE(ra.nama_rencana_aksi, ''), p.id, p.bulan, p.bobot,
+			pr.persentase, COALESCE(pr.catatan, ''), COALESCE(pr.kendala, '')
+		FROM tb_rencana_aksi ra
+		JOIN tb_pelaksanaan_rencana_aksi p ON p.rencana_aksi_id = ra.id
+		LEFT JOIN tb_progres_pelaksanaan_rencana_aksi pr ON pr.pelaksanaan_rencana_aksi_id = p.id
+		WHERE ra.rencana_kinerja_id IN (?)
+	`
+	query, args := helper.BuildInQueryString(baseQuery, rekinIds)
+	query += " ORDER BY ra.rencana_kinerja_id, ra.urutan, p.bulan"
+
+	rows, err := tx.QueryContext(ctx, query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("ProgresPelaksanaanRepository.FindBobotBulananByRekinIds: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.BobotBulanan
+	for rows.Next() {
+		var item domain.BobotBulanan
+		err := rows.Scan(
+			&item.RencanaKinerjaId,
+			&item.RencanaAksiId,
+			&item.NamaRencanaAksi,
+			&item.PelaksanaanRencanaAksiId,
+			&item.Bulan,
+			&item.Bobot,
+			&item.Persentase,
+			&item.Catatan,
+			&item.Kendala,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, item)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,25 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type RealisasiRepository interface {
+	// Upsert: satu baris per indikator+tahun+jenis_periode+periode
+	Upsert(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiIndikator) (domain.RealisasiIndikator, error)
+	Update(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiIndikator) error
+	Delete(ctx context.Context, tx *sql.Tx, id int64) error
+	FindById(ctx context.Context, tx *sql.Tx, id int64) (domain.RealisasiIndikator, error)
+	FindByIndikatorIds(ctx context.Context, tx *sql.Tx, indikatorIds []string, tahun string) ([]domain.RealisasiIndikator, error)
+	// FindTahunByIndikatorId tahun-tahun yang sudah memiliki realisasi untuk indikator
+	FindTahunByIndikatorId(ctx context.Context, tx *sql.Tx, indikatorId string) ([]string, error)
+	// FindIndikatorById: indikator dari tb_indikator atau tb_indikator_matrix (kode_indikator)
+	FindIndikatorById(ctx context.Context, tx *sql.Tx, indikatorId string) (domain.IndikatorRealisasi, error)
+	FindIndikatorByRencanaKinerjaId(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) ([]domain.IndikatorRealisasi, error)
+	// FindPolaritasByIndikatorIds polaritas tersimpan per indikator, kosong berarti belum diatur
+	FindPolaritasByIndikatorIds(ctx context.Context, tx *sql.Tx, indikatorIds []string) (map[string]string, error)
+	UpdatePolaritas(ctx context.Context, tx *sql.Tx, indikatorId string, polaritas string) error
+	FindTargetByIndikatorIds(ctx context.Context, tx *sql.Tx, indikatorIds []string, tahun string) ([]domain.Target, error)
+}
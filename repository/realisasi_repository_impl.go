@@ -0,0 +1,297 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"errors"
+	"fmt"
+)
+
+type RealisasiRepositoryImpl struct{}
+
+func NewRealisasiRepositoryImpl() *RealisasiRepositoryImpl {
+	return &RealisasiRepositoryImpl{}
+}
+
+const realisasiColumns = `id, indikator_id, tahun, jenis_periode, periode, realisasi, COALESCE(satuan, ''),
+		COALESCE(bukti_dukung, ''), COALESCE(keterangan, ''), COALESCE(created_by, ''), created_at, updated_at`
+
+func (repository *RealisasiRepositoryImpl) Upsert(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiIndikator) (domain.RealisasiIndikator, error) {
+	script := `
+		INSERT INTO tb_realisasi_indikator
+			(indikator_id, tahun, jenis_periode, periode, realisasi, satuan, bukti_dukung, keterangan, created_by)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON DUPLICATE KEY UPDATE
+			realisasi = VALUES(realisasi),
+			satuan = VALUES(satuan),
+			bukti_dukung = VALUES(bukti_dukung),
+			keterangan = VALUES(keterangan),
+			created_by = VALUES(created_by)
+	`
+	_, err := tx.ExecContext(ctx, script,
+		realisasi.IndikatorId,
+		realisasi.Tahun,
+		realisasi.JenisPeriode,
+		realisasi.Periode,
+		realisasi.Realisasi,
+		realisasi.Satuan,
+		realisasi.BuktiDukung,
+		realisasi.Keterangan,
+		realisasi.CreatedBy,
+	)
+	if err != nil {
+		return domain.RealisasiIndikator{}, fmt.Errorf("RealisasiRepository.Upsert: %w", err)
+	}
+
+	script = "SELECT " + realisasiColumns + " FROM tb_realisasi_indikator WHERE indikator_id = ? AND tahun = ? AND jenis_periode = ? AND periode = ?"
+	result, err := repository.query(ctx, tx, script, realisasi.IndikatorId, realisasi.Tahun, realisasi.JenisPeriode, realisasi.Periode)
+	if err != nil {
+		return domain.RealisasiIndikator{}, fmt.Errorf("RealisasiRepository.Upsert: %w", err)
+	}
+	if len(result) == 0 {
+		return domain.RealisasiIndikator{}, errors.New("realisasi tidak ditemukan setelah disimpan")
+	}
+	return result[0], nil
+}
+
+func (repository *RealisasiRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, realisasi domain.RealisasiIndikator) error {
+	script := `
+		UPDATE tb_realisasi_indikator
+		SET realisasi = ?, satuan = ?, bukti_dukung = ?, keterangan = ?, created_by = ?
+		WHERE id = ?
+	`
+	_, err := tx.ExecContext(ctx, script,
+		realisasi.Realisasi,
+		realisasi.Satuan,
+		realisasi.BuktiDukung,
+		realisasi.Keterangan,
+		realisasi.CreatedBy,
+		realisasi.Id,
+	)
+	if err != nil {
+		return fmt.Errorf("RealisasiRepository.Update: %w", err)
+	}
+	return nil
+}
+
+func (repository *RealisasiRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id int64) error {
+	_, err := tx.ExecContext(ctx, "DELETE FROM tb_realisasi_indikator WHERE id = ?", id)
+	if err != nil {
+		return fmt.Errorf("RealisasiRepository.Delete: %w", err)
+	}
+	return nil
+}
+
+func (repository *RealisasiRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, id int64) (domain.RealisasiIndikator, error) {
+	script := "SELECT " + realisasiColumns + " FROM tb_realisasi_indikator WHERE id = ?"
+	result, err := repository.query(ctx, tx, script, id)
+	if err != nil {
+		return domain.RealisasiIndikator{}, fmt.Errorf("RealisasiRepository.FindById: %w", err)
+	}
+	if len(result) == 0 {
+		return domain.RealisasiIndikator{}, errors.New("realisasi tidak ditemukan")
+	}
+	return result[0], nil
+}
+
+func (repository *RealisasiRepositoryImpl) FindByIndikatorIds(ctx context.Context, tx *sql.Tx, indikatorIds []string, tahun string) ([]domain.RealisasiIndikator, error) {
+	if len(indikatorIds) == 0 {
+		return nil, nil
+	}
+
+	baseQuery := "SELECT " + realisasiColumns + ` FROM tb_realisasi_indikator
+		WHERE tahun = ? AND indikator_id IN (?)
+		ORDER BY indikator_id, jenis_periode, periode`
+	query, args := helper.BuildInQueryString(baseQuery, indikatorIds)
+
+	result, err := repository.query(ctx, tx, query, append([]any{tahun}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.FindByIndikatorIds: %w", err)
+	}
+	return result, nil
+}
+
+func (repository *RealisasiRepositoryImpl) FindTahunByIndikatorId(ctx context.Context, tx *sql.Tx, indikatorId string) ([]string, error) {
+	rows, err := tx.QueryContext(ctx, "SELECT DISTINCT tahun FROM tb_realisasi_indikator WHERE indikator_id = ? ORDER BY tahun", indikatorId)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.FindTahunByIndikatorId: %w", err)
+	}
+	defer rows.Close()
+
+	var tahuns []string
+	for rows.Next() {
+		var tahun string
+		if err := rows.Scan(&tahun); err != nil {
+			return nil, fmt.Errorf("RealisasiRepository.FindTahunByIndikatorId: %w", err)
+		}
+		tahuns = append(tahuns, tahun)
+	}
+	return tahuns, rows.Err()
+}
+
+func (repository *RealisasiRepositoryImpl) FindIndikatorById(ctx context.Context, tx *sql.Tx, indikatorId string) (domain.IndikatorRealisasi, error) {
+	// kode_opd diambil dari rencana kinerja / tujuan OPD / pohon kinerja sasaran OPD pemilik indikator
+	script := `
+		SELECT i.id, COALESCE(i.indikator, ''), COALESCE(i.rumus_perhitungan, ''), COALESCE(i.polaritas, ''),
+			COALESCE(rk.kode_opd, t.kode_opd, pk.kode_opd, '')
+		FROM tb_indikator i
+		LEFT JOIN tb_rencana_kinerja rk ON rk.id = i.rencana_kinerja_id
+		LEFT JOIN tb_tujuan_opd t ON t.id = i.tujuan_opd_id
+		LEFT JOIN tb_sasaran_opd so ON so.id = i.sasaran_opd_id
+		LEFT JOIN tb_pohon_kinerja pk ON pk.id = so.pokin_id
+		WHERE i.id = ?
+		UNION ALL
+		SELECT m.kode_indikator, m.indikator, COALESCE(m.rumus_perhitungan, ''), COALESCE(m.polaritas, ''), m.kode_opd
+		FROM tb_indikator_matrix m
+		WHERE m.kode_indikator = ?
+		LIMIT 1
+	`
+	var indikator domain.IndikatorRealisasi
+	err := tx.QueryRowContext(ctx, script, indikatorId, indikatorId).Scan(
+		&indikator.Id,
+		&indikator.Indikator,
+		&indikator.RumusPerhitungan,
+		&indikator.Polaritas,
+		&indikator.KodeOpd,
+	)
+	if err == sql.ErrNoRows {
+		return indikator, errors.New("indikator tidak ditemukan")
+	}
+	if err != nil {
+		return indikator, fmt.Errorf("RealisasiRepository.FindIndikatorById: %w", err)
+	}
+	return indikator, nil
+}
+
+func (repository *RealisasiRepositoryImpl) FindIndikatorByRencanaKinerjaId(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) ([]domain.IndikatorRealisasi, error) {
+	script := `
+		SELECT i.id, COALESCE(i.indikator, ''), COALESCE(i.rumus_perhitungan, ''), COALESCE(i.polaritas, ''), COALESCE(rk.kode_opd, '')
+		FROM tb_indikator i
+		JOIN tb_rencana_kinerja rk ON rk.id = i.rencana_kinerja_id
+		WHERE i.rencana_kinerja_id = ?
+		ORDER BY i.created_at, i.id
+	`
+	rows, err := tx.QueryContext(ctx, script, rencanaKinerjaId)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.FindIndikatorByRencanaKinerjaId: %w", err)
+	}
+	defer rows.Close()
+
+	var indikators []domain.IndikatorRealisasi
+	for rows.Next() {
+		var indikator domain.IndikatorRealisasi
+		if err := rows.Scan(&indikator.Id, &indikator.Indikator, &indikator.RumusPerhitungan, &indikator.Polaritas, &indikator.KodeOpd); err != nil {
+			return nil, fmt.Errorf("RealisasiRepository.FindIndikatorByRencanaKinerjaId: %w", err)
+		}
+		indikators = append(indikators, indikator)
+	}
+	return indikators, rows.Err()
+}
+
+func (repository *RealisasiRepositoryImpl) FindPolaritasByIndikatorIds(ctx context.Context, tx *sql.Tx, indikatorIds []string) (map[string]string, error) {
+	result := make(map[string]string, len(indikatorIds))
+	if len(indikatorIds) == 0 {
+		return result, nil
+	}
+
+	scripts := []string{
+		"SELECT id, COALESCE(polaritas, '') FROM tb_indikator WHERE id IN (?)",
+		"SELECT kode_indikator, COALESCE(polaritas, '') FROM tb_indikator_matrix WHERE kode_indikator IN (?)",
+	}
+	for _, baseQuery := range scripts {
+		query, args := helper.BuildInQueryString(baseQuery, indikatorIds)
+		rows, err := tx.QueryContext(ctx, query, args...)
+		if err != nil {
+			return nil, fmt.Errorf("RealisasiRepository.FindPolaritasByIndikatorIds: %w", err)
+		}
+		for rows.Next() {
+			var id, polaritas string
+			if err := rows.Scan(&id, &polaritas); err != nil {
+				rows.Close()
+				return nil, fmt.Errorf("RealisasiRepository.FindPolaritasByIndikatorIds: %w", err)
+			}
+			if _, ok := result[id]; !ok {
+				result[id] = polaritas
+			}
+		}
+		err = rows.Err()
+		rows.Close()
+		if err != nil {
+			return nil, fmt.Errorf("RealisasiRepository.FindPolaritasByIndikatorIds: %w", err)
+		}
+	}
+	return result, nil
+}
+
+func (repository *RealisasiRepositoryImpl) UpdatePolaritas(ctx context.Context, tx *sql.Tx, indikatorId string, polaritas string) error {
+	if _, err := tx.ExecContext(ctx, "UPDATE tb_indikator SET polaritas = ? WHERE id = ?", polaritas, indikatorId); err != nil {
+		return fmt.Errorf("RealisasiRepository.UpdatePolaritas: %w", err)
+	}
+	if _, err := tx.ExecContext(ctx, "UPDATE tb_indikator_matrix SET polaritas = ? WHERE kode_indikator = ?", polaritas, indikatorId); err != nil {
+		return fmt.Errorf("RealisasiRepository.UpdatePolaritas: %w", err)
+	}
+	return nil
+}
+
+func (repository *RealisasiRepositoryImpl) FindTargetByIndikatorIds(ctx context.Context, tx *sql.Tx, indikatorIds []string, tahun string) ([]domain.Target, error) {
+	if len(indikatorIds) == 0 {
+		return nil, nil
+	}
+
+	baseQuery := `
+		SELECT id, indikator_id, COALESCE(target, ''), COALESCE(satuan, ''), tahun
+		FROM tb_target
+		WHERE tahun = ? AND indikator_id IN (?)
+	`
+	query, args := helper.BuildInQueryString(baseQuery, indikatorIds)
+
+	rows, err := tx.QueryContext(ctx, query, append([]any{tahun}, args...)...)
+	if err != nil {
+		return nil, fmt.Errorf("RealisasiRepository.FindTargetByIndikatorIds: %w", err)
+	}
+	defer rows.Close()
+
+	var targets []domain.Target
+	for rows.Next() {
+		var target domain.Target
+		if err := rows.Scan(&target.Id, &target.IndikatorId, &target.Target, &target.Satuan, &target.Tahun); err != nil {
+			return nil, fmt.Errorf("RealisasiRepository.FindTargetByIndikatorIds: %w", err)
+		}
+		targets = append(targets, target)
+	}
+	return targets, rows.Err()
+}
+
+func (repository *RealisasiRepositoryImpl) query(ctx context.Context, tx *sql.Tx, script string, args ...interface{}) ([]domain.RealisasiIndikator, error) {
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var result []domain.RealisasiIndikator
+	for rows.Next() {
+		var realisasi domain.RealisasiIndikator
+		err := rows.Scan(
+			&realisasi.Id,
+			&realisasi.IndikatorId,
+			&realisasi.Tahun,
+			&realisasi.JenisPeriode,
+			&realisasi.Periode,
+			&realisasi.Realisasi,
+			&realisasi.Satuan,
+			&realisasi.BuktiDukung,
+			&realisasi.Keterangan,
+			&realisasi.CreatedBy,
+			&realisasi.CreatedAt,
+			&realisasi.UpdatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, realisasi)
+	}
+	return result, rows.Err()
+}
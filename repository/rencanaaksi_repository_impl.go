@@ -148,9 +148,15 @@ func (repository *RencanaAksiRepositoryImpl) Update(ctx context.Context, tx *sql
 	return rencanaAksi, nil
 }
 func (repository *RencanaAksiRepositoryImpl) Delete(ctx context.Context, tx *sql.Tx, id string) error {
-	// Hapus terlebih dahulu dari tb_pelaksanaan_rencana_aksi
+	// Hapus terlebih dahulu progres dan tb_pelaksanaan_rencana_aksi
+	scriptProgres := "DELETE FROM tb_progres_pelaksanaan_rencana_aksi WHERE pelaksanaan_rencana_aksi_id IN (SELECT id FROM tb_pelaksanaan_rencana_aksi WHERE rencana_aksi_id = ?)"
+	_, err := tx.ExecContext(ctx, scriptProgres, id)
+	if err != nil {
+		return fmt.Errorf("gagal menghapus data dari tb_progres_pelaksanaan_rencana_aksi: %v", err)
+	}
+
 	scriptPelaksanaan := "DELETE FROM tb_pelaksanaan_rencana_aksi WHERE rencana_aksi_id = ?"
-	_, err := tx.ExecContext(ctx, scriptPelaksanaan, id)
+	_, err = tx.ExecContext(ctx, scriptPelaksanaan, id)
 	if err != nil {
 		return fmt.Errorf("gagal menghapus data dari tb_pelaksanaan_rencana_aksi: %v", err)
 	}
@@ -0,0 +1,17 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type RencanaKinerjaVerifikasiRepository interface {
+	FindRencanaKinerja(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) (domain.RencanaKinerja, error)
+	// UpdateStatus hanya mengubah bila status masih sama dengan dariStatus, false berarti sudah diubah pengguna lain
+	UpdateStatus(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string, dariStatus string, keStatus string) (bool, error)
+	Create(ctx context.Context, tx *sql.Tx, verifikasi domain.RencanaKinerjaVerifikasi) (domain.RencanaKinerjaVerifikasi, error)
+	FindByRencanaKinerjaId(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) ([]domain.RencanaKinerjaVerifikasi, error)
+	FindRevisi(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) (int, error)
+	FindInbox(ctx context.Context, tx *sql.Tx, nipAtasan string, tahun string) ([]domain.RencanaKinerjaInbox, error)
+}
@@ -0,0 +1,159 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+)
+
+type RencanaKinerjaVerifikasiRepositoryImpl struct{}
+
+func NewRencanaKinerjaVerifikasiRepositoryImpl() *RencanaKinerjaVerifikasiRepositoryImpl {
+	return &RencanaKinerjaVerifikasiRepositoryImpl{}
+}
+
+func (repository *RencanaKinerjaVerifikasiRepositoryImpl) FindRencanaKinerja(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) (domain.RencanaKinerja, error) {
+	script := `
+		SELECT id, nama_rencana_kinerja, COALESCE(tahun, ''), COALESCE(status_rencana_kinerja, ''),
+			COALESCE(catatan, ''), COALESCE(kode_opd, ''), COALESCE(pegawai_id, '')
+		FROM tb_rencana_kinerja
+		WHERE id = ?
+	`
+	var rencanaKinerja domain.RencanaKinerja
+	err := tx.QueryRowContext(ctx, script, rencanaKinerjaId).Scan(
+		&rencanaKinerja.Id,
+		&rencanaKinerja.NamaRencanaKinerja,
+		&rencanaKinerja.Tahun,
+		&rencanaKinerja.StatusRencanaKinerja,
+		&rencanaKinerja.Catatan,
+		&rencanaKinerja.KodeOpd,
+		&rencanaKinerja.PegawaiId,
+	)
+	return rencanaKinerja, err
+}
+
+func (repository *RencanaKinerjaVerifikasiRepositoryImpl) UpdateStatus(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string, dariStatus string, keStatus string) (bool, error) {
+	script := "UPDATE tb_rencana_kinerja SET status_rencana_kinerja = ? WHERE id = ? AND COALESCE(status_rencana_kinerja, '') = ?"
+	result, err := tx.ExecContext(ctx, script, keStatus, rencanaKinerjaId, dariStatus)
+	if err != nil {
+		return false, fmt.Errorf("RencanaKinerjaVerifikasiRepository.UpdateStatus: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("RencanaKinerjaVerifikasiRepository.UpdateStatus: %w", err)
+	}
+	return rows > 0, nil
+}
+
+func (repository *RencanaKinerjaVerifikasiRepositoryImpl) Create(ctx context.Context, tx *sql.Tx, verifikasi domain.RencanaKinerjaVerifikasi) (domain.RencanaKinerjaVerifikasi, error) {
+	script := `
+		INSERT INTO tb_rencana_kinerja_verifikasi
+			(rencana_kinerja_id, revisi, dari_status, ke_status, aksi, catatan, actor_nip, actor_peran)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)
+	`
+	result, err := tx.ExecContext(ctx, script,
+		verifikasi.RencanaKinerjaId,
+		verifikasi.Revisi,
+		verifikasi.DariStatus,
+		verifikasi.KeStatus,
+		verifikasi.Aksi,
+		verifikasi.Catatan,
+		verifikasi.ActorNip,
+		verifikasi.ActorPeran,
+	)
+	if err != nil {
+		return domain.RencanaKinerjaVerifikasi{}, fmt.Errorf("RencanaKinerjaVerifikasiRepository.Create: %w", err)
+	}
+	verifikasi.Id, err = result.LastInsertId()
+	if err != nil {
+		return domain.RencanaKinerjaVerifikasi{}, fmt.Errorf("RencanaKinerjaVerifikasiRepository.Create: %w", err)
+	}
+	return verifikasi, nil
+}
+
+func (repository *RencanaKinerjaVerifikasiRepositoryImpl) FindByRencanaKinerjaId(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) ([]domain.RencanaKinerjaVerifikasi, error) {
+	script := `
+		SELECT id, rencana_kinerja_id, revisi, dari_status, ke_status, aksi, COALESCE(catatan, ''),
+			COALESCE(actor_nip, ''), COALESCE(actor_peran, ''), created_at
+		FROM tb_rencana_kinerja_verifikasi
+		WHERE rencana_kinerja_id = ?
+		ORDER BY created_at DESC, id DESC
+	`
+	rows, err := tx.QueryContext(ctx, script, rencanaKinerjaId)
+	if err != nil {
+		return nil, fmt.Errorf("RencanaKinerjaVerifikasiRepository.FindByRencanaKinerjaId: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.RencanaKinerjaVerifikasi
+	for rows.Next() {
+		var verifikasi domain.RencanaKinerjaVerifikasi
+		err := rows.Scan(
+			&verifikasi.Id,
+			&verifikasi.RencanaKinerjaId,
+			&verifikasi.Revisi,
+			&verifikasi.DariStatus,
+			&verifikasi.KeStatus,
+			&verifikasi.Aksi,
+			&verifikasi.Catatan,
+			&verifikasi.ActorNip,
+			&verifikasi.ActorPeran,
+			&verifikasi.CreatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("RencanaKinerjaVerifikasiRepository.FindByRencanaKinerjaId: %w", err)
+		}
+		result = append(result, verifikasi)
+	}
+	return result, rows.Err()
+}
+
+func (repository *RencanaKinerjaVerifikasiRepositoryImpl) FindRevisi(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) (int, error) {
+	script := "SELECT COALESCE(MAX(revisi), 0) FROM tb_rencana_kinerja_verifikasi WHERE rencana_kinerja_id = ?"
+	var revisi int
+	if err := tx.QueryRowContext(ctx, script, rencanaKinerjaId).Scan(&revisi); err != nil {
+		return 0, fmt.Errorf("RencanaKinerjaVerifikasiRepository.FindRevisi: %w", err)
+	}
+	return revisi, nil
+}
+
+func (repository *RencanaKinerjaVerifikasiRepositoryImpl) FindInbox(ctx context.Context, tx *sql.Tx, nipAtasan string, tahun string) ([]domain.RencanaKinerjaInbox, error) {
+	script := `
+		SELECT rk.id, rk.nama_rencana_kinerja, rk.tahun, rk.kode_opd, rk.pegawai_id, COALESCE(p.nama, ''),
+			COALESCE((SELECT MAX(v.revisi) FROM tb_rencana_kinerja_verifikasi v WHERE v.rencana_kinerja_id = rk.id), 0),
+			COALESCE((SELECT MAX(v.created_at) FROM tb_rencana_kinerja_verifikasi v
+				WHERE v.rencana_kinerja_id = rk.id AND v.ke_status = rk.status_rencana_kinerja), rk.updated_at) AS diajukan_pada
+		FROM tb_rencana_kinerja rk
+		JOIN struktur_organisasi so
+			ON so.nip_bawahan = rk.pegawai_id AND so.kode_opd = rk.kode_opd AND so.tahun = rk.tahun
+		LEFT JOIN tb_pegawai p ON p.nip = rk.pegawai_id
+		WHERE so.nip_atasan = ? AND rk.tahun = ? AND rk.status_rencana_kinerja = ?
+		ORDER BY diajukan_pada ASC, rk.id ASC
+	`
+	rows, err := tx.QueryContext(ctx, script, nipAtasan, tahun, domain.StatusRekinMenungguVerifikasi)
+	if err != nil {
+		return nil, fmt.Errorf("RencanaKinerjaVerifikasiRepository.FindInbox: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.RencanaKinerjaInbox
+	for rows.Next() {
+		var inbox domain.RencanaKinerjaInbox
+		err := rows.Scan(
+			&inbox.RencanaKinerja.Id,
+			&inbox.RencanaKinerja.NamaRencanaKinerja,
+			&inbox.RencanaKinerja.Tahun,
+			&inbox.RencanaKinerja.KodeOpd,
+			&inbox.RencanaKinerja.PegawaiId,
+			&inbox.RencanaKinerja.NamaPegawai,
+			&inbox.Revisi,
+			&inbox.DiajukanPada,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("RencanaKinerjaVerifikasiRepository.FindInbox: %w", err)
+		}
+		result = append(result, inbox)
+	}
+	return result, rows.Err()
+}
@@ -1669,7 +1669,9 @@ func (r *SasaranOpdRepositoryImpl) FindIndikatorByKodeIndikator(
                COALESCE(rumus_perhitungan, ''),
                COALESCE(sumber_data, ''),
                COALESCE(definisi_operasional, ''),
-               COALESCE(jenis, '')
+               COALESCE(jenis, ''),
+               COALESCE(sasaran_opd_id, 0),
+               COALESCE((SELECT t.tahun FROM tb_target t WHERE t.indikator_id = kode_indikator LIMIT 1), '')
         FROM tb_indikator_matrix
         WHERE kode_indikator = ?`,
 		kodeIndikator,
@@ -1682,6 +1684,8 @@ func (r *SasaranOpdRepositoryImpl) FindIndikatorByKodeIndikator(
 		&indikator.SumberData,
 		&indikator.DefinisiOperasional,
 		&indikator.Jenis,
+		&indikator.SasaranOpdId,
+		&indikator.Tahun,
 	)
 	if err != nil {
 		return domain.Indikator{}, err
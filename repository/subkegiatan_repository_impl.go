@@ -129,7 +129,7 @@ func (repository *SubKegiatanRepositoryImpl) Update(ctx context.Context, tx *sql
 }
 
 func (repository *SubKegiatanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domain.SubKegiatan, error) {
-	script := `SELECT id, kode_subkegiatan, nama_subkegiatan, created_at FROM tb_subkegiatan ORDER BY kode_subkegiatan ASC`
+	script := `SELECT id, kode_subkegiatan, nama_subkegiatan, is_deprecated, created_at FROM tb_subkegiatan ORDER BY kode_subkegiatan ASC`
 
 	rows, err := tx.QueryContext(ctx, script)
 	if err != nil {
@@ -140,7 +140,7 @@ func (repository *SubKegiatanRepositoryImpl) FindAll(ctx context.Context, tx *sq
 	subKegiatans := make([]domain.SubKegiatan, 0) // Bisa tambahkan kapasitas awal jika ada perkiraan jumlah data
 	for rows.Next() {
 		var subKegiatan domain.SubKegiatan
-		if err := rows.Scan(&subKegiatan.Id, &subKegiatan.KodeSubKegiatan, &subKegiatan.NamaSubKegiatan, &subKegiatan.CreatedAt); err != nil {
+		if err := rows.Scan(&subKegiatan.Id, &subKegiatan.KodeSubKegiatan, &subKegiatan.NamaSubKegiatan, &subKegiatan.IsDeprecated, &subKegiatan.CreatedAt); err != nil {
 			return nil, err
 		}
 		subKegiatans = append(subKegiatans, subKegiatan)
@@ -333,7 +333,7 @@ func (repository *SubKegiatanRepositoryImpl) FindSubKegiatanKAK(ctx context.Cont
 
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return domain.SubKegiatanKAKQuery{}, fmt.Errorf("data tidak ditemukan untuk kode_opd: %s, kode: %s, tahun: %s", kodeOpd, kode, tahun)
+			return domain.SubKegiatanKAKQuery{}, fmt.Errorf("data tidak ditemukan untuk kode_opd: %s, kode: %s, tahun: %s: %w", kodeOpd, kode, tahun, sql.ErrNoRows)
 		}
 		return domain.SubKegiatanKAKQuery{}, fmt.Errorf("gagal mengambil data subkegiatan KAK: %v", err)
 	}
@@ -36,7 +36,7 @@ func (repository *UrusanRepositoryImpl) Update(ctx context.Context, tx *sql.Tx,
 }
 
 func (repository *UrusanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx) ([]domainmaster.Urusan, error) {
-	script := "SELECT id, kode_urusan, nama_urusan, created_at FROM tb_urusan"
+	script := "SELECT id, kode_urusan, nama_urusan, is_deprecated, created_at FROM tb_urusan"
 	rows, err := tx.QueryContext(ctx, script)
 	if err != nil {
 		return []domainmaster.Urusan{}, err
@@ -47,7 +47,7 @@ func (repository *UrusanRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx)
 	var urusans []domainmaster.Urusan
 	for rows.Next() {
 		urusan := domainmaster.Urusan{}
-		rows.Scan(&urusan.Id, &urusan.KodeUrusan, &urusan.NamaUrusan, &urusan.CreatedAt)
+		rows.Scan(&urusan.Id, &urusan.KodeUrusan, &urusan.NamaUrusan, &urusan.IsDeprecated, &urusan.CreatedAt)
 		urusans = append(urusans, urusan)
 	}
 
@@ -196,8 +196,10 @@ func (repository *UrusanRepositoryImpl) FindUrusanAndBidangByKodeOpd(ctx context
             u.id,
             u.kode_urusan,
             u.nama_urusan,
+            u.is_deprecated,
             bu.kode_bidang_urusan,
-            bu.nama_bidang_urusan
+            bu.nama_bidang_urusan,
+            bu.is_deprecated
         FROM 
             tb_urusan u
             INNER JOIN tb_bidang_urusan bu ON u.kode_urusan = LEFT(bu.kode_bidang_urusan, 1)
@@ -228,9 +230,10 @@ func (repository *UrusanRepositoryImpl) FindUrusanAndBidangByKodeOpd(ctx context
 		var (
 			id, kodeUrusan, namaUrusan         string
 			kodeBidangUrusan, namaBidangUrusan string
+			urusanDeprecated, bidangDeprecated bool
 		)
 
-		err := rows.Scan(&id, &kodeUrusan, &namaUrusan, &kodeBidangUrusan, &namaBidangUrusan)
+		err := rows.Scan(&id, &kodeUrusan, &namaUrusan, &urusanDeprecated, &kodeBidangUrusan, &namaBidangUrusan, &bidangDeprecated)
 		if err != nil {
 			return nil, fmt.Errorf("error scanning row: %v", err)
 		}
@@ -245,20 +248,23 @@ func (repository *UrusanRepositoryImpl) FindUrusanAndBidangByKodeOpd(ctx context
 					KodeBidangUrusan: kodeBidangUrusan,
 					NamaBidangUrusan: namaBidangUrusan,
 					Tahun:            "",
+					IsDeprecated:     bidangDeprecated,
 				})
 				fmt.Printf("Added bidang %s to existing urusan %s\n", kodeBidangUrusan, kodeUrusan)
 			}
 		} else {
 			// Buat urusan baru
 			newUrusan := &domainmaster.Urusan{
-				Id:         id,
-				KodeUrusan: kodeUrusan,
-				NamaUrusan: namaUrusan,
+				Id:           id,
+				KodeUrusan:   kodeUrusan,
+				NamaUrusan:   namaUrusan,
+				IsDeprecated: urusanDeprecated,
 				BidangUrusan: []domainmaster.BidangUrusan{
 					{
 						KodeBidangUrusan: kodeBidangUrusan,
 						NamaBidangUrusan: namaBidangUrusan,
 						Tahun:            "",
+						IsDeprecated:     bidangDeprecated,
 					},
 				},
 			}
@@ -64,6 +64,12 @@ func (repository *UserRepositoryImpl) Update(ctx context.Context, tx *sql.Tx, us
 	return repository.FindById(ctx, tx, users.Id)
 }
 
+func (repository *UserRepositoryImpl) UpdatePassword(ctx context.Context, tx *sql.Tx, userId int, hashedPassword string) error {
+	script := "UPDATE tb_users SET password = ? WHERE id = ?"
+	_, err := tx.ExecContext(ctx, script, hashedPassword, userId)
+	return err
+}
+
 func (repository *UserRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, kodeOpd string) ([]domain.Users, error) {
 	script := `
         SELECT DISTINCT u.id, u.nip, u.email, u.is_active, ur.role_id, r.role 
@@ -9,6 +9,7 @@ import (
 type UserRepository interface {
 	Create(ctx context.Context, tx *sql.Tx, users domain.Users) (domain.Users, error)
 	Update(ctx context.Context, tx *sql.Tx, users domain.Users) (domain.Users, error)
+	UpdatePassword(ctx context.Context, tx *sql.Tx, userId int, hashedPassword string) error
 	FindAll(ctx context.Context, tx *sql.Tx, kodeOpd string) ([]domain.Users, error)
 	FindById(ctx context.Context, tx *sql.Tx, id int) (domain.Users, error)
 	FindByNip(ctx context.Context, tx *sql.Tx, nip string) (domain.Users, error)
@@ -14,4 +14,5 @@ type UsulanMusrebangRepository interface {
 	Delete(ctx context.Context, tx *sql.Tx, idUsulan string) error
 	CreateRekin(ctx context.Context, tx *sql.Tx, idUsulan string, rekinId string) error
 	DeleteUsulanTerpilih(ctx context.Context, tx *sql.Tx, idUsulan string) error
+	FindByTahun(ctx context.Context, tx *sql.Tx, tahun string) ([]domain.UsulanMusrebang, error)
 }
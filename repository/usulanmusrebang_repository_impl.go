@@ -134,3 +134,23 @@ func (repository *UsulanMusrebangRepositoryImpl) DeleteUsulanTerpilih(ctx contex
 
 	return nil
 }
+
+// FindByTahun usulan seluruh OPD pada satu tahun, dipakai untuk cek duplikat saat import
+func (repository *UsulanMusrebangRepositoryImpl) FindByTahun(ctx context.Context, tx *sql.Tx, tahun string) ([]domain.UsulanMusrebang, error) {
+	script := "SELECT id, usulan, COALESCE(alamat, ''), tahun, kode_opd FROM tb_usulan_musrebang WHERE tahun = ?"
+	rows, err := tx.QueryContext(ctx, script, tahun)
+	if err != nil {
+		return nil, fmt.Errorf("error saat mencari usulan musrebang: %v", err)
+	}
+	defer rows.Close()
+
+	var result []domain.UsulanMusrebang
+	for rows.Next() {
+		var usulan domain.UsulanMusrebang
+		if err := rows.Scan(&usulan.Id, &usulan.Usulan, &usulan.Alamat, &usulan.Tahun, &usulan.KodeOpd); err != nil {
+			return nil, err
+		}
+		result = append(result, usulan)
+	}
+	return result, rows.Err()
+}
@@ -0,0 +1,22 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+)
+
+type UsulanPipelineRepository interface {
+	// FindAll usulan keempat jenis, jenisUsulan kosong berarti semua jenis
+	FindAll(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, jenisUsulan string) ([]domain.UsulanPipeline, error)
+	// FindPublik satu halaman usulan satu tahun untuk halaman publik beserta total sesuai filter,
+	// alamat dicocokkan sebagian dan hanya berlaku untuk jenis yang punya kolom alamat
+	FindPublik(ctx context.Context, tx *sql.Tx, tahun string, kodeOpd string, alamat string, jenisUsulan []string, limit int, offset int) ([]domain.UsulanPipeline, int, error)
+	FindById(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string) (domain.UsulanPipeline, error)
+	// FindSubKegiatanByRekinIds subkegiatan terpilih per rekin, pagu diambil dari tb_pagu dengan jenis jenisPagu
+	FindSubKegiatanByRekinIds(ctx context.Context, tx *sql.Tx, rekinIds []string, jenisPagu string) (map[string][]domain.UsulanSubKegiatan, error)
+	// UpdateTahap hanya berhasil jika tahap tersimpan masih dariTahap, dariTahap kosong berarti belum ada baris tahap
+	UpdateTahap(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string, dariTahap string, keTahap string, alasan string, updatedBy string) (bool, error)
+	CreateRiwayat(ctx context.Context, tx *sql.Tx, riwayat domain.UsulanTahapRiwayat) (domain.UsulanTahapRiwayat, error)
+	FindRiwayat(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string) ([]domain.UsulanTahapRiwayat, error)
+}
@@ -0,0 +1,259 @@
+package repository
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+type UsulanPipelineRepositoryImpl struct{}
+
+func NewUsulanPipelineRepositoryImpl() *UsulanPipelineRepositoryImpl {
+	return &UsulanPipelineRepositoryImpl{}
+}
+
+// sumberUsulanPipeline tabel tiap jenis usulan, mandatori dan inisiatif tidak punya kolom alamat
+var sumberUsulanPipeline = []struct {
+	jenis  string
+	tabel  string
+	alamat string
+}{
+	{domain.JenisUsulanMusrebang, "tb_usulan_musrebang", "COALESCE(u.alamat, '')"},
+	{domain.JenisUsulanPokokPikiran, "tb_usulan_pokok_pikiran", "COALESCE(u.alamat, '')"},
+	{domain.JenisUsulanMandatori, "tb_usulan_mandatori", "''"},
+	{domain.JenisUsulanInisiatif, "tb_usulan_inisiatif", "''"},
+}
+
+// queryUsulanPipeline UNION ALL tabel usulan dengan kondisi yang sama di setiap cabang, jenisUsulan kosong berarti keempat tabel
+func queryUsulanPipeline(jenisUsulan []string, kondisi string, args []interface{}) (string, []interface{}) {
+	var cabang []string
+	var semuaArgs []interface{}
+	for _, sumber := range sumberUsulanPipeline {
+		if len(jenisUsulan) > 0 && !slices.Contains(jenisUsulan, sumber.jenis) {
+			continue
+		}
+		cabang = append(cabang, fmt.Sprintf(`
+			SELECT '%s' AS jenis_usulan, u.id AS usulan_id, COALESCE(u.usulan, '') AS usulan, COALESCE(u.uraian, '') AS uraian,
+			       %s AS alamat, COALESCE(u.tahun, '') AS tahun, COALESCE(u.kode_opd, '') AS kode_opd,
+			       COALESCE(u.rekin_id, '') AS rekin_id, COALESCE(u.is_active, FALSE) AS is_active,
+			       COALESCE(u.status, '') AS status, u.created_at
+			FROM %s u
+			WHERE %s`, sumber.jenis, sumber.alamat, sumber.tabel, kondisi))
+		semuaArgs = append(semuaArgs, args...)
+	}
+	script := fmt.Sprintf(`
+		SELECT p.jenis_usulan, p.usulan_id, p.usulan, p.uraian, p.alamat, p.tahun, p.kode_opd,
+		       COALESCE(o.nama_opd, ''), p.rekin_id, COALESCE(r.nama_rencana_kinerja, ''),
+		       p.is_active, p.status, COALESCE(t.tahap, ''), COALESCE(t.alasan, ''), p.created_at
+		FROM (%s) p
+		LEFT JOIN tb_operasional_daerah o ON o.kode_opd = p.kode_opd
+		LEFT JOIN tb_rencana_kinerja r ON r.id = p.rekin_id AND p.rekin_id <> ''
+		LEFT JOIN tb_usulan_tahap t ON t.jenis_usulan = p.jenis_usulan AND t.usulan_id = p.usulan_id
+		ORDER BY p.jenis_usulan, p.created_at, p.usulan_id`, strings.Join(cabang, "\n\t\t\tUNION ALL"))
+	return script, semuaArgs
+}
+
+func daftarJenisUsulan(jenisUsulan string) []string {
+	if jenisUsulan == "" {
+		return nil
+	}
+	return []string{jenisUsulan}
+}
+
+func scanUsulanPipeline(rows *sql.Rows) ([]domain.UsulanPipeline, error) {
+	var result []domain.UsulanPipeline
+	for rows.Next() {
+		var usulan domain.UsulanPipeline
+		err := rows.Scan(
+			&usulan.JenisUsulan,
+			&usulan.Id,
+			&usulan.Usulan,
+			&usulan.Uraian,
+			&usulan.Alamat,
+			&usulan.Tahun,
+			&usulan.KodeOpd,
+			&usulan.NamaOpd,
+			&usulan.RekinId,
+			&usulan.NamaRencanaKinerja,
+			&usulan.IsActive,
+			&usulan.Status,
+			&usulan.Tahap,
+			&usulan.AlasanTahap,
+			&usulan.CreatedAt,
+		)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, usulan)
+	}
+	return result, rows.Err()
+}
+
+func (repository *UsulanPipelineRepositoryImpl) FindAll(ctx context.Context, tx *sql.Tx, kodeOpd string, tahun string, jenisUsulan string) ([]domain.UsulanPipeline, error) {
+	script, args := queryUsulanPipeline(daftarJenisUsulan(jenisUsulan), "u.kode_opd = ? AND u.tahun = ?", []interface{}{kodeOpd, tahun})
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, fmt.Errorf("UsulanPipelineRepository.FindAll: %w", err)
+	}
+	defer rows.Close()
+	return scanUsulanPipeline(rows)
+}
+
+func (repository *UsulanPipelineRepositoryImpl) FindPublik(ctx context.Context, tx *sql.Tx, tahun string, kodeOpd string, alamat string, jenisUsulan []string, limit int, offset int) ([]domain.UsulanPipeline, int, error) {
+	kondisi := "u.tahun = ?"
+	args := []interface{}{tahun}
+	if kodeOpd != "" {
+		kondisi += " AND u.kode_opd = ?"
+		args = append(args, kodeOpd)
+	}
+	if alamat != "" {
+		kondisi += " AND u.alamat LIKE ?"
+		args = append(args, "%"+alamat+"%")
+	}
+	script, args := queryUsulanPipeline(jenisUsulan, kondisi, args)
+
+	var total int
+	if err := tx.QueryRowContext(ctx, "SELECT COUNT(*) FROM ("+script+") halaman", args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("UsulanPipelineRepository.FindPublik: %w", err)
+	}
+	rows, err := tx.QueryContext(ctx, script+" LIMIT ? OFFSET ?", append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("UsulanPipelineRepository.FindPublik: %w", err)
+	}
+	defer rows.Close()
+	result, err := scanUsulanPipeline(rows)
+	return result, total, err
+}
+
+func (repository *UsulanPipelineRepositoryImpl) FindById(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string) (domain.UsulanPipeline, error) {
+	script, args := queryUsulanPipeline([]string{jenisUsulan}, "u.id = ?", []interface{}{usulanId})
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return domain.UsulanPipeline{}, fmt.Errorf("UsulanPipelineRepository.FindById: %w", err)
+	}
+	defer rows.Close()
+
+	result, err := scanUsulanPipeline(rows)
+	if err != nil {
+		return domain.UsulanPipeline{}, err
+	}
+	if len(result) == 0 {
+		return domain.UsulanPipeline{}, sql.ErrNoRows
+	}
+	return result[0], nil
+}
+
+func (repository *UsulanPipelineRepositoryImpl) FindSubKegiatanByRekinIds(ctx context.Context, tx *sql.Tx, rekinIds []string, jenisPagu string) (map[string][]domain.UsulanSubKegiatan, error) {
+	result := make(map[string][]domain.UsulanSubKegiatan)
+	if len(rekinIds) == 0 {
+		return result, nil
+	}
+
+	placeholders := make([]string, len(rekinIds))
+	args := []interface{}{jenisPagu}
+	for i, id := range rekinIds {
+		placeholders[i] = "?"
+		args = append(args, id)
+	}
+	script := fmt.Sprintf(`
+		SELECT st.rekin_id, st.kode_subkegiatan, COALESCE(sk.nama_subkegiatan, ''), COALESCE(pg.pagu, 0)
+		FROM tb_subkegiatan_terpilih st
+		JOIN tb_rencana_kinerja r ON r.id = st.rekin_id
+		LEFT JOIN tb_subkegiatan sk ON sk.kode_subkegiatan = st.kode_subkegiatan
+		LEFT JOIN tb_pagu pg ON pg.kode_subkegiatan = st.kode_subkegiatan
+		     AND pg.kode_opd = r.kode_opd
+		     AND pg.tahun = r.tahun
+		     AND pg.jenis = ?
+		WHERE st.rekin_id IN (%s) AND COALESCE(st.kode_subkegiatan, '') <> ''
+		ORDER BY st.rekin_id, st.kode_subkegiatan`, strings.Join(placeholders, ","))
+	rows, err := tx.QueryContext(ctx, script, args...)
+	if err != nil {
+		return nil, fmt.Errorf("UsulanPipelineRepository.FindSubKegiatanByRekinIds: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var sub domain.UsulanSubKegiatan
+		if err := rows.Scan(&sub.RekinId, &sub.KodeSubKegiatan, &sub.NamaSubKegiatan, &sub.Pagu); err != nil {
+			return nil, err
+		}
+		result[sub.RekinId] = append(result[sub.RekinId], sub)
+	}
+	return result, rows.Err()
+}
+
+func (repository *UsulanPipelineRepositoryImpl) UpdateTahap(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string, dariTahap string, keTahap string, alasan string, updatedBy string) (bool, error) {
+	if dariTahap == "" {
+		// INSERT IGNORE: baris yang sudah dibuat pengguna lain membuat RowsAffected 0
+		result, err := tx.ExecContext(ctx, `
+			INSERT IGNORE INTO tb_usulan_tahap (jenis_usulan, usulan_id, tahap, alasan, updated_by)
+			VALUES (?, ?, ?, ?, ?)`, jenisUsulan, usulanId, keTahap, alasan, updatedBy)
+		if err != nil {
+			return false, fmt.Errorf("UsulanPipelineRepository.UpdateTahap: %w", err)
+		}
+		rows, err := result.RowsAffected()
+		return rows > 0, err
+	}
+
+	result, err := tx.ExecContext(ctx, `
+		UPDATE tb_usulan_tahap SET tahap = ?, alasan = ?, updated_by = ?
+		WHERE jenis_usulan = ? AND usulan_id = ? AND tahap = ?`, keTahap, alasan, updatedBy, jenisUsulan, usulanId, dariTahap)
+	if err != nil {
+		return false, fmt.Errorf("UsulanPipelineRepository.UpdateTahap: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	return rows > 0, err
+}
+
+func (repository *UsulanPipelineRepositoryImpl) CreateRiwayat(ctx context.Context, tx *sql.Tx, riwayat domain.UsulanTahapRiwayat) (domain.UsulanTahapRiwayat, error) {
+	result, err := tx.ExecContext(ctx, `
+		INSERT INTO tb_usulan_tahap_riwayat
+			(jenis_usulan, usulan_id, dari_tahap, ke_tahap, aksi, alasan, actor_nip, actor_role)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?)`,
+		riwayat.JenisUsulan,
+		riwayat.UsulanId,
+		riwayat.DariTahap,
+		riwayat.KeTahap,
+		riwayat.Aksi,
+		riwayat.Alasan,
+		riwayat.ActorNip,
+		riwayat.ActorRole,
+	)
+	if err != nil {
+		return domain.UsulanTahapRiwayat{}, fmt.Errorf("UsulanPipelineRepository.CreateRiwayat: %w", err)
+	}
+	id, err := result.LastInsertId()
+	if err != nil {
+		return domain.UsulanTahapRiwayat{}, err
+	}
+	riwayat.Id = id
+	return riwayat, nil
+}
+
+func (repository *UsulanPipelineRepositoryImpl) FindRiwayat(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string) ([]domain.UsulanTahapRiwayat, error) {
+	rows, err := tx.QueryContext(ctx, `
+		SELECT id, jenis_usulan, usulan_id, dari_tahap, ke_tahap, aksi, COALESCE(alasan, ''),
+		       COALESCE(actor_nip, ''), COALESCE(actor_role, ''), created_at
+		FROM tb_usulan_tahap_riwayat
+		WHERE jenis_usulan = ? AND usulan_id = ?
+		ORDER BY created_at DESC, id DESC`, jenisUsulan, usulanId)
+	if err != nil {
+		return nil, fmt.Errorf("UsulanPipelineRepository.FindRiwayat: %w", err)
+	}
+	defer rows.Close()
+
+	var result []domain.UsulanTahapRiwayat
+	for rows.Next() {
+		var riwayat domain.UsulanTahapRiwayat
+		err := rows.Scan(&riwayat.Id, &riwayat.JenisUsulan, &riwayat.UsulanId, &riwayat.DariTahap, &riwayat.KeTahap,
+			&riwayat.Aksi, &riwayat.Alasan, &riwayat.ActorNip, &riwayat.ActorRole, &riwayat.CreatedAt)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, riwayat)
+	}
+	return result, rows.Err()
+}
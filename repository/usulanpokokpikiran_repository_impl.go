@@ -137,3 +137,23 @@ func (repository *UsulanPokokPikiranRepositoryImpl) DeleteUsulanTerpilih(ctx con
 
 	return nil
 }
+
+// FindByTahun usulan seluruh OPD pada satu tahun, dipakai untuk cek duplikat saat import
+func (repository *UsulanPokokPikiranRepositoryImpl) FindByTahun(ctx context.Context, tx *sql.Tx, tahun string) ([]domain.UsulanPokokPikiran, error) {
+	script := "SELECT id, usulan, COALESCE(alamat, ''), tahun, kode_opd FROM tb_usulan_pokok_pikiran WHERE tahun = ?"
+	rows, err := tx.QueryContext(ctx, script, tahun)
+	if err != nil {
+		return nil, fmt.Errorf("error saat mencari usulan pokok pikiran: %v", err)
+	}
+	defer rows.Close()
+
+	var result []domain.UsulanPokokPikiran
+	for rows.Next() {
+		var usulan domain.UsulanPokokPikiran
+		if err := rows.Scan(&usulan.Id, &usulan.Usulan, &usulan.Alamat, &usulan.Tahun, &usulan.KodeOpd); err != nil {
+			return nil, err
+		}
+		result = append(result, usulan)
+	}
+	return result, rows.Err()
+}
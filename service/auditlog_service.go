@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/auditlog"
+)
+
+// aksi yang dicatat di tb_audit_log
+const (
+	AuditActionCreate = "create"
+	AuditActionUpdate = "update"
+	AuditActionDelete = "delete"
+)
+
+// jenis entitas yang dicatat di tb_audit_log, dipakai sebagai :entity di /audit/:entity/:id
+const (
+	AuditEntityRencanaKinerja   = "rencana_kinerja"
+	AuditEntityIndikatorRenstra = "indikator_renstra"
+	AuditEntityIndikatorRenja   = "indikator_renja"
+	AuditEntityAnggaranRenstra  = "anggaran_renstra"
+	AuditEntityAnggaranRenja    = "anggaran_renja"
+	AuditEntityRincianBelanja   = "rincian_belanja"
+	AuditEntityCrosscutting     = "crosscutting_opd"
+	AuditEntityIndikatorPokin   = "indikator_pokin"
+	AuditEntityManualIK         = "manual_ik"
+)
+
+type AuditLogService interface {
+	FindByEntity(ctx context.Context, entityType string, entityId string) ([]auditlog.AuditLogResponse, error)
+	FindByOpd(ctx context.Context, kodeOpd string, tahun string) ([]auditlog.AuditLogResponse, error)
+}
@@ -0,0 +1,152 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/auditlog"
+	"ekak_kabupaten_madiun/repository"
+	"encoding/json"
+	"log"
+)
+
+type AuditLogServiceImpl struct {
+	AuditLogRepository repository.AuditLogRepository
+	OpdGuardService    OpdGuardService
+	DB                 *sql.DB
+}
+
+func NewAuditLogServiceImpl(auditLogRepository repository.AuditLogRepository, opdGuardService OpdGuardService, DB *sql.DB) *AuditLogServiceImpl {
+	return &AuditLogServiceImpl{
+		AuditLogRepository: auditLogRepository,
+		OpdGuardService:    opdGuardService,
+		DB:                 DB,
+	}
+}
+
+// auditEntry satu perubahan data yang akan dicatat ke tb_audit_log
+type auditEntry struct {
+	EntityType string
+	EntityId   string
+	Action     string
+	KodeOpd    string
+	Tahun      string
+	Before     interface{}
+	After      interface{}
+}
+
+// newAuditLog menyusun domain.AuditLog dari entry dan actor di JWTClaim context
+func newAuditLog(ctx context.Context, entry auditEntry) domain.AuditLog {
+	auditLog := domain.AuditLog{
+		EntityType: entry.EntityType,
+		EntityId:   entry.EntityId,
+		Action:     entry.Action,
+		KodeOpd:    entry.KodeOpd,
+		Tahun:      entry.Tahun,
+		BeforeData: auditJSON(entry.Before),
+		AfterData:  auditJSON(entry.After),
+	}
+	if claims, ok := helper.GetUserClaims(ctx); ok {
+		auditLog.ActorUserId = claims.UserId
+		auditLog.ActorNip = claims.Nip
+		auditLog.ActorKodeOpd = claims.KodeOpd
+	}
+	return auditLog
+}
+
+func auditJSON(data interface{}) sql.NullString {
+	if data == nil {
+		return sql.NullString{}
+	}
+	raw, err := json.Marshal(data)
+	if err != nil {
+		log.Printf("[AuditLog] gagal marshal data audit: %v", err)
+		return sql.NullString{}
+	}
+	return sql.NullString{String: string(raw), Valid: true}
+}
+
+// recordAudit mencatat perubahan di transaksi yang sama dengan perubahan datanya.
+// Kegagalan pencatatan hanya di-log agar tidak membatalkan perubahan utama.
+func recordAudit(ctx context.Context, tx *sql.Tx, auditLogRepository repository.AuditLogRepository, entry auditEntry) {
+	if auditLogRepository == nil {
+		return
+	}
+	if err := auditLogRepository.Create(ctx, tx, newAuditLog(ctx, entry)); err != nil {
+		log.Printf("[AuditLog] gagal mencatat %s %s %s: %v", entry.Action, entry.EntityType, entry.EntityId, err)
+	}
+}
+
+func (service *AuditLogServiceImpl) FindByEntity(ctx context.Context, entityType string, entityId string) ([]auditlog.AuditLogResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	auditLogs, err := service.AuditLogRepository.FindByEntity(ctx, tx, entityType, entityId)
+	if err != nil {
+		return nil, err
+	}
+
+	// riwayat entitas hanya boleh dibaca oleh OPD pemilik data
+	checked := make(map[string]bool)
+	for _, auditLog := range auditLogs {
+		key := auditLog.KodeOpd + "|" + auditLog.Tahun
+		if checked[key] {
+			continue
+		}
+		checked[key] = true
+		if err := service.OpdGuardService.CheckRead(ctx, auditLog.KodeOpd, auditLog.Tahun); err != nil {
+			return nil, err
+		}
+	}
+
+	return toAuditLogResponses(auditLogs), nil
+}
+
+func (service *AuditLogServiceImpl) FindByOpd(ctx context.Context, kodeOpd string, tahun string) ([]auditlog.AuditLogResponse, error) {
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return nil, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	auditLogs, err := service.AuditLogRepository.FindByOpd(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+
+	return toAuditLogResponses(auditLogs), nil
+}
+
+func toAuditLogResponses(auditLogs []domain.AuditLog) []auditlog.AuditLogResponse {
+	responses := make([]auditlog.AuditLogResponse, 0, len(auditLogs))
+	for _, auditLog := range auditLogs {
+		response := auditlog.AuditLogResponse{
+			Id:           auditLog.Id,
+			EntityType:   auditLog.EntityType,
+			EntityId:     auditLog.EntityId,
+			Action:       auditLog.Action,
+			KodeOpd:      auditLog.KodeOpd,
+			Tahun:        auditLog.Tahun,
+			ActorUserId:  auditLog.ActorUserId,
+			ActorNip:     auditLog.ActorNip,
+			ActorKodeOpd: auditLog.ActorKodeOpd,
+			CreatedAt:    auditLog.CreatedAt,
+		}
+		if auditLog.BeforeData.Valid {
+			response.Before = json.RawMessage(auditLog.BeforeData.String)
+		}
+		if auditLog.AfterData.Valid {
+			response.After = json.RawMessage(auditLog.AfterData.String)
+		}
+		responses = append(responses, response)
+	}
+	return responses
+}
@@ -0,0 +1,168 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestNewAuditLog(t *testing.T) {
+	claims := web.JWTClaim{UserId: 7, Nip: "198001012005011001", KodeOpd: "5.01.5.05.0.00.01.0000"}
+
+	tests := []struct {
+		name         string
+		ctx          context.Context
+		entry        auditEntry
+		expectedNip  string
+		expectBefore bool
+		expectAfter  bool
+		expectedJSON string
+	}{
+		{
+			name:         "create dengan actor",
+			ctx:          context.WithValue(context.Background(), helper.UserInfoKey, claims),
+			entry:        auditEntry{EntityType: AuditEntityRincianBelanja, EntityId: "RA-1", Action: AuditActionCreate, After: map[string]int{"anggaran": 1000}},
+			expectedNip:  claims.Nip,
+			expectAfter:  true,
+			expectedJSON: `{"anggaran":1000}`,
+		},
+		{
+			name:         "delete tanpa actor",
+			ctx:          context.Background(),
+			entry:        auditEntry{EntityType: AuditEntityRincianBelanja, EntityId: "RA-1", Action: AuditActionDelete, Before: map[string]int{"anggaran": 1000}},
+			expectedNip:  "",
+			expectBefore: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			auditLog := newAuditLog(tt.ctx, tt.entry)
+			if auditLog.ActorNip != tt.expectedNip {
+				t.Errorf("ActorNip = %q, expected %q", auditLog.ActorNip, tt.expectedNip)
+			}
+			if auditLog.BeforeData.Valid != tt.expectBefore {
+				t.Errorf("BeforeData.Valid = %v, expected %v", auditLog.BeforeData.Valid, tt.expectBefore)
+			}
+			if auditLog.AfterData.Valid != tt.expectAfter {
+				t.Errorf("AfterData.Valid = %v, expected %v", auditLog.AfterData.Valid, tt.expectAfter)
+			}
+			if tt.expectedJSON != "" && auditLog.AfterData.String != tt.expectedJSON {
+				t.Errorf("AfterData = %s, expected %s", auditLog.AfterData.String, tt.expectedJSON)
+			}
+		})
+	}
+}
+
+// driverTxKosong driver sql yang hanya mendukung transaksi kosong, query dijalankan oleh repository palsu
+type driverTxKosong struct{}
+
+type koneksiTxKosong struct{}
+
+func (driverTxKosong) Open(string) (driver.Conn, error) { return koneksiTxKosong{}, nil }
+
+func (koneksiTxKosong) Prepare(string) (driver.Stmt, error) {
+	return nil, errors.New("query tidak didukung")
+}
+func (koneksiTxKosong) Close() error              { return nil }
+func (koneksiTxKosong) Begin() (driver.Tx, error) { return koneksiTxKosong{}, nil }
+func (koneksiTxKosong) Commit() error             { return nil }
+func (koneksiTxKosong) Rollback() error           { return nil }
+
+func init() {
+	sql.Register("audit_tx_kosong", driverTxKosong{})
+}
+
+type auditLogRepositoryPalsu struct {
+	repository.AuditLogRepository
+	logs []domain.AuditLog
+}
+
+func (repo *auditLogRepositoryPalsu) Create(ctx context.Context, tx *sql.Tx, auditLog domain.AuditLog) error {
+	repo.logs = append(repo.logs, auditLog)
+	return nil
+}
+
+type matrixRenstraRepositoryPalsu struct {
+	repository.MatrixRenstraRepository
+	indikator domain.Indikator
+	dihapus   []string
+}
+
+func (repo *matrixRenstraRepositoryPalsu) FindIndikatorByKodeIndikator(ctx context.Context, tx *sql.Tx, kodeIndikator string) (domain.Indikator, error) {
+	return repo.indikator, nil
+}
+
+func (repo *matrixRenstraRepositoryPalsu) DeleteTargetByIndikatorId(ctx context.Context, tx *sql.Tx, indikatorId string) error {
+	return nil
+}
+
+func (repo *matrixRenstraRepositoryPalsu) DeleteIndikator(ctx context.Context, tx *sql.Tx, kodeIndikator string) error {
+	repo.dihapus = append(repo.dihapus, kodeIndikator)
+	return nil
+}
+
+func TestDeleteIndikatorRenstraMencatatAudit(t *testing.T) {
+	db, err := sql.Open("audit_tx_kosong", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	auditRepo := &auditLogRepositoryPalsu{}
+	matrixRepo := &matrixRenstraRepositoryPalsu{indikator: domain.Indikator{
+		KodeIndikator: "IND-RENSTRA-1", KodeOpd: "5.01.5.05.0.00.01.0000", Indikator: "Cakupan layanan", Tahun: "2026",
+		Target: []domain.Target{{Target: "80", Satuan: "%"}},
+	}}
+	service := &MatrixRenstraServiceImpl{MatrixRenstraRepository: matrixRepo, AuditLogRepository: auditRepo, DB: db}
+
+	ctx := context.WithValue(context.Background(), helper.UserInfoKey, web.JWTClaim{UserId: 7, Nip: "198001012005011001"})
+	if err := service.DeleteIndikator(ctx, "IND-RENSTRA-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(matrixRepo.dihapus) != 1 || len(auditRepo.logs) != 1 {
+		t.Fatalf("dihapus %v, audit %d, expected satu penghapusan tercatat", matrixRepo.dihapus, len(auditRepo.logs))
+	}
+	auditLog := auditRepo.logs[0]
+	if auditLog.EntityType != AuditEntityIndikatorRenstra || auditLog.EntityId != "IND-RENSTRA-1" || auditLog.Action != AuditActionDelete {
+		t.Errorf("audit = %s %s %s", auditLog.EntityType, auditLog.EntityId, auditLog.Action)
+	}
+	if auditLog.KodeOpd != "5.01.5.05.0.00.01.0000" || auditLog.Tahun != "2026" || auditLog.ActorNip != "198001012005011001" {
+		t.Errorf("audit opd/tahun/actor = %s %s %s", auditLog.KodeOpd, auditLog.Tahun, auditLog.ActorNip)
+	}
+	if !strings.Contains(auditLog.BeforeData.String, `"target":"80"`) || auditLog.AfterData.Valid {
+		t.Errorf("audit before/after = %s / %v", auditLog.BeforeData.String, auditLog.AfterData.Valid)
+	}
+}
+
+func TestRecordAuditIndikatorPokin(t *testing.T) {
+	auditRepo := &auditLogRepositoryPalsu{}
+	pokin := domain.PohonKinerja{Id: 12, KodeOpd: "5.01.5.05.0.00.01.0000", Tahun: "2026"}
+	indikator := []pohonkinerja.IndikatorResponse{{Id: "IND-POKIN-1", NamaIndikator: "Cakupan layanan"}}
+
+	recordAuditIndikatorPokin(context.Background(), nil, auditRepo, AuditActionUpdate, pokin, nil, nil)
+	if len(auditRepo.logs) != 0 {
+		t.Fatalf("pohon kinerja tanpa indikator tidak perlu dicatat, audit %d", len(auditRepo.logs))
+	}
+
+	recordAuditIndikatorPokin(context.Background(), nil, auditRepo, AuditActionDelete, pokin, indikator, nil)
+	if len(auditRepo.logs) != 1 {
+		t.Fatalf("audit %d, expected 1", len(auditRepo.logs))
+	}
+	auditLog := auditRepo.logs[0]
+	if auditLog.EntityType != AuditEntityIndikatorPokin || auditLog.EntityId != "12" || auditLog.KodeOpd != pokin.KodeOpd || auditLog.Tahun != "2026" {
+		t.Errorf("audit = %s %s %s %s", auditLog.EntityType, auditLog.EntityId, auditLog.KodeOpd, auditLog.Tahun)
+	}
+	if !strings.Contains(auditLog.BeforeData.String, "IND-POKIN-1") || auditLog.AfterData.Valid {
+		t.Errorf("audit before/after = %s / %v", auditLog.BeforeData.String, auditLog.AfterData.Valid)
+	}
+}
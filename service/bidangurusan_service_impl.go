@@ -126,6 +126,7 @@ func (service *BidangUrusanServiceImpl) FindAll(ctx context.Context) ([]bidangur
 			KodeBidangUrusan: bidangurusan.KodeBidangUrusan,
 			NamaBidangUrusan: bidangurusan.NamaBidangUrusan,
 			Tahun:            bidangurusan.Tahun,
+			IsDeprecated:     bidangurusan.IsDeprecated,
 		})
 	}
 
@@ -149,6 +150,7 @@ func (service *BidangUrusanServiceImpl) FindByKodeOpd(ctx context.Context, kodeO
 		bidangUrusanResponses = append(bidangUrusanResponses, bidangurusanresponse.BidangUrusanResponse{
 			KodeBidangUrusan: bidangUrusan.KodeBidangUrusan,
 			NamaBidangUrusan: bidangUrusan.NamaBidangUrusan,
+			IsDeprecated:     bidangUrusan.IsDeprecated,
 		})
 	}
 
@@ -225,6 +227,7 @@ func (service *BidangUrusanServiceImpl) FindBidangUrusanTerpilihByKodeOpd(ctx co
 			Id:               res.Id,
 			KodeBidangUrusan: res.KodeBidangUrusan,
 			NamaBidangUrusan: res.NamaBidangUrusan,
+			IsDeprecated:     res.IsDeprecated,
 		})
 	}
 	return responses, nil
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/clonejob"
+)
+
+// fase clone, urutannya mengikuti urutan eksekusi
+const (
+	FaseClonePokin        = "pokin"
+	FaseCloneRekin        = "rekin"
+	FaseCloneIndikator    = "indikator"
+	FaseCloneTarget       = "target"
+	FaseCloneTagging      = "tagging"
+	FaseCloneManualIK     = "manual_ik"
+	FaseCloneRenaksi      = "renaksi"
+	FaseCloneDasarHukum   = "dasar_hukum"
+	FaseClonePermasalahan = "permasalahan"
+	FaseCloneGambaranUmum = "gambaran_umum"
+	FaseCloneInovasi      = "inovasi"
+)
+
+type CloneJobService interface {
+	// Mulai mendaftarkan job lalu menjalankannya di background, response langsung kembali dengan status PENDING
+	Mulai(ctx context.Context, request clonejob.CloneJobRequest) (clonejob.CloneJobResponse, error)
+	FindById(ctx context.Context, jobId string) (clonejob.CloneJobResponse, error)
+	Batalkan(ctx context.Context, jobId string) (clonejob.CloneJobResponse, error)
+}
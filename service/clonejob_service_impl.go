@@ -0,0 +1,986 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/clonejob"
+	"ekak_kabupaten_madiun/repository"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// kumpulan warning missing dan orphan dari detail clone
+const (
+	WarningPokinParentTerlepas domain.WarningType = "pokin_parent_terlepas"
+
+	WarningIndikatorMissing domain.WarningType = "indikator_missing"
+	WarningTargetMissing    domain.WarningType = "target_missing"
+
+	WarningManualIKMissing domain.WarningType = "manual_ik_missing"
+	WarningManualIKOrphan  domain.WarningType = "manual_ik_orphan"
+
+	WarningRenaksiMissing domain.WarningType = "renaksi_missing"
+	WarningRenaksiOrphan  domain.WarningType = "renaksi_orphan"
+
+	WarningGambaranUmumMissing domain.WarningType = "gambaran_umum_missing"
+	WarningGambaranUmumOrphan  domain.WarningType = "gambaran_umum_orphan"
+
+	WarningDasarHukumMissing domain.WarningType = "dasar_hukum_missing"
+	WarningDasarHukumOrphan  domain.WarningType = "dasar_hukum_orphan"
+
+	WarningPermasalahanMissing domain.WarningType = "permasalahan_missing"
+	WarningPermasalahanOrphan  domain.WarningType = "permasalahan_orphan"
+
+	WarningInovasiMissing domain.WarningType = "inovasi_missing"
+	WarningInovasiOrphan  domain.WarningType = "inovasi_orphan"
+)
+
+// parentPokinTerlepas parent pokin hasil clone yang induknya tidak ikut di-clone (mis. pokin pemda)
+const parentPokinTerlepas = -100
+
+// simpanJobSelesai lama job yang sudah selesai tetap tersedia di memori
+const simpanJobSelesai = 24 * time.Hour
+
+// batasRecordMacet clone_record PENDING/PROCESS yang tidak berjalan di instance ini dan tidak diperbarui
+// selama ini dianggap ditinggal (mis. server dimulai ulang di tengah clone), ditandai FAILED agar clone bisa diulang
+const batasRecordMacet = 30 * time.Minute
+
+var (
+	faseClonePokin = []string{FaseClonePokin, FaseCloneIndikator, FaseCloneTarget, FaseCloneTagging}
+	faseCloneRekin = []string{FaseCloneRekin, FaseCloneIndikator, FaseCloneTarget, FaseCloneManualIK, FaseCloneRenaksi,
+		FaseCloneDasarHukum, FaseClonePermasalahan, FaseCloneGambaranUmum, FaseCloneInovasi}
+)
+
+type CloneJobServiceImpl struct {
+	CloneRecordRepository       repository.CloneRecordRepository
+	PohonKinerjaRepository      repository.PohonKinerjaRepository
+	RencanaKinerjaRepository    repository.RencanaKinerjaRepository
+	ManualIKRepository          repository.ManualIKRepository
+	RencanaAksiRepository       repository.RencanaAksiRepository
+	DasarHukumRepository        repository.DasarHukumRepository
+	PermasalahanRekinRepository repository.PermasalahanRekinRepository
+	GambaranUmumRepository      repository.GambaranUmumRepository
+	InovasiRepository           repository.InovasiRepository
+	LockDataService             LockDataService
+	OpdGuardService             OpdGuardService
+	AuditLogRepository          repository.AuditLogRepository
+	DB                          *sql.DB
+	Validate                    *validator.Validate
+
+	// jobs hanya berisi job yang dijalankan instance ini, pembatalan tidak menjangkau instance lain
+	mu   sync.Mutex
+	jobs map[string]*cloneJobBerjalan
+}
+
+func NewCloneJobServiceImpl(cloneRecordRepository repository.CloneRecordRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, manualIKRepository repository.ManualIKRepository, rencanaAksiRepository repository.RencanaAksiRepository, dasarHukumRepository repository.DasarHukumRepository, permasalahanRekinRepository repository.PermasalahanRekinRepository, gambaranUmumRepository repository.GambaranUmumRepository, inovasiRepository repository.InovasiRepository, lockDataService LockDataService, opdGuardService OpdGuardService, auditLogRepository repository.AuditLogRepository, DB *sql.DB, validate *validator.Validate) *CloneJobServiceImpl {
+	return &CloneJobServiceImpl{
+		CloneRecordRepository:       cloneRecordRepository,
+		PohonKinerjaRepository:      pohonKinerjaRepository,
+		RencanaKinerjaRepository:    rencanaKinerjaRepository,
+		ManualIKRepository:          manualIKRepository,
+		RencanaAksiRepository:       rencanaAksiRepository,
+		DasarHukumRepository:        dasarHukumRepository,
+		PermasalahanRekinRepository: permasalahanRekinRepository,
+		GambaranUmumRepository:      gambaranUmumRepository,
+		InovasiRepository:           inovasiRepository,
+		LockDataService:             lockDataService,
+		OpdGuardService:             opdGuardService,
+		AuditLogRepository:          auditLogRepository,
+		DB:                          DB,
+		Validate:                    validate,
+		jobs:                        make(map[string]*cloneJobBerjalan),
+	}
+}
+
+type cloneJobBerjalan struct {
+	mu       sync.Mutex
+	job      domain.CloneJob
+	recordId int
+	cancel   context.CancelFunc
+}
+
+func (berjalan *cloneJobBerjalan) ubah(fn func(job *domain.CloneJob)) {
+	berjalan.mu.Lock()
+	defer berjalan.mu.Unlock()
+	fn(&berjalan.job)
+	berjalan.job.UpdatedAt = time.Now()
+}
+
+func (berjalan *cloneJobBerjalan) ubahFase(nama string, fn func(fase *domain.CloneFase)) {
+	berjalan.ubah(func(job *domain.CloneJob) {
+		for i := range job.Fase {
+			if job.Fase[i].Nama == nama {
+				fn(&job.Fase[i])
+			}
+		}
+	})
+}
+
+func (berjalan *cloneJobBerjalan) snapshot() domain.CloneJob {
+	berjalan.mu.Lock()
+	defer berjalan.mu.Unlock()
+	job := berjalan.job
+	job.Fase = append([]domain.CloneFase(nil), berjalan.job.Fase...)
+	job.Warnings = append([]domain.CloneWarning(nil), berjalan.job.Warnings...)
+	return job
+}
+
+func cloneStatusFinal(status string) bool {
+	switch status {
+	case domain.CloneStatusDone, domain.CloneStatusDoneWithWarning, domain.CloneStatusFailed, domain.CloneStatusCancelled:
+		return true
+	}
+	return false
+}
+
+func faseAwalClone(jenis string) []domain.CloneFase {
+	nama := faseClonePokin
+	if jenis == domain.CloneJenisRekin {
+		nama = faseCloneRekin
+	}
+	fase := make([]domain.CloneFase, 0, len(nama))
+	for _, n := range nama {
+		fase = append(fase, domain.CloneFase{Nama: n})
+	}
+	return fase
+}
+
+func lockJenisClone(jenis string) string {
+	if jenis == domain.CloneJenisRekin {
+		return LockJenisRencanaKinerja
+	}
+	return LockJenisPohonKinerjaOpd
+}
+
+// pokinDapatDiclone pokin pemda dan pokin yang masih dalam proses persetujuan tidak ikut di-clone
+func pokinDapatDiclone(jenisPohon string, status string) bool {
+	switch jenisPohon {
+	case "Strategic Pemda", "Tactical Pemda", "Operational Pemda":
+		return false
+	}
+	switch status {
+	case domain.StatusPokinMenungguDisetujui, domain.StatusPokinTarikOpd, domain.StatusPokinDisetujui, domain.StatusPokinDitolak,
+		domain.StatusPokinCrosscuttingMenunggu, domain.StatusPokinCrosscuttingDitolak:
+		return false
+	}
+	return true
+}
+
+// parentClonePokin parent baru pokin hasil clone, terlepas bila induknya tidak ikut di-clone
+func parentClonePokin(parentLama int, idMapping map[int]int) (int, bool) {
+	if parentLama == 0 {
+		return 0, false
+	}
+	if parentBaru, ok := idMapping[parentLama]; ok {
+		return parentBaru, false
+	}
+	return parentPokinTerlepas, true
+}
+
+func persenFaseClone(fase domain.CloneFase) int {
+	if fase.Selesai || fase.Total == 0 {
+		if fase.Selesai {
+			return 100
+		}
+		return 0
+	}
+	persen := fase.Diproses * 100 / fase.Total
+	if persen > 99 {
+		// 100 hanya setelah fase ditandai selesai
+		persen = 99
+	}
+	return persen
+}
+
+func persenJobClone(fases []domain.CloneFase) int {
+	if len(fases) == 0 {
+		return 0
+	}
+	total := 0
+	for _, fase := range fases {
+		total += persenFaseClone(fase)
+	}
+	return total / len(fases)
+}
+
+func addWarning(w map[domain.WarningType]int, t domain.WarningType) {
+	w[t]++
+}
+
+// cloneWarningList warning terurut berdasarkan type agar response stabil
+func cloneWarningList(warnings map[domain.WarningType]int) []domain.CloneWarning {
+	result := make([]domain.CloneWarning, 0, len(warnings))
+	for t, count := range warnings {
+		result = append(result, domain.CloneWarning{Type: t, Count: count, Message: fmt.Sprintf("%d %s", count, t)})
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Type < result[j].Type })
+	return result
+}
+
+func buildWarningMessage(warnings []domain.CloneWarning) string {
+	var parts []string
+	for _, warning := range warnings {
+		parts = append(parts, warning.Message)
+	}
+	return strings.Join(parts, "; ")
+}
+
+func toCloneJobResponse(job domain.CloneJob) clonejob.CloneJobResponse {
+	response := clonejob.CloneJobResponse{
+		Id:           job.Id,
+		Jenis:        job.Jenis,
+		KodeOpd:      job.KodeOpd,
+		TahunSumber:  job.TahunSumber,
+		TahunTujuan:  job.TahunTujuan,
+		DryRun:       job.DryRun,
+		Status:       job.Status,
+		Persen:       persenJobClone(job.Fase),
+		Fase:         []clonejob.CloneFaseResponse{},
+		Warnings:     []clonejob.CloneWarningResponse{},
+		ErrorMessage: job.ErrorMessage,
+		CreatedBy:    job.CreatedBy,
+		CreatedAt:    job.CreatedAt,
+		UpdatedAt:    job.UpdatedAt,
+	}
+	if job.Status == domain.CloneStatusDone || job.Status == domain.CloneStatusDoneWithWarning {
+		response.Persen = 100
+	}
+	for _, fase := range job.Fase {
+		if response.FaseAktif == "" && !fase.Selesai && !cloneStatusFinal(job.Status) {
+			response.FaseAktif = fase.Nama
+		}
+		response.Fase = append(response.Fase, clonejob.CloneFaseResponse{
+			Nama:     fase.Nama,
+			Total:    fase.Total,
+			Diproses: fase.Diproses,
+			Dibuat:   fase.Dibuat,
+			Dilewati: fase.Dilewati,
+			Persen:   persenFaseClone(fase),
+			Selesai:  fase.Selesai,
+		})
+	}
+	for _, warning := range job.Warnings {
+		response.Warnings = append(response.Warnings, clonejob.CloneWarningResponse{
+			Type:    string(warning.Type),
+			Jumlah:  warning.Count,
+			Message: warning.Message,
+		})
+	}
+	return response
+}
+
+func (service *CloneJobServiceImpl) Mulai(ctx context.Context, request clonejob.CloneJobRequest) (clonejob.CloneJobResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return clonejob.CloneJobResponse{}, err
+	}
+	if request.TahunSumber == request.TahunTujuan {
+		return clonejob.CloneJobResponse{}, web.NewBadRequestError("tahun sumber dan tahun tujuan tidak boleh sama")
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, request.KodeOpd); err != nil {
+		return clonejob.CloneJobResponse{}, err
+	}
+	if !request.DryRun {
+		if err := service.LockDataService.CheckWritable(ctx, lockJenisClone(request.Jenis), request.KodeOpd, request.TahunTujuan); err != nil {
+			return clonejob.CloneJobResponse{}, err
+		}
+	}
+
+	createdBy := request.UpdatedBy
+	if claims, ok := helper.GetUserClaims(ctx); ok && claims.Nip != "" {
+		createdBy = claims.Nip
+	}
+	now := time.Now()
+	job := domain.CloneJob{
+		Id:          uuid.NewString(),
+		Jenis:       request.Jenis,
+		KodeOpd:     request.KodeOpd,
+		TahunSumber: request.TahunSumber,
+		TahunTujuan: request.TahunTujuan,
+		DryRun:      request.DryRun,
+		Status:      domain.CloneStatusPending,
+		Fase:        faseAwalClone(request.Jenis),
+		CreatedBy:   createdBy,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}
+
+	service.mu.Lock()
+	defer service.mu.Unlock()
+	service.bersihkanJobSelesai()
+
+	recordId := 0
+	if !job.DryRun {
+		for _, berjalan := range service.jobs {
+			lain := berjalan.snapshot()
+			if !lain.DryRun && !cloneStatusFinal(lain.Status) && lain.Jenis == job.Jenis && lain.KodeOpd == job.KodeOpd && lain.TahunTujuan == job.TahunTujuan {
+				return clonejob.CloneJobResponse{}, web.NewConflictError(fmt.Sprintf("clone %s opd %s ke tahun %s sedang berjalan pada job %s", job.Jenis, job.KodeOpd, job.TahunTujuan, lain.Id))
+			}
+		}
+		var err error
+		recordId, err = service.buatRecord(ctx, job)
+		if err != nil {
+			return clonejob.CloneJobResponse{}, err
+		}
+	}
+
+	// actor ikut dibawa ke job agar audit log hasil clone mencatat pengguna yang memulai
+	jobCtx := context.Background()
+	if claims, ok := helper.GetUserClaims(ctx); ok {
+		jobCtx = context.WithValue(jobCtx, helper.UserInfoKey, claims)
+	}
+	jobCtx, cancel := context.WithCancel(jobCtx)
+	berjalan := &cloneJobBerjalan{job: job, recordId: recordId, cancel: cancel}
+	service.jobs[job.Id] = berjalan
+	go service.jalankan(jobCtx, berjalan)
+
+	return toCloneJobResponse(job), nil
+}
+
+// buatRecord mencatat clone_record sebagai kunci logis agar clone yang sama tidak dijalankan dua kali
+func (service *CloneJobServiceImpl) buatRecord(ctx context.Context, job domain.CloneJob) (int, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return 0, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	existing, err := service.CloneRecordRepository.GetCloneByKodeOpdTahunSumberTahunTujuan(ctx, tx, job.Jenis, job.KodeOpd, job.TahunSumber, job.TahunTujuan)
+	if err != nil && err.Error() != "clone record not found" {
+		return 0, fmt.Errorf("gagal cek data clone: %w", err)
+	}
+	switch existing.Status {
+	case domain.CloneStatusDone, domain.CloneStatusDoneWithWarning:
+		return 0, web.NewConflictError(fmt.Sprintf("%s opd %s dari %s ke %s sudah di-clone pada %v status %s",
+			job.Jenis, job.KodeOpd, job.TahunSumber, job.TahunTujuan, existing.CreatedAt.Format("2006-01-02 15:04:05"), existing.Status))
+	case domain.CloneStatusPending, domain.CloneStatusProcess:
+		sedangBerjalan := service.jobBerjalan(existing.JobId)
+		if !sedangBerjalan {
+			macet, err := service.CloneRecordRepository.MarkFailedIfStale(ctx, tx, existing.Id, batasRecordMacet, "clone terhenti tanpa penyelesaian, dianggap gagal")
+			if err != nil {
+				return 0, fmt.Errorf("gagal menandai clone macet: %w", err)
+			}
+			sedangBerjalan = !macet
+		}
+		if sedangBerjalan {
+			return 0, web.NewConflictError(fmt.Sprintf("clone %s opd %s sedang berjalan", job.Jenis, job.KodeOpd))
+		}
+		log.Printf("[CloneJob] clone_record %d job %s macet, ditandai gagal", existing.Id, existing.JobId)
+	}
+
+	record, err := service.CloneRecordRepository.Create(ctx, tx, domain.CloneRecord{
+		KodeClone:   helper.GenerateKodeClone(job.KodeOpd),
+		KodeOpd:     job.KodeOpd,
+		TahunAsal:   job.TahunSumber,
+		TahunTarget: job.TahunTujuan,
+		UpdatedBy:   job.CreatedBy,
+		Jenis:       job.Jenis,
+		JobId:       job.Id,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("gagal menyimpan record clone: %w", err)
+	}
+	return record.Id, nil
+}
+
+// jobBerjalan job belum final di instance ini, dipanggil dengan service.mu terkunci
+func (service *CloneJobServiceImpl) jobBerjalan(jobId string) bool {
+	berjalan, ok := service.jobs[jobId]
+	return ok && !cloneStatusFinal(berjalan.snapshot().Status)
+}
+
+func (service *CloneJobServiceImpl) bersihkanJobSelesai() {
+	for id, berjalan := range service.jobs {
+		job := berjalan.snapshot()
+		if cloneStatusFinal(job.Status) && time.Since(job.UpdatedAt) > simpanJobSelesai {
+			delete(service.jobs, id)
+		}
+	}
+}
+
+func (service *CloneJobServiceImpl) jalankan(ctx context.Context, berjalan *cloneJobBerjalan) {
+	defer berjalan.cancel()
+	defer func() {
+		if r := recover(); r != nil {
+			log.Printf("[CloneJob] panic job %s: %v", berjalan.job.Id, r)
+			service.selesai(berjalan, domain.CloneStatusFailed, fmt.Sprintf("%v", r))
+		}
+	}()
+
+	berjalan.ubah(func(job *domain.CloneJob) { job.Status = domain.CloneStatusProcess })
+	service.simpanProgres(berjalan)
+
+	err := service.eksekusi(ctx, berjalan)
+	switch {
+	case err != nil && ctx.Err() != nil:
+		service.selesai(berjalan, domain.CloneStatusCancelled, "clone dibatalkan, tidak ada data yang disimpan")
+	case err != nil:
+		log.Printf("[CloneJob] job %s gagal: %v", berjalan.job.Id, err)
+		service.selesai(berjalan, domain.CloneStatusFailed, err.Error())
+	case len(berjalan.snapshot().Warnings) > 0:
+		service.selesai(berjalan, domain.CloneStatusDoneWithWarning, buildWarningMessage(berjalan.snapshot().Warnings))
+	default:
+		service.selesai(berjalan, domain.CloneStatusDone, "")
+	}
+}
+
+// eksekusi seluruh fase dalam satu transaksi, dry run dan kegagalan selalu di-rollback
+func (service *CloneJobServiceImpl) eksekusi(ctx context.Context, berjalan *cloneJobBerjalan) error {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	// rollback eksplisit karena CommitOrRollback tetap commit saat fungsi mengembalikan error
+	defer tx.Rollback()
+
+	job := berjalan.snapshot()
+	if job.Jenis == domain.CloneJenisRekin {
+		err = service.cloneRekin(ctx, tx, berjalan)
+	} else {
+		err = service.clonePokin(ctx, tx, berjalan)
+	}
+	if err != nil {
+		return err
+	}
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if job.DryRun {
+		return nil
+	}
+	return tx.Commit()
+}
+
+func (service *CloneJobServiceImpl) selesai(berjalan *cloneJobBerjalan, status string, pesan string) {
+	berjalan.ubah(func(job *domain.CloneJob) {
+		job.Status = status
+		job.ErrorMessage = pesan
+	})
+	service.simpanProgres(berjalan)
+}
+
+// simpanProgres menyalin snapshot job ke clone_record, dry run tidak punya record
+func (service *CloneJobServiceImpl) simpanProgres(berjalan *cloneJobBerjalan) {
+	if berjalan.recordId == 0 {
+		return
+	}
+	job := berjalan.snapshot()
+	progres, _ := json.Marshal(job.Fase)
+	warnings, _ := json.Marshal(job.Warnings)
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		log.Printf("[CloneJob] gagal begin tx simpan progres: %v", err)
+		return
+	}
+	defer helper.CommitOrRollback(tx)
+
+	err = service.CloneRecordRepository.UpdateProgres(context.Background(), tx, domain.CloneRecord{
+		Id:           berjalan.recordId,
+		Status:       job.Status,
+		ErrorMessage: job.ErrorMessage,
+		Progres:      string(progres),
+		Warnings:     string(warnings),
+	})
+	if err != nil {
+		log.Printf("[CloneJob] gagal simpan progres job %s: %v", job.Id, err)
+	}
+}
+
+func (berjalan *cloneJobBerjalan) selesaiFase(nama string) {
+	berjalan.ubahFase(nama, func(fase *domain.CloneFase) {
+		fase.Diproses = fase.Total
+		fase.Selesai = true
+	})
+}
+
+func (service *CloneJobServiceImpl) clonePokin(ctx context.Context, tx *sql.Tx, berjalan *cloneJobBerjalan) error {
+	job := berjalan.snapshot()
+	warnings := make(map[domain.WarningType]int)
+	defer func() {
+		berjalan.ubah(func(job *domain.CloneJob) { job.Warnings = cloneWarningList(warnings) })
+	}()
+
+	pokins, err := service.PohonKinerjaRepository.FindPokinOpdUntukClone(ctx, tx, job.KodeOpd, job.TahunSumber)
+	if err != nil {
+		return err
+	}
+	var valid []domain.PohonKinerja
+	for _, pokin := range pokins {
+		if pokinDapatDiclone(pokin.JenisPohon, pokin.Status) {
+			valid = append(valid, pokin)
+		}
+	}
+	berjalan.ubahFase(FaseClonePokin, func(fase *domain.CloneFase) {
+		fase.Total = len(pokins)
+		fase.Diproses = len(pokins) - len(valid)
+		fase.Dilewati = len(pokins) - len(valid)
+	})
+
+	// INSERT satu per satu agar idMapping didapat dari LastInsertId
+	idMapping := make(map[int]int)
+	for _, pokin := range valid {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		newId, err := service.PohonKinerjaRepository.ClonePokinNode(ctx, tx, pokin.Id, job.TahunSumber, job.TahunTujuan)
+		if err != nil {
+			return err
+		}
+		idMapping[pokin.Id] = newId
+		berjalan.ubahFase(FaseClonePokin, func(fase *domain.CloneFase) {
+			fase.Diproses++
+			fase.Dibuat++
+		})
+	}
+	for _, pokin := range valid {
+		parent, terlepas := parentClonePokin(pokin.Parent, idMapping)
+		if parent == 0 {
+			continue
+		}
+		if terlepas {
+			addWarning(warnings, WarningPokinParentTerlepas)
+		}
+		_, err := service.PohonKinerjaRepository.UpdateParent(ctx, tx, domain.PohonKinerja{Id: idMapping[pokin.Id], Parent: parent})
+		if err != nil {
+			return err
+		}
+	}
+	berjalan.selesaiFase(FaseClonePokin)
+	service.simpanProgres(berjalan)
+
+	type pasanganIndikator struct {
+		lama string
+		baru string
+	}
+	var indikators []pasanganIndikator
+	berjalan.ubahFase(FaseCloneIndikator, func(fase *domain.CloneFase) { fase.Total = len(valid) })
+	for _, pokin := range valid {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		mapping, err := service.PohonKinerjaRepository.CloneIndikatorPokin(ctx, tx, pokin.Id, idMapping[pokin.Id])
+		if err != nil {
+			return err
+		}
+		for lama, baru := range mapping {
+			indikators = append(indikators, pasanganIndikator{lama: lama, baru: baru})
+		}
+		berjalan.ubahFase(FaseCloneIndikator, func(fase *domain.CloneFase) {
+			fase.Diproses++
+			fase.Dibuat += len(mapping)
+		})
+	}
+	berjalan.selesaiFase(FaseCloneIndikator)
+	service.simpanProgres(berjalan)
+
+	berjalan.ubahFase(FaseCloneTarget, func(fase *domain.CloneFase) { fase.Total = len(indikators) })
+	for _, indikator := range indikators {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		jumlah, err := service.PohonKinerjaRepository.CloneTargetIndikator(ctx, tx, indikator.lama, indikator.baru, job.TahunTujuan)
+		if err != nil {
+			return err
+		}
+		berjalan.ubahFase(FaseCloneTarget, func(fase *domain.CloneFase) {
+			fase.Diproses++
+			fase.Dibuat += jumlah
+		})
+	}
+	berjalan.selesaiFase(FaseCloneTarget)
+	service.simpanProgres(berjalan)
+
+	berjalan.ubahFase(FaseCloneTagging, func(fase *domain.CloneFase) { fase.Total = len(valid) })
+	for _, pokin := range valid {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		jumlah, err := service.PohonKinerjaRepository.CloneTaggingPokin(ctx, tx, pokin.Id, idMapping[pokin.Id])
+		if err != nil {
+			return err
+		}
+		berjalan.ubahFase(FaseCloneTagging, func(fase *domain.CloneFase) {
+			fase.Diproses++
+			fase.Dibuat += jumlah
+		})
+	}
+	berjalan.selesaiFase(FaseCloneTagging)
+	return nil
+}
+
+// catatFaseDetail hasil fase detail rekin yang di-clone sekaligus lewat batch insert
+func (berjalan *cloneJobBerjalan) catatFaseDetail(nama string, total int, dibuat int) {
+	berjalan.ubahFase(nama, func(fase *domain.CloneFase) {
+		fase.Total = total
+		fase.Dibuat = dibuat
+		fase.Dilewati = total - dibuat
+	})
+	berjalan.selesaiFase(nama)
+}
+
+func (service *CloneJobServiceImpl) cloneRekin(ctx context.Context, tx *sql.Tx, berjalan *cloneJobBerjalan) error {
+	job := berjalan.snapshot()
+	warnings := make(map[domain.WarningType]int)
+	defer func() {
+		berjalan.ubah(func(job *domain.CloneJob) { job.Warnings = cloneWarningList(warnings) })
+	}()
+
+	data, err := service.RencanaKinerjaRepository.GetByKodeOpdAndTahun(ctx, tx, job.KodeOpd, job.TahunSumber)
+	if err != nil {
+		return err
+	}
+	berjalan.ubahFase(FaseCloneRekin, func(fase *domain.CloneFase) { fase.Total = len(data) })
+
+	oldToNewRekin := make(map[string]string)
+	oldToNewIndikator := make(map[string]string)
+	var newRekins []domain.RencanaKinerja
+	jumlahTarget := 0
+	for _, item := range data {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		newItem := item
+		newItem.Id = genereateRekinId()
+		newItem.Tahun = job.TahunTujuan
+		newItem.IdPohon = 0
+		newItem.KodeSubKegiatan = ""
+		// hasil clone memulai verifikasi dari awal
+		newItem.StatusRencanaKinerja = statusRekinSetelahEdit(domain.StatusRekinDraft, item.StatusRencanaKinerja)
+		oldToNewRekin[item.Id] = newItem.Id
+
+		indikators, err := service.RencanaKinerjaRepository.FindIndikatorbyRekinId(ctx, tx, item.Id)
+		if err != nil {
+			return err
+		}
+		newItem.Indikator = nil
+		for _, ind := range indikators {
+			targets, err := service.RencanaKinerjaRepository.FindTargetByIndikatorId(ctx, tx, ind.Id)
+			if err != nil {
+				return err
+			}
+			newInd := ind
+			newInd.Id = genereateIndikatorRekinId()
+			newInd.RencanaKinerjaId = newItem.Id
+			newInd.Tahun = job.TahunTujuan
+			newInd.Target = nil
+			oldToNewIndikator[ind.Id] = newInd.Id
+			for _, tar := range targets {
+				newTar := tar
+				newTar.Id = generateTargetRekinId()
+				newTar.IndikatorId = newInd.Id
+				newTar.Tahun = job.TahunTujuan
+				newInd.Target = append(newInd.Target, newTar)
+			}
+			jumlahTarget += len(newInd.Target)
+			newItem.Indikator = append(newItem.Indikator, newInd)
+		}
+		newRekins = append(newRekins, newItem)
+		berjalan.ubahFase(FaseCloneRekin, func(fase *domain.CloneFase) { fase.Diproses++ })
+	}
+	if len(data) > 0 && len(oldToNewIndikator) == 0 {
+		addWarning(warnings, WarningIndikatorMissing)
+	}
+	if len(oldToNewIndikator) > 0 && jumlahTarget == 0 {
+		addWarning(warnings, WarningTargetMissing)
+	}
+
+	err = service.RencanaKinerjaRepository.CreateBatch(ctx, tx, newRekins)
+	if err != nil {
+		log.Printf("rencanaKinerjaRepository.CreateBatch error: %v", err)
+		return err
+	}
+	for _, rekin := range newRekins {
+		recordAudit(ctx, tx, service.AuditLogRepository, auditEntry{
+			EntityType: AuditEntityRencanaKinerja,
+			EntityId:   rekin.Id,
+			Action:     AuditActionCreate,
+			KodeOpd:    rekin.KodeOpd,
+			Tahun:      rekin.Tahun,
+			After:      helper.ToRencanaKinerjaResponse(rekin),
+		})
+	}
+	berjalan.catatFaseDetail(FaseCloneRekin, len(data), len(newRekins))
+	berjalan.catatFaseDetail(FaseCloneIndikator, len(oldToNewIndikator), len(oldToNewIndikator))
+	berjalan.catatFaseDetail(FaseCloneTarget, jumlahTarget, jumlahTarget)
+	service.simpanProgres(berjalan)
+
+	oldIndikatorIds := make([]string, 0, len(oldToNewIndikator))
+	for oldIndId := range oldToNewIndikator {
+		oldIndikatorIds = append(oldIndikatorIds, oldIndId)
+	}
+	oldRekinIds := make([]string, 0, len(oldToNewRekin))
+	for oldRekinId := range oldToNewRekin {
+		oldRekinIds = append(oldRekinIds, oldRekinId)
+	}
+
+	// manual IK
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	manualIks, err := service.ManualIKRepository.FindByIndikatorIds(ctx, tx, oldIndikatorIds)
+	if err != nil {
+		log.Printf("manualIKRepository.FindByIndikatorIds error: %v", err)
+		return err
+	}
+	if len(manualIks) == 0 {
+		addWarning(warnings, WarningManualIKMissing)
+	}
+	var newManualIks []domain.ManualIK
+	for _, item := range manualIks {
+		newIndId, ok := oldToNewIndikator[item.IndikatorId]
+		if !ok {
+			addWarning(warnings, WarningManualIKOrphan)
+			continue
+		}
+		newItem := item
+		newItem.IndikatorId = newIndId
+		newManualIks = append(newManualIks, newItem)
+	}
+	if err := service.ManualIKRepository.CreateBatch(ctx, tx, newManualIks); err != nil {
+		log.Printf("manualIKRepository.CreateBatch error: %v", err)
+		return err
+	}
+	berjalan.catatFaseDetail(FaseCloneManualIK, len(manualIks), len(newManualIks))
+	service.simpanProgres(berjalan)
+
+	// rencana aksi
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	renaksis, err := service.RencanaAksiRepository.FindRenaksiByRekinIds(ctx, tx, oldRekinIds)
+	if err != nil {
+		log.Printf("rencanaAksiRepository.FindRenaksiByRekinIds error: %v", err)
+		return err
+	}
+	if len(renaksis) == 0 {
+		addWarning(warnings, WarningRenaksiMissing)
+	}
+	var newRenaksis []domain.RencanaAksi
+	for _, item := range renaksis {
+		newRekinId, ok := oldToNewRekin[item.RencanaKinerjaId]
+		if !ok {
+			addWarning(warnings, WarningRenaksiOrphan)
+			continue
+		}
+		newItem := item
+		newItem.Id = generateRencanaAksiId()
+		newItem.RencanaKinerjaId = newRekinId
+		newRenaksis = append(newRenaksis, newItem)
+	}
+	if err := service.RencanaAksiRepository.BatchCreate(ctx, tx, newRenaksis); err != nil {
+		log.Printf("rencanaAksiRepository.BatchCreate error: %v", err)
+		return err
+	}
+	berjalan.catatFaseDetail(FaseCloneRenaksi, len(renaksis), len(newRenaksis))
+	service.simpanProgres(berjalan)
+
+	// dasar hukum
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	dasarHukums, err := service.DasarHukumRepository.FindByRekinIds(ctx, tx, oldRekinIds)
+	if err != nil {
+		log.Printf("DasarHukumRepository.FindByRekinIds error: %v", err)
+		return err
+	}
+	if len(dasarHukums) == 0 {
+		addWarning(warnings, WarningDasarHukumMissing)
+	}
+	var newDasarHukums []domain.DasarHukum
+	for _, item := range dasarHukums {
+		newRekinId, ok := oldToNewRekin[item.RekinId]
+		if !ok {
+			addWarning(warnings, WarningDasarHukumOrphan)
+			continue
+		}
+		newItem := item
+		newItem.Id = generateDasarHukumId()
+		newItem.RekinId = newRekinId
+		newDasarHukums = append(newDasarHukums, newItem)
+	}
+	if err := service.DasarHukumRepository.BatchCreate(ctx, tx, newDasarHukums); err != nil {
+		log.Printf("DasarHukumRepository.BatchCreate error: %v", err)
+		return err
+	}
+	berjalan.catatFaseDetail(FaseCloneDasarHukum, len(dasarHukums), len(newDasarHukums))
+
+	// permasalahan
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	permasalahans, err := service.PermasalahanRekinRepository.FindByRekinIds(ctx, tx, oldRekinIds)
+	if err != nil {
+		log.Printf("permasalahanRekinRepository.FindByRekinIds error: %v", err)
+		return err
+	}
+	if len(permasalahans) == 0 {
+		addWarning(warnings, WarningPermasalahanMissing)
+	}
+	var newPermasalahans []domain.PermasalahanRekin
+	for _, item := range permasalahans {
+		newRekinId, ok := oldToNewRekin[item.RekinId]
+		if !ok {
+			addWarning(warnings, WarningPermasalahanOrphan)
+			continue
+		}
+		newItem := item
+		newItem.RekinId = newRekinId
+		newPermasalahans = append(newPermasalahans, newItem)
+	}
+	if err := service.PermasalahanRekinRepository.BatchCreate(ctx, tx, newPermasalahans); err != nil {
+		log.Printf("permasalahanRekinRepository.BatchCreate error: %v", err)
+		return err
+	}
+	berjalan.catatFaseDetail(FaseClonePermasalahan, len(permasalahans), len(newPermasalahans))
+
+	// gambaran umum
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	gambaranUmums, err := service.GambaranUmumRepository.FindByRekinIds(ctx, tx, oldRekinIds)
+	if err != nil {
+		log.Printf("GambaranUmumRepository.FindByRekinIds error: %v", err)
+		return err
+	}
+	if len(gambaranUmums) == 0 {
+		addWarning(warnings, WarningGambaranUmumMissing)
+	}
+	var newGambaranUmums []domain.GambaranUmum
+	for _, item := range gambaranUmums {
+		newRekinId, ok := oldToNewRekin[item.RekinId]
+		if !ok {
+			addWarning(warnings, WarningGambaranUmumOrphan)
+			continue
+		}
+		newItem := item
+		newItem.Id = generateGambaranUmumId()
+		newItem.RekinId = newRekinId
+		newGambaranUmums = append(newGambaranUmums, newItem)
+	}
+	if err := service.GambaranUmumRepository.BatchCreate(ctx, tx, newGambaranUmums); err != nil {
+		log.Printf("GambaranUmumRepository.BatchCreate error: %v", err)
+		return err
+	}
+	berjalan.catatFaseDetail(FaseCloneGambaranUmum, len(gambaranUmums), len(newGambaranUmums))
+
+	// inovasi
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	inovasis, err := service.InovasiRepository.FindByRekinIds(ctx, tx, oldRekinIds)
+	if err != nil {
+		log.Printf("InovasiRepository.FindByRekinIds error: %v", err)
+		return err
+	}
+	if len(inovasis) == 0 {
+		addWarning(warnings, WarningInovasiMissing)
+	}
+	var newInovasis []domain.Inovasi
+	for _, item := range inovasis {
+		newRekinId, ok := oldToNewRekin[item.RekinId]
+		if !ok {
+			addWarning(warnings, WarningInovasiOrphan)
+			continue
+		}
+		newItem := item
+		newItem.Id = generateInovasiId()
+		newItem.RekinId = newRekinId
+		newInovasis = append(newInovasis, newItem)
+	}
+	if err := service.InovasiRepository.BatchCreate(ctx, tx, newInovasis); err != nil {
+		log.Printf("InovasiRepository.BatchCreate error: %v", err)
+		return err
+	}
+	berjalan.catatFaseDetail(FaseCloneInovasi, len(inovasis), len(newInovasis))
+	return nil
+}
+
+func (service *CloneJobServiceImpl) FindById(ctx context.Context, jobId string) (clonejob.CloneJobResponse, error) {
+	service.mu.Lock()
+	berjalan, ok := service.jobs[jobId]
+	service.mu.Unlock()
+
+	var job domain.CloneJob
+	if ok {
+		job = berjalan.snapshot()
+	} else {
+		var err error
+		job, err = service.findJobRecord(ctx, jobId)
+		if err != nil {
+			return clonejob.CloneJobResponse{}, err
+		}
+	}
+	if err := service.OpdGuardService.CheckRead(ctx, job.KodeOpd, job.TahunTujuan); err != nil {
+		return clonejob.CloneJobResponse{}, err
+	}
+	return toCloneJobResponse(job), nil
+}
+
+// findJobRecord job yang sudah tidak ada di memori (mis. setelah restart) dibaca dari clone_record
+func (service *CloneJobServiceImpl) findJobRecord(ctx context.Context, jobId string) (domain.CloneJob, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return domain.CloneJob{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	record, err := service.CloneRecordRepository.FindByJobId(ctx, tx, jobId)
+	if err == sql.ErrNoRows {
+		return domain.CloneJob{}, web.NewNotFoundError(fmt.Sprintf("job clone %s tidak ditemukan", jobId))
+	}
+	if err != nil {
+		return domain.CloneJob{}, err
+	}
+
+	job := domain.CloneJob{
+		Id:           record.JobId,
+		Jenis:        record.Jenis,
+		KodeOpd:      record.KodeOpd,
+		TahunSumber:  record.TahunAsal,
+		TahunTujuan:  record.TahunTarget,
+		Status:       record.Status,
+		ErrorMessage: record.ErrorMessage,
+		CreatedBy:    record.UpdatedBy,
+		CreatedAt:    record.CreatedAt,
+		UpdatedAt:    record.UpdatedAt,
+	}
+	if record.Progres == "" || json.Unmarshal([]byte(record.Progres), &job.Fase) != nil {
+		job.Fase = faseAwalClone(record.Jenis)
+	}
+	if record.Warnings != "" {
+		_ = json.Unmarshal([]byte(record.Warnings), &job.Warnings)
+	}
+	return job, nil
+}
+
+func (service *CloneJobServiceImpl) Batalkan(ctx context.Context, jobId string) (clonejob.CloneJobResponse, error) {
+	service.mu.Lock()
+	berjalan, ok := service.jobs[jobId]
+	service.mu.Unlock()
+	if !ok {
+		return clonejob.CloneJobResponse{}, web.NewNotFoundError(fmt.Sprintf("job clone %s tidak sedang berjalan di server ini", jobId))
+	}
+
+	job := berjalan.snapshot()
+	if err := service.OpdGuardService.CheckWrite(ctx, job.KodeOpd); err != nil {
+		return clonejob.CloneJobResponse{}, err
+	}
+	if cloneStatusFinal(job.Status) {
+		return clonejob.CloneJobResponse{}, web.NewConflictError(fmt.Sprintf("job clone sudah selesai dengan status %s", job.Status))
+	}
+
+	// status CANCELLED ditulis goroutine job setelah transaksi di-rollback
+	berjalan.cancel()
+	return toCloneJobResponse(berjalan.snapshot()), nil
+}
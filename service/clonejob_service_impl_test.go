@@ -0,0 +1,93 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"testing"
+)
+
+func TestPokinDapatDiclone(t *testing.T) {
+	tests := []struct {
+		name       string
+		jenisPohon string
+		status     string
+		expected   bool
+	}{
+		{name: "strategic opd draft", jenisPohon: "Strategic", status: domain.StatusPokinDraft, expected: true},
+		{name: "pokin dari pemda yang sudah diterima", jenisPohon: "Tactical", status: domain.StatusPokinDariPemda, expected: true},
+		{name: "crosscutting disetujui", jenisPohon: "Operational", status: domain.StatusPokinCrosscuttingSetuju, expected: true},
+		{name: "pokin pemda", jenisPohon: "Tactical Pemda", status: domain.StatusPokinDraft, expected: false},
+		{name: "menunggu disetujui", jenisPohon: "Tactical", status: domain.StatusPokinMenungguDisetujui, expected: false},
+		{name: "ditolak", jenisPohon: "Operational", status: domain.StatusPokinDitolak, expected: false},
+		{name: "crosscutting menunggu", jenisPohon: "Operational", status: domain.StatusPokinCrosscuttingMenunggu, expected: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := pokinDapatDiclone(tt.jenisPohon, tt.status); got != tt.expected {
+				t.Errorf("pokinDapatDiclone() = %v, expected %v", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParentClonePokin(t *testing.T) {
+	idMapping := map[int]int{10: 110, 11: 111}
+
+	tests := []struct {
+		name     string
+		parent   int
+		expected int
+		terlepas bool
+	}{
+		{name: "root tetap root", parent: 0, expected: 0},
+		{name: "parent ikut di-clone", parent: 10, expected: 110},
+		{name: "parent pokin pemda tidak ikut di-clone", parent: 5, expected: parentPokinTerlepas, terlepas: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, terlepas := parentClonePokin(tt.parent, idMapping)
+			if got != tt.expected || terlepas != tt.terlepas {
+				t.Errorf("parentClonePokin() = %d, %v, expected %d, %v", got, terlepas, tt.expected, tt.terlepas)
+			}
+		})
+	}
+}
+
+func TestPersenJobClone(t *testing.T) {
+	tests := []struct {
+		name     string
+		fase     []domain.CloneFase
+		expected int
+	}{
+		{name: "belum ada fase", fase: nil, expected: 0},
+		{name: "fase belum dihitung", fase: []domain.CloneFase{{Nama: FaseClonePokin}, {Nama: FaseCloneIndikator}}, expected: 0},
+		{name: "setengah fase pertama", fase: []domain.CloneFase{{Total: 10, Diproses: 5}, {}}, expected: 25},
+		{name: "fase penuh belum ditandai selesai", fase: []domain.CloneFase{{Total: 4, Diproses: 4}}, expected: 99},
+		{name: "fase kosong yang selesai", fase: []domain.CloneFase{{Selesai: true}, {Total: 2, Diproses: 1}}, expected: 75},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := persenJobClone(tt.fase); got != tt.expected {
+				t.Errorf("persenJobClone() = %d, expected %d", got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBuildWarningMessage(t *testing.T) {
+	warnings := map[domain.WarningType]int{}
+	addWarning(warnings, WarningRenaksiOrphan)
+	addWarning(warnings, WarningRenaksiOrphan)
+	addWarning(warnings, WarningInovasiMissing)
+
+	list := cloneWarningList(warnings)
+	if len(list) != 2 || list[0].Type != WarningInovasiMissing || list[1].Count != 2 {
+		t.Fatalf("cloneWarningList() = %+v", list)
+	}
+	expected := "1 inovasi_missing; 2 renaksi_orphan"
+	if got := buildWarningMessage(list); got != expected {
+		t.Errorf("buildWarningMessage() = %q, expected %q", got, expected)
+	}
+}
@@ -20,19 +20,61 @@ type CrosscuttingOpdServiceImpl struct {
 	PohonKinerjaRepository    repository.PohonKinerjaRepository
 	PegawaiRepository         repository.PegawaiRepository
 	OpdRepository             repository.OpdRepository
+	AuditLogRepository        repository.AuditLogRepository
+	PokinWorkflowService      PokinWorkflowService
 	DB                        *sql.DB
 }
 
-func NewCrosscuttingOpdServiceImpl(crosscuttingOpdRepository repository.CrosscuttingOpdRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, pegawaiRepository repository.PegawaiRepository, opdRepository repository.OpdRepository, DB *sql.DB) *CrosscuttingOpdServiceImpl {
+func NewCrosscuttingOpdServiceImpl(crosscuttingOpdRepository repository.CrosscuttingOpdRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, pegawaiRepository repository.PegawaiRepository, opdRepository repository.OpdRepository, auditLogRepository repository.AuditLogRepository, pokinWorkflowService PokinWorkflowService, DB *sql.DB) *CrosscuttingOpdServiceImpl {
 	return &CrosscuttingOpdServiceImpl{
 		CrosscuttingOpdRepository: crosscuttingOpdRepository,
 		PohonKinerjaRepository:    pohonKinerjaRepository,
 		PegawaiRepository:         pegawaiRepository,
 		OpdRepository:             opdRepository,
+		AuditLogRepository:        auditLogRepository,
+		PokinWorkflowService:      pokinWorkflowService,
 		DB:                        DB,
 	}
 }
 
+// crosscuttingSnapshot mengambil kondisi crosscutting untuk audit log, nil jika tidak ditemukan
+func (service *CrosscuttingOpdServiceImpl) crosscuttingSnapshot(ctx context.Context, tx *sql.Tx, crosscuttingId int) *pohonkinerja.CrosscuttingOpdResponse {
+	crosscutting, err := service.CrosscuttingOpdRepository.FindCrosscuttingById(ctx, tx, crosscuttingId)
+	if err != nil {
+		return nil
+	}
+	return &pohonkinerja.CrosscuttingOpdResponse{
+		IdCrosscutting: crosscutting.Id,
+		Id:             crosscutting.CrosscuttingTo,
+		Parent:         crosscutting.CrosscuttingFrom,
+		KodeOpd:        crosscutting.KodeOpd,
+		Keterangan:     crosscutting.Keterangan,
+		Tahun:          crosscutting.Tahun,
+		Status:         crosscutting.Status,
+	}
+}
+
+func (service *CrosscuttingOpdServiceImpl) recordCrosscuttingAudit(ctx context.Context, tx *sql.Tx, crosscuttingId int, action string, before *pohonkinerja.CrosscuttingOpdResponse) {
+	entry := auditEntry{
+		EntityType: AuditEntityCrosscutting,
+		EntityId:   strconv.Itoa(crosscuttingId),
+		Action:     action,
+	}
+	if before != nil {
+		entry.Before = before
+		entry.KodeOpd = before.KodeOpd
+		entry.Tahun = before.Tahun
+	}
+	if action != AuditActionDelete {
+		if after := service.crosscuttingSnapshot(ctx, tx, crosscuttingId); after != nil {
+			entry.After = after
+			entry.KodeOpd = after.KodeOpd
+			entry.Tahun = after.Tahun
+		}
+	}
+	recordAudit(ctx, tx, service.AuditLogRepository, entry)
+}
+
 func (service *CrosscuttingOpdServiceImpl) Create(ctx context.Context, request pohonkinerja.CrosscuttingOpdCreateRequest, parentId int) (pohonkinerja.CrosscuttingDikirimResponse, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -82,6 +124,7 @@ func (service *CrosscuttingOpdServiceImpl) Create(ctx context.Context, request p
 	if err != nil {
 		return pohonkinerja.CrosscuttingDikirimResponse{}, err
 	}
+	service.recordCrosscuttingAudit(ctx, tx, result.Id, AuditActionCreate, nil)
 	namaOpdTujuan := ""
 	if opd, err := service.OpdRepository.FindByKodeOpd(ctx, tx, result.KodeOpd); err == nil {
 		namaOpdTujuan = opd.NamaOpd
@@ -115,12 +158,16 @@ func (service *CrosscuttingOpdServiceImpl) Update(ctx context.Context, request p
 		Tahun:      request.Tahun,
 	}
 
+	before := service.crosscuttingSnapshot(ctx, tx, request.Id)
+
 	// Update data
 	result, err := service.CrosscuttingOpdRepository.UpdateCrosscutting(ctx, tx, pokin)
 	if err != nil {
 		return pohonkinerja.CrosscuttingOpdResponse{}, err
 	}
 
+	service.recordCrosscuttingAudit(ctx, tx, request.Id, AuditActionUpdate, before)
+
 	// Konversi ke response
 	response := pohonkinerja.CrosscuttingOpdResponse{
 		Id:         result.Id,
@@ -256,7 +303,8 @@ func (service *CrosscuttingOpdServiceImpl) ApproveOrReject(ctx context.Context,
 	if err != nil {
 		return nil, err
 	}
-	defer helper.CommitOrRollback(tx)
+	// rollback eksplisit agar persetujuan tidak tersimpan saat status pohon tujuan gagal diubah
+	defer tx.Rollback()
 
 	// Validasi request
 	if request.Approve {
@@ -271,10 +319,25 @@ func (service *CrosscuttingOpdServiceImpl) ApproveOrReject(ctx context.Context,
 		}
 	}
 
-	err = service.CrosscuttingOpdRepository.ApproveOrRejectCrosscutting(ctx, tx, crosscuttingId, request)
+	before := service.crosscuttingSnapshot(ctx, tx, crosscuttingId)
+	pokinId, err := service.CrosscuttingOpdRepository.ApproveOrRejectCrosscutting(ctx, tx, crosscuttingId, request)
 	if err != nil {
 		return nil, err
 	}
+	// status pohon tujuan hanya berubah lewat workflow agar tercatat di riwayat
+	if pokinId > 0 {
+		aksi := AksiPokinSetujuiCrosscutting
+		if !request.Approve {
+			aksi = AksiPokinTolakCrosscutting
+		}
+		if _, err := service.PokinWorkflowService.TransisiSistemTx(ctx, tx, pokinId, aksi, request.Alasan); err != nil {
+			return nil, err
+		}
+	}
+	service.recordCrosscuttingAudit(ctx, tx, crosscuttingId, AuditActionUpdate, before)
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
 
 	currentTime := time.Now()
 	response := &pohonkinerja.CrosscuttingApproveResponse{
@@ -305,7 +368,12 @@ func (service *CrosscuttingOpdServiceImpl) Delete(ctx context.Context, crosscutt
 		return fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
 	defer helper.CommitOrRollback(tx)
-	return service.CrosscuttingOpdRepository.DeleteCrosscutting(ctx, tx, crosscuttingId, nipPegawai)
+	before := service.crosscuttingSnapshot(ctx, tx, crosscuttingId)
+	if err := service.CrosscuttingOpdRepository.DeleteCrosscutting(ctx, tx, crosscuttingId, nipPegawai); err != nil {
+		return err
+	}
+	service.recordCrosscuttingAudit(ctx, tx, crosscuttingId, AuditActionDelete, before)
+	return nil
 }
 
 func (service *CrosscuttingOpdServiceImpl) DeleteUnused(ctx context.Context, crosscuttingId int) error {
@@ -315,10 +383,12 @@ func (service *CrosscuttingOpdServiceImpl) DeleteUnused(ctx context.Context, cro
 	}
 	defer helper.CommitOrRollback(tx)
 
+	before := service.crosscuttingSnapshot(ctx, tx, crosscuttingId)
 	err = service.CrosscuttingOpdRepository.DeleteUnused(ctx, tx, crosscuttingId)
 	if err != nil {
 		return err
 	}
+	service.recordCrosscuttingAudit(ctx, tx, crosscuttingId, AuditActionDelete, before)
 
 	return nil
 }
@@ -403,7 +473,12 @@ func (service *CrosscuttingOpdServiceImpl) DeleteCrosscuttingDiterima(ctx contex
 		return fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
 	defer helper.CommitOrRollback(tx)
-	return service.CrosscuttingOpdRepository.DeleteCrosscuttingDiterima(ctx, tx, crosscuttingId)
+	before := service.crosscuttingSnapshot(ctx, tx, crosscuttingId)
+	if err := service.CrosscuttingOpdRepository.DeleteCrosscuttingDiterima(ctx, tx, crosscuttingId); err != nil {
+		return err
+	}
+	service.recordCrosscuttingAudit(ctx, tx, crosscuttingId, AuditActionDelete, before)
+	return nil
 }
 
 // Plan B
@@ -415,5 +490,10 @@ func (service *CrosscuttingOpdServiceImpl) UnlinkCrosscuttingDiterima(
 		return fmt.Errorf("gagal memulai transaksi: %w", err)
 	}
 	defer helper.CommitOrRollback(tx)
-	return service.CrosscuttingOpdRepository.UnlinkCrosscuttingDiterima(ctx, tx, crosscuttingId)
+	before := service.crosscuttingSnapshot(ctx, tx, crosscuttingId)
+	if err := service.CrosscuttingOpdRepository.UnlinkCrosscuttingDiterima(ctx, tx, crosscuttingId); err != nil {
+		return err
+	}
+	service.recordCrosscuttingAudit(ctx, tx, crosscuttingId, AuditActionDelete, before)
+	return nil
 }
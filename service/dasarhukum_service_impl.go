@@ -13,14 +13,16 @@ import (
 )
 
 type DasarHukumServiceImpl struct {
-	DasarHukumRepository repository.DasarHukumRepository
-	DB                   *sql.DB
+	DasarHukumRepository               repository.DasarHukumRepository
+	RencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository
+	DB                                 *sql.DB
 }
 
-func NewDasarHukumServiceImpl(dasarHukumRepository repository.DasarHukumRepository, DB *sql.DB) *DasarHukumServiceImpl {
+func NewDasarHukumServiceImpl(dasarHukumRepository repository.DasarHukumRepository, rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository, DB *sql.DB) *DasarHukumServiceImpl {
 	return &DasarHukumServiceImpl{
-		DasarHukumRepository: dasarHukumRepository,
-		DB:                   DB,
+		DasarHukumRepository:               dasarHukumRepository,
+		RencanaKinerjaVerifikasiRepository: rencanaKinerjaVerifikasiRepository,
+		DB:                                 DB,
 	}
 }
 
@@ -31,6 +33,10 @@ func (service *DasarHukumServiceImpl) Create(ctx context.Context, request dasarh
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, request.RekinId); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
+
 	// Membuat UUID dengan format yang diinginkan
 	randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 	uuId := fmt.Sprintf("DASHU-REKIN-%s", randomDigits)
@@ -74,6 +80,9 @@ func (service *DasarHukumServiceImpl) Update(ctx context.Context, request dasarh
 	if err != nil {
 		return dasarhukum.DasarHukumResponse{}, err
 	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, dasarHukum.RekinId); err != nil {
+		return dasarhukum.DasarHukumResponse{}, err
+	}
 
 	dasarHukum.PeraturanTerkait = request.PeraturanTerkait
 	dasarHukum.Uraian = request.Uraian
@@ -91,6 +100,14 @@ func (service *DasarHukumServiceImpl) Delete(ctx context.Context, id string) err
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	dasarHukum, err := service.DasarHukumRepository.FindById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, dasarHukum.RekinId); err != nil {
+		return err
+	}
+
 	err = service.DasarHukumRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return err
@@ -13,14 +13,16 @@ import (
 )
 
 type GambaranUmumServiceImpl struct {
-	gambaranUmumRepository repository.GambaranUmumRepository
-	DB                     *sql.DB
+	gambaranUmumRepository             repository.GambaranUmumRepository
+	rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository
+	DB                                 *sql.DB
 }
 
-func NewGambaranUmumServiceImpl(gambaranUmumRepository repository.GambaranUmumRepository, DB *sql.DB) *GambaranUmumServiceImpl {
+func NewGambaranUmumServiceImpl(gambaranUmumRepository repository.GambaranUmumRepository, rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository, DB *sql.DB) *GambaranUmumServiceImpl {
 	return &GambaranUmumServiceImpl{
-		gambaranUmumRepository: gambaranUmumRepository,
-		DB:                     DB,
+		gambaranUmumRepository:             gambaranUmumRepository,
+		rencanaKinerjaVerifikasiRepository: rencanaKinerjaVerifikasiRepository,
+		DB:                                 DB,
 	}
 }
 
@@ -31,6 +33,10 @@ func (service *GambaranUmumServiceImpl) Create(ctx context.Context, request gamb
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := periksaRekinTerkunciById(ctx, tx, service.rencanaKinerjaVerifikasiRepository, request.RekinId); err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
+
 	// Membuat UUID dengan format yang diinginkan
 	randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 	uuId := fmt.Sprintf("GMBRUMUM-REKIN-%s", randomDigits)
@@ -65,6 +71,14 @@ func (service *GambaranUmumServiceImpl) Update(ctx context.Context, request gamb
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	existing, err := service.gambaranUmumRepository.FindById(ctx, tx, request.Id)
+	if err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.rencanaKinerjaVerifikasiRepository, existing.RekinId); err != nil {
+		return gambaranumum.GambaranUmumResponse{}, err
+	}
+
 	gambaranUmum := domain.GambaranUmum{
 		Id:           request.Id,
 		Urutan:       request.Urutan,
@@ -84,6 +98,14 @@ func (service *GambaranUmumServiceImpl) Delete(ctx context.Context, id string) e
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	gambaranUmum, err := service.gambaranUmumRepository.FindById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.rencanaKinerjaVerifikasiRepository, gambaranUmum.RekinId); err != nil {
+		return err
+	}
+
 	return service.gambaranUmumRepository.Delete(ctx, tx, id)
 
 }
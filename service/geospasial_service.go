@@ -0,0 +1,17 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/geospasial"
+)
+
+type GeospasialService interface {
+	FindWilayah(ctx context.Context, tingkat string, kodeInduk string) (geospasial.FeatureCollection, error)
+	ImportWilayah(ctx context.Context, tingkat string, content []byte) (geospasial.WilayahImportResponse, error)
+	UpdateLokasiUsulan(ctx context.Context, request geospasial.LokasiUsulanRequest) (geospasial.LokasiResponse, error)
+	UpdateLokasiIndikator(ctx context.Context, request geospasial.LokasiIndikatorRequest) ([]geospasial.LokasiResponse, error)
+	FeatureUsulan(ctx context.Context, kodeOpd string, tahun string, tahap string, jenisUsulan string) (geospasial.FeatureCollection, error)
+	FeatureIndikator(ctx context.Context, kodeOpd string, tahun string) (geospasial.FeatureCollection, error)
+	// Sebaran batas wilayah per tingkat dengan jumlah usulan dan indikator di dalamnya
+	Sebaran(ctx context.Context, tingkat string, kodeOpd string, tahun string, tahap string) (geospasial.FeatureCollection, error)
+}
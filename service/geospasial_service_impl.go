@@ -0,0 +1,682 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/geospasial"
+	"ekak_kabupaten_madiun/repository"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type GeospasialServiceImpl struct {
+	GeospasialRepository repository.GeospasialRepository
+	OpdGuardService      OpdGuardService
+	DB                   *sql.DB
+	Validate             *validator.Validate
+}
+
+func NewGeospasialServiceImpl(geospasialRepository repository.GeospasialRepository, opdGuardService OpdGuardService, DB *sql.DB, validate *validator.Validate) *GeospasialServiceImpl {
+	return &GeospasialServiceImpl{
+		GeospasialRepository: geospasialRepository,
+		OpdGuardService:      opdGuardService,
+		DB:                   DB,
+		Validate:             validate,
+	}
+}
+
+const tipeFeatureCollection = "FeatureCollection"
+
+// alias property GeoJSON batas wilayah dari berbagai sumber (BIG, BPS, digitasi sendiri)
+var (
+	propertyKodeWilayah = map[string][]string{
+		domain.TingkatWilayahKecamatan: {"kode_wilayah", "kode", "kode_kecamatan", "kode_kec", "kdkec", "id"},
+		domain.TingkatWilayahDesa:      {"kode_wilayah", "kode", "kode_desa", "kode_kel", "kddesa", "id"},
+	}
+	propertyNamaWilayah = map[string][]string{
+		domain.TingkatWilayahKecamatan: {"nama", "nama_wilayah", "nama_kecamatan", "kecamatan", "wadmkc", "name"},
+		domain.TingkatWilayahDesa:      {"nama", "nama_wilayah", "nama_desa", "desa", "wadmkd", "name"},
+	}
+	propertyKodeInduk = []string{"kode_induk", "kode_kecamatan", "kode_kec", "kdkec"}
+)
+
+func tingkatWilayahValid(tingkat string) bool {
+	return tingkat == domain.TingkatWilayahKecamatan || tingkat == domain.TingkatWilayahDesa
+}
+
+// jenisUsulanBerlokasi hanya musrebang dan pokok pikiran yang memiliki alamat
+func jenisUsulanBerlokasi(jenisUsulan string) ([]string, error) {
+	switch jenisUsulan {
+	case "":
+		return []string{domain.JenisUsulanMusrebang, domain.JenisUsulanPokokPikiran}, nil
+	case domain.JenisUsulanMusrebang, domain.JenisUsulanPokokPikiran:
+		return []string{jenisUsulan}, nil
+	}
+	return nil, web.NewBadRequestError(fmt.Sprintf("usulan %s tidak memiliki lokasi, hanya musrebang dan pokok_pikiran", jenisUsulan))
+}
+
+// siapkanLokasi memvalidasi request, titik diturunkan dari geometri bila latitude/longitude tidak diisi
+func siapkanLokasi(request geospasial.LokasiRequest) (domain.Lokasi, error) {
+	lokasi := domain.Lokasi{
+		Latitude:    request.Latitude,
+		Longitude:   request.Longitude,
+		KodeWilayah: strings.TrimSpace(request.KodeWilayah),
+	}
+	if (lokasi.Latitude == nil) != (lokasi.Longitude == nil) {
+		return domain.Lokasi{}, web.NewBadRequestError("latitude dan longitude harus diisi berpasangan")
+	}
+	if lokasi.Latitude != nil {
+		if err := helper.ValidasiKoordinat(*lokasi.Latitude, *lokasi.Longitude); err != nil {
+			return domain.Lokasi{}, web.NewBadRequestError(err.Error())
+		}
+	}
+
+	geometri := strings.TrimSpace(string(request.Geometri))
+	if geometri == "" || geometri == "null" {
+		return lokasi, nil
+	}
+	if _, err := helper.ValidasiGeometri([]byte(geometri)); err != nil {
+		return domain.Lokasi{}, web.NewBadRequestError(err.Error())
+	}
+	lokasi.Geometri = geometri
+	if lokasi.Latitude == nil {
+		if latitude, longitude, ok := helper.TitikTengahGeometri([]byte(geometri)); ok {
+			lokasi.Latitude, lokasi.Longitude = &latitude, &longitude
+		}
+	}
+	return lokasi, nil
+}
+
+// cariWilayahTitik desa yang memuat titik lebih diutamakan daripada kecamatan
+func cariWilayahTitik(daftarWilayah []domain.Wilayah, latitude, longitude float64) string {
+	kecamatan := ""
+	for _, wilayah := range daftarWilayah {
+		if wilayah.Geometri == "" || !helper.TitikDalamGeometri([]byte(wilayah.Geometri), latitude, longitude) {
+			continue
+		}
+		if wilayah.Tingkat == domain.TingkatWilayahDesa {
+			return wilayah.KodeWilayah
+		}
+		if kecamatan == "" {
+			kecamatan = wilayah.KodeWilayah
+		}
+	}
+	return kecamatan
+}
+
+func petaWilayah(daftarWilayah []domain.Wilayah) map[string]domain.Wilayah {
+	peta := make(map[string]domain.Wilayah, len(daftarWilayah))
+	for _, wilayah := range daftarWilayah {
+		peta[wilayah.KodeWilayah] = wilayah
+	}
+	return peta
+}
+
+// wilayahPadaTingkat naik dari desa ke kecamatan induknya bila yang diminta tingkat kecamatan
+func wilayahPadaTingkat(peta map[string]domain.Wilayah, kodeWilayah string, tingkat string) (domain.Wilayah, bool) {
+	wilayah, ok := peta[kodeWilayah]
+	if !ok {
+		return domain.Wilayah{}, false
+	}
+	if wilayah.Tingkat == tingkat {
+		return wilayah, true
+	}
+	if tingkat == domain.TingkatWilayahKecamatan && wilayah.Tingkat == domain.TingkatWilayahDesa {
+		induk, ok := peta[wilayah.KodeInduk]
+		return induk, ok && induk.Tingkat == tingkat
+	}
+	return domain.Wilayah{}, false
+}
+
+// kodeWilayahLokasi lokasi lama tanpa kode wilayah dicocokkan ulang ke batas wilayah yang sudah dimuat
+func kodeWilayahLokasi(lokasi domain.Lokasi, daftarWilayah []domain.Wilayah) string {
+	if lokasi.KodeWilayah != "" {
+		return lokasi.KodeWilayah
+	}
+	if lokasi.Latitude != nil && lokasi.Longitude != nil {
+		return cariWilayahTitik(daftarWilayah, *lokasi.Latitude, *lokasi.Longitude)
+	}
+	return ""
+}
+
+// geometriLokasi geometri tersimpan, lalu titik, terakhir titik tengah wilayah sebagai perkiraan
+func geometriLokasi(lokasi domain.Lokasi, peta map[string]domain.Wilayah) (json.RawMessage, bool, bool) {
+	if lokasi.Geometri != "" {
+		return json.RawMessage(lokasi.Geometri), false, true
+	}
+	if lokasi.Latitude != nil && lokasi.Longitude != nil {
+		return helper.GeometriTitik(*lokasi.Latitude, *lokasi.Longitude), false, true
+	}
+	if wilayah, ok := peta[lokasi.KodeWilayah]; ok && wilayah.Latitude != nil && wilayah.Longitude != nil {
+		return helper.GeometriTitik(*wilayah.Latitude, *wilayah.Longitude), true, true
+	}
+	return nil, false, false
+}
+
+func isiPropertyWilayah(properties map[string]interface{}, peta map[string]domain.Wilayah, kodeWilayah string) {
+	properties["kode_wilayah"] = kodeWilayah
+	properties["nama_wilayah"] = peta[kodeWilayah].Nama
+	kecamatan, _ := wilayahPadaTingkat(peta, kodeWilayah, domain.TingkatWilayahKecamatan)
+	properties["kode_kecamatan"] = kecamatan.KodeWilayah
+	properties["nama_kecamatan"] = kecamatan.Nama
+}
+
+func toLokasiResponse(lokasi domain.Lokasi, peta map[string]domain.Wilayah, keterangan string) geospasial.LokasiResponse {
+	kecamatan, _ := wilayahPadaTingkat(peta, lokasi.KodeWilayah, domain.TingkatWilayahKecamatan)
+	response := geospasial.LokasiResponse{
+		Latitude:      lokasi.Latitude,
+		Longitude:     lokasi.Longitude,
+		KodeWilayah:   lokasi.KodeWilayah,
+		NamaWilayah:   peta[lokasi.KodeWilayah].Nama,
+		KodeKecamatan: kecamatan.KodeWilayah,
+		NamaKecamatan: kecamatan.Nama,
+		Keterangan:    keterangan,
+	}
+	if lokasi.Geometri != "" {
+		response.Geometri = json.RawMessage(lokasi.Geometri)
+	}
+	return response
+}
+
+func featureUsulan(daftarUsulan []domain.LokasiUsulan, daftarWilayah []domain.Wilayah, tahap string) geospasial.FeatureCollection {
+	peta := petaWilayah(daftarWilayah)
+	collection := geospasial.FeatureCollection{Type: tipeFeatureCollection, Features: []geospasial.Feature{}}
+	for _, usulan := range daftarUsulan {
+		tahapEfektif := tahapUsulan(domain.UsulanPipeline{Tahap: usulan.Tahap, RekinId: usulan.RekinId})
+		if tahap != "" && tahapEfektif != tahap {
+			continue
+		}
+		lokasi := usulan.Lokasi
+		lokasi.KodeWilayah = kodeWilayahLokasi(lokasi, daftarWilayah)
+		geometri, perkiraan, ok := geometriLokasi(lokasi, peta)
+		if !ok {
+			collection.TanpaLokasi++
+			continue
+		}
+		properties := map[string]interface{}{
+			"jenis_usulan":     usulan.JenisUsulan,
+			"usulan_id":        usulan.UsulanId,
+			"usulan":           usulan.Usulan,
+			"alamat":           usulan.Alamat,
+			"tahun":            usulan.Tahun,
+			"kode_opd":         usulan.KodeOpd,
+			"nama_opd":         usulan.NamaOpd,
+			"tahap":            tahapEfektif,
+			"lokasi_perkiraan": perkiraan,
+		}
+		isiPropertyWilayah(properties, peta, lokasi.KodeWilayah)
+		collection.Features = append(collection.Features, geospasial.Feature{
+			Type:       "Feature",
+			Id:         usulan.JenisUsulan + "/" + usulan.UsulanId,
+			Geometry:   geometri,
+			Properties: properties,
+		})
+	}
+	return collection
+}
+
+func featureIndikator(daftarLokasi []domain.LokasiIndikator, daftarWilayah []domain.Wilayah) geospasial.FeatureCollection {
+	peta := petaWilayah(daftarWilayah)
+	collection := geospasial.FeatureCollection{Type: tipeFeatureCollection, Features: []geospasial.Feature{}}
+	for _, item := range daftarLokasi {
+		lokasi := item.Lokasi
+		lokasi.KodeWilayah = kodeWilayahLokasi(lokasi, daftarWilayah)
+		geometri, perkiraan, ok := geometriLokasi(lokasi, peta)
+		if !ok {
+			collection.TanpaLokasi++
+			continue
+		}
+		properties := map[string]interface{}{
+			"indikator_id":         item.IndikatorId,
+			"indikator":            item.Indikator,
+			"rencana_kinerja_id":   item.RekinId,
+			"nama_rencana_kinerja": item.NamaRencanaKinerja,
+			"kode_opd":             item.KodeOpd,
+			"tahun":                item.Tahun,
+			"keterangan":           item.Keterangan,
+			"lokasi_perkiraan":     perkiraan,
+		}
+		isiPropertyWilayah(properties, peta, lokasi.KodeWilayah)
+		collection.Features = append(collection.Features, geospasial.Feature{
+			Type:       "Feature",
+			Id:         "indikator/" + strconv.FormatInt(item.Id, 10),
+			Geometry:   geometri,
+			Properties: properties,
+		})
+	}
+	return collection
+}
+
+// sebaranWilayah indikator dihitung sekali per wilayah walaupun punya beberapa titik di wilayah yang sama
+func sebaranWilayah(tingkat string, daftarWilayah []domain.Wilayah, daftarUsulan []domain.LokasiUsulan, daftarIndikator []domain.LokasiIndikator, tahap string) geospasial.FeatureCollection {
+	peta := petaWilayah(daftarWilayah)
+	jumlahUsulan := make(map[string]int)
+	perTahap := make(map[string]map[string]int)
+	indikatorWilayah := make(map[string]map[string]bool)
+	collection := geospasial.FeatureCollection{Type: tipeFeatureCollection, Features: []geospasial.Feature{}}
+
+	for _, usulan := range daftarUsulan {
+		tahapEfektif := tahapUsulan(domain.UsulanPipeline{Tahap: usulan.Tahap, RekinId: usulan.RekinId})
+		if tahap != "" && tahapEfektif != tahap {
+			continue
+		}
+		wilayah, ok := wilayahPadaTingkat(peta, kodeWilayahLokasi(usulan.Lokasi, daftarWilayah), tingkat)
+		if !ok {
+			collection.TanpaLokasi++
+			continue
+		}
+		jumlahUsulan[wilayah.KodeWilayah]++
+		if perTahap[wilayah.KodeWilayah] == nil {
+			perTahap[wilayah.KodeWilayah] = make(map[string]int)
+		}
+		perTahap[wilayah.KodeWilayah][tahapEfektif]++
+	}
+	for _, item := range daftarIndikator {
+		wilayah, ok := wilayahPadaTingkat(peta, kodeWilayahLokasi(item.Lokasi, daftarWilayah), tingkat)
+		if !ok {
+			continue
+		}
+		if indikatorWilayah[wilayah.KodeWilayah] == nil {
+			indikatorWilayah[wilayah.KodeWilayah] = make(map[string]bool)
+		}
+		indikatorWilayah[wilayah.KodeWilayah][item.IndikatorId] = true
+	}
+
+	for _, wilayah := range daftarWilayah {
+		if wilayah.Tingkat != tingkat {
+			continue
+		}
+		var geometri json.RawMessage
+		if wilayah.Geometri != "" {
+			geometri = json.RawMessage(wilayah.Geometri)
+		}
+		tahapWilayah := perTahap[wilayah.KodeWilayah]
+		if tahapWilayah == nil {
+			tahapWilayah = map[string]int{}
+		}
+		collection.Features = append(collection.Features, geospasial.Feature{
+			Type:     "Feature",
+			Id:       wilayah.KodeWilayah,
+			Geometry: geometri,
+			Properties: map[string]interface{}{
+				"kode_wilayah":     wilayah.KodeWilayah,
+				"nama":             wilayah.Nama,
+				"tingkat":          wilayah.Tingkat,
+				"kode_induk":       wilayah.KodeInduk,
+				"jumlah_usulan":    jumlahUsulan[wilayah.KodeWilayah],
+				"jumlah_indikator": len(indikatorWilayah[wilayah.KodeWilayah]),
+				"per_tahap":        tahapWilayah,
+			},
+		})
+	}
+	return collection
+}
+
+func nilaiProperty(properties map[string]interface{}, aliases []string) string {
+	kecil := make(map[string]interface{}, len(properties))
+	for key, value := range properties {
+		kecil[strings.ToLower(strings.TrimSpace(key))] = value
+	}
+	for _, alias := range aliases {
+		switch value := kecil[alias].(type) {
+		case string:
+			if strings.TrimSpace(value) != "" {
+				return strings.TrimSpace(value)
+			}
+		case float64:
+			// kode wilayah sering tersimpan sebagai angka di shapefile hasil konversi
+			return strconv.FormatFloat(value, 'f', -1, 64)
+		}
+	}
+	return ""
+}
+
+// bacaWilayahGeoJson fitur tanpa kode, nama atau geometri poligon yang valid ditolak per fitur,
+// induk desa yang kosong dicari dari kecamatan yang memuat titik tengahnya
+func bacaWilayahGeoJson(content []byte, tingkat string, daftarKecamatan []domain.Wilayah) ([]domain.Wilayah, int, []string, error) {
+	var collection struct {
+		Type     string `json:"type"`
+		Features []struct {
+			Geometry   json.RawMessage        `json:"geometry"`
+			Properties map[string]interface{} `json:"properties"`
+		} `json:"features"`
+	}
+	if err := json.Unmarshal(content, &collection); err != nil || collection.Type != tipeFeatureCollection {
+		return nil, 0, nil, web.NewBadRequestError("file harus berupa GeoJSON FeatureCollection")
+	}
+
+	var hasil []domain.Wilayah
+	ditolak := []string{}
+	sudah := make(map[string]bool)
+	for i, feature := range collection.Features {
+		kode := nilaiProperty(feature.Properties, propertyKodeWilayah[tingkat])
+		nama := nilaiProperty(feature.Properties, propertyNamaWilayah[tingkat])
+		label := fmt.Sprintf("fitur ke-%d", i+1)
+		if kode != "" {
+			label += " (" + kode + ")"
+		}
+		if kode == "" || nama == "" {
+			ditolak = append(ditolak, label+": kode atau nama wilayah kosong")
+			continue
+		}
+		if sudah[kode] {
+			ditolak = append(ditolak, label+": kode wilayah duplikat dalam file")
+			continue
+		}
+		tipe, err := helper.ValidasiGeometri(feature.Geometry)
+		if err != nil {
+			ditolak = append(ditolak, label+": "+err.Error())
+			continue
+		}
+		if tipe != helper.GeometriPolygon && tipe != helper.GeometriMultiPolygon {
+			ditolak = append(ditolak, fmt.Sprintf("%s: batas wilayah harus Polygon atau MultiPolygon, bukan %s", label, tipe))
+			continue
+		}
+
+		wilayah := domain.Wilayah{KodeWilayah: kode, Nama: nama, Tingkat: tingkat, Geometri: string(feature.Geometry)}
+		if latitude, longitude, ok := helper.TitikTengahGeometri(feature.Geometry); ok {
+			wilayah.Latitude, wilayah.Longitude = &latitude, &longitude
+		}
+		if tingkat == domain.TingkatWilayahDesa {
+			wilayah.KodeInduk = nilaiProperty(feature.Properties, propertyKodeInduk)
+			if wilayah.KodeInduk == "" && wilayah.Latitude != nil {
+				wilayah.KodeInduk = cariWilayahTitik(daftarKecamatan, *wilayah.Latitude, *wilayah.Longitude)
+			}
+		}
+		sudah[kode] = true
+		hasil = append(hasil, wilayah)
+	}
+	return hasil, len(collection.Features), ditolak, nil
+}
+
+func (service *GeospasialServiceImpl) FindWilayah(ctx context.Context, tingkat string, kodeInduk string) (geospasial.FeatureCollection, error) {
+	if tingkat != "" && !tingkatWilayahValid(tingkat) {
+		return geospasial.FeatureCollection{}, web.NewBadRequestError("tingkat wilayah harus kecamatan atau desa")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	daftarWilayah, err := service.GeospasialRepository.FindWilayah(ctx, tx, tingkat, kodeInduk)
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	collection := geospasial.FeatureCollection{Type: tipeFeatureCollection, Features: []geospasial.Feature{}}
+	for _, wilayah := range daftarWilayah {
+		var geometri json.RawMessage
+		if wilayah.Geometri != "" {
+			geometri = json.RawMessage(wilayah.Geometri)
+		}
+		collection.Features = append(collection.Features, geospasial.Feature{
+			Type:     "Feature",
+			Id:       wilayah.KodeWilayah,
+			Geometry: geometri,
+			Properties: map[string]interface{}{
+				"kode_wilayah": wilayah.KodeWilayah,
+				"nama":         wilayah.Nama,
+				"tingkat":      wilayah.Tingkat,
+				"kode_induk":   wilayah.KodeInduk,
+				"latitude":     wilayah.Latitude,
+				"longitude":    wilayah.Longitude,
+			},
+		})
+	}
+	return collection, nil
+}
+
+func (service *GeospasialServiceImpl) ImportWilayah(ctx context.Context, tingkat string, content []byte) (geospasial.WilayahImportResponse, error) {
+	if !tingkatWilayahValid(tingkat) {
+		return geospasial.WilayahImportResponse{}, web.NewBadRequestError("tingkat wilayah harus kecamatan atau desa")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return geospasial.WilayahImportResponse{}, err
+	}
+	defer tx.Rollback()
+
+	var daftarKecamatan []domain.Wilayah
+	if tingkat == domain.TingkatWilayahDesa {
+		daftarKecamatan, err = service.GeospasialRepository.FindWilayah(ctx, tx, domain.TingkatWilayahKecamatan, "")
+		if err != nil {
+			return geospasial.WilayahImportResponse{}, err
+		}
+	}
+	daftarWilayah, jumlahFitur, ditolak, err := bacaWilayahGeoJson(content, tingkat, daftarKecamatan)
+	if err != nil {
+		return geospasial.WilayahImportResponse{}, err
+	}
+	for _, wilayah := range daftarWilayah {
+		if err := service.GeospasialRepository.UpsertWilayah(ctx, tx, wilayah); err != nil {
+			return geospasial.WilayahImportResponse{}, err
+		}
+	}
+	if err := tx.Commit(); err != nil {
+		return geospasial.WilayahImportResponse{}, err
+	}
+
+	return geospasial.WilayahImportResponse{
+		Tingkat:        tingkat,
+		JumlahFitur:    jumlahFitur,
+		JumlahDisimpan: len(daftarWilayah),
+		Ditolak:        ditolak,
+	}, nil
+}
+
+// lengkapiKodeWilayah kode wilayah dari request harus ada di referensi, kosong diisi dari titik
+func lengkapiKodeWilayah(lokasi *domain.Lokasi, daftarWilayah []domain.Wilayah) error {
+	if lokasi.KodeWilayah != "" {
+		if _, ok := petaWilayah(daftarWilayah)[lokasi.KodeWilayah]; !ok {
+			return web.NewBadRequestError(fmt.Sprintf("kode wilayah %s tidak ada di referensi wilayah", lokasi.KodeWilayah))
+		}
+		return nil
+	}
+	lokasi.KodeWilayah = kodeWilayahLokasi(*lokasi, daftarWilayah)
+	return nil
+}
+
+func (service *GeospasialServiceImpl) UpdateLokasiUsulan(ctx context.Context, request geospasial.LokasiUsulanRequest) (geospasial.LokasiResponse, error) {
+	if request.JenisUsulan == "" {
+		return geospasial.LokasiResponse{}, web.NewBadRequestError("jenis usulan wajib diisi")
+	}
+	if _, err := jenisUsulanBerlokasi(request.JenisUsulan); err != nil {
+		return geospasial.LokasiResponse{}, err
+	}
+	lokasi, err := siapkanLokasi(request.LokasiRequest)
+	if err != nil {
+		return geospasial.LokasiResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return geospasial.LokasiResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	usulan, err := service.GeospasialRepository.FindLokasiUsulanById(ctx, tx, request.JenisUsulan, request.UsulanId)
+	if err == sql.ErrNoRows {
+		return geospasial.LokasiResponse{}, web.NewNotFoundError(fmt.Sprintf("usulan %s %s tidak ditemukan", request.JenisUsulan, request.UsulanId))
+	}
+	if err != nil {
+		return geospasial.LokasiResponse{}, err
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, usulan.KodeOpd); err != nil {
+		return geospasial.LokasiResponse{}, err
+	}
+
+	daftarWilayah, err := service.GeospasialRepository.FindWilayah(ctx, tx, "", "")
+	if err != nil {
+		return geospasial.LokasiResponse{}, err
+	}
+	if err := lengkapiKodeWilayah(&lokasi, daftarWilayah); err != nil {
+		return geospasial.LokasiResponse{}, err
+	}
+	if err := service.GeospasialRepository.UpdateLokasiUsulan(ctx, tx, request.JenisUsulan, request.UsulanId, lokasi); err != nil {
+		return geospasial.LokasiResponse{}, err
+	}
+	return toLokasiResponse(lokasi, petaWilayah(daftarWilayah), ""), nil
+}
+
+func (service *GeospasialServiceImpl) UpdateLokasiIndikator(ctx context.Context, request geospasial.LokasiIndikatorRequest) ([]geospasial.LokasiResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return nil, err
+	}
+	var daftarLokasi []domain.LokasiIndikator
+	for i, item := range request.Lokasi {
+		lokasi, err := siapkanLokasi(item.LokasiRequest)
+		if err != nil {
+			return nil, web.NewBadRequestError(fmt.Sprintf("lokasi ke-%d: %s", i+1, err.Error()))
+		}
+		if lokasi.Kosong() {
+			return nil, web.NewBadRequestError(fmt.Sprintf("lokasi ke-%d kosong, isi titik, geometri atau kode wilayah", i+1))
+		}
+		daftarLokasi = append(daftarLokasi, domain.LokasiIndikator{Keterangan: strings.TrimSpace(item.Keterangan), Lokasi: lokasi})
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	// rollback eksplisit agar lokasi lama tidak ikut terhapus saat insert lokasi baru gagal
+	defer tx.Rollback()
+
+	indikator, spasial, err := service.GeospasialRepository.FindIndikatorSpasial(ctx, tx, request.IndikatorId)
+	if err == sql.ErrNoRows {
+		return nil, web.NewNotFoundError(fmt.Sprintf("indikator %s tidak ditemukan", request.IndikatorId))
+	}
+	if err != nil {
+		return nil, err
+	}
+	if !spasial && len(daftarLokasi) > 0 {
+		return nil, web.NewConflictError("manual IK indikator belum ditandai spasial")
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, indikator.KodeOpd); err != nil {
+		return nil, err
+	}
+
+	daftarWilayah, err := service.GeospasialRepository.FindWilayah(ctx, tx, "", "")
+	if err != nil {
+		return nil, err
+	}
+	for i := range daftarLokasi {
+		if err := lengkapiKodeWilayah(&daftarLokasi[i].Lokasi, daftarWilayah); err != nil {
+			return nil, web.NewBadRequestError(fmt.Sprintf("lokasi ke-%d: %s", i+1, err.Error()))
+		}
+	}
+	if err := service.GeospasialRepository.ReplaceLokasiIndikator(ctx, tx, request.IndikatorId, daftarLokasi); err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+
+	peta := petaWilayah(daftarWilayah)
+	result := []geospasial.LokasiResponse{}
+	for _, item := range daftarLokasi {
+		result = append(result, toLokasiResponse(item.Lokasi, peta, item.Keterangan))
+	}
+	return result, nil
+}
+
+func (service *GeospasialServiceImpl) periksaFilter(ctx context.Context, kodeOpd string, tahun string, tahap string) error {
+	if !polaTahun.MatchString(tahun) {
+		return web.NewBadRequestError("tahun wajib diisi 4 digit")
+	}
+	if tahap != "" {
+		if _, ok := keteranganTahapUsulan[tahap]; !ok {
+			return web.NewBadRequestError(fmt.Sprintf("tahap %s tidak dikenal", tahap))
+		}
+	}
+	return service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun)
+}
+
+func (service *GeospasialServiceImpl) FeatureUsulan(ctx context.Context, kodeOpd string, tahun string, tahap string, jenisUsulan string) (geospasial.FeatureCollection, error) {
+	jenis, err := jenisUsulanBerlokasi(jenisUsulan)
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	if err := service.periksaFilter(ctx, kodeOpd, tahun, tahap); err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	daftarUsulan, err := service.GeospasialRepository.FindLokasiUsulan(ctx, tx, jenis, kodeOpd, tahun)
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	daftarWilayah, err := service.GeospasialRepository.FindWilayah(ctx, tx, "", "")
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	return featureUsulan(daftarUsulan, daftarWilayah, tahap), nil
+}
+
+func (service *GeospasialServiceImpl) FeatureIndikator(ctx context.Context, kodeOpd string, tahun string) (geospasial.FeatureCollection, error) {
+	if err := service.periksaFilter(ctx, kodeOpd, tahun, ""); err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	daftarLokasi, err := service.GeospasialRepository.FindLokasiIndikator(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	daftarWilayah, err := service.GeospasialRepository.FindWilayah(ctx, tx, "", "")
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	return featureIndikator(daftarLokasi, daftarWilayah), nil
+}
+
+func (service *GeospasialServiceImpl) Sebaran(ctx context.Context, tingkat string, kodeOpd string, tahun string, tahap string) (geospasial.FeatureCollection, error) {
+	if tingkat == "" {
+		tingkat = domain.TingkatWilayahKecamatan
+	}
+	if !tingkatWilayahValid(tingkat) {
+		return geospasial.FeatureCollection{}, web.NewBadRequestError("tingkat wilayah harus kecamatan atau desa")
+	}
+	if err := service.periksaFilter(ctx, kodeOpd, tahun, tahap); err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	jenis, _ := jenisUsulanBerlokasi("")
+	daftarUsulan, err := service.GeospasialRepository.FindLokasiUsulan(ctx, tx, jenis, kodeOpd, tahun)
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	daftarIndikator, err := service.GeospasialRepository.FindLokasiIndikator(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	daftarWilayah, err := service.GeospasialRepository.FindWilayah(ctx, tx, "", "")
+	if err != nil {
+		return geospasial.FeatureCollection{}, err
+	}
+	return sebaranWilayah(tingkat, daftarWilayah, daftarUsulan, daftarIndikator, tahap), nil
+}
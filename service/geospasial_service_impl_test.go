@@ -0,0 +1,147 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/geospasial"
+	"encoding/json"
+	"testing"
+)
+
+func koordinat(nilai float64) *float64 {
+	return &nilai
+}
+
+// kecamatan K1 persegi 111.0-111.2 BT, desa D1 separuh baratnya
+func wilayahUji() []domain.Wilayah {
+	return []domain.Wilayah{
+		{KodeWilayah: "K1", Nama: "Kecamatan Satu", Tingkat: domain.TingkatWilayahKecamatan, Latitude: koordinat(-7.6), Longitude: koordinat(111.1),
+			Geometri: `{"type":"Polygon","coordinates":[[[111.0,-7.7],[111.2,-7.7],[111.2,-7.5],[111.0,-7.5],[111.0,-7.7]]]}`},
+		{KodeWilayah: "D1", Nama: "Desa Satu", Tingkat: domain.TingkatWilayahDesa, KodeInduk: "K1", Latitude: koordinat(-7.6), Longitude: koordinat(111.05),
+			Geometri: `{"type":"Polygon","coordinates":[[[111.0,-7.7],[111.1,-7.7],[111.1,-7.5],[111.0,-7.5],[111.0,-7.7]]]}`},
+	}
+}
+
+func TestSiapkanLokasi(t *testing.T) {
+	tests := []struct {
+		name    string
+		request geospasial.LokasiRequest
+		valid   bool
+		lat     float64
+	}{
+		{name: "titik", request: geospasial.LokasiRequest{Latitude: koordinat(-7.6), Longitude: koordinat(111.5)}, valid: true, lat: -7.6},
+		{name: "titik dari geometri point", request: geospasial.LokasiRequest{Geometri: json.RawMessage(`{"type":"Point","coordinates":[111.5,-7.55]}`)}, valid: true, lat: -7.55},
+		{name: "hanya kode wilayah", request: geospasial.LokasiRequest{KodeWilayah: " D1 "}, valid: true},
+		{name: "latitude tanpa longitude", request: geospasial.LokasiRequest{Latitude: koordinat(-7.6)}},
+		{name: "longitude di luar rentang", request: geospasial.LokasiRequest{Latitude: koordinat(-7.6), Longitude: koordinat(211.5)}},
+		{name: "geometri rusak", request: geospasial.LokasiRequest{Geometri: json.RawMessage(`{"type":"Point"}`)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			lokasi, err := siapkanLokasi(tt.request)
+			if !tt.valid {
+				if err == nil {
+					t.Errorf("siapkanLokasi() = %+v, expected error", lokasi)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("siapkanLokasi() error = %v", err)
+			}
+			if tt.lat != 0 && (lokasi.Latitude == nil || *lokasi.Latitude != tt.lat) {
+				t.Errorf("siapkanLokasi() latitude = %v, expected %v", lokasi.Latitude, tt.lat)
+			}
+		})
+	}
+}
+
+func TestCariWilayahTitik(t *testing.T) {
+	daftarWilayah := wilayahUji()
+	if kode := cariWilayahTitik(daftarWilayah, -7.6, 111.05); kode != "D1" {
+		t.Errorf("titik di desa = %q, expected D1", kode)
+	}
+	if kode := cariWilayahTitik(daftarWilayah, -7.6, 111.15); kode != "K1" {
+		t.Errorf("titik di kecamatan tanpa desa = %q, expected K1", kode)
+	}
+	if kode := cariWilayahTitik(daftarWilayah, -7.6, 112.0); kode != "" {
+		t.Errorf("titik di luar wilayah = %q, expected kosong", kode)
+	}
+}
+
+func TestFeatureUsulan(t *testing.T) {
+	daftarUsulan := []domain.LokasiUsulan{
+		{JenisUsulan: domain.JenisUsulanMusrebang, UsulanId: "1", Lokasi: domain.Lokasi{Latitude: koordinat(-7.6), Longitude: koordinat(111.05)}},
+		{JenisUsulan: domain.JenisUsulanMusrebang, UsulanId: "2", RekinId: "R1", Lokasi: domain.Lokasi{KodeWilayah: "K1"}},
+		{JenisUsulan: domain.JenisUsulanPokokPikiran, UsulanId: "3"},
+	}
+
+	collection := featureUsulan(daftarUsulan, wilayahUji(), "")
+	if len(collection.Features) != 2 || collection.TanpaLokasi != 1 {
+		t.Fatalf("featureUsulan() = %d fitur, %d tanpa lokasi", len(collection.Features), collection.TanpaLokasi)
+	}
+	pertama := collection.Features[0].Properties
+	if pertama["kode_wilayah"] != "D1" || pertama["kode_kecamatan"] != "K1" || pertama["lokasi_perkiraan"] != false {
+		t.Errorf("fitur titik = %+v", pertama)
+	}
+	if collection.Features[1].Properties["lokasi_perkiraan"] != true {
+		t.Errorf("fitur dari titik tengah wilayah seharusnya perkiraan")
+	}
+
+	diakomodir := featureUsulan(daftarUsulan, wilayahUji(), domain.TahapUsulanDiakomodir)
+	if len(diakomodir.Features) != 1 || diakomodir.Features[0].Id != "musrebang/2" {
+		t.Errorf("filter tahap = %+v", diakomodir.Features)
+	}
+}
+
+func TestSebaranWilayah(t *testing.T) {
+	daftarUsulan := []domain.LokasiUsulan{
+		{UsulanId: "1", Lokasi: domain.Lokasi{KodeWilayah: "D1"}},
+		{UsulanId: "2", Lokasi: domain.Lokasi{Latitude: koordinat(-7.6), Longitude: koordinat(111.15)}},
+		{UsulanId: "3"},
+	}
+	daftarIndikator := []domain.LokasiIndikator{
+		{IndikatorId: "IND-1", Lokasi: domain.Lokasi{KodeWilayah: "D1"}},
+		{IndikatorId: "IND-1", Lokasi: domain.Lokasi{KodeWilayah: "K1"}},
+	}
+
+	kecamatan := sebaranWilayah(domain.TingkatWilayahKecamatan, wilayahUji(), daftarUsulan, daftarIndikator, "")
+	if len(kecamatan.Features) != 1 || kecamatan.TanpaLokasi != 1 {
+		t.Fatalf("sebaran kecamatan = %+v", kecamatan)
+	}
+	properties := kecamatan.Features[0].Properties
+	if properties["jumlah_usulan"] != 2 || properties["jumlah_indikator"] != 1 {
+		t.Errorf("sebaran kecamatan properties = %+v", properties)
+	}
+
+	desa := sebaranWilayah(domain.TingkatWilayahDesa, wilayahUji(), daftarUsulan, daftarIndikator, "")
+	if len(desa.Features) != 1 || desa.Features[0].Properties["jumlah_usulan"] != 1 || desa.TanpaLokasi != 2 {
+		t.Errorf("sebaran desa = %+v", desa)
+	}
+}
+
+func TestBacaWilayahGeoJson(t *testing.T) {
+	content := []byte(`{"type":"FeatureCollection","features":[
+		{"type":"Feature","properties":{"KODE_DESA":3519010001,"NAMA_DESA":"Desa Satu"},
+		 "geometry":{"type":"Polygon","coordinates":[[[111.0,-7.7],[111.1,-7.7],[111.1,-7.5],[111.0,-7.5],[111.0,-7.7]]]}},
+		{"type":"Feature","properties":{"kode":"3519010002"},
+		 "geometry":{"type":"Polygon","coordinates":[[[111.0,-7.7],[111.1,-7.7],[111.1,-7.5],[111.0,-7.5],[111.0,-7.7]]]}},
+		{"type":"Feature","properties":{"kode":"3519010003","nama":"Titik"},
+		 "geometry":{"type":"Point","coordinates":[111.0,-7.7]}}
+	]}`)
+
+	daftarWilayah, jumlah, ditolak, err := bacaWilayahGeoJson(content, domain.TingkatWilayahDesa, wilayahUji()[:1])
+	if err != nil {
+		t.Fatalf("bacaWilayahGeoJson() error = %v", err)
+	}
+	if jumlah != 3 || len(daftarWilayah) != 1 || len(ditolak) != 2 {
+		t.Fatalf("bacaWilayahGeoJson() = %d fitur, %+v, ditolak %v", jumlah, daftarWilayah, ditolak)
+	}
+	desa := daftarWilayah[0]
+	if desa.KodeWilayah != "3519010001" || desa.KodeInduk != "K1" || desa.Latitude == nil {
+		t.Errorf("desa = %+v", desa)
+	}
+
+	if _, _, _, err := bacaWilayahGeoJson([]byte(`{"type":"Feature"}`), domain.TingkatWilayahDesa, nil); err == nil {
+		t.Error("file bukan FeatureCollection seharusnya ditolak")
+	}
+}
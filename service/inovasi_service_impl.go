@@ -13,14 +13,16 @@ import (
 )
 
 type InovasiServiceImpl struct {
-	InovasiRepository repository.InovasiRepository
-	DB                *sql.DB
+	InovasiRepository                  repository.InovasiRepository
+	RencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository
+	DB                                 *sql.DB
 }
 
-func NewInovasiServiceImpl(inovasiRepository repository.InovasiRepository, DB *sql.DB) *InovasiServiceImpl {
+func NewInovasiServiceImpl(inovasiRepository repository.InovasiRepository, rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository, DB *sql.DB) *InovasiServiceImpl {
 	return &InovasiServiceImpl{
-		InovasiRepository: inovasiRepository,
-		DB:                DB,
+		InovasiRepository:                  inovasiRepository,
+		RencanaKinerjaVerifikasiRepository: rencanaKinerjaVerifikasiRepository,
+		DB:                                 DB,
 	}
 }
 
@@ -31,6 +33,10 @@ func (service *InovasiServiceImpl) Create(ctx context.Context, request inovasi.I
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, request.RekinId); err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
+
 	randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 	uuId := fmt.Sprintf("INOV-REKIN-%s", randomDigits)
 
@@ -59,6 +65,17 @@ func (service *InovasiServiceImpl) Update(ctx context.Context, request inovasi.I
 	}
 	defer helper.CommitOrRollback(tx)
 
+	// rekin lama dan rekin tujuan sama-sama tidak boleh terkunci
+	existing, err := service.InovasiRepository.FindById(ctx, tx, request.Id)
+	if err != nil {
+		return inovasi.InovasiResponse{}, err
+	}
+	for _, rekinId := range []string{existing.RekinId, request.RekinId} {
+		if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, rekinId); err != nil {
+			return inovasi.InovasiResponse{}, err
+		}
+	}
+
 	domainInovasi := domain.Inovasi{
 		Id:                    request.Id,
 		RekinId:               request.RekinId,
@@ -119,13 +136,16 @@ func (service *InovasiServiceImpl) Delete(ctx context.Context, inovasiId string)
 	defer helper.CommitOrRollback(tx)
 
 	// Periksa apakah inovasi dengan ID tersebut ada
-	_, err = service.InovasiRepository.FindById(ctx, tx, inovasiId)
+	existing, err := service.InovasiRepository.FindById(ctx, tx, inovasiId)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("id tidak ditemukan")
 		}
 		return err
 	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, existing.RekinId); err != nil {
+		return err
+	}
 
 	err = service.InovasiRepository.Delete(ctx, tx, inovasiId)
 	if err != nil {
@@ -309,6 +309,7 @@ func (service *KegiatanServiceImpl) FindAll(ctx context.Context) ([]kegiatan.Keg
 			Id:           keg.Id,
 			KodeKegiatan: keg.KodeKegiatan,
 			NamaKegiatan: keg.NamaKegiatan,
+			IsDeprecated: keg.IsDeprecated,
 			Indikator:    indikatorResponses,
 		}
 		kegiatanResponses = append(kegiatanResponses, kegiatanResponse)
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web/laporan"
+)
+
+type LaporanService interface {
+	// Lkjip menyusun data Laporan Kinerja Instansi Pemerintah satu OPD pada satu tahun
+	Lkjip(ctx context.Context, kodeOpd string, tahun string) (laporan.LkjipResponse, error)
+	LkjipDokumen(ctx context.Context, kodeOpd string, tahun string) (*helper.Dokumen, error)
+	// KakDokumen Kerangka Acuan Kerja satu rencana kinerja beserta jadwal pelaksanaan rencana aksi
+	KakDokumen(ctx context.Context, rencanaKinerjaId string) (*helper.Dokumen, error)
+}
@@ -0,0 +1,720 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/laporan"
+	"ekak_kabupaten_madiun/model/web/realisasi"
+	"ekak_kabupaten_madiun/model/web/rencanaaksi"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+	"ekak_kabupaten_madiun/model/web/rincianbelanja"
+	"ekak_kabupaten_madiun/model/web/subkegiatan"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// levelPkKepalaOpd level pohon rencana kinerja kepala OPD (strategic), dipakai sebagai PK pada LKjIP
+const levelPkKepalaOpd = 4
+
+type LaporanServiceImpl struct {
+	OpdRepository            repository.OpdRepository
+	PeriodeRepository        repository.PeriodeRepository
+	ProgramRepository        repository.ProgramRepository
+	TujuanOpdService         TujuanOpdService
+	SasaranOpdService        SasaranOpdService
+	IkuService               IkuService
+	PkService                PkService
+	RincianBelanjaService    RincianBelanjaService
+	RealisasiService         RealisasiService
+	RencanaKinerjaRepository repository.RencanaKinerjaRepository
+	RencanaKinerjaService    RencanaKinerjaService
+	SubKegiatanService       SubKegiatanService
+	OpdGuardService          OpdGuardService
+	DB                       *sql.DB
+}
+
+func NewLaporanServiceImpl(
+	opdRepository repository.OpdRepository,
+	periodeRepository repository.PeriodeRepository,
+	programRepository repository.ProgramRepository,
+	tujuanOpdService TujuanOpdService,
+	sasaranOpdService SasaranOpdService,
+	ikuService IkuService,
+	pkService PkService,
+	rincianBelanjaService RincianBelanjaService,
+	realisasiService RealisasiService,
+	rencanaKinerjaRepository repository.RencanaKinerjaRepository,
+	rencanaKinerjaService RencanaKinerjaService,
+	subKegiatanService SubKegiatanService,
+	opdGuardService OpdGuardService,
+	DB *sql.DB,
+) *LaporanServiceImpl {
+	return &LaporanServiceImpl{
+		OpdRepository:            opdRepository,
+		PeriodeRepository:        periodeRepository,
+		ProgramRepository:        programRepository,
+		TujuanOpdService:         tujuanOpdService,
+		SasaranOpdService:        sasaranOpdService,
+		IkuService:               ikuService,
+		PkService:                pkService,
+		RincianBelanjaService:    rincianBelanjaService,
+		RealisasiService:         realisasiService,
+		RencanaKinerjaRepository: rencanaKinerjaRepository,
+		RencanaKinerjaService:    rencanaKinerjaService,
+		SubKegiatanService:       subKegiatanService,
+		OpdGuardService:          opdGuardService,
+		DB:                       DB,
+	}
+}
+
+func (service *LaporanServiceImpl) Lkjip(ctx context.Context, kodeOpd string, tahun string) (laporan.LkjipResponse, error) {
+	if !helper.IsTahun(tahun) {
+		return laporan.LkjipResponse{}, web.NewBadRequestError("tahun tidak valid")
+	}
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return laporan.LkjipResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return laporan.LkjipResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	opd, err := service.OpdRepository.FindByKodeOpd(ctx, tx, kodeOpd)
+	if err != nil {
+		return laporan.LkjipResponse{}, web.NewNotFoundError(fmt.Sprintf("OPD dengan kode %s tidak ditemukan", kodeOpd))
+	}
+	periode, err := service.PeriodeRepository.FindByTahun(ctx, tx, tahun)
+	if err != nil {
+		return laporan.LkjipResponse{}, web.NewNotFoundError(fmt.Sprintf("periode untuk tahun %s tidak ditemukan", tahun))
+	}
+
+	response := laporan.LkjipResponse{
+		KodeOpd:       opd.KodeOpd,
+		NamaOpd:       opd.NamaOpd,
+		NamaKepalaOpd: opd.NamaKepalaOpd,
+		NipKepalaOpd:  opd.NIPKepalaOpd,
+		Tahun:         tahun,
+		Periode: laporan.PeriodeResponse{
+			TahunAwal:    periode.TahunAwal,
+			TahunAkhir:   periode.TahunAkhir,
+			JenisPeriode: periode.JenisPeriode,
+		},
+		Tujuan:            []laporan.TujuanLkjip{},
+		Sasaran:           []laporan.SasaranLkjip{},
+		Iku:               []laporan.IndikatorLkjip{},
+		PerjanjianKinerja: []laporan.PkLkjip{},
+		Anggaran:          []laporan.ProgramLkjip{},
+	}
+
+	tujuanList, err := service.TujuanOpdService.FindAll(ctx, kodeOpd, periode.TahunAwal, periode.TahunAkhir, periode.JenisPeriode)
+	if err != nil {
+		return laporan.LkjipResponse{}, fmt.Errorf("gagal mengambil tujuan OPD: %w", err)
+	}
+	for _, bidang := range tujuanList {
+		for _, tujuan := range bidang.TujuanOpd {
+			item := laporan.TujuanLkjip{Id: tujuan.Id, Tujuan: tujuan.Tujuan, Indikator: []laporan.IndikatorLkjip{}}
+			for _, indikator := range tujuan.Indikator {
+				lkjipIndikator := laporan.IndikatorLkjip{
+					IndikatorId:      indikator.Id,
+					Indikator:        indikator.NamaIndikator,
+					RumusPerhitungan: indikator.RumusPerhitungan,
+				}
+				for _, target := range indikator.Target {
+					if target.Tahun == tahun {
+						lkjipIndikator.Target, lkjipIndikator.Satuan = target.TargetIndikator, target.SatuanIndikator
+					}
+				}
+				item.Indikator = append(item.Indikator, lkjipIndikator)
+			}
+			response.Tujuan = append(response.Tujuan, item)
+		}
+	}
+
+	sasaranList, err := service.SasaranOpdService.FindAll(ctx, kodeOpd, periode.TahunAwal, periode.TahunAkhir, periode.JenisPeriode)
+	if err != nil {
+		return laporan.LkjipResponse{}, fmt.Errorf("gagal mengambil sasaran OPD: %w", err)
+	}
+	for _, pohon := range sasaranList {
+		for _, sasaran := range pohon.SasaranOpd {
+			item := laporan.SasaranLkjip{Id: sasaran.Id, Sasaran: sasaran.NamaSasaranOpd, Tujuan: sasaran.NamaTujuanOpd, Indikator: []laporan.IndikatorLkjip{}}
+			for _, indikator := range sasaran.Indikator {
+				lkjipIndikator := laporan.IndikatorLkjip{
+					IndikatorId:      indikator.Id,
+					Indikator:        indikator.Indikator,
+					RumusPerhitungan: indikator.RumusPerhitungan,
+				}
+				for _, target := range indikator.Target {
+					if target.Tahun == tahun {
+						lkjipIndikator.Target, lkjipIndikator.Satuan = target.Target, target.Satuan
+					}
+				}
+				item.Indikator = append(item.Indikator, lkjipIndikator)
+			}
+			response.Sasaran = append(response.Sasaran, item)
+		}
+	}
+
+	ikuList, err := service.IkuService.FindAllIkuOpd(ctx, kodeOpd, periode.TahunAwal, periode.TahunAkhir, periode.JenisPeriode)
+	if err != nil {
+		return laporan.LkjipResponse{}, fmt.Errorf("gagal mengambil IKU OPD: %w", err)
+	}
+	for _, iku := range ikuList {
+		if !iku.IkuActive {
+			continue
+		}
+		item := laporan.IndikatorLkjip{
+			IndikatorId:      iku.IndikatorId,
+			Indikator:        iku.Indikator,
+			RumusPerhitungan: iku.RumusPerhitungan,
+		}
+		for _, target := range iku.Target {
+			if target.Tahun == tahun {
+				item.Target, item.Satuan = target.Target, target.Satuan
+			}
+		}
+		response.Iku = append(response.Iku, item)
+	}
+
+	tahunInt, _ := strconv.Atoi(tahun)
+	pk, err := service.PkService.FindByKodeOpdTahun(ctx, kodeOpd, tahunInt)
+	if err != nil {
+		return laporan.LkjipResponse{}, fmt.Errorf("gagal mengambil perjanjian kinerja: %w", err)
+	}
+	for _, level := range pk.PkItem {
+		if level.LevelPk != levelPkKepalaOpd {
+			continue
+		}
+		for _, pegawai := range level.Pegawais {
+			for _, pkAsn := range pegawai.Pks {
+				item := laporan.PkLkjip{
+					LevelPk:        pkAsn.LevelPk,
+					Nip:            pegawai.Nip,
+					Nama:           pegawai.Nama,
+					Jabatan:        pegawai.JabatanPegawai,
+					RencanaKinerja: pkAsn.RekinPemilikPk,
+					Indikator:      []laporan.IndikatorLkjip{},
+				}
+				for _, indikator := range pkAsn.Indikators {
+					lkjipIndikator := laporan.IndikatorLkjip{IndikatorId: indikator.IdIndikator, Indikator: indikator.Indikator}
+					if len(indikator.Targets) > 0 {
+						lkjipIndikator.Target, lkjipIndikator.Satuan = indikator.Targets[0].Target, indikator.Targets[0].Satuan
+					}
+					item.Indikator = append(item.Indikator, lkjipIndikator)
+				}
+				response.PerjanjianKinerja = append(response.PerjanjianKinerja, item)
+			}
+		}
+	}
+
+	belanjaList, err := service.RincianBelanjaService.LaporanRincianBelanjaOpd(ctx, kodeOpd, tahun)
+	if err != nil {
+		return laporan.LkjipResponse{}, fmt.Errorf("gagal mengambil rincian belanja: %w", err)
+	}
+	response.Anggaran, response.TotalAnggaran = service.anggaranPerProgram(ctx, tx, belanjaList)
+
+	if err := service.isiRealisasi(ctx, &response); err != nil {
+		return laporan.LkjipResponse{}, err
+	}
+	response.Ringkasan = ringkasLkjip(response)
+
+	return response, nil
+}
+
+// anggaranPerProgram mengelompokkan pagu sub kegiatan ke program (tiga segmen pertama kode sub kegiatan)
+func (service *LaporanServiceImpl) anggaranPerProgram(ctx context.Context, tx *sql.Tx, belanjaList []rincianbelanja.RincianBelanjaAsnResponse) ([]laporan.ProgramLkjip, int64) {
+	programMap := make(map[string]*laporan.ProgramLkjip)
+	subKegiatanMap := make(map[string]int)
+	var kodePrograms []string
+	var total int64
+
+	for _, belanja := range belanjaList {
+		kodeProgram := kodeProgramDariSubKegiatan(belanja.KodeSubkegiatan)
+		program, ok := programMap[kodeProgram]
+		if !ok {
+			program = &laporan.ProgramLkjip{KodeProgram: kodeProgram, SubKegiatan: []laporan.SubKegiatanLkjip{}}
+			if master, err := service.ProgramRepository.FindByKodeProgram(ctx, tx, kodeProgram); err == nil {
+				program.NamaProgram = master.NamaProgram
+			}
+			programMap[kodeProgram] = program
+			kodePrograms = append(kodePrograms, kodeProgram)
+		}
+
+		pagu := int64(belanja.TotalAnggaran)
+		program.Pagu += pagu
+		total += pagu
+
+		// sub kegiatan yang sama bisa muncul untuk beberapa pegawai
+		key := kodeProgram + "|" + belanja.KodeSubkegiatan
+		if idx, ok := subKegiatanMap[key]; ok {
+			program.SubKegiatan[idx].Pagu += pagu
+			continue
+		}
+		subKegiatanMap[key] = len(program.SubKegiatan)
+		program.SubKegiatan = append(program.SubKegiatan, laporan.SubKegiatanLkjip{
+			KodeSubKegiatan: belanja.KodeSubkegiatan,
+			NamaSubKegiatan: belanja.NamaSubkegiatan,
+			Pagu:            pagu,
+		})
+	}
+
+	sort.Strings(kodePrograms)
+	result := make([]laporan.ProgramLkjip, 0, len(kodePrograms))
+	for _, kodeProgram := range kodePrograms {
+		result = append(result, *programMap[kodeProgram])
+	}
+	return result, total
+}
+
+// isiRealisasi melengkapi realisasi dan capaian seluruh indikator laporan, target dari sumber data tetap dipakai
+func (service *LaporanServiceImpl) isiRealisasi(ctx context.Context, response *laporan.LkjipResponse) error {
+	var semua []*laporan.IndikatorLkjip
+	for i := range response.Tujuan {
+		for j := range response.Tujuan[i].Indikator {
+			semua = append(semua, &response.Tujuan[i].Indikator[j])
+		}
+	}
+	for i := range response.Sasaran {
+		for j := range response.Sasaran[i].Indikator {
+			semua = append(semua, &response.Sasaran[i].Indikator[j])
+		}
+	}
+	for i := range response.Iku {
+		semua = append(semua, &response.Iku[i])
+	}
+	for i := range response.PerjanjianKinerja {
+		for j := range response.PerjanjianKinerja[i].Indikator {
+			semua = append(semua, &response.PerjanjianKinerja[i].Indikator[j])
+		}
+	}
+
+	var indikators []domain.IndikatorRealisasi
+	sudahAda := make(map[string]bool)
+	for _, indikator := range semua {
+		if indikator.IndikatorId == "" || sudahAda[indikator.IndikatorId] {
+			continue
+		}
+		sudahAda[indikator.IndikatorId] = true
+		indikators = append(indikators, domain.IndikatorRealisasi{
+			Id:               indikator.IndikatorId,
+			Indikator:        indikator.Indikator,
+			RumusPerhitungan: indikator.RumusPerhitungan,
+			KodeOpd:          response.KodeOpd,
+		})
+	}
+
+	capaianList, err := service.RealisasiService.FindCapaian(ctx, indikators, response.Tahun)
+	if err != nil {
+		return fmt.Errorf("gagal mengambil realisasi: %w", err)
+	}
+	capaianMap := make(map[string]realisasi.CapaianIndikatorResponse, len(capaianList))
+	for _, capaian := range capaianList {
+		capaianMap[capaian.IndikatorId] = capaian
+	}
+
+	for _, indikator := range semua {
+		capaian, ok := capaianMap[indikator.IndikatorId]
+		if !ok || capaian.RealisasiTerakhir == "" {
+			continue
+		}
+		if indikator.Target == "" {
+			indikator.Target, indikator.Satuan = capaian.Target, capaian.Satuan
+		}
+		indikator.Realisasi = capaian.RealisasiTerakhir
+		indikator.Capaian = helper.CapaianDariTeks(indikator.Target, capaian.RealisasiTerakhir, capaian.Polaritas)
+	}
+	return nil
+}
+
+// ringkasLkjip rata-rata capaian dihitung dari indikator sasaran dan IKU yang sudah terukur
+func ringkasLkjip(response laporan.LkjipResponse) laporan.RingkasanLkjip {
+	var ringkasan laporan.RingkasanLkjip
+	var total float64
+
+	hitung := func(indikator laporan.IndikatorLkjip) {
+		ringkasan.JumlahIndikator++
+		if indikator.Capaian != nil {
+			ringkasan.JumlahTerukur++
+			total += *indikator.Capaian
+		}
+	}
+	for _, sasaran := range response.Sasaran {
+		for _, indikator := range sasaran.Indikator {
+			hitung(indikator)
+		}
+	}
+	for _, indikator := range response.Iku {
+		hitung(indikator)
+	}
+
+	if ringkasan.JumlahTerukur > 0 {
+		rataRata := math.Round(total/float64(ringkasan.JumlahTerukur)*100) / 100
+		ringkasan.RataRataCapaian = &rataRata
+	}
+	return ringkasan
+}
+
+func kodeProgramDariSubKegiatan(kodeSubKegiatan string) string {
+	segments := strings.Split(kodeSubKegiatan, ".")
+	if len(segments) < 3 {
+		return kodeSubKegiatan
+	}
+	return strings.Join(segments[:3], ".")
+}
+
+func (service *LaporanServiceImpl) LkjipDokumen(ctx context.Context, kodeOpd string, tahun string) (*helper.Dokumen, error) {
+	response, err := service.Lkjip(ctx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+	return dokumenLkjip(response), nil
+}
+
+func dokumenLkjip(response laporan.LkjipResponse) *helper.Dokumen {
+	dokumen := helper.NewDokumen(
+		"LAPORAN KINERJA INSTANSI PEMERINTAH (LKjIP)",
+		strings.ToUpper(response.NamaOpd),
+		"TAHUN "+response.Tahun,
+	)
+	dokumen.Landscape = true
+
+	dokumen.TambahJudul(1, "A. Identitas")
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Lebar:      []float64{1, 3},
+		TanpaGaris: true,
+		Baris: [][]string{
+			{"Perangkat Daerah", response.NamaOpd},
+			{"Kode", response.KodeOpd},
+			{"Kepala Perangkat Daerah", response.NamaKepalaOpd},
+			{"NIP", response.NipKepalaOpd},
+			{"Periode Renstra", fmt.Sprintf("%s - %s (%s)", response.Periode.TahunAwal, response.Periode.TahunAkhir, response.Periode.JenisPeriode)},
+		},
+	})
+
+	kolomIndikator := []string{"No", "Uraian", "Indikator", "Target", "Satuan", "Realisasi", "Capaian (%)"}
+	lebarIndikator := []float64{0.5, 4, 4, 1.2, 1.2, 1.2, 1.1}
+
+	dokumen.TambahJudul(1, "B. Tujuan Perangkat Daerah")
+	var barisTujuan [][]string
+	for i, tujuan := range response.Tujuan {
+		barisTujuan = append(barisTujuan, barisIndikatorLkjip(i+1, tujuan.Tujuan, tujuan.Indikator)...)
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{Kolom: kolomIndikator, Lebar: lebarIndikator, Baris: barisAtauKosong(barisTujuan, len(kolomIndikator))})
+
+	dokumen.TambahJudul(1, "C. Sasaran Perangkat Daerah")
+	var barisSasaran [][]string
+	for i, sasaran := range response.Sasaran {
+		barisSasaran = append(barisSasaran, barisIndikatorLkjip(i+1, sasaran.Sasaran, sasaran.Indikator)...)
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{Kolom: kolomIndikator, Lebar: lebarIndikator, Baris: barisAtauKosong(barisSasaran, len(kolomIndikator))})
+
+	dokumen.TambahJudul(1, "D. Indikator Kinerja Utama")
+	var barisIku [][]string
+	for i, iku := range response.Iku {
+		barisIku = append(barisIku, []string{strconv.Itoa(i + 1), iku.Indikator, iku.Target, iku.Satuan, iku.Realisasi, formatCapaian(iku.Capaian)})
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Kolom: []string{"No", "Indikator", "Target", "Satuan", "Realisasi", "Capaian (%)"},
+		Lebar: []float64{0.5, 8, 1.2, 1.2, 1.2, 1.1},
+		Baris: barisAtauKosong(barisIku, 6),
+	})
+
+	dokumen.TambahJudul(1, "E. Perjanjian Kinerja")
+	var barisPk [][]string
+	for i, pk := range response.PerjanjianKinerja {
+		barisPk = append(barisPk, barisIndikatorLkjip(i+1, fmt.Sprintf("%s\n%s", pk.RencanaKinerja, pk.Nama), pk.Indikator)...)
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{Kolom: kolomIndikator, Lebar: lebarIndikator, Baris: barisAtauKosong(barisPk, len(kolomIndikator))})
+
+	dokumen.TambahJudul(1, "F. Anggaran per Program")
+	var barisAnggaran [][]string
+	for i, program := range response.Anggaran {
+		barisAnggaran = append(barisAnggaran, []string{strconv.Itoa(i + 1), program.KodeProgram, program.NamaProgram, helper.FormatRupiah(program.Pagu)})
+		for _, sub := range program.SubKegiatan {
+			barisAnggaran = append(barisAnggaran, []string{"", sub.KodeSubKegiatan, sub.NamaSubKegiatan, helper.FormatRupiah(sub.Pagu)})
+		}
+	}
+	barisAnggaran = append(barisAnggaran, []string{"", "", "TOTAL", helper.FormatRupiah(response.TotalAnggaran)})
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Kolom: []string{"No", "Kode", "Program / Sub Kegiatan", "Pagu (Rp)"},
+		Lebar: []float64{0.5, 2, 7, 2},
+		Baris: barisAnggaran,
+	})
+
+	dokumen.TambahJudul(1, "G. Ringkasan Capaian")
+	ringkasan := fmt.Sprintf("Dari %d indikator sasaran dan IKU, %d indikator sudah memiliki realisasi.",
+		response.Ringkasan.JumlahIndikator, response.Ringkasan.JumlahTerukur)
+	if response.Ringkasan.RataRataCapaian != nil {
+		ringkasan += fmt.Sprintf(" Rata-rata capaian kinerja sebesar %s%%.", formatCapaian(response.Ringkasan.RataRataCapaian))
+	}
+	dokumen.TambahParagraf(ringkasan)
+
+	return dokumen
+}
+
+// barisIndikatorLkjip satu baris per indikator, uraian hanya ditulis pada baris pertama
+func barisIndikatorLkjip(nomor int, uraian string, indikators []laporan.IndikatorLkjip) [][]string {
+	if len(indikators) == 0 {
+		return [][]string{{strconv.Itoa(nomor), uraian, "-", "", "", "", ""}}
+	}
+	baris := make([][]string, 0, len(indikators))
+	for i, indikator := range indikators {
+		no, teks := "", ""
+		if i == 0 {
+			no, teks = strconv.Itoa(nomor), uraian
+		}
+		baris = append(baris, []string{no, teks, indikator.Indikator, indikator.Target, indikator.Satuan, indikator.Realisasi, formatCapaian(indikator.Capaian)})
+	}
+	return baris
+}
+
+func barisAtauKosong(baris [][]string, jumlahKolom int) [][]string {
+	if len(baris) > 0 {
+		return baris
+	}
+	kosong := make([]string, jumlahKolom)
+	kosong[0] = "-"
+	if jumlahKolom > 1 {
+		kosong[1] = "Belum ada data"
+	}
+	return [][]string{kosong}
+}
+
+func formatCapaian(capaian *float64) string {
+	if capaian == nil {
+		return "-"
+	}
+	return strings.Replace(strconv.FormatFloat(*capaian, 'f', 2, 64), ".", ",", 1)
+}
+
+var namaBulanSingkat = []string{"Jan", "Feb", "Mar", "Apr", "Mei", "Jun", "Jul", "Agu", "Sep", "Okt", "Nov", "Des"}
+
+func (service *LaporanServiceImpl) KakDokumen(ctx context.Context, rencanaKinerjaId string) (*helper.Dokumen, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	rekin, err := service.RencanaKinerjaRepository.FindById(ctx, tx, rencanaKinerjaId, "", "")
+	helper.CommitOrRollback(tx)
+	if err == sql.ErrNoRows {
+		return nil, web.NewNotFoundError("rencana kinerja tidak ditemukan")
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := service.OpdGuardService.CheckRead(ctx, rekin.KodeOpd, rekin.Tahun); err != nil {
+		return nil, err
+	}
+
+	rincian, err := service.RencanaKinerjaService.FindAllRincianKak(ctx, rekin.PegawaiId, rencanaKinerjaId)
+	if err != nil {
+		return nil, err
+	}
+	if len(rincian) == 0 {
+		return nil, web.NewNotFoundError("rincian KAK rencana kinerja tidak ditemukan")
+	}
+
+	var kaks []subkegiatan.SubKegiatanKAKResponse
+	for _, sub := range rincian[0].SubKegiatan {
+		kak, err := service.SubKegiatanService.FindSubKegiatanKAK(ctx, rekin.KodeOpd, sub.KodeSubKegiatan, rekin.Tahun)
+		if errors.Is(err, sql.ErrNoRows) {
+			// subkegiatan belum masuk cascading/pagu OPD, tetap dicantumkan dari data rekin
+			kak = subkegiatan.SubKegiatanKAKResponse{SubKegiatan: subkegiatan.SubKegiatanDetailKAKResponse{Subkegiatan: sub.KodeSubKegiatan, Nama: sub.NamaSubKegiatan}}
+		} else if err != nil {
+			return nil, err
+		}
+		kaks = append(kaks, kak)
+	}
+
+	return dokumenKak(rincian[0], kaks), nil
+}
+
+func dokumenKak(rincian rencanakinerja.DataRincianKerja, kaks []subkegiatan.SubKegiatanKAKResponse) *helper.Dokumen {
+	rekin := rincian.RencanaKinerja
+	dokumen := helper.NewDokumen(
+		"KERANGKA ACUAN KERJA (KAK)",
+		strings.ToUpper(rekin.NamaRencanaKinerja),
+		strings.ToUpper(rekin.KodeOpd.NamaOpd),
+		"TAHUN "+rekin.Tahun,
+	)
+
+	dokumen.TambahJudul(1, "A. Identitas")
+	identitas := [][]string{
+		{"Perangkat Daerah", rekin.KodeOpd.NamaOpd},
+		{"Pemilik Rencana Kinerja", fmt.Sprintf("%s (NIP. %s)", rekin.NamaPegawai, rekin.PegawaiId)},
+		{"Rencana Kinerja", rekin.NamaRencanaKinerja},
+		{"Pohon Kinerja", rekin.NamaPohon},
+		{"Tahun", rekin.Tahun},
+	}
+	var totalPagu int64
+	for _, kak := range kaks {
+		pagu, _ := strconv.ParseInt(kak.PaguAnggaran, 10, 64)
+		totalPagu += pagu
+	}
+	identitas = append(identitas, []string{"Pagu Anggaran", "Rp " + helper.FormatRupiah(totalPagu)})
+	dokumen.TambahTabel(helper.TabelDokumen{Lebar: []float64{1, 3}, TanpaGaris: true, Baris: identitas})
+
+	dokumen.TambahJudul(1, "B. Latar Belakang")
+	dokumen.TambahJudul(2, "1. Dasar Hukum")
+	var barisDasarHukum [][]string
+	for i, dasarHukum := range rincian.DasarHukum {
+		barisDasarHukum = append(barisDasarHukum, []string{strconv.Itoa(i + 1), dasarHukum.PeraturanTerkait, dasarHukum.Uraian})
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Kolom: []string{"No", "Peraturan Terkait", "Uraian"},
+		Lebar: []float64{0.5, 4, 6},
+		Baris: barisAtauKosong(barisDasarHukum, 3),
+	})
+
+	dokumen.TambahJudul(2, "2. Gambaran Umum")
+	if len(rincian.GambaranUmum) == 0 {
+		dokumen.TambahParagraf("-")
+	}
+	for _, gambaranUmum := range rincian.GambaranUmum {
+		dokumen.TambahParagraf(gambaranUmum.GambaranUmum)
+	}
+
+	dokumen.TambahJudul(2, "3. Permasalahan")
+	var barisPermasalahan [][]string
+	for i, masalah := range rincian.Permasalahan {
+		barisPermasalahan = append(barisPermasalahan, []string{strconv.Itoa(i + 1), masalah.Permasalahan, masalah.PenyebabInternal, masalah.PenyebabEksternal, masalah.JenisPermasalahan})
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Kolom: []string{"No", "Permasalahan", "Penyebab Internal", "Penyebab Eksternal", "Jenis"},
+		Lebar: []float64{0.5, 4, 3, 3, 1.5},
+		Baris: barisAtauKosong(barisPermasalahan, 5),
+	})
+
+	dokumen.TambahJudul(1, "C. Indikator Kinerja")
+	var barisIndikator [][]string
+	for i, indikator := range rekin.Indikator {
+		target, satuan := "", ""
+		if len(indikator.Target) > 0 {
+			target, satuan = indikator.Target[0].TargetIndikator, indikator.Target[0].SatuanIndikator
+		}
+		barisIndikator = append(barisIndikator, []string{strconv.Itoa(i + 1), indikator.NamaIndikator, target, satuan})
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Kolom: []string{"No", "Indikator Rencana Kinerja", "Target", "Satuan"},
+		Lebar: []float64{0.5, 7, 1.5, 1.5},
+		Baris: barisAtauKosong(barisIndikator, 4),
+	})
+
+	dokumen.TambahJudul(1, "D. Program, Kegiatan dan Sub Kegiatan")
+	var barisProgram [][]string
+	for _, kak := range kaks {
+		tambah := func(level, kode, nama string, indikator subkegiatan.IndikatorKinerjaKAKResponse) {
+			if kode == "" && nama == "" {
+				return
+			}
+			barisProgram = append(barisProgram, []string{level, kode + " " + nama, indikator.Nama, indikator.Target, indikator.Satuan})
+		}
+		tambah("Program", kak.Program.Kode, kak.Program.Nama, kak.Program.IndikatorKinerjaProgram)
+		tambah("Kegiatan", kak.Kegiatan.Kode, kak.Kegiatan.Nama, kak.Kegiatan.IndikatorKinerjaKegiatan)
+		tambah("Sub Kegiatan", kak.SubKegiatan.Subkegiatan, kak.SubKegiatan.Nama, kak.SubKegiatan.IndikatorKinerjaSubKegiatan)
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Kolom: []string{"Level", "Nomenklatur", "Indikator", "Target", "Satuan"},
+		Lebar: []float64{1.3, 4, 4, 1.2, 1.2},
+		Baris: barisAtauKosong(barisProgram, 5),
+	})
+
+	dokumen.TambahJudul(1, "E. Usulan yang Diakomodasi")
+	var barisUsulan [][]string
+	for i, usulan := range rincian.Usulan {
+		jenis := strings.ReplaceAll(strings.TrimPrefix(usulan.JenisUsulan, "usulan_"), "_", " ")
+		barisUsulan = append(barisUsulan, []string{strconv.Itoa(i + 1), jenis, usulan.Usulan, usulan.Uraian, usulan.Status})
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Kolom: []string{"No", "Jenis", "Usulan", "Uraian", "Status"},
+		Lebar: []float64{0.5, 1.5, 4, 4, 1.5},
+		Baris: barisAtauKosong(barisUsulan, 5),
+	})
+
+	dokumen.TambahJudul(1, "F. Inovasi")
+	var barisInovasi [][]string
+	for i, inovasi := range rincian.Inovasi {
+		barisInovasi = append(barisInovasi, []string{strconv.Itoa(i + 1), inovasi.JudulInovasi, inovasi.JenisInovasi, inovasi.GambaranNilaiKebaruan})
+	}
+	dokumen.TambahTabel(helper.TabelDokumen{
+		Kolom: []string{"No", "Judul Inovasi", "Jenis", "Gambaran Nilai Kebaruan"},
+		Lebar: []float64{0.5, 3.5, 1.5, 5},
+		Baris: barisAtauKosong(barisInovasi, 4),
+	})
+
+	dokumen.TambahJudul(1, "G. Rencana Aksi dan Jadwal Pelaksanaan")
+	dokumen.TambahTabel(ganttKak(rincian.RencanaAksi))
+	dokumen.TambahParagraf(fmt.Sprintf("Waktu pelaksanaan yang dibutuhkan %d bulan dengan total bobot %d.", rincian.RencanaAksi.WaktuDibutuhkan, rincian.RencanaAksi.TotalKeseluruhan))
+
+	dokumen.TambahJudul(1, "H. Penutup")
+	dokumen.TambahParagraf("Demikian Kerangka Acuan Kerja ini disusun sebagai pedoman pelaksanaan rencana kinerja agar berjalan efektif, efisien dan mencapai target kinerja yang telah ditetapkan.")
+	dokumen.TambahTabel(helper.TabelDokumen{
+		TanpaGaris: true,
+		RataTengah: true,
+		Baris: [][]string{
+			{"", "............................, .................... " + rekin.Tahun},
+			{"", "Pemilik Rencana Kinerja,"},
+			{"", "\n\n\n\n"},
+			{"", rekin.NamaPegawai},
+			{"", "NIP. " + rekin.PegawaiId},
+		},
+	})
+
+	return dokumen
+}
+
+// ganttKak bobot per bulan tiap rencana aksi, bulan berbobot diarsir
+func ganttKak(tabelRencanaAksi rencanaaksi.RencanaAksiTableResponse) helper.TabelDokumen {
+	kolom := append([]string{"No", "Rencana Aksi"}, namaBulanSingkat...)
+	kolom = append(kolom, "Total")
+	lebar := []float64{0.5, 4.5}
+	for range namaBulanSingkat {
+		lebar = append(lebar, 0.75)
+	}
+	lebar = append(lebar, 0.9)
+
+	bobotSel := func(bobot int) string {
+		if bobot == 0 {
+			return ""
+		}
+		return strconv.Itoa(bobot)
+	}
+
+	tabel := helper.TabelDokumen{Kolom: kolom, Lebar: lebar}
+	for i, rencanaAksi := range tabelRencanaAksi.RencanaAksi {
+		baris := make([]string, len(kolom))
+		arsir := make([]bool, len(kolom))
+		baris[0] = strconv.Itoa(i + 1)
+		baris[1] = rencanaAksi.NamaRencanaAksi
+		for _, pelaksanaan := range rencanaAksi.PelaksanaanRencanaAksi {
+			if pelaksanaan.Bulan < 1 || pelaksanaan.Bulan > 12 {
+				continue
+			}
+			baris[pelaksanaan.Bulan+1] = bobotSel(pelaksanaan.Bobot)
+			arsir[pelaksanaan.Bulan+1] = pelaksanaan.Bobot > 0
+		}
+		baris[len(kolom)-1] = strconv.Itoa(rencanaAksi.TotalBobotRencanaAksi)
+		tabel.Baris = append(tabel.Baris, baris)
+		tabel.Arsir = append(tabel.Arsir, arsir)
+	}
+
+	total := make([]string, len(kolom))
+	total[1] = "Total per Bulan"
+	for _, bulanan := range tabelRencanaAksi.TotalPerBulan {
+		if bulanan.Bulan >= 1 && bulanan.Bulan <= 12 {
+			total[bulanan.Bulan+1] = bobotSel(bulanan.TotalBobot)
+		}
+	}
+	total[len(kolom)-1] = strconv.Itoa(tabelRencanaAksi.TotalKeseluruhan)
+	tabel.Baris = append(tabel.Baris, total)
+	return tabel
+}
@@ -0,0 +1,91 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/web/laporan"
+	"ekak_kabupaten_madiun/model/web/rencanaaksi"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+	"testing"
+)
+
+func TestKodeProgramDariSubKegiatan(t *testing.T) {
+	tests := []struct {
+		kode     string
+		expected string
+	}{
+		{kode: "5.01.02.2.01.0003", expected: "5.01.02"},
+		{kode: "1.02.01", expected: "1.02.01"},
+		{kode: "X", expected: "X"},
+	}
+
+	for _, tt := range tests {
+		if result := kodeProgramDariSubKegiatan(tt.kode); result != tt.expected {
+			t.Errorf("kodeProgramDariSubKegiatan(%s) = %s, expected %s", tt.kode, result, tt.expected)
+		}
+	}
+}
+
+func TestRingkasLkjip(t *testing.T) {
+	capaian := func(nilai float64) *float64 { return &nilai }
+
+	response := laporan.LkjipResponse{
+		Tujuan: []laporan.TujuanLkjip{
+			{Indikator: []laporan.IndikatorLkjip{{IndikatorId: "T-1", Capaian: capaian(10)}}},
+		},
+		Sasaran: []laporan.SasaranLkjip{
+			{Indikator: []laporan.IndikatorLkjip{{IndikatorId: "S-1", Capaian: capaian(80)}, {IndikatorId: "S-2"}}},
+		},
+		Iku: []laporan.IndikatorLkjip{{IndikatorId: "IKU-1", Capaian: capaian(95.5)}},
+	}
+
+	ringkasan := ringkasLkjip(response)
+	if ringkasan.JumlahIndikator != 3 || ringkasan.JumlahTerukur != 2 {
+		t.Errorf("ringkasLkjip() = (%d, %d), expected (3, 2)", ringkasan.JumlahIndikator, ringkasan.JumlahTerukur)
+	}
+	if ringkasan.RataRataCapaian == nil || *ringkasan.RataRataCapaian != 87.75 {
+		t.Errorf("ringkasLkjip() rata-rata = %v, expected 87.75", ringkasan.RataRataCapaian)
+	}
+
+	if kosong := ringkasLkjip(laporan.LkjipResponse{}); kosong.RataRataCapaian != nil {
+		t.Errorf("ringkasLkjip() tanpa indikator terukur seharusnya nil")
+	}
+
+	if _, err := dokumenLkjip(response).PDF(); err != nil {
+		t.Errorf("dokumenLkjip().PDF() error: %v", err)
+	}
+}
+
+func TestGanttKak(t *testing.T) {
+	tabelRencanaAksi := rencanaaksi.RencanaAksiTableResponse{
+		RencanaAksi: []rencanaaksi.RencanaAksiResponse{{
+			NamaRencanaAksi: "Rapat koordinasi",
+			PelaksanaanRencanaAksi: []rencanaaksi.PelaksanaanRencanaAksiResponse{
+				{Bulan: 1, Bobot: 10}, {Bulan: 2, Bobot: 0}, {Bulan: 12, Bobot: 5},
+			},
+			TotalBobotRencanaAksi: 15,
+		}},
+		TotalPerBulan:    []rencanaaksi.BobotBulanan{{Bulan: 1, TotalBobot: 10}, {Bulan: 12, TotalBobot: 5}},
+		TotalKeseluruhan: 15,
+	}
+
+	tabel := ganttKak(tabelRencanaAksi)
+	if len(tabel.Kolom) != 15 || len(tabel.Baris) != 2 || len(tabel.Arsir) != 1 {
+		t.Fatalf("ganttKak() kolom %d, baris %d, arsir %d", len(tabel.Kolom), len(tabel.Baris), len(tabel.Arsir))
+	}
+	// kolom bulan dimulai dari indeks 2
+	arsir := tabel.Arsir[0]
+	if !arsir[2] || arsir[3] || !arsir[13] || arsir[14] {
+		t.Errorf("ganttKak() arsir = %v", arsir)
+	}
+	if baris := tabel.Baris[0]; baris[2] != "10" || baris[3] != "" || baris[14] != "15" {
+		t.Errorf("ganttKak() baris = %q", baris)
+	}
+	if total := tabel.Baris[1]; total[13] != "5" || total[14] != "15" {
+		t.Errorf("ganttKak() total = %q", total)
+	}
+
+	rincian := rencanakinerja.DataRincianKerja{RencanaAksi: tabelRencanaAksi}
+	rincian.RencanaKinerja.NamaRencanaKinerja = "Terselenggaranya koordinasi"
+	if _, err := dokumenKak(rincian, nil).PDF(); err != nil {
+		t.Errorf("dokumenKak().PDF() error: %v", err)
+	}
+}
@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/lockdata"
+)
+
+// jenis dokumen perencanaan yang bisa dikunci di tb_lock_data
+const (
+	LockJenisTujuanOpd       = "tujuan_opd"
+	LockJenisSasaranOpd      = "sasaran_opd"
+	LockJenisRencanaKinerja  = "rencana_kinerja"
+	LockJenisRenjaRanwal     = "renja_ranwal"
+	LockJenisRenjaRankhir    = "renja_rankhir"
+	LockJenisRenjaPenetapan  = "renja_penetapan"
+	LockJenisRincianBelanja  = "rincian_belanja"
+	LockJenisPohonKinerjaOpd = "pohon_kinerja_opd"
+	LockJenisRenstra         = "renstra"
+	LockJenisRealisasi       = "realisasi"
+)
+
+type LockDataService interface {
+	Lock(ctx context.Context, request lockdata.LockDataRequest) (lockdata.LockDataResponse, error)
+	Unlock(ctx context.Context, request lockdata.LockDataRequest) error
+	FindAll(ctx context.Context, kodeOpd, tahun string) ([]lockdata.LockDataResponse, error)
+	// CheckWritable mengembalikan error 423 jika dokumen sedang dikunci
+	CheckWritable(ctx context.Context, jenisData, kodeOpd, tahun string) error
+}
@@ -0,0 +1,157 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/lockdata"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"log"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+var lockJenisValid = map[string]bool{
+	LockJenisTujuanOpd:       true,
+	LockJenisSasaranOpd:      true,
+	LockJenisRencanaKinerja:  true,
+	LockJenisRenjaRanwal:     true,
+	LockJenisRenjaRankhir:    true,
+	LockJenisRenjaPenetapan:  true,
+	LockJenisRincianBelanja:  true,
+	LockJenisPohonKinerjaOpd: true,
+	LockJenisRenstra:         true,
+	LockJenisRealisasi:       true,
+}
+
+type LockDataServiceImpl struct {
+	LockDataRepository repository.LockDataRepository
+	DB                 *sql.DB
+	Validate           *validator.Validate
+}
+
+func NewLockDataServiceImpl(lockDataRepository repository.LockDataRepository, DB *sql.DB, validate *validator.Validate) *LockDataServiceImpl {
+	return &LockDataServiceImpl{
+		LockDataRepository: lockDataRepository,
+		DB:                 DB,
+		Validate:           validate,
+	}
+}
+
+// lockJenisRenja memetakan jenis indikator renja (ranwal/rankhir/penetapan) ke jenis lock
+func lockJenisRenja(jenis string) string {
+	return "renja_" + jenis
+}
+
+func (service *LockDataServiceImpl) Lock(ctx context.Context, request lockdata.LockDataRequest) (lockdata.LockDataResponse, error) {
+	if err := service.validateRequest(request); err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	err = service.LockDataRepository.Lock(ctx, tx, request.JenisData, request.KodeOpd, request.Tahun)
+	if err != nil {
+		return lockdata.LockDataResponse{}, err
+	}
+
+	if claims, ok := helper.GetUserClaims(ctx); ok {
+		log.Printf("[LockData] %s mengunci %s kode_opd=%s tahun=%s", claims.Nip, request.JenisData, request.KodeOpd, request.Tahun)
+	}
+
+	return lockdata.LockDataResponse{
+		JenisData: request.JenisData,
+		KodeOpd:   request.KodeOpd,
+		Tahun:     request.Tahun,
+		LockedAt:  time.Now(),
+	}, nil
+}
+
+func (service *LockDataServiceImpl) Unlock(ctx context.Context, request lockdata.LockDataRequest) error {
+	if err := service.validateRequest(request); err != nil {
+		return err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	err = service.LockDataRepository.Unlock(ctx, tx, request.JenisData, request.KodeOpd, request.Tahun)
+	if err != nil {
+		return err
+	}
+
+	if claims, ok := helper.GetUserClaims(ctx); ok {
+		log.Printf("[LockData] %s membuka kunci %s kode_opd=%s tahun=%s", claims.Nip, request.JenisData, request.KodeOpd, request.Tahun)
+	}
+
+	return nil
+}
+
+func (service *LockDataServiceImpl) FindAll(ctx context.Context, kodeOpd, tahun string) ([]lockdata.LockDataResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	locks, err := service.LockDataRepository.FindAll(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+
+	responses := make([]lockdata.LockDataResponse, 0, len(locks))
+	for _, lock := range locks {
+		responses = append(responses, lockdata.LockDataResponse{
+			Id:        lock.Id,
+			JenisData: lock.JenisData,
+			KodeOpd:   lock.KodeOpd,
+			Tahun:     lock.Tahun,
+			LockedAt:  lock.LockedAt,
+		})
+	}
+
+	return responses, nil
+}
+
+func (service *LockDataServiceImpl) CheckWritable(ctx context.Context, jenisData, kodeOpd, tahun string) error {
+	// kode_opd atau tahun kosong berarti pemanggil belum me-resolve data, jangan lolos dari kunci
+	if kodeOpd == "" || tahun == "" {
+		return web.NewBadRequestError(fmt.Sprintf("kode_opd dan tahun wajib diketahui untuk memeriksa kunci data %s", jenisData))
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	locked, err := service.LockDataRepository.IsLocked(ctx, tx, jenisData, kodeOpd, tahun)
+	if err != nil {
+		return err
+	}
+	if locked {
+		return web.NewLockedError(fmt.Sprintf("data %s OPD %s tahun %s sudah dikunci, hubungi admin untuk membuka kunci", jenisData, kodeOpd, tahun))
+	}
+
+	return nil
+}
+
+func (service *LockDataServiceImpl) validateRequest(request lockdata.LockDataRequest) error {
+	if err := service.Validate.Struct(request); err != nil {
+		return web.NewBadRequestError(err.Error())
+	}
+	if !lockJenisValid[request.JenisData] {
+		return web.NewBadRequestError(fmt.Sprintf("jenis_data %s tidak dikenal", request.JenisData))
+	}
+	return nil
+}
@@ -17,13 +17,15 @@ import (
 
 type ManualIKServiceImpl struct {
 	ManualIKRepository repository.ManualIKRepository
+	AuditLogRepository repository.AuditLogRepository
 	DB                 *sql.DB
 	Validate           *validator.Validate
 }
 
-func NewManualIKServiceImpl(manualIKRepository repository.ManualIKRepository, db *sql.DB, validate *validator.Validate) *ManualIKServiceImpl {
+func NewManualIKServiceImpl(manualIKRepository repository.ManualIKRepository, db *sql.DB, validate *validator.Validate, auditLogRepository repository.AuditLogRepository) *ManualIKServiceImpl {
 	return &ManualIKServiceImpl{
 		ManualIKRepository: manualIKRepository,
+		AuditLogRepository: auditLogRepository,
 		DB:                 db,
 		Validate:           validate,
 	}
@@ -77,7 +79,10 @@ func (service *ManualIKServiceImpl) Create(ctx context.Context, request rencanak
 		return rencanakinerja.ManualIKResponse{}, err
 	}
 
-	return helper.ToManualIKResponse(manualIK), nil
+	response := helper.ToManualIKResponse(manualIK)
+	service.recordAudit(ctx, tx, AuditActionCreate, indikatorId, nil, response)
+
+	return response, nil
 }
 
 func (service *ManualIKServiceImpl) Update(ctx context.Context, request rencanakinerja.ManualIKUpdateRequest, indikatorId string) (rencanakinerja.ManualIKResponse, error) {
@@ -90,6 +95,11 @@ func (service *ManualIKServiceImpl) Update(ctx context.Context, request rencanak
 	helper.PanicIfError(err)
 	defer helper.CommitOrRollback(tx)
 
+	existing, err := service.ManualIKRepository.FindByIndikatorId(ctx, tx, indikatorId)
+	if err != nil {
+		return rencanakinerja.ManualIKResponse{}, err
+	}
+
 	manualIK := domain.ManualIK{
 		IndikatorId:         indikatorId,
 		Perspektif:          request.Perspektif,
@@ -114,7 +124,30 @@ func (service *ManualIKServiceImpl) Update(ctx context.Context, request rencanak
 		return rencanakinerja.ManualIKResponse{}, err
 	}
 
-	return helper.ToManualIKResponse(manualIK), nil
+	response := helper.ToManualIKResponse(manualIK)
+	var before interface{}
+	if existing.Id != 0 {
+		before = helper.ToManualIKResponse(existing)
+	}
+	service.recordAudit(ctx, tx, AuditActionUpdate, indikatorId, before, response)
+
+	return response, nil
+}
+
+// recordAudit mencatat manual IK per indikator, kode_opd dan tahun diambil dari rencana kinerja pemilik indikator jika ada
+func (service *ManualIKServiceImpl) recordAudit(ctx context.Context, tx *sql.Tx, action string, indikatorId string, before interface{}, after interface{}) {
+	entry := auditEntry{
+		EntityType: AuditEntityManualIK,
+		EntityId:   indikatorId,
+		Action:     action,
+		Before:     before,
+		After:      after,
+	}
+	if _, rencanaKinerja, _, _, err := service.ManualIKRepository.GetRencanaKinerjaWithTarget(ctx, tx, indikatorId); err == nil {
+		entry.KodeOpd = rencanaKinerja.KodeOpd
+		entry.Tahun = rencanaKinerja.Tahun
+	}
+	recordAudit(ctx, tx, service.AuditLogRepository, entry)
 }
 
 // Fungsi untuk mendapatkan data rencana kinerja
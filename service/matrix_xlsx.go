@@ -0,0 +1,496 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/programkegiatan"
+	"fmt"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// judul kolom spreadsheet matrix, kolom per tahun diberi akhiran tahun (mis. "Target 2025")
+const (
+	kolomMatrixKode             = "Kode"
+	kolomMatrixNomenklatur      = "Urusan / Bidang Urusan / Program / Kegiatan / Sub Kegiatan"
+	kolomMatrixJenis            = "Jenis"
+	kolomMatrixIndikator        = "Indikator"
+	kolomMatrixTarget           = "Target"
+	kolomMatrixSatuan           = "Satuan"
+	kolomMatrixPagu             = "Pagu"
+	kolomMatrixPenanggungJwb    = "Penanggung Jawab"
+	kolomMatrixKodeIndikator    = "Kode Indikator"
+	jenisMatrixSubKegiatan      = "subkegiatans"
+	maksRentangTahunRenstra     = 10
+	pesanKolomMatrixTidakAda    = "kolom %s tidak ditemukan, gunakan file hasil export matrix"
+	pesanKodeMatrixTidakDikenal = "kode %s tidak terdapat pada matrix OPD"
+)
+
+var labelJenisMatrix = map[string]string{
+	"urusans":              "Urusan",
+	"bidang_urusans":       "Bidang Urusan",
+	"programs":             "Program",
+	"kegiatans":            "Kegiatan",
+	jenisMatrixSubKegiatan: "Sub Kegiatan",
+}
+
+// nodeMatrix satu node hierarki urusan sampai subkegiatan yang sudah diratakan
+type nodeMatrix struct {
+	Kode        string
+	Nama        string
+	Jenis       string
+	NamaPegawai string
+	Indikator   []programkegiatan.IndikatorMatrixResponse
+	Anggaran    []programkegiatan.PaguAnggaranTotalResponse
+}
+
+func ratakanMatrix(details []programkegiatan.UrusanDetailResponse) []nodeMatrix {
+	var nodes []nodeMatrix
+	for _, detail := range details {
+		for _, urusan := range detail.Urusan {
+			nodes = append(nodes, nodeMatrix{Kode: urusan.Kode, Nama: urusan.Nama, Jenis: urusan.Jenis, Indikator: urusan.Indikator, Anggaran: urusan.Anggaran})
+			for _, bidang := range urusan.BidangUrusan {
+				nodes = append(nodes, nodeMatrix{Kode: bidang.Kode, Nama: bidang.Nama, Jenis: bidang.Jenis, Indikator: bidang.Indikator, Anggaran: bidang.Anggaran})
+				for _, program := range bidang.Program {
+					nodes = append(nodes, nodeMatrix{Kode: program.Kode, Nama: program.Nama, Jenis: program.Jenis, Indikator: program.Indikator, Anggaran: program.Anggaran})
+					for _, kegiatan := range program.Kegiatan {
+						nodes = append(nodes, nodeMatrix{Kode: kegiatan.Kode, Nama: kegiatan.Nama, Jenis: kegiatan.Jenis, Indikator: kegiatan.Indikator, Anggaran: kegiatan.Anggaran})
+						for _, subKegiatan := range kegiatan.SubKegiatan {
+							nodes = append(nodes, nodeMatrix{
+								Kode:        subKegiatan.Kode,
+								Nama:        subKegiatan.Nama,
+								Jenis:       subKegiatan.Jenis,
+								NamaPegawai: subKegiatan.NamaPegawai,
+								Indikator:   subKegiatan.Indikator,
+								Anggaran:    subKegiatan.Anggaran,
+							})
+						}
+					}
+				}
+			}
+		}
+	}
+	return nodes
+}
+
+// kodeIndikatorMatrix matrix renja mengisi kode indikator di field Id
+func kodeIndikatorMatrix(indikator programkegiatan.IndikatorMatrixResponse) string {
+	if indikator.KodeIndikator != "" {
+		return indikator.KodeIndikator
+	}
+	return indikator.Id
+}
+
+func tahunIndikatorMatrix(indikator programkegiatan.IndikatorMatrixResponse, tahunRange []string) string {
+	if len(tahunRange) == 1 {
+		return tahunRange[0]
+	}
+	return indikator.Tahun
+}
+
+// rentangTahunRenstra daftar tahun dari tahun awal sampai tahun akhir periode renstra
+func rentangTahunRenstra(tahunAwal, tahunAkhir string) ([]string, error) {
+	awal, errAwal := strconv.Atoi(tahunAwal)
+	akhir, errAkhir := strconv.Atoi(tahunAkhir)
+	if errAwal != nil || errAkhir != nil || awal > akhir || akhir-awal >= maksRentangTahunRenstra {
+		return nil, web.NewBadRequestError(fmt.Sprintf("tahun_awal dan tahun_akhir harus berupa tahun dengan rentang maksimal %d tahun", maksRentangTahunRenstra))
+	}
+	tahunRange := make([]string, 0, akhir-awal+1)
+	for tahun := awal; tahun <= akhir; tahun++ {
+		tahunRange = append(tahunRange, strconv.Itoa(tahun))
+	}
+	return tahunRange, nil
+}
+
+// barisIndikatorMatrix indikator dengan nama sama pada beberapa tahun ditulis dalam satu baris
+type barisIndikatorMatrix struct {
+	Indikator string
+	PerTahun  map[string]programkegiatan.IndikatorMatrixResponse
+}
+
+func kelompokkanIndikatorMatrix(indikators []programkegiatan.IndikatorMatrixResponse, tahunRange []string) []barisIndikatorMatrix {
+	urut := append([]programkegiatan.IndikatorMatrixResponse(nil), indikators...)
+	sort.SliceStable(urut, func(i, j int) bool {
+		return kodeIndikatorMatrix(urut[i]) < kodeIndikatorMatrix(urut[j])
+	})
+
+	var kelompok []barisIndikatorMatrix
+	for _, indikator := range urut {
+		tahun := tahunIndikatorMatrix(indikator, tahunRange)
+		ditempatkan := false
+		for i := range kelompok {
+			if _, terisi := kelompok[i].PerTahun[tahun]; kelompok[i].Indikator == indikator.Indikator && !terisi {
+				kelompok[i].PerTahun[tahun] = indikator
+				ditempatkan = true
+				break
+			}
+		}
+		if !ditempatkan {
+			kelompok = append(kelompok, barisIndikatorMatrix{
+				Indikator: indikator.Indikator,
+				PerTahun:  map[string]programkegiatan.IndikatorMatrixResponse{tahun: indikator},
+			})
+		}
+	}
+	return kelompok
+}
+
+func kolomMatrix(tahunRange []string) []string {
+	kolom := []string{kolomMatrixKode, kolomMatrixNomenklatur, kolomMatrixJenis, kolomMatrixIndikator}
+	for _, tahun := range tahunRange {
+		kolom = append(kolom, kolomMatrixTarget+" "+tahun, kolomMatrixSatuan+" "+tahun, kolomMatrixPagu+" "+tahun)
+	}
+	kolom = append(kolom, kolomMatrixPenanggungJwb)
+	for _, tahun := range tahunRange {
+		kolom = append(kolom, kolomMatrixKodeIndikator+" "+tahun)
+	}
+	return kolom
+}
+
+// sheetMatrix tabel matrix standar: node diikuti baris indikator tambahan, pagu per tahun dan baris total
+func sheetMatrix(namaSheet string, details []programkegiatan.UrusanDetailResponse, tahunRange []string) helper.SheetXlsx {
+	kolom := kolomMatrix(tahunRange)
+	lebar := []float64{22, 60, 14, 50}
+	for range tahunRange {
+		lebar = append(lebar, 10, 12, 16)
+	}
+	lebar = append(lebar, 28)
+	for range tahunRange {
+		lebar = append(lebar, 24)
+	}
+
+	sheet := helper.SheetXlsx{Nama: namaSheet, Baris: [][]string{kolom}, LebarKolom: lebar}
+	totalPagu := make(map[string]int64, len(tahunRange))
+	jumlahTahun := len(tahunRange)
+	for _, node := range ratakanMatrix(details) {
+		pagu := make(map[string]int64, len(node.Anggaran))
+		for _, anggaran := range node.Anggaran {
+			pagu[anggaran.Tahun] = anggaran.PaguAnggaran
+			if node.Jenis == jenisMatrixSubKegiatan {
+				totalPagu[anggaran.Tahun] += anggaran.PaguAnggaran
+			}
+		}
+
+		kelompok := kelompokkanIndikatorMatrix(node.Indikator, tahunRange)
+		jumlahBaris := len(kelompok)
+		if jumlahBaris == 0 {
+			jumlahBaris = 1
+		}
+		for i := 0; i < jumlahBaris; i++ {
+			baris := make([]string, len(kolom))
+			baris[0] = node.Kode
+			baris[2] = labelJenisMatrix[node.Jenis]
+			if i == 0 {
+				baris[1] = node.Nama
+				baris[4+3*jumlahTahun] = node.NamaPegawai
+			}
+			if i < len(kelompok) {
+				baris[3] = kelompok[i].Indikator
+			}
+			for t, tahun := range tahunRange {
+				if i == 0 {
+					baris[4+3*t+2] = strconv.FormatInt(pagu[tahun], 10)
+				}
+				if i >= len(kelompok) {
+					continue
+				}
+				if indikator, ok := kelompok[i].PerTahun[tahun]; ok {
+					baris[4+3*t] = indikator.Target
+					baris[4+3*t+1] = indikator.Satuan
+					baris[5+3*jumlahTahun+t] = kodeIndikatorMatrix(indikator)
+				}
+			}
+			sheet.Baris = append(sheet.Baris, baris)
+		}
+	}
+
+	total := make([]string, len(kolom))
+	total[1] = "TOTAL"
+	for t, tahun := range tahunRange {
+		total[4+3*t+2] = strconv.FormatInt(totalPagu[tahun], 10)
+	}
+	sheet.Baris = append(sheet.Baris, total)
+	return sheet
+}
+
+// barisImportMatrix satu baris data spreadsheet, Nomor mengikuti nomor baris di spreadsheet
+type barisImportMatrix struct {
+	Nomor     int
+	Kode      string
+	Jenis     string
+	Indikator string
+	PerTahun  map[string]selImportMatrix
+}
+
+type selImportMatrix struct {
+	KodeIndikator string
+	Target        string
+	Satuan        string
+	Pagu          string
+}
+
+func (sel selImportMatrix) kosong() bool {
+	return sel.KodeIndikator == "" && sel.Target == "" && sel.Satuan == ""
+}
+
+// bacaSheetMatrix kolom dicari berdasarkan judulnya sehingga urutan kolom boleh berubah
+func bacaSheetMatrix(content []byte, tahunRange []string) ([]barisImportMatrix, error) {
+	sheets, err := helper.BacaXlsx(content)
+	if err != nil {
+		return nil, web.NewBadRequestError(err.Error())
+	}
+	if len(sheets) == 0 || len(sheets[0].Baris) == 0 {
+		return nil, web.NewBadRequestError("spreadsheet kosong")
+	}
+
+	rows := sheets[0].Baris
+	indeksKolom := make(map[string]int)
+	for i, judul := range rows[0] {
+		indeksKolom[strings.ToLower(strings.TrimSpace(judul))] = i
+	}
+	posisi := func(judul string, wajib bool) (int, error) {
+		indeks, ok := indeksKolom[strings.ToLower(judul)]
+		if !ok {
+			if wajib {
+				return -1, web.NewBadRequestError(fmt.Sprintf(pesanKolomMatrixTidakAda, judul))
+			}
+			return -1, nil
+		}
+		return indeks, nil
+	}
+	type kolomTahun struct{ kodeIndikator, target, satuan, pagu int }
+
+	kolomKode, err := posisi(kolomMatrixKode, true)
+	if err != nil {
+		return nil, err
+	}
+	kolomIndikator, err := posisi(kolomMatrixIndikator, true)
+	if err != nil {
+		return nil, err
+	}
+	kolomJenis, _ := posisi(kolomMatrixJenis, false)
+	perTahun := make(map[string]kolomTahun, len(tahunRange))
+	for _, tahun := range tahunRange {
+		var kolom kolomTahun
+		if kolom.target, err = posisi(kolomMatrixTarget+" "+tahun, true); err != nil {
+			return nil, err
+		}
+		if kolom.satuan, err = posisi(kolomMatrixSatuan+" "+tahun, true); err != nil {
+			return nil, err
+		}
+		kolom.pagu, _ = posisi(kolomMatrixPagu+" "+tahun, false)
+		kolom.kodeIndikator, _ = posisi(kolomMatrixKodeIndikator+" "+tahun, false)
+		perTahun[tahun] = kolom
+	}
+
+	sel := func(baris []string, indeks int) string {
+		if indeks < 0 || indeks >= len(baris) {
+			return ""
+		}
+		return strings.TrimSpace(baris[indeks])
+	}
+
+	var result []barisImportMatrix
+	for i, row := range rows[1:] {
+		baris := barisImportMatrix{
+			Nomor:     i + 2,
+			Kode:      sel(row, kolomKode),
+			Jenis:     sel(row, kolomJenis),
+			Indikator: sel(row, kolomIndikator),
+			PerTahun:  make(map[string]selImportMatrix, len(tahunRange)),
+		}
+		kosong := baris.Kode == "" && baris.Indikator == ""
+		for tahun, kolom := range perTahun {
+			isi := selImportMatrix{
+				KodeIndikator: sel(row, kolom.kodeIndikator),
+				Target:        sel(row, kolom.target),
+				Satuan:        sel(row, kolom.satuan),
+				Pagu:          sel(row, kolom.pagu),
+			}
+			kosong = kosong && isi.kosong()
+			baris.PerTahun[tahun] = isi
+		}
+		// baris kosong dan baris TOTAL dilewati
+		if kosong {
+			continue
+		}
+		result = append(result, baris)
+	}
+	return result, nil
+}
+
+// parsePaguMatrix menerima angka sel numerik maupun teks berpemisah ribuan
+func parsePaguMatrix(nilai string) (int64, bool) {
+	if pagu, err := strconv.ParseInt(nilai, 10, 64); err == nil {
+		return pagu, pagu >= 0
+	}
+	if pagu, err := strconv.ParseFloat(nilai, 64); err == nil && pagu == math.Trunc(pagu) {
+		return int64(pagu), pagu >= 0
+	}
+	tanpaPemisah := strings.NewReplacer(".", "", ",", "", " ", "").Replace(nilai)
+	pagu, err := strconv.ParseInt(tanpaPemisah, 10, 64)
+	return pagu, err == nil && pagu >= 0
+}
+
+type indikatorImportMatrix struct {
+	Kode          string
+	Tahun         string
+	KodeIndikator string
+	Indikator     string
+	Target        string
+	Satuan        string
+}
+
+type anggaranImportMatrix struct {
+	Kode  string
+	Tahun string
+	Pagu  int64
+}
+
+// aturanImportMatrix TargetWajib untuk renja yang menolak indikator tanpa target, DenganPagu bila pagu subkegiatan ikut disimpan
+type aturanImportMatrix struct {
+	TargetWajib bool
+	DenganPagu  bool
+}
+
+// rencanakanImportMatrix memvalidasi seluruh baris terhadap matrix saat ini dan menyusun data yang akan disimpan
+func rencanakanImportMatrix(
+	rows []barisImportMatrix,
+	nodes []nodeMatrix,
+	tahunRange []string,
+	aturan aturanImportMatrix,
+) ([]indikatorImportMatrix, []anggaranImportMatrix, []programkegiatan.MatrixImportKesalahan) {
+	jenisByKode := make(map[string]string, len(nodes))
+	type scopeIndikator struct{ kode, tahun string }
+	pemilikIndikator := make(map[string]scopeIndikator)
+	for _, node := range nodes {
+		jenisByKode[node.Kode] = node.Jenis
+		for _, indikator := range node.Indikator {
+			pemilikIndikator[kodeIndikatorMatrix(indikator)] = scopeIndikator{node.Kode, tahunIndikatorMatrix(indikator, tahunRange)}
+		}
+	}
+
+	var (
+		indikators []indikatorImportMatrix
+		anggarans  []anggaranImportMatrix
+		kesalahan  []programkegiatan.MatrixImportKesalahan
+	)
+	kodeIndikatorDipakai := make(map[string]int)
+	anggaranTercatat := make(map[scopeIndikator]bool)
+	for _, row := range rows {
+		salah := func(format string, args ...interface{}) {
+			kesalahan = append(kesalahan, programkegiatan.MatrixImportKesalahan{Baris: row.Nomor, Kode: row.Kode, Pesan: fmt.Sprintf(format, args...)})
+		}
+
+		if row.Kode == "" {
+			salah("kode wajib diisi")
+			continue
+		}
+		jenis, ok := jenisByKode[row.Kode]
+		if !ok {
+			salah(pesanKodeMatrixTidakDikenal, row.Kode)
+			continue
+		}
+		if row.Jenis != "" && !strings.EqualFold(row.Jenis, labelJenisMatrix[jenis]) {
+			salah("jenis %s tidak sesuai, kode %s adalah %s", row.Jenis, row.Kode, labelJenisMatrix[jenis])
+		}
+
+		adaTarget := false
+		jumlahKesalahan := len(kesalahan)
+		for _, tahun := range tahunRange {
+			sel := row.PerTahun[tahun]
+
+			if aturan.DenganPagu && jenis == jenisMatrixSubKegiatan && sel.Pagu != "" {
+				pagu, valid := parsePaguMatrix(sel.Pagu)
+				scope := scopeIndikator{row.Kode, tahun}
+				switch {
+				case !valid:
+					salah("pagu tahun %s harus berupa angka bulat tidak negatif", tahun)
+				case !anggaranTercatat[scope]:
+					anggaranTercatat[scope] = true
+					anggarans = append(anggarans, anggaranImportMatrix{Kode: row.Kode, Tahun: tahun, Pagu: pagu})
+				}
+			}
+
+			if sel.kosong() {
+				if row.Indikator != "" && aturan.TargetWajib {
+					salah("target dan satuan tahun %s wajib diisi", tahun)
+				}
+				continue
+			}
+			if row.Indikator == "" {
+				salah("indikator wajib diisi karena target tahun %s terisi", tahun)
+				continue
+			}
+			if sel.Target == "" || sel.Satuan == "" {
+				salah("target dan satuan tahun %s harus diisi bersamaan", tahun)
+				continue
+			}
+			if sel.KodeIndikator != "" {
+				if barisSebelumnya, dipakai := kodeIndikatorDipakai[sel.KodeIndikator]; dipakai {
+					salah("kode indikator %s sudah dipakai pada baris %d", sel.KodeIndikator, barisSebelumnya)
+					continue
+				}
+				kodeIndikatorDipakai[sel.KodeIndikator] = row.Nomor
+				if pemilik, ada := pemilikIndikator[sel.KodeIndikator]; !ada || pemilik != (scopeIndikator{row.Kode, tahun}) {
+					salah("kode indikator %s bukan milik kode %s tahun %s", sel.KodeIndikator, row.Kode, tahun)
+					continue
+				}
+			}
+			adaTarget = true
+			indikators = append(indikators, indikatorImportMatrix{
+				Kode:          row.Kode,
+				Tahun:         tahun,
+				KodeIndikator: sel.KodeIndikator,
+				Indikator:     row.Indikator,
+				Target:        sel.Target,
+				Satuan:        sel.Satuan,
+			})
+		}
+		if row.Indikator != "" && !adaTarget && !aturan.TargetWajib && len(kesalahan) == jumlahKesalahan {
+			salah("indikator %s belum memiliki target pada tahun manapun", row.Indikator)
+		}
+	}
+	return indikators, anggarans, kesalahan
+}
+
+// scopeIndikatorImport mengelompokkan indikator per (kode, tahun) sesuai urutan kemunculan di spreadsheet
+func scopeIndikatorImport(indikators []indikatorImportMatrix) [][]indikatorImportMatrix {
+	type scope struct{ kode, tahun string }
+	indeks := make(map[scope]int)
+	var result [][]indikatorImportMatrix
+	for _, indikator := range indikators {
+		key := scope{indikator.Kode, indikator.Tahun}
+		i, ok := indeks[key]
+		if !ok {
+			i = len(result)
+			indeks[key] = i
+			result = append(result, nil)
+		}
+		result[i] = append(result[i], indikator)
+	}
+	return result
+}
+
+func laporanImportMatrix(
+	jenis, kodeOpd string,
+	rows []barisImportMatrix,
+	indikators []indikatorImportMatrix,
+	anggarans []anggaranImportMatrix,
+	kesalahan []programkegiatan.MatrixImportKesalahan,
+) programkegiatan.MatrixImportResponse {
+	if kesalahan == nil {
+		kesalahan = []programkegiatan.MatrixImportKesalahan{}
+	}
+	return programkegiatan.MatrixImportResponse{
+		Jenis:           jenis,
+		KodeOpd:         kodeOpd,
+		JumlahBaris:     len(rows),
+		JumlahIndikator: len(indikators),
+		JumlahAnggaran:  len(anggarans),
+		Valid:           len(kesalahan) == 0,
+		Kesalahan:       kesalahan,
+	}
+}
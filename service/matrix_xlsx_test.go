@@ -0,0 +1,127 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web/programkegiatan"
+	"strings"
+	"testing"
+)
+
+func contohMatrixRenstra() []programkegiatan.UrusanDetailResponse {
+	anggaran := func(pagu2025, pagu2026 int64) []programkegiatan.PaguAnggaranTotalResponse {
+		return []programkegiatan.PaguAnggaranTotalResponse{{Tahun: "2025", PaguAnggaran: pagu2025}, {Tahun: "2026", PaguAnggaran: pagu2026}}
+	}
+	return []programkegiatan.UrusanDetailResponse{{
+		Urusan: []programkegiatan.UrusanResponse{{
+			Kode: "1", Nama: "Urusan Wajib", Jenis: "urusans", Anggaran: anggaran(100, 200),
+			BidangUrusan: []programkegiatan.BidangUrusanResponse{{
+				Kode: "1.01", Nama: "Pendidikan", Jenis: "bidang_urusans", Anggaran: anggaran(100, 200),
+				Program: []programkegiatan.ProgramResponse{{
+					Kode: "1.01.01", Nama: "Program Penunjang", Jenis: "programs", Anggaran: anggaran(100, 200),
+					Indikator: []programkegiatan.IndikatorMatrixResponse{
+						{KodeIndikator: "RENS-A", Indikator: "Nilai SAKIP", Tahun: "2025", Target: "80", Satuan: "nilai"},
+						{KodeIndikator: "RENS-B", Indikator: "Nilai SAKIP", Tahun: "2026", Target: "82", Satuan: "nilai"},
+					},
+					Kegiatan: []programkegiatan.KegiatanResponse{{
+						Kode: "1.01.01.2.01", Nama: "Perencanaan", Jenis: "kegiatans", Anggaran: anggaran(100, 200),
+						SubKegiatan: []programkegiatan.SubKegiatanResponse{{
+							Kode: "1.01.01.2.01.0001", Nama: "Penyusunan Renstra", Jenis: "subkegiatans",
+							NamaPegawai: "Budi", Anggaran: anggaran(100, 200),
+						}},
+					}},
+				}},
+			}},
+		}},
+	}}
+}
+
+func TestMatrixXlsxRoundTrip(t *testing.T) {
+	tahunRange := []string{"2025", "2026"}
+	matrix := contohMatrixRenstra()
+
+	sheet := sheetMatrix("Matrix Renstra", matrix, tahunRange)
+	// 5 node, indikator program digabung satu baris, ditambah kepala dan total
+	if len(sheet.Baris) != 7 {
+		t.Fatalf("sheetMatrix() jumlah baris = %d, expected 7", len(sheet.Baris))
+	}
+	if total := sheet.Baris[6]; total[1] != "TOTAL" || total[6] != "100" || total[9] != "200" {
+		t.Errorf("sheetMatrix() baris total = %q", total)
+	}
+
+	content, err := helper.TulisXlsx([]helper.SheetXlsx{sheet})
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows, err := bacaSheetMatrix(content, tahunRange)
+	if err != nil {
+		t.Fatalf("bacaSheetMatrix() error: %v", err)
+	}
+	if len(rows) != 5 {
+		t.Fatalf("bacaSheetMatrix() jumlah baris = %d, expected 5", len(rows))
+	}
+
+	indikators, anggarans, kesalahan := rencanakanImportMatrix(rows, ratakanMatrix(matrix), tahunRange, aturanImportMatrix{DenganPagu: true})
+	if len(kesalahan) != 0 {
+		t.Fatalf("rencanakanImportMatrix() kesalahan = %+v", kesalahan)
+	}
+	if len(indikators) != 2 || indikators[0].KodeIndikator != "RENS-A" || indikators[1].Tahun != "2026" {
+		t.Errorf("rencanakanImportMatrix() indikator = %+v", indikators)
+	}
+	// pagu hanya diambil dari subkegiatan
+	if len(anggarans) != 2 || anggarans[0].Kode != "1.01.01.2.01.0001" || anggarans[1].Pagu != 200 {
+		t.Errorf("rencanakanImportMatrix() anggaran = %+v", anggarans)
+	}
+}
+
+func TestRencanakanImportMatrixKesalahan(t *testing.T) {
+	tahunRange := []string{"2025"}
+	nodes := ratakanMatrix(contohMatrixRenstra())
+	baris := func(nomor int, kode, indikator string, sel selImportMatrix) barisImportMatrix {
+		return barisImportMatrix{Nomor: nomor, Kode: kode, Indikator: indikator, PerTahun: map[string]selImportMatrix{"2025": sel}}
+	}
+
+	tests := []struct {
+		name     string
+		row      barisImportMatrix
+		aturan   aturanImportMatrix
+		expected string
+	}{
+		{name: "kode kosong", row: baris(2, "", "Indikator", selImportMatrix{Target: "1", Satuan: "%"}), expected: "kode wajib diisi"},
+		{name: "kode tidak dikenal", row: baris(2, "9.99", "", selImportMatrix{}), expected: "tidak terdapat pada matrix"},
+		{name: "target tanpa satuan", row: baris(2, "1.01.01", "Indikator", selImportMatrix{Target: "1"}), expected: "diisi bersamaan"},
+		{name: "target tanpa indikator", row: baris(2, "1.01.01", "", selImportMatrix{Target: "1", Satuan: "%"}), expected: "indikator wajib diisi"},
+		{name: "kode indikator milik kode lain", row: baris(2, "1.01", "Indikator", selImportMatrix{KodeIndikator: "RENS-A", Target: "1", Satuan: "%"}), expected: "bukan milik"},
+		{name: "pagu bukan angka", row: baris(2, "1.01.01.2.01.0001", "", selImportMatrix{Pagu: "seratus"}), aturan: aturanImportMatrix{DenganPagu: true}, expected: "pagu tahun 2025"},
+		{name: "renja wajib target", row: baris(2, "1.01.01", "Indikator", selImportMatrix{}), aturan: aturanImportMatrix{TargetWajib: true}, expected: "wajib diisi"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, kesalahan := rencanakanImportMatrix([]barisImportMatrix{tt.row}, nodes, tahunRange, tt.aturan)
+			if len(kesalahan) != 1 || !strings.Contains(kesalahan[0].Pesan, tt.expected) || kesalahan[0].Baris != tt.row.Nomor {
+				t.Errorf("rencanakanImportMatrix() kesalahan = %+v, expected memuat %q", kesalahan, tt.expected)
+			}
+		})
+	}
+}
+
+func TestParsePaguMatrix(t *testing.T) {
+	tests := []struct {
+		nilai    string
+		expected int64
+		valid    bool
+	}{
+		{nilai: "1500000", expected: 1500000, valid: true},
+		{nilai: "1500000.0", expected: 1500000, valid: true},
+		{nilai: "1.500.000", expected: 1500000, valid: true},
+		{nilai: "-5", valid: false},
+		{nilai: "abc", valid: false},
+	}
+
+	for _, tt := range tests {
+		result, valid := parsePaguMatrix(tt.nilai)
+		if valid != tt.valid || (valid && result != tt.expected) {
+			t.Errorf("parsePaguMatrix(%q) = %d, %v, expected %d, %v", tt.nilai, result, valid, tt.expected, tt.valid)
+		}
+	}
+}
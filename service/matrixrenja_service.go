@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
 )
 
@@ -12,4 +13,7 @@ type MatrixRenjaService interface {
 	UpsertBatchIndikatorRenjaPenetapan(ctx context.Context, requests []programkegiatan.IndikatorRenjaCreateRequest) ([]programkegiatan.IndikatorUpsertResponse, error)
 	UpsertAnggaran(ctx context.Context, request programkegiatan.AnggaranRenjaRequest) (programkegiatan.AnggaranRenjaResponse, error)
 	GetRenjaPenetapan(ctx context.Context, kodeOpd, tahun, jenisPagu string) ([]programkegiatan.UrusanDetailResponse, error)
+	// jenis: ranwal, rankhir atau penetapan
+	ExportXlsx(ctx context.Context, jenis, kodeOpd, tahun string) ([]helper.SheetXlsx, error)
+	ImportXlsx(ctx context.Context, jenis, kodeOpd, tahun string, content []byte, simpan bool) (programkegiatan.MatrixImportResponse, error)
 }
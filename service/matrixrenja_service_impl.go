@@ -3,7 +3,9 @@ package service
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
 	"ekak_kabupaten_madiun/repository"
 	"fmt"
@@ -16,6 +18,9 @@ type MatrixRenjaServiceImpl struct {
 	MatrixRenjaRepository repository.MatrixRenjaRepository
 	PeriodeRepository     repository.PeriodeRepository
 	PegawaiRepository     repository.PegawaiRepository
+	OpdGuardService       OpdGuardService
+	LockDataService       LockDataService
+	AuditLogRepository    repository.AuditLogRepository
 	DB                    *sql.DB
 }
 
@@ -23,17 +28,44 @@ func NewMatrixRenjaServiceImpl(
 	matrixRenjaRepository repository.MatrixRenjaRepository,
 	periodeRepository repository.PeriodeRepository,
 	pegawaiRepository repository.PegawaiRepository,
+	opdGuardService OpdGuardService,
+	lockDataService LockDataService,
+	auditLogRepository repository.AuditLogRepository,
 	db *sql.DB,
 ) *MatrixRenjaServiceImpl {
 	return &MatrixRenjaServiceImpl{
 		MatrixRenjaRepository: matrixRenjaRepository,
 		PeriodeRepository:     periodeRepository,
 		PegawaiRepository:     pegawaiRepository,
+		OpdGuardService:       opdGuardService,
+		LockDataService:       lockDataService,
+		AuditLogRepository:    auditLogRepository,
 		DB:                    db,
 	}
 }
 
+// recordIndikatorRenjaAudit mencatat hasil upsert batch indikator renja per indikator
+func (service *MatrixRenjaServiceImpl) recordIndikatorRenjaAudit(ctx context.Context, tx *sql.Tx, requests []programkegiatan.IndikatorRenjaCreateRequest, responses []programkegiatan.IndikatorUpsertResponse) {
+	for i, response := range responses {
+		action := AuditActionUpdate
+		if requests[i].KodeIndikator == "" {
+			action = AuditActionCreate
+		}
+		recordAudit(ctx, tx, service.AuditLogRepository, auditEntry{
+			EntityType: AuditEntityIndikatorRenja,
+			EntityId:   response.KodeIndikator,
+			Action:     action,
+			KodeOpd:    response.KodeOpd,
+			Tahun:      response.Tahun,
+			After:      response,
+		})
+	}
+}
+
 func (service *MatrixRenjaServiceImpl) GetRenja(ctx context.Context, kodeOpd, tahun, jenisPagu string) ([]programkegiatan.UrusanDetailResponse, error) {
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return nil, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
@@ -51,6 +83,9 @@ func (service *MatrixRenjaServiceImpl) GetRenja(ctx context.Context, kodeOpd, ta
 	return result, nil
 }
 func (service *MatrixRenjaServiceImpl) GetRenjaRankhir(ctx context.Context, kodeOpd, tahun string) ([]programkegiatan.UrusanDetailResponse, error) {
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return nil, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
@@ -583,89 +618,56 @@ func (service *MatrixRenjaServiceImpl) transformToResponse(data []domain.SubKegi
 // }
 
 func (service *MatrixRenjaServiceImpl) UpsertBatchIndikatorRenja(ctx context.Context, requests []programkegiatan.IndikatorRenjaCreateRequest) ([]programkegiatan.IndikatorUpsertResponse, error) {
+	for _, req := range requests {
+		if err := service.OpdGuardService.CheckWrite(ctx, req.KodeOpd); err != nil {
+			return nil, err
+		}
+		if err := service.LockDataService.CheckWritable(ctx, lockJenisRenja(req.Jenis), req.KodeOpd, req.Tahun); err != nil {
+			return nil, err
+		}
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
-	prefixBase := fmt.Sprintf("RENJA-RANKHIR-%s-%s-%s-", requests[0].Kode, requests[0].KodeOpd, requests[0].Tahun)
-	existingCount, err := service.MatrixRenjaRepository.CountIndikatorMatrixByScope(
-		ctx, tx,
-		requests[0].Kode, requests[0].KodeOpd, requests[0].Tahun, requests[0].Jenis,
-		prefixBase,
-	)
+	responses, err := service.upsertIndikatorRenja(ctx, tx, requests, "RANKHIR")
 	if err != nil {
-		return nil, fmt.Errorf("gagal menghitung urutan indikator: %w", err)
-	}
-	nextUrutan := existingCount + 1
-	var (
-		domainItems []domain.Indikator
-		respItems   []programkegiatan.IndikatorUpsertResponse
-		keepList    []string // ← TAMBAH INI
-	)
-	for _, item := range requests {
-		var kodeIndikator string
-		var targetId string
-		if item.KodeIndikator == "" {
-			kodeIndikator = fmt.Sprintf("%s%03d", prefixBase, nextUrutan)
-			nextUrutan++
-			targetId = fmt.Sprintf("TRG-%s-%05d", strings.ToUpper(requests[0].Jenis), uuid.New().ID()%100000)
-		} else {
-			kodeIndikator = item.KodeIndikator
-			targetId = fmt.Sprintf("TRG-%s-%05d", strings.ToUpper(requests[0].Jenis), uuid.New().ID()%100000)
-		}
-		keepList = append(keepList, kodeIndikator) // ← TAMBAH INI
-		domainItems = append(domainItems, domain.Indikator{
-			KodeIndikator: kodeIndikator,
-			Kode:          requests[0].Kode,
-			KodeOpd:       requests[0].KodeOpd,
-			Indikator:     item.Indikator,
-			Tahun:         requests[0].Tahun,
-			Jenis:         requests[0].Jenis,
-			Target: []domain.Target{{
-				Id:          targetId,
-				IndikatorId: kodeIndikator,
-				Tahun:       requests[0].Tahun,
-				Target:      item.Target,
-				Satuan:      item.Satuan,
-				Jenis:       requests[0].Jenis,
-			}},
-		})
-		respItems = append(respItems, programkegiatan.IndikatorUpsertResponse{
-			KodeIndikator: kodeIndikator,
-			Kode:          requests[0].Kode,
-			KodeOpd:       requests[0].KodeOpd,
-			Indikator:     item.Indikator,
-			Jenis:         requests[0].Jenis,
-			Tahun:         requests[0].Tahun,
-			Target:        item.Target,
-			Satuan:        item.Satuan,
-		})
-	}
-	if err := service.MatrixRenjaRepository.UpsertBatchIndikatorRenja(ctx, tx, domainItems); err != nil {
 		return nil, err
 	}
-	// ── SYNC: hapus indikator di DB yang tidak ada di request ──  ← TAMBAH INI
-	if err := service.MatrixRenjaRepository.DeleteIndicatorsExcept(
-		ctx, tx,
-		requests[0].Kode, requests[0].KodeOpd, requests[0].Tahun, requests[0].Jenis,
-		keepList,
-	); err != nil {
-		return nil, fmt.Errorf("gagal menghapus indikator: %w", err)
-	}
 	if err := tx.Commit(); err != nil {
 		return nil, err
 	}
-	return respItems, nil
+	return responses, nil
 }
 
 func (service *MatrixRenjaServiceImpl) UpsertBatchIndikatorRenjaPenetapan(ctx context.Context, requests []programkegiatan.IndikatorRenjaCreateRequest) ([]programkegiatan.IndikatorUpsertResponse, error) {
+	for _, req := range requests {
+		if err := service.OpdGuardService.CheckWrite(ctx, req.KodeOpd); err != nil {
+			return nil, err
+		}
+		if err := service.LockDataService.CheckWritable(ctx, LockJenisRenjaPenetapan, req.KodeOpd, req.Tahun); err != nil {
+			return nil, err
+		}
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
-	prefixBase := fmt.Sprintf("RENJA-PENETAPAN-%s-%s-%s-", requests[0].Kode, requests[0].KodeOpd, requests[0].Tahun)
+	responses, err := service.upsertIndikatorRenja(ctx, tx, requests, "PENETAPAN")
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// upsertIndikatorRenja satu scope (kode, kode_opd, tahun, jenis) per panggilan, indikator di luar requests dihapus
+func (service *MatrixRenjaServiceImpl) upsertIndikatorRenja(ctx context.Context, tx *sql.Tx, requests []programkegiatan.IndikatorRenjaCreateRequest, prefixJenis string) ([]programkegiatan.IndikatorUpsertResponse, error) {
+	prefixBase := fmt.Sprintf("RENJA-%s-%s-%s-%s-", prefixJenis, requests[0].Kode, requests[0].KodeOpd, requests[0].Tahun)
 	existingCount, err := service.MatrixRenjaRepository.CountIndikatorMatrixByScope(
 		ctx, tx,
 		requests[0].Kode, requests[0].KodeOpd, requests[0].Tahun, requests[0].Jenis,
@@ -730,26 +732,24 @@ func (service *MatrixRenjaServiceImpl) UpsertBatchIndikatorRenjaPenetapan(ctx co
 	); err != nil {
 		return nil, fmt.Errorf("gagal menghapus indikator: %w", err)
 	}
-	if err := tx.Commit(); err != nil {
-		return nil, err
-	}
+	service.recordIndikatorRenjaAudit(ctx, tx, requests, respItems)
 	return respItems, nil
 }
 
 func (service *MatrixRenjaServiceImpl) UpsertAnggaran(ctx context.Context, request programkegiatan.AnggaranRenjaRequest) (programkegiatan.AnggaranRenjaResponse, error) {
+	if err := service.OpdGuardService.CheckWrite(ctx, request.KodeOpd); err != nil {
+		return programkegiatan.AnggaranRenjaResponse{}, err
+	}
+	// pagu anggaran renja disimpan sebagai jenis penetapan
+	if err := service.LockDataService.CheckWritable(ctx, LockJenisRenjaPenetapan, request.KodeOpd, request.Tahun); err != nil {
+		return programkegiatan.AnggaranRenjaResponse{}, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return programkegiatan.AnggaranRenjaResponse{}, err
 	}
 	defer tx.Rollback()
-	err = service.MatrixRenjaRepository.UpsertAnggaran(
-		ctx, tx,
-		request.KodeSubKegiatan,
-		request.KodeOpd,
-		request.Tahun,
-		request.Pagu,
-	)
-	if err != nil {
+	if err = service.upsertAnggaran(ctx, tx, request); err != nil {
 		return programkegiatan.AnggaranRenjaResponse{}, err
 	}
 
@@ -764,7 +764,33 @@ func (service *MatrixRenjaServiceImpl) UpsertAnggaran(ctx context.Context, reque
 	}, nil
 }
 
+func (service *MatrixRenjaServiceImpl) upsertAnggaran(ctx context.Context, tx *sql.Tx, request programkegiatan.AnggaranRenjaRequest) error {
+	err := service.MatrixRenjaRepository.UpsertAnggaran(
+		ctx, tx,
+		request.KodeSubKegiatan,
+		request.KodeOpd,
+		request.Tahun,
+		request.Pagu,
+	)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(ctx, tx, service.AuditLogRepository, auditEntry{
+		EntityType: AuditEntityAnggaranRenja,
+		EntityId:   request.KodeSubKegiatan,
+		Action:     AuditActionUpdate,
+		KodeOpd:    request.KodeOpd,
+		Tahun:      request.Tahun,
+		After:      request,
+	})
+	return nil
+}
+
 func (service *MatrixRenjaServiceImpl) GetRenjaPenetapan(ctx context.Context, kodeOpd, tahun, jenisPagu string) ([]programkegiatan.UrusanDetailResponse, error) {
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return nil, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
@@ -780,3 +806,97 @@ func (service *MatrixRenjaServiceImpl) GetRenjaPenetapan(ctx context.Context, ko
 	}
 	return result, nil
 }
+
+// matrixRenjaByJenis jenis pagu sama dengan yang dipakai controller GET matrix renja
+func (service *MatrixRenjaServiceImpl) matrixRenjaByJenis(ctx context.Context, jenis, kodeOpd, tahun string) ([]programkegiatan.UrusanDetailResponse, error) {
+	switch jenis {
+	case "ranwal":
+		return service.GetRenja(ctx, kodeOpd, tahun, "renstra")
+	case "rankhir":
+		return service.GetRenjaRankhir(ctx, kodeOpd, tahun)
+	case "penetapan":
+		return service.GetRenjaPenetapan(ctx, kodeOpd, tahun, "penetapan")
+	}
+	return nil, web.NewBadRequestError(fmt.Sprintf("jenis renja %s tidak dikenal, gunakan ranwal, rankhir atau penetapan", jenis))
+}
+
+func (service *MatrixRenjaServiceImpl) ExportXlsx(ctx context.Context, jenis, kodeOpd, tahun string) ([]helper.SheetXlsx, error) {
+	matrix, err := service.matrixRenjaByJenis(ctx, jenis, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+	return []helper.SheetXlsx{sheetMatrix("Matrix Renja "+strings.ToUpper(jenis[:1])+jenis[1:], matrix, []string{tahun})}, nil
+}
+
+// ImportXlsx pagu subkegiatan hanya disimpan untuk renja penetapan, ranwal dan rankhir mengikuti renstra dan rincian belanja
+func (service *MatrixRenjaServiceImpl) ImportXlsx(ctx context.Context, jenis, kodeOpd, tahun string, content []byte, simpan bool) (programkegiatan.MatrixImportResponse, error) {
+	matrix, err := service.matrixRenjaByJenis(ctx, jenis, kodeOpd, tahun)
+	if err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	tahunRange := []string{tahun}
+	rows, err := bacaSheetMatrix(content, tahunRange)
+	if err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+
+	aturan := aturanImportMatrix{TargetWajib: true, DenganPagu: jenis == "penetapan"}
+	indikators, anggarans, kesalahan := rencanakanImportMatrix(rows, ratakanMatrix(matrix), tahunRange, aturan)
+	laporan := laporanImportMatrix(jenis, kodeOpd, rows, indikators, anggarans, kesalahan)
+	if !laporan.Valid || !simpan {
+		return laporan, nil
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, kodeOpd); err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	if err := service.LockDataService.CheckWritable(ctx, lockJenisRenja(jenis), kodeOpd, tahun); err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+
+	prefixJenis := "RANKHIR"
+	if jenis == "penetapan" {
+		prefixJenis = "PENETAPAN"
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	defer tx.Rollback()
+
+	for _, scope := range scopeIndikatorImport(indikators) {
+		requests := make([]programkegiatan.IndikatorRenjaCreateRequest, 0, len(scope))
+		for _, indikator := range scope {
+			requests = append(requests, programkegiatan.IndikatorRenjaCreateRequest{
+				KodeIndikator: indikator.KodeIndikator,
+				Kode:          indikator.Kode,
+				KodeOpd:       kodeOpd,
+				Indikator:     indikator.Indikator,
+				Tahun:         indikator.Tahun,
+				Jenis:         jenis,
+				Target:        indikator.Target,
+				Satuan:        indikator.Satuan,
+			})
+		}
+		if _, err := service.upsertIndikatorRenja(ctx, tx, requests, prefixJenis); err != nil {
+			return programkegiatan.MatrixImportResponse{}, err
+		}
+	}
+	for _, anggaran := range anggarans {
+		err := service.upsertAnggaran(ctx, tx, programkegiatan.AnggaranRenjaRequest{
+			KodeSubKegiatan: anggaran.Kode,
+			KodeOpd:         kodeOpd,
+			Tahun:           anggaran.Tahun,
+			Pagu:            anggaran.Pagu,
+		})
+		if err != nil {
+			return programkegiatan.MatrixImportResponse{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	laporan.Disimpan = true
+	return laporan, nil
+}
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
 )
 
@@ -11,4 +12,7 @@ type MatrixRenstraService interface {
 	DeleteIndikator(ctx context.Context, kodeIndikator string) error
 	FindIndikatorByKodeIndikator(ctx context.Context, kodeIndikator string) (programkegiatan.IndikatorResponse, error)
 	UpsertAnggaran(ctx context.Context, request programkegiatan.AnggaranRenstraRequest) (programkegiatan.AnggaranRenstraResponse, error)
+	ExportXlsx(ctx context.Context, kodeOpd, tahunAwal, tahunAkhir string) ([]helper.SheetXlsx, error)
+	// ImportXlsx selalu memvalidasi seluruh baris, data hanya disimpan bila simpan true dan tidak ada kesalahan
+	ImportXlsx(ctx context.Context, kodeOpd, tahunAwal, tahunAkhir string, content []byte, simpan bool) (programkegiatan.MatrixImportResponse, error)
 }
@@ -3,6 +3,7 @@ package service
 import (
 	"context"
 	"database/sql"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
 	"ekak_kabupaten_madiun/model/web/programkegiatan"
 	"ekak_kabupaten_madiun/repository"
@@ -16,6 +17,9 @@ type MatrixRenstraServiceImpl struct {
 	MatrixRenstraRepository repository.MatrixRenstraRepository
 	PeriodeRepository       repository.PeriodeRepository
 	PegawaiRepository       repository.PegawaiRepository
+	OpdGuardService         OpdGuardService
+	LockDataService         LockDataService
+	AuditLogRepository      repository.AuditLogRepository
 	DB                      *sql.DB
 }
 
@@ -23,17 +27,43 @@ func NewMatrixRenstraServiceImpl(
 	matrixRenstraRepository repository.MatrixRenstraRepository,
 	periodeRepository repository.PeriodeRepository,
 	pegawaiRepository repository.PegawaiRepository,
+	opdGuardService OpdGuardService,
+	lockDataService LockDataService,
+	auditLogRepository repository.AuditLogRepository,
 	db *sql.DB,
 ) *MatrixRenstraServiceImpl {
 	return &MatrixRenstraServiceImpl{
 		MatrixRenstraRepository: matrixRenstraRepository,
 		PeriodeRepository:       periodeRepository,
 		PegawaiRepository:       pegawaiRepository,
+		OpdGuardService:         opdGuardService,
+		LockDataService:         lockDataService,
+		AuditLogRepository:      auditLogRepository,
 		DB:                      db,
 	}
 }
 
+// indikatorRenstraSnapshot bentuk indikator renstra yang disimpan di audit log
+func indikatorRenstraSnapshot(ind domain.Indikator) programkegiatan.IndikatorUpsertResponse {
+	snapshot := programkegiatan.IndikatorUpsertResponse{
+		KodeIndikator: ind.KodeIndikator,
+		Kode:          ind.Kode,
+		KodeOpd:       ind.KodeOpd,
+		Indikator:     ind.Indikator,
+		Tahun:         ind.Tahun,
+		Jenis:         "renstra",
+	}
+	if len(ind.Target) > 0 {
+		snapshot.Target = ind.Target[0].Target
+		snapshot.Satuan = ind.Target[0].Satuan
+	}
+	return snapshot
+}
+
 func (service *MatrixRenstraServiceImpl) GetByKodeSubKegiatan(ctx context.Context, kodeOpd string, tahunAwal string, tahunAkhir string) ([]programkegiatan.UrusanDetailResponse, error) {
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, ""); err != nil {
+		return nil, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
@@ -329,7 +359,7 @@ func (service *MatrixRenstraServiceImpl) DeleteIndikator(ctx context.Context, ko
 		return err
 	}
 	defer tx.Rollback()
-	_, err = service.MatrixRenstraRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
+	existing, err := service.MatrixRenstraRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("indikator %s tidak ditemukan", kodeIndikator)
@@ -342,6 +372,14 @@ func (service *MatrixRenstraServiceImpl) DeleteIndikator(ctx context.Context, ko
 	if err = service.MatrixRenstraRepository.DeleteIndikator(ctx, tx, kodeIndikator); err != nil {
 		return err
 	}
+	recordAudit(ctx, tx, service.AuditLogRepository, auditEntry{
+		EntityType: AuditEntityIndikatorRenstra,
+		EntityId:   kodeIndikator,
+		Action:     AuditActionDelete,
+		KodeOpd:    existing.KodeOpd,
+		Tahun:      existing.Tahun,
+		Before:     indikatorRenstraSnapshot(existing),
+	})
 	return tx.Commit()
 }
 
@@ -378,19 +416,15 @@ func (service *MatrixRenstraServiceImpl) FindIndikatorByKodeIndikator(ctx contex
 }
 
 func (service *MatrixRenstraServiceImpl) UpsertAnggaran(ctx context.Context, request programkegiatan.AnggaranRenstraRequest) (programkegiatan.AnggaranRenstraResponse, error) {
+	if err := service.OpdGuardService.CheckWrite(ctx, request.KodeOpd); err != nil {
+		return programkegiatan.AnggaranRenstraResponse{}, err
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return programkegiatan.AnggaranRenstraResponse{}, err
 	}
 	defer tx.Rollback()
-	err = service.MatrixRenstraRepository.UpsertAnggaran(
-		ctx, tx,
-		request.KodeSubKegiatan,
-		request.KodeOpd,
-		request.Tahun,
-		request.Pagu,
-	)
-	if err != nil {
+	if err = service.upsertAnggaran(ctx, tx, request); err != nil {
 		return programkegiatan.AnggaranRenstraResponse{}, err
 	}
 	// ← TAMBAHKAN INI sebelum return
@@ -405,12 +439,52 @@ func (service *MatrixRenstraServiceImpl) UpsertAnggaran(ctx context.Context, req
 	}, nil
 }
 
+// upsertAnggaran dipakai bersama oleh UpsertAnggaran dan import xlsx dalam satu transaksi
+func (service *MatrixRenstraServiceImpl) upsertAnggaran(ctx context.Context, tx *sql.Tx, request programkegiatan.AnggaranRenstraRequest) error {
+	err := service.MatrixRenstraRepository.UpsertAnggaran(
+		ctx, tx,
+		request.KodeSubKegiatan,
+		request.KodeOpd,
+		request.Tahun,
+		request.Pagu,
+	)
+	if err != nil {
+		return err
+	}
+	recordAudit(ctx, tx, service.AuditLogRepository, auditEntry{
+		EntityType: AuditEntityAnggaranRenstra,
+		EntityId:   request.KodeSubKegiatan,
+		Action:     AuditActionUpdate,
+		KodeOpd:    request.KodeOpd,
+		Tahun:      request.Tahun,
+		After:      request,
+	})
+	return nil
+}
+
 func (service *MatrixRenstraServiceImpl) UpsertBatchIndikator(ctx context.Context, requests []programkegiatan.IndikatorRenstraCreateRequest) ([]programkegiatan.IndikatorUpsertResponse, error) {
+	for _, req := range requests {
+		if err := service.OpdGuardService.CheckWrite(ctx, req.KodeOpd); err != nil {
+			return nil, err
+		}
+	}
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
 	}
 	defer tx.Rollback()
+	responses, err := service.upsertBatchIndikator(ctx, tx, requests)
+	if err != nil {
+		return nil, err
+	}
+	if err = tx.Commit(); err != nil {
+		return nil, err
+	}
+	return responses, nil
+}
+
+// upsertBatchIndikator indikator yang tidak ada di requests dihapus per scope (kode, kode_opd, tahun)
+func (service *MatrixRenstraServiceImpl) upsertBatchIndikator(ctx context.Context, tx *sql.Tx, requests []programkegiatan.IndikatorRenstraCreateRequest) ([]programkegiatan.IndikatorUpsertResponse, error) {
 	var responses []programkegiatan.IndikatorUpsertResponse
 	prefixCounter := make(map[string]int)
 	// Kumpulkan kode_indikator yang diproses per scope (kode+kodeOpd+tahun)
@@ -421,6 +495,7 @@ func (service *MatrixRenstraServiceImpl) UpsertBatchIndikator(ctx context.Contex
 		scope := scopeKey{req.Kode, req.KodeOpd, req.Tahun}
 		kodeIndikator := req.KodeIndikator
 		existingTargetId := ""
+		var before interface{}
 		if kodeIndikator == "" {
 			// CREATE: urutan + bilangan acak agar kode_indikator jarang bentrok (ON DUPLICATE KEY)
 			prefix := fmt.Sprintf("RENS-%s-%s", req.KodeOpd, req.Tahun)
@@ -446,6 +521,9 @@ func (service *MatrixRenstraServiceImpl) UpsertBatchIndikator(ctx context.Contex
 			if len(existing.Target) > 0 && existing.Target[0].Id != "" {
 				existingTargetId = existing.Target[0].Id
 			}
+			if err == nil {
+				before = indikatorRenstraSnapshot(existing)
+			}
 		}
 		// Catat kode_indikator ini sebagai "keep" untuk scope-nya
 		processedPerScope[scope] = append(processedPerScope[scope], kodeIndikator)
@@ -476,7 +554,7 @@ func (service *MatrixRenstraServiceImpl) UpsertBatchIndikator(ctx context.Contex
 		if err := service.MatrixRenstraRepository.UpsertTarget(ctx, tx, target); err != nil {
 			return nil, err
 		}
-		responses = append(responses, programkegiatan.IndikatorUpsertResponse{
+		response := programkegiatan.IndikatorUpsertResponse{
 			KodeIndikator: kodeIndikator,
 			Kode:          req.Kode,
 			KodeOpd:       req.KodeOpd,
@@ -485,7 +563,21 @@ func (service *MatrixRenstraServiceImpl) UpsertBatchIndikator(ctx context.Contex
 			Jenis:         "renstra",
 			Target:        req.Target,
 			Satuan:        req.Satuan,
+		}
+		action := AuditActionUpdate
+		if before == nil {
+			action = AuditActionCreate
+		}
+		recordAudit(ctx, tx, service.AuditLogRepository, auditEntry{
+			EntityType: AuditEntityIndikatorRenstra,
+			EntityId:   kodeIndikator,
+			Action:     action,
+			KodeOpd:    req.KodeOpd,
+			Tahun:      req.Tahun,
+			Before:     before,
+			After:      response,
 		})
+		responses = append(responses, response)
 	}
 	// ── SYNC: hapus indikator di DB yang tidak ada di request ──
 	for scope, keepList := range processedPerScope {
@@ -498,9 +590,6 @@ func (service *MatrixRenstraServiceImpl) UpsertBatchIndikator(ctx context.Contex
 			return nil, err
 		}
 	}
-	if err = tx.Commit(); err != nil {
-		return nil, err
-	}
 	return responses, nil
 }
 
@@ -511,3 +600,86 @@ func randomUint31() (uint32, error) {
 	}
 	return binary.BigEndian.Uint32(b[:]) & 0x7fffffff, nil
 }
+
+func (service *MatrixRenstraServiceImpl) ExportXlsx(ctx context.Context, kodeOpd, tahunAwal, tahunAkhir string) ([]helper.SheetXlsx, error) {
+	tahunRange, err := rentangTahunRenstra(tahunAwal, tahunAkhir)
+	if err != nil {
+		return nil, err
+	}
+	matrix, err := service.GetByKodeSubKegiatan(ctx, kodeOpd, tahunAwal, tahunAkhir)
+	if err != nil {
+		return nil, err
+	}
+	return []helper.SheetXlsx{sheetMatrix("Matrix Renstra", matrix, tahunRange)}, nil
+}
+
+func (service *MatrixRenstraServiceImpl) ImportXlsx(ctx context.Context, kodeOpd, tahunAwal, tahunAkhir string, content []byte, simpan bool) (programkegiatan.MatrixImportResponse, error) {
+	tahunRange, err := rentangTahunRenstra(tahunAwal, tahunAkhir)
+	if err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	rows, err := bacaSheetMatrix(content, tahunRange)
+	if err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	matrix, err := service.GetByKodeSubKegiatan(ctx, kodeOpd, tahunAwal, tahunAkhir)
+	if err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+
+	indikators, anggarans, kesalahan := rencanakanImportMatrix(rows, ratakanMatrix(matrix), tahunRange, aturanImportMatrix{DenganPagu: true})
+	laporan := laporanImportMatrix("renstra", kodeOpd, rows, indikators, anggarans, kesalahan)
+	if !laporan.Valid || !simpan {
+		return laporan, nil
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, kodeOpd); err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	// import menimpa target seluruh periode, satu tahun terkunci membatalkan import
+	for _, tahun := range tahunRange {
+		if err := service.LockDataService.CheckWritable(ctx, LockJenisRenstra, kodeOpd, tahun); err != nil {
+			return programkegiatan.MatrixImportResponse{}, err
+		}
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	defer tx.Rollback()
+
+	if len(indikators) > 0 {
+		requests := make([]programkegiatan.IndikatorRenstraCreateRequest, 0, len(indikators))
+		for _, indikator := range indikators {
+			requests = append(requests, programkegiatan.IndikatorRenstraCreateRequest{
+				KodeIndikator: indikator.KodeIndikator,
+				Kode:          indikator.Kode,
+				KodeOpd:       kodeOpd,
+				Indikator:     indikator.Indikator,
+				Tahun:         indikator.Tahun,
+				Target:        indikator.Target,
+				Satuan:        indikator.Satuan,
+			})
+		}
+		if _, err := service.upsertBatchIndikator(ctx, tx, requests); err != nil {
+			return programkegiatan.MatrixImportResponse{}, err
+		}
+	}
+	for _, anggaran := range anggarans {
+		err := service.upsertAnggaran(ctx, tx, programkegiatan.AnggaranRenstraRequest{
+			KodeSubKegiatan: anggaran.Kode,
+			KodeOpd:         kodeOpd,
+			Tahun:           anggaran.Tahun,
+			Pagu:            anggaran.Pagu,
+		})
+		if err != nil {
+			return programkegiatan.MatrixImportResponse{}, err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return programkegiatan.MatrixImportResponse{}, err
+	}
+	laporan.Disimpan = true
+	return laporan, nil
+}
@@ -0,0 +1,277 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/nomenklatur"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// urutan tingkat dari induk ke anak, dipakai untuk urutan laporan dan penerapan
+var daftarTingkatNomenklatur = []string{
+	domainmaster.TingkatNomenklaturUrusan,
+	domainmaster.TingkatNomenklaturBidangUrusan,
+	domainmaster.TingkatNomenklaturProgram,
+	domainmaster.TingkatNomenklaturKegiatan,
+	domainmaster.TingkatNomenklaturSubKegiatan,
+}
+
+// tingkatSegmenNomenklatur kode kegiatan Kepmendagri 4 segmen (x.xx.xx.x.xx) dihitung 5 karena segmen ke-4 satu digit
+var tingkatSegmenNomenklatur = map[int]string{
+	1: domainmaster.TingkatNomenklaturUrusan,
+	2: domainmaster.TingkatNomenklaturBidangUrusan,
+	3: domainmaster.TingkatNomenklaturProgram,
+	5: domainmaster.TingkatNomenklaturKegiatan,
+	6: domainmaster.TingkatNomenklaturSubKegiatan,
+}
+
+// judul kolom file referensi Kepmendagri, sudah dinormalisasi
+var kolomImportNomenklatur = map[string][]string{
+	"kode": {"kode", "kode rekening", "kode nomenklatur", "kode urusan program kegiatan", "no kode"},
+	"nama": {"nomenklatur", "nama", "uraian", "nama nomenklatur", "nomenklatur urusan kabupaten kota", "nomenklatur urusan provinsi kabupaten kota"},
+}
+
+// segmen kode berupa angka, atau X untuk urusan/bidang gabungan
+var polaSegmenNomenklatur = regexp.MustCompile(`^(\d+|[Xx]+)$`)
+
+type barisImportNomenklatur struct {
+	Nomor int
+	Kode  string
+	Nama  string
+}
+
+// hasilImportNomenklatur operasi yang akan diterapkan beserta laporannya
+type hasilImportNomenklatur struct {
+	Laporan   nomenklatur.NomenklaturImportResponse
+	Perubahan []domainmaster.NomenklaturPerubahan
+}
+
+// normalisasiKodeNomenklatur menghapus spasi dan titik di akhir, X ditulis kapital
+func normalisasiKodeNomenklatur(kode string) string {
+	kode = strings.Join(strings.Fields(kode), "")
+	return strings.ToUpper(strings.TrimRight(kode, "."))
+}
+
+// tingkatKodeNomenklatur string kosong berarti format kode tidak dikenali
+func tingkatKodeNomenklatur(kode string) string {
+	if kode == "" {
+		return ""
+	}
+	segmen := strings.Split(kode, ".")
+	for _, s := range segmen {
+		if !polaSegmenNomenklatur.MatchString(s) {
+			return ""
+		}
+	}
+	return tingkatSegmenNomenklatur[len(segmen)]
+}
+
+// indukKodeNomenklatur kode tingkat di atasnya, kosong untuk urusan
+func indukKodeNomenklatur(kode string) string {
+	segmen := strings.Split(kode, ".")
+	switch len(segmen) {
+	case 2, 3, 6:
+		return strings.Join(segmen[:len(segmen)-1], ".")
+	case 5:
+		return strings.Join(segmen[:3], ".")
+	}
+	return ""
+}
+
+// samaNamaNomenklatur perbedaan spasi dan huruf besar kecil tidak dihitung perubahan nama
+func samaNamaNomenklatur(a, b string) bool {
+	return strings.EqualFold(strings.Join(strings.Fields(a), " "), strings.Join(strings.Fields(b), " "))
+}
+
+func bacaBarisImportNomenklatur(rows [][]string) ([]barisImportNomenklatur, error) {
+	barisJudul, indeksKolom := -1, map[string]int{}
+	for i := 0; i < len(rows) && i < maksBarisJudulImportUsulan; i++ {
+		indeks := make(map[string]int)
+		for j, judul := range rows[i] {
+			judul = normalisasiTeksImport(judul)
+			for kolom, alias := range kolomImportNomenklatur {
+				if _, ada := indeks[kolom]; ada {
+					continue
+				}
+				for _, a := range alias {
+					if judul == a {
+						indeks[kolom] = j
+					}
+				}
+			}
+		}
+		_, adaKode := indeks["kode"]
+		_, adaNama := indeks["nama"]
+		if adaKode && adaNama {
+			barisJudul, indeksKolom = i, indeks
+			break
+		}
+	}
+	if barisJudul < 0 {
+		return nil, web.NewBadRequestError("kolom kode dan nomenklatur tidak ditemukan pada file")
+	}
+
+	sel := func(baris []string, kolom string) string {
+		indeks := indeksKolom[kolom]
+		if indeks >= len(baris) {
+			return ""
+		}
+		return strings.TrimSpace(baris[indeks])
+	}
+
+	var result []barisImportNomenklatur
+	for i := barisJudul + 1; i < len(rows); i++ {
+		baris := barisImportNomenklatur{
+			Nomor: i + 1,
+			Kode:  normalisasiKodeNomenklatur(sel(rows[i], "kode")),
+			Nama:  strings.Join(strings.Fields(sel(rows[i], "nama")), " "),
+		}
+		if baris.Kode == "" && baris.Nama == "" {
+			continue
+		}
+		result = append(result, baris)
+	}
+	return result, nil
+}
+
+// kodeMasihTerpakai kode dipakai langsung atau menjadi induk kode yang dipakai
+func kodeMasihTerpakai(kode string, terpakai []string) bool {
+	for _, t := range terpakai {
+		if t == kode || strings.HasPrefix(t, kode+".") {
+			return true
+		}
+	}
+	return false
+}
+
+// periksaImportNomenklatur diff file terhadap master. Kode yang hilang dari file dihapus,
+// kecuali masih dirujuk data transaksi (lihat FindKodeTerpakai) sehingga hanya ditandai deprecated
+func periksaImportNomenklatur(rows []barisImportNomenklatur, master map[string][]domainmaster.Nomenklatur, terpakai []string) hasilImportNomenklatur {
+	laporan := nomenklatur.NomenklaturImportResponse{
+		JumlahBaris:  len(rows),
+		PerTingkat:   make(map[string]nomenklatur.NomenklaturRingkasan),
+		Perubahan:    []nomenklatur.NomenklaturPerubahanResponse{},
+		BarisDitolak: []nomenklatur.NomenklaturBarisDitolak{},
+		Peringatan:   []string{},
+	}
+	tolak := func(baris barisImportNomenklatur, alasan string) {
+		laporan.BarisDitolak = append(laporan.BarisDitolak, nomenklatur.NomenklaturBarisDitolak{Baris: baris.Nomor, Kode: baris.Kode, Alasan: alasan})
+	}
+
+	file := make(map[string]map[string]string)
+	for _, tingkat := range daftarTingkatNomenklatur {
+		file[tingkat] = make(map[string]string)
+	}
+	semuaKode := make(map[string]bool)
+	for _, baris := range rows {
+		tingkat := tingkatKodeNomenklatur(baris.Kode)
+		switch {
+		case baris.Kode == "":
+			tolak(baris, "kode kosong")
+			continue
+		case tingkat == "":
+			tolak(baris, "format kode tidak dikenali")
+			continue
+		case baris.Nama == "":
+			tolak(baris, "nomenklatur kosong")
+			continue
+		}
+		if nama, ada := file[tingkat][baris.Kode]; ada {
+			if !samaNamaNomenklatur(nama, baris.Nama) {
+				tolak(baris, "kode ganda dengan nomenklatur berbeda")
+			}
+			continue
+		}
+		file[tingkat][baris.Kode] = baris.Nama
+		semuaKode[baris.Kode] = true
+	}
+
+	var hasil hasilImportNomenklatur
+	catat := func(tingkat, kode, aksi, namaLama, namaBaru string) {
+		hasil.Perubahan = append(hasil.Perubahan, domainmaster.NomenklaturPerubahan{
+			Tingkat: tingkat, Kode: kode, Aksi: aksi, NamaLama: namaLama, NamaBaru: namaBaru,
+		})
+	}
+
+	for _, tingkat := range daftarTingkatNomenklatur {
+		ringkasan := nomenklatur.NomenklaturRingkasan{JumlahFile: len(file[tingkat]), JumlahMaster: len(master[tingkat])}
+
+		kodeFile := make([]string, 0, len(file[tingkat]))
+		for kode := range file[tingkat] {
+			kodeFile = append(kodeFile, kode)
+		}
+		sort.Strings(kodeFile)
+		for _, kode := range kodeFile {
+			if induk := indukKodeNomenklatur(kode); induk != "" && !semuaKode[induk] {
+				laporan.Peringatan = append(laporan.Peringatan, fmt.Sprintf("induk %s dari kode %s tidak ada di file", induk, kode))
+			}
+		}
+
+		petaMaster := make(map[string]domainmaster.Nomenklatur, len(master[tingkat]))
+		for _, item := range master[tingkat] {
+			petaMaster[item.Kode] = item
+		}
+		for _, kode := range kodeFile {
+			nama := file[tingkat][kode]
+			lama, ada := petaMaster[kode]
+			switch {
+			case !ada:
+				catat(tingkat, kode, domainmaster.AksiNomenklaturTambah, "", nama)
+				ringkasan.Tambah++
+			case !samaNamaNomenklatur(lama.Nama, nama):
+				// update nama sekaligus mengaktifkan kembali kode deprecated
+				catat(tingkat, kode, domainmaster.AksiNomenklaturUbahNama, lama.Nama, nama)
+				ringkasan.UbahNama++
+			case lama.IsDeprecated:
+				catat(tingkat, kode, domainmaster.AksiNomenklaturAktifKembali, lama.Nama, nama)
+				ringkasan.AktifKembali++
+			}
+		}
+
+		for _, item := range master[tingkat] {
+			if _, ada := file[tingkat][item.Kode]; ada {
+				continue
+			}
+			switch {
+			case kodeMasihTerpakai(item.Kode, terpakai):
+				if !item.IsDeprecated {
+					catat(tingkat, item.Kode, domainmaster.AksiNomenklaturDeprecated, item.Nama, "")
+					ringkasan.Deprecated++
+				}
+			default:
+				catat(tingkat, item.Kode, domainmaster.AksiNomenklaturHapus, item.Nama, "")
+				ringkasan.Hapus++
+			}
+		}
+		laporan.PerTingkat[tingkat] = ringkasan
+	}
+
+	for _, item := range hasil.Perubahan {
+		laporan.Perubahan = append(laporan.Perubahan, nomenklatur.NomenklaturPerubahanResponse{
+			Tingkat: item.Tingkat, Kode: item.Kode, Aksi: item.Aksi, NamaLama: item.NamaLama, NamaBaru: item.NamaBaru,
+		})
+	}
+	hasil.Laporan = laporan
+	return hasil
+}
+
+// periksaPenerapanNomenklatur file sebagian atau berisi baris ditolak akan menghapus kode yang
+// sebenarnya masih berlaku, sehingga hanya file lengkap yang boleh diterapkan
+func periksaPenerapanNomenklatur(laporan nomenklatur.NomenklaturImportResponse) error {
+	if len(laporan.BarisDitolak) > 0 {
+		return web.NewBadRequestError(fmt.Sprintf("%d baris ditolak, perbaiki file sebelum diterapkan", len(laporan.BarisDitolak)))
+	}
+	var kosong []string
+	for _, tingkat := range daftarTingkatNomenklatur {
+		if laporan.PerTingkat[tingkat].JumlahFile == 0 {
+			kosong = append(kosong, tingkat)
+		}
+	}
+	if len(kosong) > 0 {
+		return web.NewBadRequestError(fmt.Sprintf("file tidak memuat tingkat %s, import harus berisi referensi lengkap", strings.Join(kosong, ", ")))
+	}
+	return nil
+}
@@ -0,0 +1,127 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"strings"
+	"testing"
+)
+
+func TestTingkatKodeNomenklatur(t *testing.T) {
+	tests := []struct {
+		kode     string
+		expected string
+	}{
+		{kode: "1", expected: domainmaster.TingkatNomenklaturUrusan},
+		{kode: "1.02", expected: domainmaster.TingkatNomenklaturBidangUrusan},
+		{kode: "X.XX", expected: domainmaster.TingkatNomenklaturBidangUrusan},
+		{kode: "1.02.01", expected: domainmaster.TingkatNomenklaturProgram},
+		{kode: "1.02.01.2.01", expected: domainmaster.TingkatNomenklaturKegiatan},
+		{kode: "1.02.01.2.01.0001", expected: domainmaster.TingkatNomenklaturSubKegiatan},
+		{kode: "1.02.01.2", expected: ""},
+		{kode: "1.a2", expected: ""},
+		{kode: "", expected: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.kode, func(t *testing.T) {
+			if got := tingkatKodeNomenklatur(tt.kode); got != tt.expected {
+				t.Errorf("tingkatKodeNomenklatur(%q) = %q, expected %q", tt.kode, got, tt.expected)
+			}
+		})
+	}
+}
+
+func TestBacaBarisImportNomenklatur(t *testing.T) {
+	content := "LAMPIRAN KEPMENDAGRI;\n;\nKode;Nomenklatur Urusan Kabupaten/Kota\n1 ;URUSAN WAJIB\n1.02.;  Urusan   Kesehatan \n;\n"
+	rows, err := bacaTabelImport([]byte(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	baris, err := bacaBarisImportNomenklatur(rows)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(baris) != 2 {
+		t.Fatalf("jumlah baris = %d, expected 2", len(baris))
+	}
+	if baris[1].Nomor != 5 || baris[1].Kode != "1.02" || baris[1].Nama != "Urusan Kesehatan" {
+		t.Errorf("baris kedua = %+v", baris[1])
+	}
+
+	if _, err := bacaBarisImportNomenklatur([][]string{{"no", "uraian"}}); err == nil {
+		t.Error("file tanpa kolom kode harus ditolak")
+	}
+}
+
+func TestPeriksaImportNomenklatur(t *testing.T) {
+	rows := []barisImportNomenklatur{
+		{Nomor: 2, Kode: "1", Nama: "URUSAN WAJIB"},
+		{Nomor: 3, Kode: "1.02", Nama: "URUSAN KESEHATAN"},
+		{Nomor: 4, Kode: "1.02.01", Nama: "PROGRAM PENUNJANG"},
+		{Nomor: 5, Kode: "1.02.02", Nama: "PROGRAM PEMENUHAN UPAYA KESEHATAN"},
+		{Nomor: 6, Kode: "1.02.02.2.01", Nama: "Kegiatan Baru"},
+		{Nomor: 7, Kode: "1.02.02.2.01.0001", Nama: "Sub Kegiatan Baru"},
+		{Nomor: 8, Kode: "1.02.02", Nama: "PROGRAM LAIN"},
+		{Nomor: 9, Kode: "1.02.9", Nama: ""},
+		{Nomor: 10, Kode: "1.03.01", Nama: "PROGRAM TANPA INDUK"},
+	}
+	master := map[string][]domainmaster.Nomenklatur{
+		domainmaster.TingkatNomenklaturUrusan: {
+			{Kode: "1", Nama: "urusan  wajib"},
+		},
+		domainmaster.TingkatNomenklaturProgram: {
+			{Kode: "1.02.01", Nama: "PROGRAM PENUNJANG URUSAN"},
+			{Kode: "1.02.03", Nama: "PROGRAM LAMA", IsDeprecated: true},
+		},
+		domainmaster.TingkatNomenklaturKegiatan: {
+			{Kode: "1.02.01.2.01", Nama: "KEGIATAN TERPAKAI"},
+			{Kode: "1.02.01.2.02", Nama: "KEGIATAN TIDAK TERPAKAI"},
+		},
+		domainmaster.TingkatNomenklaturSubKegiatan: {
+			{Kode: "1.02.01.2.01.0001", Nama: "SUB TERPAKAI"},
+			{Kode: "1.02.02.2.01.0001", Nama: "Sub Kegiatan Baru", IsDeprecated: true},
+		},
+	}
+	terpakai := []string{"1.02.01.2.01.0001", "1.02.03"}
+
+	hasil := periksaImportNomenklatur(rows, master, terpakai)
+
+	aksi := make(map[string]string)
+	for _, item := range hasil.Perubahan {
+		aksi[item.Kode] = item.Aksi
+	}
+	expected := map[string]string{
+		"1.02":              domainmaster.AksiNomenklaturTambah,
+		"1.02.01":           domainmaster.AksiNomenklaturUbahNama,
+		"1.02.02":           domainmaster.AksiNomenklaturTambah,
+		"1.03.01":           domainmaster.AksiNomenklaturTambah,
+		"1.02.02.2.01":      domainmaster.AksiNomenklaturTambah,
+		"1.02.01.2.01":      domainmaster.AksiNomenklaturDeprecated,
+		"1.02.01.2.02":      domainmaster.AksiNomenklaturHapus,
+		"1.02.01.2.01.0001": domainmaster.AksiNomenklaturDeprecated,
+		"1.02.02.2.01.0001": domainmaster.AksiNomenklaturAktifKembali,
+	}
+	if len(aksi) != len(expected) {
+		t.Errorf("perubahan = %v, expected %v", aksi, expected)
+	}
+	for kode, a := range expected {
+		if aksi[kode] != a {
+			t.Errorf("aksi %s = %q, expected %q", kode, aksi[kode], a)
+		}
+	}
+
+	laporan := hasil.Laporan
+	if len(laporan.BarisDitolak) != 2 || laporan.BarisDitolak[0].Baris != 8 || laporan.BarisDitolak[1].Baris != 9 {
+		t.Errorf("baris ditolak = %+v, expected baris 8 dan 9", laporan.BarisDitolak)
+	}
+	if len(laporan.Peringatan) != 1 || !strings.Contains(laporan.Peringatan[0], "1.03") {
+		t.Errorf("peringatan = %v, expected induk 1.03 tidak ada", laporan.Peringatan)
+	}
+	if ringkasan := laporan.PerTingkat[domainmaster.TingkatNomenklaturProgram]; ringkasan.JumlahFile != 3 || ringkasan.Tambah != 2 || ringkasan.UbahNama != 1 {
+		t.Errorf("ringkasan program = %+v", ringkasan)
+	}
+
+	if err := periksaPenerapanNomenklatur(laporan); err == nil {
+		t.Error("file dengan baris ditolak tidak boleh diterapkan")
+	}
+}
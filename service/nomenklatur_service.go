@@ -0,0 +1,13 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/nomenklatur"
+)
+
+type NomenklaturService interface {
+	// Import tanpa simpan hanya mengembalikan diff, simpan menerapkan semua perubahan dalam satu transaksi
+	Import(ctx context.Context, versi string, namaFile string, content []byte, simpan bool) (nomenklatur.NomenklaturImportResponse, error)
+	FindAllVersi(ctx context.Context) ([]nomenklatur.NomenklaturVersiResponse, error)
+	FindVersiById(ctx context.Context, id int64) (nomenklatur.NomenklaturVersiResponse, error)
+}
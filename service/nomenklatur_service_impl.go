@@ -0,0 +1,193 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/nomenklatur"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"strings"
+)
+
+type NomenklaturServiceImpl struct {
+	NomenklaturRepository repository.NomenklaturRepository
+	DB                    *sql.DB
+}
+
+func NewNomenklaturServiceImpl(nomenklaturRepository repository.NomenklaturRepository, DB *sql.DB) *NomenklaturServiceImpl {
+	return &NomenklaturServiceImpl{
+		NomenklaturRepository: nomenklaturRepository,
+		DB:                    DB,
+	}
+}
+
+func (service *NomenklaturServiceImpl) Import(ctx context.Context, versi string, namaFile string, content []byte, simpan bool) (nomenklatur.NomenklaturImportResponse, error) {
+	versi = strings.TrimSpace(versi)
+	if simpan && versi == "" {
+		return nomenklatur.NomenklaturImportResponse{}, web.NewBadRequestError("versi wajib diisi saat menerapkan nomenklatur")
+	}
+	rows, err := bacaTabelImport(content)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+	baris, err := bacaBarisImportNomenklatur(rows)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+	// rollback eksplisit agar kegagalan di tengah penerapan tidak mengubah sebagian master
+	defer tx.Rollback()
+
+	if versi != "" {
+		exist, err := service.NomenklaturRepository.IsVersiExist(ctx, tx, versi)
+		if err != nil {
+			return nomenklatur.NomenklaturImportResponse{}, err
+		}
+		if exist {
+			return nomenklatur.NomenklaturImportResponse{}, web.NewConflictError(fmt.Sprintf("versi nomenklatur %s sudah pernah diterapkan", versi))
+		}
+	}
+
+	master := make(map[string][]domainmaster.Nomenklatur, len(daftarTingkatNomenklatur))
+	for _, tingkat := range daftarTingkatNomenklatur {
+		master[tingkat], err = service.NomenklaturRepository.FindAllMaster(ctx, tx, tingkat)
+		if err != nil {
+			return nomenklatur.NomenklaturImportResponse{}, err
+		}
+	}
+	terpakai, err := service.NomenklaturRepository.FindKodeTerpakai(ctx, tx)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	hasil := periksaImportNomenklatur(baris, master, terpakai)
+	hasil.Laporan.Versi = versi
+	hasil.Laporan.NamaFile = namaFile
+	if !simpan {
+		return hasil.Laporan, nil
+	}
+	if err := periksaPenerapanNomenklatur(hasil.Laporan); err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	versiBaru := domainmaster.NomenklaturVersi{Versi: versi, NamaFile: namaFile}
+	for _, item := range hasil.Perubahan {
+		if err := service.terapkanPerubahan(ctx, tx, item); err != nil {
+			return nomenklatur.NomenklaturImportResponse{}, err
+		}
+		switch item.Aksi {
+		case domainmaster.AksiNomenklaturTambah:
+			versiBaru.JumlahTambah++
+		case domainmaster.AksiNomenklaturUbahNama:
+			versiBaru.JumlahUbah++
+		case domainmaster.AksiNomenklaturHapus:
+			versiBaru.JumlahHapus++
+		case domainmaster.AksiNomenklaturDeprecated:
+			versiBaru.JumlahDeprecated++
+		case domainmaster.AksiNomenklaturAktifKembali:
+			versiBaru.JumlahAktifKembali++
+		}
+	}
+	if claims, ok := helper.GetUserClaims(ctx); ok {
+		versiBaru.AppliedBy = claims.Nip
+	}
+	versiBaru, err = service.NomenklaturRepository.CreateVersi(ctx, tx, versiBaru)
+	if err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+	if err := service.NomenklaturRepository.CreatePerubahan(ctx, tx, versiBaru.Id, hasil.Perubahan); err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nomenklatur.NomenklaturImportResponse{}, err
+	}
+
+	hasil.Laporan.Disimpan = true
+	hasil.Laporan.VersiId = versiBaru.Id
+	return hasil.Laporan, nil
+}
+
+func (service *NomenklaturServiceImpl) terapkanPerubahan(ctx context.Context, tx *sql.Tx, item domainmaster.NomenklaturPerubahan) error {
+	switch item.Aksi {
+	case domainmaster.AksiNomenklaturTambah:
+		return service.NomenklaturRepository.Insert(ctx, tx, domainmaster.Nomenklatur{Tingkat: item.Tingkat, Kode: item.Kode, Nama: item.NamaBaru})
+	case domainmaster.AksiNomenklaturUbahNama:
+		return service.NomenklaturRepository.UpdateNama(ctx, tx, item.Tingkat, item.Kode, item.NamaBaru)
+	case domainmaster.AksiNomenklaturAktifKembali:
+		return service.NomenklaturRepository.UpdateDeprecated(ctx, tx, item.Tingkat, item.Kode, false)
+	case domainmaster.AksiNomenklaturDeprecated:
+		return service.NomenklaturRepository.UpdateDeprecated(ctx, tx, item.Tingkat, item.Kode, true)
+	case domainmaster.AksiNomenklaturHapus:
+		return service.NomenklaturRepository.Delete(ctx, tx, item.Tingkat, item.Kode)
+	}
+	return fmt.Errorf("aksi nomenklatur %s tidak dikenal", item.Aksi)
+}
+
+func (service *NomenklaturServiceImpl) FindAllVersi(ctx context.Context) ([]nomenklatur.NomenklaturVersiResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	daftarVersi, err := service.NomenklaturRepository.FindAllVersi(ctx, tx)
+	if err != nil {
+		return nil, err
+	}
+	result := []nomenklatur.NomenklaturVersiResponse{}
+	for _, versi := range daftarVersi {
+		result = append(result, toNomenklaturVersiResponse(versi))
+	}
+	return result, nil
+}
+
+func (service *NomenklaturServiceImpl) FindVersiById(ctx context.Context, id int64) (nomenklatur.NomenklaturVersiResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nomenklatur.NomenklaturVersiResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	versi, err := service.NomenklaturRepository.FindVersiById(ctx, tx, id)
+	if err == sql.ErrNoRows {
+		return nomenklatur.NomenklaturVersiResponse{}, web.NewNotFoundError("versi nomenklatur tidak ditemukan")
+	}
+	if err != nil {
+		return nomenklatur.NomenklaturVersiResponse{}, err
+	}
+	perubahan, err := service.NomenklaturRepository.FindPerubahanByVersiId(ctx, tx, id)
+	if err != nil {
+		return nomenklatur.NomenklaturVersiResponse{}, err
+	}
+
+	response := toNomenklaturVersiResponse(versi)
+	response.Perubahan = []nomenklatur.NomenklaturPerubahanResponse{}
+	for _, item := range perubahan {
+		response.Perubahan = append(response.Perubahan, nomenklatur.NomenklaturPerubahanResponse{
+			Tingkat: item.Tingkat, Kode: item.Kode, Aksi: item.Aksi, NamaLama: item.NamaLama, NamaBaru: item.NamaBaru,
+		})
+	}
+	return response, nil
+}
+
+func toNomenklaturVersiResponse(versi domainmaster.NomenklaturVersi) nomenklatur.NomenklaturVersiResponse {
+	return nomenklatur.NomenklaturVersiResponse{
+		Id:                 versi.Id,
+		Versi:              versi.Versi,
+		NamaFile:           versi.NamaFile,
+		JumlahTambah:       versi.JumlahTambah,
+		JumlahUbah:         versi.JumlahUbah,
+		JumlahHapus:        versi.JumlahHapus,
+		JumlahDeprecated:   versi.JumlahDeprecated,
+		JumlahAktifKembali: versi.JumlahAktifKembali,
+		AppliedBy:          versi.AppliedBy,
+		CreatedAt:          versi.CreatedAt,
+	}
+}
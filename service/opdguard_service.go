@@ -0,0 +1,10 @@
+package service
+
+import "context"
+
+type OpdGuardService interface {
+	// CheckWrite: user hanya boleh mengubah data OPD-nya sendiri (kecuali super_admin)
+	CheckWrite(ctx context.Context, kodeOpd string) error
+	// CheckRead: seperti CheckWrite, ditambah pengecualian untuk OPD yang terhubung crosscutting disetujui
+	CheckRead(ctx context.Context, kodeOpd string, tahun string) error
+}
@@ -0,0 +1,71 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/repository"
+	"log"
+)
+
+type OpdGuardServiceImpl struct {
+	CrosscuttingOpdRepository repository.CrosscuttingOpdRepository
+	DB                        *sql.DB
+}
+
+func NewOpdGuardServiceImpl(crosscuttingOpdRepository repository.CrosscuttingOpdRepository, DB *sql.DB) *OpdGuardServiceImpl {
+	return &OpdGuardServiceImpl{
+		CrosscuttingOpdRepository: crosscuttingOpdRepository,
+		DB:                        DB,
+	}
+}
+
+func (service *OpdGuardServiceImpl) CheckWrite(ctx context.Context, kodeOpd string) error {
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok {
+		// tanpa claims berarti request lewat public path AuthMiddleware
+		return nil
+	}
+	if helper.HasAnyRole(claims, helper.RoleSuperAdmin) {
+		return nil
+	}
+	if kodeOpd != "" && kodeOpd == claims.KodeOpd {
+		return nil
+	}
+
+	log.Printf("[OpdGuard] akses tulis lintas OPD ditolak: nip=%s kode_opd_user=%s kode_opd_target=%s", claims.Nip, claims.KodeOpd, kodeOpd)
+	return web.NewForbiddenError("anda tidak memiliki akses ke data OPD lain")
+}
+
+func (service *OpdGuardServiceImpl) CheckRead(ctx context.Context, kodeOpd string, tahun string) error {
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok {
+		return nil
+	}
+	if helper.HasAnyRole(claims, helper.RoleSuperAdmin) {
+		return nil
+	}
+	if kodeOpd != "" && kodeOpd == claims.KodeOpd {
+		return nil
+	}
+
+	if kodeOpd != "" && claims.KodeOpd != "" {
+		tx, err := service.DB.Begin()
+		if err != nil {
+			return err
+		}
+		defer tx.Rollback()
+
+		linked, err := service.CrosscuttingOpdRepository.IsCrosscuttingDisetujuiAntarOpd(ctx, tx, claims.KodeOpd, kodeOpd, tahun)
+		if err != nil {
+			return err
+		}
+		if linked {
+			return nil
+		}
+	}
+
+	log.Printf("[OpdGuard] akses baca lintas OPD ditolak: nip=%s kode_opd_user=%s kode_opd_target=%s tahun=%s", claims.Nip, claims.KodeOpd, kodeOpd, tahun)
+	return web.NewForbiddenError("anda tidak memiliki akses ke data OPD lain")
+}
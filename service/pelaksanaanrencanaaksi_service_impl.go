@@ -13,16 +13,18 @@ import (
 )
 
 type PelaksanaanRencanaAksiServiceImpl struct {
-	PelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository
-	RencanaAksiRepository            repository.RencanaAksiRepository
-	DB                               *sql.DB
+	PelaksanaanRencanaAksiRepository   repository.PelaksanaanRencanaAksiRepository
+	RencanaAksiRepository              repository.RencanaAksiRepository
+	RencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository
+	DB                                 *sql.DB
 }
 
-func NewPelaksanaanRencanaAksiServiceImpl(pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, rencanaAksiRepository repository.RencanaAksiRepository, DB *sql.DB) *PelaksanaanRencanaAksiServiceImpl {
+func NewPelaksanaanRencanaAksiServiceImpl(pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, rencanaAksiRepository repository.RencanaAksiRepository, rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository, DB *sql.DB) *PelaksanaanRencanaAksiServiceImpl {
 	return &PelaksanaanRencanaAksiServiceImpl{
-		PelaksanaanRencanaAksiRepository: pelaksanaanRencanaAksiRepository,
-		RencanaAksiRepository:            rencanaAksiRepository,
-		DB:                               DB,
+		PelaksanaanRencanaAksiRepository:   pelaksanaanRencanaAksiRepository,
+		RencanaAksiRepository:              rencanaAksiRepository,
+		RencanaKinerjaVerifikasiRepository: rencanaKinerjaVerifikasiRepository,
+		DB:                                 DB,
 	}
 }
 
@@ -48,6 +50,9 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Create(ctx context.Context, re
 	if err != nil {
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal mendapatkan RencanaAksi: %v", err)
 	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
 
 	// Check dengan SELECT FOR UPDATE
 	exists, err := service.PelaksanaanRencanaAksiRepository.ExistsByRencanaAksiIdAndBulan(ctx, tx, request.RencanaAksiId, request.Bulan)
@@ -146,6 +151,9 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Update(ctx context.Context, re
 		}
 		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, fmt.Errorf("gagal mendapatkan PelaksanaanRencanaAksi: %v", err)
 	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.PelaksanaanRencanaAksiResponse{}, err
+	}
 
 	// Periksa total bobot yang sudah ada untuk rencana kinerja ini
 	totalBobot, err := service.RencanaAksiRepository.GetTotalBobotForRencanaKinerja(ctx, tx, rencanaAksi.RencanaKinerjaId)
@@ -288,6 +296,21 @@ func (service *PelaksanaanRencanaAksiServiceImpl) Delete(ctx context.Context, id
 	}
 	defer tx.Rollback()
 
+	pelaksanaan, err := service.PelaksanaanRencanaAksiRepository.FindById(ctx, tx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.NewNotFoundError(fmt.Sprintf("PelaksanaanRencanaAksi dengan ID %s tidak ditemukan", id))
+		}
+		return fmt.Errorf("gagal mendapatkan PelaksanaanRencanaAksi: %v", err)
+	}
+	rencanaAksi, err := service.RencanaAksiRepository.FindById(ctx, tx, pelaksanaan.RencanaAksiId)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan RencanaAksi: %v", err)
+	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, rencanaAksi.RencanaKinerjaId); err != nil {
+		return err
+	}
+
 	err = service.PelaksanaanRencanaAksiRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return fmt.Errorf("gagal menghapus pelaksanaan rencana aksi: %v", err)
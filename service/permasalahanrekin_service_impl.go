@@ -10,14 +10,16 @@ import (
 )
 
 type PermasalahanRekinServiceImpl struct {
-	PermasalahanRekinRepository repository.PermasalahanRekinRepository
-	DB                          *sql.DB
+	PermasalahanRekinRepository        repository.PermasalahanRekinRepository
+	RencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository
+	DB                                 *sql.DB
 }
 
-func NewPermasalahanRekinServiceImpl(permasalahanRekinRepository repository.PermasalahanRekinRepository, DB *sql.DB) *PermasalahanRekinServiceImpl {
+func NewPermasalahanRekinServiceImpl(permasalahanRekinRepository repository.PermasalahanRekinRepository, rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository, DB *sql.DB) *PermasalahanRekinServiceImpl {
 	return &PermasalahanRekinServiceImpl{
-		PermasalahanRekinRepository: permasalahanRekinRepository,
-		DB:                          DB,
+		PermasalahanRekinRepository:        permasalahanRekinRepository,
+		RencanaKinerjaVerifikasiRepository: rencanaKinerjaVerifikasiRepository,
+		DB:                                 DB,
 	}
 }
 
@@ -28,6 +30,10 @@ func (service *PermasalahanRekinServiceImpl) Create(ctx context.Context, request
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, request.RekinId); err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
+
 	permasalahanDomain := domain.PermasalahanRekin{
 		Id:                helper.GenerateRandomNumber(6),
 		RekinId:           request.RekinId,
@@ -59,6 +65,14 @@ func (service *PermasalahanRekinServiceImpl) Update(ctx context.Context, request
 	}
 	defer helper.CommitOrRollback(tx)
 
+	existing, err := service.PermasalahanRekinRepository.FindById(ctx, tx, request.Id)
+	if err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, existing.RekinId); err != nil {
+		return permasalahan.PermasalahanRekinResponse{}, err
+	}
+
 	permasalahanDomain := domain.PermasalahanRekin{
 		Id:                request.Id,
 		Permasalahan:      request.Permasalahan,
@@ -88,6 +102,14 @@ func (service *PermasalahanRekinServiceImpl) Delete(ctx context.Context, id int)
 	}
 	defer helper.CommitOrRollback(tx)
 
+	existing, err := service.PermasalahanRekinRepository.FindById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.RencanaKinerjaVerifikasiRepository, existing.RekinId); err != nil {
+		return err
+	}
+
 	err = service.PermasalahanRekinRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return err
@@ -2,6 +2,7 @@ package service
 
 import (
 	"context"
+	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/web/pkopd"
 )
 
@@ -9,4 +10,6 @@ type PkService interface {
 	FindByKodeOpdTahun(ctx context.Context, kodeOpd string, tahun int) (pkopd.PkOpdResponse, error)
 	HubungkanRekin(ctx context.Context, request pkopd.PkOpdRequest) (pkopd.PkOpdResponse, error)
 	HubungkanAtasan(ctx context.Context, request pkopd.HubungkanAtasanRequest) (pkopd.PkOpdResponse, error)
+	// DokumenPk naskah PK siap tanda tangan, nip kosong berarti seluruh pegawai OPD
+	DokumenPk(ctx context.Context, kodeOpd string, tahun int, nip string) (*helper.Dokumen, error)
 }
@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
 	"ekak_kabupaten_madiun/model/web/pegawai"
 	"ekak_kabupaten_madiun/model/web/pkopd"
@@ -25,6 +26,7 @@ type PkServiceImpl struct {
 	rekinService                 RencanaKinerjaService
 	opdService                   OpdService
 	strukturOrganisasiRepository repository.StrukturOrganisasiRepository
+	opdGuardService              OpdGuardService
 	Validate                     *validator.Validate
 	DB                           *sql.DB
 }
@@ -35,6 +37,7 @@ func NewPkServiceImpl(
 	rekinService RencanaKinerjaService,
 	opdService OpdService,
 	strukturOrganisasiRepository repository.StrukturOrganisasiRepository,
+	opdGuardService OpdGuardService,
 	validate *validator.Validate,
 	DB *sql.DB,
 ) *PkServiceImpl {
@@ -44,6 +47,7 @@ func NewPkServiceImpl(
 		rekinService:                 rekinService,
 		opdService:                   opdService,
 		strukturOrganisasiRepository: strukturOrganisasiRepository,
+		opdGuardService:              opdGuardService,
 		Validate:                     validate,
 		DB:                           DB,
 	}
@@ -768,10 +772,11 @@ func toSasaranPemdaResponse(
 
 	for _, sp := range list {
 		result = append(result, pkopd.SasaranPemdaPk{
-			NamaKepalaPemda:  sp.NamaKepalaPemda,
-			NipKepalaPemda:   sp.NipKepalaPemda,
-			IdSasaranPemda:   sp.SasaranPemdaId,
-			NamaSasaranPemda: sp.SasaranPemda,
+			NamaKepalaPemda:    sp.NamaKepalaPemda,
+			NipKepalaPemda:     sp.NipKepalaPemda,
+			JabatanKepalaPemda: sp.JabatanKepalaPemda,
+			IdSasaranPemda:     sp.SasaranPemdaId,
+			NamaSasaranPemda:   sp.SasaranPemda,
 		})
 	}
 
@@ -868,3 +873,185 @@ func replaceKode(kode, kodeOpd string) string {
 	// gabungkan dengan sisa kode lama
 	return newPrefix + "." + strings.Join(kParts[2:], ".")
 }
+
+const jabatanKepalaPemdaDefault = "Kepala Daerah"
+
+// pihakPk identitas penanda tangan perjanjian kinerja
+type pihakPk struct {
+	Nama    string
+	Nip     string
+	Jabatan string
+}
+
+func (service *PkServiceImpl) DokumenPk(ctx context.Context, kodeOpd string, tahun int, nip string) (*helper.Dokumen, error) {
+	if err := service.opdGuardService.CheckRead(ctx, kodeOpd, strconv.Itoa(tahun)); err != nil {
+		return nil, err
+	}
+
+	pkOpd, err := service.FindByKodeOpdTahun(ctx, kodeOpd, tahun)
+	if err != nil {
+		return nil, err
+	}
+
+	pegawais := pegawaiPenandaTangan(pkOpd, nip)
+	if len(pegawais) == 0 {
+		if nip != "" {
+			return nil, web.NewNotFoundError(fmt.Sprintf("perjanjian kinerja pegawai %s tahun %d tidak ditemukan", nip, tahun))
+		}
+		return nil, web.NewNotFoundError(fmt.Sprintf("belum ada perjanjian kinerja %s tahun %d", kodeOpd, tahun))
+	}
+
+	return dokumenPk(pkOpd, pegawais), nil
+}
+
+// pegawaiPenandaTangan pegawai yang sudah memiliki PK, urut sesuai level
+func pegawaiPenandaTangan(pkOpd pkopd.PkOpdResponse, nip string) []pkopd.PkPegawai {
+	var result []pkopd.PkPegawai
+	for _, level := range pkOpd.PkItem {
+		for _, pegawai := range level.Pegawais {
+			if nip != "" && pegawai.Nip != nip {
+				continue
+			}
+			if len(pegawai.Pks) == 0 {
+				continue
+			}
+			result = append(result, pegawai)
+		}
+	}
+	return result
+}
+
+// pihakKeduaPk atasan langsung pegawai, untuk kepala OPD (level 4) fallback ke kepala daerah
+func pihakKeduaPk(pegawai pkopd.PkPegawai, sasaranPemdas []pkopd.SasaranPemdaPk) pihakPk {
+	if pegawai.NamaAtasan != "" {
+		return pihakPk{Nama: pegawai.NamaAtasan, Nip: pegawai.NipAtasan, Jabatan: pegawai.JabatanAtasan}
+	}
+	for _, pk := range pegawai.Pks {
+		if pk.NamaAtasan != "" {
+			return pihakPk{Nama: pk.NamaAtasan, Nip: pk.NipAtasan}
+		}
+	}
+	if pegawai.LevelPk == 4 {
+		for _, sasaranPemda := range sasaranPemdas {
+			if sasaranPemda.NamaKepalaPemda == "" {
+				continue
+			}
+			jabatan := sasaranPemda.JabatanKepalaPemda
+			if jabatan == "" {
+				jabatan = jabatanKepalaPemdaDefault
+			}
+			return pihakPk{Nama: sasaranPemda.NamaKepalaPemda, Nip: sasaranPemda.NipKepalaPemda, Jabatan: jabatan}
+		}
+	}
+	return pihakPk{}
+}
+
+// isianKosong titik-titik untuk diisi manual saat penandatanganan
+func isianKosong(nilai string) string {
+	if strings.TrimSpace(nilai) == "" {
+		return "...................................."
+	}
+	return nilai
+}
+
+func identitasPk(pihak pihakPk) helper.TabelDokumen {
+	return helper.TabelDokumen{
+		Lebar:      []float64{2, 0.3, 8},
+		TanpaGaris: true,
+		Baris: [][]string{
+			{"Nama", ":", isianKosong(pihak.Nama)},
+			{"NIP", ":", isianKosong(pihak.Nip)},
+			{"Jabatan", ":", isianKosong(pihak.Jabatan)},
+		},
+	}
+}
+
+func tandaTanganPk(pertama, kedua pihakPk, tahun int) helper.TabelDokumen {
+	return helper.TabelDokumen{
+		TanpaGaris: true,
+		RataTengah: true,
+		Baris: [][]string{
+			{"", fmt.Sprintf("............................, .................... %d", tahun)},
+			{"PIHAK KEDUA,", "PIHAK PERTAMA,"},
+			{isianKosong(kedua.Jabatan), isianKosong(pertama.Jabatan)},
+			{"\n\n\n\n", "\n\n\n\n"},
+			{isianKosong(kedua.Nama), isianKosong(pertama.Nama)},
+			{"NIP. " + kedua.Nip, "NIP. " + pertama.Nip},
+		},
+	}
+}
+
+func labelItemPk(level int) string {
+	if level >= 6 {
+		return "Sub Kegiatan"
+	}
+	return "Program"
+}
+
+// dokumenPk satu naskah perjanjian beserta lampiran per pegawai, tiap naskah dimulai di halaman baru
+func dokumenPk(pkOpd pkopd.PkOpdResponse, pegawais []pkopd.PkPegawai) *helper.Dokumen {
+	dokumen := helper.NewDokumen("")
+	for i, pegawai := range pegawais {
+		if i > 0 {
+			dokumen.TambahHalamanBaru()
+		}
+		pertama := pihakPk{Nama: pegawai.Nama, Nip: pegawai.Nip, Jabatan: pegawai.JabatanPegawai}
+		kedua := pihakKeduaPk(pegawai, pkOpd.SasaranPemdas)
+
+		dokumen.TambahJudulTengah(fmt.Sprintf("PERJANJIAN KINERJA TAHUN %d", pkOpd.Tahun))
+		dokumen.TambahJudulTengah(strings.ToUpper(pkOpd.NamaOpd))
+		dokumen.TambahParagraf("Dalam rangka mewujudkan manajemen pemerintahan yang efektif, transparan, dan akuntabel serta berorientasi pada hasil, kami yang bertanda tangan di bawah ini:")
+		dokumen.TambahTabel(identitasPk(pertama))
+		dokumen.TambahParagraf("selanjutnya disebut pihak pertama.")
+		dokumen.TambahTabel(identitasPk(kedua))
+		dokumen.TambahParagraf("selaku atasan pihak pertama, selanjutnya disebut pihak kedua.")
+		dokumen.TambahParagraf("Pihak pertama berjanji akan mewujudkan target kinerja yang seharusnya sesuai lampiran perjanjian ini, dalam rangka mencapai target kinerja jangka menengah seperti yang telah ditetapkan dalam dokumen perencanaan. Keberhasilan dan kegagalan pencapaian target kinerja tersebut menjadi tanggung jawab kami.")
+		dokumen.TambahParagraf("Pihak kedua akan melakukan supervisi yang diperlukan serta akan melakukan evaluasi terhadap capaian kinerja dari perjanjian ini dan mengambil tindakan yang diperlukan dalam rangka pemberian penghargaan dan sanksi.")
+		dokumen.TambahTabel(tandaTanganPk(pertama, kedua, pkOpd.Tahun))
+
+		dokumen.TambahHalamanBaru()
+		dokumen.TambahJudulTengah(fmt.Sprintf("LAMPIRAN PERJANJIAN KINERJA TAHUN %d", pkOpd.Tahun))
+		dokumen.TambahJudulTengah(strings.ToUpper(isianKosong(pegawai.JabatanPegawai)))
+
+		var barisKinerja [][]string
+		for no, pk := range pegawai.Pks {
+			if len(pk.Indikators) == 0 {
+				barisKinerja = append(barisKinerja, []string{strconv.Itoa(no + 1), pk.RekinPemilikPk, "-", "-", "-"})
+				continue
+			}
+			for j, indikator := range pk.Indikators {
+				nomor, rekin := "", ""
+				if j == 0 {
+					nomor, rekin = strconv.Itoa(no+1), pk.RekinPemilikPk
+				}
+				target, satuan := "-", "-"
+				if len(indikator.Targets) > 0 {
+					target, satuan = indikator.Targets[0].Target, indikator.Targets[0].Satuan
+				}
+				barisKinerja = append(barisKinerja, []string{nomor, rekin, indikator.Indikator, target, satuan})
+			}
+		}
+		dokumen.TambahTabel(helper.TabelDokumen{
+			Kolom: []string{"No", "Sasaran / Rencana Kinerja", "Indikator", "Target", "Satuan"},
+			Lebar: []float64{0.6, 5, 5, 1.4, 1.6},
+			Baris: barisKinerja,
+		})
+
+		if len(pegawai.Item) > 0 {
+			labelItem := labelItemPk(pegawai.LevelPk)
+			barisItem := make([][]string, 0, len(pegawai.Item)+1)
+			for no, item := range pegawai.Item {
+				barisItem = append(barisItem, []string{strconv.Itoa(no + 1), item.KodeItem, item.NamaItem, helper.FormatRupiah(item.PaguItem)})
+			}
+			barisItem = append(barisItem, []string{"", "", "TOTAL", helper.FormatRupiah(pegawai.TotalPagu)})
+			dokumen.TambahTabel(helper.TabelDokumen{
+				Kolom: []string{"No", "Kode", labelItem, "Anggaran (Rp)"},
+				Lebar: []float64{0.6, 2.4, 7, 2.6},
+				Baris: barisItem,
+			})
+		}
+
+		dokumen.TambahTabel(tandaTanganPk(pertama, kedua, pkOpd.Tahun))
+	}
+	return dokumen
+}
@@ -1,6 +1,8 @@
 package service
 
 import (
+	"bytes"
+	"ekak_kabupaten_madiun/model/web/pkopd"
 	"testing"
 )
 
@@ -65,3 +67,75 @@ func TestReplaceKode(t *testing.T) {
 		})
 	}
 }
+
+func TestPihakKeduaPk(t *testing.T) {
+	sasaranPemdas := []pkopd.SasaranPemdaPk{{NamaKepalaPemda: "Bupati", NipKepalaPemda: "-"}}
+	tests := []struct {
+		name     string
+		pegawai  pkopd.PkPegawai
+		expected pihakPk
+	}{
+		{
+			name:     "atasan pegawai",
+			pegawai:  pkopd.PkPegawai{LevelPk: 5, NamaAtasan: "Kadis", NipAtasan: "1", JabatanAtasan: "Kepala Dinas"},
+			expected: pihakPk{Nama: "Kadis", Nip: "1", Jabatan: "Kepala Dinas"},
+		},
+		{
+			name:     "atasan dari pk",
+			pegawai:  pkopd.PkPegawai{LevelPk: 6, Pks: []pkopd.PkAsn{{}, {NamaAtasan: "Kabid", NipAtasan: "2"}}},
+			expected: pihakPk{Nama: "Kabid", Nip: "2"},
+		},
+		{
+			name:     "kepala opd ke kepala daerah",
+			pegawai:  pkopd.PkPegawai{LevelPk: 4},
+			expected: pihakPk{Nama: "Bupati", Nip: "-", Jabatan: jabatanKepalaPemdaDefault},
+		},
+		{
+			name:     "tanpa atasan",
+			pegawai:  pkopd.PkPegawai{LevelPk: 5},
+			expected: pihakPk{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := pihakKeduaPk(tt.pegawai, sasaranPemdas); result != tt.expected {
+				t.Errorf("pihakKeduaPk() = %+v, expected %+v", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestDokumenPk(t *testing.T) {
+	pkOpd := pkopd.PkOpdResponse{
+		NamaOpd: "Dinas Uji",
+		Tahun:   2025,
+		PkItem: []pkopd.PkOpdByLevel{{LevelPk: 5, Pegawais: []pkopd.PkPegawai{
+			{Nama: "Tanpa PK", Nip: "3"},
+			{
+				Nama: "Kabid", Nip: "2", LevelPk: 5, NamaAtasan: "Kadis",
+				Pks: []pkopd.PkAsn{{RekinPemilikPk: "Meningkatnya layanan", Indikators: []pkopd.IndikatorPk{
+					{Indikator: "Persentase layanan", Targets: []pkopd.TargetIndPk{{Target: "90", Satuan: "%"}}},
+				}}},
+				Item:      []pkopd.ItemPk{{KodeItem: "1.01.01", NamaItem: "Program Uji", PaguItem: 1500000}},
+				TotalPagu: 1500000,
+			},
+		}}},
+	}
+
+	pegawais := pegawaiPenandaTangan(pkOpd, "")
+	if len(pegawais) != 1 || pegawais[0].Nip != "2" {
+		t.Fatalf("pegawaiPenandaTangan() = %+v, expected hanya pegawai dengan PK", pegawais)
+	}
+	if result := pegawaiPenandaTangan(pkOpd, "3"); len(result) != 0 {
+		t.Errorf("pegawaiPenandaTangan() pegawai tanpa PK = %d, expected 0", len(result))
+	}
+
+	content, err := dokumenPk(pkOpd, pegawais).PDF()
+	if err != nil {
+		t.Fatalf("PDF() error: %v", err)
+	}
+	if !bytes.HasPrefix(content, []byte("%PDF-1.4")) {
+		t.Errorf("dokumenPk() tidak menghasilkan PDF")
+	}
+}
@@ -29,9 +29,11 @@ type PohonKinerjaAdminServiceImpl struct {
 	csfRepository             repository.CSFRepository
 	DB                        *sql.DB
 	programUnggulanRepository repository.ProgramUnggulanRepository
+	pokinWorkflowService      PokinWorkflowService
+	auditLogRepository        repository.AuditLogRepository
 }
 
-func NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepository repository.PohonKinerjaRepository, opdRepository repository.OpdRepository, csfRepository repository.CSFRepository, DB *sql.DB, pegawaiRepository repository.PegawaiRepository, reviewRepository repository.ReviewRepository, programUnggulanRepository repository.ProgramUnggulanRepository) *PohonKinerjaAdminServiceImpl {
+func NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepository repository.PohonKinerjaRepository, opdRepository repository.OpdRepository, csfRepository repository.CSFRepository, DB *sql.DB, pegawaiRepository repository.PegawaiRepository, reviewRepository repository.ReviewRepository, programUnggulanRepository repository.ProgramUnggulanRepository, pokinWorkflowService PokinWorkflowService, auditLogRepository repository.AuditLogRepository) *PohonKinerjaAdminServiceImpl {
 	return &PohonKinerjaAdminServiceImpl{
 		pohonKinerjaRepository:    pohonKinerjaRepository,
 		opdRepository:             opdRepository,
@@ -40,6 +42,8 @@ func NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepository repository.PohonKine
 		reviewRepository:          reviewRepository,
 		csfRepository:             csfRepository,
 		programUnggulanRepository: programUnggulanRepository,
+		pokinWorkflowService:      pokinWorkflowService,
+		auditLogRepository:        auditLogRepository,
 	}
 }
 
@@ -153,6 +157,8 @@ func (service *PohonKinerjaAdminServiceImpl) Create(ctx context.Context, request
 	}
 
 	log.Printf("Berhasil membuat PohonKinerja dengan ID: %d", result.Id)
+	recordAuditIndikatorPokin(ctx, tx, service.auditLogRepository, AuditActionCreate, result,
+		nil, indikatorPokinAudit(ctx, tx, service.pohonKinerjaRepository, result.Id))
 
 	// CSF
 	tahunCsf, err := strconv.Atoi(request.Tahun)
@@ -357,6 +363,7 @@ func (service *PohonKinerjaAdminServiceImpl) Update(ctx context.Context, request
 
 	// Jika ini adalah pohon kinerja asli (clone_from = 0)
 	// Bisa update semua data dan akan mempengaruhi pohon kinerja yang clone_from-nya mengarah ke id ini
+	indikatorSebelum := indikatorPokinAudit(ctx, tx, service.pohonKinerjaRepository, existingPokin.Id)
 	var pokinsToUpdate []domain.PohonKinerja
 	pokinsToUpdate = append(pokinsToUpdate, existingPokin)
 
@@ -524,6 +531,8 @@ func (service *PohonKinerjaAdminServiceImpl) Update(ctx context.Context, request
 			updatedPokin = result
 		}
 	}
+	recordAuditIndikatorPokin(ctx, tx, service.auditLogRepository, AuditActionUpdate, updatedPokin,
+		indikatorSebelum, indikatorPokinAudit(ctx, tx, service.pohonKinerjaRepository, updatedPokin.Id))
 
 	// Build response untuk pohon kinerja asli
 	var pelaksanaResponses []pohonkinerja.PelaksanaOpdResponse
@@ -1037,6 +1046,7 @@ func (service *PohonKinerjaAdminServiceImpl) Delete(ctx context.Context, id int)
 	if pokin.LevelPohon < 0 {
 		return fmt.Errorf("level pohon kinerja tidak valid")
 	}
+	indikatorSebelum := indikatorPokinAudit(ctx, tx, service.pohonKinerjaRepository, pokin.Id)
 
 	// Cek apakah data adalah hasil clone
 	cloneFrom, err := service.pohonKinerjaRepository.CheckCloneFrom(ctx, tx, id)
@@ -1050,6 +1060,7 @@ func (service *PohonKinerjaAdminServiceImpl) Delete(ctx context.Context, id int)
 		if err != nil {
 			return fmt.Errorf("gagal menghapus data clone: %v", err)
 		}
+		recordAuditIndikatorPokin(ctx, tx, service.auditLogRepository, AuditActionDelete, pokin, indikatorSebelum, nil)
 		return nil
 	}
 
@@ -1058,6 +1069,7 @@ func (service *PohonKinerjaAdminServiceImpl) Delete(ctx context.Context, id int)
 	if err != nil {
 		return fmt.Errorf("gagal menghapus data: %v", err)
 	}
+	recordAuditIndikatorPokin(ctx, tx, service.auditLogRepository, AuditActionDelete, pokin, indikatorSebelum, nil)
 
 	return nil
 }
@@ -1652,7 +1664,7 @@ func (service *PohonKinerjaAdminServiceImpl) CreateStrategicAdmin(ctx context.Co
 		KodeOpd:    existingPokin.KodeOpd,
 		Keterangan: existingPokin.Keterangan,
 		Tahun:      existingPokin.Tahun,
-		Status:     "tarik pokin opd",
+		Status:     domain.StatusPokinTarikOpd,
 		CloneFrom:  cloneReference,
 		IsActive:   existingPokin.IsActive,
 	}
@@ -1736,7 +1748,7 @@ func (service *PohonKinerjaAdminServiceImpl) CreateStrategicAdmin(ctx context.Co
 		NamaOpd:    namaOpd,
 		Keterangan: existingPokin.Keterangan,
 		Tahun:      existingPokin.Tahun,
-		Status:     "tarik pokin opd",
+		Status:     domain.StatusPokinTarikOpd,
 		IsActive:   existingPokin.IsActive,
 		Indikators: indikatorResponses,
 		Childs:     childs,
@@ -1842,7 +1854,7 @@ func (service *PohonKinerjaAdminServiceImpl) cloneChildrenHierarchy(ctx context.
 			KodeOpd:    child.KodeOpd,
 			Keterangan: child.Keterangan,
 			Tahun:      child.Tahun,
-			Status:     "tarik pokin opd",
+			Status:     domain.StatusPokinTarikOpd,
 			CloneFrom:  child.Id,
 			IsActive:   child.IsActive,
 		}
@@ -1883,7 +1895,7 @@ func (service *PohonKinerjaAdminServiceImpl) cloneChildrenHierarchy(ctx context.
 			NamaOpd:    namaOpd,
 			Keterangan: child.Keterangan,
 			Tahun:      child.Tahun,
-			Status:     "tarik pokin opd",
+			Status:     domain.StatusPokinTarikOpd,
 			IsActive:   child.IsActive, // Tambahkan ini
 			Indikators: indikatorResponses,
 			Childs:     childChilds,
@@ -1906,16 +1918,11 @@ func (service *PohonKinerjaAdminServiceImpl) CloneStrategiFromPemda(ctx context.
 	}
 	defer helper.CommitOrRollback(tx)
 
-	// Validasi status pokin
-	status, err := service.pohonKinerjaRepository.CheckPokinStatus(ctx, tx, request.IdToClone)
-	if err != nil {
+	// Setujui pokin yang diclone lebih dulu agar status tidak valid ditolak sebelum data apa pun ditulis
+	if _, err := service.pokinWorkflowService.TransisiTx(ctx, tx, request.IdToClone, AksiPokinSetujui, ""); err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
 
-	if status != "menunggu_disetujui" && status != "ditolak" {
-		return pohonkinerja.PohonKinerjaAdminResponseData{}, errors.New("hanya pohon kinerja dengan status menunggu_disetujui atau ditolak yang dapat diclone")
-	}
-
 	// Fungsi helper untuk clone indikator dan target
 	cloneIndikatorAndTargets := func(ctx context.Context, tx *sql.Tx, oldPokinId int, newPokinId int64) error {
 		indikators, err := service.pohonKinerjaRepository.FindIndikatorToClone(ctx, tx, oldPokinId)
@@ -1962,8 +1969,8 @@ func (service *PohonKinerjaAdminServiceImpl) CloneStrategiFromPemda(ctx context.
 		// Cek apakah kode_opd sama dengan parent atau ini adalah pohon pertama
 		if parentKodeOpd == "" || pokin.KodeOpd == parentKodeOpd {
 			// Update status hanya jika kode_opd sesuai dan statusnya menunggu_disetujui atau ditolak
-			if pokin.Status == "menunggu_disetujui" || pokin.Status == "ditolak" {
-				err = service.pohonKinerjaRepository.UpdatePokinStatus(ctx, tx, pokinId, "disetujui")
+			if pokin.Status == domain.StatusPokinMenungguDisetujui || pokin.Status == domain.StatusPokinDitolak {
+				_, err = service.pokinWorkflowService.TransisiTx(ctx, tx, pokinId, AksiPokinSetujui, "")
 				if err != nil {
 					return err
 				}
@@ -2049,7 +2056,7 @@ func (service *PohonKinerjaAdminServiceImpl) CloneStrategiFromPemda(ctx context.
 			KodeOpd:    existingPokin.KodeOpd,
 			Keterangan: existingPokin.Keterangan,
 			Tahun:      existingPokin.Tahun,
-			Status:     "pokin dari pemda",
+			Status:     domain.StatusPokinDariPemda,
 			CloneFrom:  pokinId,
 			Pelaksana:  existingPokin.Pelaksana,
 		}
@@ -2396,7 +2403,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinByStatus(ctx context.Conte
 	}
 	defer helper.CommitOrRollback(tx)
 
-	pokins, err := service.pohonKinerjaRepository.FindPokinByStatus(ctx, tx, kodeOpd, tahun, "menunggu_disetujui")
+	pokins, err := service.pohonKinerjaRepository.FindPokinByStatus(ctx, tx, kodeOpd, tahun, domain.StatusPokinMenungguDisetujui)
 	if err != nil {
 		return nil, err
 	}
@@ -2424,21 +2431,8 @@ func (service *PohonKinerjaAdminServiceImpl) TolakPokin(ctx context.Context, req
 	}
 	defer helper.CommitOrRollback(tx)
 
-	status, err := service.pohonKinerjaRepository.CheckPokinStatus(ctx, tx, request.Id)
-	if err != nil {
-		return err
-	}
-
-	if status != "menunggu_disetujui" {
-		return errors.New("hanya pohon kinerja dengan status menunggu_disetujui yang dapat ditolak")
-	}
-
-	err = service.pohonKinerjaRepository.UpdatePokinStatusTolak(ctx, tx, request.Id, "ditolak")
-	if err != nil {
-		return err
-	}
-
-	return nil
+	_, err = service.pokinWorkflowService.TransisiTx(ctx, tx, request.Id, AksiPokinTolak, request.Alasan)
+	return err
 }
 
 func (service *PohonKinerjaAdminServiceImpl) CrosscuttingOpd(ctx context.Context, request pohonkinerja.PohonKinerjaAdminStrategicCreateRequest) (pohonkinerja.PohonKinerjaAdminResponseData, error) {
@@ -2476,7 +2470,7 @@ func (service *PohonKinerjaAdminServiceImpl) CrosscuttingOpd(ctx context.Context
 	}
 
 	// Update status pokin yang diclone menjadi disetujui
-	err = service.pohonKinerjaRepository.UpdatePokinStatus(ctx, tx, request.IdToClone, "disetujui")
+	_, err = service.pokinWorkflowService.TransisiTx(ctx, tx, request.IdToClone, AksiPokinSetujui, "")
 	if err != nil {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, err
 	}
@@ -2497,7 +2491,7 @@ func (service *PohonKinerjaAdminServiceImpl) CrosscuttingOpd(ctx context.Context
 		KodeOpd:    existingPokin.KodeOpd,
 		Keterangan: existingPokin.Keterangan,
 		Tahun:      existingPokin.Tahun,
-		Status:     "crosscutting_menunggu",
+		Status:     domain.StatusPokinCrosscuttingMenunggu,
 		CloneFrom:  cloneReference,
 		Pelaksana:  existingPokin.Pelaksana,
 	}
@@ -2575,7 +2569,7 @@ func (service *PohonKinerjaAdminServiceImpl) CrosscuttingOpd(ctx context.Context
 		NamaOpd:    namaOpd,
 		Keterangan: existingPokin.Keterangan,
 		Tahun:      existingPokin.Tahun,
-		Status:     "crosscutting_menunggu",
+		Status:     domain.StatusPokinCrosscuttingMenunggu,
 		Indikators: indikatorResponses,
 		Pelaksana:  pelaksanaResponses,
 	}
@@ -2710,7 +2704,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinFromPemda(ctx context.Cont
 		// if pokin.Status != "menunggu_disetujui" && pokin.Status != "ditolak" {
 		// 	continue
 		// }
-		if pokin.Status != "menunggu_disetujui" {
+		if pokin.Status != domain.StatusPokinMenungguDisetujui {
 			continue
 		}
 
@@ -2786,21 +2780,8 @@ func (service *PohonKinerjaAdminServiceImpl) TolakCrosscutting(ctx context.Conte
 		return errors.New("id tidak boleh kosong")
 	}
 
-	status, err := service.pohonKinerjaRepository.CheckPokinStatus(ctx, tx, request.Id)
-	if err != nil {
-		return err
-	}
-
-	if status != "crosscutting_menunggu" {
-		return errors.New("hanya pohon kinerja dengan status crosscutting_menunggu yang dapat ditolak")
-	}
-
-	err = service.pohonKinerjaRepository.UpdatePokinStatusTolak(ctx, tx, request.Id, "crosscutting_ditolak")
-	if err != nil {
-		return err
-	}
-
-	return nil
+	_, err = service.pokinWorkflowService.TransisiTx(ctx, tx, request.Id, AksiPokinTolakCrosscutting, request.Alasan)
+	return err
 }
 
 func (service *PohonKinerjaAdminServiceImpl) SetujuiCrosscutting(ctx context.Context, request pohonkinerja.PohonKinerjaAdminTolakRequest) error {
@@ -2814,21 +2795,8 @@ func (service *PohonKinerjaAdminServiceImpl) SetujuiCrosscutting(ctx context.Con
 		return errors.New("id tidak boleh kosong")
 	}
 
-	status, err := service.pohonKinerjaRepository.CheckPokinStatus(ctx, tx, request.Id)
-	if err != nil {
-		return err
-	}
-
-	if status != "crosscutting_menunggu" {
-		return errors.New("hanya pohon kinerja dengan status crosscutting_menunggu yang dapat disetujui")
-	}
-
-	err = service.pohonKinerjaRepository.UpdatePokinStatusTolak(ctx, tx, request.Id, "crosscutting_disetujui")
-	if err != nil {
-		return err
-	}
-
-	return nil
+	_, err = service.pokinWorkflowService.TransisiTx(ctx, tx, request.Id, AksiPokinSetujuiCrosscutting, "")
+	return err
 }
 
 func (service *PohonKinerjaAdminServiceImpl) FindPokinFromOpd(ctx context.Context, kodeOpd string, tahun string, levelPohon int) ([]pohonkinerja.PohonKinerjaAdminResponseData, error) {
@@ -2858,7 +2826,7 @@ func (service *PohonKinerjaAdminServiceImpl) FindPokinFromOpd(ctx context.Contex
 	var result []pohonkinerja.PohonKinerjaAdminResponseData
 	for _, pokin := range pokins {
 		// Skip pohon kinerja dengan status yang tidak diinginkan
-		if pokin.Status == "menunggu_disetujui" || pokin.Status == "crosscutting_menunggu" || pokin.Status == "tarik pokin opd" || pokin.Status == "disetujui" || pokin.Status == "ditolak" || pokin.Status == "crosscutting_ditolak" {
+		if pokin.Status == domain.StatusPokinMenungguDisetujui || pokin.Status == domain.StatusPokinCrosscuttingMenunggu || pokin.Status == domain.StatusPokinTarikOpd || pokin.Status == domain.StatusPokinDisetujui || pokin.Status == domain.StatusPokinDitolak || pokin.Status == domain.StatusPokinCrosscuttingDitolak {
 			continue
 		}
 
@@ -3187,7 +3155,7 @@ func (service *PohonKinerjaAdminServiceImpl) ClonePokinPemda(ctx context.Context
 	}
 
 	// 2. Validasi - source tidak boleh berstatus 'tarik pokin opd'
-	if sourcePokin.Status == "tarik pokin opd" {
+	if sourcePokin.Status == domain.StatusPokinTarikOpd {
 		return pohonkinerja.PohonKinerjaAdminResponseData{}, fmt.Errorf("tidak dapat clone pohon kinerja dengan status 'tarik pokin opd'")
 	}
 	alreadyCloned, err := service.pohonKinerjaRepository.CheckIfSourceAlreadyCloned(ctx, tx, request.IdPokinSource, request.TahunTarget)
@@ -8,16 +8,19 @@ import (
 type PohonKinerjaOpdService interface {
 	Create(ctx context.Context, request pohonkinerja.PohonKinerjaCreateRequest) (pohonkinerja.PohonKinerjaOpdResponse, error)
 	Update(ctx context.Context, request pohonkinerja.PohonKinerjaUpdateRequest) (pohonkinerja.PohonKinerjaOpdResponse, error)
+	// Delete ikut membatalkan persetujuan sumber clone lewat workflow, jika ada sumber yang disetujui
+	// hanya super_admin dan admin_opd yang dapat menghapus (role lain mendapat 403)
 	Delete(ctx context.Context, id int) error
 	FindById(ctx context.Context, id int) (pohonkinerja.PohonKinerjaOpdResponse, error)
 	FindAll(ctx context.Context, kodeOpd, tahun string) (pohonkinerja.PohonKinerjaOpdAllResponse, error)
 	FindStrategicNoParent(ctx context.Context, kodeOpd, tahun string) ([]pohonkinerja.StrategicOpdResponse, error)
 	DeletePelaksana(ctx context.Context, pelaksanaId string) error
 	FindPokinByPelaksana(ctx context.Context, pegawaiId string, tahun string) ([]pohonkinerja.PohonKinerjaOpdResponse, error)
+	// DeletePokinPemdaInOpd membatalkan persetujuan pokin pemda asal lewat workflow,
+	// sehingga hanya super_admin dan admin_opd yang dapat menghapus (role lain mendapat 403)
 	DeletePokinPemdaInOpd(ctx context.Context, id int) error
 	UpdateParent(ctx context.Context, pohonKinerja pohonkinerja.PohonKinerjaUpdateParentRequest) (pohonkinerja.PohonKinerjaOpdResponse, error)
 	FindidPokinWithAllTema(ctx context.Context, id int) (pohonkinerja.PohonKinerjaAdminResponse, error)
-	CloneByKodeOpdAndTahun(ctx context.Context, request pohonkinerja.PohonKinerjaCloneRequest) error
 	CheckPokinExistsByTahun(ctx context.Context, kodeOpd string, tahun string) (bool, error)
 	FindAllPokinParentClonePokinOpd(ctx context.Context, kodeOpd, tahun string, levelPohon *int) ([]pohonkinerja.PohonKinerjaOpdResponse, error)
 	CountPokinPemda(ctx context.Context, kodeOpd, tahun string) (pohonkinerja.CountPokinPemdaResponse, error)
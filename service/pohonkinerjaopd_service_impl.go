@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/opdmaster"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/repository"
@@ -32,9 +33,13 @@ type PohonKinerjaOpdServiceImpl struct {
 	Validate                  *validator.Validate
 	ProgramUnggulanRepository repository.ProgramUnggulanRepository
 	RedisClient               *redis.Client
+	opdGuardService           OpdGuardService
+	lockDataService           LockDataService
+	pokinWorkflowService      PokinWorkflowService
+	auditLogRepository        repository.AuditLogRepository
 }
 
-func NewPohonKinerjaOpdServiceImpl(pohonKinerjaOpdRepository repository.PohonKinerjaRepository, opdRepository repository.OpdRepository, pegawaiRepository repository.PegawaiRepository, tujuanOpdRepository repository.TujuanOpdRepository, crosscuttingOpdRepository repository.CrosscuttingOpdRepository, reviewRepository repository.ReviewRepository, DB *sql.DB, validate *validator.Validate, programUnggulanRepository repository.ProgramUnggulanRepository, redisClient *redis.Client) *PohonKinerjaOpdServiceImpl {
+func NewPohonKinerjaOpdServiceImpl(pohonKinerjaOpdRepository repository.PohonKinerjaRepository, opdRepository repository.OpdRepository, pegawaiRepository repository.PegawaiRepository, tujuanOpdRepository repository.TujuanOpdRepository, crosscuttingOpdRepository repository.CrosscuttingOpdRepository, reviewRepository repository.ReviewRepository, DB *sql.DB, validate *validator.Validate, programUnggulanRepository repository.ProgramUnggulanRepository, redisClient *redis.Client, opdGuardService OpdGuardService, lockDataService LockDataService, pokinWorkflowService PokinWorkflowService, auditLogRepository repository.AuditLogRepository) *PohonKinerjaOpdServiceImpl {
 	return &PohonKinerjaOpdServiceImpl{
 		pohonKinerjaOpdRepository: pohonKinerjaOpdRepository,
 		opdRepository:             opdRepository,
@@ -46,10 +51,21 @@ func NewPohonKinerjaOpdServiceImpl(pohonKinerjaOpdRepository repository.PohonKin
 		Validate:                  validate,
 		ProgramUnggulanRepository: programUnggulanRepository,
 		RedisClient:               redisClient,
+		opdGuardService:           opdGuardService,
+		lockDataService:           lockDataService,
+		pokinWorkflowService:      pokinWorkflowService,
+		auditLogRepository:        auditLogRepository,
 	}
 }
 
 func (service *PohonKinerjaOpdServiceImpl) Create(ctx context.Context, request pohonkinerja.PohonKinerjaCreateRequest) (pohonkinerja.PohonKinerjaOpdResponse, error) {
+	if err := service.opdGuardService.CheckWrite(ctx, request.KodeOpd); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+	if err := service.lockDataService.CheckWritable(ctx, LockJenisPohonKinerjaOpd, request.KodeOpd, request.Tahun); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaOpdResponse{}, err
@@ -206,6 +222,8 @@ func (service *PohonKinerjaOpdServiceImpl) Create(ctx context.Context, request p
 	if err != nil {
 		return pohonkinerja.PohonKinerjaOpdResponse{}, err
 	}
+	recordAuditIndikatorPokin(ctx, tx, service.auditLogRepository, AuditActionCreate, result,
+		nil, indikatorPokinAudit(ctx, tx, service.pohonKinerjaOpdRepository, result.Id))
 
 	// Update tagging responses dengan ID yang sudah di-generate
 	for i, tagging := range result.TaggingPokin {
@@ -253,6 +271,29 @@ func (service *PohonKinerjaOpdServiceImpl) Update(ctx context.Context, request p
 	}
 	defer helper.CommitOrRollback(tx)
 
+	// guard memakai OPD pohon yang tersimpan, bukan kode_opd dari body
+	existingPokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, request.Id)
+	if err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("data pohon kinerja tidak ditemukan")
+	}
+	if err := service.opdGuardService.CheckWrite(ctx, existingPokin.KodeOpd); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+	if request.KodeOpd != existingPokin.KodeOpd {
+		if err := service.opdGuardService.CheckWrite(ctx, request.KodeOpd); err != nil {
+			return pohonkinerja.PohonKinerjaOpdResponse{}, err
+		}
+	}
+	if err := service.lockDataService.CheckWritable(ctx, LockJenisPohonKinerjaOpd, existingPokin.KodeOpd, existingPokin.Tahun); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+	if request.KodeOpd != existingPokin.KodeOpd || request.Tahun != existingPokin.Tahun {
+		if err := service.lockDataService.CheckWritable(ctx, LockJenisPohonKinerjaOpd, request.KodeOpd, request.Tahun); err != nil {
+			return pohonkinerja.PohonKinerjaOpdResponse{}, err
+		}
+	}
+	indikatorSebelum := indikatorPokinAudit(ctx, tx, service.pohonKinerjaOpdRepository, existingPokin.Id)
+
 	// Validasi request
 	if request.NamaPohon == "" {
 		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("nama program tidak boleh kosong")
@@ -282,10 +323,6 @@ func (service *PohonKinerjaOpdServiceImpl) Update(ctx context.Context, request p
 	var pokinsToUpdate []domain.PohonKinerja
 
 	// Tambahkan pohon kinerja yang sedang diupdate
-	existingPokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, request.Id)
-	if err != nil {
-		return pohonkinerja.PohonKinerjaOpdResponse{}, errors.New("data pohon kinerja tidak ditemukan")
-	}
 	pokinsToUpdate = append(pokinsToUpdate, existingPokin)
 
 	// Cari pohon kinerja yang merupakan clone dari yang sedang diupdate
@@ -443,6 +480,8 @@ func (service *PohonKinerjaOpdServiceImpl) Update(ctx context.Context, request p
 			updatedPokin = result
 		}
 	}
+	recordAuditIndikatorPokin(ctx, tx, service.auditLogRepository, AuditActionUpdate, updatedPokin,
+		indikatorSebelum, indikatorPokinAudit(ctx, tx, service.pohonKinerjaOpdRepository, updatedPokin.Id))
 
 	countReview, err := service.reviewRepository.CountReviewByPohonKinerja(ctx, tx, updatedPokin.Id)
 	helper.PanicIfError(err)
@@ -589,23 +628,114 @@ func (service *PohonKinerjaOpdServiceImpl) Delete(ctx context.Context, id int) e
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
-	defer helper.CommitOrRollback(tx)
+	// rollback eksplisit agar pokin tidak terhapus saat status sumber clone gagal dikembalikan
+	defer tx.Rollback()
 
 	// 1. Cek apakah pohon kinerja dengan ID tersebut ada
-	_, err = service.pohonKinerjaOpdRepository.FindById(ctx, tx, id)
+	existingPokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, id)
 	if err != nil {
 		return fmt.Errorf("pohon kinerja tidak ditemukan: %v", err)
 	}
+	if err := service.opdGuardService.CheckWrite(ctx, existingPokin.KodeOpd); err != nil {
+		return err
+	}
+	if err := service.lockDataService.CheckWritable(ctx, LockJenisPohonKinerjaOpd, existingPokin.KodeOpd, existingPokin.Tahun); err != nil {
+		return err
+	}
+	indikatorSebelum := indikatorPokinAudit(ctx, tx, service.pohonKinerjaOpdRepository, existingPokin.Id)
 
 	// 2. Lakukan penghapusan dengan fungsi baru
-	err = service.pohonKinerjaOpdRepository.Delete(ctx, tx, id)
+	cloneFromIds, err := service.pohonKinerjaOpdRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return fmt.Errorf("gagal menghapus pohon kinerja: %v", err)
 	}
+	recordAuditIndikatorPokin(ctx, tx, service.auditLogRepository, AuditActionDelete, existingPokin, indikatorSebelum, nil)
 
+	// 3. Sumber clone yang masih disetujui kembali ke menunggu_disetujui
+	if err := service.batalkanPersetujuanSumberClone(ctx, tx, cloneFromIds); err != nil {
+		return err
+	}
+	return tx.Commit()
+}
+
+// batalkanPersetujuanSumberClone memindah sumber clone yang masih disetujui ke menunggu_disetujui lewat workflow
+func (service *PohonKinerjaOpdServiceImpl) batalkanPersetujuanSumberClone(ctx context.Context, tx *sql.Tx, cloneFromIds []int) error {
+	for _, cloneFromId := range cloneFromIds {
+		sumber, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, cloneFromId)
+		if err != nil {
+			return err
+		}
+		if sumber.Id == 0 || sumber.Status != domain.StatusPokinDisetujui {
+			continue
+		}
+		if _, err := service.pokinWorkflowService.TransisiSistemTx(ctx, tx, cloneFromId, AksiPokinBatalkanPersetujuan, ""); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// indikatorPokinAudit snapshot indikator dan target pohon kinerja untuk audit log, juga dipakai PohonKinerjaAdminServiceImpl
+func indikatorPokinAudit(ctx context.Context, tx *sql.Tx, pohonKinerjaRepository repository.PohonKinerjaRepository, pokinId int) []pohonkinerja.IndikatorResponse {
+	indikatorMap, err := pohonKinerjaRepository.FindIndikatorByPokinIdsBatch(ctx, tx, []int{pokinId})
+	if err != nil {
+		log.Printf("[AuditLog] gagal membaca indikator pohon kinerja %d: %v", pokinId, err)
+		return nil
+	}
+	indikators := indikatorMap[pokinId]
+	indikatorIds := make([]string, 0, len(indikators))
+	for _, indikator := range indikators {
+		indikatorIds = append(indikatorIds, indikator.Id)
+	}
+	targetMap, err := pohonKinerjaRepository.FindTargetByIndikatorIdsBatch(ctx, tx, indikatorIds)
+	if err != nil {
+		log.Printf("[AuditLog] gagal membaca target indikator pohon kinerja %d: %v", pokinId, err)
+		return nil
+	}
+
+	responses := make([]pohonkinerja.IndikatorResponse, 0, len(indikators))
+	for _, indikator := range indikators {
+		var targets []pohonkinerja.TargetResponse
+		for _, target := range targetMap[indikator.Id] {
+			targets = append(targets, pohonkinerja.TargetResponse{
+				Id:              target.Id,
+				IndikatorId:     target.IndikatorId,
+				TargetIndikator: target.Target,
+				SatuanIndikator: target.Satuan,
+				TahunSasaran:    target.Tahun,
+			})
+		}
+		responses = append(responses, pohonkinerja.IndikatorResponse{
+			Id:            indikator.Id,
+			IdPokin:       strconv.Itoa(pokinId),
+			NamaIndikator: indikator.Indikator,
+			Target:        targets,
+		})
+	}
+	return responses
+}
+
+// recordAuditIndikatorPokin mencatat perubahan indikator pohon kinerja, dilewati jika tidak ada indikator sebelum maupun sesudah
+func recordAuditIndikatorPokin(ctx context.Context, tx *sql.Tx, auditLogRepository repository.AuditLogRepository, action string, pokin domain.PohonKinerja, sebelum, sesudah []pohonkinerja.IndikatorResponse) {
+	if len(sebelum) == 0 && len(sesudah) == 0 {
+		return
+	}
+	entry := auditEntry{
+		EntityType: AuditEntityIndikatorPokin,
+		EntityId:   strconv.Itoa(pokin.Id),
+		Action:     action,
+		KodeOpd:    pokin.KodeOpd,
+		Tahun:      pokin.Tahun,
+	}
+	if len(sebelum) > 0 {
+		entry.Before = sebelum
+	}
+	if len(sesudah) > 0 {
+		entry.After = sesudah
+	}
+	recordAudit(ctx, tx, auditLogRepository, entry)
+}
+
 func (service *PohonKinerjaOpdServiceImpl) FindById(ctx context.Context, id int) (pohonkinerja.PohonKinerjaOpdResponse, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -737,6 +867,10 @@ func (service *PohonKinerjaOpdServiceImpl) FindAll(ctx context.Context, kodeOpd,
 	startTime := time.Now()
 	serviceName := "PohonKinerjaOpdService.FindAll"
 
+	if err := service.opdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return pohonkinerja.PohonKinerjaOpdAllResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return pohonkinerja.PohonKinerjaOpdAllResponse{}, err
@@ -1095,10 +1229,10 @@ func lessPohonPemdaJenisFirst(a, b domain.PohonKinerja) bool {
 	if !aPemda && bPemda {
 		return false
 	}
-	if a.Status == "pokin dari pemda" && b.Status != "pokin dari pemda" {
+	if a.Status == domain.StatusPokinDariPemda && b.Status != domain.StatusPokinDariPemda {
 		return true
 	}
-	if a.Status != "pokin dari pemda" && b.Status == "pokin dari pemda" {
+	if a.Status != domain.StatusPokinDariPemda && b.Status == domain.StatusPokinDariPemda {
 		return false
 	}
 	return a.Id < b.Id
@@ -1377,10 +1511,10 @@ func appendOperationals(
 
 	// Sort operationals
 	sort.Slice(operationals, func(i, j int) bool {
-		if operationals[i].Status == "pokin dari pemda" && operationals[j].Status != "pokin dari pemda" {
+		if operationals[i].Status == domain.StatusPokinDariPemda && operationals[j].Status != domain.StatusPokinDariPemda {
 			return true
 		}
-		if operationals[i].Status != "pokin dari pemda" && operationals[j].Status == "pokin dari pemda" {
+		if operationals[i].Status != domain.StatusPokinDariPemda && operationals[j].Status == domain.StatusPokinDariPemda {
 			return false
 		}
 		return operationals[i].Id < operationals[j].Id
@@ -1425,10 +1559,10 @@ func appendOperationalN(
 
 	// Sort children
 	sort.Slice(children, func(i, j int) bool {
-		if children[i].Status == "pokin dari pemda" && children[j].Status != "pokin dari pemda" {
+		if children[i].Status == domain.StatusPokinDariPemda && children[j].Status != domain.StatusPokinDariPemda {
 			return true
 		}
-		if children[i].Status != "pokin dari pemda" && children[j].Status == "pokin dari pemda" {
+		if children[i].Status != domain.StatusPokinDariPemda && children[j].Status == domain.StatusPokinDariPemda {
 			return false
 		}
 		return children[i].Id < children[j].Id
@@ -1473,10 +1607,10 @@ func appendOperationalNRecursive(
 
 	// Sort children
 	sort.Slice(children, func(i, j int) bool {
-		if children[i].Status == "pokin dari pemda" && children[j].Status != "pokin dari pemda" {
+		if children[i].Status == domain.StatusPokinDariPemda && children[j].Status != domain.StatusPokinDariPemda {
 			return true
 		}
-		if children[i].Status != "pokin dari pemda" && children[j].Status == "pokin dari pemda" {
+		if children[i].Status != domain.StatusPokinDariPemda && children[j].Status == domain.StatusPokinDariPemda {
 			return false
 		}
 		return children[i].Id < children[j].Id
@@ -1549,9 +1683,38 @@ func (service *PohonKinerjaOpdServiceImpl) DeletePelaksana(ctx context.Context,
 		return err
 	}
 	defer helper.CommitOrRollback(tx)
+
+	pokinId, err := service.pohonKinerjaOpdRepository.FindPokinIdByPelaksanaId(ctx, tx, pelaksanaId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.NewNotFoundError("pelaksana pohon kinerja tidak ditemukan")
+		}
+		return err
+	}
+	if _, err := service.checkPokinWritable(ctx, tx, pokinId); err != nil {
+		return err
+	}
 	return service.pohonKinerjaOpdRepository.DeletePelaksanaPokin(ctx, tx, pelaksanaId)
 }
 
+// checkPokinWritable guard OPD dan kunci data memakai kode_opd dan tahun pohon yang tersimpan
+func (service *PohonKinerjaOpdServiceImpl) checkPokinWritable(ctx context.Context, tx *sql.Tx, id int) (domain.PohonKinerja, error) {
+	pokin, err := service.pohonKinerjaOpdRepository.FindById(ctx, tx, id)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return domain.PohonKinerja{}, web.NewNotFoundError("pohon kinerja tidak ditemukan")
+		}
+		return domain.PohonKinerja{}, err
+	}
+	if err := service.opdGuardService.CheckWrite(ctx, pokin.KodeOpd); err != nil {
+		return domain.PohonKinerja{}, err
+	}
+	if err := service.lockDataService.CheckWritable(ctx, LockJenisPohonKinerjaOpd, pokin.KodeOpd, pokin.Tahun); err != nil {
+		return domain.PohonKinerja{}, err
+	}
+	return pokin, nil
+}
+
 // Tambahkan fungsi helper untuk membangun OperationalN response
 func (service *PohonKinerjaOpdServiceImpl) buildOperationalNResponse(ctx context.Context, tx *sql.Tx, pohonMap map[int]map[int][]domain.PohonKinerja, operationalN domain.PohonKinerja, pelaksanaMap map[int][]pohonkinerja.PelaksanaOpdResponse, indikatorMap map[int][]pohonkinerja.IndikatorResponse) pohonkinerja.OperationalNOpdResponse {
 	// var keteranganCrosscutting *string
@@ -1745,10 +1908,10 @@ func (service *PohonKinerjaOpdServiceImpl) buildStrategicResponse(ctx context.Co
 	if tacticalList := pohonMap[5][strategic.Id]; len(tacticalList) > 0 {
 		var tacticals []pohonkinerja.TacticalOpdResponse
 		sort.Slice(tacticalList, func(i, j int) bool {
-			if tacticalList[i].Status == "pokin dari pemda" && tacticalList[j].Status != "pokin dari pemda" {
+			if tacticalList[i].Status == domain.StatusPokinDariPemda && tacticalList[j].Status != domain.StatusPokinDariPemda {
 				return true
 			}
-			if tacticalList[i].Status != "pokin dari pemda" && tacticalList[j].Status == "pokin dari pemda" {
+			if tacticalList[i].Status != domain.StatusPokinDariPemda && tacticalList[j].Status == domain.StatusPokinDariPemda {
 				return false
 			}
 			return tacticalList[i].Id < tacticalList[j].Id
@@ -1859,10 +2022,10 @@ func (service *PohonKinerjaOpdServiceImpl) buildTacticalResponse(ctx context.Con
 	if operationalList := pohonMap[6][tactical.Id]; len(operationalList) > 0 {
 		var operationals []pohonkinerja.OperationalOpdResponse
 		sort.Slice(operationalList, func(i, j int) bool {
-			if operationalList[i].Status == "pokin dari pemda" && operationalList[j].Status != "pokin dari pemda" {
+			if operationalList[i].Status == domain.StatusPokinDariPemda && operationalList[j].Status != domain.StatusPokinDariPemda {
 				return true
 			}
-			if operationalList[i].Status != "pokin dari pemda" && operationalList[j].Status == "pokin dari pemda" {
+			if operationalList[i].Status != domain.StatusPokinDariPemda && operationalList[j].Status == domain.StatusPokinDariPemda {
 				return false
 			}
 			return operationalList[i].Id < operationalList[j].Id
@@ -1977,10 +2140,10 @@ func (service *PohonKinerjaOpdServiceImpl) buildOperationalResponse(ctx context.
 		// Ubah pengurutan untuk operational-n
 		sort.Slice(operationalNList, func(i, j int) bool {
 			// Prioritaskan status "pokin dari pemda"
-			if operationalNList[i].Status == "pokin dari pemda" && operationalNList[j].Status != "pokin dari pemda" {
+			if operationalNList[i].Status == domain.StatusPokinDariPemda && operationalNList[j].Status != domain.StatusPokinDariPemda {
 				return true
 			}
-			if operationalNList[i].Status != "pokin dari pemda" && operationalNList[j].Status == "pokin dari pemda" {
+			if operationalNList[i].Status != domain.StatusPokinDariPemda && operationalNList[j].Status == domain.StatusPokinDariPemda {
 				return false
 			}
 			return operationalNList[i].Id < operationalNList[j].Id
@@ -2200,13 +2363,15 @@ func (service *PohonKinerjaOpdServiceImpl) DeletePokinPemdaInOpd(ctx context.Con
 	if err != nil {
 		return fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
-	defer helper.CommitOrRollback(tx)
+	// rollback eksplisit agar hierarki pemda tidak tersimpan setengah dikembalikan
+	defer tx.Rollback()
 
-	// 1. Cek apakah pohon kinerja dengan ID tersebut ada
-	_, err = service.pohonKinerjaOpdRepository.FindById(ctx, tx, id)
+	// 1. Cek apakah pohon kinerja dengan ID tersebut ada dan boleh diubah
+	existingPokin, err := service.checkPokinWritable(ctx, tx, id)
 	if err != nil {
-		return fmt.Errorf("pohon kinerja tidak ditemukan: %v", err)
+		return err
 	}
+	indikatorSebelum := indikatorPokinAudit(ctx, tx, service.pohonKinerjaOpdRepository, existingPokin.Id)
 
 	// 2. Cek apakah ini adalah pohon kinerja yang di-clone dan dapatkan ID aslinya
 	cloneFrom, err := service.pohonKinerjaOpdRepository.CheckCloneFrom(ctx, tx, id)
@@ -2218,16 +2383,20 @@ func (service *PohonKinerjaOpdServiceImpl) DeletePokinPemdaInOpd(ctx context.Con
 		return fmt.Errorf("pohon kinerja ini bukan merupakan hasil clone dari pemda")
 	}
 
-	// 3. Hapus data clone saat ini
-	err = service.pohonKinerjaOpdRepository.Delete(ctx, tx, id)
+	// 3. Kembalikan pohon kinerja asli (yang di-clone) ke menunggu_disetujui, sebelum menghapus agar transisi tidak valid membatalkan proses
+	_, err = service.pokinWorkflowService.TransisiSistemTx(ctx, tx, cloneFrom, AksiPokinBatalkanPersetujuan, "")
 	if err != nil {
-		return fmt.Errorf("gagal menghapus pohon kinerja clone: %v", err)
+		return err
 	}
 
-	// 4. Update status pohon kinerja asli (yang di-clone) menjadi "ditolak"
-	err = service.pohonKinerjaOpdRepository.UpdatePokinStatusFromApproved(ctx, tx, cloneFrom)
+	// 4. Hapus data clone saat ini
+	cloneFromIds, err := service.pohonKinerjaOpdRepository.Delete(ctx, tx, id)
 	if err != nil {
-		return fmt.Errorf("gagal mengupdate status pohon kinerja asli: %v", err)
+		return fmt.Errorf("gagal menghapus pohon kinerja clone: %v", err)
+	}
+	recordAuditIndikatorPokin(ctx, tx, service.auditLogRepository, AuditActionDelete, existingPokin, indikatorSebelum, nil)
+	if err := service.batalkanPersetujuanSumberClone(ctx, tx, cloneFromIds); err != nil {
+		return err
 	}
 
 	// 5. Dapatkan dan update status semua child dari pohon kinerja asli
@@ -2238,16 +2407,15 @@ func (service *PohonKinerjaOpdServiceImpl) DeletePokinPemdaInOpd(ctx context.Con
 
 	// 6. Update status untuk semua child dari pohon kinerja asli
 	for _, originalPokin := range originalHierarchy {
-		if originalPokin.Id != cloneFrom { // Skip pohon kinerja utama karena sudah diupdate
-			err = service.pohonKinerjaOpdRepository.UpdatePokinStatusFromApproved(ctx, tx, originalPokin.Id)
+		if originalPokin.Id != cloneFrom && originalPokin.Status == domain.StatusPokinDisetujui { // Skip pohon kinerja utama karena sudah diupdate
+			_, err = service.pokinWorkflowService.TransisiSistemTx(ctx, tx, originalPokin.Id, AksiPokinBatalkanPersetujuan, "")
 			if err != nil {
-				log.Printf("Warning: gagal mengupdate status child pohon kinerja asli ID %d: %v", originalPokin.Id, err)
-				continue // Lanjutkan ke child berikutnya meskipun ada error
+				return fmt.Errorf("gagal mengembalikan status child pohon kinerja asli ID %d: %w", originalPokin.Id, err)
 			}
 		}
 	}
 
-	return nil
+	return tx.Commit()
 }
 
 func (service *PohonKinerjaOpdServiceImpl) UpdateParent(ctx context.Context, pohonKinerja pohonkinerja.PohonKinerjaUpdateParentRequest) (pohonkinerja.PohonKinerjaOpdResponse, error) {
@@ -2257,6 +2425,10 @@ func (service *PohonKinerjaOpdServiceImpl) UpdateParent(ctx context.Context, poh
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if _, err := service.checkPokinWritable(ctx, tx, pohonKinerja.Id); err != nil {
+		return pohonkinerja.PohonKinerjaOpdResponse{}, err
+	}
+
 	pokin := domain.PohonKinerja{
 		Id:     pohonKinerja.Id,
 		Parent: pohonKinerja.Parent,
@@ -2498,28 +2670,6 @@ func (service *PohonKinerjaOpdServiceImpl) FindidPokinWithAllTema(ctx context.Co
 
 	return response, nil
 }
-func (service *PohonKinerjaOpdServiceImpl) CloneByKodeOpdAndTahun(ctx context.Context, request pohonkinerja.PohonKinerjaCloneRequest) error {
-	// Validasi request
-	err := service.Validate.Struct(request)
-	if err != nil {
-		return err
-	}
-
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return err
-	}
-	defer helper.CommitOrRollback(tx)
-
-	// Lakukan cloning
-	err = service.pohonKinerjaOpdRepository.ClonePokinOpd(ctx, tx, request.KodeOpd, request.TahunSumber, request.TahunTujuan)
-	if err != nil {
-		return fmt.Errorf("gagal melakukan cloning: %v", err)
-	}
-
-	return nil
-}
-
 func (service *PohonKinerjaOpdServiceImpl) CheckPokinExistsByTahun(ctx context.Context, kodeOpd string, tahun string) (bool, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -3003,6 +3153,9 @@ func (service *PohonKinerjaOpdServiceImpl) UpdateParentClone(ctx context.Context
 		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, fmt.Errorf("gagal memulai transaksi: %v", err)
 	}
 	defer helper.CommitOrRollback(tx)
+	if _, err := service.checkPokinWritable(ctx, tx, req.Id); err != nil {
+		return pohonkinerja.PohonKinerjaUpdateParentCloneResponse{}, err
+	}
 	pokin := domain.PohonKinerja{Id: req.Id, Parent: req.Parent}
 	pokin, err = service.pohonKinerjaOpdRepository.UpdateParent(ctx, tx, pokin)
 	if err != nil {
@@ -0,0 +1,31 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+)
+
+// aksi workflow pohon kinerja
+const (
+	AksiPokinAjukan              = "ajukan"
+	AksiPokinSetujui             = "setujui"
+	AksiPokinTolak               = "tolak"
+	AksiPokinBatalkanPersetujuan = "batalkan_persetujuan"
+	AksiPokinSetujuiCrosscutting = "setujui_crosscutting"
+	AksiPokinTolakCrosscutting   = "tolak_crosscutting"
+)
+
+// ActorRoleSistem dicatat di riwayat untuk transisi akibat perubahan data lain
+const ActorRoleSistem = "sistem"
+
+type PokinWorkflowService interface {
+	Transisi(ctx context.Context, request pohonkinerja.PokinTransisiRequest) (pohonkinerja.PokinTransisiResponse, error)
+	// TransisiTx satu-satunya jalan mengubah status pokin, dipakai service lain di dalam transaksinya
+	TransisiTx(ctx context.Context, tx *sql.Tx, pohonKinerjaId int, aksi string, alasan string) (domain.PohonKinerjaTransisi, error)
+	// TransisiSistemTx transisi ikutan dari operasi yang izinnya sudah diperiksa pemanggil, role pengguna tidak diperiksa
+	TransisiSistemTx(ctx context.Context, tx *sql.Tx, pohonKinerjaId int, aksi string, alasan string) (domain.PohonKinerjaTransisi, error)
+	FindWorkflow(ctx context.Context, pohonKinerjaId int) (pohonkinerja.PokinWorkflowResponse, error)
+	FindAturan() []pohonkinerja.PokinAturanTransisiResponse
+}
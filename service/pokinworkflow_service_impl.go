@@ -0,0 +1,293 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/pohonkinerja"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type PokinWorkflowServiceImpl struct {
+	PohonKinerjaTransisiRepository repository.PohonKinerjaTransisiRepository
+	OpdGuardService                OpdGuardService
+	DB                             *sql.DB
+	Validate                       *validator.Validate
+}
+
+func NewPokinWorkflowServiceImpl(pohonKinerjaTransisiRepository repository.PohonKinerjaTransisiRepository, opdGuardService OpdGuardService, DB *sql.DB, validate *validator.Validate) *PokinWorkflowServiceImpl {
+	return &PokinWorkflowServiceImpl{
+		PohonKinerjaTransisiRepository: pohonKinerjaTransisiRepository,
+		OpdGuardService:                opdGuardService,
+		DB:                             DB,
+		Validate:                       validate,
+	}
+}
+
+type transisiPokin struct {
+	Aksi        string
+	Dari        string
+	Ke          string
+	Roles       []string
+	WajibAlasan bool
+}
+
+var (
+	rolePengelolaPokin = []string{helper.RoleSuperAdmin}
+	rolePemilikPokin   = []string{helper.RoleSuperAdmin, helper.RoleAdminOpd}
+)
+
+// aturanTransisiPokin seluruh perpindahan status yang diizinkan, di luar daftar ini ditolak
+var aturanTransisiPokin = []transisiPokin{
+	{Aksi: AksiPokinAjukan, Dari: domain.StatusPokinDraft, Ke: domain.StatusPokinMenungguDisetujui, Roles: rolePemilikPokin},
+	{Aksi: AksiPokinAjukan, Dari: domain.StatusPokinDitolak, Ke: domain.StatusPokinMenungguDisetujui, Roles: rolePemilikPokin},
+	{Aksi: AksiPokinSetujui, Dari: domain.StatusPokinMenungguDisetujui, Ke: domain.StatusPokinDisetujui, Roles: rolePengelolaPokin},
+	{Aksi: AksiPokinSetujui, Dari: domain.StatusPokinDitolak, Ke: domain.StatusPokinDisetujui, Roles: rolePengelolaPokin},
+	{Aksi: AksiPokinTolak, Dari: domain.StatusPokinMenungguDisetujui, Ke: domain.StatusPokinDitolak, Roles: rolePengelolaPokin, WajibAlasan: true},
+	{Aksi: AksiPokinBatalkanPersetujuan, Dari: domain.StatusPokinDisetujui, Ke: domain.StatusPokinMenungguDisetujui, Roles: rolePemilikPokin},
+	{Aksi: AksiPokinSetujuiCrosscutting, Dari: domain.StatusPokinCrosscuttingMenunggu, Ke: domain.StatusPokinCrosscuttingSetuju, Roles: rolePemilikPokin},
+	{Aksi: AksiPokinTolakCrosscutting, Dari: domain.StatusPokinCrosscuttingMenunggu, Ke: domain.StatusPokinCrosscuttingDitolak, Roles: rolePemilikPokin, WajibAlasan: true},
+	{Aksi: AksiPokinAjukan, Dari: domain.StatusPokinCrosscuttingDitolak, Ke: domain.StatusPokinCrosscuttingMenunggu, Roles: rolePemilikPokin},
+}
+
+func labelStatusPokin(status string) string {
+	if status == domain.StatusPokinDraft {
+		return "belum diajukan"
+	}
+	return status
+}
+
+// periksaTransisiPokin mencari aturan untuk status dan aksi, lalu memeriksa role dan alasan
+func periksaTransisiPokin(dari string, aksi string, roles []string, alasan string) (transisiPokin, error) {
+	aturan, err := cariTransisiPokin(dari, aksi)
+	if err != nil {
+		return transisiPokin{}, err
+	}
+	if !helper.HasAnyRole(web.JWTClaim{Roles: roles}, aturan.Roles...) {
+		return transisiPokin{}, web.NewForbiddenError(fmt.Sprintf("aksi %s hanya dapat dilakukan oleh %s", aksi, strings.Join(aturan.Roles, ", ")))
+	}
+	return aturan, periksaAlasanPokin(aturan, alasan)
+}
+
+// cariTransisiPokin aturan untuk status dan aksi tanpa memeriksa role pelaku
+func cariTransisiPokin(dari string, aksi string) (transisiPokin, error) {
+	aksiDikenal := false
+	for _, aturan := range aturanTransisiPokin {
+		if aturan.Aksi != aksi {
+			continue
+		}
+		aksiDikenal = true
+		if aturan.Dari == dari {
+			return aturan, nil
+		}
+	}
+	if !aksiDikenal {
+		return transisiPokin{}, web.NewBadRequestError(fmt.Sprintf("aksi %s tidak dikenal", aksi))
+	}
+	return transisiPokin{}, web.NewConflictError(fmt.Sprintf("aksi %s tidak dapat dilakukan pada pohon kinerja berstatus %s", aksi, labelStatusPokin(dari)))
+}
+
+func periksaAlasanPokin(aturan transisiPokin, alasan string) error {
+	if aturan.WajibAlasan && strings.TrimSpace(alasan) == "" {
+		return web.NewBadRequestError(fmt.Sprintf("alasan wajib diisi untuk aksi %s", aturan.Aksi))
+	}
+	return nil
+}
+
+// aksiTersediaPokin transisi dari status tertentu yang boleh dilakukan pemilik roles
+func aksiTersediaPokin(status string, roles []string) []pohonkinerja.PokinAturanTransisiResponse {
+	result := []pohonkinerja.PokinAturanTransisiResponse{}
+	for _, aturan := range aturanTransisiPokin {
+		if aturan.Dari == status && helper.HasAnyRole(web.JWTClaim{Roles: roles}, aturan.Roles...) {
+			result = append(result, toAturanTransisiResponse(aturan))
+		}
+	}
+	return result
+}
+
+func toAturanTransisiResponse(aturan transisiPokin) pohonkinerja.PokinAturanTransisiResponse {
+	return pohonkinerja.PokinAturanTransisiResponse{
+		Aksi:        aturan.Aksi,
+		DariStatus:  aturan.Dari,
+		KeStatus:    aturan.Ke,
+		Roles:       aturan.Roles,
+		WajibAlasan: aturan.WajibAlasan,
+	}
+}
+
+// rolePelaku role user yang memberi izin transisi, dicatat di riwayat
+func rolePelaku(claims web.JWTClaim, roles []string) string {
+	for _, role := range roles {
+		if helper.HasAnyRole(claims, role) {
+			return role
+		}
+	}
+	return ""
+}
+
+func toTransisiResponse(transisi domain.PohonKinerjaTransisi) pohonkinerja.PokinTransisiResponse {
+	return pohonkinerja.PokinTransisiResponse{
+		Id:             transisi.Id,
+		PohonKinerjaId: transisi.PohonKinerjaId,
+		DariStatus:     transisi.DariStatus,
+		KeStatus:       transisi.KeStatus,
+		Aksi:           transisi.Aksi,
+		Alasan:         transisi.Alasan,
+		ActorNip:       transisi.ActorNip,
+		ActorRole:      transisi.ActorRole,
+		ActorKodeOpd:   transisi.ActorKodeOpd,
+		CreatedAt:      transisi.CreatedAt,
+	}
+}
+
+func (service *PokinWorkflowServiceImpl) TransisiTx(ctx context.Context, tx *sql.Tx, pohonKinerjaId int, aksi string, alasan string) (domain.PohonKinerjaTransisi, error) {
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok {
+		return domain.PohonKinerjaTransisi{}, web.NewUnauthorizedError("transisi pohon kinerja memerlukan login")
+	}
+
+	pokin, err := service.findPohonKinerja(ctx, tx, pohonKinerjaId)
+	if err != nil {
+		return domain.PohonKinerjaTransisi{}, err
+	}
+
+	aturan, err := periksaTransisiPokin(pokin.Status, aksi, claims.Roles, alasan)
+	if err != nil {
+		return domain.PohonKinerjaTransisi{}, err
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, pokin.KodeOpd); err != nil {
+		return domain.PohonKinerjaTransisi{}, err
+	}
+
+	return service.simpanTransisi(ctx, tx, aturan, domain.PohonKinerjaTransisi{
+		PohonKinerjaId: pohonKinerjaId,
+		Alasan:         strings.TrimSpace(alasan),
+		ActorNip:       claims.Nip,
+		ActorRole:      rolePelaku(claims, aturan.Roles),
+		ActorKodeOpd:   claims.KodeOpd,
+	})
+}
+
+func (service *PokinWorkflowServiceImpl) TransisiSistemTx(ctx context.Context, tx *sql.Tx, pohonKinerjaId int, aksi string, alasan string) (domain.PohonKinerjaTransisi, error) {
+	pokin, err := service.findPohonKinerja(ctx, tx, pohonKinerjaId)
+	if err != nil {
+		return domain.PohonKinerjaTransisi{}, err
+	}
+
+	aturan, err := cariTransisiPokin(pokin.Status, aksi)
+	if err != nil {
+		return domain.PohonKinerjaTransisi{}, err
+	}
+	if err := periksaAlasanPokin(aturan, alasan); err != nil {
+		return domain.PohonKinerjaTransisi{}, err
+	}
+
+	// pengguna yang memicu tetap dicatat, role-nya tidak ikut menentukan izin
+	claims, _ := helper.GetUserClaims(ctx)
+	return service.simpanTransisi(ctx, tx, aturan, domain.PohonKinerjaTransisi{
+		PohonKinerjaId: pohonKinerjaId,
+		Alasan:         strings.TrimSpace(alasan),
+		ActorNip:       claims.Nip,
+		ActorRole:      ActorRoleSistem,
+		ActorKodeOpd:   claims.KodeOpd,
+	})
+}
+
+func (service *PokinWorkflowServiceImpl) findPohonKinerja(ctx context.Context, tx *sql.Tx, pohonKinerjaId int) (domain.PohonKinerja, error) {
+	pokin, err := service.PohonKinerjaTransisiRepository.FindPohonKinerja(ctx, tx, pohonKinerjaId)
+	if err == sql.ErrNoRows {
+		return pokin, web.NewNotFoundError(fmt.Sprintf("pohon kinerja %d tidak ditemukan", pohonKinerjaId))
+	}
+	return pokin, err
+}
+
+// simpanTransisi mengubah status dengan cek status lama lalu mencatat riwayatnya
+func (service *PokinWorkflowServiceImpl) simpanTransisi(ctx context.Context, tx *sql.Tx, aturan transisiPokin, transisi domain.PohonKinerjaTransisi) (domain.PohonKinerjaTransisi, error) {
+	berubah, err := service.PohonKinerjaTransisiRepository.UpdateStatus(ctx, tx, transisi.PohonKinerjaId, aturan.Dari, aturan.Ke)
+	if err != nil {
+		return domain.PohonKinerjaTransisi{}, err
+	}
+	if !berubah {
+		return domain.PohonKinerjaTransisi{}, web.NewConflictError("status pohon kinerja sudah diubah pengguna lain, muat ulang data")
+	}
+
+	transisi.DariStatus = aturan.Dari
+	transisi.KeStatus = aturan.Ke
+	transisi.Aksi = aturan.Aksi
+	return service.PohonKinerjaTransisiRepository.Create(ctx, tx, transisi)
+}
+
+func (service *PokinWorkflowServiceImpl) Transisi(ctx context.Context, request pohonkinerja.PokinTransisiRequest) (pohonkinerja.PokinTransisiResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return pohonkinerja.PokinTransisiResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return pohonkinerja.PokinTransisiResponse{}, err
+	}
+	// rollback eksplisit: status yang sudah berubah tidak boleh tersimpan tanpa riwayatnya
+	defer tx.Rollback()
+
+	transisi, err := service.TransisiTx(ctx, tx, request.PohonKinerjaId, request.Aksi, request.Alasan)
+	if err != nil {
+		return pohonkinerja.PokinTransisiResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return pohonkinerja.PokinTransisiResponse{}, err
+	}
+	return toTransisiResponse(transisi), nil
+}
+
+func (service *PokinWorkflowServiceImpl) FindWorkflow(ctx context.Context, pohonKinerjaId int) (pohonkinerja.PokinWorkflowResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return pohonkinerja.PokinWorkflowResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	pokin, err := service.PohonKinerjaTransisiRepository.FindPohonKinerja(ctx, tx, pohonKinerjaId)
+	if err == sql.ErrNoRows {
+		return pohonkinerja.PokinWorkflowResponse{}, web.NewNotFoundError(fmt.Sprintf("pohon kinerja %d tidak ditemukan", pohonKinerjaId))
+	}
+	if err != nil {
+		return pohonkinerja.PokinWorkflowResponse{}, err
+	}
+	// pokin pemda (tanpa kode_opd) boleh dilihat semua user
+	if pokin.KodeOpd != "" {
+		if err := service.OpdGuardService.CheckRead(ctx, pokin.KodeOpd, pokin.Tahun); err != nil {
+			return pohonkinerja.PokinWorkflowResponse{}, err
+		}
+	}
+
+	riwayat, err := service.PohonKinerjaTransisiRepository.FindByPohonKinerjaId(ctx, tx, pohonKinerjaId)
+	if err != nil {
+		return pohonkinerja.PokinWorkflowResponse{}, err
+	}
+
+	claims, _ := helper.GetUserClaims(ctx)
+	response := pohonkinerja.PokinWorkflowResponse{
+		PohonKinerjaId: pokin.Id,
+		Status:         pokin.Status,
+		AksiTersedia:   aksiTersediaPokin(pokin.Status, claims.Roles),
+		Riwayat:        []pohonkinerja.PokinTransisiResponse{},
+	}
+	for _, transisi := range riwayat {
+		response.Riwayat = append(response.Riwayat, toTransisiResponse(transisi))
+	}
+	return response, nil
+}
+
+func (service *PokinWorkflowServiceImpl) FindAturan() []pohonkinerja.PokinAturanTransisiResponse {
+	var result []pohonkinerja.PokinAturanTransisiResponse
+	for _, aturan := range aturanTransisiPokin {
+		result = append(result, toAturanTransisiResponse(aturan))
+	}
+	return result
+}
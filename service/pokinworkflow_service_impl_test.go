@@ -0,0 +1,76 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPeriksaTransisiPokin(t *testing.T) {
+	superAdmin := []string{helper.RoleSuperAdmin}
+	adminOpd := []string{helper.RoleAdminOpd}
+
+	tests := []struct {
+		name     string
+		dari     string
+		aksi     string
+		roles    []string
+		alasan   string
+		expected string
+		code     int
+	}{
+		{name: "ajukan pokin opd", dari: domain.StatusPokinDraft, aksi: AksiPokinAjukan, roles: adminOpd, expected: domain.StatusPokinMenungguDisetujui},
+		{name: "ajukan ulang setelah ditolak", dari: domain.StatusPokinDitolak, aksi: AksiPokinAjukan, roles: adminOpd, expected: domain.StatusPokinMenungguDisetujui},
+		{name: "setujui", dari: domain.StatusPokinMenungguDisetujui, aksi: AksiPokinSetujui, roles: superAdmin, expected: domain.StatusPokinDisetujui},
+		{name: "tolak dengan alasan", dari: domain.StatusPokinMenungguDisetujui, aksi: AksiPokinTolak, roles: superAdmin, alasan: "indikator belum terukur", expected: domain.StatusPokinDitolak},
+		{name: "crosscutting ditolak diajukan ulang", dari: domain.StatusPokinCrosscuttingDitolak, aksi: AksiPokinAjukan, roles: adminOpd, expected: domain.StatusPokinCrosscuttingMenunggu},
+		{name: "tolak tanpa alasan", dari: domain.StatusPokinMenungguDisetujui, aksi: AksiPokinTolak, roles: superAdmin, alasan: "  ", code: http.StatusBadRequest},
+		{name: "admin opd tidak boleh menyetujui", dari: domain.StatusPokinMenungguDisetujui, aksi: AksiPokinSetujui, roles: adminOpd, code: http.StatusForbidden},
+		{name: "tolak pokin yang sudah disetujui", dari: domain.StatusPokinDisetujui, aksi: AksiPokinTolak, roles: superAdmin, alasan: "x", code: http.StatusConflict},
+		{name: "pokin dari pemda tidak punya transisi", dari: domain.StatusPokinDariPemda, aksi: AksiPokinAjukan, roles: superAdmin, code: http.StatusConflict},
+		{name: "aksi tidak dikenal", dari: domain.StatusPokinDraft, aksi: "hapus", roles: superAdmin, code: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aturan, err := periksaTransisiPokin(tt.dari, tt.aksi, tt.roles, tt.alasan)
+			if tt.code == 0 {
+				if err != nil || aturan.Ke != tt.expected {
+					t.Errorf("periksaTransisiPokin() = %q, %v, expected %q", aturan.Ke, err, tt.expected)
+				}
+				return
+			}
+			var customErr *web.CustomError
+			if !errors.As(err, &customErr) || customErr.Code != tt.code {
+				t.Errorf("periksaTransisiPokin() error = %v, expected code %d", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestAksiTersediaPokin(t *testing.T) {
+	aksi := aksiTersediaPokin(domain.StatusPokinMenungguDisetujui, []string{helper.RoleSuperAdmin})
+	if len(aksi) != 2 || aksi[0].Aksi != AksiPokinSetujui || aksi[1].Aksi != AksiPokinTolak || !aksi[1].WajibAlasan {
+		t.Errorf("aksiTersediaPokin(super_admin) = %+v", aksi)
+	}
+	if aksi := aksiTersediaPokin(domain.StatusPokinMenungguDisetujui, []string{helper.RoleAdminOpd}); len(aksi) != 0 {
+		t.Errorf("aksiTersediaPokin(admin_opd) = %+v, expected kosong", aksi)
+	}
+}
+
+func TestCariTransisiPokinTanpaRole(t *testing.T) {
+	// transisi ikutan (hapus clone, persetujuan crosscutting) tidak bergantung role pemicu
+	aturan, err := cariTransisiPokin(domain.StatusPokinDisetujui, AksiPokinBatalkanPersetujuan)
+	if err != nil || aturan.Ke != domain.StatusPokinMenungguDisetujui {
+		t.Errorf("cariTransisiPokin(batalkan_persetujuan) = %q, %v", aturan.Ke, err)
+	}
+	if _, err := periksaTransisiPokin(domain.StatusPokinDisetujui, AksiPokinBatalkanPersetujuan, []string{helper.RoleLevel1}, ""); err == nil {
+		t.Error("periksaTransisiPokin() level_1 harus ditolak pada transisi langsung")
+	}
+	if _, err := cariTransisiPokin(domain.StatusPokinDraft, AksiPokinBatalkanPersetujuan); err == nil {
+		t.Error("cariTransisiPokin() dari draft harus ditolak")
+	}
+}
@@ -369,12 +369,13 @@ func (service *ProgramServiceImpl) FindAll(ctx context.Context) ([]programkegiat
 
 		// Membuat response untuk program dengan indikator dan targetnya
 		programResponse := programkegiatan.ProgramKegiatanResponse{
-			Id:          program.Id,
-			KodeProgram: program.KodeProgram,
-			NamaProgram: program.NamaProgram,
-			Tahun:       program.Tahun,
-			IsActive:    program.IsActive,
-			Indikator:   indikatorResponses,
+			Id:           program.Id,
+			KodeProgram:  program.KodeProgram,
+			NamaProgram:  program.NamaProgram,
+			Tahun:        program.Tahun,
+			IsActive:     program.IsActive,
+			IsDeprecated: program.IsDeprecated,
+			Indikator:    indikatorResponses,
 		}
 		programResponses = append(programResponses, programResponse)
 	}
@@ -0,0 +1,15 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/rencanaaksi"
+)
+
+type ProgresPelaksanaanService interface {
+	// Simpan laporan progres bulanan satu pelaksanaan rencana aksi, laporan sebelumnya ditimpa
+	Simpan(ctx context.Context, request rencanaaksi.ProgresPelaksanaanRequest) (rencanaaksi.ProgresPelaksanaanResponse, error)
+	Delete(ctx context.Context, pelaksanaanRencanaAksiId string) error
+	// KurvaS rencana vs realisasi kumulatif per bulan, bulan 0 berarti bulan acuan otomatis
+	KurvaS(ctx context.Context, rencanaKinerjaId string, bulan int) (rencanaaksi.KurvaSResponse, error)
+	FindByOpd(ctx context.Context, kodeOpd string, tahun string, bulan int) (rencanaaksi.ProgresOpdResponse, error)
+}
@@ -0,0 +1,352 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/rencanaaksi"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"math"
+	"strconv"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// batasDeviasiTerlambatPersen selisih realisasi kumulatif di bawah rencana, dalam persen total bobot,
+// sebelum dianggap terlambat. Persen dipakai karena total bobot tiap rekin bisa berbeda
+const batasDeviasiTerlambatPersen = 5.0
+
+type ProgresPelaksanaanServiceImpl struct {
+	ProgresPelaksanaanRepository     repository.ProgresPelaksanaanRepository
+	PelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository
+	RencanaKinerjaRepository         repository.RencanaKinerjaRepository
+	OpdGuardService                  OpdGuardService
+	LockDataService                  LockDataService
+	DB                               *sql.DB
+	Validate                         *validator.Validate
+}
+
+func NewProgresPelaksanaanServiceImpl(
+	progresPelaksanaanRepository repository.ProgresPelaksanaanRepository,
+	pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository,
+	rencanaKinerjaRepository repository.RencanaKinerjaRepository,
+	opdGuardService OpdGuardService,
+	lockDataService LockDataService,
+	DB *sql.DB,
+	validate *validator.Validate,
+) *ProgresPelaksanaanServiceImpl {
+	return &ProgresPelaksanaanServiceImpl{
+		ProgresPelaksanaanRepository:     progresPelaksanaanRepository,
+		PelaksanaanRencanaAksiRepository: pelaksanaanRencanaAksiRepository,
+		RencanaKinerjaRepository:         rencanaKinerjaRepository,
+		OpdGuardService:                  opdGuardService,
+		LockDataService:                  lockDataService,
+		DB:                               DB,
+		Validate:                         validate,
+	}
+}
+
+// checkWritable progres hanya boleh diubah OPD pemilik rekin selama data rencana kinerja tahun itu belum dikunci
+func (service *ProgresPelaksanaanServiceImpl) checkWritable(ctx context.Context, tx *sql.Tx, pelaksanaanRencanaAksiId string) error {
+	rekin, err := service.ProgresPelaksanaanRepository.FindRekinByPelaksanaanId(ctx, tx, pelaksanaanRencanaAksiId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.NewNotFoundError(fmt.Sprintf("pelaksanaan rencana aksi dengan ID %s tidak ditemukan", pelaksanaanRencanaAksiId))
+		}
+		return err
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, rekin.KodeOpd); err != nil {
+		return err
+	}
+	return service.LockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, rekin.KodeOpd, rekin.Tahun)
+}
+
+func (service *ProgresPelaksanaanServiceImpl) Simpan(ctx context.Context, request rencanaaksi.ProgresPelaksanaanRequest) (rencanaaksi.ProgresPelaksanaanResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return rencanaaksi.ProgresPelaksanaanResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return rencanaaksi.ProgresPelaksanaanResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	if err := service.checkWritable(ctx, tx, request.PelaksanaanRencanaAksiId); err != nil {
+		return rencanaaksi.ProgresPelaksanaanResponse{}, err
+	}
+
+	pelaksanaan, err := service.PelaksanaanRencanaAksiRepository.FindById(ctx, tx, request.PelaksanaanRencanaAksiId)
+	if err != nil {
+		return rencanaaksi.ProgresPelaksanaanResponse{}, err
+	}
+
+	progres, err := service.ProgresPelaksanaanRepository.Upsert(ctx, tx, domain.ProgresPelaksanaan{
+		PelaksanaanRencanaAksiId: request.PelaksanaanRencanaAksiId,
+		Persentase:               request.Persentase,
+		Catatan:                  request.Catatan,
+		Kendala:                  request.Kendala,
+		CreatedBy:                realisasiActor(ctx),
+	})
+	if err != nil {
+		return rencanaaksi.ProgresPelaksanaanResponse{}, err
+	}
+
+	return rencanaaksi.ProgresPelaksanaanResponse{
+		PelaksanaanRencanaAksiId: progres.PelaksanaanRencanaAksiId,
+		Bulan:                    pelaksanaan.Bulan,
+		Bobot:                    pelaksanaan.Bobot,
+		Persentase:               &progres.Persentase,
+		BobotRealisasi:           bobotRealisasi(pelaksanaan.Bobot, progres.Persentase),
+		Catatan:                  progres.Catatan,
+		Kendala:                  progres.Kendala,
+		CreatedBy:                progres.CreatedBy,
+		UpdatedAt:                &progres.UpdatedAt,
+	}, nil
+}
+
+func (service *ProgresPelaksanaanServiceImpl) Delete(ctx context.Context, pelaksanaanRencanaAksiId string) error {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	if err := service.checkWritable(ctx, tx, pelaksanaanRencanaAksiId); err != nil {
+		return err
+	}
+
+	return service.ProgresPelaksanaanRepository.DeleteByPelaksanaanId(ctx, tx, pelaksanaanRencanaAksiId)
+}
+
+func (service *ProgresPelaksanaanServiceImpl) KurvaS(ctx context.Context, rencanaKinerjaId string, bulan int) (rencanaaksi.KurvaSResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return rencanaaksi.KurvaSResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rekin, err := service.RencanaKinerjaRepository.FindById(ctx, tx, rencanaKinerjaId, "", "")
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return rencanaaksi.KurvaSResponse{}, web.NewNotFoundError(fmt.Sprintf("rencana kinerja dengan ID %s tidak ditemukan", rencanaKinerjaId))
+		}
+		return rencanaaksi.KurvaSResponse{}, err
+	}
+	if err := service.OpdGuardService.CheckRead(ctx, rekin.KodeOpd, rekin.Tahun); err != nil {
+		return rencanaaksi.KurvaSResponse{}, err
+	}
+
+	rows, err := service.ProgresPelaksanaanRepository.FindBobotBulananByRekinIds(ctx, tx, []string{rencanaKinerjaId})
+	if err != nil {
+		return rencanaaksi.KurvaSResponse{}, err
+	}
+
+	acuan := bulanAcuan(rekin.Tahun, bulan, time.Now())
+	kurva := hitungKurvaS(rows, acuan)
+	ringkasan := ringkasKurvaS(kurva, acuan)
+
+	response := rencanaaksi.KurvaSResponse{
+		RencanaKinerjaId:   rekin.Id,
+		NamaRencanaKinerja: rekin.NamaRencanaKinerja,
+		Tahun:              rekin.Tahun,
+		BulanAcuan:         acuan,
+		TotalBobot:         totalBobot(rows),
+		RencanaKumulatif:   ringkasan.RencanaKumulatif,
+		RealisasiKumulatif: ringkasan.RealisasiKumulatif,
+		Deviasi:            ringkasan.Deviasi,
+		Terlambat:          terlambat(ringkasan.Deviasi, totalBobot(rows)),
+		Kurva:              kurva,
+		RencanaAksi:        []rencanaaksi.RencanaAksiProgresResponse{},
+	}
+
+	// kelompokkan pelaksanaan per rencana aksi, urutan mengikuti query
+	indexRenaksi := make(map[string]int)
+	for _, row := range rows {
+		idx, ok := indexRenaksi[row.RencanaAksiId]
+		if !ok {
+			idx = len(response.RencanaAksi)
+			indexRenaksi[row.RencanaAksiId] = idx
+			response.RencanaAksi = append(response.RencanaAksi, rencanaaksi.RencanaAksiProgresResponse{
+				RencanaAksiId:   row.RencanaAksiId,
+				NamaRencanaAksi: row.NamaRencanaAksi,
+			})
+		}
+
+		progres := rencanaaksi.ProgresPelaksanaanResponse{
+			PelaksanaanRencanaAksiId: row.PelaksanaanRencanaAksiId,
+			Bulan:                    row.Bulan,
+			Bobot:                    row.Bobot,
+			Catatan:                  row.Catatan,
+			Kendala:                  row.Kendala,
+		}
+		if row.Persentase.Valid {
+			persentase := row.Persentase.Float64
+			progres.Persentase = &persentase
+			progres.BobotRealisasi = bobotRealisasi(row.Bobot, persentase)
+		}
+		response.RencanaAksi[idx].Pelaksanaan = append(response.RencanaAksi[idx].Pelaksanaan, progres)
+	}
+
+	return response, nil
+}
+
+func (service *ProgresPelaksanaanServiceImpl) FindByOpd(ctx context.Context, kodeOpd string, tahun string, bulan int) (rencanaaksi.ProgresOpdResponse, error) {
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return rencanaaksi.ProgresOpdResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return rencanaaksi.ProgresOpdResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rekins, err := service.RencanaKinerjaRepository.GetByKodeOpdAndTahun(ctx, tx, kodeOpd, tahun)
+	if err != nil {
+		return rencanaaksi.ProgresOpdResponse{}, err
+	}
+
+	acuan := bulanAcuan(tahun, bulan, time.Now())
+	response := rencanaaksi.ProgresOpdResponse{
+		KodeOpd:        kodeOpd,
+		Tahun:          tahun,
+		BulanAcuan:     acuan,
+		RencanaKinerja: []rencanaaksi.ProgresRekinOpdResponse{},
+	}
+	if len(rekins) == 0 {
+		return response, nil
+	}
+
+	rekinIds := make([]string, 0, len(rekins))
+	for _, rekin := range rekins {
+		rekinIds = append(rekinIds, rekin.Id)
+	}
+	rows, err := service.ProgresPelaksanaanRepository.FindBobotBulananByRekinIds(ctx, tx, rekinIds)
+	if err != nil {
+		return rencanaaksi.ProgresOpdResponse{}, err
+	}
+	rowsByRekin := make(map[string][]domain.BobotBulanan)
+	for _, row := range rows {
+		rowsByRekin[row.RencanaKinerjaId] = append(rowsByRekin[row.RencanaKinerjaId], row)
+	}
+
+	for _, rekin := range rekins {
+		rekinRows := rowsByRekin[rekin.Id]
+		// rencana kinerja tanpa rencana aksi belum punya jadwal untuk dibandingkan
+		if len(rekinRows) == 0 {
+			continue
+		}
+
+		ringkasan := ringkasKurvaS(hitungKurvaS(rekinRows, acuan), acuan)
+		item := rencanaaksi.ProgresRekinOpdResponse{
+			RencanaKinerjaId:   rekin.Id,
+			NamaRencanaKinerja: rekin.NamaRencanaKinerja,
+			PegawaiId:          rekin.PegawaiId,
+			TotalBobot:         totalBobot(rekinRows),
+			RencanaKumulatif:   ringkasan.RencanaKumulatif,
+			RealisasiKumulatif: ringkasan.RealisasiKumulatif,
+			Deviasi:            ringkasan.Deviasi,
+			Terlambat:          terlambat(ringkasan.Deviasi, totalBobot(rekinRows)),
+		}
+		for _, row := range rekinRows {
+			if row.Kendala != "" && row.Bulan <= acuan {
+				item.Kendala = append(item.Kendala, fmt.Sprintf("Bulan %d: %s", row.Bulan, row.Kendala))
+			}
+		}
+
+		response.RencanaKinerja = append(response.RencanaKinerja, item)
+		if item.Terlambat {
+			response.JumlahTerlambat++
+		}
+	}
+	response.JumlahRekin = len(response.RencanaKinerja)
+
+	return response, nil
+}
+
+// bulanAcuan bulan pembanding kurva S: bulan yang diminta, bulan berjalan untuk tahun ini,
+// 12 untuk tahun yang sudah lewat dan 0 untuk tahun yang belum dimulai
+func bulanAcuan(tahun string, bulan int, now time.Time) int {
+	if bulan >= 1 && bulan <= 12 {
+		return bulan
+	}
+	tahunInt, err := strconv.Atoi(tahun)
+	if err != nil {
+		return int(now.Month())
+	}
+	switch {
+	case tahunInt < now.Year():
+		return 12
+	case tahunInt > now.Year():
+		return 0
+	}
+	return int(now.Month())
+}
+
+// hitungKurvaS menyusun rencana dan realisasi bobot per bulan beserta kumulatifnya,
+// realisasi setelah bulan acuan tetap ditampilkan tapi tidak dihitung dalam deviasi ringkasan
+func hitungKurvaS(rows []domain.BobotBulanan, acuan int) []rencanaaksi.KurvaSBulanResponse {
+	var rencana, realisasi [13]float64
+	for _, row := range rows {
+		if row.Bulan < 1 || row.Bulan > 12 {
+			continue
+		}
+		rencana[row.Bulan] += float64(row.Bobot)
+		if row.Persentase.Valid {
+			realisasi[row.Bulan] += float64(row.Bobot) * row.Persentase.Float64 / 100
+		}
+	}
+
+	kurva := make([]rencanaaksi.KurvaSBulanResponse, 0, 12)
+	var rencanaKumulatif, realisasiKumulatif float64
+	for bulan := 1; bulan <= 12; bulan++ {
+		rencanaKumulatif += rencana[bulan]
+		realisasiKumulatif += realisasi[bulan]
+		kurva = append(kurva, rencanaaksi.KurvaSBulanResponse{
+			Bulan:              bulan,
+			Rencana:            bulatkan(rencana[bulan]),
+			Realisasi:          bulatkan(realisasi[bulan]),
+			RencanaKumulatif:   bulatkan(rencanaKumulatif),
+			RealisasiKumulatif: bulatkan(realisasiKumulatif),
+			Deviasi:            bulatkan(realisasiKumulatif - rencanaKumulatif),
+		})
+	}
+	return kurva
+}
+
+// ringkasKurvaS posisi kurva pada bulan acuan
+func ringkasKurvaS(kurva []rencanaaksi.KurvaSBulanResponse, acuan int) rencanaaksi.KurvaSBulanResponse {
+	if acuan < 1 || acuan > len(kurva) {
+		return rencanaaksi.KurvaSBulanResponse{Bulan: acuan}
+	}
+	return kurva[acuan-1]
+}
+
+// terlambat deviasi negatif melebihi batas persen dari total bobot rekin
+func terlambat(deviasi float64, total int) bool {
+	if total <= 0 {
+		return false
+	}
+	return deviasi < -float64(total)*batasDeviasiTerlambatPersen/100
+}
+
+func totalBobot(rows []domain.BobotBulanan) int {
+	total := 0
+	for _, row := range rows {
+		total += row.Bobot
+	}
+	return total
+}
+
+func bobotRealisasi(bobot int, persentase float64) float64 {
+	return bulatkan(float64(bobot) * persentase / 100)
+}
+
+func bulatkan(nilai float64) float64 {
+	return math.Round(nilai*100) / 100
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"testing"
+	"time"
+)
+
+func TestBulanAcuan(t *testing.T) {
+	now := time.Date(2026, time.October, 18, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name     string
+		tahun    string
+		bulan    int
+		expected int
+	}{
+		{name: "bulan diminta", tahun: "2026", bulan: 3, expected: 3},
+		{name: "tahun berjalan", tahun: "2026", expected: 10},
+		{name: "tahun lalu", tahun: "2025", expected: 12},
+		{name: "tahun depan", tahun: "2027", expected: 0},
+		{name: "bulan tidak valid diabaikan", tahun: "2025", bulan: 13, expected: 12},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := bulanAcuan(tt.tahun, tt.bulan, now)
+			if result != tt.expected {
+				t.Errorf("bulanAcuan() = %d, expected %d", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestHitungKurvaS(t *testing.T) {
+	rows := []domain.BobotBulanan{
+		{RencanaAksiId: "RA-1", Bulan: 1, Bobot: 20, Persentase: sql.NullFloat64{Float64: 100, Valid: true}},
+		{RencanaAksiId: "RA-1", Bulan: 2, Bobot: 30, Persentase: sql.NullFloat64{Float64: 50, Valid: true}},
+		{RencanaAksiId: "RA-2", Bulan: 2, Bobot: 10},
+		{RencanaAksiId: "RA-2", Bulan: 6, Bobot: 40, Persentase: sql.NullFloat64{Float64: 25, Valid: true}},
+	}
+
+	kurva := hitungKurvaS(rows, 2)
+	if len(kurva) != 12 {
+		t.Fatalf("hitungKurvaS() menghasilkan %d bulan, expected 12", len(kurva))
+	}
+
+	tests := []struct {
+		bulan              int
+		rencanaKumulatif   float64
+		realisasiKumulatif float64
+		deviasi            float64
+	}{
+		{bulan: 1, rencanaKumulatif: 20, realisasiKumulatif: 20, deviasi: 0},
+		{bulan: 2, rencanaKumulatif: 60, realisasiKumulatif: 35, deviasi: -25},
+		{bulan: 6, rencanaKumulatif: 100, realisasiKumulatif: 45, deviasi: -55},
+		{bulan: 12, rencanaKumulatif: 100, realisasiKumulatif: 45, deviasi: -55},
+	}
+	for _, tt := range tests {
+		item := kurva[tt.bulan-1]
+		if item.RencanaKumulatif != tt.rencanaKumulatif || item.RealisasiKumulatif != tt.realisasiKumulatif || item.Deviasi != tt.deviasi {
+			t.Errorf("bulan %d = (%v, %v, %v), expected (%v, %v, %v)", tt.bulan,
+				item.RencanaKumulatif, item.RealisasiKumulatif, item.Deviasi,
+				tt.rencanaKumulatif, tt.realisasiKumulatif, tt.deviasi)
+		}
+	}
+
+	ringkasan := ringkasKurvaS(kurva, 2)
+	if ringkasan.Deviasi != -25 || !terlambat(ringkasan.Deviasi, totalBobot(rows)) {
+		t.Errorf("ringkasKurvaS() deviasi = %v, expected -25 dan terlambat", ringkasan.Deviasi)
+	}
+	if ringkasan := ringkasKurvaS(kurva, 0); ringkasan.RencanaKumulatif != 0 {
+		t.Errorf("ringkasKurvaS() bulan 0 = %v, expected kosong", ringkasan.RencanaKumulatif)
+	}
+}
+
+func TestTerlambat(t *testing.T) {
+	tests := []struct {
+		name     string
+		deviasi  float64
+		total    int
+		expected bool
+	}{
+		{name: "dalam batas 5 persen total bobot", deviasi: -4, total: 100, expected: false},
+		{name: "melewati batas 5 persen total bobot", deviasi: -6, total: 100, expected: true},
+		{name: "total bobot kecil memperketat batas", deviasi: -2, total: 20, expected: true},
+		{name: "total bobot besar memperlonggar batas", deviasi: -8, total: 200, expected: false},
+		{name: "tanpa bobot tidak pernah terlambat", deviasi: -10, total: 0, expected: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if result := terlambat(tt.deviasi, tt.total); result != tt.expected {
+				t.Errorf("terlambat(%v, %d) = %v, expected %v", tt.deviasi, tt.total, result, tt.expected)
+			}
+		})
+	}
+}
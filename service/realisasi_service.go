@@ -0,0 +1,41 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web/realisasi"
+)
+
+// jenis periode pengisian realisasi
+const (
+	RealisasiBulanan  = "bulanan"
+	RealisasiTriwulan = "triwulan"
+	RealisasiTahunan  = "tahunan"
+)
+
+// jenis rumus perhitungan realisasi tahunan dari realisasi per periode
+const (
+	// RumusNilaiAkhir realisasi periode paling akhir, dipakai juga jika rumus kosong
+	RumusNilaiAkhir = "nilai_akhir"
+	// RumusKumulatif jumlah realisasi seluruh periode
+	RumusKumulatif = "kumulatif"
+	// RumusRasio rata-rata realisasi periode, untuk indikator berupa rasio/persentase
+	RumusRasio = "rasio"
+	// RumusTidakDidukung rumus tidak dikenali, capaian tidak dihitung
+	RumusTidakDidukung = "tidak_didukung"
+)
+
+type RealisasiService interface {
+	// Create menyimpan realisasi satu periode, periode yang sama akan ditimpa
+	Create(ctx context.Context, request realisasi.RealisasiCreateRequest) (realisasi.RealisasiResponse, error)
+	Update(ctx context.Context, request realisasi.RealisasiUpdateRequest) (realisasi.RealisasiResponse, error)
+	Delete(ctx context.Context, id int64) error
+	// UpdatePolaritas mengatur arah capaian indikator (positif/negatif), ditolak jika realisasi tahun mana pun sudah dikunci
+	UpdatePolaritas(ctx context.Context, request realisasi.PolaritasUpdateRequest) error
+	FindByIndikator(ctx context.Context, indikatorId string, tahun string) (realisasi.CapaianIndikatorResponse, error)
+	FindByRencanaKinerja(ctx context.Context, rencanaKinerjaId string, tahun string) ([]realisasi.CapaianIndikatorResponse, error)
+	// FindIkuOpd capaian IKU OPD yang aktif pada periode renstra yang memuat tahun
+	FindIkuOpd(ctx context.Context, kodeOpd string, tahun string) ([]realisasi.CapaianIndikatorResponse, error)
+	// FindCapaian capaian sekumpulan indikator tanpa cek akses, pemanggil wajib sudah memeriksa akses OPD
+	FindCapaian(ctx context.Context, indikators []domain.IndikatorRealisasi, tahun string) ([]realisasi.CapaianIndikatorResponse, error)
+}
@@ -0,0 +1,494 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/realisasi"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type RealisasiServiceImpl struct {
+	RealisasiRepository repository.RealisasiRepository
+	IkuRepository       repository.IkuRepository
+	PeriodeRepository   repository.PeriodeRepository
+	OpdGuardService     OpdGuardService
+	LockDataService     LockDataService
+	DB                  *sql.DB
+	Validate            *validator.Validate
+}
+
+func NewRealisasiServiceImpl(
+	realisasiRepository repository.RealisasiRepository,
+	ikuRepository repository.IkuRepository,
+	periodeRepository repository.PeriodeRepository,
+	opdGuardService OpdGuardService,
+	lockDataService LockDataService,
+	DB *sql.DB,
+	validate *validator.Validate,
+) *RealisasiServiceImpl {
+	return &RealisasiServiceImpl{
+		RealisasiRepository: realisasiRepository,
+		IkuRepository:       ikuRepository,
+		PeriodeRepository:   periodeRepository,
+		OpdGuardService:     opdGuardService,
+		LockDataService:     lockDataService,
+		DB:                  DB,
+		Validate:            validate,
+	}
+}
+
+// maksimal nomor periode per jenis periode
+var realisasiPeriodeMaks = map[string]int{
+	RealisasiBulanan:  12,
+	RealisasiTriwulan: 4,
+	RealisasiTahunan:  1,
+}
+
+func (service *RealisasiServiceImpl) Create(ctx context.Context, request realisasi.RealisasiCreateRequest) (realisasi.RealisasiResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+
+	if request.JenisPeriode == RealisasiTahunan && request.Periode == 0 {
+		request.Periode = 1
+	}
+	if request.Periode < 1 || request.Periode > realisasiPeriodeMaks[request.JenisPeriode] {
+		return realisasi.RealisasiResponse{}, web.NewBadRequestError(fmt.Sprintf("periode %s harus antara 1 dan %d", request.JenisPeriode, realisasiPeriodeMaks[request.JenisPeriode]))
+	}
+	if _, ok := helper.ParseAngka(request.Realisasi); !ok {
+		return realisasi.RealisasiResponse{}, web.NewBadRequestError("realisasi harus berupa angka")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	indikator, err := service.RealisasiRepository.FindIndikatorById(ctx, tx, request.IndikatorId)
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+	if err := service.checkWritable(ctx, indikator.KodeOpd, request.Tahun); err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+
+	result, err := service.RealisasiRepository.Upsert(ctx, tx, domain.RealisasiIndikator{
+		IndikatorId:  request.IndikatorId,
+		Tahun:        request.Tahun,
+		JenisPeriode: request.JenisPeriode,
+		Periode:      request.Periode,
+		Realisasi:    request.Realisasi,
+		Satuan:       request.Satuan,
+		BuktiDukung:  request.BuktiDukung,
+		Keterangan:   request.Keterangan,
+		CreatedBy:    realisasiActor(ctx),
+	})
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+
+	target, err := service.findTarget(ctx, tx, indikator.Id, result.Tahun)
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+
+	return toRealisasiResponse(result, target.Target, helper.NormalisasiPolaritas(indikator.Polaritas), jenisRumus(indikator.RumusPerhitungan)), nil
+}
+
+func (service *RealisasiServiceImpl) Update(ctx context.Context, request realisasi.RealisasiUpdateRequest) (realisasi.RealisasiResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+	if _, ok := helper.ParseAngka(request.Realisasi); !ok {
+		return realisasi.RealisasiResponse{}, web.NewBadRequestError("realisasi harus berupa angka")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	existing, err := service.RealisasiRepository.FindById(ctx, tx, request.Id)
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+	indikator, err := service.RealisasiRepository.FindIndikatorById(ctx, tx, existing.IndikatorId)
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+	if err := service.checkWritable(ctx, indikator.KodeOpd, existing.Tahun); err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+
+	existing.Realisasi = request.Realisasi
+	existing.Satuan = request.Satuan
+	existing.BuktiDukung = request.BuktiDukung
+	existing.Keterangan = request.Keterangan
+	existing.CreatedBy = realisasiActor(ctx)
+
+	if err := service.RealisasiRepository.Update(ctx, tx, existing); err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+	updated, err := service.RealisasiRepository.FindById(ctx, tx, existing.Id)
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+
+	target, err := service.findTarget(ctx, tx, indikator.Id, updated.Tahun)
+	if err != nil {
+		return realisasi.RealisasiResponse{}, err
+	}
+
+	return toRealisasiResponse(updated, target.Target, helper.NormalisasiPolaritas(indikator.Polaritas), jenisRumus(indikator.RumusPerhitungan)), nil
+}
+
+func (service *RealisasiServiceImpl) Delete(ctx context.Context, id int64) error {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	existing, err := service.RealisasiRepository.FindById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	indikator, err := service.RealisasiRepository.FindIndikatorById(ctx, tx, existing.IndikatorId)
+	if err != nil {
+		return err
+	}
+	if err := service.checkWritable(ctx, indikator.KodeOpd, existing.Tahun); err != nil {
+		return err
+	}
+
+	return service.RealisasiRepository.Delete(ctx, tx, id)
+}
+
+func (service *RealisasiServiceImpl) UpdatePolaritas(ctx context.Context, request realisasi.PolaritasUpdateRequest) error {
+	if err := service.Validate.Struct(request); err != nil {
+		return err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	indikator, err := service.RealisasiRepository.FindIndikatorById(ctx, tx, request.IndikatorId)
+	if err != nil {
+		return err
+	}
+	// polaritas mengubah capaian setiap tahun yang sudah berisi realisasi
+	tahuns, err := service.RealisasiRepository.FindTahunByIndikatorId(ctx, tx, indikator.Id)
+	if err != nil {
+		return err
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, indikator.KodeOpd); err != nil {
+		return err
+	}
+	for _, tahun := range tahuns {
+		if err := service.LockDataService.CheckWritable(ctx, LockJenisRealisasi, indikator.KodeOpd, tahun); err != nil {
+			return err
+		}
+	}
+
+	return service.RealisasiRepository.UpdatePolaritas(ctx, tx, indikator.Id, request.Polaritas)
+}
+
+// checkWritable akses tulis OPD dan kunci realisasi pada tahun yang diisi
+func (service *RealisasiServiceImpl) checkWritable(ctx context.Context, kodeOpd string, tahun string) error {
+	if err := service.OpdGuardService.CheckWrite(ctx, kodeOpd); err != nil {
+		return err
+	}
+	return service.LockDataService.CheckWritable(ctx, LockJenisRealisasi, kodeOpd, tahun)
+}
+
+func (service *RealisasiServiceImpl) FindByIndikator(ctx context.Context, indikatorId string, tahun string) (realisasi.CapaianIndikatorResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return realisasi.CapaianIndikatorResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	indikator, err := service.RealisasiRepository.FindIndikatorById(ctx, tx, indikatorId)
+	if err != nil {
+		return realisasi.CapaianIndikatorResponse{}, err
+	}
+	if err := service.OpdGuardService.CheckRead(ctx, indikator.KodeOpd, tahun); err != nil {
+		return realisasi.CapaianIndikatorResponse{}, err
+	}
+
+	responses, err := service.buildCapaian(ctx, tx, []domain.IndikatorRealisasi{indikator}, tahun)
+	if err != nil {
+		return realisasi.CapaianIndikatorResponse{}, err
+	}
+	return responses[0], nil
+}
+
+func (service *RealisasiServiceImpl) FindByRencanaKinerja(ctx context.Context, rencanaKinerjaId string, tahun string) ([]realisasi.CapaianIndikatorResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	indikators, err := service.RealisasiRepository.FindIndikatorByRencanaKinerjaId(ctx, tx, rencanaKinerjaId)
+	if err != nil {
+		return nil, err
+	}
+	if len(indikators) > 0 {
+		if err := service.OpdGuardService.CheckRead(ctx, indikators[0].KodeOpd, tahun); err != nil {
+			return nil, err
+		}
+	}
+
+	return service.buildCapaian(ctx, tx, indikators, tahun)
+}
+
+func (service *RealisasiServiceImpl) FindIkuOpd(ctx context.Context, kodeOpd string, tahun string) ([]realisasi.CapaianIndikatorResponse, error) {
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return nil, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	periode, err := service.PeriodeRepository.FindByTahun(ctx, tx, tahun)
+	if err != nil {
+		return nil, web.NewNotFoundError(fmt.Sprintf("periode untuk tahun %s tidak ditemukan", tahun))
+	}
+
+	ikus, err := service.IkuRepository.FindAllIkuOpd(ctx, tx, kodeOpd, periode.TahunAwal, periode.TahunAkhir, periode.JenisPeriode)
+	if err != nil {
+		return nil, err
+	}
+
+	var indikators []domain.IndikatorRealisasi
+	for _, iku := range ikus {
+		if !iku.IkuActive || iku.Id == "" {
+			continue
+		}
+		indikators = append(indikators, domain.IndikatorRealisasi{
+			Id:               iku.Id,
+			Indikator:        iku.Indikator,
+			RumusPerhitungan: iku.RumusPerhitungan.String,
+			KodeOpd:          kodeOpd,
+		})
+	}
+
+	return service.buildCapaian(ctx, tx, indikators, tahun)
+}
+
+func (service *RealisasiServiceImpl) FindCapaian(ctx context.Context, indikators []domain.IndikatorRealisasi, tahun string) ([]realisasi.CapaianIndikatorResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	return service.buildCapaian(ctx, tx, indikators, tahun)
+}
+
+// buildCapaian menggabungkan target tahun dan realisasi per periode untuk setiap indikator
+func (service *RealisasiServiceImpl) buildCapaian(ctx context.Context, tx *sql.Tx, indikators []domain.IndikatorRealisasi, tahun string) ([]realisasi.CapaianIndikatorResponse, error) {
+	responses := make([]realisasi.CapaianIndikatorResponse, 0, len(indikators))
+	if len(indikators) == 0 {
+		return responses, nil
+	}
+
+	indikatorIds := make([]string, 0, len(indikators))
+	for _, indikator := range indikators {
+		indikatorIds = append(indikatorIds, indikator.Id)
+	}
+
+	targets, err := service.RealisasiRepository.FindTargetByIndikatorIds(ctx, tx, indikatorIds, tahun)
+	if err != nil {
+		return nil, err
+	}
+	targetMap := make(map[string]domain.Target, len(targets))
+	for _, target := range targets {
+		targetMap[target.IndikatorId] = target
+	}
+
+	realisasis, err := service.RealisasiRepository.FindByIndikatorIds(ctx, tx, indikatorIds, tahun)
+	if err != nil {
+		return nil, err
+	}
+	realisasiMap := make(map[string][]domain.RealisasiIndikator)
+	for _, item := range realisasis {
+		realisasiMap[item.IndikatorId] = append(realisasiMap[item.IndikatorId], item)
+	}
+
+	polaritasMap, err := service.RealisasiRepository.FindPolaritasByIndikatorIds(ctx, tx, indikatorIds)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, indikator := range indikators {
+		target := targetMap[indikator.Id]
+		polaritas := indikator.Polaritas
+		if tersimpan, ok := polaritasMap[indikator.Id]; ok {
+			polaritas = tersimpan
+		}
+		polaritas = helper.NormalisasiPolaritas(polaritas)
+
+		entries := realisasiMap[indikator.Id]
+		sortRealisasi(entries)
+		rumus := jenisRumus(indikator.RumusPerhitungan)
+
+		response := realisasi.CapaianIndikatorResponse{
+			IndikatorId:      indikator.Id,
+			Indikator:        indikator.Indikator,
+			RumusPerhitungan: indikator.RumusPerhitungan,
+			JenisRumus:       rumus,
+			Polaritas:        polaritas,
+			Tahun:            tahun,
+			Target:           target.Target,
+			Satuan:           target.Satuan,
+			Realisasi:        make([]realisasi.RealisasiResponse, 0, len(entries)),
+		}
+		for _, entry := range entries {
+			response.Realisasi = append(response.Realisasi, toRealisasiResponse(entry, target.Target, polaritas, rumus))
+		}
+
+		if len(entries) > 0 {
+			response.RealisasiTerakhir = entries[len(entries)-1].Realisasi
+			if nilai, ok := realisasiTahun(entries, rumus); ok {
+				response.RealisasiTahun = helper.FormatAngka(nilai)
+				if targetAngka, ok := helper.ParseAngka(target.Target); ok {
+					if capaian, ok := helper.HitungCapaian(targetAngka, nilai, polaritas); ok {
+						response.Capaian = &capaian
+					}
+				}
+			}
+		}
+
+		responses = append(responses, response)
+	}
+
+	return responses, nil
+}
+
+func (service *RealisasiServiceImpl) findTarget(ctx context.Context, tx *sql.Tx, indikatorId string, tahun string) (domain.Target, error) {
+	targets, err := service.RealisasiRepository.FindTargetByIndikatorIds(ctx, tx, []string{indikatorId}, tahun)
+	if err != nil || len(targets) == 0 {
+		return domain.Target{}, err
+	}
+	return targets[0], nil
+}
+
+// jenisRumus mengenali jenis rumus dari teks rumus perhitungan indikator
+func jenisRumus(rumusPerhitungan string) string {
+	rumus := strings.ToLower(strings.TrimSpace(rumusPerhitungan))
+	switch {
+	case rumus == "":
+		return RumusNilaiAkhir
+	// rumus rasio sering memuat kata "jumlah" pada pembilang/penyebut, periksa lebih dulu
+	case strings.Contains(rumus, "rasio"), strings.Contains(rumus, "persentase"), strings.Contains(rumus, "rata-rata"),
+		strings.Contains(rumus, "%"), strings.Contains(rumus, "/"):
+		return RumusRasio
+	case strings.Contains(rumus, "kumulatif"), strings.Contains(rumus, "akumulasi"), strings.Contains(rumus, "jumlah"),
+		strings.Contains(rumus, "total"):
+		return RumusKumulatif
+	case strings.Contains(rumus, "terakhir"), strings.Contains(rumus, "akhir"), strings.Contains(rumus, "posisi"):
+		return RumusNilaiAkhir
+	}
+	return RumusTidakDidukung
+}
+
+// realisasiTahun realisasi satu tahun menurut jenis rumus, entries harus sudah diurutkan.
+// Kumulatif dan rasio hanya memakai periode sejenis dengan periode paling akhir agar bulan tidak terhitung dua kali.
+func realisasiTahun(entries []domain.RealisasiIndikator, rumus string) (float64, bool) {
+	if len(entries) == 0 || rumus == RumusTidakDidukung {
+		return 0, false
+	}
+
+	terakhir := entries[len(entries)-1]
+	if rumus == RumusNilaiAkhir {
+		return helper.ParseAngka(terakhir.Realisasi)
+	}
+
+	var total float64
+	var jumlah int
+	for _, entry := range entries {
+		if entry.JenisPeriode != terakhir.JenisPeriode {
+			continue
+		}
+		nilai, ok := helper.ParseAngka(entry.Realisasi)
+		if !ok {
+			return 0, false
+		}
+		total += nilai
+		jumlah++
+	}
+	if rumus == RumusRasio {
+		return total / float64(jumlah), true
+	}
+	return total, true
+}
+
+// realisasiBulanAkhir bulan terakhir yang dicakup satu periode, tahunan dianggap paling akhir
+func realisasiBulanAkhir(item domain.RealisasiIndikator) int {
+	switch item.JenisPeriode {
+	case RealisasiTriwulan:
+		return item.Periode * 3
+	case RealisasiTahunan:
+		return 13
+	}
+	return item.Periode
+}
+
+// sortRealisasi mengurutkan realisasi dari periode paling awal ke paling akhir
+func sortRealisasi(items []domain.RealisasiIndikator) {
+	sort.SliceStable(items, func(i, j int) bool {
+		bulanI, bulanJ := realisasiBulanAkhir(items[i]), realisasiBulanAkhir(items[j])
+		if bulanI != bulanJ {
+			return bulanI < bulanJ
+		}
+		// periode sama panjang: bulanan lebih dulu dari triwulan
+		return items[i].JenisPeriode == RealisasiBulanan && items[j].JenisPeriode != RealisasiBulanan
+	})
+}
+
+func realisasiActor(ctx context.Context) string {
+	if claims, ok := helper.GetUserClaims(ctx); ok {
+		return claims.Nip
+	}
+	return ""
+}
+
+func toRealisasiResponse(item domain.RealisasiIndikator, target string, polaritas string, rumus string) realisasi.RealisasiResponse {
+	response := realisasi.RealisasiResponse{
+		Id:           item.Id,
+		IndikatorId:  item.IndikatorId,
+		Tahun:        item.Tahun,
+		JenisPeriode: item.JenisPeriode,
+		Periode:      item.Periode,
+		Realisasi:    item.Realisasi,
+		Satuan:       item.Satuan,
+		Capaian:      helper.CapaianDariTeks(target, item.Realisasi, polaritas),
+		BuktiDukung:  item.BuktiDukung,
+		Keterangan:   item.Keterangan,
+		CreatedBy:    item.CreatedBy,
+		UpdatedAt:    item.UpdatedAt,
+	}
+	// capaian per periode tidak ditebak jika rumus indikator tidak dikenali
+	if rumus == RumusTidakDidukung {
+		response.Capaian = nil
+	}
+	return response
+}
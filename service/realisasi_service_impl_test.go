@@ -0,0 +1,121 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/realisasi"
+	"ekak_kabupaten_madiun/repository"
+	"errors"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+)
+
+func TestRealisasiTahun(t *testing.T) {
+	bulanan := []domain.RealisasiIndikator{
+		{JenisPeriode: RealisasiBulanan, Periode: 1, Realisasi: "10"},
+		{JenisPeriode: RealisasiBulanan, Periode: 2, Realisasi: "20"},
+		{JenisPeriode: RealisasiBulanan, Periode: 3, Realisasi: "30"},
+	}
+	// triwulan I sudah mencakup bulan 1-3, tidak boleh dijumlah dua kali
+	campuran := append([]domain.RealisasiIndikator{{JenisPeriode: RealisasiTriwulan, Periode: 1, Realisasi: "60"}}, bulanan...)
+	campuran = append(campuran, domain.RealisasiIndikator{JenisPeriode: RealisasiTriwulan, Periode: 2, Realisasi: "40"})
+	sortRealisasi(campuran)
+
+	tests := []struct {
+		name      string
+		rumus     string
+		entries   []domain.RealisasiIndikator
+		jenis     string
+		realisasi float64
+		ok        bool
+	}{
+		{name: "rumus kosong memakai nilai akhir", rumus: "", entries: bulanan, jenis: RumusNilaiAkhir, realisasi: 30, ok: true},
+		{name: "kumulatif", rumus: "Akumulasi jumlah dokumen", entries: bulanan, jenis: RumusKumulatif, realisasi: 60, ok: true},
+		{name: "kumulatif hanya periode sejenis", rumus: "Kumulatif", entries: campuran, jenis: RumusKumulatif, realisasi: 100, ok: true},
+		{name: "rasio dirata-rata", rumus: "(jumlah lulus / jumlah peserta) x 100%", entries: bulanan, jenis: RumusRasio, realisasi: 20, ok: true},
+		{name: "nilai akhir", rumus: "Nilai posisi akhir tahun", entries: bulanan, jenis: RumusNilaiAkhir, realisasi: 30, ok: true},
+		{name: "rumus tidak dikenali", rumus: "Indeks komposit BPS", entries: bulanan, jenis: RumusTidakDidukung},
+		{name: "tanpa realisasi", rumus: "Kumulatif", jenis: RumusKumulatif},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			jenis := jenisRumus(tt.rumus)
+			if jenis != tt.jenis {
+				t.Fatalf("jenisRumus(%q) = %s, expected %s", tt.rumus, jenis, tt.jenis)
+			}
+			result, ok := realisasiTahun(tt.entries, jenis)
+			if ok != tt.ok || result != tt.realisasi {
+				t.Errorf("realisasiTahun() = (%v, %v), expected (%v, %v)", result, ok, tt.realisasi, tt.ok)
+			}
+		})
+	}
+}
+
+type realisasiRepositoryPalsu struct {
+	repository.RealisasiRepository
+	tahuns    []string
+	polaritas string
+}
+
+func (repo *realisasiRepositoryPalsu) FindIndikatorById(ctx context.Context, tx *sql.Tx, indikatorId string) (domain.IndikatorRealisasi, error) {
+	return domain.IndikatorRealisasi{Id: indikatorId, KodeOpd: "5.01.5.05.0.00.01.0000"}, nil
+}
+
+func (repo *realisasiRepositoryPalsu) FindTahunByIndikatorId(ctx context.Context, tx *sql.Tx, indikatorId string) ([]string, error) {
+	return repo.tahuns, nil
+}
+
+func (repo *realisasiRepositoryPalsu) UpdatePolaritas(ctx context.Context, tx *sql.Tx, indikatorId string, polaritas string) error {
+	repo.polaritas = polaritas
+	return nil
+}
+
+type opdGuardServicePalsu struct{}
+
+func (opdGuardServicePalsu) CheckWrite(ctx context.Context, kodeOpd string) error { return nil }
+
+func (opdGuardServicePalsu) CheckRead(ctx context.Context, kodeOpd string, tahun string) error {
+	return nil
+}
+
+type lockDataServicePalsu struct {
+	LockDataService
+	terkunci map[string]bool
+}
+
+func (lock lockDataServicePalsu) CheckWritable(ctx context.Context, jenisData, kodeOpd, tahun string) error {
+	if lock.terkunci[jenisData+"/"+tahun] {
+		return web.NewLockedError("terkunci")
+	}
+	return nil
+}
+
+func TestUpdatePolaritasDitolakJikaRealisasiTerkunci(t *testing.T) {
+	db, err := sql.Open("audit_tx_kosong", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer db.Close()
+
+	repo := &realisasiRepositoryPalsu{tahuns: []string{"2025", "2026"}}
+	service := &RealisasiServiceImpl{
+		RealisasiRepository: repo,
+		OpdGuardService:     opdGuardServicePalsu{},
+		LockDataService:     lockDataServicePalsu{terkunci: map[string]bool{LockJenisRealisasi + "/2025": true}},
+		DB:                  db,
+		Validate:            validator.New(),
+	}
+
+	err = service.UpdatePolaritas(context.Background(), realisasi.PolaritasUpdateRequest{IndikatorId: "IND-1", Polaritas: "negatif"})
+	var customErr *web.CustomError
+	if !errors.As(err, &customErr) || customErr.Code != 423 {
+		t.Fatalf("UpdatePolaritas() error = %v, expected locked", err)
+	}
+	if repo.polaritas != "" {
+		t.Errorf("polaritas tetap diubah menjadi %s", repo.polaritas)
+	}
+}
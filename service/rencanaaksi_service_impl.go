@@ -17,18 +17,20 @@ import (
 )
 
 type RencanaAksiServiceImpl struct {
-	rencanaAksiRepository            repository.RencanaAksiRepository
-	DB                               *sql.DB
-	Validate                         *validator.Validate
-	pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository
+	rencanaAksiRepository              repository.RencanaAksiRepository
+	DB                                 *sql.DB
+	Validate                           *validator.Validate
+	pelaksanaanRencanaAksiRepository   repository.PelaksanaanRencanaAksiRepository
+	rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository
 }
 
-func NewRencanaAksiServiceImpl(rencanaAksiRepository repository.RencanaAksiRepository, DB *sql.DB, validate *validator.Validate, pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository) *RencanaAksiServiceImpl {
+func NewRencanaAksiServiceImpl(rencanaAksiRepository repository.RencanaAksiRepository, DB *sql.DB, validate *validator.Validate, pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository) *RencanaAksiServiceImpl {
 	return &RencanaAksiServiceImpl{
-		rencanaAksiRepository:            rencanaAksiRepository,
-		DB:                               DB,
-		Validate:                         validate,
-		pelaksanaanRencanaAksiRepository: pelaksanaanRencanaAksiRepository,
+		rencanaAksiRepository:              rencanaAksiRepository,
+		DB:                                 DB,
+		Validate:                           validate,
+		pelaksanaanRencanaAksiRepository:   pelaksanaanRencanaAksiRepository,
+		rencanaKinerjaVerifikasiRepository: rencanaKinerjaVerifikasiRepository,
 	}
 }
 
@@ -50,6 +52,10 @@ func (service *RencanaAksiServiceImpl) Create(ctx context.Context, request renca
 		return rencanaaksi.RencanaAksiResponse{}, fmt.Errorf("urutan tidak boleh kurang dari atau sama dengan 0")
 	}
 
+	if err := periksaRekinTerkunciById(ctx, tx, service.rencanaKinerjaVerifikasiRepository, request.RencanaKinerjaId); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
+
 	// Buat UUID baru dengan format yang diinginkan
 	uuId := fmt.Sprintf("RENAKSI-REKIN-%s", uuid.New().String()[:5])
 
@@ -104,6 +110,9 @@ func (service *RencanaAksiServiceImpl) Update(ctx context.Context, request renca
 	if err != nil {
 		return rencanaaksi.RencanaAksiResponse{}, fmt.Errorf("rencana aksi dengan ID %s tidak ditemukan", request.Id)
 	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.rencanaKinerjaVerifikasiRepository, existingRencanaAksi.RencanaKinerjaId); err != nil {
+		return rencanaaksi.RencanaAksiResponse{}, err
+	}
 
 	// Update data rencana aksi
 	existingRencanaAksi.Urutan = request.Urutan
@@ -278,13 +287,16 @@ func (service *RencanaAksiServiceImpl) Delete(ctx context.Context, id string) er
 	defer helper.CommitOrRollback(tx)
 
 	// Periksa apakah rencana aksi dengan ID tersebut ada
-	_, err = service.rencanaAksiRepository.FindById(ctx, tx, id)
+	existing, err := service.rencanaAksiRepository.FindById(ctx, tx, id)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("rencana aksi dengan ID %s tidak ditemukan", id)
 		}
 		return fmt.Errorf("gagal memeriksa rencana aksi: %v", err)
 	}
+	if err := periksaRekinTerkunciById(ctx, tx, service.rencanaKinerjaVerifikasiRepository, existing.RencanaKinerjaId); err != nil {
+		return err
+	}
 
 	// Panggil repository untuk menghapus rencana aksi
 	err = service.rencanaAksiRepository.Delete(ctx, tx, id)
@@ -54,10 +54,11 @@ type RencanaKinerjaServiceImpl struct {
 	programRepository        repository.ProgramRepository
 	rincianBelanjaRepository repository.RincianBelanjaRepository
 	rencanaAksiRepository    repository.RencanaAksiRepository
-	cloneRecordRepository    repository.CloneRecordRepository
+	lockDataService          LockDataService
+	auditLogRepository       repository.AuditLogRepository
 }
 
-func NewRencanaKinerjaServiceImpl(rencanaKinerjaRepository repository.RencanaKinerjaRepository, DB *sql.DB, validate *validator.Validate, opdRepository repository.OpdRepository, usulanMusrebangRepository repository.UsulanMusrebangRepository, usulanMandatoriRepository repository.UsulanMandatoriRepository, usulanPokokPikiranRepository repository.UsulanPokokPikiranRepository, usulanInisiatifRepository repository.UsulanInisiatifRepository, subKegiatanRepository repository.SubKegiatanRepository, dasarHukumRepository repository.DasarHukumRepository, gambaranUmumRepository repository.GambaranUmumRepository, inovasiRepository repository.InovasiRepository, pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, pegawaiRepository repository.PegawaiRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, manualIKRepository repository.ManualIKRepository, permasalahanRekinRepository repository.PermasalahanRekinRepository, subKegiatanTerpilihRepository repository.SubKegiatanTerpilihRepository, subKegiatanService *SubKegiatanServiceImpl, periodeRepository repository.PeriodeRepository, sasaranOpdRepository repository.SasaranOpdRepository, cascadingOpdService *CascadingOpdServiceImpl, cascadingOpdRepository repository.CascadingOpdRepository, programRepository repository.ProgramRepository, rincianBelanjaRepository repository.RincianBelanjaRepository, rencanaAksiRepository repository.RencanaAksiRepository, cloneRecordRepository repository.CloneRecordRepository,
+func NewRencanaKinerjaServiceImpl(rencanaKinerjaRepository repository.RencanaKinerjaRepository, DB *sql.DB, validate *validator.Validate, opdRepository repository.OpdRepository, usulanMusrebangRepository repository.UsulanMusrebangRepository, usulanMandatoriRepository repository.UsulanMandatoriRepository, usulanPokokPikiranRepository repository.UsulanPokokPikiranRepository, usulanInisiatifRepository repository.UsulanInisiatifRepository, subKegiatanRepository repository.SubKegiatanRepository, dasarHukumRepository repository.DasarHukumRepository, gambaranUmumRepository repository.GambaranUmumRepository, inovasiRepository repository.InovasiRepository, pelaksanaanRencanaAksiRepository repository.PelaksanaanRencanaAksiRepository, pegawaiRepository repository.PegawaiRepository, pohonKinerjaRepository repository.PohonKinerjaRepository, manualIKRepository repository.ManualIKRepository, permasalahanRekinRepository repository.PermasalahanRekinRepository, subKegiatanTerpilihRepository repository.SubKegiatanTerpilihRepository, subKegiatanService *SubKegiatanServiceImpl, periodeRepository repository.PeriodeRepository, sasaranOpdRepository repository.SasaranOpdRepository, cascadingOpdService *CascadingOpdServiceImpl, cascadingOpdRepository repository.CascadingOpdRepository, programRepository repository.ProgramRepository, rincianBelanjaRepository repository.RincianBelanjaRepository, rencanaAksiRepository repository.RencanaAksiRepository, lockDataService LockDataService, auditLogRepository repository.AuditLogRepository,
 ) *RencanaKinerjaServiceImpl {
 	return &RencanaKinerjaServiceImpl{
 		rencanaKinerjaRepository:         rencanaKinerjaRepository,
@@ -88,10 +89,30 @@ func NewRencanaKinerjaServiceImpl(rencanaKinerjaRepository repository.RencanaKin
 		programRepository:        programRepository,
 		rincianBelanjaRepository: rincianBelanjaRepository,
 		rencanaAksiRepository:    rencanaAksiRepository,
-		cloneRecordRepository:    cloneRecordRepository,
+		lockDataService:          lockDataService,
+		auditLogRepository:       auditLogRepository,
 	}
 }
 
+// auditSnapshot mengambil kondisi rencana kinerja beserta indikator dan target untuk audit log
+func (service *RencanaKinerjaServiceImpl) auditSnapshot(ctx context.Context, tx *sql.Tx, id string) interface{} {
+	rencanaKinerja, err := service.rencanaKinerjaRepository.FindById(ctx, tx, id, "", "")
+	if err != nil {
+		return nil
+	}
+	indikators, err := service.rencanaKinerjaRepository.FindIndikatorbyRekinId(ctx, tx, id)
+	if err == nil {
+		for i, indikator := range indikators {
+			targets, err := service.rencanaKinerjaRepository.FindTargetByIndikatorId(ctx, tx, indikator.Id)
+			if err == nil {
+				indikators[i].Target = targets
+			}
+		}
+		rencanaKinerja.Indikator = indikators
+	}
+	return helper.ToRencanaKinerjaResponse(rencanaKinerja)
+}
+
 func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request rencanakinerja.RencanaKinerjaCreateRequest) (rencanakinerja.RencanaKinerjaResponse, error) {
 	log.Println("Memulai proses Create RencanaKinerja")
 
@@ -101,6 +122,11 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("validasi gagal: %v", err)
 	}
 
+	err = service.lockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, request.KodeOpd, request.Tahun)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		log.Printf("Gagal memulai transaksi: %v", err)
@@ -155,7 +181,7 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 		IdPohon:              request.IdPohon,
 		NamaRencanaKinerja:   request.NamaRencanaKinerja,
 		Tahun:                request.Tahun,
-		StatusRencanaKinerja: request.StatusRencanaKinerja,
+		StatusRencanaKinerja: statusRekinSetelahEdit(domain.StatusRekinDraft, request.StatusRencanaKinerja),
 		Catatan:              request.Catatan,
 		KodeOpd:              request.KodeOpd,
 		PegawaiId:            pegawais.Nip,
@@ -217,6 +243,15 @@ func (service *RencanaKinerjaServiceImpl) Create(ctx context.Context, request re
 	response := helper.ToRencanaKinerjaResponse(rencanaKinerja)
 	log.Printf("Response: %+v", response)
 
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRencanaKinerja,
+		EntityId:   rencanaKinerja.Id,
+		Action:     AuditActionCreate,
+		KodeOpd:    rencanaKinerja.KodeOpd,
+		Tahun:      rencanaKinerja.Tahun,
+		After:      response,
+	})
+
 	return response, nil
 }
 
@@ -229,6 +264,11 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("validasi gagal: %v", err)
 	}
 
+	err = service.lockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, request.KodeOpd, request.Tahun)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		log.Printf("Gagal memulai transaksi: %v", err)
@@ -236,6 +276,8 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 	}
 	defer helper.CommitOrRollback(tx)
 
+	before := service.auditSnapshot(ctx, tx, request.Id)
+
 	// Validasi OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
@@ -283,6 +325,13 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 			log.Printf("Gagal menemukan RencanaKinerja: %v", err)
 			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal menemukan RencanaKinerja: %v", err)
 		}
+		if err := periksaRekinTerkunci(rencanaKinerja.StatusRencanaKinerja); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
+		// kunci dicek pada tahun yang tersimpan, tahun di body hanya tujuan perubahan
+		if err := service.lockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, rencanaKinerja.KodeOpd, rencanaKinerja.Tahun); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
 	} else {
 		randomDigits := fmt.Sprintf("%05d", uuid.New().ID()%100000)
 		rencanaKinerja.Id = fmt.Sprintf("REKIN-PEG-%s", randomDigits)
@@ -292,7 +341,7 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 	rencanaKinerja.IdPohon = request.IdPohon
 	rencanaKinerja.NamaRencanaKinerja = request.NamaRencanaKinerja
 	rencanaKinerja.Tahun = request.Tahun
-	rencanaKinerja.StatusRencanaKinerja = request.StatusRencanaKinerja
+	rencanaKinerja.StatusRencanaKinerja = statusRekinSetelahEdit(rencanaKinerja.StatusRencanaKinerja, request.StatusRencanaKinerja)
 	rencanaKinerja.Catatan = request.Catatan
 	rencanaKinerja.KodeOpd = request.KodeOpd
 	rencanaKinerja.PegawaiId = request.PegawaiId
@@ -354,6 +403,16 @@ func (service *RencanaKinerjaServiceImpl) Update(ctx context.Context, request re
 	response := helper.ToRencanaKinerjaResponse(rencanaKinerja)
 	log.Printf("Response: %+v", response)
 
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRencanaKinerja,
+		EntityId:   rencanaKinerja.Id,
+		Action:     AuditActionUpdate,
+		KodeOpd:    rencanaKinerja.KodeOpd,
+		Tahun:      rencanaKinerja.Tahun,
+		Before:     before,
+		After:      response,
+	})
+
 	return response, nil
 }
 
@@ -729,8 +788,31 @@ func (service *RencanaKinerjaServiceImpl) Delete(ctx context.Context, id string)
 	if err != nil {
 		return err
 	}
+	if err := periksaRekinTerkunci(rencanaKinerja.StatusRencanaKinerja); err != nil {
+		return err
+	}
+
+	err = service.lockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, rencanaKinerja.KodeOpd, rencanaKinerja.Tahun)
+	if err != nil {
+		return err
+	}
+
+	before := service.auditSnapshot(ctx, tx, rencanaKinerja.Id)
+	err = service.rencanaKinerjaRepository.Delete(ctx, tx, rencanaKinerja.Id)
+	if err != nil {
+		return err
+	}
+
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRencanaKinerja,
+		EntityId:   rencanaKinerja.Id,
+		Action:     AuditActionDelete,
+		KodeOpd:    rencanaKinerja.KodeOpd,
+		Tahun:      rencanaKinerja.Tahun,
+		Before:     before,
+	})
 
-	return service.rencanaKinerjaRepository.Delete(ctx, tx, rencanaKinerja.Id)
+	return nil
 }
 
 func (service *RencanaKinerjaServiceImpl) FindAllRincianKak(ctx context.Context, pegawaiId string, rencanaKinerjaId string) ([]rencanakinerja.DataRincianKerja, error) {
@@ -1214,6 +1296,11 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("validasi gagal: %v", err)
 	}
 
+	err = service.lockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, request.KodeOpd, request.Tahun)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		log.Printf("Gagal memulai transaksi: %v", err)
@@ -1270,7 +1357,7 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 		NamaRencanaKinerja:   request.NamaRencanaKinerja,
 		Tahun:                request.Tahun,
 		KodeSubKegiatan:      "",
-		StatusRencanaKinerja: request.StatusRencanaKinerja,
+		StatusRencanaKinerja: statusRekinSetelahEdit(domain.StatusRekinDraft, request.StatusRencanaKinerja),
 		Catatan:              request.Catatan,
 		KodeOpd:              request.KodeOpd,
 		PegawaiId:            pegawais.Nip,
@@ -1360,6 +1447,15 @@ func (service *RencanaKinerjaServiceImpl) CreateRekinLevel1(ctx context.Context,
 	response := helper.ToRencanaKinerjaResponse(rencanaKinerja)
 	log.Printf("Response: %+v", response)
 
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRencanaKinerja,
+		EntityId:   rencanaKinerja.Id,
+		Action:     AuditActionCreate,
+		KodeOpd:    rencanaKinerja.KodeOpd,
+		Tahun:      rencanaKinerja.Tahun,
+		After:      response,
+	})
+
 	return response, nil
 }
 
@@ -1372,6 +1468,11 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("validasi gagal: %v", err)
 	}
 
+	err = service.lockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, request.KodeOpd, request.Tahun)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		log.Printf("Gagal memulai transaksi: %v", err)
@@ -1379,6 +1480,8 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 	}
 	defer helper.CommitOrRollback(tx)
 
+	before := service.auditSnapshot(ctx, tx, request.Id)
+
 	// Validasi OPD
 	opd, err := service.opdRepository.FindByKodeOpd(ctx, tx, request.KodeOpd)
 	if err != nil {
@@ -1425,6 +1528,13 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 		if err != nil {
 			return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("gagal menemukan RencanaKinerja: %v", err)
 		}
+		if err := periksaRekinTerkunci(rencanaKinerja.StatusRencanaKinerja); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
+		// kunci dicek pada tahun yang tersimpan, tahun di body hanya tujuan perubahan
+		if err := service.lockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, rencanaKinerja.KodeOpd, rencanaKinerja.Tahun); err != nil {
+			return rencanakinerja.RencanaKinerjaResponse{}, err
+		}
 
 		// Ambil semua indikator lama
 		indikatorLamaList, err := service.rencanaKinerjaRepository.FindIndikatorbyRekinId(ctx, tx, rencanaKinerja.Id)
@@ -1455,7 +1565,7 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 	rencanaKinerja.SasaranOpdId = helper.EmptyIntIfNull(request.SasaranOpdId)
 	rencanaKinerja.NamaRencanaKinerja = request.NamaRencanaKinerja
 	rencanaKinerja.Tahun = request.Tahun
-	rencanaKinerja.StatusRencanaKinerja = request.StatusRencanaKinerja
+	rencanaKinerja.StatusRencanaKinerja = statusRekinSetelahEdit(rencanaKinerja.StatusRencanaKinerja, request.StatusRencanaKinerja)
 	rencanaKinerja.Catatan = request.Catatan
 	rencanaKinerja.KodeOpd = request.KodeOpd
 	rencanaKinerja.PegawaiId = request.PegawaiId
@@ -1599,6 +1709,16 @@ func (service *RencanaKinerjaServiceImpl) UpdateRekinLevel1(ctx context.Context,
 	response := helper.ToRencanaKinerjaResponse(rencanaKinerja)
 	log.Printf("Response: %+v", response)
 
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRencanaKinerja,
+		EntityId:   rencanaKinerja.Id,
+		Action:     AuditActionUpdate,
+		KodeOpd:    rencanaKinerja.KodeOpd,
+		Tahun:      rencanaKinerja.Tahun,
+		Before:     before,
+		After:      response,
+	})
+
 	return response, nil
 }
 
@@ -2166,6 +2286,15 @@ func (service *RencanaKinerjaServiceImpl) CloneRencanaKinerja(ctx context.Contex
 		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("rencana kinerja tidak ditemukan: %v", err)
 	}
 
+	rekinAsal, err := service.rencanaKinerjaRepository.FindById(ctx, tx, rekinId, "", "")
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, fmt.Errorf("rencana kinerja tidak ditemukan: %v", err)
+	}
+	err = service.lockDataService.CheckWritable(ctx, LockJenisRencanaKinerja, rekinAsal.KodeOpd, tahunBaru)
+	if err != nil {
+		return rencanakinerja.RencanaKinerjaResponse{}, err
+	}
+
 	// 2. Clone rencana kinerja utama
 	newRekin, err := service.rencanaKinerjaRepository.CloneRencanaKinerja(ctx, tx, rekinId, tahunBaru)
 	if err != nil {
@@ -2346,6 +2475,15 @@ func (service *RencanaKinerjaServiceImpl) CloneRencanaKinerja(ctx context.Contex
 		Indikator:   indikatorResponses,
 	}
 
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRencanaKinerja,
+		EntityId:   rencanaKinerjaBaru.Id,
+		Action:     AuditActionCreate,
+		KodeOpd:    rencanaKinerjaBaru.KodeOpd,
+		Tahun:      rencanaKinerjaBaru.Tahun,
+		After:      response,
+	})
+
 	return response, nil
 }
 
@@ -2391,91 +2529,6 @@ func (service *RencanaKinerjaServiceImpl) FindByFilter(ctx context.Context, filt
 	return response, nil
 }
 
-func (service *RencanaKinerjaServiceImpl) CloneRekinByKodeOpdAndTahun(
-	ctx context.Context,
-	cloneRequest rencanakinerja.RekinByOpdCloneRequest,
-) error {
-
-	tx, err := service.DB.Begin()
-	if err != nil {
-		log.Printf("Gagal memulai transaksi: %v", err)
-		return fmt.Errorf("gagal memulai transaksi: %w", err)
-	}
-	defer helper.CommitOrRollback(tx)
-
-	kodeOpd := cloneRequest.KodeOpd
-	tahunSumber := cloneRequest.TahunSumber
-	tahunTarget := cloneRequest.TahunTujuan
-
-	// 1. Check existing clone record
-	existing, err := service.cloneRecordRepository.
-		GetCloneByKodeOpdTahunSumberTahunTujuan(ctx, tx, kodeOpd, tahunSumber, tahunTarget)
-
-	if err != nil {
-		// bedakan error
-		if err.Error() != "clone record not found" {
-			log.Printf("error checking clone record: %v", err)
-			return fmt.Errorf("gagal cek data clone: %w", err)
-		}
-	}
-	if existing.Status == "DONE" || existing.Status == "DONE_WITH_WARNING" {
-		// sudah pernah clone
-		return fmt.Errorf(
-			"rekin opd %s dari %s ke %s sudah di-clone pada %v status %s",
-			kodeOpd,
-			tahunSumber,
-			tahunTarget,
-			existing.CreatedAt.Format("2006-01-02 15:04:05"),
-			existing.Status,
-		)
-	}
-	if existing.Status == "PROCESS" || existing.Status == "PENDING" {
-		// sudah pernah clone
-		return fmt.Errorf("clone rekin sedang berjalan")
-	}
-
-	// 2. (Optional) insert tracking dulu → untuk lock logical
-	newRecord := domain.CloneRecord{
-		KodeClone:   helper.GenerateKodeClone(kodeOpd), // pastikan ada
-		KodeOpd:     kodeOpd,
-		TahunAsal:   cloneRequest.TahunSumber,
-		TahunTarget: tahunTarget,
-		UpdatedBy:   cloneRequest.UpdatedBy,
-	}
-
-	cloneRecord, err := service.cloneRecordRepository.Create(ctx, tx, newRecord)
-	if err != nil {
-		// handle duplicate (jika UNIQUE constraint ada)
-		log.Printf("error insert clone record: %v", err)
-		return fmt.Errorf("gagal menyimpan record clone: %w", err)
-	}
-
-	// 4. jalankan async (OUTSIDE TX)
-	go func(recordId int, req rencanakinerja.RekinByOpdCloneRequest) {
-		defer func() {
-			if r := recover(); r != nil {
-				log.Printf("panic clone: %v", r)
-				service.updateStatusSafe(recordId, "FAILED", fmt.Sprintf("%v", r))
-			}
-		}()
-
-		warningMsg, err := service.doCloneBackground(context.Background(), req, recordId)
-		if err != nil {
-			log.Printf("clone background gagal: %v", err)
-			service.updateStatusSafe(recordId, "FAILED", err.Error())
-			return
-		}
-		if warningMsg != "" {
-			service.updateStatusSafe(recordId, "DONE_WITH_WARNING", warningMsg)
-			return
-		}
-
-		service.updateStatusSafe(recordId, "DONE", "")
-	}(cloneRecord.Id, cloneRequest)
-
-	return nil
-}
-
 func toIndikatorResponses(
 	indikators []domain.Indikator,
 ) []rencanakinerja.IndikatorResponse {
@@ -2516,308 +2569,6 @@ func toTargetResponses(targets []domain.Target) []rencanakinerja.TargetResponse
 	return responses
 }
 
-func (service *RencanaKinerjaServiceImpl) doCloneBackground(
-	ctx context.Context,
-	req rencanakinerja.RekinByOpdCloneRequest,
-	recordId int,
-) (warningMsg string, err error) {
-
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return "", err
-	}
-	defer helper.CommitOrRollback(tx)
-
-	// 1. set status PROCESS
-	service.updateStatusSafe(recordId, "PROCESS", "")
-
-	// kumpulan warning missing dan orphan
-	// dari detail rekin
-	const (
-		// indikator
-		WarningIndikatorMissing domain.WarningType = "indikator_missing"
-		WarningIndikatorOrphan  domain.WarningType = "indikator_orphan"
-
-		// target
-		WarningTargetMissing domain.WarningType = "target_missing"
-		WarningTargetOrphan  domain.WarningType = "target_orphan"
-
-		// manualik
-		WarningManualIKMissing domain.WarningType = "manual_ik_missing"
-		WarningManualIKOrphan  domain.WarningType = "manual_ik_orphan"
-
-		// rencana aksi
-		WarningRenaksiMissing domain.WarningType = "renaksi_missing"
-		WarningRenaksiOrphan  domain.WarningType = "renaksi_orphan"
-
-		// gambaran umum
-		WarningGambaranUmumMissing domain.WarningType = "gambaran_umum_missing"
-		WarningGambaranUmumOrphan  domain.WarningType = "gambaran_umum_orphan"
-
-		// dasar hukum
-		WarningDasarHukumMissing domain.WarningType = "dasar_hukum_missing"
-		WarningDasarHukumOrphan  domain.WarningType = "dasar_hukum_orphan"
-
-		// permasalahan
-		WarningPermasalahanMissing domain.WarningType = "permasalahan_missing"
-		WarningPermasalahanOrphan  domain.WarningType = "permasalahan_orphan"
-
-		// inovasi
-		WarningInovasiMissing domain.WarningType = "inovasi_missing"
-		WarningInovasiOrphan  domain.WarningType = "inovasi_orphan"
-	)
-	warnings := make(map[domain.WarningType]int)
-	// 2. ambil data sumber
-	data, err := service.rencanaKinerjaRepository.GetByKodeOpdAndTahun(
-		ctx,
-		tx,
-		req.KodeOpd,
-		req.TahunSumber,
-	)
-	if err != nil {
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
-	}
-
-	oldToNewRekin := make(map[string]string)
-	oldToNewIndikator := make(map[string]string)
-	var newRekins []domain.RencanaKinerja
-	// 3. lakukan clone
-	for _, item := range data {
-		tahunTujuan := req.TahunTujuan
-
-		oldRekinId := item.Id
-		newRekinId := genereateRekinId()
-
-		oldToNewRekin[oldRekinId] = newRekinId
-
-		// prepare baru
-		newItem := item
-		itemIndikators := item.Indikator
-
-		newItem.Id = newRekinId
-		newItem.Tahun = tahunTujuan
-		newItem.IdPohon = 0
-		newItem.KodeSubKegiatan = ""
-
-		// new item indikator
-		var newItemIndikator []domain.Indikator
-
-		for _, ind := range itemIndikators {
-			oldIndId := ind.Id
-			newIndId := genereateIndikatorRekinId()
-
-			oldToNewIndikator[oldIndId] = newIndId
-
-			newInd := ind
-			indTarget := ind.Target
-
-			newInd.Id = newIndId
-
-			var newTargets []domain.Target
-			for _, tar := range indTarget {
-				newTar := tar
-				newTar.Id = generateTargetRekinId()
-				newTar.IndikatorId = newInd.Id
-				newTar.Tahun = tahunTujuan
-				newTargets = append(newTargets, newTar)
-			}
-
-			newInd.RencanaKinerjaId = newItem.Id
-			newInd.Tahun = tahunTujuan
-			newInd.Target = newTargets
-			newItemIndikator = append(newItemIndikator, newInd)
-		}
-		newItem.Indikator = newItemIndikator
-
-		newRekins = append(newRekins, newItem)
-	}
-	// clone batch
-	err = service.rencanaKinerjaRepository.CreateBatch(ctx, tx, newRekins)
-	if err != nil {
-		log.Printf("rencanaKinerjaRepository.CreateBatch error: %v", err)
-		return "", err
-	}
-	// 4. manualik
-	oldIndikatorIds := make([]string, 0, len(oldToNewIndikator))
-	for oldIndId := range oldToNewIndikator {
-		oldIndikatorIds = append(oldIndikatorIds, oldIndId)
-	}
-
-	manualIks, err := service.manualIKRepository.FindByIndikatorIds(ctx, tx, oldIndikatorIds)
-	if err != nil {
-		log.Printf("manualIKRepository.FindByIndikatorIds error: %v", err)
-		return "", err
-	}
-	if len(manualIks) == 0 {
-		addWarning(warnings, WarningManualIKMissing)
-	}
-	var newManualIks []domain.ManualIK
-	for _, item := range manualIks {
-		newIndId, ok := oldToNewIndikator[item.IndikatorId]
-		if !ok {
-			addWarning(warnings, WarningManualIKOrphan)
-			continue
-		}
-		newItem := item
-		newItem.IndikatorId = newIndId
-		newManualIks = append(newManualIks, newItem)
-	}
-	err = service.manualIKRepository.CreateBatch(ctx, tx, newManualIks)
-	if err != nil {
-		log.Printf("rencanaKinerjaRepository.CreateDetailBatch error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
-	}
-
-	// 5. renaksis
-	oldRekinIds := make([]string, 0, len(oldToNewRekin))
-	for oldRekinId := range oldToNewRekin {
-		oldRekinIds = append(oldRekinIds, oldRekinId)
-	}
-
-	renaksis, err := service.rencanaAksiRepository.FindRenaksiByRekinIds(ctx, tx, oldRekinIds)
-	if err != nil {
-		log.Printf("rencanaAksiRepository.FindRenaksiByRekinIds error: %v", err)
-		return "", err
-	}
-	if len(renaksis) == 0 {
-		addWarning(warnings, WarningRenaksiMissing)
-	}
-	var newRenaksis []domain.RencanaAksi
-	for _, item := range renaksis {
-		newRekinId, ok := oldToNewRekin[item.RencanaKinerjaId]
-		if !ok {
-			addWarning(warnings, WarningRenaksiOrphan)
-			continue
-		}
-		newItem := item
-		newItem.Id = generateRencanaAksiId()
-		newItem.RencanaKinerjaId = newRekinId
-		newRenaksis = append(newRenaksis, newItem)
-	}
-	err = service.rencanaAksiRepository.BatchCreate(ctx, tx, newRenaksis)
-	if err != nil {
-		log.Printf("rencanaKinerjaRepository.CreateDetailBatch error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
-	}
-	// 6.1. TODO pelaksanaan
-	// 7. Dasar Hukum
-	dasarHukums, err := service.DasarHukumRepository.FindByRekinIds(ctx, tx, oldRekinIds)
-	if err != nil {
-		log.Printf("DasarHukumRepository.FindByRekinIds error: %v", err)
-		return "", err
-	}
-	if len(dasarHukums) == 0 {
-		addWarning(warnings, WarningDasarHukumMissing)
-	}
-	var newDasarHukums []domain.DasarHukum
-	for _, item := range dasarHukums {
-		newRekinId, ok := oldToNewRekin[item.RekinId]
-		if !ok {
-			addWarning(warnings, WarningRenaksiOrphan)
-			continue
-		}
-		newItem := item
-		newItem.Id = generateDasarHukumId()
-		newItem.RekinId = newRekinId
-		newDasarHukums = append(newDasarHukums, newItem)
-	}
-	err = service.DasarHukumRepository.BatchCreate(ctx, tx, newDasarHukums)
-	if err != nil {
-		log.Printf("DasarHukumRepository.BatchCreate error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
-	}
-	// 8. Permasalahan
-	permasalahans, err := service.permasalahanRekinRepository.FindByRekinIds(ctx, tx, oldRekinIds)
-	if err != nil {
-		log.Printf("service.permasalahanRekinRepository error: %v", err)
-		return "", err
-	}
-	if len(permasalahans) == 0 {
-		addWarning(warnings, WarningPermasalahanMissing)
-	}
-	var newPermasalahans []domain.PermasalahanRekin
-	for _, item := range permasalahans {
-		newRekinId, ok := oldToNewRekin[item.RekinId]
-		if !ok {
-			addWarning(warnings, WarningPermasalahanOrphan)
-			continue
-		}
-		newItem := item
-		newItem.RekinId = newRekinId
-		newPermasalahans = append(newPermasalahans, newItem)
-	}
-	err = service.permasalahanRekinRepository.BatchCreate(ctx, tx, newPermasalahans)
-	if err != nil {
-		log.Printf("permasalahanRekinRepository.BatchCreate error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
-	}
-	// 9. Gambaran Umum
-	gambaranUmums, err := service.GambaranUmumRepository.FindByRekinIds(ctx, tx, oldRekinIds)
-	if err != nil {
-		log.Printf("GambaranUmumRepository.FindByRekinIds error: %v", err)
-		return "", err
-	}
-	if len(gambaranUmums) == 0 {
-		addWarning(warnings, WarningGambaranUmumMissing)
-	}
-	var newGamabaranUmums []domain.GambaranUmum
-	for _, item := range gambaranUmums {
-		newRekinId, ok := oldToNewRekin[item.RekinId]
-		if !ok {
-			addWarning(warnings, WarningGambaranUmumOrphan)
-			continue
-		}
-		newItem := item
-		newItem.Id = generateGambaranUmumId()
-		newItem.RekinId = newRekinId
-		newGamabaranUmums = append(newGamabaranUmums, newItem)
-	}
-	err = service.GambaranUmumRepository.BatchCreate(ctx, tx, newGamabaranUmums)
-	if err != nil {
-		log.Printf("GambaranUmumRepository.BatchCreate error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
-	}
-
-	// 10. Inovasi
-	inovasis, err := service.InovasiRepository.FindByRekinIds(ctx, tx, oldRekinIds)
-	if err != nil {
-		log.Printf("InovasiRepository.FindByRekinIds error: %v", err)
-		return "", err
-	}
-	if len(inovasis) == 0 {
-		addWarning(warnings, WarningInovasiMissing)
-	}
-	var newInovasis []domain.Inovasi
-	for _, item := range inovasis {
-		newRekinId, ok := oldToNewRekin[item.RekinId]
-		if !ok {
-			addWarning(warnings, WarningInovasiOrphan)
-			continue
-		}
-		newItem := item
-		newItem.Id = generateInovasiId()
-		newItem.RekinId = newRekinId
-		newInovasis = append(newInovasis, newItem)
-	}
-	err = service.InovasiRepository.BatchCreate(ctx, tx, newInovasis)
-	if err != nil {
-		log.Printf("InovasiRepository.BatchCreate error: %v", err)
-		service.updateStatusSafe(recordId, "FAILED", err.Error())
-		return "", err
-	}
-	// 11. ...
-
-	warningMsg = buildWarningMessage(warnings)
-
-	return warningMsg, nil
-}
-
 func genereateRekinId() string {
 	randomDigits := uuid.NewString()
 	currentTime := time.Now().Format("20060102")
@@ -2859,47 +2610,3 @@ func generateInovasiId() string {
 	currentTime := time.Now().Format("20060102")
 	return fmt.Sprintf("INOV-REKIN-%s-%s", currentTime, inovasiRandomDigits)
 }
-
-func (service *RencanaKinerjaServiceImpl) updateStatusSafe(
-	id int,
-	status string,
-	errMsg string,
-) {
-	tx, err := service.DB.Begin()
-	if err != nil {
-		log.Printf("gagal begin tx update status: %v", err)
-		return
-	}
-	defer helper.CommitOrRollback(tx)
-
-	err = service.cloneRecordRepository.UpdateStatus(
-		context.Background(),
-		tx,
-		id,
-		status,
-		errMsg,
-	)
-	if err != nil {
-		log.Printf("gagal update status: %v", err)
-	}
-}
-
-func addWarning(w map[domain.WarningType]int, t domain.WarningType) {
-	w[t]++
-}
-
-func buildWarningMessage(warnings map[domain.WarningType]int) string {
-	if len(warnings) == 0 {
-		return ""
-	}
-
-	var parts []string
-
-	for t, count := range warnings {
-		parts = append(parts,
-			fmt.Sprintf("%d %s", count, t),
-		)
-	}
-
-	return strings.Join(parts, "; ")
-}
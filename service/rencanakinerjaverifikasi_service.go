@@ -0,0 +1,28 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+)
+
+// aksi verifikasi rencana kinerja oleh atasan
+const (
+	AksiRekinAjukan     = "ajukan"
+	AksiRekinVerifikasi = "verifikasi"
+	AksiRekinKembalikan = "kembalikan"
+	AksiRekinTolak      = "tolak"
+	AksiRekinRevisi     = "revisi"
+)
+
+// peran pelaku verifikasi, ditentukan dari nip login terhadap rekin dan struktur organisasi
+const (
+	PeranRekinPemilik = "pemilik"
+	PeranRekinAtasan  = "atasan"
+)
+
+type RencanaKinerjaVerifikasiService interface {
+	Transisi(ctx context.Context, request rencanakinerja.RekinVerifikasiRequest) (rencanakinerja.RekinVerifikasiResponse, error)
+	FindStatus(ctx context.Context, rencanaKinerjaId string) (rencanakinerja.RekinStatusVerifikasiResponse, error)
+	// FindInbox rekin bawahan user login yang menunggu verifikasi
+	FindInbox(ctx context.Context, tahun string) ([]rencanakinerja.RekinInboxResponse, error)
+}
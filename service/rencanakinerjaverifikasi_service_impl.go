@@ -0,0 +1,369 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/rencanakinerja"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type RencanaKinerjaVerifikasiServiceImpl struct {
+	RencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository
+	StrukturOrganisasiRepository       repository.StrukturOrganisasiRepository
+	OpdGuardService                    OpdGuardService
+	DB                                 *sql.DB
+	Validate                           *validator.Validate
+}
+
+func NewRencanaKinerjaVerifikasiServiceImpl(rencanaKinerjaVerifikasiRepository repository.RencanaKinerjaVerifikasiRepository, strukturOrganisasiRepository repository.StrukturOrganisasiRepository, opdGuardService OpdGuardService, DB *sql.DB, validate *validator.Validate) *RencanaKinerjaVerifikasiServiceImpl {
+	return &RencanaKinerjaVerifikasiServiceImpl{
+		RencanaKinerjaVerifikasiRepository: rencanaKinerjaVerifikasiRepository,
+		StrukturOrganisasiRepository:       strukturOrganisasiRepository,
+		OpdGuardService:                    opdGuardService,
+		DB:                                 DB,
+		Validate:                           validate,
+	}
+}
+
+type transisiRekin struct {
+	Aksi         string
+	Dari         string
+	Ke           string
+	Peran        string
+	WajibCatatan bool
+}
+
+// aturanTransisiRekin seluruh perpindahan status verifikasi yang diizinkan, di luar daftar ini ditolak
+var aturanTransisiRekin = []transisiRekin{
+	{Aksi: AksiRekinAjukan, Dari: domain.StatusRekinDraft, Ke: domain.StatusRekinMenungguVerifikasi, Peran: PeranRekinPemilik},
+	{Aksi: AksiRekinAjukan, Dari: domain.StatusRekinDikembalikan, Ke: domain.StatusRekinMenungguVerifikasi, Peran: PeranRekinPemilik},
+	{Aksi: AksiRekinVerifikasi, Dari: domain.StatusRekinMenungguVerifikasi, Ke: domain.StatusRekinTerverifikasi, Peran: PeranRekinAtasan},
+	{Aksi: AksiRekinKembalikan, Dari: domain.StatusRekinMenungguVerifikasi, Ke: domain.StatusRekinDikembalikan, Peran: PeranRekinAtasan, WajibCatatan: true},
+	{Aksi: AksiRekinTolak, Dari: domain.StatusRekinMenungguVerifikasi, Ke: domain.StatusRekinDitolak, Peran: PeranRekinAtasan, WajibCatatan: true},
+	{Aksi: AksiRekinRevisi, Dari: domain.StatusRekinTerverifikasi, Ke: domain.StatusRekinDraft, Peran: PeranRekinPemilik, WajibCatatan: true},
+	{Aksi: AksiRekinRevisi, Dari: domain.StatusRekinDitolak, Ke: domain.StatusRekinDraft, Peran: PeranRekinPemilik},
+}
+
+// statusVerifikasiRekin status bebas di luar workflow verifikasi dianggap draft
+func statusVerifikasiRekin(status string) string {
+	switch status {
+	case domain.StatusRekinMenungguVerifikasi, domain.StatusRekinTerverifikasi, domain.StatusRekinDikembalikan, domain.StatusRekinDitolak:
+		return status
+	}
+	return domain.StatusRekinDraft
+}
+
+func labelStatusRekin(status string) string {
+	if status == domain.StatusRekinDraft {
+		return "belum diajukan"
+	}
+	return status
+}
+
+// periksaRekinTerkunci rekin yang sedang diverifikasi atau sudah terverifikasi tidak boleh diubah
+func periksaRekinTerkunci(status string) error {
+	switch statusVerifikasiRekin(status) {
+	case domain.StatusRekinMenungguVerifikasi:
+		return web.NewConflictError("rencana kinerja sedang menunggu verifikasi atasan dan tidak dapat diubah")
+	case domain.StatusRekinTerverifikasi:
+		return web.NewConflictError("rencana kinerja sudah terverifikasi, ajukan revisi sebelum mengubah")
+	}
+	return nil
+}
+
+// periksaRekinTerkunciById dipakai service isi rekin (rencana aksi, pelaksanaan, dasar hukum, dll)
+// agar kunci verifikasi tidak bisa dilewati dengan mengubah data turunan rekin
+func periksaRekinTerkunciById(ctx context.Context, tx *sql.Tx, verifikasiRepository repository.RencanaKinerjaVerifikasiRepository, rencanaKinerjaId string) error {
+	rekin, err := verifikasiRepository.FindRencanaKinerja(ctx, tx, rencanaKinerjaId)
+	if err == sql.ErrNoRows {
+		return web.NewNotFoundError(fmt.Sprintf("rencana kinerja %s tidak ditemukan", rencanaKinerjaId))
+	}
+	if err != nil {
+		return err
+	}
+	return periksaRekinTerkunci(rekin.StatusRencanaKinerja)
+}
+
+// statusRekinSetelahEdit status verifikasi hanya berubah lewat transisi, bukan dari request edit
+func statusRekinSetelahEdit(lama string, baru string) string {
+	if statusVerifikasiRekin(lama) != domain.StatusRekinDraft || statusVerifikasiRekin(baru) != domain.StatusRekinDraft {
+		return lama
+	}
+	return baru
+}
+
+// peranPelakuRekin peran nip login terhadap rekin, bisa keduanya bila atasan sekaligus pemilik
+func peranPelakuRekin(nip string, nipPemilik string, nipAtasan string) []string {
+	var peran []string
+	if nip == "" {
+		return peran
+	}
+	if nip == nipPemilik {
+		peran = append(peran, PeranRekinPemilik)
+	}
+	if nip == nipAtasan {
+		peran = append(peran, PeranRekinAtasan)
+	}
+	return peran
+}
+
+func memilikiPeran(peran []string, dicari string) bool {
+	for _, p := range peran {
+		if p == dicari {
+			return true
+		}
+	}
+	return false
+}
+
+// periksaTransisiRekin mencari aturan untuk status dan aksi, lalu memeriksa peran dan catatan
+func periksaTransisiRekin(dari string, aksi string, peran []string, catatan string) (transisiRekin, error) {
+	dari = statusVerifikasiRekin(dari)
+	aksiDikenal := false
+	for _, aturan := range aturanTransisiRekin {
+		if aturan.Aksi != aksi {
+			continue
+		}
+		aksiDikenal = true
+		if aturan.Dari != dari {
+			continue
+		}
+		if !memilikiPeran(peran, aturan.Peran) {
+			return transisiRekin{}, web.NewForbiddenError(fmt.Sprintf("aksi %s hanya dapat dilakukan oleh %s rencana kinerja", aksi, aturan.Peran))
+		}
+		if aturan.WajibCatatan && strings.TrimSpace(catatan) == "" {
+			return transisiRekin{}, web.NewBadRequestError(fmt.Sprintf("catatan wajib diisi untuk aksi %s", aksi))
+		}
+		return aturan, nil
+	}
+	if !aksiDikenal {
+		return transisiRekin{}, web.NewBadRequestError(fmt.Sprintf("aksi %s tidak dikenal", aksi))
+	}
+	return transisiRekin{}, web.NewConflictError(fmt.Sprintf("aksi %s tidak dapat dilakukan pada rencana kinerja berstatus %s", aksi, labelStatusRekin(dari)))
+}
+
+// aksiTersediaRekin transisi dari status tertentu yang boleh dilakukan pemilik peran
+func aksiTersediaRekin(status string, peran []string) []rencanakinerja.RekinAturanVerifikasiResponse {
+	status = statusVerifikasiRekin(status)
+	result := []rencanakinerja.RekinAturanVerifikasiResponse{}
+	for _, aturan := range aturanTransisiRekin {
+		if aturan.Dari == status && memilikiPeran(peran, aturan.Peran) {
+			result = append(result, rencanakinerja.RekinAturanVerifikasiResponse{
+				Aksi:         aturan.Aksi,
+				DariStatus:   aturan.Dari,
+				KeStatus:     aturan.Ke,
+				Peran:        aturan.Peran,
+				WajibCatatan: aturan.WajibCatatan,
+			})
+		}
+	}
+	return result
+}
+
+// catatanAtasanTerakhir catatan atasan terbaru pada revisi berjalan, riwayat terurut terbaru lebih dulu
+func catatanAtasanTerakhir(riwayat []domain.RencanaKinerjaVerifikasi) string {
+	for _, verifikasi := range riwayat {
+		if verifikasi.Revisi != riwayat[0].Revisi {
+			break
+		}
+		if verifikasi.ActorPeran == PeranRekinAtasan && verifikasi.Catatan != "" {
+			return verifikasi.Catatan
+		}
+	}
+	return ""
+}
+
+func toRekinVerifikasiResponse(verifikasi domain.RencanaKinerjaVerifikasi) rencanakinerja.RekinVerifikasiResponse {
+	return rencanakinerja.RekinVerifikasiResponse{
+		Id:               verifikasi.Id,
+		RencanaKinerjaId: verifikasi.RencanaKinerjaId,
+		Revisi:           verifikasi.Revisi,
+		DariStatus:       verifikasi.DariStatus,
+		KeStatus:         verifikasi.KeStatus,
+		Aksi:             verifikasi.Aksi,
+		Catatan:          verifikasi.Catatan,
+		ActorNip:         verifikasi.ActorNip,
+		ActorPeran:       verifikasi.ActorPeran,
+		CreatedAt:        verifikasi.CreatedAt,
+	}
+}
+
+func (service *RencanaKinerjaVerifikasiServiceImpl) findRekin(ctx context.Context, tx *sql.Tx, rencanaKinerjaId string) (domain.RencanaKinerja, error) {
+	rekin, err := service.RencanaKinerjaVerifikasiRepository.FindRencanaKinerja(ctx, tx, rencanaKinerjaId)
+	if err == sql.ErrNoRows {
+		return domain.RencanaKinerja{}, web.NewNotFoundError(fmt.Sprintf("rencana kinerja %s tidak ditemukan", rencanaKinerjaId))
+	}
+	return rekin, err
+}
+
+// findNipAtasan atasan pemilik rekin menurut struktur organisasi OPD pada tahun rekin
+func (service *RencanaKinerjaVerifikasiServiceImpl) findNipAtasan(ctx context.Context, tx *sql.Tx, rekin domain.RencanaKinerja) (string, error) {
+	tahun, err := strconv.Atoi(rekin.Tahun)
+	if err != nil {
+		return "", nil
+	}
+	atasans, err := service.StrukturOrganisasiRepository.AtasanBawahanByKodeOpdTahun(ctx, tx, rekin.KodeOpd, tahun)
+	if err != nil {
+		return "", err
+	}
+	return atasans[rekin.PegawaiId], nil
+}
+
+func (service *RencanaKinerjaVerifikasiServiceImpl) Transisi(ctx context.Context, request rencanakinerja.RekinVerifikasiRequest) (rencanakinerja.RekinVerifikasiResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok {
+		return rencanakinerja.RekinVerifikasiResponse{}, web.NewUnauthorizedError("verifikasi rencana kinerja memerlukan login")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+	// rollback eksplisit: status yang sudah berubah tidak boleh tersimpan tanpa riwayat verifikasinya
+	defer tx.Rollback()
+
+	rekin, err := service.findRekin(ctx, tx, request.RencanaKinerjaId)
+	if err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+	nipAtasan, err := service.findNipAtasan(ctx, tx, rekin)
+	if err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+
+	peran := peranPelakuRekin(claims.Nip, rekin.PegawaiId, nipAtasan)
+	aturan, err := periksaTransisiRekin(rekin.StatusRencanaKinerja, request.Aksi, peran, request.Catatan)
+	if err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+	if aturan.Aksi == AksiRekinAjukan && nipAtasan == "" {
+		return rencanakinerja.RekinVerifikasiResponse{}, web.NewBadRequestError("atasan pegawai belum ditetapkan pada struktur organisasi")
+	}
+
+	revisi, err := service.RencanaKinerjaVerifikasiRepository.FindRevisi(ctx, tx, rekin.Id)
+	if err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+	if aturan.Aksi == AksiRekinRevisi {
+		revisi++
+	}
+
+	berubah, err := service.RencanaKinerjaVerifikasiRepository.UpdateStatus(ctx, tx, rekin.Id, rekin.StatusRencanaKinerja, aturan.Ke)
+	if err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+	if !berubah {
+		return rencanakinerja.RekinVerifikasiResponse{}, web.NewConflictError("status rencana kinerja sudah diubah pengguna lain, muat ulang data")
+	}
+
+	// catatan hanya disimpan di riwayat, kolom catatan rekin tetap milik pemilik rekin
+	catatan := strings.TrimSpace(request.Catatan)
+	verifikasi, err := service.RencanaKinerjaVerifikasiRepository.Create(ctx, tx, domain.RencanaKinerjaVerifikasi{
+		RencanaKinerjaId: rekin.Id,
+		Revisi:           revisi,
+		DariStatus:       rekin.StatusRencanaKinerja,
+		KeStatus:         aturan.Ke,
+		Aksi:             aturan.Aksi,
+		Catatan:          catatan,
+		ActorNip:         claims.Nip,
+		ActorPeran:       aturan.Peran,
+	})
+	if err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return rencanakinerja.RekinVerifikasiResponse{}, err
+	}
+	return toRekinVerifikasiResponse(verifikasi), nil
+}
+
+func (service *RencanaKinerjaVerifikasiServiceImpl) FindStatus(ctx context.Context, rencanaKinerjaId string) (rencanakinerja.RekinStatusVerifikasiResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return rencanakinerja.RekinStatusVerifikasiResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	rekin, err := service.findRekin(ctx, tx, rencanaKinerjaId)
+	if err != nil {
+		return rencanakinerja.RekinStatusVerifikasiResponse{}, err
+	}
+	if err := service.OpdGuardService.CheckRead(ctx, rekin.KodeOpd, rekin.Tahun); err != nil {
+		return rencanakinerja.RekinStatusVerifikasiResponse{}, err
+	}
+	nipAtasan, err := service.findNipAtasan(ctx, tx, rekin)
+	if err != nil {
+		return rencanakinerja.RekinStatusVerifikasiResponse{}, err
+	}
+	riwayat, err := service.RencanaKinerjaVerifikasiRepository.FindByRencanaKinerjaId(ctx, tx, rekin.Id)
+	if err != nil {
+		return rencanakinerja.RekinStatusVerifikasiResponse{}, err
+	}
+
+	claims, _ := helper.GetUserClaims(ctx)
+	response := rencanakinerja.RekinStatusVerifikasiResponse{
+		RencanaKinerjaId: rekin.Id,
+		Status:           statusVerifikasiRekin(rekin.StatusRencanaKinerja),
+		Catatan:          rekin.Catatan,
+		CatatanAtasan:    catatanAtasanTerakhir(riwayat),
+		Terkunci:         periksaRekinTerkunci(rekin.StatusRencanaKinerja) != nil,
+		NipAtasan:        nipAtasan,
+		AksiTersedia:     aksiTersediaRekin(rekin.StatusRencanaKinerja, peranPelakuRekin(claims.Nip, rekin.PegawaiId, nipAtasan)),
+		Riwayat:          []rencanakinerja.RekinVerifikasiResponse{},
+	}
+	// riwayat terbaru lebih dulu, revisi saat ini ada di baris pertama
+	if len(riwayat) > 0 {
+		response.Revisi = riwayat[0].Revisi
+	}
+	for _, verifikasi := range riwayat {
+		response.Riwayat = append(response.Riwayat, toRekinVerifikasiResponse(verifikasi))
+	}
+	return response, nil
+}
+
+func (service *RencanaKinerjaVerifikasiServiceImpl) FindInbox(ctx context.Context, tahun string) ([]rencanakinerja.RekinInboxResponse, error) {
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok || claims.Nip == "" {
+		return nil, web.NewUnauthorizedError("inbox verifikasi memerlukan login")
+	}
+	if tahun == "" {
+		return nil, web.NewBadRequestError("tahun wajib diisi")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	inboxes, err := service.RencanaKinerjaVerifikasiRepository.FindInbox(ctx, tx, claims.Nip, tahun)
+	if err != nil {
+		return nil, err
+	}
+
+	result := []rencanakinerja.RekinInboxResponse{}
+	for _, inbox := range inboxes {
+		result = append(result, rencanakinerja.RekinInboxResponse{
+			Id:                 inbox.RencanaKinerja.Id,
+			NamaRencanaKinerja: inbox.RencanaKinerja.NamaRencanaKinerja,
+			Tahun:              inbox.RencanaKinerja.Tahun,
+			KodeOpd:            inbox.RencanaKinerja.KodeOpd,
+			PegawaiId:          inbox.RencanaKinerja.PegawaiId,
+			NamaPegawai:        inbox.RencanaKinerja.NamaPegawai,
+			Revisi:             inbox.Revisi,
+			DiajukanPada:       inbox.DiajukanPada,
+		})
+	}
+	return result, nil
+}
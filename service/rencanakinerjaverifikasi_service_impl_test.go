@@ -0,0 +1,111 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPeriksaTransisiRekin(t *testing.T) {
+	pemilik := []string{PeranRekinPemilik}
+	atasan := []string{PeranRekinAtasan}
+
+	tests := []struct {
+		name     string
+		dari     string
+		aksi     string
+		peran    []string
+		catatan  string
+		expected string
+		code     int
+	}{
+		{name: "ajukan rekin draft", dari: domain.StatusRekinDraft, aksi: AksiRekinAjukan, peran: pemilik, expected: domain.StatusRekinMenungguVerifikasi},
+		{name: "status lama di luar workflow dianggap draft", dari: "aktif", aksi: AksiRekinAjukan, peran: pemilik, expected: domain.StatusRekinMenungguVerifikasi},
+		{name: "ajukan ulang setelah dikembalikan", dari: domain.StatusRekinDikembalikan, aksi: AksiRekinAjukan, peran: pemilik, expected: domain.StatusRekinMenungguVerifikasi},
+		{name: "atasan memverifikasi", dari: domain.StatusRekinMenungguVerifikasi, aksi: AksiRekinVerifikasi, peran: atasan, expected: domain.StatusRekinTerverifikasi},
+		{name: "atasan mengembalikan dengan catatan", dari: domain.StatusRekinMenungguVerifikasi, aksi: AksiRekinKembalikan, peran: atasan, catatan: "target belum terukur", expected: domain.StatusRekinDikembalikan},
+		{name: "revisi rekin terverifikasi", dari: domain.StatusRekinTerverifikasi, aksi: AksiRekinRevisi, peran: pemilik, catatan: "perubahan anggaran", expected: domain.StatusRekinDraft},
+		{name: "kembalikan tanpa catatan", dari: domain.StatusRekinMenungguVerifikasi, aksi: AksiRekinKembalikan, peran: atasan, catatan: " ", code: http.StatusBadRequest},
+		{name: "pemilik tidak boleh memverifikasi", dari: domain.StatusRekinMenungguVerifikasi, aksi: AksiRekinVerifikasi, peran: pemilik, code: http.StatusForbidden},
+		{name: "pegawai lain tidak boleh mengajukan", dari: domain.StatusRekinDraft, aksi: AksiRekinAjukan, peran: nil, code: http.StatusForbidden},
+		{name: "verifikasi rekin yang belum diajukan", dari: domain.StatusRekinDraft, aksi: AksiRekinVerifikasi, peran: atasan, code: http.StatusConflict},
+		{name: "aksi tidak dikenal", dari: domain.StatusRekinDraft, aksi: "hapus", peran: pemilik, code: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aturan, err := periksaTransisiRekin(tt.dari, tt.aksi, tt.peran, tt.catatan)
+			if tt.code == 0 {
+				if err != nil || aturan.Ke != tt.expected {
+					t.Errorf("periksaTransisiRekin() = %q, %v, expected %q", aturan.Ke, err, tt.expected)
+				}
+				return
+			}
+			var customErr *web.CustomError
+			if !errors.As(err, &customErr) || customErr.Code != tt.code {
+				t.Errorf("periksaTransisiRekin() error = %v, expected code %d", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestKunciRekin(t *testing.T) {
+	for _, status := range []string{domain.StatusRekinMenungguVerifikasi, domain.StatusRekinTerverifikasi} {
+		if err := periksaRekinTerkunci(status); err == nil {
+			t.Errorf("periksaRekinTerkunci(%q) = nil, expected terkunci", status)
+		}
+	}
+	for _, status := range []string{"", "aktif", domain.StatusRekinDikembalikan, domain.StatusRekinDitolak} {
+		if err := periksaRekinTerkunci(status); err != nil {
+			t.Errorf("periksaRekinTerkunci(%q) = %v, expected nil", status, err)
+		}
+	}
+
+	tests := []struct {
+		lama     string
+		baru     string
+		expected string
+	}{
+		{lama: "aktif", baru: "draft", expected: "draft"},
+		{lama: "", baru: domain.StatusRekinTerverifikasi, expected: ""},
+		{lama: domain.StatusRekinDikembalikan, baru: "aktif", expected: domain.StatusRekinDikembalikan},
+	}
+	for _, tt := range tests {
+		if result := statusRekinSetelahEdit(tt.lama, tt.baru); result != tt.expected {
+			t.Errorf("statusRekinSetelahEdit(%q, %q) = %q, expected %q", tt.lama, tt.baru, result, tt.expected)
+		}
+	}
+}
+
+func TestAksiTersediaRekin(t *testing.T) {
+	peran := peranPelakuRekin("198001", "199002", "198001")
+	aksi := aksiTersediaRekin(domain.StatusRekinMenungguVerifikasi, peran)
+	if len(aksi) != 3 || aksi[0].Aksi != AksiRekinVerifikasi || !aksi[1].WajibCatatan {
+		t.Errorf("aksiTersediaRekin(atasan) = %+v", aksi)
+	}
+	if aksi := aksiTersediaRekin(domain.StatusRekinMenungguVerifikasi, peranPelakuRekin("199002", "199002", "198001")); len(aksi) != 0 {
+		t.Errorf("aksiTersediaRekin(pemilik) = %+v, expected kosong", aksi)
+	}
+	if peran := peranPelakuRekin("", "", ""); len(peran) != 0 {
+		t.Errorf("peranPelakuRekin() tanpa nip = %v, expected kosong", peran)
+	}
+}
+
+func TestCatatanAtasanTerakhir(t *testing.T) {
+	riwayat := []domain.RencanaKinerjaVerifikasi{
+		{Revisi: 1, Aksi: AksiRekinAjukan, ActorPeran: PeranRekinPemilik, Catatan: "sudah diperbaiki"},
+		{Revisi: 1, Aksi: AksiRekinKembalikan, ActorPeran: PeranRekinAtasan, Catatan: "target belum terukur"},
+		{Revisi: 0, Aksi: AksiRekinTolak, ActorPeran: PeranRekinAtasan, Catatan: "revisi lama"},
+	}
+	if catatan := catatanAtasanTerakhir(riwayat); catatan != "target belum terukur" {
+		t.Errorf("catatanAtasanTerakhir() = %q, expected catatan atasan revisi berjalan", catatan)
+	}
+	if catatan := catatanAtasanTerakhir(riwayat[:1]); catatan != "" {
+		t.Errorf("catatanAtasanTerakhir() tanpa catatan atasan = %q, expected kosong", catatan)
+	}
+	if catatan := catatanAtasanTerakhir(nil); catatan != "" {
+		t.Errorf("catatanAtasanTerakhir(nil) = %q, expected kosong", catatan)
+	}
+}
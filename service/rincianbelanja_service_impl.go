@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/rincianbelanja"
 	"ekak_kabupaten_madiun/repository"
 	"errors"
@@ -16,17 +17,54 @@ import (
 type RincianBelanjaServiceImpl struct {
 	rincianBelanjaRepository repository.RincianBelanjaRepository
 	pegawaiRepository        repository.PegawaiRepository
+	rencanaAksiRepository    repository.RencanaAksiRepository
+	rencanaKinerjaRepository repository.RencanaKinerjaRepository
+	opdGuardService          OpdGuardService
+	lockDataService          LockDataService
+	auditLogRepository       repository.AuditLogRepository
 	DB                       *sql.DB
 }
 
-func NewRincianBelanjaServiceImpl(rincianBelanjaRepository repository.RincianBelanjaRepository, pegawaiRepository repository.PegawaiRepository, DB *sql.DB) *RincianBelanjaServiceImpl {
+func NewRincianBelanjaServiceImpl(rincianBelanjaRepository repository.RincianBelanjaRepository, pegawaiRepository repository.PegawaiRepository, rencanaAksiRepository repository.RencanaAksiRepository, rencanaKinerjaRepository repository.RencanaKinerjaRepository, opdGuardService OpdGuardService, lockDataService LockDataService, auditLogRepository repository.AuditLogRepository, DB *sql.DB) *RincianBelanjaServiceImpl {
 	return &RincianBelanjaServiceImpl{
 		rincianBelanjaRepository: rincianBelanjaRepository,
 		pegawaiRepository:        pegawaiRepository,
+		rencanaAksiRepository:    rencanaAksiRepository,
+		rencanaKinerjaRepository: rencanaKinerjaRepository,
+		opdGuardService:          opdGuardService,
+		lockDataService:          lockDataService,
+		auditLogRepository:       auditLogRepository,
 		DB:                       DB,
 	}
 }
 
+// checkWritable memastikan rincian belanja milik renaksi boleh diubah user dan belum dikunci,
+// kode_opd dan tahun diambil dari rencana kinerja induk renaksi dan dikembalikan untuk audit log
+func (service *RincianBelanjaServiceImpl) checkWritable(ctx context.Context, tx *sql.Tx, renaksiId string) (string, string, error) {
+	rencanaAksi, err := service.rencanaAksiRepository.FindById(ctx, tx, renaksiId)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", "", web.NewNotFoundError("rencana aksi tidak ditemukan")
+		}
+		return "", "", err
+	}
+	rencanaKinerja, err := service.rencanaKinerjaRepository.FindById(ctx, tx, rencanaAksi.RencanaKinerjaId, "", "")
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", "", web.NewNotFoundError("rencana kinerja induk rencana aksi tidak ditemukan")
+		}
+		return "", "", err
+	}
+	if err := service.opdGuardService.CheckWrite(ctx, rencanaKinerja.KodeOpd); err != nil {
+		return "", "", err
+	}
+	if err := periksaRekinTerkunci(rencanaKinerja.StatusRencanaKinerja); err != nil {
+		return "", "", err
+	}
+	err = service.lockDataService.CheckWritable(ctx, LockJenisRincianBelanja, rencanaKinerja.KodeOpd, rencanaKinerja.Tahun)
+	return rencanaKinerja.KodeOpd, rencanaKinerja.Tahun, err
+}
+
 func (service *RincianBelanjaServiceImpl) Create(ctx context.Context, request rincianbelanja.RincianBelanjaCreateRequest) (rincianbelanja.RencanaAksiResponse, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -41,6 +79,10 @@ func (service *RincianBelanjaServiceImpl) Create(ctx context.Context, request ri
 	if request.Anggaran < 0 {
 		return rincianbelanja.RencanaAksiResponse{}, errors.New("anggaran tidak boleh negatif")
 	}
+	kodeOpd, tahun, err := service.checkWritable(ctx, tx, request.RenaksiId)
+	if err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
+	}
 
 	// Konversi request ke domain model
 	rincianBelanja := domain.RincianBelanja{
@@ -61,6 +103,15 @@ func (service *RincianBelanjaServiceImpl) Create(ctx context.Context, request ri
 		Anggaran:  int(result.Anggaran),
 	}
 
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRincianBelanja,
+		EntityId:   response.RenaksiId,
+		Action:     AuditActionCreate,
+		KodeOpd:    kodeOpd,
+		Tahun:      tahun,
+		After:      response,
+	})
+
 	return response, nil
 }
 
@@ -79,6 +130,16 @@ func (service *RincianBelanjaServiceImpl) Update(ctx context.Context, request ri
 	if existing.RenaksiId == "" {
 		return rincianbelanja.RencanaAksiResponse{}, errors.New("rincian belanja tidak ditemukan")
 	}
+	kodeOpd, tahun, err := service.checkWritable(ctx, tx, existing.RenaksiId)
+	if err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
+	}
+
+	before := rincianbelanja.RencanaAksiResponse{
+		RenaksiId: existing.RenaksiId,
+		Renaksi:   existing.Renaksi,
+		Anggaran:  int(existing.Anggaran),
+	}
 
 	// Konversi request ke domain model
 	rincianBelanja := domain.RincianBelanja{
@@ -99,6 +160,16 @@ func (service *RincianBelanjaServiceImpl) Update(ctx context.Context, request ri
 		Anggaran:  int(result.Anggaran),
 	}
 
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRincianBelanja,
+		EntityId:   response.RenaksiId,
+		Action:     AuditActionUpdate,
+		KodeOpd:    kodeOpd,
+		Tahun:      tahun,
+		Before:     before,
+		After:      response,
+	})
+
 	return response, nil
 }
 
@@ -255,6 +326,10 @@ func (service *RincianBelanjaServiceImpl) FindRincianBelanjaAsn(ctx context.Cont
 }
 
 func (service *RincianBelanjaServiceImpl) LaporanRincianBelanjaOpd(ctx context.Context, kodeOpd string, tahun string) ([]rincianbelanja.RincianBelanjaAsnResponse, error) {
+	if err := service.opdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return nil, err
+	}
+
 	tx, err := service.DB.Begin()
 	if err != nil {
 		return nil, err
@@ -596,6 +671,21 @@ func (service *RincianBelanjaServiceImpl) Upsert(ctx context.Context, request ri
 	if request.Anggaran < 0 {
 		return rincianbelanja.RencanaAksiResponse{}, errors.New("anggaran tidak boleh negatif")
 	}
+	kodeOpd, tahun, err := service.checkWritable(ctx, tx, request.RenaksiId)
+	if err != nil {
+		return rincianbelanja.RencanaAksiResponse{}, err
+	}
+
+	var before interface{}
+	action := AuditActionCreate
+	if existing, err := service.rincianBelanjaRepository.FindByRenaksiId(ctx, tx, request.RenaksiId); err == nil && existing.RenaksiId != "" {
+		action = AuditActionUpdate
+		before = rincianbelanja.RencanaAksiResponse{
+			RenaksiId: existing.RenaksiId,
+			Renaksi:   existing.Renaksi,
+			Anggaran:  int(existing.Anggaran),
+		}
+	}
 
 	// Konversi request ke domain model
 	rincianBelanja := domain.RincianBelanja{
@@ -622,5 +712,15 @@ func (service *RincianBelanjaServiceImpl) Upsert(ctx context.Context, request ri
 		Anggaran:  int(rincianBelanjaLengkap.Anggaran),
 	}
 
+	recordAudit(ctx, tx, service.auditLogRepository, auditEntry{
+		EntityType: AuditEntityRincianBelanja,
+		EntityId:   response.RenaksiId,
+		Action:     action,
+		KodeOpd:    kodeOpd,
+		Tahun:      tahun,
+		Before:     before,
+		After:      response,
+	})
+
 	return response, nil
 }
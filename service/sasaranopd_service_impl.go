@@ -5,6 +5,7 @@ import (
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/pohonkinerja"
 	"ekak_kabupaten_madiun/model/web/sasaranopd"
 	"ekak_kabupaten_madiun/repository"
@@ -28,6 +29,7 @@ type SasaranOpdServiceImpl struct {
 	DB                        *sql.DB
 	validate                  *validator.Validate
 	tujuanOpdRepository       repository.TujuanOpdRepository
+	lockDataService           LockDataService
 }
 
 func NewSasaranOpdServiceImpl(
@@ -38,6 +40,7 @@ func NewSasaranOpdServiceImpl(
 	pegawaiRepository repository.PegawaiRepository,
 	pohonkinerjaRepository repository.PohonKinerjaRepository,
 	tujuanOpdRepository repository.TujuanOpdRepository,
+	lockDataService LockDataService,
 	db *sql.DB,
 	validate *validator.Validate,
 ) *SasaranOpdServiceImpl {
@@ -49,6 +52,7 @@ func NewSasaranOpdServiceImpl(
 		pegawaiRepository:         pegawaiRepository,
 		pohonkinerjaRepository:    pohonkinerjaRepository,
 		tujuanOpdRepository:       tujuanOpdRepository,
+		lockDataService:           lockDataService,
 		DB:                        db,
 		validate:                  validate,
 	}
@@ -247,13 +251,17 @@ func (service *SasaranOpdServiceImpl) Create(ctx context.Context, request sasara
 		return nil, err
 	}
 
-	_, err = service.pohonkinerjaRepository.FindById(ctx, tx, request.IdPohon)
+	pokin, err := service.pohonkinerjaRepository.FindById(ctx, tx, request.IdPohon)
 	if err != nil {
 		if errors.Is(err, sql.ErrNoRows) {
 			return nil, fmt.Errorf("data pohon kinerja dengan ID %d tidak ditemukan", request.IdPohon)
 		}
 		return nil, fmt.Errorf("gagal mengambil data pohon kinerja: %v", err)
 	}
+	err = service.lockDataService.CheckWritable(ctx, LockJenisSasaranOpd, pokin.KodeOpd, pokin.Tahun)
+	if err != nil {
+		return nil, err
+	}
 	// Generate ID yang lebih unik untuk sasaran OPD
 	idSasaran := rand.Intn(100000)
 
@@ -376,6 +384,9 @@ func (service *SasaranOpdServiceImpl) Update(ctx context.Context, request sasara
 	if err != nil {
 		return nil, errors.New("sasaran opd tidak ditemukan")
 	}
+	if err := service.checkSasaranWritable(ctx, tx, request.IdSasaranOpd, LockJenisSasaranOpd, ""); err != nil {
+		return nil, err
+	}
 	// Validasi tujuan OPD ada
 	tujuanOpd, err := service.tujuanOpdRepository.FindById(ctx, tx, request.IdTujuanOpd)
 	if err != nil {
@@ -487,6 +498,12 @@ func (service *SasaranOpdServiceImpl) Delete(ctx context.Context, id string) err
 	}
 	defer helper.CommitOrRollback(tx)
 
+	if idSasaran, errConv := strconv.Atoi(id); errConv == nil {
+		if err := service.checkSasaranWritable(ctx, tx, idSasaran, LockJenisSasaranOpd, ""); err != nil {
+			return err
+		}
+	}
+
 	err = service.sasaranOpdRepository.Delete(ctx, tx, id)
 	if err != nil {
 		return err
@@ -495,6 +512,22 @@ func (service *SasaranOpdServiceImpl) Delete(ctx context.Context, id string) err
 	return nil
 }
 
+// checkSasaranWritable mengecek lock berdasarkan kode_opd pohon sasaran,
+// tahun kosong berarti memakai tahun pohon kinerja sasaran
+func (service *SasaranOpdServiceImpl) checkSasaranWritable(ctx context.Context, tx *sql.Tx, sasaranOpdId int, jenisData string, tahun string) error {
+	sasaran, err := service.sasaranOpdRepository.FindById(ctx, tx, sasaranOpdId)
+	if err != nil {
+		return err
+	}
+	if sasaran == nil {
+		return web.NewNotFoundError(fmt.Sprintf("sasaran opd id %d tidak ditemukan", sasaranOpdId))
+	}
+	if tahun == "" {
+		tahun = sasaran.TahunPohon
+	}
+	return service.lockDataService.CheckWritable(ctx, jenisData, sasaran.KodeOpd, tahun)
+}
+
 func (service *SasaranOpdServiceImpl) FindByIdPokin(ctx context.Context, idPokin int, tahun string) (*sasaranopd.SasaranOpdResponse, error) {
 	// Start transaction
 	tx, err := service.DB.Begin()
@@ -927,10 +960,19 @@ func (service *SasaranOpdServiceImpl) CreateRenjaIndikator(
 		return nil, err
 	}
 	defer helper.CommitOrRollback(tx)
-	_, err = service.sasaranOpdRepository.FindById(ctx, tx, sasaranOpdId) // ← lowercase
+	sasaran, err := service.sasaranOpdRepository.FindById(ctx, tx, sasaranOpdId) // ← lowercase
 	if err != nil {
 		return nil, fmt.Errorf("sasaran opd id %d tidak ditemukan", sasaranOpdId)
 	}
+	for _, req := range requests {
+		if len(req.Target) == 0 {
+			continue
+		}
+		err = service.lockDataService.CheckWritable(ctx, lockJenisRenja(jenis), sasaran.KodeOpd, req.Target[0].Tahun)
+		if err != nil {
+			return nil, err
+		}
+	}
 	var indikatorDomains []domain.Indikator
 	var responses []sasaranopd.IndikatorResponse
 	for _, req := range requests {
@@ -992,7 +1034,7 @@ func (service *SasaranOpdServiceImpl) UpdateRenjaIndikator(ctx context.Context,
 		return sasaranopd.IndikatorResponse{}, err
 	}
 	defer helper.CommitOrRollback(tx)
-	_, err = service.sasaranOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
+	existingIndikator, err := service.sasaranOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return sasaranopd.IndikatorResponse{}, fmt.Errorf("indikator dengan kode %s tidak ditemukan", kodeIndikator)
@@ -1011,6 +1053,9 @@ func (service *SasaranOpdServiceImpl) UpdateRenjaIndikator(ctx context.Context,
 	if request.Target[0].Tahun == "" {
 		return sasaranopd.IndikatorResponse{}, fmt.Errorf("tahun target tidak boleh kosong")
 	}
+	if err := service.checkSasaranWritable(ctx, tx, existingIndikator.SasaranOpdId, lockJenisRenja(jenis), request.Target[0].Tahun); err != nil {
+		return sasaranopd.IndikatorResponse{}, err
+	}
 	targetId := request.Target[0].Id
 	if targetId == "" {
 		targetId = fmt.Sprintf("TRG-SAR-%s", uuid.New().String()[:5])
@@ -1053,12 +1098,17 @@ func (service *SasaranOpdServiceImpl) DeleteRenjaIndikator(ctx context.Context,
 		return err
 	}
 	defer helper.CommitOrRollback(tx)
-	_, err = service.sasaranOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator) // ← lowercase
+	indikator, err := service.sasaranOpdRepository.FindIndikatorByKodeIndikator(ctx, tx, kodeIndikator) // ← lowercase
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return fmt.Errorf("kode indikator %s tidak ditemukan", kodeIndikator)
 		}
 		return err
 	}
+	if indikator.Tahun != "" {
+		if err := service.checkSasaranWritable(ctx, tx, indikator.SasaranOpdId, lockJenisRenja(indikator.Jenis), indikator.Tahun); err != nil {
+			return err
+		}
+	}
 	return service.sasaranOpdRepository.DeleteIndikatorTargetRenja(ctx, tx, kodeIndikator) // ← lowercase
 }
@@ -1291,8 +1291,6 @@ func (service *TujuanOpdServiceImpl) FindTujuanPenetapan(
 	return service.buildTujuanOpdResponse(tujuanOpds, opd, bidangUrusanMap), nil
 }
 
-const lockJenisTujuanOpd = "tujuan_opd"
-
 func (service *TujuanOpdServiceImpl) TujuanOpdPenetapan(ctx context.Context, kodeOpd, tahun, jenisPeriode string) ([]tujuanopd.TujuanOpdPenetapanResponse, error) {
 	if len(tahun) != 4 {
 		return nil, fmt.Errorf("format tahun tidak valid")
@@ -1307,7 +1305,7 @@ func (service *TujuanOpdServiceImpl) TujuanOpdPenetapan(ctx context.Context, kod
 		return nil, err
 	}
 	// ── 1. Cek status lock dari tb_lock_data ──────────────────────
-	isLocked, err := service.LockDataRepository.IsLocked(ctx, tx, lockJenisTujuanOpd, kodeOpd, tahun)
+	isLocked, err := service.LockDataRepository.IsLocked(ctx, tx, LockJenisTujuanOpd, kodeOpd, tahun)
 	if err != nil {
 		return nil, err
 	}
@@ -1625,7 +1623,7 @@ func (service *TujuanOpdServiceImpl) LockTujuanOpd(ctx context.Context, kodeOpd,
 		return err
 	}
 	defer helper.CommitOrRollback(tx)
-	return service.LockDataRepository.Lock(ctx, tx, lockJenisTujuanOpd, kodeOpd, tahun)
+	return service.LockDataRepository.Lock(ctx, tx, LockJenisTujuanOpd, kodeOpd, tahun)
 }
 func (service *TujuanOpdServiceImpl) UnlockTujuanOpd(ctx context.Context, kodeOpd, tahun string) error {
 	tx, err := service.DB.Begin()
@@ -1633,7 +1631,7 @@ func (service *TujuanOpdServiceImpl) UnlockTujuanOpd(ctx context.Context, kodeOp
 		return err
 	}
 	defer helper.CommitOrRollback(tx)
-	return service.LockDataRepository.Unlock(ctx, tx, lockJenisTujuanOpd, kodeOpd, tahun)
+	return service.LockDataRepository.Unlock(ctx, tx, LockJenisTujuanOpd, kodeOpd, tahun)
 }
 
 // rankhiir builder
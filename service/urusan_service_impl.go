@@ -109,9 +109,10 @@ func (service *UrusanServiceImpl) FindAll(ctx context.Context) ([]urusanrespon.U
 	var urusanResponses []urusanrespon.UrusanResponse
 	for _, urusan := range urusans {
 		urusanResponses = append(urusanResponses, urusanrespon.UrusanResponse{
-			Id:         urusan.Id,
-			KodeUrusan: urusan.KodeUrusan,
-			NamaUrusan: urusan.NamaUrusan,
+			Id:           urusan.Id,
+			KodeUrusan:   urusan.KodeUrusan,
+			NamaUrusan:   urusan.NamaUrusan,
+			IsDeprecated: urusan.IsDeprecated,
 		})
 	}
 
@@ -196,6 +197,7 @@ func (service *UrusanServiceImpl) FindUrusanAndBidangByKodeOpd(ctx context.Conte
 			bidangResponses = append(bidangResponses, bidangurusanresponse.BidangUrusanResponse{
 				KodeBidangUrusan: bidang.KodeBidangUrusan,
 				NamaBidangUrusan: bidang.NamaBidangUrusan,
+				IsDeprecated:     bidang.IsDeprecated,
 			})
 			// Debug: cetak setiap bidang urusan yang dikonversi
 			fmt.Printf("  Added bidang: %s - %s\n", bidang.KodeBidangUrusan, bidang.NamaBidangUrusan)
@@ -205,6 +207,7 @@ func (service *UrusanServiceImpl) FindUrusanAndBidangByKodeOpd(ctx context.Conte
 			Id:           urusan.Id,
 			KodeUrusan:   urusan.KodeUrusan,
 			NamaUrusan:   urusan.NamaUrusan,
+			IsDeprecated: urusan.IsDeprecated,
 			BidangUrusan: bidangResponses,
 		})
 	}
@@ -12,6 +12,12 @@ type UserService interface {
 	FindAll(ctx context.Context, kodeOpd string) ([]user.UserResponse, error)
 	FindById(ctx context.Context, id int) (user.UserResponse, error)
 	Login(ctx context.Context, request user.UserLoginRequest) (user.UserLoginResponse, error)
+	RefreshToken(ctx context.Context, request user.UserRefreshRequest) (user.UserLoginResponse, error)
+	Logout(ctx context.Context, request user.UserLogoutRequest) error
+	ChangePassword(ctx context.Context, request user.UserChangePasswordRequest) error
+	ResetPassword(ctx context.Context, userId int) (user.UserResetPasswordResponse, error)
+	ConfirmResetPassword(ctx context.Context, request user.UserResetPasswordConfirmRequest) error
+	UnlockLogin(ctx context.Context, id int) error
 	FindByKodeOpdAndRole(ctx context.Context, kodeOpd string, roleName string) ([]user.UserResponse, error)
 	FindByNip(ctx context.Context, nip string) (user.UserResponse, error)
 	CekAdminOpd(ctx context.Context) ([]user.CekAdminOpdResponse, error)
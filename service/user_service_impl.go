@@ -2,32 +2,51 @@ package service
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/sha256"
 	"database/sql"
 	"ekak_kabupaten_madiun/helper"
 	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
 	"ekak_kabupaten_madiun/model/web/user"
 	"ekak_kabupaten_madiun/repository"
+	"encoding/hex"
 	"errors"
+	"fmt"
+	"log"
+	"math"
 	"sort"
+	"time"
 
+	"github.com/redis/go-redis/v9"
 	"golang.org/x/crypto/bcrypt"
 )
 
 type UserServiceImpl struct {
-	UserRepository    repository.UserRepository
-	RoleRepository    repository.RoleRepository
-	PegawaiRepository repository.PegawaiRepository
-	OpdRepository     repository.OpdRepository
-	DB                *sql.DB
+	UserRepository          repository.UserRepository
+	RoleRepository          repository.RoleRepository
+	PegawaiRepository       repository.PegawaiRepository
+	OpdRepository           repository.OpdRepository
+	PasswordResetRepository repository.PasswordResetRepository
+	OpdGuardService         OpdGuardService
+	LoginLimiter            *helper.LoginLimiter
+	DB                      *sql.DB
 }
 
-func NewUserServiceImpl(userRepository repository.UserRepository, roleRepository repository.RoleRepository, pegawaiRepository repository.PegawaiRepository, opdRepository repository.OpdRepository, db *sql.DB) *UserServiceImpl {
+// masa berlaku token reset password yang diterbitkan admin
+const passwordResetTokenTTL = 24 * time.Hour
+
+func NewUserServiceImpl(userRepository repository.UserRepository, roleRepository repository.RoleRepository, pegawaiRepository repository.PegawaiRepository, opdRepository repository.OpdRepository, passwordResetRepository repository.PasswordResetRepository, opdGuardService OpdGuardService, redisClient *redis.Client, db *sql.DB) *UserServiceImpl {
 	return &UserServiceImpl{
-		UserRepository:    userRepository,
-		RoleRepository:    roleRepository,
-		PegawaiRepository: pegawaiRepository,
-		OpdRepository:     opdRepository,
-		DB:                db,
+		UserRepository:          userRepository,
+		RoleRepository:          roleRepository,
+		PegawaiRepository:       pegawaiRepository,
+		OpdRepository:           opdRepository,
+		PasswordResetRepository: passwordResetRepository,
+		OpdGuardService:         opdGuardService,
+		LoginLimiter:            helper.NewLoginLimiter(redisClient),
+		DB:                      db,
 	}
 }
 
@@ -72,6 +91,9 @@ func (service *UserServiceImpl) Create(ctx context.Context, request user.UserCre
 		}
 		roles = append(roles, role)
 	}
+	if err := service.checkKelolaUser(ctx, tx, request.Nip, roles); err != nil {
+		return user.UserResponse{}, err
+	}
 
 	userDomain := domain.Users{
 		Nip:      request.Nip,
@@ -113,6 +135,38 @@ func (service *UserServiceImpl) Create(ctx context.Context, request user.UserCre
 	return response, nil
 }
 
+// checkKelolaUser admin_opd hanya boleh mengelola user pegawai OPD-nya sendiri dan tanpa role super_admin
+func (service *UserServiceImpl) checkKelolaUser(ctx context.Context, tx *sql.Tx, nip string, roles []domain.Roles) error {
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok {
+		return nil
+	}
+	if err := periksaRoleKelolaUser(claims, roles); err != nil {
+		return err
+	}
+	if helper.HasAnyRole(claims, helper.RoleSuperAdmin) {
+		return nil
+	}
+	pegawaiDomain, err := service.PegawaiRepository.FindByNip(ctx, tx, nip)
+	if err != nil && err != sql.ErrNoRows {
+		return err
+	}
+	return service.OpdGuardService.CheckWrite(ctx, pegawaiDomain.KodeOpd)
+}
+
+// periksaRoleKelolaUser role super_admin hanya boleh diberikan, diubah atau dihapus oleh super_admin
+func periksaRoleKelolaUser(claims web.JWTClaim, roles []domain.Roles) error {
+	if helper.HasAnyRole(claims, helper.RoleSuperAdmin) {
+		return nil
+	}
+	for _, role := range roles {
+		if role.Role == helper.RoleSuperAdmin {
+			return web.NewForbiddenError("hanya super_admin yang dapat mengelola user super_admin")
+		}
+	}
+	return nil
+}
+
 func (service *UserServiceImpl) Update(ctx context.Context, request user.UserUpdateRequest) (user.UserResponse, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -154,6 +208,13 @@ func (service *UserServiceImpl) Update(ctx context.Context, request user.UserUpd
 		}
 		roles = append(roles, role)
 	}
+	// user lama dan hasil perubahan sama-sama harus dalam wewenang pemanggil
+	if err := service.checkKelolaUser(ctx, tx, existingUser.Nip, existingUser.Role); err != nil {
+		return user.UserResponse{}, err
+	}
+	if err := service.checkKelolaUser(ctx, tx, request.Nip, roles); err != nil {
+		return user.UserResponse{}, err
+	}
 
 	userDomain := domain.Users{
 		Id:       existingUser.Id,
@@ -179,6 +240,14 @@ func (service *UserServiceImpl) Update(ctx context.Context, request user.UserUpd
 		return user.UserResponse{}, err
 	}
 
+	// password ditimpa admin, akun dinonaktifkan, atau role berubah: sesi lama tidak berlaku lagi
+	if request.Password != "" || (existingUser.IsActive && !request.IsActive) || roleBerubah(existingUser.Role, roles) {
+		err = helper.RevokeUserSessions(ctx, existingUser.Id)
+		if err != nil {
+			return user.UserResponse{}, err
+		}
+	}
+
 	// Konversi role ke response
 	var roleResponses []user.RoleResponse
 	for _, role := range updatedUser.Role {
@@ -213,6 +282,9 @@ func (service *UserServiceImpl) Delete(ctx context.Context, id int) error {
 	if existingUser.Id == 0 {
 		return errors.New("user tidak ditemukan")
 	}
+	if err := service.checkKelolaUser(ctx, tx, existingUser.Nip, existingUser.Role); err != nil {
+		return err
+	}
 
 	err = service.UserRepository.Delete(ctx, tx, id)
 	if err != nil {
@@ -331,9 +403,46 @@ func (service *UserServiceImpl) FindById(ctx context.Context, id int) (user.User
 		Role:        roles,
 	}
 
+	if claims, ok := helper.GetUserClaims(ctx); ok && helper.HasAnyRole(claims, helper.RoleSuperAdmin) {
+		attempt := service.LoginLimiter.Get(ctx, helper.LoginKeyNip(userDomain.Nip))
+		lockout := &user.LoginLockoutResponse{Failures: attempt.Failures}
+		if locked, _ := attempt.IsLocked(); locked {
+			lockout.IsLocked = true
+			lockout.LockedUntil = &attempt.LockedUntil
+		}
+		response.LoginLockout = lockout
+	}
+
 	return response, nil
 }
 
+func (service *UserServiceImpl) UnlockLogin(ctx context.Context, id int) error {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	userDomain, err := service.UserRepository.FindById(ctx, tx, id)
+	if err != nil {
+		return err
+	}
+	if userDomain.Id == 0 {
+		return web.NewNotFoundError("user tidak ditemukan")
+	}
+
+	pegawaiDomain, err := service.PegawaiRepository.FindByNip(ctx, tx, userDomain.Nip)
+	if err != nil {
+		return err
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, pegawaiDomain.KodeOpd); err != nil {
+		return err
+	}
+
+	service.LoginLimiter.Reset(ctx, helper.LoginKeyNip(userDomain.Nip))
+	return nil
+}
+
 // func (service *UserServiceImpl) Login(ctx context.Context, request user.UserLoginRequest) (user.UserLoginResponse, error) {
 // 	tx, err := service.DB.Begin()
 // 	if err != nil {
@@ -392,12 +501,6 @@ func (service *UserServiceImpl) FindById(ctx context.Context, id int) (user.User
 // }
 
 func (service *UserServiceImpl) Login(ctx context.Context, request user.UserLoginRequest) (user.UserLoginResponse, error) {
-	tx, err := service.DB.Begin()
-	if err != nil {
-		return user.UserLoginResponse{}, err
-	}
-	defer helper.CommitOrRollback(tx)
-
 	// Validasi input
 	if request.Username == "" {
 		return user.UserLoginResponse{}, errors.New("nip harus diisi")
@@ -406,6 +509,60 @@ func (service *UserServiceImpl) Login(ctx context.Context, request user.UserLogi
 		return user.UserLoginResponse{}, errors.New("password harus diisi")
 	}
 
+	// username bisa berupa email, lockout selalu disimpan per NIP agar terbaca di FindById dan UnlockLogin
+	nipKey := helper.LoginKeyNip(service.resolveLoginNip(ctx, request.Username))
+	ipKey := helper.LoginKeyIp(request.ClientIp)
+	for _, key := range []string{nipKey, ipKey} {
+		if locked, remaining := service.LoginLimiter.Get(ctx, key).IsLocked(); locked {
+			return user.UserLoginResponse{}, loginLockedError(remaining)
+		}
+	}
+
+	response, err := service.login(ctx, request)
+	if err != nil {
+		if err == errLoginFailed {
+			nipAttempt := service.LoginLimiter.RegisterFailure(ctx, nipKey, helper.LoginMaxFailuresNip)
+			ipAttempt := service.LoginLimiter.RegisterFailure(ctx, ipKey, helper.LoginMaxFailuresIp)
+			if nipAttempt.Failures >= helper.LoginMaxFailuresNip || ipAttempt.Failures >= helper.LoginMaxFailuresIp {
+				log.Printf("[Login] percobaan login gagal berulang: nip=%s ip=%s gagal_nip=%d gagal_ip=%d", request.Username, request.ClientIp, nipAttempt.Failures, ipAttempt.Failures)
+			}
+		}
+		return user.UserLoginResponse{}, err
+	}
+
+	service.LoginLimiter.Reset(ctx, nipKey)
+	return response, nil
+}
+
+var errLoginFailed = errors.New("nip atau password salah")
+
+// resolveLoginNip NIP user dari username nip/email, username yang tidak terdaftar tetap dihitung apa adanya
+func (service *UserServiceImpl) resolveLoginNip(ctx context.Context, username string) string {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return username
+	}
+	defer tx.Rollback()
+
+	userDomain, err := service.UserRepository.FindByEmailOrNip(ctx, tx, username)
+	if err != nil || userDomain.Nip == "" {
+		return username
+	}
+	return userDomain.Nip
+}
+
+func loginLockedError(remaining time.Duration) error {
+	minutes := int(math.Ceil(remaining.Minutes()))
+	return web.NewTooManyRequestsError(fmt.Sprintf("terlalu banyak percobaan login gagal, coba lagi dalam %d menit", minutes))
+}
+
+func (service *UserServiceImpl) login(ctx context.Context, request user.UserLoginRequest) (user.UserLoginResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
 	// Validasi format NIP
 	// if !helper.IsValidNIP(request.Username) {
 	// 	return user.UserLoginResponse{}, errors.New("format nip tidak valid")
@@ -415,7 +572,7 @@ func (service *UserServiceImpl) Login(ctx context.Context, request user.UserLogi
 	userDomain, err := service.UserRepository.FindByEmailOrNip(ctx, tx, request.Username)
 	if err != nil {
 		if err == sql.ErrNoRows {
-			return user.UserLoginResponse{}, errors.New("nip atau password salah")
+			return user.UserLoginResponse{}, errLoginFailed
 		}
 		return user.UserLoginResponse{}, err
 	}
@@ -437,13 +594,18 @@ func (service *UserServiceImpl) Login(ctx context.Context, request user.UserLogi
 
 	err = bcrypt.CompareHashAndPassword([]byte(userDomain.Password), []byte(request.Password))
 	if err != nil {
-		return user.UserLoginResponse{}, errors.New("nip atau password salah")
+		return user.UserLoginResponse{}, errLoginFailed
 	}
 
 	if !userDomain.IsActive {
 		return user.UserLoginResponse{}, errors.New("akun tidak aktif")
 	}
 
+	return issueTokenPair(userDomain, pegawaiDomain, opdDomain)
+}
+
+// issueTokenPair membuat access token dan refresh token baru untuk user
+func issueTokenPair(userDomain domain.Users, pegawaiDomain domainmaster.Pegawai, opdDomain domainmaster.Opd) (user.UserLoginResponse, error) {
 	roleNames := make([]string, 0, len(userDomain.Role))
 	for _, role := range userDomain.Role {
 		roleNames = append(roleNames, role.Role)
@@ -460,13 +622,91 @@ func (service *UserServiceImpl) Login(ctx context.Context, request user.UserLogi
 		roleNames,
 	)
 
+	refreshToken, err := helper.CreateRefreshToken(userDomain.Id)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
 	response := user.UserLoginResponse{
-		Token: token,
+		Token:        token,
+		RefreshToken: refreshToken,
+		ExpiresIn:    int64(helper.AccessTokenDuration().Seconds()),
 	}
 
 	return response, nil
 }
 
+func (service *UserServiceImpl) RefreshToken(ctx context.Context, request user.UserRefreshRequest) (user.UserLoginResponse, error) {
+	if request.RefreshToken == "" {
+		return user.UserLoginResponse{}, errors.New("refresh token harus diisi")
+	}
+
+	refreshClaims, err := helper.ValidateRefreshToken(request.RefreshToken)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	// data user diambil ulang agar perubahan role / status aktif langsung berlaku
+	userDomain, err := service.UserRepository.FindById(ctx, tx, refreshClaims.UserId)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+	if userDomain.Id == 0 {
+		return user.UserLoginResponse{}, errors.New("user tidak ditemukan")
+	}
+	if !userDomain.IsActive {
+		return user.UserLoginResponse{}, errors.New("akun tidak aktif")
+	}
+
+	pegawaiDomain, err := service.PegawaiRepository.FindByNip(ctx, tx, userDomain.Nip)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
+	opdDomain, err := service.OpdRepository.FindByKodeOpd(ctx, tx, pegawaiDomain.KodeOpd)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
+	// rotasi: refresh token lama dipakai secara atomik, request bersamaan dengan token yang sama ditolak
+	err = helper.ConsumeRefreshToken(ctx, refreshClaims.Jti, refreshClaims.Exp)
+	if err != nil {
+		return user.UserLoginResponse{}, err
+	}
+
+	return issueTokenPair(userDomain, pegawaiDomain, opdDomain)
+}
+
+func (service *UserServiceImpl) Logout(ctx context.Context, request user.UserLogoutRequest) error {
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok || claims.UserId == 0 {
+		return errors.New("token tidak valid")
+	}
+
+	err := helper.RevokeToken(ctx, claims.Jti, claims.Exp)
+	if err != nil {
+		return err
+	}
+
+	if request.RefreshToken != "" {
+		refreshClaims, err := helper.ValidateRefreshToken(request.RefreshToken)
+		if err == nil && refreshClaims.UserId == claims.UserId {
+			err = helper.RevokeToken(ctx, refreshClaims.Jti, refreshClaims.Exp)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
 func (service *UserServiceImpl) FindByKodeOpdAndRole(ctx context.Context, kodeOpd string, roleName string) ([]user.UserResponse, error) {
 	tx, err := service.DB.Begin()
 	if err != nil {
@@ -597,3 +837,213 @@ func (service *UserServiceImpl) CekAdminOpd(ctx context.Context) ([]user.CekAdmi
 
 	return response, nil
 }
+
+func (service *UserServiceImpl) ChangePassword(ctx context.Context, request user.UserChangePasswordRequest) error {
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok || claims.UserId == 0 {
+		return errors.New("token tidak valid")
+	}
+
+	if request.PasswordLama == "" {
+		return web.NewBadRequestError("password lama harus diisi")
+	}
+	if request.PasswordBaru != request.KonfirmasiPassword {
+		return web.NewBadRequestError("konfirmasi password tidak sama")
+	}
+	if request.PasswordBaru == request.PasswordLama {
+		return web.NewBadRequestError("password baru tidak boleh sama dengan password lama")
+	}
+	if err := helper.ValidatePasswordPolicy(request.PasswordBaru); err != nil {
+		return web.NewBadRequestError(err.Error())
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	// rollback eksplisit karena CommitOrRollback tetap commit saat fungsi mengembalikan error
+	defer tx.Rollback()
+
+	userDomain, err := service.UserRepository.FindById(ctx, tx, claims.UserId)
+	if err != nil {
+		return err
+	}
+	if userDomain.Id == 0 {
+		return web.NewNotFoundError("user tidak ditemukan")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(userDomain.Password), []byte(request.PasswordLama))
+	if err != nil {
+		return web.NewBadRequestError("password lama salah")
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.PasswordBaru), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	err = service.UserRepository.UpdatePassword(ctx, tx, userDomain.Id, string(hashedPassword))
+	if err != nil {
+		return err
+	}
+
+	// token reset yang belum dipakai ikut dibatalkan
+	err = service.PasswordResetRepository.InvalidateByUserId(ctx, tx, userDomain.Id)
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	// sesi dicabut setelah password baru tersimpan
+	return helper.RevokeUserSessions(ctx, userDomain.Id)
+}
+
+func (service *UserServiceImpl) ResetPassword(ctx context.Context, userId int) (user.UserResetPasswordResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return user.UserResetPasswordResponse{}, err
+	}
+	defer tx.Rollback()
+
+	userDomain, err := service.UserRepository.FindById(ctx, tx, userId)
+	if err != nil {
+		return user.UserResetPasswordResponse{}, err
+	}
+	if userDomain.Id == 0 {
+		return user.UserResetPasswordResponse{}, web.NewNotFoundError("user tidak ditemukan")
+	}
+
+	// admin_opd hanya boleh mereset user di OPD-nya sendiri, token reset super_admin sama dengan mengambil alih akunnya
+	if err := service.checkKelolaUser(ctx, tx, userDomain.Nip, userDomain.Role); err != nil {
+		return user.UserResetPasswordResponse{}, err
+	}
+
+	token, err := generateResetToken()
+	if err != nil {
+		return user.UserResetPasswordResponse{}, err
+	}
+
+	err = service.PasswordResetRepository.InvalidateByUserId(ctx, tx, userDomain.Id)
+	if err != nil {
+		return user.UserResetPasswordResponse{}, err
+	}
+
+	createdBy := ""
+	if claims, ok := helper.GetUserClaims(ctx); ok {
+		createdBy = claims.Nip
+	}
+
+	reset, err := service.PasswordResetRepository.Create(ctx, tx, domain.PasswordReset{
+		UserId:    userDomain.Id,
+		TokenHash: hashResetToken(token),
+		CreatedBy: createdBy,
+	}, passwordResetTokenTTL)
+	if err != nil {
+		return user.UserResetPasswordResponse{}, err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return user.UserResetPasswordResponse{}, err
+	}
+
+	// sesi lama langsung tidak berlaku sejak password direset
+	err = helper.RevokeUserSessions(ctx, userDomain.Id)
+	if err != nil {
+		return user.UserResetPasswordResponse{}, err
+	}
+
+	return user.UserResetPasswordResponse{
+		UserId:    userDomain.Id,
+		Nip:       userDomain.Nip,
+		Token:     token,
+		ExpiredAt: reset.ExpiredAt,
+	}, nil
+}
+
+func (service *UserServiceImpl) ConfirmResetPassword(ctx context.Context, request user.UserResetPasswordConfirmRequest) error {
+	if request.Token == "" {
+		return web.NewBadRequestError("token harus diisi")
+	}
+	if request.PasswordBaru != request.KonfirmasiPassword {
+		return web.NewBadRequestError("konfirmasi password tidak sama")
+	}
+	if err := helper.ValidatePasswordPolicy(request.PasswordBaru); err != nil {
+		return web.NewBadRequestError(err.Error())
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return err
+	}
+	defer tx.Rollback()
+
+	// baris token dikunci agar dua request dengan token yang sama tidak sama-sama lolos
+	reset, err := service.PasswordResetRepository.FindValidByTokenHash(ctx, tx, hashResetToken(request.Token))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return web.NewBadRequestError("token reset password tidak valid atau sudah kedaluwarsa")
+		}
+		return err
+	}
+
+	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(request.PasswordBaru), bcrypt.DefaultCost)
+	if err != nil {
+		return err
+	}
+
+	err = service.UserRepository.UpdatePassword(ctx, tx, reset.UserId, string(hashedPassword))
+	if err != nil {
+		return err
+	}
+
+	// token hanya bisa dipakai sekali
+	err = service.PasswordResetRepository.MarkUsed(ctx, tx, reset.Id)
+	if errors.Is(err, sql.ErrNoRows) {
+		return web.NewBadRequestError("token reset password tidak valid atau sudah kedaluwarsa")
+	}
+	if err != nil {
+		return err
+	}
+	if err := tx.Commit(); err != nil {
+		return err
+	}
+
+	return helper.RevokeUserSessions(ctx, reset.UserId)
+}
+
+// roleBerubah membandingkan himpunan role id lama dan baru
+func roleBerubah(lama []domain.Roles, baru []domain.Roles) bool {
+	idLama := make(map[int]bool, len(lama))
+	for _, role := range lama {
+		idLama[role.Id] = true
+	}
+	idBaru := make(map[int]bool, len(baru))
+	for _, role := range baru {
+		idBaru[role.Id] = true
+	}
+	if len(idLama) != len(idBaru) {
+		return true
+	}
+	for id := range idBaru {
+		if !idLama[id] {
+			return true
+		}
+	}
+	return false
+}
+
+func generateResetToken() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(buf), nil
+}
+
+// hanya hash token yang disimpan di database
+func hashResetToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,60 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"testing"
+)
+
+func TestPeriksaRoleKelolaUser(t *testing.T) {
+	superAdmin := web.JWTClaim{Roles: []string{helper.RoleSuperAdmin}}
+	adminOpd := web.JWTClaim{Roles: []string{helper.RoleAdminOpd}}
+	roleSuperAdmin := []domain.Roles{{Id: 1, Role: helper.RoleSuperAdmin}}
+	roleAsn := []domain.Roles{{Id: 8, Role: helper.RoleAsn}, {Id: 2, Role: helper.RoleAdminOpd}}
+
+	tests := []struct {
+		name    string
+		claims  web.JWTClaim
+		roles   []domain.Roles
+		ditolak bool
+	}{
+		{name: "super admin memberi super admin", claims: superAdmin, roles: roleSuperAdmin},
+		{name: "admin opd memberi role biasa", claims: adminOpd, roles: roleAsn},
+		{name: "admin opd memberi super admin", claims: adminOpd, roles: roleSuperAdmin, ditolak: true},
+		{name: "admin opd mengelola super admin", claims: adminOpd, roles: append(roleAsn, roleSuperAdmin...), ditolak: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := periksaRoleKelolaUser(tt.claims, tt.roles)
+			if (err != nil) != tt.ditolak {
+				t.Errorf("periksaRoleKelolaUser() error = %v, ditolak %v", err, tt.ditolak)
+			}
+		})
+	}
+}
+
+func TestRoleBerubah(t *testing.T) {
+	asn := domain.Roles{Id: 8, Role: helper.RoleAsn}
+	adminOpd := domain.Roles{Id: 2, Role: helper.RoleAdminOpd}
+
+	tests := []struct {
+		name    string
+		lama    []domain.Roles
+		baru    []domain.Roles
+		berubah bool
+	}{
+		{name: "sama dengan urutan berbeda", lama: []domain.Roles{asn, adminOpd}, baru: []domain.Roles{adminOpd, asn}},
+		{name: "role ditambah", lama: []domain.Roles{asn}, baru: []domain.Roles{asn, adminOpd}, berubah: true},
+		{name: "role diganti", lama: []domain.Roles{asn}, baru: []domain.Roles{adminOpd}, berubah: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := roleBerubah(tt.lama, tt.baru); got != tt.berubah {
+				t.Errorf("roleBerubah() = %v, expected %v", got, tt.berubah)
+			}
+		})
+	}
+}
@@ -0,0 +1,368 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/usulan"
+	"ekak_kabupaten_madiun/repository"
+	"encoding/csv"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/google/uuid"
+)
+
+const (
+	JenisImportMusrebang    = "musrebang"
+	JenisImportPokokPikiran = "pokok_pikiran"
+
+	StatusBarisImportDiterima = "diterima"
+	StatusBarisImportDitolak  = "ditolak"
+)
+
+// judul kolom yang dikenali dari export sistem musrenbang dan pokir DPRD, sudah dinormalisasi
+var kolomImportUsulan = map[string][]string{
+	"usulan":   {"usulan", "nama usulan", "judul usulan", "usulan kegiatan", "kegiatan usulan"},
+	"alamat":   {"alamat", "lokasi", "alamat lokasi", "lokasi usulan", "alamat usulan"},
+	"uraian":   {"uraian", "uraian usulan", "keterangan", "deskripsi", "permasalahan"},
+	"opd":      {"opd", "nama opd", "opd tujuan", "perangkat daerah", "perangkat daerah tujuan", "skpd", "nama skpd"},
+	"kode_opd": {"kode opd", "kode perangkat daerah", "kode skpd"},
+	"tahun":    {"tahun", "tahun usulan"},
+}
+
+// batas baris yang diperiksa untuk mencari judul kolom, export biasanya diawali judul laporan
+const maksBarisJudulImportUsulan = 10
+
+var (
+	nonAlfanumerik = regexp.MustCompile(`[^a-z0-9]+`)
+	polaTahun      = regexp.MustCompile(`^\d{4}$`)
+)
+
+// barisImportUsulan satu baris data file, Nomor mengikuti nomor baris di file
+type barisImportUsulan struct {
+	Nomor   int
+	Usulan  string
+	Alamat  string
+	Uraian  string
+	NamaOpd string
+	KodeOpd string
+	Tahun   string
+}
+
+func normalisasiTeksImport(teks string) string {
+	return strings.TrimSpace(nonAlfanumerik.ReplaceAllString(strings.ToLower(teks), " "))
+}
+
+// normalisasiNamaOpd nama OPD di file sering diakhiri nama kabupaten
+func normalisasiNamaOpd(nama string) string {
+	nama = normalisasiTeksImport(nama)
+	for _, akhiran := range []string{" kabupaten madiun", " kab madiun"} {
+		nama = strings.TrimSuffix(nama, akhiran)
+	}
+	return nama
+}
+
+// bacaTabelImport file xlsx dikenali dari signature zip, selain itu dibaca sebagai csv
+func bacaTabelImport(content []byte) ([][]string, error) {
+	if bytes.HasPrefix(content, []byte("PK\x03\x04")) {
+		sheets, err := helper.BacaXlsx(content)
+		if err != nil {
+			return nil, web.NewBadRequestError(err.Error())
+		}
+		if len(sheets) == 0 {
+			return nil, web.NewBadRequestError("spreadsheet kosong")
+		}
+		return sheets[0].Baris, nil
+	}
+
+	content = bytes.TrimPrefix(content, []byte("\xef\xbb\xbf"))
+	reader := csv.NewReader(bytes.NewReader(content))
+	reader.Comma = pemisahCsv(content)
+	reader.FieldsPerRecord = -1
+	reader.LazyQuotes = true
+	rows, err := reader.ReadAll()
+	if err != nil {
+		return nil, web.NewBadRequestError(fmt.Sprintf("file csv tidak valid: %v", err))
+	}
+	return rows, nil
+}
+
+// pemisahCsv export SIPD memakai titik koma, export lain memakai koma atau tab
+func pemisahCsv(content []byte) rune {
+	barisPertama := content
+	if i := bytes.IndexByte(content, '\n'); i >= 0 {
+		barisPertama = content[:i]
+	}
+	pemisah, jumlah := ',', bytes.Count(barisPertama, []byte(","))
+	for _, kandidat := range []rune{';', '\t'} {
+		if n := bytes.Count(barisPertama, []byte(string(kandidat))); n > jumlah {
+			pemisah, jumlah = kandidat, n
+		}
+	}
+	return pemisah
+}
+
+// bacaBarisImportUsulan kolom dicari berdasarkan judulnya sehingga urutan kolom boleh berubah
+func bacaBarisImportUsulan(content []byte) ([]barisImportUsulan, error) {
+	rows, err := bacaTabelImport(content)
+	if err != nil {
+		return nil, err
+	}
+
+	barisJudul, indeksKolom := -1, map[string]int{}
+	for i := 0; i < len(rows) && i < maksBarisJudulImportUsulan; i++ {
+		indeks := make(map[string]int)
+		for j, judul := range rows[i] {
+			judul = normalisasiTeksImport(judul)
+			for kolom, alias := range kolomImportUsulan {
+				if _, ada := indeks[kolom]; ada {
+					continue
+				}
+				for _, a := range alias {
+					if judul == a {
+						indeks[kolom] = j
+					}
+				}
+			}
+		}
+		if _, ada := indeks["usulan"]; ada {
+			barisJudul, indeksKolom = i, indeks
+			break
+		}
+	}
+	if barisJudul < 0 {
+		return nil, web.NewBadRequestError("kolom usulan tidak ditemukan pada file")
+	}
+	_, adaOpd := indeksKolom["opd"]
+	_, adaKodeOpd := indeksKolom["kode_opd"]
+	if !adaOpd && !adaKodeOpd {
+		return nil, web.NewBadRequestError("kolom opd atau kode opd tidak ditemukan pada file")
+	}
+
+	sel := func(baris []string, kolom string) string {
+		indeks, ok := indeksKolom[kolom]
+		if !ok || indeks >= len(baris) {
+			return ""
+		}
+		return strings.TrimSpace(baris[indeks])
+	}
+
+	var result []barisImportUsulan
+	for i := barisJudul + 1; i < len(rows); i++ {
+		baris := barisImportUsulan{
+			Nomor:   i + 1,
+			Usulan:  sel(rows[i], "usulan"),
+			Alamat:  sel(rows[i], "alamat"),
+			Uraian:  sel(rows[i], "uraian"),
+			NamaOpd: sel(rows[i], "opd"),
+			KodeOpd: sel(rows[i], "kode_opd"),
+			Tahun:   sel(rows[i], "tahun"),
+		}
+		if baris.Usulan == "" && baris.Alamat == "" && baris.Uraian == "" && baris.NamaOpd == "" && baris.KodeOpd == "" {
+			continue
+		}
+		result = append(result, baris)
+	}
+	return result, nil
+}
+
+// petaOpdImport nama dan singkatan OPD ternormalisasi ke kode OPD, satu nama bisa cocok ke lebih dari satu OPD
+func petaOpdImport(opds []domainmaster.Opd) map[string][]string {
+	peta := make(map[string][]string)
+	tambah := func(kunci string, kodeOpd string) {
+		if kunci == "" {
+			return
+		}
+		for _, kode := range peta[kunci] {
+			if kode == kodeOpd {
+				return
+			}
+		}
+		peta[kunci] = append(peta[kunci], kodeOpd)
+	}
+	for _, opd := range opds {
+		tambah(normalisasiNamaOpd(opd.NamaOpd), opd.KodeOpd)
+		tambah(normalisasiNamaOpd(opd.Singkatan), opd.KodeOpd)
+	}
+	return peta
+}
+
+// kunciDuplikatUsulan usulan dianggap sama bila usulan, alamat dan tahunnya sama
+func kunciDuplikatUsulan(usulanTeks, alamat, tahun string) string {
+	return normalisasiTeksImport(usulanTeks) + "|" + normalisasiTeksImport(alamat) + "|" + tahun
+}
+
+// periksaImportUsulan menentukan diterima atau ditolaknya setiap baris beserta alasannya
+func periksaImportUsulan(jenis, tahun string, rows []barisImportUsulan, opds []domainmaster.Opd, kunciAda map[string]bool) usulan.UsulanImportResponse {
+	petaOpd := petaOpdImport(opds)
+	namaOpd := make(map[string]string, len(opds))
+	for _, opd := range opds {
+		namaOpd[opd.KodeOpd] = opd.NamaOpd
+	}
+
+	laporan := usulan.UsulanImportResponse{
+		Jenis:       jenis,
+		Tahun:       tahun,
+		JumlahBaris: len(rows),
+		Baris:       make([]usulan.UsulanImportBaris, 0, len(rows)),
+	}
+	barisKunci := make(map[string]int)
+	for _, row := range rows {
+		hasil := usulan.UsulanImportBaris{
+			Baris:   row.Nomor,
+			Alasan:  []string{},
+			Usulan:  row.Usulan,
+			Alamat:  row.Alamat,
+			Uraian:  row.Uraian,
+			Tahun:   row.Tahun,
+			NamaOpd: row.NamaOpd,
+			KodeOpd: row.KodeOpd,
+		}
+		tolak := func(format string, args ...interface{}) {
+			hasil.Alasan = append(hasil.Alasan, fmt.Sprintf(format, args...))
+		}
+
+		if row.Usulan == "" {
+			tolak("usulan wajib diisi")
+		}
+		if hasil.Tahun == "" {
+			hasil.Tahun = tahun
+		}
+		if !polaTahun.MatchString(hasil.Tahun) {
+			tolak("tahun %q tidak valid", hasil.Tahun)
+		} else if hasil.Tahun != tahun {
+			tolak("tahun %s berbeda dengan tahun import %s", hasil.Tahun, tahun)
+		}
+
+		switch {
+		case row.KodeOpd != "":
+			if nama, ok := namaOpd[row.KodeOpd]; ok {
+				hasil.NamaOpd = nama
+			} else {
+				tolak("kode opd %s tidak ditemukan", row.KodeOpd)
+			}
+		case row.NamaOpd == "":
+			tolak("opd wajib diisi")
+		default:
+			kode := petaOpd[normalisasiNamaOpd(row.NamaOpd)]
+			switch len(kode) {
+			case 0:
+				tolak("opd %q tidak ditemukan", row.NamaOpd)
+			case 1:
+				hasil.KodeOpd = kode[0]
+				hasil.NamaOpd = namaOpd[kode[0]]
+			default:
+				tolak("opd %q cocok dengan lebih dari satu opd: %s", row.NamaOpd, strings.Join(kode, ", "))
+			}
+		}
+
+		kunci := kunciDuplikatUsulan(row.Usulan, row.Alamat, hasil.Tahun)
+		if row.Usulan != "" {
+			if kunciAda[kunci] {
+				tolak("duplikat dengan usulan yang sudah tersimpan")
+			} else if nomor, ok := barisKunci[kunci]; ok {
+				tolak("duplikat dengan baris %d", nomor)
+			}
+		}
+
+		// hanya baris diterima yang menjadi acuan duplikat baris berikutnya
+		if len(hasil.Alasan) == 0 {
+			barisKunci[kunci] = row.Nomor
+			hasil.Status = StatusBarisImportDiterima
+			laporan.JumlahDiterima++
+		} else {
+			hasil.Status = StatusBarisImportDitolak
+			laporan.JumlahDitolak++
+		}
+		laporan.Baris = append(laporan.Baris, hasil)
+	}
+	return laporan
+}
+
+// idUsulanImport 8 digit agar ratusan usulan sekali import tidak saling bertabrakan
+func idUsulanImport(prefix string, dipakai map[string]bool) string {
+	for {
+		id := fmt.Sprintf("%s-%08d", prefix, uuid.New().ID()%100000000)
+		if !dipakai[id] {
+			dipakai[id] = true
+			return id
+		}
+	}
+}
+
+// usulanTersimpan usulan tahun import yang sudah ada di database
+type usulanTersimpan struct {
+	Id     string
+	Usulan string
+	Alamat string
+	Tahun  string
+}
+
+// jenisImportUsulan bagian import yang berbeda per jenis usulan
+type jenisImportUsulan struct {
+	Jenis    string
+	PrefixId string
+	// CariTersimpan usulan yang sudah ada pada tahun import, untuk cek duplikat dan id
+	CariTersimpan func(ctx context.Context, tx *sql.Tx, tahun string) ([]usulanTersimpan, error)
+	// Buat menyimpan satu baris diterima dengan id yang sudah dipilih, mengembalikan id tersimpan
+	Buat func(ctx context.Context, tx *sql.Tx, id string, baris usulan.UsulanImportBaris) (string, error)
+}
+
+// importUsulan membaca file, memeriksa setiap baris lalu menyimpan baris diterima dalam satu transaksi jika simpan
+func importUsulan(ctx context.Context, db *sql.DB, opdRepository repository.OpdRepository, jenis jenisImportUsulan, tahun string, content []byte, simpan bool) (usulan.UsulanImportResponse, error) {
+	if !polaTahun.MatchString(tahun) {
+		return usulan.UsulanImportResponse{}, web.NewBadRequestError("tahun harus 4 digit")
+	}
+	rows, err := bacaBarisImportUsulan(content)
+	if err != nil {
+		return usulan.UsulanImportResponse{}, err
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return usulan.UsulanImportResponse{}, err
+	}
+	// rollback eksplisit agar kegagalan di tengah import tidak menyimpan sebagian baris
+	defer tx.Rollback()
+
+	opds, err := opdRepository.FindAll(ctx, tx)
+	if err != nil {
+		return usulan.UsulanImportResponse{}, err
+	}
+	existing, err := jenis.CariTersimpan(ctx, tx, tahun)
+	if err != nil {
+		return usulan.UsulanImportResponse{}, err
+	}
+	kunciAda := make(map[string]bool, len(existing))
+	idDipakai := make(map[string]bool, len(existing))
+	for _, item := range existing {
+		kunciAda[kunciDuplikatUsulan(item.Usulan, item.Alamat, item.Tahun)] = true
+		idDipakai[item.Id] = true
+	}
+
+	laporan := periksaImportUsulan(jenis.Jenis, tahun, rows, opds, kunciAda)
+	if !simpan || laporan.JumlahDiterima == 0 {
+		return laporan, nil
+	}
+
+	for i, baris := range laporan.Baris {
+		if baris.Status != StatusBarisImportDiterima {
+			continue
+		}
+		id, err := jenis.Buat(ctx, tx, idUsulanImport(jenis.PrefixId, idDipakai), baris)
+		if err != nil {
+			return usulan.UsulanImportResponse{}, err
+		}
+		laporan.Baris[i].Id = id
+	}
+	if err := tx.Commit(); err != nil {
+		return usulan.UsulanImportResponse{}, err
+	}
+	laporan.Disimpan = true
+	return laporan, nil
+}
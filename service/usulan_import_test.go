@@ -0,0 +1,124 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain/domainmaster"
+	"strings"
+	"testing"
+)
+
+func TestBacaBarisImportUsulan(t *testing.T) {
+	csvSipd := "\xef\xbb\xbfREKAP USULAN MUSRENBANG 2026;;;\n" +
+		"No;Usulan;Lokasi;Perangkat Daerah;Keterangan\n" +
+		"1;Perbaikan jalan desa;Desa Kaibon;Dinas PUPR;rusak berat\n" +
+		";;;;\n" +
+		"2;\"Rehab; posyandu\";Desa Sumberejo;Dinas Kesehatan;\n"
+	xlsx, err := helper.TulisXlsx([]helper.SheetXlsx{{Nama: "Pokir", Baris: [][]string{
+		{"Kode OPD", "Judul Usulan", "Alamat", "Tahun"},
+		{"1.02.0.00.0.00.01.0000", "Bantuan alat tani", "Desa Klecorejo", "2026"},
+	}}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name     string
+		content  []byte
+		expected []barisImportUsulan
+		gagal    string
+	}{
+		{
+			name:    "csv titik koma dengan judul laporan",
+			content: []byte(csvSipd),
+			expected: []barisImportUsulan{
+				{Nomor: 3, Usulan: "Perbaikan jalan desa", Alamat: "Desa Kaibon", NamaOpd: "Dinas PUPR", Uraian: "rusak berat"},
+				{Nomor: 5, Usulan: "Rehab; posyandu", Alamat: "Desa Sumberejo", NamaOpd: "Dinas Kesehatan"},
+			},
+		},
+		{
+			name:    "xlsx dengan kode opd",
+			content: xlsx,
+			expected: []barisImportUsulan{
+				{Nomor: 2, Usulan: "Bantuan alat tani", Alamat: "Desa Klecorejo", KodeOpd: "1.02.0.00.0.00.01.0000", Tahun: "2026"},
+			},
+		},
+		{name: "tanpa kolom usulan", content: []byte("nama,alamat\na,b\n"), gagal: "kolom usulan"},
+		{name: "tanpa kolom opd", content: []byte("usulan,alamat\na,b\n"), gagal: "kolom opd"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rows, err := bacaBarisImportUsulan(tt.content)
+			if tt.gagal != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.gagal) {
+					t.Fatalf("bacaBarisImportUsulan() error = %v, expected %q", err, tt.gagal)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(rows) != len(tt.expected) {
+				t.Fatalf("bacaBarisImportUsulan() = %+v, expected %+v", rows, tt.expected)
+			}
+			for i := range rows {
+				if rows[i] != tt.expected[i] {
+					t.Errorf("baris %d = %+v, expected %+v", i, rows[i], tt.expected[i])
+				}
+			}
+		})
+	}
+}
+
+func TestPeriksaImportUsulan(t *testing.T) {
+	opds := []domainmaster.Opd{
+		{KodeOpd: "1.03", NamaOpd: "Dinas Pekerjaan Umum dan Penataan Ruang", Singkatan: "PUPR"},
+		{KodeOpd: "1.02", NamaOpd: "Dinas Kesehatan"},
+		{KodeOpd: "7.01", NamaOpd: "Kecamatan Dolopo", Singkatan: "KEC"},
+		{KodeOpd: "7.02", NamaOpd: "Kecamatan Geger", Singkatan: "KEC"},
+	}
+	kunciAda := map[string]bool{kunciDuplikatUsulan("Normalisasi saluran", "Desa Bader", "2026"): true}
+	rows := []barisImportUsulan{
+		{Nomor: 2, Usulan: "Perbaikan jalan", Alamat: "Desa Kaibon", NamaOpd: "Dinas PUPR"},
+		{Nomor: 3, Usulan: "Rehab posyandu", NamaOpd: "DINAS KESEHATAN KABUPATEN MADIUN"},
+		{Nomor: 4, Usulan: "perbaikan  JALAN", Alamat: "desa kaibon", KodeOpd: "1.03"},
+		{Nomor: 5, Usulan: "Normalisasi saluran", Alamat: "Desa Bader", NamaOpd: "PUPR"},
+		{Nomor: 6, Usulan: "Lampu jalan", NamaOpd: "Dinas Perhubungan"},
+		{Nomor: 7, Usulan: "Balai desa", NamaOpd: "kec"},
+		{Nomor: 8, Usulan: "", NamaOpd: "Dinas Kesehatan", Tahun: "2025"},
+	}
+
+	laporan := periksaImportUsulan(JenisImportMusrebang, "2026", rows, opds, kunciAda)
+	expected := []struct {
+		status  string
+		kodeOpd string
+		alasan  string
+	}{
+		{StatusBarisImportDitolak, "", "opd \"Dinas PUPR\" tidak ditemukan"},
+		{StatusBarisImportDiterima, "1.02", ""},
+		{StatusBarisImportDiterima, "1.03", ""},
+		{StatusBarisImportDitolak, "1.03", "duplikat dengan usulan yang sudah tersimpan"},
+		{StatusBarisImportDitolak, "", "opd \"Dinas Perhubungan\" tidak ditemukan"},
+		{StatusBarisImportDitolak, "", "lebih dari satu opd"},
+		{StatusBarisImportDitolak, "1.02", "usulan wajib diisi"},
+	}
+	if laporan.JumlahBaris != 7 || laporan.JumlahDiterima != 2 || laporan.JumlahDitolak != 5 {
+		t.Fatalf("periksaImportUsulan() jumlah = %d/%d/%d", laporan.JumlahBaris, laporan.JumlahDiterima, laporan.JumlahDitolak)
+	}
+	for i, e := range expected {
+		baris := laporan.Baris[i]
+		alasan := strings.Join(baris.Alasan, "; ")
+		if baris.Status != e.status || baris.KodeOpd != e.kodeOpd || (e.alasan == "") != (alasan == "") || !strings.Contains(alasan, e.alasan) {
+			t.Errorf("baris %d = %s %s %q, expected %s %s %q", baris.Baris, baris.Status, baris.KodeOpd, alasan, e.status, e.kodeOpd, e.alasan)
+		}
+	}
+	if !strings.Contains(strings.Join(laporan.Baris[6].Alasan, "; "), "berbeda dengan tahun import") {
+		t.Errorf("baris 8 alasan = %v, expected tahun berbeda", laporan.Baris[6].Alasan)
+	}
+
+	// baris sama dengan baris yang sudah diterima ditolak sebagai duplikat
+	laporan = periksaImportUsulan(JenisImportMusrebang, "2026", []barisImportUsulan{rows[1], rows[1]}, opds, nil)
+	if laporan.Baris[1].Status != StatusBarisImportDitolak || laporan.Baris[1].Alasan[0] != "duplikat dengan baris 3" {
+		t.Errorf("duplikat dalam file = %+v", laporan.Baris[1])
+	}
+}
@@ -241,3 +241,33 @@ func (service *UsulanMusrebangServiceImpl) DeleteUsulanTerpilih(ctx context.Cont
 
 	return nil
 }
+
+func (service *UsulanMusrebangServiceImpl) Import(ctx context.Context, tahun string, content []byte, simpan bool) (usulan.UsulanImportResponse, error) {
+	return importUsulan(ctx, service.DB, service.opdRepository, jenisImportUsulan{
+		Jenis:    JenisImportMusrebang,
+		PrefixId: "USU-MUS",
+		CariTersimpan: func(ctx context.Context, tx *sql.Tx, tahun string) ([]usulanTersimpan, error) {
+			items, err := service.usulanMusrebangRepository.FindByTahun(ctx, tx, tahun)
+			if err != nil {
+				return nil, err
+			}
+			tersimpan := make([]usulanTersimpan, 0, len(items))
+			for _, item := range items {
+				tersimpan = append(tersimpan, usulanTersimpan{Id: item.Id, Usulan: item.Usulan, Alamat: item.Alamat, Tahun: item.Tahun})
+			}
+			return tersimpan, nil
+		},
+		Buat: func(ctx context.Context, tx *sql.Tx, id string, baris usulan.UsulanImportBaris) (string, error) {
+			created, err := service.usulanMusrebangRepository.Create(ctx, tx, domain.UsulanMusrebang{
+				Id:      id,
+				Usulan:  baris.Usulan,
+				Alamat:  baris.Alamat,
+				Uraian:  baris.Uraian,
+				Tahun:   baris.Tahun,
+				KodeOpd: baris.KodeOpd,
+				Status:  "belum_diambil",
+			})
+			return created.Id, err
+		},
+	}, tahun, content, simpan)
+}
@@ -0,0 +1,27 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/usulan"
+)
+
+// aksi pipeline usulan
+const (
+	AksiUsulanVerifikasi  = "verifikasi"
+	AksiUsulanAkomodir    = "akomodir"
+	AksiUsulanDanai       = "danai"
+	AksiUsulanSelesaikan  = "selesaikan"
+	AksiUsulanTolak       = "tolak"
+	AksiUsulanAjukanUlang = "ajukan_ulang"
+)
+
+// jenis pagu default, pagu renja yang sudah ditetapkan
+const JenisPaguUsulanDefault = "penetapan"
+
+type UsulanPipelineService interface {
+	FindAll(ctx context.Context, kodeOpd string, tahun string, jenisUsulan string, tahap string, jenisPagu string) ([]usulan.UsulanPipelineResponse, error)
+	FindById(ctx context.Context, jenisUsulan string, usulanId string, jenisPagu string) (usulan.UsulanPipelineResponse, error)
+	Transisi(ctx context.Context, request usulan.UsulanTransisiRequest) (usulan.UsulanTahapRiwayatResponse, error)
+	Rekap(ctx context.Context, kodeOpd string, tahun string, jenisUsulan string, jenisPagu string) (usulan.UsulanRekapResponse, error)
+	FindAturan() []usulan.UsulanAturanTransisiResponse
+}
@@ -0,0 +1,441 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/usulan"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+)
+
+type UsulanPipelineServiceImpl struct {
+	UsulanPipelineRepository repository.UsulanPipelineRepository
+	OpdGuardService          OpdGuardService
+	DB                       *sql.DB
+	Validate                 *validator.Validate
+}
+
+func NewUsulanPipelineServiceImpl(usulanPipelineRepository repository.UsulanPipelineRepository, opdGuardService OpdGuardService, DB *sql.DB, validate *validator.Validate) *UsulanPipelineServiceImpl {
+	return &UsulanPipelineServiceImpl{
+		UsulanPipelineRepository: usulanPipelineRepository,
+		OpdGuardService:          opdGuardService,
+		DB:                       DB,
+		Validate:                 validate,
+	}
+}
+
+// syarat transisi yang diperiksa dari data usulan, bukan dari tahapnya
+const (
+	syaratUsulanRekin = "usulan sudah dipilih ke rencana kinerja"
+	syaratUsulanPagu  = "subkegiatan rencana kinerja usulan sudah memiliki pagu"
+)
+
+type transisiUsulan struct {
+	Aksi        string
+	Dari        string
+	Ke          string
+	Roles       []string
+	WajibAlasan bool
+	Syarat      string
+}
+
+var (
+	rolePengelolaUsulan = []string{helper.RoleSuperAdmin, helper.RoleAdminOpd}
+	rolePenutupUsulan   = []string{helper.RoleSuperAdmin}
+)
+
+// aturanTransisiUsulan seluruh perpindahan tahap yang diizinkan, di luar daftar ini ditolak
+var aturanTransisiUsulan = []transisiUsulan{
+	{Aksi: AksiUsulanVerifikasi, Dari: domain.TahapUsulanDiajukan, Ke: domain.TahapUsulanDiverifikasiOpd, Roles: rolePengelolaUsulan},
+	{Aksi: AksiUsulanAkomodir, Dari: domain.TahapUsulanDiverifikasiOpd, Ke: domain.TahapUsulanDiakomodir, Roles: rolePengelolaUsulan, Syarat: syaratUsulanRekin},
+	{Aksi: AksiUsulanDanai, Dari: domain.TahapUsulanDiakomodir, Ke: domain.TahapUsulanDidanai, Roles: rolePengelolaUsulan, Syarat: syaratUsulanPagu},
+	{Aksi: AksiUsulanSelesaikan, Dari: domain.TahapUsulanDidanai, Ke: domain.TahapUsulanSelesai, Roles: rolePenutupUsulan},
+	{Aksi: AksiUsulanTolak, Dari: domain.TahapUsulanDiajukan, Ke: domain.TahapUsulanDitolak, Roles: rolePengelolaUsulan, WajibAlasan: true},
+	{Aksi: AksiUsulanTolak, Dari: domain.TahapUsulanDiverifikasiOpd, Ke: domain.TahapUsulanDitolak, Roles: rolePengelolaUsulan, WajibAlasan: true},
+	{Aksi: AksiUsulanTolak, Dari: domain.TahapUsulanDiakomodir, Ke: domain.TahapUsulanDitolak, Roles: rolePengelolaUsulan, WajibAlasan: true},
+	{Aksi: AksiUsulanAjukanUlang, Dari: domain.TahapUsulanDitolak, Ke: domain.TahapUsulanDiajukan, Roles: rolePengelolaUsulan},
+}
+
+func jenisUsulanValid(jenisUsulan string) bool {
+	switch jenisUsulan {
+	case domain.JenisUsulanMusrebang, domain.JenisUsulanMandatori, domain.JenisUsulanPokokPikiran, domain.JenisUsulanInisiatif:
+		return true
+	}
+	return false
+}
+
+// tahapUsulan usulan lama tanpa baris tahap dianggap diakomodir bila sudah dipilih ke rekin
+func tahapUsulan(item domain.UsulanPipeline) string {
+	if item.Tahap != "" {
+		return item.Tahap
+	}
+	if item.RekinId != "" {
+		return domain.TahapUsulanDiakomodir
+	}
+	return domain.TahapUsulanDiajukan
+}
+
+func totalPaguUsulan(subKegiatans []domain.UsulanSubKegiatan) int64 {
+	var total int64
+	for _, sub := range subKegiatans {
+		total += sub.Pagu
+	}
+	return total
+}
+
+// periksaTransisiUsulan mencari aturan untuk tahap dan aksi, lalu memeriksa role dan alasan
+func periksaTransisiUsulan(dari string, aksi string, roles []string, alasan string) (transisiUsulan, error) {
+	aksiDikenal := false
+	for _, aturan := range aturanTransisiUsulan {
+		if aturan.Aksi != aksi {
+			continue
+		}
+		aksiDikenal = true
+		if aturan.Dari != dari {
+			continue
+		}
+		if !helper.HasAnyRole(web.JWTClaim{Roles: roles}, aturan.Roles...) {
+			return transisiUsulan{}, web.NewForbiddenError(fmt.Sprintf("aksi %s hanya dapat dilakukan oleh %s", aksi, strings.Join(aturan.Roles, ", ")))
+		}
+		if aturan.WajibAlasan && strings.TrimSpace(alasan) == "" {
+			return transisiUsulan{}, web.NewBadRequestError(fmt.Sprintf("alasan wajib diisi untuk aksi %s", aksi))
+		}
+		return aturan, nil
+	}
+	if !aksiDikenal {
+		return transisiUsulan{}, web.NewBadRequestError(fmt.Sprintf("aksi %s tidak dikenal", aksi))
+	}
+	return transisiUsulan{}, web.NewConflictError(fmt.Sprintf("aksi %s tidak dapat dilakukan pada usulan tahap %s", aksi, dari))
+}
+
+// periksaSyaratUsulan akomodir butuh rekin, danai butuh pagu subkegiatan rekin tersebut
+func periksaSyaratUsulan(aturan transisiUsulan, item domain.UsulanPipeline) error {
+	switch aturan.Syarat {
+	case syaratUsulanRekin:
+		if item.RekinId == "" {
+			return web.NewConflictError("usulan belum dipilih ke rencana kinerja")
+		}
+	case syaratUsulanPagu:
+		if item.RekinId == "" || totalPaguUsulan(item.SubKegiatan) <= 0 {
+			return web.NewConflictError("subkegiatan pada rencana kinerja usulan belum memiliki pagu")
+		}
+	}
+	return nil
+}
+
+func aksiTersediaUsulan(tahap string, roles []string) []usulan.UsulanAturanTransisiResponse {
+	result := []usulan.UsulanAturanTransisiResponse{}
+	for _, aturan := range aturanTransisiUsulan {
+		if aturan.Dari == tahap && helper.HasAnyRole(web.JWTClaim{Roles: roles}, aturan.Roles...) {
+			result = append(result, toAturanTransisiUsulanResponse(aturan))
+		}
+	}
+	return result
+}
+
+func toAturanTransisiUsulanResponse(aturan transisiUsulan) usulan.UsulanAturanTransisiResponse {
+	return usulan.UsulanAturanTransisiResponse{
+		Aksi:        aturan.Aksi,
+		DariTahap:   aturan.Dari,
+		KeTahap:     aturan.Ke,
+		Roles:       aturan.Roles,
+		WajibAlasan: aturan.WajibAlasan,
+		Syarat:      aturan.Syarat,
+	}
+}
+
+func toUsulanPipelineResponse(item domain.UsulanPipeline) usulan.UsulanPipelineResponse {
+	response := usulan.UsulanPipelineResponse{
+		JenisUsulan:        item.JenisUsulan,
+		Id:                 item.Id,
+		Usulan:             item.Usulan,
+		Uraian:             item.Uraian,
+		Alamat:             item.Alamat,
+		Tahun:              item.Tahun,
+		KodeOpd:            item.KodeOpd,
+		NamaOpd:            item.NamaOpd,
+		RekinId:            item.RekinId,
+		NamaRencanaKinerja: item.NamaRencanaKinerja,
+		Status:             item.Status,
+		Tahap:              tahapUsulan(item),
+		AlasanTahap:        item.AlasanTahap,
+		SubKegiatan:        []usulan.UsulanSubKegiatanResponse{},
+		TotalPagu:          totalPaguUsulan(item.SubKegiatan),
+	}
+	for _, sub := range item.SubKegiatan {
+		response.SubKegiatan = append(response.SubKegiatan, usulan.UsulanSubKegiatanResponse{
+			KodeSubKegiatan: sub.KodeSubKegiatan,
+			NamaSubKegiatan: sub.NamaSubKegiatan,
+			Pagu:            sub.Pagu,
+		})
+	}
+	return response
+}
+
+func toUsulanTahapRiwayatResponse(riwayat domain.UsulanTahapRiwayat) usulan.UsulanTahapRiwayatResponse {
+	return usulan.UsulanTahapRiwayatResponse{
+		Id:          riwayat.Id,
+		JenisUsulan: riwayat.JenisUsulan,
+		UsulanId:    riwayat.UsulanId,
+		DariTahap:   riwayat.DariTahap,
+		KeTahap:     riwayat.KeTahap,
+		Aksi:        riwayat.Aksi,
+		Alasan:      riwayat.Alasan,
+		ActorNip:    riwayat.ActorNip,
+		ActorRole:   riwayat.ActorRole,
+		CreatedAt:   riwayat.CreatedAt,
+	}
+}
+
+// rekapUsulan usulan ditolak tidak dihitung masuk renja walaupun rekinnya masih terhubung
+func rekapUsulan(kodeOpd, tahun, jenisUsulan, jenisPagu string, items []domain.UsulanPipeline) usulan.UsulanRekapResponse {
+	rekap := usulan.UsulanRekapResponse{
+		KodeOpd:      kodeOpd,
+		Tahun:        tahun,
+		JenisUsulan:  jenisUsulan,
+		JenisPagu:    jenisPagu,
+		JumlahUsulan: len(items),
+		PerTahap:     map[string]int{},
+		SubKegiatan:  []usulan.UsulanRekapSubKegiatanResponse{},
+	}
+	perSubKegiatan := make(map[string]*usulan.UsulanRekapSubKegiatanResponse)
+	for _, item := range items {
+		tahap := tahapUsulan(item)
+		rekap.PerTahap[tahap]++
+		if tahap == domain.TahapUsulanDitolak || item.RekinId == "" {
+			continue
+		}
+		rekap.JumlahDiakomodir++
+		if len(item.SubKegiatan) > 0 {
+			rekap.JumlahMasukRenja++
+		}
+		if totalPaguUsulan(item.SubKegiatan) > 0 {
+			rekap.JumlahDidanai++
+		}
+		// satu subkegiatan bisa menampung beberapa usulan, pagunya hanya dihitung sekali
+		dihitung := make(map[string]bool)
+		for _, sub := range item.SubKegiatan {
+			if dihitung[sub.KodeSubKegiatan] {
+				continue
+			}
+			dihitung[sub.KodeSubKegiatan] = true
+			rekapSub, ok := perSubKegiatan[sub.KodeSubKegiatan]
+			if !ok {
+				rekapSub = &usulan.UsulanRekapSubKegiatanResponse{KodeSubKegiatan: sub.KodeSubKegiatan, NamaSubKegiatan: sub.NamaSubKegiatan}
+				perSubKegiatan[sub.KodeSubKegiatan] = rekapSub
+				rekap.TotalPagu += sub.Pagu
+			}
+			if sub.Pagu > rekapSub.Pagu {
+				rekapSub.Pagu = sub.Pagu
+			}
+			rekapSub.JumlahUsulan++
+		}
+	}
+	for _, rekapSub := range perSubKegiatan {
+		rekap.SubKegiatan = append(rekap.SubKegiatan, *rekapSub)
+	}
+	sort.Slice(rekap.SubKegiatan, func(i, j int) bool {
+		return rekap.SubKegiatan[i].KodeSubKegiatan < rekap.SubKegiatan[j].KodeSubKegiatan
+	})
+	return rekap
+}
+
+// lengkapiSubKegiatanUsulan mengisi subkegiatan terpilih dan pagu untuk usulan yang sudah punya rekin
+func lengkapiSubKegiatanUsulan(ctx context.Context, tx *sql.Tx, usulanPipelineRepository repository.UsulanPipelineRepository, items []domain.UsulanPipeline, jenisPagu string) error {
+	var rekinIds []string
+	sudah := make(map[string]bool)
+	for _, item := range items {
+		if item.RekinId != "" && !sudah[item.RekinId] {
+			sudah[item.RekinId] = true
+			rekinIds = append(rekinIds, item.RekinId)
+		}
+	}
+	perRekin, err := usulanPipelineRepository.FindSubKegiatanByRekinIds(ctx, tx, rekinIds, jenisPagu)
+	if err != nil {
+		return err
+	}
+	for i := range items {
+		items[i].SubKegiatan = perRekin[items[i].RekinId]
+	}
+	return nil
+}
+
+func jenisPaguUsulan(jenisPagu string) string {
+	if jenisPagu == "" {
+		return JenisPaguUsulanDefault
+	}
+	return jenisPagu
+}
+
+func (service *UsulanPipelineServiceImpl) findAll(ctx context.Context, kodeOpd, tahun, jenisUsulan, jenisPagu string) ([]domain.UsulanPipeline, error) {
+	if jenisUsulan != "" && !jenisUsulanValid(jenisUsulan) {
+		return nil, web.NewBadRequestError(fmt.Sprintf("jenis usulan %s tidak dikenal", jenisUsulan))
+	}
+	if err := service.OpdGuardService.CheckRead(ctx, kodeOpd, tahun); err != nil {
+		return nil, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return nil, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	items, err := service.UsulanPipelineRepository.FindAll(ctx, tx, kodeOpd, tahun, jenisUsulan)
+	if err != nil {
+		return nil, err
+	}
+	if err := lengkapiSubKegiatanUsulan(ctx, tx, service.UsulanPipelineRepository, items, jenisPagu); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+func (service *UsulanPipelineServiceImpl) FindAll(ctx context.Context, kodeOpd string, tahun string, jenisUsulan string, tahap string, jenisPagu string) ([]usulan.UsulanPipelineResponse, error) {
+	items, err := service.findAll(ctx, kodeOpd, tahun, jenisUsulan, jenisPaguUsulan(jenisPagu))
+	if err != nil {
+		return nil, err
+	}
+
+	result := []usulan.UsulanPipelineResponse{}
+	for _, item := range items {
+		if tahap != "" && tahapUsulan(item) != tahap {
+			continue
+		}
+		result = append(result, toUsulanPipelineResponse(item))
+	}
+	return result, nil
+}
+
+func (service *UsulanPipelineServiceImpl) findById(ctx context.Context, tx *sql.Tx, jenisUsulan string, usulanId string, jenisPagu string) (domain.UsulanPipeline, error) {
+	if !jenisUsulanValid(jenisUsulan) {
+		return domain.UsulanPipeline{}, web.NewBadRequestError(fmt.Sprintf("jenis usulan %s tidak dikenal", jenisUsulan))
+	}
+	item, err := service.UsulanPipelineRepository.FindById(ctx, tx, jenisUsulan, usulanId)
+	if err == sql.ErrNoRows {
+		return domain.UsulanPipeline{}, web.NewNotFoundError(fmt.Sprintf("usulan %s %s tidak ditemukan", jenisUsulan, usulanId))
+	}
+	if err != nil {
+		return domain.UsulanPipeline{}, err
+	}
+	items := []domain.UsulanPipeline{item}
+	if err := lengkapiSubKegiatanUsulan(ctx, tx, service.UsulanPipelineRepository, items, jenisPagu); err != nil {
+		return domain.UsulanPipeline{}, err
+	}
+	return items[0], nil
+}
+
+func (service *UsulanPipelineServiceImpl) FindById(ctx context.Context, jenisUsulan string, usulanId string, jenisPagu string) (usulan.UsulanPipelineResponse, error) {
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return usulan.UsulanPipelineResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	item, err := service.findById(ctx, tx, jenisUsulan, usulanId, jenisPaguUsulan(jenisPagu))
+	if err != nil {
+		return usulan.UsulanPipelineResponse{}, err
+	}
+	if err := service.OpdGuardService.CheckRead(ctx, item.KodeOpd, item.Tahun); err != nil {
+		return usulan.UsulanPipelineResponse{}, err
+	}
+	riwayat, err := service.UsulanPipelineRepository.FindRiwayat(ctx, tx, jenisUsulan, usulanId)
+	if err != nil {
+		return usulan.UsulanPipelineResponse{}, err
+	}
+
+	claims, _ := helper.GetUserClaims(ctx)
+	response := toUsulanPipelineResponse(item)
+	response.AksiTersedia = aksiTersediaUsulan(response.Tahap, claims.Roles)
+	for _, r := range riwayat {
+		response.Riwayat = append(response.Riwayat, toUsulanTahapRiwayatResponse(r))
+	}
+	return response, nil
+}
+
+func (service *UsulanPipelineServiceImpl) Transisi(ctx context.Context, request usulan.UsulanTransisiRequest) (usulan.UsulanTahapRiwayatResponse, error) {
+	if err := service.Validate.Struct(request); err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+	claims, ok := helper.GetUserClaims(ctx)
+	if !ok {
+		return usulan.UsulanTahapRiwayatResponse{}, web.NewUnauthorizedError("transisi usulan memerlukan login")
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+	// rollback eksplisit: tahap yang sudah berubah tidak boleh tersimpan tanpa riwayatnya
+	defer tx.Rollback()
+
+	item, err := service.findById(ctx, tx, request.JenisUsulan, request.UsulanId, JenisPaguUsulanDefault)
+	if err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+	dari := tahapUsulan(item)
+	aturan, err := periksaTransisiUsulan(dari, request.Aksi, claims.Roles, request.Alasan)
+	if err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+	if err := service.OpdGuardService.CheckWrite(ctx, item.KodeOpd); err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+	if err := periksaSyaratUsulan(aturan, item); err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+
+	alasan := strings.TrimSpace(request.Alasan)
+	// item.Tahap mentah dipakai sebagai kondisi agar usulan tanpa baris tahap di-insert
+	berubah, err := service.UsulanPipelineRepository.UpdateTahap(ctx, tx, item.JenisUsulan, item.Id, item.Tahap, aturan.Ke, alasan, claims.Nip)
+	if err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+	if !berubah {
+		return usulan.UsulanTahapRiwayatResponse{}, web.NewConflictError("tahap usulan sudah diubah pengguna lain, muat ulang data")
+	}
+
+	riwayat, err := service.UsulanPipelineRepository.CreateRiwayat(ctx, tx, domain.UsulanTahapRiwayat{
+		JenisUsulan: item.JenisUsulan,
+		UsulanId:    item.Id,
+		DariTahap:   dari,
+		KeTahap:     aturan.Ke,
+		Aksi:        aturan.Aksi,
+		Alasan:      alasan,
+		ActorNip:    claims.Nip,
+		ActorRole:   rolePelaku(claims, aturan.Roles),
+		CreatedAt:   time.Now(),
+	})
+	if err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+	if err := tx.Commit(); err != nil {
+		return usulan.UsulanTahapRiwayatResponse{}, err
+	}
+	return toUsulanTahapRiwayatResponse(riwayat), nil
+}
+
+func (service *UsulanPipelineServiceImpl) Rekap(ctx context.Context, kodeOpd string, tahun string, jenisUsulan string, jenisPagu string) (usulan.UsulanRekapResponse, error) {
+	jenisPagu = jenisPaguUsulan(jenisPagu)
+	items, err := service.findAll(ctx, kodeOpd, tahun, jenisUsulan, jenisPagu)
+	if err != nil {
+		return usulan.UsulanRekapResponse{}, err
+	}
+	return rekapUsulan(kodeOpd, tahun, jenisUsulan, jenisPagu, items), nil
+}
+
+func (service *UsulanPipelineServiceImpl) FindAturan() []usulan.UsulanAturanTransisiResponse {
+	var result []usulan.UsulanAturanTransisiResponse
+	for _, aturan := range aturanTransisiUsulan {
+		result = append(result, toAturanTransisiUsulanResponse(aturan))
+	}
+	return result
+}
@@ -0,0 +1,111 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"errors"
+	"net/http"
+	"testing"
+)
+
+func TestPeriksaTransisiUsulan(t *testing.T) {
+	superAdmin := []string{helper.RoleSuperAdmin}
+	adminOpd := []string{helper.RoleAdminOpd}
+
+	tests := []struct {
+		name     string
+		dari     string
+		aksi     string
+		roles    []string
+		alasan   string
+		expected string
+		code     int
+	}{
+		{name: "verifikasi usulan", dari: domain.TahapUsulanDiajukan, aksi: AksiUsulanVerifikasi, roles: adminOpd, expected: domain.TahapUsulanDiverifikasiOpd},
+		{name: "akomodir ke rekin", dari: domain.TahapUsulanDiverifikasiOpd, aksi: AksiUsulanAkomodir, roles: adminOpd, expected: domain.TahapUsulanDiakomodir},
+		{name: "danai", dari: domain.TahapUsulanDiakomodir, aksi: AksiUsulanDanai, roles: superAdmin, expected: domain.TahapUsulanDidanai},
+		{name: "selesaikan", dari: domain.TahapUsulanDidanai, aksi: AksiUsulanSelesaikan, roles: superAdmin, expected: domain.TahapUsulanSelesai},
+		{name: "tolak dengan alasan", dari: domain.TahapUsulanDiakomodir, aksi: AksiUsulanTolak, roles: adminOpd, alasan: "bukan kewenangan opd", expected: domain.TahapUsulanDitolak},
+		{name: "ajukan ulang", dari: domain.TahapUsulanDitolak, aksi: AksiUsulanAjukanUlang, roles: adminOpd, expected: domain.TahapUsulanDiajukan},
+		{name: "tolak tanpa alasan", dari: domain.TahapUsulanDiajukan, aksi: AksiUsulanTolak, roles: adminOpd, alasan: " ", code: http.StatusBadRequest},
+		{name: "admin opd tidak boleh menyelesaikan", dari: domain.TahapUsulanDidanai, aksi: AksiUsulanSelesaikan, roles: adminOpd, code: http.StatusForbidden},
+		{name: "tolak usulan yang sudah didanai", dari: domain.TahapUsulanDidanai, aksi: AksiUsulanTolak, roles: superAdmin, alasan: "x", code: http.StatusConflict},
+		{name: "danai melompati akomodir", dari: domain.TahapUsulanDiajukan, aksi: AksiUsulanDanai, roles: superAdmin, code: http.StatusConflict},
+		{name: "aksi tidak dikenal", dari: domain.TahapUsulanDiajukan, aksi: "hapus", roles: superAdmin, code: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			aturan, err := periksaTransisiUsulan(tt.dari, tt.aksi, tt.roles, tt.alasan)
+			if tt.code == 0 {
+				if err != nil || aturan.Ke != tt.expected {
+					t.Errorf("periksaTransisiUsulan() = %q, %v, expected %q", aturan.Ke, err, tt.expected)
+				}
+				return
+			}
+			var customErr *web.CustomError
+			if !errors.As(err, &customErr) || customErr.Code != tt.code {
+				t.Errorf("periksaTransisiUsulan() error = %v, expected code %d", err, tt.code)
+			}
+		})
+	}
+}
+
+func TestPeriksaSyaratUsulan(t *testing.T) {
+	akomodir, _ := periksaTransisiUsulan(domain.TahapUsulanDiverifikasiOpd, AksiUsulanAkomodir, []string{helper.RoleAdminOpd}, "")
+	danai, _ := periksaTransisiUsulan(domain.TahapUsulanDiakomodir, AksiUsulanDanai, []string{helper.RoleAdminOpd}, "")
+
+	if err := periksaSyaratUsulan(akomodir, domain.UsulanPipeline{}); err == nil {
+		t.Error("akomodir usulan tanpa rekin seharusnya ditolak")
+	}
+	if err := periksaSyaratUsulan(akomodir, domain.UsulanPipeline{RekinId: "REKIN-1"}); err != nil {
+		t.Errorf("akomodir usulan dengan rekin = %v", err)
+	}
+	tanpaPagu := domain.UsulanPipeline{RekinId: "REKIN-1", SubKegiatan: []domain.UsulanSubKegiatan{{KodeSubKegiatan: "1.01.01.2.01.0001"}}}
+	if err := periksaSyaratUsulan(danai, tanpaPagu); err == nil {
+		t.Error("danai usulan tanpa pagu seharusnya ditolak")
+	}
+	tanpaPagu.SubKegiatan[0].Pagu = 1000000
+	if err := periksaSyaratUsulan(danai, tanpaPagu); err != nil {
+		t.Errorf("danai usulan berpagu = %v", err)
+	}
+}
+
+func TestTahapUsulan(t *testing.T) {
+	if tahap := tahapUsulan(domain.UsulanPipeline{}); tahap != domain.TahapUsulanDiajukan {
+		t.Errorf("usulan baru = %q", tahap)
+	}
+	if tahap := tahapUsulan(domain.UsulanPipeline{RekinId: "REKIN-1"}); tahap != domain.TahapUsulanDiakomodir {
+		t.Errorf("usulan lama yang sudah dipilih rekin = %q", tahap)
+	}
+	if tahap := tahapUsulan(domain.UsulanPipeline{RekinId: "REKIN-1", Tahap: domain.TahapUsulanDitolak}); tahap != domain.TahapUsulanDitolak {
+		t.Errorf("tahap tersimpan = %q", tahap)
+	}
+}
+
+func TestRekapUsulan(t *testing.T) {
+	subA := domain.UsulanSubKegiatan{KodeSubKegiatan: "A", NamaSubKegiatan: "Sub A", Pagu: 500}
+	subB := domain.UsulanSubKegiatan{KodeSubKegiatan: "B", NamaSubKegiatan: "Sub B"}
+	items := []domain.UsulanPipeline{
+		{Id: "1"},
+		{Id: "2", RekinId: "R1"},
+		{Id: "3", RekinId: "R2", SubKegiatan: []domain.UsulanSubKegiatan{subA, subB}},
+		{Id: "4", RekinId: "R3", SubKegiatan: []domain.UsulanSubKegiatan{subA}, Tahap: domain.TahapUsulanDidanai},
+		{Id: "5", RekinId: "R4", SubKegiatan: []domain.UsulanSubKegiatan{subA}, Tahap: domain.TahapUsulanDitolak},
+	}
+
+	rekap := rekapUsulan("1.01", "2025", domain.JenisUsulanMusrebang, JenisPaguUsulanDefault, items)
+	if rekap.JumlahUsulan != 5 || rekap.JumlahDiakomodir != 3 || rekap.JumlahMasukRenja != 2 || rekap.JumlahDidanai != 2 {
+		t.Errorf("rekapUsulan() jumlah = %+v", rekap)
+	}
+	if rekap.TotalPagu != 500 {
+		t.Errorf("rekapUsulan() TotalPagu = %d, expected 500", rekap.TotalPagu)
+	}
+	if rekap.PerTahap[domain.TahapUsulanDiakomodir] != 2 || rekap.PerTahap[domain.TahapUsulanDitolak] != 1 {
+		t.Errorf("rekapUsulan() PerTahap = %v", rekap.PerTahap)
+	}
+	if len(rekap.SubKegiatan) != 2 || rekap.SubKegiatan[0].KodeSubKegiatan != "A" || rekap.SubKegiatan[0].JumlahUsulan != 2 {
+		t.Errorf("rekapUsulan() SubKegiatan = %+v", rekap.SubKegiatan)
+	}
+}
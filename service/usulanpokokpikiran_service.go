@@ -13,4 +13,6 @@ type UsulanPokokPikiranService interface {
 	Delete(ctx context.Context, idUsulan string) error
 	CreateRekin(ctx context.Context, request usulan.UsulanPokokPikiranCreateRekinRequest) ([]usulan.UsulanPokokPikiranResponse, error)
 	DeleteUsulanTerpilih(ctx context.Context, idUsulan string) error
+	// Import tanpa simpan hanya mengembalikan laporan per baris sebagai preview
+	Import(ctx context.Context, tahun string, content []byte, simpan bool) (usulan.UsulanImportResponse, error)
 }
@@ -224,3 +224,33 @@ func (service *UsulanPokokPikiranServiceImpl) DeleteUsulanTerpilih(ctx context.C
 
 	return nil
 }
+
+func (service *UsulanPokokPikiranServiceImpl) Import(ctx context.Context, tahun string, content []byte, simpan bool) (usulan.UsulanImportResponse, error) {
+	return importUsulan(ctx, service.DB, service.OpdRepository, jenisImportUsulan{
+		Jenis:    JenisImportPokokPikiran,
+		PrefixId: "USU-POKIR",
+		CariTersimpan: func(ctx context.Context, tx *sql.Tx, tahun string) ([]usulanTersimpan, error) {
+			items, err := service.UsulanPokokPikiranRepository.FindByTahun(ctx, tx, tahun)
+			if err != nil {
+				return nil, err
+			}
+			tersimpan := make([]usulanTersimpan, 0, len(items))
+			for _, item := range items {
+				tersimpan = append(tersimpan, usulanTersimpan{Id: item.Id, Usulan: item.Usulan, Alamat: item.Alamat, Tahun: item.Tahun})
+			}
+			return tersimpan, nil
+		},
+		Buat: func(ctx context.Context, tx *sql.Tx, id string, baris usulan.UsulanImportBaris) (string, error) {
+			created, err := service.UsulanPokokPikiranRepository.Create(ctx, tx, domain.UsulanPokokPikiran{
+				Id:      id,
+				Usulan:  baris.Usulan,
+				Alamat:  baris.Alamat,
+				Uraian:  baris.Uraian,
+				Tahun:   baris.Tahun,
+				KodeOpd: baris.KodeOpd,
+				Status:  "belum_diambil",
+			})
+			return created.Id, err
+		},
+	}, tahun, content, simpan)
+}
@@ -0,0 +1,19 @@
+package service
+
+import (
+	"context"
+	"ekak_kabupaten_madiun/model/web/usulan"
+)
+
+// ukuran halaman daftar usulan publik
+const (
+	UsulanPublikLimitDefault = 50
+	UsulanPublikLimitMaks    = 100
+)
+
+// UsulanPublikService akses baca tanpa login untuk usulan musrebang dan pokok pikiran
+type UsulanPublikService interface {
+	// FindAll limit 0 memakai UsulanPublikLimitDefault, limit di atas UsulanPublikLimitMaks dipotong
+	FindAll(ctx context.Context, tahun string, kodeOpd string, kecamatan string, jenisUsulan string, limit int, offset int) (usulan.UsulanPublikListResponse, error)
+	FindById(ctx context.Context, jenisUsulan string, usulanId string) (usulan.UsulanPublikResponse, error)
+}
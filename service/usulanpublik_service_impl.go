@@ -0,0 +1,177 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/model/domain"
+	"ekak_kabupaten_madiun/model/web"
+	"ekak_kabupaten_madiun/model/web/usulan"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"strings"
+)
+
+type UsulanPublikServiceImpl struct {
+	UsulanPipelineRepository repository.UsulanPipelineRepository
+	DB                       *sql.DB
+}
+
+func NewUsulanPublikServiceImpl(usulanPipelineRepository repository.UsulanPipelineRepository, DB *sql.DB) *UsulanPublikServiceImpl {
+	return &UsulanPublikServiceImpl{
+		UsulanPipelineRepository: usulanPipelineRepository,
+		DB:                       DB,
+	}
+}
+
+// jenisUsulanPublik hanya usulan dari warga dan DPRD yang dibuka ke publik
+var jenisUsulanPublik = []string{domain.JenisUsulanMusrebang, domain.JenisUsulanPokokPikiran}
+
+var keteranganTahapUsulan = map[string]string{
+	domain.TahapUsulanDiajukan:        "Diajukan",
+	domain.TahapUsulanDiverifikasiOpd: "Diverifikasi OPD",
+	domain.TahapUsulanDiakomodir:      "Diakomodir dalam rencana kinerja",
+	domain.TahapUsulanDidanai:         "Didanai melalui subkegiatan",
+	domain.TahapUsulanSelesai:         "Selesai",
+	domain.TahapUsulanDitolak:         "Ditolak",
+}
+
+func jenisUsulanPublikDipilih(jenisUsulan string) ([]string, error) {
+	if jenisUsulan == "" {
+		return jenisUsulanPublik, nil
+	}
+	for _, jenis := range jenisUsulanPublik {
+		if jenis == jenisUsulan {
+			return []string{jenis}, nil
+		}
+	}
+	return nil, web.NewBadRequestError(fmt.Sprintf("jenis usulan %s tidak tersedia untuk publik", jenisUsulan))
+}
+
+// toUsulanPublikResponse rekin dan subkegiatan hanya ditampilkan bila usulan diakomodir dan tidak ditolak
+func toUsulanPublikResponse(item domain.UsulanPipeline) usulan.UsulanPublikResponse {
+	tahap := tahapUsulan(item)
+	response := usulan.UsulanPublikResponse{
+		JenisUsulan:      item.JenisUsulan,
+		Id:               item.Id,
+		Usulan:           item.Usulan,
+		Uraian:           item.Uraian,
+		Alamat:           item.Alamat,
+		Tahun:            item.Tahun,
+		KodeOpd:          item.KodeOpd,
+		NamaOpd:          item.NamaOpd,
+		Status:           tahap,
+		KeteranganStatus: keteranganTahapUsulan[tahap],
+		SubKegiatan:      []usulan.UsulanSubKegiatanResponse{},
+		TanggalUsulan:    item.CreatedAt,
+	}
+	if tahap == domain.TahapUsulanDitolak {
+		response.AlasanDitolak = item.AlasanTahap
+		return response
+	}
+	if item.RekinId == "" {
+		return response
+	}
+
+	response.Diakomodir = true
+	response.NamaRencanaKinerja = item.NamaRencanaKinerja
+	for _, sub := range item.SubKegiatan {
+		response.SubKegiatan = append(response.SubKegiatan, usulan.UsulanSubKegiatanResponse{
+			KodeSubKegiatan: sub.KodeSubKegiatan,
+			NamaSubKegiatan: sub.NamaSubKegiatan,
+			Pagu:            sub.Pagu,
+		})
+	}
+	response.TotalPagu = totalPaguUsulan(item.SubKegiatan)
+	return response
+}
+
+func (service *UsulanPublikServiceImpl) FindAll(ctx context.Context, tahun string, kodeOpd string, kecamatan string, jenisUsulan string, limit int, offset int) (usulan.UsulanPublikListResponse, error) {
+	if !polaTahun.MatchString(tahun) {
+		return usulan.UsulanPublikListResponse{}, web.NewBadRequestError("tahun wajib diisi 4 digit")
+	}
+	jenis, err := jenisUsulanPublikDipilih(jenisUsulan)
+	if err != nil {
+		return usulan.UsulanPublikListResponse{}, err
+	}
+	limit, offset, err = halamanUsulanPublik(limit, offset)
+	if err != nil {
+		return usulan.UsulanPublikListResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return usulan.UsulanPublikListResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	items, total, err := service.UsulanPipelineRepository.FindPublik(ctx, tx, tahun, strings.TrimSpace(kodeOpd), strings.TrimSpace(kecamatan), jenis, limit, offset)
+	if err != nil {
+		return usulan.UsulanPublikListResponse{}, err
+	}
+	if err := lengkapiSubKegiatanUsulan(ctx, tx, service.UsulanPipelineRepository, items, JenisPaguUsulanDefault); err != nil {
+		return usulan.UsulanPublikListResponse{}, err
+	}
+
+	result := usulan.UsulanPublikListResponse{Total: total, Limit: limit, Offset: offset, Usulan: []usulan.UsulanPublikResponse{}}
+	for _, item := range items {
+		result.Usulan = append(result.Usulan, toUsulanPublikResponse(item))
+	}
+	return result, nil
+}
+
+// halamanUsulanPublik endpoint tanpa login tidak boleh mengembalikan seluruh usulan sekaligus
+func halamanUsulanPublik(limit int, offset int) (int, int, error) {
+	if limit < 0 || offset < 0 {
+		return 0, 0, web.NewBadRequestError("limit dan offset tidak boleh negatif")
+	}
+	if limit == 0 {
+		limit = UsulanPublikLimitDefault
+	}
+	if limit > UsulanPublikLimitMaks {
+		limit = UsulanPublikLimitMaks
+	}
+	return limit, offset, nil
+}
+
+func (service *UsulanPublikServiceImpl) FindById(ctx context.Context, jenisUsulan string, usulanId string) (usulan.UsulanPublikResponse, error) {
+	if jenisUsulan == "" {
+		return usulan.UsulanPublikResponse{}, web.NewBadRequestError("jenis usulan wajib diisi")
+	}
+	if _, err := jenisUsulanPublikDipilih(jenisUsulan); err != nil {
+		return usulan.UsulanPublikResponse{}, err
+	}
+
+	tx, err := service.DB.Begin()
+	if err != nil {
+		return usulan.UsulanPublikResponse{}, err
+	}
+	defer helper.CommitOrRollback(tx)
+
+	item, err := service.UsulanPipelineRepository.FindById(ctx, tx, jenisUsulan, usulanId)
+	if err == sql.ErrNoRows {
+		return usulan.UsulanPublikResponse{}, web.NewNotFoundError(fmt.Sprintf("usulan %s tidak ditemukan", usulanId))
+	}
+	if err != nil {
+		return usulan.UsulanPublikResponse{}, err
+	}
+	items := []domain.UsulanPipeline{item}
+	if err := lengkapiSubKegiatanUsulan(ctx, tx, service.UsulanPipelineRepository, items, JenisPaguUsulanDefault); err != nil {
+		return usulan.UsulanPublikResponse{}, err
+	}
+	riwayat, err := service.UsulanPipelineRepository.FindRiwayat(ctx, tx, jenisUsulan, usulanId)
+	if err != nil {
+		return usulan.UsulanPublikResponse{}, err
+	}
+
+	response := toUsulanPublikResponse(items[0])
+	// pelaku dan alasan tiap perpindahan tidak dibuka, hanya tahap dan tanggalnya
+	for _, r := range riwayat {
+		response.Riwayat = append(response.Riwayat, usulan.UsulanPublikRiwayatResponse{
+			Status:           r.KeTahap,
+			KeteranganStatus: keteranganTahapUsulan[r.KeTahap],
+			Tanggal:          r.CreatedAt,
+		})
+	}
+	return response, nil
+}
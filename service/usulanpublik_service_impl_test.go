@@ -0,0 +1,56 @@
+package service
+
+import (
+	"ekak_kabupaten_madiun/model/domain"
+	"testing"
+)
+
+func TestJenisUsulanPublikDipilih(t *testing.T) {
+	if jenis, err := jenisUsulanPublikDipilih(""); err != nil || len(jenis) != 2 {
+		t.Errorf("jenis kosong = %v, %v, expected musrebang dan pokok pikiran", jenis, err)
+	}
+	if jenis, err := jenisUsulanPublikDipilih(domain.JenisUsulanPokokPikiran); err != nil || len(jenis) != 1 {
+		t.Errorf("pokok pikiran = %v, %v", jenis, err)
+	}
+	if _, err := jenisUsulanPublikDipilih(domain.JenisUsulanMandatori); err == nil {
+		t.Error("usulan mandatori tidak boleh dibuka ke publik")
+	}
+}
+
+func TestToUsulanPublikResponse(t *testing.T) {
+	sub := []domain.UsulanSubKegiatan{{RekinId: "REKIN-1", KodeSubKegiatan: "A", NamaSubKegiatan: "Sub A", Pagu: 750}}
+
+	diakomodir := toUsulanPublikResponse(domain.UsulanPipeline{
+		JenisUsulan:        domain.JenisUsulanMusrebang,
+		RekinId:            "REKIN-1",
+		NamaRencanaKinerja: "Meningkatnya kualitas jalan desa",
+		SubKegiatan:        sub,
+	})
+	if !diakomodir.Diakomodir || diakomodir.Status != domain.TahapUsulanDiakomodir || diakomodir.NamaRencanaKinerja == "" || diakomodir.TotalPagu != 750 || len(diakomodir.SubKegiatan) != 1 {
+		t.Errorf("usulan diakomodir = %+v", diakomodir)
+	}
+
+	ditolak := toUsulanPublikResponse(domain.UsulanPipeline{
+		JenisUsulan:        domain.JenisUsulanMusrebang,
+		RekinId:            "REKIN-1",
+		NamaRencanaKinerja: "Meningkatnya kualitas jalan desa",
+		SubKegiatan:        sub,
+		Tahap:              domain.TahapUsulanDitolak,
+		AlasanTahap:        "bukan kewenangan kabupaten",
+	})
+	if ditolak.Diakomodir || ditolak.NamaRencanaKinerja != "" || len(ditolak.SubKegiatan) != 0 || ditolak.AlasanDitolak == "" || ditolak.KeteranganStatus != "Ditolak" {
+		t.Errorf("usulan ditolak = %+v", ditolak)
+	}
+}
+
+func TestHalamanUsulanPublik(t *testing.T) {
+	if limit, offset, err := halamanUsulanPublik(0, 0); err != nil || limit != UsulanPublikLimitDefault || offset != 0 {
+		t.Errorf("halaman default = %d, %d, %v", limit, offset, err)
+	}
+	if limit, _, err := halamanUsulanPublik(5000, 20); err != nil || limit != UsulanPublikLimitMaks {
+		t.Errorf("limit besar = %d, %v, expected dipotong ke %d", limit, err, UsulanPublikLimitMaks)
+	}
+	if _, _, err := halamanUsulanPublik(10, -1); err == nil {
+		t.Error("offset negatif harus ditolak")
+	}
+}
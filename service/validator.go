@@ -0,0 +1,43 @@
+package service
+
+import (
+	"context"
+	"database/sql"
+	"ekak_kabupaten_madiun/helper"
+	"ekak_kabupaten_madiun/repository"
+	"fmt"
+	"log"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// NewValidator membuat validator bersama untuk controller dan service.
+// Tag tambahan: `tahun` (4 digit) dan `tahun_periode` (4 digit dan terdaftar di tb_tahun_periode).
+func NewValidator(periodeRepository repository.PeriodeRepository, DB *sql.DB) *validator.Validate {
+	validate := validator.New()
+	validate.RegisterTagNameFunc(helper.JsonTagName)
+
+	err := validate.RegisterValidation("tahun", helper.ValidateTahun)
+	helper.PanicIfError(err)
+
+	err = validate.RegisterValidationCtx("tahun_periode", func(ctx context.Context, fl validator.FieldLevel) bool {
+		tahun := fmt.Sprint(fl.Field().Interface())
+		if !helper.IsTahun(tahun) {
+			return false
+		}
+
+		tx, err := DB.Begin()
+		if err != nil {
+			log.Printf("validasi tahun_periode: %v", err)
+			return false
+		}
+		defer tx.Rollback()
+
+		_, err = periodeRepository.FindByTahun(ctx, tx, tahun)
+		return err == nil
+	})
+	helper.PanicIfError(err)
+
+	helper.SetRequestValidator(validate)
+	return validate
+}
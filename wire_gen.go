@@ -13,7 +13,6 @@ import (
 	"ekak_kabupaten_madiun/middleware"
 	"ekak_kabupaten_madiun/repository"
 	"ekak_kabupaten_madiun/service"
-	"github.com/go-playground/validator/v10"
 	"github.com/google/wire"
 	"net/http"
 )
@@ -27,8 +26,8 @@ import (
 func InitializeServer() *http.Server {
 	rencanaKinerjaRepositoryImpl := repository.NewRencanaKinerjaRepositoryImpl()
 	db := app.GetConnection()
-	v := _wireValue
-	validate := validator.New(v...)
+	periodeRepositoryImpl := repository.NewPeriodeRepositoryImpl()
+	validate := service.NewValidator(periodeRepositoryImpl, db)
 	opdRepositoryImpl := repository.NewOpdRepositoryImpl()
 	usulanMusrebangRepositoryImpl := repository.NewUsulanMusrebangRepositoryImpl()
 	usulanMandatoriRepositoryImpl := repository.NewUsulanMandatoriRepositoryImpl()
@@ -45,7 +44,6 @@ func InitializeServer() *http.Server {
 	permasalahanRekinRepositoryImpl := repository.NewPermasalahanRekinRepositoryImpl()
 	subKegiatanTerpilihRepositoryImpl := repository.NewSubKegiatanTerpilihRepositoryImpl()
 	subKegiatanServiceImpl := service.NewSubKegiatanServiceImpl(subKegiatanRepositoryImpl, opdRepositoryImpl, rencanaKinerjaRepositoryImpl, db, validate)
-	periodeRepositoryImpl := repository.NewPeriodeRepositoryImpl()
 	sasaranOpdRepositoryImpl := repository.NewSasaranOpdRepositoryImpl()
 	tujuanOpdRepositoryImpl := repository.NewTujuanOpdRepositoryImpl()
 	programRepositoryImpl := repository.NewProgramRepositoryImpl()
@@ -53,14 +51,18 @@ func InitializeServer() *http.Server {
 	bidangUrusanRepositoryImpl := repository.NewBidangUrusanRepositoryImpl()
 	rincianBelanjaRepositoryImpl := repository.NewRincianBelanjaRepositoryImpl()
 	rencanaAksiRepositoryImpl := repository.NewRencanaAksiRepositoryImpl()
+	lockDataRepositoryImpl := repository.NewLockDataRepositoryImpl()
+	lockDataServiceImpl := service.NewLockDataServiceImpl(lockDataRepositoryImpl, db, validate)
+	auditLogRepositoryImpl := repository.NewAuditLogRepositoryImpl()
 	client := app.GetRedisClient()
 	cascadingOpdServiceImpl := service.NewCascadingOpdServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, pegawaiRepositoryImpl, tujuanOpdRepositoryImpl, rencanaKinerjaRepositoryImpl, db, programRepositoryImpl, cascadingOpdRepositoryImpl, bidangUrusanRepositoryImpl, rincianBelanjaRepositoryImpl, rencanaAksiRepositoryImpl, client)
 	cloneRecordRepositoryImpl := repository.NewCloneRecordRepositoryImpl()
-	rencanaKinerjaServiceImpl := service.NewRencanaKinerjaServiceImpl(rencanaKinerjaRepositoryImpl, db, validate, opdRepositoryImpl, usulanMusrebangRepositoryImpl, usulanMandatoriRepositoryImpl, usulanPokokPikiranRepositoryImpl, usulanInisiatifRepositoryImpl, subKegiatanRepositoryImpl, dasarHukumRepositoryImpl, gambaranUmumRepositoryImpl, inovasiRepositoryImpl, pelaksanaanRencanaAksiRepositoryImpl, pegawaiRepositoryImpl, pohonKinerjaRepositoryImpl, manualIKRepositoryImpl, permasalahanRekinRepositoryImpl, subKegiatanTerpilihRepositoryImpl, subKegiatanServiceImpl, periodeRepositoryImpl, sasaranOpdRepositoryImpl, cascadingOpdServiceImpl, cascadingOpdRepositoryImpl, programRepositoryImpl, rincianBelanjaRepositoryImpl, rencanaAksiRepositoryImpl, cloneRecordRepositoryImpl)
+	rencanaKinerjaServiceImpl := service.NewRencanaKinerjaServiceImpl(rencanaKinerjaRepositoryImpl, db, validate, opdRepositoryImpl, usulanMusrebangRepositoryImpl, usulanMandatoriRepositoryImpl, usulanPokokPikiranRepositoryImpl, usulanInisiatifRepositoryImpl, subKegiatanRepositoryImpl, dasarHukumRepositoryImpl, gambaranUmumRepositoryImpl, inovasiRepositoryImpl, pelaksanaanRencanaAksiRepositoryImpl, pegawaiRepositoryImpl, pohonKinerjaRepositoryImpl, manualIKRepositoryImpl, permasalahanRekinRepositoryImpl, subKegiatanTerpilihRepositoryImpl, subKegiatanServiceImpl, periodeRepositoryImpl, sasaranOpdRepositoryImpl, cascadingOpdServiceImpl, cascadingOpdRepositoryImpl, programRepositoryImpl, rincianBelanjaRepositoryImpl, rencanaAksiRepositoryImpl, lockDataServiceImpl, auditLogRepositoryImpl)
 	rencanaKinerjaControllerImpl := controller.NewRencanaKinerjaControllerImpl(rencanaKinerjaServiceImpl)
-	rencanaAksiServiceImpl := service.NewRencanaAksiServiceImpl(rencanaAksiRepositoryImpl, db, validate, pelaksanaanRencanaAksiRepositoryImpl)
+	rencanaKinerjaVerifikasiRepositoryImpl := repository.NewRencanaKinerjaVerifikasiRepositoryImpl()
+	rencanaAksiServiceImpl := service.NewRencanaAksiServiceImpl(rencanaAksiRepositoryImpl, db, validate, pelaksanaanRencanaAksiRepositoryImpl, rencanaKinerjaVerifikasiRepositoryImpl)
 	rencanaAksiControllerImpl := controller.NewRencanaAksiControllerImpl(rencanaAksiServiceImpl)
-	pelaksanaanRencanaAksiServiceImpl := service.NewPelaksanaanRencanaAksiServiceImpl(pelaksanaanRencanaAksiRepositoryImpl, rencanaAksiRepositoryImpl, db)
+	pelaksanaanRencanaAksiServiceImpl := service.NewPelaksanaanRencanaAksiServiceImpl(pelaksanaanRencanaAksiRepositoryImpl, rencanaAksiRepositoryImpl, rencanaKinerjaVerifikasiRepositoryImpl, db)
 	pelaksanaanRencanaAksiControllerImpl := controller.NewPelaksanaanRencanaAksiControllerImpl(pelaksanaanRencanaAksiServiceImpl)
 	usulanMusrebangServiceImpl := service.NewUsulanMusrebangServiceImpl(usulanMusrebangRepositoryImpl, rencanaKinerjaRepositoryImpl, opdRepositoryImpl, db)
 	usulanMusrebangControllerImpl := controller.NewUsulanMusrebangControllerImpl(usulanMusrebangServiceImpl)
@@ -73,19 +75,22 @@ func InitializeServer() *http.Server {
 	usulanTerpilihRepositoryImpl := repository.NewUsulanTerpilihRepositoryImpl()
 	usulanTerpilihServiceImpl := service.NewUsulanTerpilihServiceImpl(usulanTerpilihRepositoryImpl, db, validate)
 	usulanTerpilihControllerImpl := controller.NewUsulanTerpilihControllerImpl(usulanTerpilihServiceImpl)
-	gambaranUmumServiceImpl := service.NewGambaranUmumServiceImpl(gambaranUmumRepositoryImpl, db)
+	gambaranUmumServiceImpl := service.NewGambaranUmumServiceImpl(gambaranUmumRepositoryImpl, rencanaKinerjaVerifikasiRepositoryImpl, db)
 	gambaranUmumControllerImpl := controller.NewGambaranUmumControllerImpl(gambaranUmumServiceImpl)
-	dasarHukumServiceImpl := service.NewDasarHukumServiceImpl(dasarHukumRepositoryImpl, db)
+	dasarHukumServiceImpl := service.NewDasarHukumServiceImpl(dasarHukumRepositoryImpl, rencanaKinerjaVerifikasiRepositoryImpl, db)
 	dasarHukumControllerImpl := controller.NewDasarHukumControllerImpl(dasarHukumServiceImpl)
-	inovasiServiceImpl := service.NewInovasiServiceImpl(inovasiRepositoryImpl, db)
+	inovasiServiceImpl := service.NewInovasiServiceImpl(inovasiRepositoryImpl, rencanaKinerjaVerifikasiRepositoryImpl, db)
 	inovasiControllerImpl := controller.NewInovasiControllerImpl(inovasiServiceImpl)
 	subKegiatanControllerImpl := controller.NewSubKegiatanControllerImpl(subKegiatanServiceImpl)
 	subKegiatanTerpilihServiceImpl := service.NewSubKegiatanTerpilihServiceImpl(rencanaKinerjaRepositoryImpl, subKegiatanRepositoryImpl, subKegiatanTerpilihRepositoryImpl, opdRepositoryImpl, db, validate)
 	subKegiatanTerpilihControllerImpl := controller.NewSubKegiatanTerpilihControllerImpl(subKegiatanTerpilihServiceImpl)
 	crosscuttingOpdRepositoryImpl := repository.NewCrosscuttingOpdRepositoryImpl()
+	opdGuardServiceImpl := service.NewOpdGuardServiceImpl(crosscuttingOpdRepositoryImpl, db)
+	pohonKinerjaTransisiRepositoryImpl := repository.NewPohonKinerjaTransisiRepositoryImpl()
+	pokinWorkflowServiceImpl := service.NewPokinWorkflowServiceImpl(pohonKinerjaTransisiRepositoryImpl, opdGuardServiceImpl, db, validate)
 	reviewRepositoryImpl := repository.NewReviewRepositoryImpl()
 	programUnggulanRepositoryImpl := repository.NewProgramUnggulanRepositoryImpl()
-	pohonKinerjaOpdServiceImpl := service.NewPohonKinerjaOpdServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, pegawaiRepositoryImpl, tujuanOpdRepositoryImpl, crosscuttingOpdRepositoryImpl, reviewRepositoryImpl, db, validate, programUnggulanRepositoryImpl, client)
+	pohonKinerjaOpdServiceImpl := service.NewPohonKinerjaOpdServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, pegawaiRepositoryImpl, tujuanOpdRepositoryImpl, crosscuttingOpdRepositoryImpl, reviewRepositoryImpl, db, validate, programUnggulanRepositoryImpl, client, opdGuardServiceImpl, lockDataServiceImpl, pokinWorkflowServiceImpl, auditLogRepositoryImpl)
 	pohonKinerjaOpdControllerImpl := controller.NewPohonKinerjaOpdControllerImpl(pohonKinerjaOpdServiceImpl)
 	jabatanPegawaiRepositoryImpl := repository.NewJabatanPegawaiRepositoryImpl()
 	pegawaiServiceImpl := service.NewPegawaiServiceImpl(pegawaiRepositoryImpl, opdRepositoryImpl, jabatanPegawaiRepositoryImpl, db)
@@ -97,7 +102,7 @@ func InitializeServer() *http.Server {
 	jabatanServiceImpl := service.NewJabatanServiceImpl(jabatanRepositoryImpl, opdRepositoryImpl, db)
 	jabatanControllerImpl := controller.NewJabatanControllerImpl(jabatanServiceImpl)
 	csfRepository := repository.NewCSFRepositoryImpl()
-	pohonKinerjaAdminServiceImpl := service.NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, csfRepository, db, pegawaiRepositoryImpl, reviewRepositoryImpl, programUnggulanRepositoryImpl)
+	pohonKinerjaAdminServiceImpl := service.NewPohonKinerjaAdminServiceImpl(pohonKinerjaRepositoryImpl, opdRepositoryImpl, csfRepository, db, pegawaiRepositoryImpl, reviewRepositoryImpl, programUnggulanRepositoryImpl, pokinWorkflowServiceImpl, auditLogRepositoryImpl)
 	pohonKinerjaAdminControllerImpl := controller.NewPohonKinerjaAdminControllerImpl(pohonKinerjaAdminServiceImpl)
 	opdServiceImpl := service.NewOpdServiceImpl(opdRepositoryImpl, lembagaRepositoryImpl, db, validate)
 	opdControllerImpl := controller.NewOpdControllerImpl(opdServiceImpl)
@@ -113,16 +118,16 @@ func InitializeServer() *http.Server {
 	kegiatanControllerImpl := controller.NewKegiatanControllerImpl(kegiatanServiceImpl)
 	userRepositoryImpl := repository.NewUserRepositoryImpl()
 	roleRepositoryImpl := repository.NewRoleRepositoryImpl()
-	userServiceImpl := service.NewUserServiceImpl(userRepositoryImpl, roleRepositoryImpl, pegawaiRepositoryImpl, opdRepositoryImpl, db)
+	passwordResetRepositoryImpl := repository.NewPasswordResetRepositoryImpl()
+	userServiceImpl := service.NewUserServiceImpl(userRepositoryImpl, roleRepositoryImpl, pegawaiRepositoryImpl, opdRepositoryImpl, passwordResetRepositoryImpl, opdGuardServiceImpl, client, db)
 	userControllerImpl := controller.NewUserControllerImpl(userServiceImpl)
 	roleServiceImpl := service.NewRoleServiceImpl(roleRepositoryImpl, db)
 	roleControllerImpl := controller.NewRoleControllerImpl(roleServiceImpl)
-	lockDataRepositoryImpl := repository.NewLockDataRepositoryImpl()
 	tujuanOpdServiceImpl := service.NewTujuanOpdServiceImpl(tujuanOpdRepositoryImpl, opdRepositoryImpl, periodeRepositoryImpl, bidangUrusanRepositoryImpl, lockDataRepositoryImpl, db)
 	tujuanOpdControllerImpl := controller.NewTujuanOpdControllerImpl(tujuanOpdServiceImpl)
-	crosscuttingOpdServiceImpl := service.NewCrosscuttingOpdServiceImpl(crosscuttingOpdRepositoryImpl, pohonKinerjaRepositoryImpl, pegawaiRepositoryImpl, opdRepositoryImpl, db)
+	crosscuttingOpdServiceImpl := service.NewCrosscuttingOpdServiceImpl(crosscuttingOpdRepositoryImpl, pohonKinerjaRepositoryImpl, pegawaiRepositoryImpl, opdRepositoryImpl, auditLogRepositoryImpl, pokinWorkflowServiceImpl, db)
 	crosscuttingOpdControllerImpl := controller.NewCrosscuttingOpdControllerImpl(crosscuttingOpdServiceImpl)
-	manualIKServiceImpl := service.NewManualIKServiceImpl(manualIKRepositoryImpl, db, validate)
+	manualIKServiceImpl := service.NewManualIKServiceImpl(manualIKRepositoryImpl, db, validate, auditLogRepositoryImpl)
 	manualIKControllerImpl := controller.NewManualIKControllerImpl(manualIKServiceImpl)
 	reviewServiceImpl := service.NewReviewServiceImpl(reviewRepositoryImpl, db, pohonKinerjaRepositoryImpl, pegawaiRepositoryImpl)
 	reviewControllerImpl := controller.NewReviewControllerImpl(reviewServiceImpl)
@@ -136,22 +141,22 @@ func InitializeServer() *http.Server {
 	sasaranPemdaRepositoryImpl := repository.NewSasaranPemdaRepositoryImpl()
 	sasaranPemdaServiceImpl := service.NewSasaranPemdaServiceImpl(sasaranPemdaRepositoryImpl, periodeRepositoryImpl, pohonKinerjaRepositoryImpl, tujuanPemdaRepositoryImpl, db)
 	sasaranPemdaControllerImpl := controller.NewSasaranPemdaControllerImpl(sasaranPemdaServiceImpl)
-	permasalahanRekinServiceImpl := service.NewPermasalahanRekinServiceImpl(permasalahanRekinRepositoryImpl, db)
+	permasalahanRekinServiceImpl := service.NewPermasalahanRekinServiceImpl(permasalahanRekinRepositoryImpl, rencanaKinerjaVerifikasiRepositoryImpl, db)
 	permasalahanRekinControllerImpl := controller.NewPermasalahanRekinControllerImpl(permasalahanRekinServiceImpl)
 	ikuRepositoryImpl := repository.NewIkuRepositoryImpl()
 	ikuServiceImpl := service.NewIkuServiceImpl(ikuRepositoryImpl, db)
 	ikuControllerImpl := controller.NewIkuControllerImpl(ikuServiceImpl)
-	sasaranOpdServiceImpl := service.NewSasaranOpdServiceImpl(sasaranOpdRepositoryImpl, opdRepositoryImpl, rencanaKinerjaRepositoryImpl, manualIKRepositoryImpl, pegawaiRepositoryImpl, pohonKinerjaRepositoryImpl, tujuanOpdRepositoryImpl, db, validate)
+	sasaranOpdServiceImpl := service.NewSasaranOpdServiceImpl(sasaranOpdRepositoryImpl, opdRepositoryImpl, rencanaKinerjaRepositoryImpl, manualIKRepositoryImpl, pegawaiRepositoryImpl, pohonKinerjaRepositoryImpl, tujuanOpdRepositoryImpl, lockDataServiceImpl, db, validate)
 	sasaranOpdControllerImpl := controller.NewSasaranOpdControllerImpl(sasaranOpdServiceImpl)
 	visiPemdaServiceImpl := service.NewVisiPemdaServiceImpl(visiPemdaRepositoryImpl, validate, db)
 	visiPemdaControllerImpl := controller.NewVisiPemdaControllerImpl(visiPemdaServiceImpl)
 	misiPemdaServiceImpl := service.NewMisiPemdaServiceImpl(misiPemdaRepositoryImpl, visiPemdaRepositoryImpl, validate, db)
 	misiPemdaControllerImpl := controller.NewMisiPemdaControllerImpl(misiPemdaServiceImpl)
 	matrixRenstraRepositoryImpl := repository.NewMatrixRenstraRepositoryImpl()
-	matrixRenstraServiceImpl := service.NewMatrixRenstraServiceImpl(matrixRenstraRepositoryImpl, periodeRepositoryImpl, pegawaiRepositoryImpl, db)
+	matrixRenstraServiceImpl := service.NewMatrixRenstraServiceImpl(matrixRenstraRepositoryImpl, periodeRepositoryImpl, pegawaiRepositoryImpl, opdGuardServiceImpl, lockDataServiceImpl, auditLogRepositoryImpl, db)
 	matrixRenstraControllerImpl := controller.NewMatrixRenstraControllerImpl(matrixRenstraServiceImpl)
 	cascadingOpdControllerImpl := controller.NewCascadingOpdControllerImpl(cascadingOpdServiceImpl)
-	rincianBelanjaServiceImpl := service.NewRincianBelanjaServiceImpl(rincianBelanjaRepositoryImpl, pegawaiRepositoryImpl, db)
+	rincianBelanjaServiceImpl := service.NewRincianBelanjaServiceImpl(rincianBelanjaRepositoryImpl, pegawaiRepositoryImpl, rencanaAksiRepositoryImpl, rencanaKinerjaRepositoryImpl, opdGuardServiceImpl, lockDataServiceImpl, auditLogRepositoryImpl, db)
 	rincianBelanjaControllerImpl := controller.NewRincianBelanjaControllerImpl(rincianBelanjaServiceImpl)
 	kelompokAnggaranRepositoryImpl := repository.NewKelompokAnggaranRepositoryImpl()
 	kelompokAnggaranServiceImpl := service.NewKelompokAnggaranServiceImpl(kelompokAnggaranRepositoryImpl, db, validate)
@@ -161,22 +166,45 @@ func InitializeServer() *http.Server {
 	programUnggulanServiceImpl := service.NewProgramUnggulanServiceImpl(programUnggulanRepositoryImpl, db, validate)
 	programUnggulanControllerImpl := controller.NewProgramUnggulanControllerImpl(programUnggulanServiceImpl)
 	matrixRenjaRepositoryImpl := repository.NewMatrixRenjaRepositoryImpl()
-	matrixRenjaServiceImpl := service.NewMatrixRenjaServiceImpl(matrixRenjaRepositoryImpl, periodeRepositoryImpl, pegawaiRepositoryImpl, db)
+	matrixRenjaServiceImpl := service.NewMatrixRenjaServiceImpl(matrixRenjaRepositoryImpl, periodeRepositoryImpl, pegawaiRepositoryImpl, opdGuardServiceImpl, lockDataServiceImpl, auditLogRepositoryImpl, db)
 	matrixRenjaControllerImpl := controller.NewMatrixRenjaControllerImpl(matrixRenjaServiceImpl)
 	pkRepositoryImpl := repository.NewPkRepositoryImpl()
 	strukturOrganisasiRepositoryImpl := repository.NewStrukturOrganisasiRepositoryImpl()
-	pkServiceImpl := service.NewPkServiceImpl(pkRepositoryImpl, pegawaiServiceImpl, rencanaKinerjaServiceImpl, opdServiceImpl, strukturOrganisasiRepositoryImpl, validate, db)
+	pkServiceImpl := service.NewPkServiceImpl(pkRepositoryImpl, pegawaiServiceImpl, rencanaKinerjaServiceImpl, opdServiceImpl, strukturOrganisasiRepositoryImpl, opdGuardServiceImpl, validate, db)
 	pkControllerImpl := controller.NewPkControllerImpl(pkServiceImpl)
-	router := app.NewRouter(rencanaKinerjaControllerImpl, rencanaAksiControllerImpl, pelaksanaanRencanaAksiControllerImpl, usulanMusrebangControllerImpl, usulanMandatoriControllerImpl, usulanPokokPikiranControllerImpl, usulanInisiatifControllerImpl, usulanTerpilihControllerImpl, gambaranUmumControllerImpl, dasarHukumControllerImpl, inovasiControllerImpl, subKegiatanControllerImpl, subKegiatanTerpilihControllerImpl, pohonKinerjaOpdControllerImpl, pegawaiControllerImpl, lembagaControllerImpl, jabatanControllerImpl, pohonKinerjaAdminControllerImpl, opdControllerImpl, programControllerImpl, urusanControllerImpl, bidangUrusanControllerImpl, kegiatanControllerImpl, userControllerImpl, roleControllerImpl, tujuanOpdControllerImpl, crosscuttingOpdControllerImpl, manualIKControllerImpl, reviewControllerImpl, periodeControllerImpl, tujuanPemdaControllerImpl, sasaranPemdaControllerImpl, permasalahanRekinControllerImpl, ikuControllerImpl, sasaranOpdControllerImpl, visiPemdaControllerImpl, misiPemdaControllerImpl, matrixRenstraControllerImpl, cascadingOpdControllerImpl, rincianBelanjaControllerImpl, kelompokAnggaranControllerImpl, csfController, programUnggulanControllerImpl, matrixRenjaControllerImpl, pkControllerImpl)
-	authMiddleware := middleware.NewAuthMiddleware(router)
+	lockDataControllerImpl := controller.NewLockDataControllerImpl(lockDataServiceImpl)
+	auditLogServiceImpl := service.NewAuditLogServiceImpl(auditLogRepositoryImpl, opdGuardServiceImpl, db)
+	auditLogControllerImpl := controller.NewAuditLogControllerImpl(auditLogServiceImpl)
+	realisasiRepositoryImpl := repository.NewRealisasiRepositoryImpl()
+	realisasiServiceImpl := service.NewRealisasiServiceImpl(realisasiRepositoryImpl, ikuRepositoryImpl, periodeRepositoryImpl, opdGuardServiceImpl, lockDataServiceImpl, db, validate)
+	realisasiControllerImpl := controller.NewRealisasiControllerImpl(realisasiServiceImpl)
+	progresPelaksanaanRepositoryImpl := repository.NewProgresPelaksanaanRepositoryImpl()
+	progresPelaksanaanServiceImpl := service.NewProgresPelaksanaanServiceImpl(progresPelaksanaanRepositoryImpl, pelaksanaanRencanaAksiRepositoryImpl, rencanaKinerjaRepositoryImpl, opdGuardServiceImpl, lockDataServiceImpl, db, validate)
+	progresPelaksanaanControllerImpl := controller.NewProgresPelaksanaanControllerImpl(progresPelaksanaanServiceImpl)
+	pokinWorkflowControllerImpl := controller.NewPokinWorkflowControllerImpl(pokinWorkflowServiceImpl)
+	laporanServiceImpl := service.NewLaporanServiceImpl(opdRepositoryImpl, periodeRepositoryImpl, programRepositoryImpl, tujuanOpdServiceImpl, sasaranOpdServiceImpl, ikuServiceImpl, pkServiceImpl, rincianBelanjaServiceImpl, realisasiServiceImpl, rencanaKinerjaRepositoryImpl, rencanaKinerjaServiceImpl, subKegiatanServiceImpl, opdGuardServiceImpl, db)
+	laporanControllerImpl := controller.NewLaporanControllerImpl(laporanServiceImpl)
+	rencanaKinerjaVerifikasiServiceImpl := service.NewRencanaKinerjaVerifikasiServiceImpl(rencanaKinerjaVerifikasiRepositoryImpl, strukturOrganisasiRepositoryImpl, opdGuardServiceImpl, db, validate)
+	rencanaKinerjaVerifikasiControllerImpl := controller.NewRencanaKinerjaVerifikasiControllerImpl(rencanaKinerjaVerifikasiServiceImpl)
+	cloneJobServiceImpl := service.NewCloneJobServiceImpl(cloneRecordRepositoryImpl, pohonKinerjaRepositoryImpl, rencanaKinerjaRepositoryImpl, manualIKRepositoryImpl, rencanaAksiRepositoryImpl, dasarHukumRepositoryImpl, permasalahanRekinRepositoryImpl, gambaranUmumRepositoryImpl, inovasiRepositoryImpl, lockDataServiceImpl, opdGuardServiceImpl, auditLogRepositoryImpl, db, validate)
+	cloneJobControllerImpl := controller.NewCloneJobControllerImpl(cloneJobServiceImpl)
+	usulanPipelineRepositoryImpl := repository.NewUsulanPipelineRepositoryImpl()
+	usulanPipelineServiceImpl := service.NewUsulanPipelineServiceImpl(usulanPipelineRepositoryImpl, opdGuardServiceImpl, db, validate)
+	usulanPipelineControllerImpl := controller.NewUsulanPipelineControllerImpl(usulanPipelineServiceImpl)
+	usulanPublikServiceImpl := service.NewUsulanPublikServiceImpl(usulanPipelineRepositoryImpl, db)
+	usulanPublikControllerImpl := controller.NewUsulanPublikControllerImpl(usulanPublikServiceImpl)
+	geospasialRepositoryImpl := repository.NewGeospasialRepositoryImpl()
+	geospasialServiceImpl := service.NewGeospasialServiceImpl(geospasialRepositoryImpl, opdGuardServiceImpl, db, validate)
+	geospasialControllerImpl := controller.NewGeospasialControllerImpl(geospasialServiceImpl)
+	nomenklaturRepositoryImpl := repository.NewNomenklaturRepositoryImpl()
+	nomenklaturServiceImpl := service.NewNomenklaturServiceImpl(nomenklaturRepositoryImpl, db)
+	nomenklaturControllerImpl := controller.NewNomenklaturControllerImpl(nomenklaturServiceImpl)
+	router := app.NewRouter(rencanaKinerjaControllerImpl, rencanaAksiControllerImpl, pelaksanaanRencanaAksiControllerImpl, usulanMusrebangControllerImpl, usulanMandatoriControllerImpl, usulanPokokPikiranControllerImpl, usulanInisiatifControllerImpl, usulanTerpilihControllerImpl, gambaranUmumControllerImpl, dasarHukumControllerImpl, inovasiControllerImpl, subKegiatanControllerImpl, subKegiatanTerpilihControllerImpl, pohonKinerjaOpdControllerImpl, pegawaiControllerImpl, lembagaControllerImpl, jabatanControllerImpl, pohonKinerjaAdminControllerImpl, opdControllerImpl, programControllerImpl, urusanControllerImpl, bidangUrusanControllerImpl, kegiatanControllerImpl, userControllerImpl, roleControllerImpl, tujuanOpdControllerImpl, crosscuttingOpdControllerImpl, manualIKControllerImpl, reviewControllerImpl, periodeControllerImpl, tujuanPemdaControllerImpl, sasaranPemdaControllerImpl, permasalahanRekinControllerImpl, ikuControllerImpl, sasaranOpdControllerImpl, visiPemdaControllerImpl, misiPemdaControllerImpl, matrixRenstraControllerImpl, cascadingOpdControllerImpl, rincianBelanjaControllerImpl, kelompokAnggaranControllerImpl, csfController, programUnggulanControllerImpl, matrixRenjaControllerImpl, pkControllerImpl, lockDataControllerImpl, auditLogControllerImpl, realisasiControllerImpl, progresPelaksanaanControllerImpl, laporanControllerImpl, pokinWorkflowControllerImpl, rencanaKinerjaVerifikasiControllerImpl, cloneJobControllerImpl, usulanPipelineControllerImpl, usulanPublikControllerImpl, geospasialControllerImpl, nomenklaturControllerImpl, client)
+	authMiddleware := middleware.NewAuthMiddleware(router, client)
 	server := NewServer(authMiddleware)
 	return server
 }
 
-var (
-	_wireValue = []validator.Option{}
-)
-
 func InitializeSeeder() dataseeder.Seeder {
 	db := app.GetConnection()
 	roleRepositoryImpl := repository.NewRoleRepositoryImpl()
@@ -239,7 +267,7 @@ var kegiatanSet = wire.NewSet(repository.NewKegiatanRepositoryImpl, wire.Bind(ne
 
 var roleSet = wire.NewSet(repository.NewRoleRepositoryImpl, wire.Bind(new(repository.RoleRepository), new(*repository.RoleRepositoryImpl)), service.NewRoleServiceImpl, wire.Bind(new(service.RoleService), new(*service.RoleServiceImpl)), controller.NewRoleControllerImpl, wire.Bind(new(controller.RoleController), new(*controller.RoleControllerImpl)))
 
-var userSet = wire.NewSet(repository.NewUserRepositoryImpl, wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)), service.NewUserServiceImpl, wire.Bind(new(service.UserService), new(*service.UserServiceImpl)), controller.NewUserControllerImpl, wire.Bind(new(controller.UserController), new(*controller.UserControllerImpl)))
+var userSet = wire.NewSet(repository.NewUserRepositoryImpl, wire.Bind(new(repository.UserRepository), new(*repository.UserRepositoryImpl)), repository.NewPasswordResetRepositoryImpl, wire.Bind(new(repository.PasswordResetRepository), new(*repository.PasswordResetRepositoryImpl)), service.NewUserServiceImpl, wire.Bind(new(service.UserService), new(*service.UserServiceImpl)), controller.NewUserControllerImpl, wire.Bind(new(controller.UserController), new(*controller.UserControllerImpl)))
 
 var seederProviderSet = wire.NewSet(dataseeder.NewSeederImpl, wire.Bind(new(dataseeder.Seeder), new(*dataseeder.SeederImpl)), dataseeder.NewRoleSeederImpl, wire.Bind(new(dataseeder.RoleSeeder), new(*dataseeder.RoleSeederImpl)), dataseeder.NewUserSeederImpl, wire.Bind(new(dataseeder.UserSeeder), new(*dataseeder.UserSeederImpl)), dataseeder.NewPegawaiSeederImpl, wire.Bind(new(dataseeder.PegawaiSeeder), new(*dataseeder.PegawaiSeederImpl)))
 
@@ -290,3 +318,27 @@ var jabatanPegawaiSet = wire.NewSet(repository.NewJabatanPegawaiRepositoryImpl,
 var cloneRecordSet = wire.NewSet(repository.NewCloneRecordRepositoryImpl, wire.Bind(new(repository.CloneRecordRepository), new(*repository.CloneRecordRepositoryImpl)))
 
 var lockDataRepository = wire.NewSet(repository.NewLockDataRepositoryImpl, wire.Bind(new(repository.LockDataRepository), new(*repository.LockDataRepositoryImpl)))
+
+var opdGuardSet = wire.NewSet(service.NewOpdGuardServiceImpl, wire.Bind(new(service.OpdGuardService), new(*service.OpdGuardServiceImpl)))
+
+var auditLogSet = wire.NewSet(repository.NewAuditLogRepositoryImpl, wire.Bind(new(repository.AuditLogRepository), new(*repository.AuditLogRepositoryImpl)), service.NewAuditLogServiceImpl, wire.Bind(new(service.AuditLogService), new(*service.AuditLogServiceImpl)), controller.NewAuditLogControllerImpl, wire.Bind(new(controller.AuditLogController), new(*controller.AuditLogControllerImpl)))
+
+var realisasiSet = wire.NewSet(repository.NewRealisasiRepositoryImpl, wire.Bind(new(repository.RealisasiRepository), new(*repository.RealisasiRepositoryImpl)), service.NewRealisasiServiceImpl, wire.Bind(new(service.RealisasiService), new(*service.RealisasiServiceImpl)), controller.NewRealisasiControllerImpl, wire.Bind(new(controller.RealisasiController), new(*controller.RealisasiControllerImpl)))
+
+var progresPelaksanaanSet = wire.NewSet(repository.NewProgresPelaksanaanRepositoryImpl, wire.Bind(new(repository.ProgresPelaksanaanRepository), new(*repository.ProgresPelaksanaanRepositoryImpl)), service.NewProgresPelaksanaanServiceImpl, wire.Bind(new(service.ProgresPelaksanaanService), new(*service.ProgresPelaksanaanServiceImpl)), controller.NewProgresPelaksanaanControllerImpl, wire.Bind(new(controller.ProgresPelaksanaanController), new(*controller.ProgresPelaksanaanControllerImpl)))
+
+var laporanSet = wire.NewSet(service.NewLaporanServiceImpl, wire.Bind(new(service.LaporanService), new(*service.LaporanServiceImpl)), controller.NewLaporanControllerImpl, wire.Bind(new(controller.LaporanController), new(*controller.LaporanControllerImpl)))
+
+var pokinWorkflowSet = wire.NewSet(repository.NewPohonKinerjaTransisiRepositoryImpl, wire.Bind(new(repository.PohonKinerjaTransisiRepository), new(*repository.PohonKinerjaTransisiRepositoryImpl)), service.NewPokinWorkflowServiceImpl, wire.Bind(new(service.PokinWorkflowService), new(*service.PokinWorkflowServiceImpl)), controller.NewPokinWorkflowControllerImpl, wire.Bind(new(controller.PokinWorkflowController), new(*controller.PokinWorkflowControllerImpl)))
+
+var rencanaKinerjaVerifikasiSet = wire.NewSet(repository.NewRencanaKinerjaVerifikasiRepositoryImpl, wire.Bind(new(repository.RencanaKinerjaVerifikasiRepository), new(*repository.RencanaKinerjaVerifikasiRepositoryImpl)), service.NewRencanaKinerjaVerifikasiServiceImpl, wire.Bind(new(service.RencanaKinerjaVerifikasiService), new(*service.RencanaKinerjaVerifikasiServiceImpl)), controller.NewRencanaKinerjaVerifikasiControllerImpl, wire.Bind(new(controller.RencanaKinerjaVerifikasiController), new(*controller.RencanaKinerjaVerifikasiControllerImpl)))
+
+var cloneJobSet = wire.NewSet(service.NewCloneJobServiceImpl, wire.Bind(new(service.CloneJobService), new(*service.CloneJobServiceImpl)), controller.NewCloneJobControllerImpl, wire.Bind(new(controller.CloneJobController), new(*controller.CloneJobControllerImpl)))
+
+var usulanPipelineSet = wire.NewSet(repository.NewUsulanPipelineRepositoryImpl, wire.Bind(new(repository.UsulanPipelineRepository), new(*repository.UsulanPipelineRepositoryImpl)), service.NewUsulanPipelineServiceImpl, wire.Bind(new(service.UsulanPipelineService), new(*service.UsulanPipelineServiceImpl)), controller.NewUsulanPipelineControllerImpl, wire.Bind(new(controller.UsulanPipelineController), new(*controller.UsulanPipelineControllerImpl)), service.NewUsulanPublikServiceImpl, wire.Bind(new(service.UsulanPublikService), new(*service.UsulanPublikServiceImpl)), controller.NewUsulanPublikControllerImpl, wire.Bind(new(controller.UsulanPublikController), new(*controller.UsulanPublikControllerImpl)))
+
+var geospasialSet = wire.NewSet(repository.NewGeospasialRepositoryImpl, wire.Bind(new(repository.GeospasialRepository), new(*repository.GeospasialRepositoryImpl)), service.NewGeospasialServiceImpl, wire.Bind(new(service.GeospasialService), new(*service.GeospasialServiceImpl)), controller.NewGeospasialControllerImpl, wire.Bind(new(controller.GeospasialController), new(*controller.GeospasialControllerImpl)))
+
+var nomenklaturSet = wire.NewSet(repository.NewNomenklaturRepositoryImpl, wire.Bind(new(repository.NomenklaturRepository), new(*repository.NomenklaturRepositoryImpl)), service.NewNomenklaturServiceImpl, wire.Bind(new(service.NomenklaturService), new(*service.NomenklaturServiceImpl)), controller.NewNomenklaturControllerImpl, wire.Bind(new(controller.NomenklaturController), new(*controller.NomenklaturControllerImpl)))
+
+var lockDataSet = wire.NewSet(service.NewLockDataServiceImpl, wire.Bind(new(service.LockDataService), new(*service.LockDataServiceImpl)), controller.NewLockDataControllerImpl, wire.Bind(new(controller.LockDataController), new(*controller.LockDataControllerImpl)))